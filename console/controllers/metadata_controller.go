@@ -341,8 +341,14 @@ func (ctrl *EditTableAction) Execute(c *gin.Context) (interface{}, error) {
 		return nil, common.PARAM_FORMAT_ERROR
 	}
 	log.Debug("regxsJsonArray:%v", regxsJsonArray)
+	// 副本放置规则, 空表示不修改
+	placement := c.PostForm("placement")
+	if placement != "" && !json.Valid([]byte(placement)) {
+		log.Error("parse placement failed. placement:[%v]", placement)
+		return nil, common.PARAM_FORMAT_ERROR
+	}
 
-	return nil, service.NewService().EditTable(cId, dbName, tableName, rangeKeys, &columnJsonArray, &regxsJsonArray)
+	return nil, service.NewService().EditTable(cId, dbName, tableName, rangeKeys, placement, &columnJsonArray, &regxsJsonArray)
 }
 
 type RangeViewInfo struct {
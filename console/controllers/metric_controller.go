@@ -17,6 +17,8 @@ const (
 
 	REQURL_METRIC_CONFIG_SET = "/metric/config/set"
 	REQURL_METRIC_CONFIG_GET = "/metric/config/get"
+
+	REQURL_METRIC_STATEMENT_TOPN = "/metric/statement/topN"
 )
 
 /**
@@ -131,3 +133,33 @@ func (gmc *GetMetricConfigAction) Execute(c *gin.Context) (interface{}, error) {
 	log.Debug("get cluster %v metric server:", cId)
 	return service.NewService().GetMetricConfig(cId)
 }
+
+/**
+ * 查询集群网关按sql指纹聚合的语句topN
+ */
+type StatementTopNAction struct {
+}
+
+func NewStatementTopNAction() *StatementTopNAction {
+	return &StatementTopNAction{
+	}
+}
+func (stc *StatementTopNAction) Execute(c *gin.Context) (interface{}, error) {
+	cIdStr := c.Query("clusterId")
+	topNStr := c.Query("topN")
+	if cIdStr == "" || topNStr == "" {
+		return nil, common.PARSE_PARAM_ERROR
+	}
+	cId, err := strconv.Atoi(cIdStr)
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	topN, err := strconv.Atoi(topNStr)
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	// latency, count, errors, examined, last_seen
+	order := c.Query("order")
+	log.Debug("query cluster %v statement topN: %v, order by %v", cId, topN, order)
+	return service.NewService().GetStatementTopN(cId, topN, order)
+}
@@ -24,6 +24,8 @@ const (
 	NODE_CLEAR_QUEUE = "/node/clearQueueOfNode"
 	NODE_GET_PENDING_QUEUES = "/node/getPendingQueuesOfNode"
 	NODE_FLUSH_DB = "/node/flushDBOfNode"
+	NODE_DRAIN = "/node/drainNode"
+	NODE_GET_DRAIN_PROGRESS = "/node/getDrainProgressOfNode"
 )
 
 type NodeViewInfo struct {
@@ -370,4 +372,63 @@ func (ctrl *NodeFlushDB) Execute(c *gin.Context) (interface{}, error) {
 	log.Debug("flush db of node. clusterId:[%v], nodeId:[%v], wait:[%t]", clusterId, nodeId, waitBool)
 
 	return service.NewService().FlushDBOfNode(clusterId, nodeId, waitBool)
-}
\ No newline at end of file
+}
+
+type NodeDrain struct {
+}
+
+func NewNodeDrain() *NodeDrain {
+	return &NodeDrain{}
+}
+
+func (ctrl *NodeDrain) Execute(c *gin.Context) (interface{}, error) {
+	cIdStr := c.PostForm("clusterId")
+	nIdStr := c.PostForm("nodeId")
+	if cIdStr == "" || nIdStr == "" {
+		return nil, common.PARSE_PARAM_ERROR
+	}
+	clusterId, err := strconv.Atoi(cIdStr)
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	nodeId, err := strconv.Atoi(nIdStr)
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	cancel, err := strconv.ParseBool(c.PostForm("cancel"))
+	if err != nil {
+		cancel = false
+	}
+
+	log.Debug("drain node. clusterId:[%v], nodeId:[%v], cancel:[%v]", clusterId, nodeId, cancel)
+	if err := service.NewService().DrainNode(clusterId, nodeId, cancel); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+type NodeGetDrainProgress struct {
+}
+
+func NewNodeGetDrainProgress() *NodeGetDrainProgress {
+	return &NodeGetDrainProgress{}
+}
+
+func (ctrl *NodeGetDrainProgress) Execute(c *gin.Context) (interface{}, error) {
+	cIdStr := c.PostForm("clusterId")
+	nIdStr := c.PostForm("nodeId")
+	if cIdStr == "" || nIdStr == "" {
+		return nil, common.PARSE_PARAM_ERROR
+	}
+	clusterId, err := strconv.Atoi(cIdStr)
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	nodeId, err := strconv.Atoi(nIdStr)
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+
+	log.Debug("get drain progress of node. clusterId:[%v], nodeId:[%v]", clusterId, nodeId)
+	return service.NewService().GetDrainProgressOfNode(clusterId, nodeId)
+}
@@ -32,6 +32,7 @@ const (
 
 	TASK_GET_PRESENT = "/task/getPresentTaskById"
 	TASK_OPERATION   = "/task/taskOperationById"
+	TASK_GET_HISTORY = "/task/getHistory"
 )
 
 type PeerDelete struct {
@@ -251,6 +252,34 @@ func (t *TaskOperation) Execute(c *gin.Context) (interface{}, error) {
 	return resp, nil
 }
 
+type TaskHistory struct {
+}
+
+func NewTaskHistory() *TaskHistory {
+	return &TaskHistory{}
+}
+
+func (t *TaskHistory) Execute(c *gin.Context) (interface{}, error) {
+	clusterId := c.Query("clusterId")
+	if "" == clusterId {
+		return nil, common.PARSE_PARAM_ERROR
+	}
+	filters := make(map[string]string)
+	for _, key := range []string{"rangeId", "nodeId", "tableId", "startTime", "endTime", "limit"} {
+		if v := c.Query(key); len(v) > 0 {
+			filters[key] = v
+		}
+	}
+
+	log.Debug("get task history: clusterId: %v, filters: %v", clusterId, filters)
+	clusterId_, _ := strconv.ParseUint(clusterId, 10, 64)
+	resp, err := service.NewService().GetTaskHistory(int(clusterId_), filters)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 type RangeUpdate struct {
 }
 
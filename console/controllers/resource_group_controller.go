@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"console/common"
+	"console/service"
+	"util/log"
+)
+
+const (
+	REQURL_RESOURCE_GROUP_GETALL = "/resourceGroup/getAll"
+	REQURL_RESOURCE_GROUP_SET    = "/resourceGroup/set"
+	REQURL_RESOURCE_GROUP_DEL    = "/resourceGroup/del"
+)
+
+/**
+ * 查询集群的资源组
+ */
+type GetResourceGroupAction struct {
+}
+
+func NewGetResourceGroupAction() *GetResourceGroupAction {
+	return &GetResourceGroupAction{}
+}
+func (ctrl *GetResourceGroupAction) Execute(c *gin.Context) (interface{}, error) {
+	cId, err := strconv.Atoi(c.PostForm("clusterId"))
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	log.Debug("get cluster %v resource groups", cId)
+	return service.NewService().GetResourceGroups(cId)
+}
+
+/**
+ * 添加或者修改资源组, 限制为每秒的值, 0表示不限制
+ */
+type SetResourceGroupAction struct {
+}
+
+func NewSetResourceGroupAction() *SetResourceGroupAction {
+	return &SetResourceGroupAction{}
+}
+func (ctrl *SetResourceGroupAction) Execute(c *gin.Context) (interface{}, error) {
+	cId, err := strconv.Atoi(c.PostForm("clusterId"))
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	name := c.PostForm("name")
+	kind := c.PostForm("kind")
+	key := c.PostForm("key")
+	if name == "" || kind == "" || key == "" {
+		return nil, common.PARSE_PARAM_ERROR
+	}
+	var limits [3]uint64
+	for i, param := range []string{"qps", "readRows", "writeBytes"} {
+		value := c.PostForm(param)
+		if value == "" {
+			continue
+		}
+		if limits[i], err = strconv.ParseUint(value, 10, 64); err != nil {
+			return nil, common.PARAM_FORMAT_ERROR
+		}
+	}
+	log.Debug("set cluster %v resource group %v %v=%v, limits %v", cId, name, kind, key, limits)
+	return service.NewService().SetResourceGroup(cId, name, kind, key, limits[0], limits[1], limits[2])
+}
+
+/**
+ * 删除资源组
+ */
+type DelResourceGroupAction struct {
+}
+
+func NewDelResourceGroupAction() *DelResourceGroupAction {
+	return &DelResourceGroupAction{}
+}
+func (ctrl *DelResourceGroupAction) Execute(c *gin.Context) (interface{}, error) {
+	cId, err := strconv.Atoi(c.PostForm("clusterId"))
+	if err != nil {
+		return nil, common.PARAM_FORMAT_ERROR
+	}
+	name := c.PostForm("name")
+	if name == "" {
+		return nil, common.PARSE_PARAM_ERROR
+	}
+	log.Debug("delete cluster %v resource group %v", cId, name)
+	return service.NewService().DelResourceGroup(cId, name)
+}
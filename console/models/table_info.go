@@ -25,6 +25,7 @@ type TableInfo struct {
 	Epoch      Epoch        `json:"epoch"`
 	CreateTime int64        `json:"create_time"`
 	Status     int          `json:"status"`
+	Properties string       `json:"properties"`
 }
 
 type Peer struct {
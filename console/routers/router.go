@@ -580,6 +580,25 @@ func (r *Router) StartRouter() *gin.Engine {
 			})
 		})
 
+		group.GET("/page/cluster/viewStatementTopN", func(c *gin.Context) {
+			cid := c.Query("clusterId")
+			if cid == "" {
+				html404(c)
+				return
+			}
+			topN := c.Query("topN")
+			if topN == "" {
+				html404(c)
+				return
+			}
+
+			c.HTML(http.StatusOK, "statement_topn.html", gin.H{
+				"basePath":  r.staticRootDir,
+				"clusterId": cid,
+				"topN":      topN,
+			})
+		})
+
 		group.GET("/page/range/unhealthy", func(c *gin.Context) {
 			cid := c.Query("clusterId")
 			if cid == "" {
@@ -630,6 +649,19 @@ func (r *Router) StartRouter() *gin.Engine {
 			})
 		})
 
+		group.GET("/page/task/history", func(c *gin.Context) {
+			cid := c.Query("clusterId")
+			if cid == "" {
+				html404(c)
+				return
+			}
+			c.HTML(http.StatusOK, "task_history.html", gin.H{
+				"basePath":  r.staticRootDir,
+				"clusterId": cid,
+				"rangeId":   c.Query("rangeId"),
+			})
+		})
+
 		group.GET("/page/range/peerinfo", func(c *gin.Context) {
 			cid := c.Query("clusterId")
 			if cid == "" {
@@ -838,6 +870,12 @@ func (r *Router) StartRouter() *gin.Engine {
 		router.POST(controllers.NODE_FLUSH_DB, func(c *gin.Context) {
 			handleAction(c, controllers.NewNodeFlushDB())
 		})
+		router.POST(controllers.NODE_DRAIN, func(c *gin.Context) {
+			handleAction(c, controllers.NewNodeDrain())
+		})
+		router.POST(controllers.NODE_GET_DRAIN_PROGRESS, func(c *gin.Context) {
+			handleAction(c, controllers.NewNodeGetDrainProgress())
+		})
 		router.POST(controllers.RANGE_PEERDEL, func(c *gin.Context) {
 			handleAction(c, controllers.NewPeerDelete())
 		})
@@ -898,6 +936,9 @@ func (r *Router) StartRouter() *gin.Engine {
 		router.POST(controllers.TASK_OPERATION, func(c *gin.Context) {
 			handleAction(c, controllers.NewTaskOperation())
 		})
+		router.GET(controllers.TASK_GET_HISTORY, func(c *gin.Context) {
+			handleAction(c, controllers.NewTaskHistory())
+		})
 		router.GET(controllers.REQURI_SCHEDULER_GETALL, func(c *gin.Context) {
 			handleAction(c, controllers.NewSchedulerAllAction())
 		})
@@ -1044,6 +1085,20 @@ func (r *Router) StartRouter() *gin.Engine {
 	router.POST(controllers.REQURL_METRIC_CONFIG_SET, func(c *gin.Context) {
 		handleAction(c, controllers.NewSetMetricConfigAction())
 	})
+	router.GET(controllers.REQURL_METRIC_STATEMENT_TOPN, func(c *gin.Context) {
+		handleAction(c, controllers.NewStatementTopNAction())
+	})
+
+	//resource group
+	router.POST(controllers.REQURL_RESOURCE_GROUP_GETALL, func(c *gin.Context) {
+		handleAction(c, controllers.NewGetResourceGroupAction())
+	})
+	router.POST(controllers.REQURL_RESOURCE_GROUP_SET, func(c *gin.Context) {
+		handleAction(c, controllers.NewSetResourceGroupAction())
+	})
+	router.POST(controllers.REQURL_RESOURCE_GROUP_DEL, func(c *gin.Context) {
+		handleAction(c, controllers.NewDelResourceGroupAction())
+	})
 
 	//sql ca
 	router.GET(controllers.REQURI_SQL_CA_GETALL, func(c *gin.Context) {
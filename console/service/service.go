@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 	"strings"
 	"io/ioutil"
@@ -408,7 +409,7 @@ func (s *Service) DeleteTable(cId int, dbName, tableName, flag string) error {
 	return nil
 }
 
-func (s *Service) EditTable(cId int, dbName, tableName, rangeKeys string, columnJsonArray, regxsJsonArray interface{}) error {
+func (s *Service) EditTable(cId int, dbName, tableName, rangeKeys, placement string, columnJsonArray, regxsJsonArray interface{}) error {
 	info, err := s.selectClusterById(cId)
 	if err != nil {
 		return err
@@ -427,11 +428,15 @@ func (s *Service) EditTable(cId int, dbName, tableName, rangeKeys string, column
 	reqParams["rangeKeys"] = rangeKeys
 	reqParams["tableName"] = tableName
 	var propJson = struct {
-		Columns interface{} `json:"columns"`
-		Regxs   interface{} `json:"regxs"`
+		Columns   interface{}     `json:"columns"`
+		Regxs     interface{}     `json:"regxs"`
+		Placement json.RawMessage `json:"placement,omitempty"`
 	}{}
 	propJson.Columns = columnJsonArray
 	propJson.Regxs = regxsJsonArray
+	if placement != "" {
+		propJson.Placement = json.RawMessage(placement)
+	}
 	p, _ := json.Marshal(propJson)
 	r, _ := json.Marshal(string(p))
 
@@ -662,6 +667,71 @@ func (s *Service) SetNodeLogOut(clusterId, nodeId int) error {
 	return nil
 }
 
+// DrainNode 计划下线节点, cancel时取消下线
+func (s *Service) DrainNode(clusterId, nodeId int, cancel bool) error {
+	info, err := s.selectClusterById(clusterId)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return common.CLUSTER_NOTEXISTS_ERROR
+	}
+
+	ts := time.Now().Unix()
+	sign := common.CalcMsReqSign(clusterId, info.ClusterToken, ts)
+
+	reqParams := make(map[string]interface{})
+	reqParams["d"] = ts
+	reqParams["s"] = sign
+	reqParams["nodeId"] = nodeId
+	reqParams["cancel"] = cancel
+
+	var nodeDrainResp = struct {
+		Code int    `json:"code"`
+		Msg  string `json:"message"`
+	}{}
+	if err := sendGetReq(info.MasterUrl, "/manage/node/drain", reqParams, &nodeDrainResp); err != nil {
+		return err
+	}
+	if nodeDrainResp.Code != 0 {
+		log.Error("drain node failed. err:[%v]", nodeDrainResp)
+		return &common.FbaseError{Code: common.INTERNAL_ERROR.Code, Msg: nodeDrainResp.Msg}
+	}
+	return nil
+}
+
+func (s *Service) GetDrainProgressOfNode(clusterId, nodeId int) (interface{}, error) {
+	info, err := s.selectClusterById(clusterId)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, common.CLUSTER_NOTEXISTS_ERROR
+	}
+
+	ts := time.Now().Unix()
+	sign := common.CalcMsReqSign(clusterId, info.ClusterToken, ts)
+
+	reqParams := make(map[string]interface{})
+	reqParams["d"] = ts
+	reqParams["s"] = sign
+	reqParams["nodeId"] = nodeId
+
+	var drainProgressResp = struct {
+		Code int         `json:"code"`
+		Msg  string      `json:"message"`
+		Data interface{} `json:"data"`
+	}{}
+	if err := sendGetReq(info.MasterUrl, "/manage/node/drain/progress", reqParams, &drainProgressResp); err != nil {
+		return nil, err
+	}
+	if drainProgressResp.Code != 0 {
+		log.Error("get drain progress of node[nodeId=%d, clusterId=%d] failed. err:[%v]", nodeId, clusterId, drainProgressResp)
+		return nil, &common.FbaseError{Code: common.INTERNAL_ERROR.Code, Msg: drainProgressResp.Msg}
+	}
+	return drainProgressResp.Data, nil
+}
+
 func (s *Service) SetNodeUpgrade(clusterId, nodeId int) error {
 	info, err := s.selectClusterById(clusterId)
 	if err != nil {
@@ -827,6 +897,44 @@ func (s *Service) GetPresentTask(clusterId int) (interface{}, error) {
 	return resp.Data, nil
 }
 
+// GetTaskHistory 查询已结束的调度任务和人工操作, filters为master的过滤参数
+func (s *Service) GetTaskHistory(clusterId int, filters map[string]string) (interface{}, error) {
+	if s == nil {
+		return nil, errors.New("service is nil")
+	}
+	info, err := s.selectClusterById(clusterId)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, common.CLUSTER_NOTEXISTS_ERROR
+	}
+
+	ts := time.Now().Unix()
+	sign := common.CalcMsReqSign(clusterId, info.ClusterToken, ts)
+
+	reqParams := make(map[string]interface{})
+	reqParams["d"] = ts
+	reqParams["s"] = sign
+	for k, v := range filters {
+		reqParams[k] = v
+	}
+
+	var resp = struct {
+		Code int         `json:"code"`
+		Msg  string      `json:"message"`
+		Data interface{} `json:"data"`
+	}{}
+	if err := sendGetReq(info.MasterUrl, "/manage/task/history", reqParams, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		log.Error("task get history failed. err:[%v]", resp)
+		return nil, &common.FbaseError{Code: common.INTERNAL_ERROR.Code, Msg: resp.Msg}
+	}
+	return resp.Data, nil
+}
+
 func (s *Service) DeletePeer(clusterId int, rangeId, peerId string) (interface{}, error) {
 	if s == nil {
 		return nil, errors.New("service is nil")
@@ -2073,6 +2181,37 @@ func (s *Service) GetRangeOpsTopN(clusterId int, topN int) (interface{}, error)
 	return getTopNResp.Data, nil
 }
 
+func (s *Service) GetStatementTopN(clusterId int, topN int, order string) (interface{}, error) {
+	info, err := s.selectClusterById(clusterId)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, common.CLUSTER_NOTEXISTS_ERROR
+	}
+
+	reqParams := make(map[string]interface{})
+	reqParams["clusterId"] = clusterId
+	reqParams["topN"] = topN
+	if order != "" {
+		reqParams["order"] = order
+	}
+
+	var getTopNResp = struct {
+		Code int         `json:"code"`
+		Msg  string      `json:"message"`
+		Data interface{} `json:"data"`
+	}{}
+	if err := sendGetReq(info.MasterUrl, "/metric/get/statement", reqParams, &getTopNResp); err != nil {
+		return nil, err
+	}
+	if getTopNResp.Code != 0 {
+		log.Error("get cluster %d statement topN %v failed. err:[%v]", clusterId, topN, getTopNResp)
+		return nil, fmt.Errorf(getTopNResp.Msg)
+	}
+	return getTopNResp.Data, nil
+}
+
 func (s *Service) GetPrivilegeInfo(offset, limit int, order string) ([]*models.UserPrivilege, error) {
 	result := make([]*models.UserPrivilege, offset, limit)
 	rows, err := s.db.Query(fmt.Sprintf(`SELECT * FROM %s order by user_name %s limit %d,%d  `, TABLE_NAME_PRIVILEGE, order, offset, limit))
@@ -3129,6 +3268,60 @@ func (s *Service) SetMetricConfig(cId int, addr, interval string) (map[string]st
 }
 //=============metric end ===============
 
+//=============resource group start ===============
+func (s *Service) GetResourceGroups(cId int) (interface{}, error) {
+	return s.sendResourceGroupReq(cId, "/manage/resource_group/list", nil)
+}
+
+func (s *Service) SetResourceGroup(cId int, name, kind, key string, qps, readRows, writeBytes uint64) (interface{}, error) {
+	params := make(map[string]interface{})
+	params["name"] = url.QueryEscape(name)
+	params["kind"] = kind
+	params["key"] = url.QueryEscape(key)
+	params["qps"] = qps
+	params["read_rows"] = readRows
+	params["write_bytes"] = writeBytes
+	return s.sendResourceGroupReq(cId, "/manage/resource_group/set", params)
+}
+
+func (s *Service) DelResourceGroup(cId int, name string) (interface{}, error) {
+	params := make(map[string]interface{})
+	params["name"] = url.QueryEscape(name)
+	return s.sendResourceGroupReq(cId, "/manage/resource_group/del", params)
+}
+
+func (s *Service) sendResourceGroupReq(cId int, uri string, params map[string]interface{}) (interface{}, error) {
+	info, err := s.selectClusterById(cId)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, common.CLUSTER_NOTEXISTS_ERROR
+	}
+
+	ts := time.Now().Unix()
+	reqParams := make(map[string]interface{})
+	reqParams["d"] = ts
+	reqParams["s"] = common.CalcMsReqSign(info.Id, info.ClusterToken, ts)
+	for k, v := range params {
+		reqParams[k] = v
+	}
+	var resp = struct {
+		Code int         `json:"code"`
+		Msg  string      `json:"message"`
+		Data interface{} `json:"data"`
+	}{}
+	if err := sendGetReq(info.MasterUrl, uri, reqParams, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		log.Error("cluster[%d] master %s failed. err:[%v]", cId, uri, resp)
+		return nil, &common.FbaseError{Code: common.INTERNAL_ERROR.Code, Msg: resp.Msg}
+	}
+	return resp.Data, nil
+}
+//=============resource group end ===============
+
 //============sql ca start ==============
 func (s *Service) GetSqlCaList(pageInfo *models.PagerInfo) (int, []*models.SqlCAInfo, error) {
 	selectSql := fmt.Sprintf(`SELECT cluster_id, user_name, password FROM %s`, TABLE_NAME_SQL_CA)
@@ -354,6 +354,8 @@ app.controller('myClusterNodeInfo', function ($rootScope, $scope, $http, $timeou
                         return "N_Upgrade";
                     } else if (value == 6) {
                         return "N_Initial";
+                    } else if (value == 7) {
+                        return "N_Draining";
                     } else {
                         return value;
                     }
@@ -367,6 +369,8 @@ app.controller('myClusterNodeInfo', function ($rootScope, $scope, $http, $timeou
                         "<button id=\"upgradeNode\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"升级\" onclick=\"upgradeNode('" + row.id + "');\">升级</button>&nbsp;&nbsp;",
                         "<button id=\"viewNodeMonitor\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"监控\" onclick=\"viewNodeMonitor('" + row.id + "','" + row.server_addr + "');\">监控</button>&nbsp;&nbsp;",
                         "<button id=\"updateNodeStatus\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"修改状态\" onclick=\"updateNodeStatus('" + row.id + "');\">状态修改</button>&nbsp;&nbsp;",
+                        "<button id=\"drainNode\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"下线迁移\" onclick=\"drainNode('" + row.id + "');\">下线迁移</button>&nbsp;&nbsp;",
+                        "<button id=\"getDrainProgressOfNode\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"迁移进度\" onclick=\"getDrainProgressOfNode('" + row.id + "');\">迁移进度</button>&nbsp;&nbsp;",
                         "<button id=\"deleteNode\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"删除\" onclick=\"deleteNode(" + row.id + ");\">删除</button>&nbsp;&nbsp;",
                         "<button id=\"updateNodeLogLevel\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"修改日志级别\" onclick=\"updateNodeLogLevel('" + row.id + "');\">设置日志级别</button>&nbsp;&nbsp;",
                         "<button id=\"getRangeTopoOfNode\" class=\"btn btn-primary btn-rounded\" type=\"button\" value==\"查看range\" onclick=\"getRangeTopoOfNode('" + row.id + "');\">查看range</button>&nbsp;&nbsp;",
@@ -437,6 +441,75 @@ function updateNodeStatus(nodeId) {
         });
 }
 
+//计划下线node: 不再分配新的副本, 逐步迁出leader和副本, 迁移完成后变为logout可以删除
+function drainNode(nodeId) {
+    swal({
+            title: "下线迁移",
+            text: "<select class='form-control' id='selectDrainAction' style='height: 33px'><option value='false'>开始下线迁移</option><option value='true'>取消下线迁移</option></select>",
+            html: true,
+            showCancelButton: true,
+            confirmButtonColor: "#DD6B55",
+            confirmButtonText: "执行",
+            closeOnConfirm: false
+        },
+        function () {
+            var cancel = $('#selectDrainAction').val();
+            //获取集群id
+            var clusterId = $('#clusterId').val();
+            $.ajax({
+                url: "/node/drainNode",
+                type: "post",
+                contentType: "application/x-www-form-urlencoded; charset=UTF-8",
+                dataType: "json",
+                data: {
+                    "nodeId": nodeId,
+                    "clusterId": clusterId,
+                    "cancel": cancel
+                },
+                success: function (data) {
+                    if (data.code === 0) {
+                        swal("操作成功！", "可以通过迁移进度查看下线情况", "success");
+                    } else {
+                        swal("操作失败！", data.msg, "error");
+                    }
+                },
+                error: function (res) {
+                    swal("操作失败！", "请联系管理员!", "error");
+                }
+            });
+        });
+}
+
+//查看node下线迁移进度
+function getDrainProgressOfNode(nodeId) {
+    var clusterId = $('#clusterId').val();
+    $.ajax({
+        url: "/node/getDrainProgressOfNode",
+        type: "post",
+        contentType: "application/x-www-form-urlencoded; charset=UTF-8",
+        dataType: "json",
+        data: {
+            "nodeId": nodeId,
+            "clusterId": clusterId
+        },
+        success: function (data) {
+            if (data.code === 0 && data.data != null && data.data.length > 0) {
+                var p = data.data[0];
+                var text = "状态: " + p.state + "<br>" +
+                    "range总数: " + p.total_ranges + ", 剩余: " + p.remain_ranges + ", leader: " + p.leaders + "<br>" +
+                    "迁移中的leader: " + p.moving_leaders + ", 迁移中的副本: " + p.moving_replicas + "<br>" +
+                    "可以删除: " + (p.removable ? "是" : "否");
+                swal({title: "迁移进度", text: text, html: true});
+            } else {
+                swal("查询失败！", data.msg, "error");
+            }
+        },
+        error: function (res) {
+            swal("查询失败！", "请联系管理员!", "error");
+        }
+    });
+}
+
 //node批量下线操作
 function batchDeleteNode() {
     var selectedNodeRows = $('#viewnodeList').bootstrapTable('getSelections');
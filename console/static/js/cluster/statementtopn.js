@@ -0,0 +1,26 @@
+var app = angular.module('statementTopN', []);
+app.controller('statementTopNCtrl', ['$rootScope', '$scope', '$http', '$timeout', function ($rootScope, $scope, $http, $timeout) {
+    var clusterId = $("#clusterId").val();
+    var topN = $("#topN").val();
+
+    $scope.orders = [
+        {value: "latency", name: "总耗时"},
+        {value: "count", name: "执行次数"},
+        {value: "errors", name: "错误次数"},
+        {value: "examined", name: "扫描行数"},
+        {value: "last_seen", name: "最近执行"}
+    ];
+    $scope.order = "latency";
+
+    $scope.getStatementTopN = function () {
+        var getTopNUrl = "/metric/statement/topN?topN=" + topN + "&clusterId=" + clusterId + "&order=" + $scope.order;
+        $http.get(getTopNUrl).success(function (data) {
+            if (data.code === 0) {
+                $scope.statList = data.data;
+            } else {
+                swal("查询失败", data.msg, "error");
+            }
+        });
+    };
+    $scope.getStatementTopN();
+}]);
@@ -31,6 +31,17 @@ $(document).ready(function() {
 						regxsArray.push(data.data.regxs[i]);
 					}
 				}
+				//副本放置规则
+				if(data.data.properties){
+					try {
+						var props = JSON.parse(data.data.properties);
+						if(props.placement != null){
+							$("#placement").val(JSON.stringify(props.placement));
+						}
+					} catch(e) {
+						console.log("parse table properties failed: " + e);
+					}
+				}
 			}else {
 				swal("获取失败", data.msg, "error");
 			}
@@ -208,11 +219,21 @@ function createDataBaseTable(data) {
 	var name = $("#name").val();
 	//集群id
 	var clusterId = $('#clusterId').val();
+	//副本放置规则, 空表示不修改
+	var placement = myTrim($("#placement").val());
 	//验证表名
     if(name == undefined || name == null || name == "") {
     	swal("表名必须填写");
         return false;
     }
+    if(placement != "") {
+        try {
+            JSON.parse(placement);
+        } catch(e) {
+            swal("副本放置规则不是合法的JSON");
+            return false;
+        }
+    }
     swal({
     	  title: "修改表?",
     	  type: "warning",
@@ -234,6 +255,7 @@ function createDataBaseTable(data) {
     	        	"columns":JSON.stringify(data),
     	        	"regxs":JSON.stringify(regxsList),
     	        	"rangeKeys":rangeKeys,
+    	        	"placement":placement,
     	        	"clusterId":clusterId
     	        },
     			success: function(data){
@@ -16,4 +16,8 @@ app.controller('unsRange', function($rootScope, $scope, $http, $timeout) {
 	  $scope.viewPeerInfo = function(rangeId) {
 	        window.location.href="/page/range/peerinfo?rangeId="+ rangeId +"&tableName="+tableName+"&dbName="+dbName+"&clusterId="+clusterId + "&flag="+false;
        };
+
+	  $scope.viewTaskHistory = function(rangeId) {
+	        window.location.href="/page/task/history?rangeId="+ rangeId +"&clusterId="+clusterId;
+       };
  });
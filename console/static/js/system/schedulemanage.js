@@ -78,6 +78,27 @@ app.controller('myFbaseClusterInfo', function($rootScope, $scope, $http, $timeou
             }
         );
     };
+
+    $scope.viewStatementTopN = function (space) {
+        swal({
+                title: "输入查看的条数，不填默认top10",
+                text: "请输入查看的条数",
+                type: "input",
+                showCancelButton: true,
+                confirmButtonColor: "#DD6B55",
+                confirmButtonText: "查看",
+                closeOnConfirm: false
+            },
+            function (inputValue) {
+                if (inputValue === false) return;
+                if (inputValue === "") {
+                    inputValue = 10;
+                }
+                var topN = inputValue;
+                window.location.href = "/page/cluster/viewStatementTopN?clusterId=" + space.id + "&topN=" + topN;
+            }
+        );
+    };
 });
 
 function saveInfo() {
@@ -0,0 +1,46 @@
+var app = angular.module('taskHistory', []);
+
+app.controller('taskTimeline', function($rootScope, $scope, $http) {
+    var clusterId = $('#clusterId').val();
+    $scope.filter = {rangeId: $('#rangeId').val(), limit: 100};
+    $scope.records = [];
+
+    function unixTime(t) {
+        if (!t) {
+            return '';
+        }
+        return Math.floor(t.getTime() / 1000);
+    }
+
+    $scope.query = function() {
+        var params = {
+            clusterId: clusterId,
+            rangeId: $scope.filter.rangeId,
+            nodeId: $scope.filter.nodeId,
+            tableId: $scope.filter.tableId,
+            startTime: unixTime($scope.filter.start),
+            endTime: unixTime($scope.filter.end),
+            limit: $scope.filter.limit
+        };
+        $http.get('/task/getHistory', {params: params}).success(function(data) {
+            if (data.code === 0) {
+                $scope.records = data.data || [];
+            } else {
+                swal("获取失败", data.msg, "error");
+            }
+        });
+    };
+
+    $scope.iconClass = function(record) {
+        switch (record.outcome) {
+            case 'finished':
+                return 'navy-bg';
+            case 'canceled':
+                return 'yellow-bg';
+            default:
+                return 'red-bg';
+        }
+    };
+
+    $scope.query();
+});
@@ -106,7 +106,7 @@ Status AdminServer::forceSplit(const ForceSplitRequest& req, ForceSplitResponse*
         return Status(Status::kNotFound, "range", std::to_string(req.range_id()));
     }
     FLOG_INFO("[Admin] force split range %" PRIu64 ", version: %" PRIu64, req.range_id(), req.version());
-    return rng->ForceSplit(req.version(), resp->mutable_split_key());
+    return rng->ForceSplit(req.version(), req.split_key(), resp->mutable_split_key());
 }
 
 Status AdminServer::compaction(const CompactionRequest& req, CompactionResponse* resp) {
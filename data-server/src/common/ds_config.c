@@ -405,6 +405,12 @@ static int load_watch_config(IniContext *ini_context) {
         ds_config.watch_config.buffer_queue_size = 8;
     }
 
+    ds_config.watch_config.row_buffer_size =
+            iniGetIntValue(section, "row_buffer_size", ini_context, 0);
+    if (ds_config.watch_config.row_buffer_size < 0) {
+        ds_config.watch_config.row_buffer_size = 0;
+    }
+
     return 0;
 }
 
@@ -89,6 +89,7 @@ typedef struct ds_config_s {
         int buffer_map_size;
         int buffer_queue_size;
         int watcher_set_size;
+        int row_buffer_size; // row changes kept per sql range for cdc, 0 disabled
     } watch_config;
 
     sf_socket_thread_config_t manager_config;  // manager thread config
@@ -25,6 +25,9 @@ public:
 
     virtual void OnLeaderChange(uint64_t leader, uint64_t term) = 0;
 
+    // learner追上日志后是否可以提升为投票成员，由leader在提升前检查
+    virtual bool CanPromote(const Peer& peer) { return true; }
+
     // TODO: use unique_ptr?
     virtual std::shared_ptr<Snapshot> GetSnapshot() = 0;
 
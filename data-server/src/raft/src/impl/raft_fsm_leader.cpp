@@ -387,13 +387,22 @@ std::shared_ptr<SendSnapTask> RaftFsm::newSendSnapTask(uint64_t to,
 void RaftFsm::checkCaughtUp() {
     Peer max_peer;
     uint64_t max_match = 0;
+    bool found = false;
     for (const auto& p : learners_) {
         const auto& pr = *p.second;
+        // 不可提升的learner(如分析用的副本)一直保持learner
+        if (!sm_->CanPromote(pr.peer())) {
+            continue;
+        }
         if (pr.match() >= max_match) {
             max_match = pr.match();
             max_peer = pr.peer();
+            found = true;
         }
     }
+    if (!found) {
+        return;
+    }
 
     auto lasti = raft_log_->lastIndex();
     auto precent_threshold = (sops_.promote_gap_percent * lasti) / 100;
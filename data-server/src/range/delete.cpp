@@ -93,7 +93,7 @@ void Range::Delete(common::ProtoMessage *msg, kvrpcpb::DsDeleteRequest &req) {
     }
 }
 
-Status Range::ApplyDelete(const raft_cmdpb::Command &cmd) {
+Status Range::ApplyDelete(const raft_cmdpb::Command &cmd, uint64_t index) {
     Status ret;
     uint64_t affected_keys = 0;
     errorpb::Error *err = nullptr;
@@ -119,7 +119,9 @@ Status Range::ApplyDelete(const raft_cmdpb::Command &cmd) {
             }
         }
 
-        ret = store_->DeleteRows(req, &affected_keys);
+        std::vector<std::pair<std::string, std::string>> rows;
+        updateWriteTime(req.timestamp());
+        ret = store_->DeleteRows(req, &affected_keys, row_changes_ != nullptr ? &rows : nullptr);
         context_->Statistics()->PushTime(HistogramType::kStore, get_micro_second() - btime);
 
         if (!ret.ok()) {
@@ -127,6 +129,21 @@ Status Range::ApplyDelete(const raft_cmdpb::Command &cmd) {
                        ret.ToString().c_str());
             break;
         }
+
+        if (row_changes_ != nullptr && !rows.empty()) {
+            std::vector<watch::RowChange> changes(rows.size());
+            for (size_t i = 0; i < rows.size(); i++) {
+                auto &change = changes[i];
+                change.index = index;
+                change.type = watchpb::DELETE;
+                change.key = std::move(rows[i].first);
+                change.has_prev = true;
+                change.prev_value = std::move(rows[i].second);
+                change.commit_wall_time = req.timestamp().wall_time();
+                change.commit_logical = req.timestamp().logical();
+            }
+            row_changes_->Append(std::move(changes));
+        }
     } while (false);
 
     if (cmd.cmd_id().node_id() == node_id_) {
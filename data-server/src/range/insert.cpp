@@ -48,7 +48,29 @@ void Range::Insert(common::ProtoMessage *msg, kvrpcpb::DsInsertRequest &req) {
     }
 }
 
-Status Range::ApplyInsert(const raft_cmdpb::Command &cmd) {
+Status Range::matchExpected(const kvrpcpb::InsertRequest &req, kvrpcpb::InsertRequest *matched) {
+    if (req.expected_values_size() != req.rows_size()) {
+        return Status(Status::kInvalidArgument, "expected values",
+                      std::to_string(req.expected_values_size()) + " != " + std::to_string(req.rows_size()));
+    }
+    matched->set_check_duplicate(req.check_duplicate());
+    matched->mutable_timestamp()->CopyFrom(req.timestamp());
+    std::string value;
+    for (int i = 0; i < req.rows_size(); i++) {
+        auto ret = store_->Get(req.rows(i).key(), &value);
+        if (ret.code() == Status::kNotFound) {
+            continue;
+        } else if (!ret.ok()) {
+            return ret;
+        }
+        if (value == req.expected_values(i)) {
+            matched->add_rows()->CopyFrom(req.rows(i));
+        }
+    }
+    return Status::OK();
+}
+
+Status Range::ApplyInsert(const raft_cmdpb::Command &cmd, uint64_t index) {
     Status ret;
     uint64_t affected_keys = 0;
 
@@ -56,7 +78,8 @@ Status Range::ApplyInsert(const raft_cmdpb::Command &cmd) {
 
     RANGE_LOG_DEBUG("ApplyInsert begin");
 
-    auto &req = cmd.insert_req();
+    const kvrpcpb::InsertRequest *insert = &cmd.insert_req();
+    kvrpcpb::InsertRequest matched;
     auto btime = get_micro_second();
     do {
         auto &epoch = cmd.verify_epoch();
@@ -66,6 +89,48 @@ Status Range::ApplyInsert(const raft_cmdpb::Command &cmd) {
             break;
         }
 
+        // conditional insert, a row is written only if its current value is
+        // the expected one. the check is atomic since the commands of a range
+        // are applied one by one.
+        if (insert->expected_values_size() > 0) {
+            ret = matchExpected(*insert, &matched);
+            if (!ret.ok()) {
+                RANGE_LOG_ERROR("ApplyInsert match expected failed, code:%d, msg:%s", ret.code(),
+                           ret.ToString().c_str());
+                break;
+            }
+            insert = &matched;
+        }
+        auto &req = *insert;
+
+        // keep the row images before they are overwritten
+        std::vector<watch::RowChange> changes;
+        if (row_changes_ != nullptr) {
+            changes.resize(req.rows_size());
+            for (int i = 0; i < req.rows_size(); i++) {
+                auto &change = changes[i];
+                change.index = index;
+                change.type = watchpb::PUT;
+                change.key = req.rows(i).key();
+                change.value = req.rows(i).value();
+                change.commit_wall_time = req.timestamp().wall_time();
+                change.commit_logical = req.timestamp().logical();
+                ret = store_->Get(change.key, &change.prev_value);
+                if (ret.ok()) {
+                    change.has_prev = true;
+                } else if (ret.code() != Status::kNotFound) {
+                    break;
+                }
+                ret = Status::OK();
+            }
+            if (!ret.ok()) {
+                RANGE_LOG_ERROR("ApplyInsert get row failed, code:%d, msg:%s", ret.code(),
+                           ret.ToString().c_str());
+                break;
+            }
+        }
+
+        updateWriteTime(req.timestamp());
         ret = store_->Insert(req, &affected_keys);
         auto etime = get_micro_second();
         context_->Statistics()->PushTime(HistogramType::kStore, etime - btime);
@@ -76,6 +141,10 @@ Status Range::ApplyInsert(const raft_cmdpb::Command &cmd) {
             break;
         }
 
+        if (row_changes_ != nullptr) {
+            row_changes_->Append(std::move(changes));
+        }
+
         if (cmd.cmd_id().node_id() == node_id_) {
             uint64_t len = 0;
             auto size = req.rows_size();
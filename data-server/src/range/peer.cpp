@@ -37,6 +37,7 @@ void Range::AddPeer(const metapb::Peer &peer) {
     ap->set_id(peer.id());
     ap->set_node_id(peer.node_id());
     ap->set_type(peer.type());
+    ap->set_keep_learner(peer.keep_learner());
 
     auto ep = pt.mutable_verify_epoch();
     ep->set_conf_ver(meta_.GetConfVer());
@@ -82,6 +83,14 @@ void Range::DelPeer(const metapb::Peer &peer) {
               peer.node_id(), meta_.GetVersion(), meta_.GetConfVer());
 }
 
+bool Range::CanPromote(const raft::Peer &peer) {
+    metapb::Peer meta_peer;
+    if (meta_.FindPeerByNodeID(peer.node_id, &meta_peer) && meta_peer.id() == peer.peer_id) {
+        return !meta_peer.keep_learner();
+    }
+    return true;
+}
+
 Status Range::ApplyMemberChange(const raft::ConfChange &cc, uint64_t index) {
     RANGE_LOG_INFO(
             "start ApplyMemberChange: %s, current conf ver: %" PRIu64 " at index %" PRIu64,
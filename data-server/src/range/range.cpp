@@ -19,6 +19,11 @@ static const int kDownPeerThresholdSecs = 50;
 // 磁盘使用率大于百分之92停写
 static const uint64_t kStopWriteFsUsagePercent = 92;
 
+// hlc wall time is in nanoseconds
+static int64_t nowWallTime() {
+    return get_micro_second() * 1000;
+}
+
 Range::Range(RangeContext* context, const metapb::Range &meta) :
 	context_(context),
 	node_id_(context_->GetNodeID()),
@@ -28,6 +33,8 @@ Range::Range(RangeContext* context, const metapb::Range &meta) :
 	store_(new storage::Store(meta, context->DBInstance())) {
     eventBuffer = new watch::CEventBuffer(ds_config.watch_config.buffer_map_size,
                                         ds_config.watch_config.buffer_queue_size);
+    // the writes applied before the range is loaded are unknown
+    max_write_time_ = nowWallTime();
 }
 
 
@@ -51,6 +58,11 @@ Status Range::Initialize(uint64_t leader, uint64_t log_start_index) {
         }
     }
 
+    if (ds_config.watch_config.row_buffer_size > 0) {
+        row_changes_.reset(new watch::RowChangeBuffer(
+            static_cast<size_t>(ds_config.watch_config.row_buffer_size), apply_index_));
+    }
+
     // 初始化raft
     raft::RaftOptions options;
     options.id = id_;
@@ -207,9 +219,9 @@ Status Range::Apply(const raft_cmdpb::Command &cmd, uint64_t index) {
         case raft_cmdpb::CmdType::RawDelete:
             return ApplyRawDelete(cmd);
         case raft_cmdpb::CmdType::Insert:
-            return ApplyInsert(cmd);
+            return ApplyInsert(cmd, index);
         case raft_cmdpb::CmdType::Delete:
-            return ApplyDelete(cmd);
+            return ApplyDelete(cmd, index);
         case raft_cmdpb::CmdType::KvSet:
             return ApplyKVSet(cmd);
         case raft_cmdpb::CmdType::KvBatchSet:
@@ -346,6 +358,7 @@ Status Range::ApplySnapshotStart(const std::string &context) {
     }
 
     apply_index_ = 0;
+    max_write_time_ = nowWallTime();
 
     auto s = store_->Truncate();
     if (!s.ok()) {
@@ -387,6 +400,9 @@ Status Range::ApplySnapshotFinish(uint64_t index) {
     }
 
     apply_index_ = index;
+    if (row_changes_ != nullptr) {
+        row_changes_->Reset(index);
+    }
     auto s = context_->MetaStore()->SaveApplyIndex(id_, index);
     if (!s.ok()) {
         RANGE_LOG_ERROR("save snapshot applied index failed(%s)!", s.ToString().c_str());
@@ -396,6 +412,20 @@ Status Range::ApplySnapshotFinish(uint64_t index) {
     }
 }
 
+void Range::updateWriteTime(const timestamp::Timestamp &ts) {
+    // a write without timestamp is taken as written now. only the apply
+    // thread updates it, so no compare and swap is needed
+    auto wall = ts.wall_time() > 0 ? ts.wall_time() : nowWallTime();
+    if (wall > max_write_time_) {
+        max_write_time_ = wall;
+    }
+}
+
+bool Range::writtenAfter(const timestamp::Timestamp &ts) const {
+    // the logical part is not kept, a write at the same wall time is taken as newer
+    return max_write_time_ >= ts.wall_time();
+}
+
 Status Range::SaveMeta(const metapb::Range &meta) {
     return context_->MetaStore()->AddRange(meta);
 }
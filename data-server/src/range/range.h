@@ -29,6 +29,7 @@ _Pragma("once");
 #include "server/context_server.h"
 #include "server/run_status.h"
 #include "watch/watch_event_buffer.h"
+#include "watch/row_change_buffer.h"
 #include "watch/watcher.h"
 
 #include "meta_keeper.h"
@@ -74,6 +75,7 @@ public:
     void OnReplicateError(const std::string &cmd, const Status &status) override {};
 
     void OnLeaderChange(uint64_t leader, uint64_t term) override;
+    bool CanPromote(const raft::Peer &peer) override;
 
     std::shared_ptr<raft::Snapshot> GetSnapshot() override;
     Status ApplySnapshotStart(const std::string &context) override;
@@ -84,7 +86,8 @@ public:
     void GetPeerInfo(raft::RaftStatus *raft_status);
     uint64_t GetPeerID() const;
 
-    Status ForceSplit(uint64_t version, std::string* split_key);
+    // split at split_key if it's not empty, otherwise at the middle key
+    Status ForceSplit(uint64_t version, const std::string& split_key, std::string* result_split_key);
 
     // lock
     kvrpcpb::LockValue *LockGet(const std::string &key);
@@ -117,6 +120,8 @@ public:
     Status GetAndResp( watch::WatcherPtr pWatcher, const watchpb::WatchCreateRequest& req, const std::string &dbKey, const bool &prefix,
                               int64_t &version, watchpb::DsWatchResponse *dsResp);
     void WatchGet(common::ProtoMessage *msg, watchpb::DsWatchRequest &req);
+    // WatchRows returns row changes of sql table after start version for cdc
+    void WatchRows(common::ProtoMessage *msg, watchpb::DsWatchRequest &req);
     void PureGet(common::ProtoMessage *msg, watchpb::DsKvWatchGetMultiRequest &req);
     void WatchPut(common::ProtoMessage *msg, watchpb::DsKvWatchPutRequest &req);
     void WatchDel(common::ProtoMessage *msg, watchpb::DsKvWatchDeleteRequest &req);
@@ -161,8 +166,15 @@ private:
     Status ApplyWatchPut(const raft_cmdpb::Command &cmd, uint64_t raftIdx);
     Status ApplyWatchDel(const raft_cmdpb::Command &cmd, uint64_t raftIdx);
 
-    Status ApplyInsert(const raft_cmdpb::Command &cmd);
-    Status ApplyDelete(const raft_cmdpb::Command &cmd);
+    Status ApplyInsert(const raft_cmdpb::Command &cmd, uint64_t index);
+    // the rows whose current value is the expected one
+    Status matchExpected(const kvrpcpb::InsertRequest &req, kvrpcpb::InsertRequest *matched);
+    Status ApplyDelete(const raft_cmdpb::Command &cmd, uint64_t index);
+
+    // snapshot read
+    void updateWriteTime(const timestamp::Timestamp &ts);
+    bool writtenAfter(const timestamp::Timestamp &ts) const;
+    Status checkSnapshotRead(const kvrpcpb::SelectRequest &req, kvrpcpb::SelectResponse *resp);
 
     Status ApplySplit(const raft_cmdpb::Command &cmd, uint64_t index);
 
@@ -294,6 +306,9 @@ private:
 
     uint64_t apply_index_ = 0;
     std::atomic<bool> is_leader_ = {false};
+    // wall time of the newest applied row write, a snapshot read at an older
+    // timestamp fails instead of returning rows newer than the timestamp
+    std::atomic<int64_t> max_write_time_ = {0};
 
     uint64_t real_size_ = 0;
     std::atomic<bool> statis_flag_ = {false};
@@ -301,6 +316,8 @@ private:
     uint64_t split_range_id_ = 0;
 
     watch::CEventBuffer *eventBuffer = nullptr;
+    // row changes for cdc, null if disabled
+    std::unique_ptr<watch::RowChangeBuffer> row_changes_;
     SubmitQueue submit_queue_;
 
     std::unique_ptr<storage::Store> store_;
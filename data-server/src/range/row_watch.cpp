@@ -0,0 +1,107 @@
+#include "range.h"
+
+#include "server/range_server.h"
+#include "range_logger.h"
+
+namespace sharkstore {
+namespace dataserver {
+namespace range {
+
+using namespace sharkstore::monitor;
+
+static const size_t kMaxRowWatchEvents = 1000;
+
+// WatchRows returns the row changes with raft index greater than the start
+// version, or kCompacted if some of them were evicted. the epoch is not
+// checked, a range keeps its changes before split, so the consumer could
+// drain them before watching the new ranges.
+// header.apply_index is the index the consumer has got all changes up to.
+void Range::WatchRows(common::ProtoMessage *msg, watchpb::DsWatchRequest &req) {
+    errorpb::Error *err = nullptr;
+
+    auto btime = get_micro_second();
+    context_->Statistics()->PushTime(HistogramType::kQWait, btime - msg->begin_time);
+
+    auto ds_resp = new watchpb::DsWatchResponse;
+    auto header = ds_resp->mutable_header();
+
+    RANGE_LOG_DEBUG("WatchRows begin, start version: %" PRId64, req.req().startversion());
+
+    if (!VerifyLeader(err)) {
+        RANGE_LOG_WARN("WatchRows error: %s", err->message().c_str());
+        common::SetResponseHeader(req.header(), header, err);
+        context_->SocketSession()->Send(msg, ds_resp);
+        return;
+    }
+
+    common::SetResponseHeader(req.header(), header);
+    auto resp = ds_resp->mutable_resp();
+    resp->set_watchid(req.req().watchid());
+
+    if (row_changes_ == nullptr) {
+        resp->set_code(Status::kNotSupported);
+        context_->SocketSession()->Send(msg, ds_resp);
+        return;
+    }
+
+    // changes up to the applied index are already in the buffer
+    uint64_t applied = apply_index_;
+    // negative start version asks for the current position only
+    if (req.req().startversion() < 0) {
+        resp->set_code(Status::kOk);
+        header->set_apply_index(applied);
+        context_->SocketSession()->Send(msg, ds_resp);
+        return;
+    }
+    uint64_t from = static_cast<uint64_t>(req.req().startversion());
+    std::vector<watch::RowChange> changes;
+    if (!row_changes_->Load(from, kMaxRowWatchEvents, &changes)) {
+        RANGE_LOG_WARN("WatchRows start version %" PRIu64 " is compacted, base index: %" PRIu64,
+                       from, row_changes_->BaseIndex());
+        resp->set_code(Status::kCompacted);
+        header->set_apply_index(applied);
+        context_->SocketSession()->Send(msg, ds_resp);
+        return;
+    }
+
+    auto table_id = meta_.GetTableID();
+    for (auto &change : changes) {
+        auto evt = resp->add_events();
+        evt->set_type(change.type);
+
+        auto kv = evt->mutable_kv();
+        kv->set_tableid(table_id);
+        kv->add_key(change.key);
+        kv->set_version(static_cast<int64_t>(change.index));
+        if (change.type == watchpb::PUT) {
+            kv->set_value(change.value);
+        }
+        if (change.has_prev) {
+            auto prev = evt->mutable_prevkv();
+            prev->set_tableid(table_id);
+            prev->add_key(change.key);
+            prev->set_value(change.prev_value);
+        }
+        auto ts = evt->mutable_committs();
+        ts->set_wall_time(change.commit_wall_time);
+        ts->set_logical(change.commit_logical);
+    }
+
+    if (!changes.empty()) {
+        // the load stopped at the limit, the consumer continues from the last change
+        if (changes.size() >= kMaxRowWatchEvents || changes.back().index > applied) {
+            applied = changes.back().index;
+        }
+    }
+    if (applied < from) {
+        applied = from;
+    }
+    header->set_apply_index(applied);
+    resp->set_code(Status::kOk);
+
+    context_->SocketSession()->Send(msg, ds_resp);
+}
+
+}  // namespace range
+}  // namespace dataserver
+}  // namespace sharkstore
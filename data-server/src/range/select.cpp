@@ -16,6 +16,9 @@ kvrpcpb::SelectResponse *Range::SelectResp(const kvrpcpb::DsSelectRequest &req)
     if (is_leader_ && (key.empty() || KeyInRange(key))) {
         auto resp = new kvrpcpb::SelectResponse;
         auto ret = store_->Select(req.req(), resp);
+        if (ret.ok()) {
+            ret = checkSnapshotRead(req.req(), resp);
+        }
         if (ret.ok()) {
             resp->set_code(0);
         } else {
@@ -28,6 +31,16 @@ kvrpcpb::SelectResponse *Range::SelectResp(const kvrpcpb::DsSelectRequest &req)
     return nullptr;
 }
 
+// a snapshot read fails if the range has applied a write newer than the read
+// timestamp, the rows may include it
+Status Range::checkSnapshotRead(const kvrpcpb::SelectRequest &req, kvrpcpb::SelectResponse *resp) {
+    if (req.snapshot_read() && writtenAfter(req.timestamp())) {
+        resp->clear_rows();
+        return Status(Status::kExpired, "snapshot read", "written after the read timestamp");
+    }
+    return Status::OK();
+}
+
 kvrpcpb::SelectResponse *Range::SelectTry(const kvrpcpb::DsSelectRequest &req) {
     std::shared_ptr<Range> rng = context_->FindRange(split_range_id_);
     if (rng == nullptr) {
@@ -88,6 +101,9 @@ void Range::Select(common::ProtoMessage *msg, kvrpcpb::DsSelectRequest &req) {
         auto ret = store_->Select(req.req(), resp);
         auto etime = get_micro_second();
         context_->Statistics()->PushTime(HistogramType::kStore, etime - btime);
+        if (ret.ok()) {
+            ret = checkSnapshotRead(req.req(), resp);
+        }
 
         if (etime - msg->begin_time > kTimeTakeWarnThresoldUSec) {
             RANGE_LOG_WARN("select takes too long(%" PRId64 " ms), sid=%" PRId64 ", msgid=%" PRId64,
@@ -229,7 +229,7 @@ Status Range::ApplySplit(const raft_cmdpb::Command &cmd, uint64_t index) {
     return ret;
 }
 
-Status Range::ForceSplit(uint64_t version, std::string *result_split_key) {
+Status Range::ForceSplit(uint64_t version, const std::string &at_key, std::string *result_split_key) {
     auto meta = meta_.Get();
     // check version when version ne zero
     if (version != 0 && meta.range_epoch().version() != version) {
@@ -241,7 +241,9 @@ Status Range::ForceSplit(uint64_t version, std::string *result_split_key) {
 
     std::string split_key;
     auto mode = context_->GetSplitPolicy()->KeyMode();
-    if (mode != SplitKeyMode::kNormal) {
+    if (!at_key.empty()) {
+        split_key = at_key;
+    } else if (mode != SplitKeyMode::kNormal) {
         // TODO: support unnormal mode
         return Status(Status::kNotSupported, "split key mode", SplitKeyModeName(mode));
     } else {
@@ -82,6 +82,11 @@ Status Range::GetAndResp( watch::WatcherPtr pWatcher, const watchpb::WatchCreate
 
 
 void Range::WatchGet(common::ProtoMessage *msg, watchpb::DsWatchRequest &req) {
+    // row watch of sql table is a prefix watch without keys
+    if (req.req().prefix() && req.req().kv().key_size() == 0) {
+        return WatchRows(msg, req);
+    }
+
     errorpb::Error *err = nullptr;
 
     auto btime = get_micro_second();
@@ -5,18 +5,36 @@ namespace dataserver {
 namespace storage {
 
 Iterator::Iterator(rocksdb::Iterator* it, const std::string& start,
-                   const std::string& limit)
-    : rit_(it), limit_(limit) {
+                   const std::string& limit, bool reverse)
+    : rit_(it), start_(start), limit_(limit), reverse_(reverse) {
     assert(!start.empty());
     assert(!limit.empty());
-    rit_->Seek(start);
+    if (!reverse_) {
+        rit_->Seek(start);
+        return;
+    }
+    // limit是开区间
+    rit_->SeekForPrev(limit);
+    if (rit_->Valid() && rit_->key().compare(limit) >= 0) {
+        rit_->Prev();
+    }
 }
 
 Iterator::~Iterator() { delete rit_; }
 
-bool Iterator::Valid() { return rit_->Valid() && (key() < limit_); }
+bool Iterator::Valid() {
+    if (!rit_->Valid()) return false;
+    if (reverse_) return rit_->key().compare(start_) >= 0;
+    return rit_->key().compare(limit_) < 0;
+}
 
-void Iterator::Next() { rit_->Next(); }
+void Iterator::Next() {
+    if (reverse_) {
+        rit_->Prev();
+    } else {
+        rit_->Next();
+    }
+}
 
 Status Iterator::status() {
     if (!rit_->status().ok()) {
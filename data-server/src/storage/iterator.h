@@ -11,8 +11,9 @@ class Metric;
 
 class Iterator {
 public:
+    // reverse为true时从limit之前的最后一个key开始逆序遍历到start
     Iterator(rocksdb::Iterator* it, const std::string& start,
-             const std::string& limit);
+             const std::string& limit, bool reverse = false);
     ~Iterator();
 
     bool Valid();
@@ -27,7 +28,9 @@ public:
 
 private:
     rocksdb::Iterator* rit_ = nullptr;
+    const std::string start_;
     const std::string limit_;
+    const bool reverse_ = false;
 };
 
 } /* namespace storage */
@@ -309,10 +309,31 @@ static Status parseThreshold(const std::string& thres, const metapb::Column& col
     return Status::OK();
 }
 
+// a column without value or with the zero value
+static bool isUnset(const FieldValue* f) {
+    if (nullptr == f) {
+        return true;
+    }
+    switch (f->Type()) {
+        case FieldType::kInt:
+            return f->Int() == 0;
+        case FieldType::kUInt:
+            return f->UInt() == 0;
+        case FieldType::kFloat:
+            return f->Float() == 0;
+        case FieldType::kBytes:
+            return f->Bytes().empty();
+    }
+    return false;
+}
+
 static bool filter(const RowResult& result, const std::vector<kvrpcpb::Match>& filters) {
     for (auto it = filters.cbegin(); it != filters.cend(); ++it) {
         const kvrpcpb::Match& m = *it;
         auto f = result.GetField(m.column().id());
+        if (m.match_unset() && isUnset(f)) {
+            continue;
+        }
         if (nullptr == f) {
             return false;
         }
@@ -14,7 +14,7 @@ static const size_t kIteratorTooManyKeys = 1000;
 RowFetcher::RowFetcher(Store& s, const kvrpcpb::SelectRequest& req)
     : store_(s),
       decoder_(s.GetPrimaryKeys(), req.field_list(), req.where_filters()) {
-    init(req.key(), req.scope());
+    init(req.key(), req.scope(), req.reverse());
 }
 
 RowFetcher::RowFetcher(Store& s, const kvrpcpb::DeleteRequest& req)
@@ -37,12 +37,13 @@ Status RowFetcher::Next(RowResult* result, bool* over) {
     }
 }
 
-void RowFetcher::init(const std::string& key, const ::kvrpcpb::Scope& scope) {
+void RowFetcher::init(const std::string& key, const ::kvrpcpb::Scope& scope,
+                      bool reverse) {
     if (!key.empty()) {
         key_ = key;
         return;
     }
-    iter_ = store_.NewIterator(scope);
+    iter_ = store_.NewIterator(scope, reverse);
 }
 
 Status RowFetcher::nextOneKey(RowResult* result, bool* over) {
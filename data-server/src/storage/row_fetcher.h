@@ -22,7 +22,8 @@ public:
     Status Next(RowResult* result, bool* over);
 
 private:
-    void init(const std::string& key, const ::kvrpcpb::Scope& scope);
+    void init(const std::string& key, const ::kvrpcpb::Scope& scope,
+              bool reverse = false);
     Status nextOneKey(RowResult* result, bool* over);
     Status nextScope(RowResult* result, bool* over);
 
@@ -268,8 +268,8 @@ Status Store::Select(const kvrpcpb::SelectRequest& req,
     }
 }
 
-Status Store::DeleteRows(const kvrpcpb::DeleteRequest& req,
-                         uint64_t* affected) {
+Status Store::DeleteRows(const kvrpcpb::DeleteRequest& req, uint64_t* affected,
+                         std::vector<std::pair<std::string, std::string>>* rows) {
     RowFetcher f(*this, req);
     Status s;
     std::unique_ptr<RowResult> r(new RowResult);
@@ -282,6 +282,15 @@ Status Store::DeleteRows(const kvrpcpb::DeleteRequest& req,
         s = f.Next(r.get(), &over);
         if (s.ok() && !over) {
             assert(!r->Key().empty());
+            if (rows != nullptr) {
+                std::string value;
+                auto gs = db_->Get(rocksdb::ReadOptions(ds_config.rocksdb_config.read_checksum,true), r->Key(), &value);
+                if (!gs.ok()) {
+                    s = Status(Status::kIOError, "get", gs.ToString());
+                    break;
+                }
+                rows->emplace_back(r->Key(), std::move(value));
+            }
             batch.Delete(r->Key());
             ++(*affected);
             bytes_written += r->Key().size();
@@ -329,7 +338,7 @@ std::string Store::GetEndKey() const {
     return end_key_;
 }
 
-Iterator* Store::NewIterator(const kvrpcpb::Scope& scope) {
+Iterator* Store::NewIterator(const kvrpcpb::Scope& scope, bool reverse) {
     auto it = db_->NewIterator(rocksdb::ReadOptions(ds_config.rocksdb_config.read_checksum,true));
     std::string start = scope.start();
     std::string limit = scope.limit();
@@ -343,7 +352,7 @@ Iterator* Store::NewIterator(const kvrpcpb::Scope& scope) {
             limit = end_key_;
         }
     }
-    return new Iterator(it, start, limit);
+    return new Iterator(it, start, limit, reverse);
 }
 
 Iterator* Store::NewIterator(std::string start, std::string limit) {
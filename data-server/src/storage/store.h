@@ -36,7 +36,9 @@ public:
     Status Insert(const kvrpcpb::InsertRequest& req, uint64_t* affected);
     Status Select(const kvrpcpb::SelectRequest& req,
                   kvrpcpb::SelectResponse* resp);
-    Status DeleteRows(const kvrpcpb::DeleteRequest& req, uint64_t* affected);
+    // rows returns the key and value of deleted rows if it is not null
+    Status DeleteRows(const kvrpcpb::DeleteRequest& req, uint64_t* affected,
+                      std::vector<std::pair<std::string, std::string>>* rows = nullptr);
     Status Truncate();
 
     Status WatchPut(const watchpb::KvWatchPutRequest& req, int64_t version);
@@ -60,7 +62,7 @@ public:
             uint64_t *real_size, std::string *split_key);
 
 public:
-    Iterator* NewIterator(const ::kvrpcpb::Scope& scope);
+    Iterator* NewIterator(const ::kvrpcpb::Scope& scope, bool reverse = false);
     Iterator* NewIterator(std::string start = std::string(),
                           std::string limit = std::string());
     Status BatchDelete(const std::vector<std::string>& keys);
@@ -0,0 +1,56 @@
+#include "row_change_buffer.h"
+
+namespace sharkstore {
+namespace dataserver {
+namespace watch {
+
+RowChangeBuffer::RowChangeBuffer(size_t capacity, uint64_t base_index)
+    : capacity_(capacity), base_index_(base_index) {}
+
+void RowChangeBuffer::Append(std::vector<RowChange>&& changes) {
+    std::lock_guard<std::mutex> lock(mu_);
+
+    for (auto& change : changes) {
+        changes_.push_back(std::move(change));
+    }
+    while (changes_.size() > capacity_) {
+        // the rest changes of the evicted index are no longer complete
+        base_index_ = changes_.front().index;
+        changes_.pop_front();
+    }
+}
+
+bool RowChangeBuffer::Load(uint64_t from_index, size_t limit,
+                           std::vector<RowChange>* result) {
+    std::lock_guard<std::mutex> lock(mu_);
+
+    if (from_index < base_index_) {
+        return false;
+    }
+    for (const auto& change : changes_) {
+        if (change.index <= from_index) {
+            continue;
+        }
+        if (result->size() >= limit && result->back().index != change.index) {
+            break;
+        }
+        result->push_back(change);
+    }
+    return true;
+}
+
+void RowChangeBuffer::Reset(uint64_t base_index) {
+    std::lock_guard<std::mutex> lock(mu_);
+
+    base_index_ = base_index;
+    changes_.clear();
+}
+
+uint64_t RowChangeBuffer::BaseIndex() {
+    std::lock_guard<std::mutex> lock(mu_);
+    return base_index_;
+}
+
+}  // namespace watch
+}  // namespace dataserver
+}  // namespace sharkstore
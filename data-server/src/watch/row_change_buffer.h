@@ -0,0 +1,61 @@
+_Pragma("once");
+
+#include <stdint.h>
+#include <deque>
+#include <mutex>
+#include <string>
+#include <vector>
+
+#include "proto/gen/watchpb.pb.h"
+
+namespace sharkstore {
+namespace dataserver {
+namespace watch {
+
+// RowChange is a row written by an insert or delete command,
+// index is the raft index of the command
+struct RowChange {
+    uint64_t index = 0;
+    watchpb::EventType type = watchpb::PUT;
+    std::string key;
+    std::string value;
+    bool has_prev = false;
+    std::string prev_value;
+    int64_t commit_wall_time = 0;
+    int32_t commit_logical = 0;
+};
+
+// RowChangeBuffer keeps the latest row changes of a sql range in apply order,
+// all changes with index greater than base index are in the buffer
+class RowChangeBuffer {
+public:
+    RowChangeBuffer(size_t capacity, uint64_t base_index);
+    ~RowChangeBuffer() = default;
+
+    RowChangeBuffer(const RowChangeBuffer&) = delete;
+    RowChangeBuffer& operator=(const RowChangeBuffer&) = delete;
+
+    // Append adds the changes of one command, so that a load never returns
+    // a part of them
+    void Append(std::vector<RowChange>&& changes);
+
+    // Load returns false if changes after from_index were evicted,
+    // changes of the same index are never split between two loads
+    bool Load(uint64_t from_index, size_t limit, std::vector<RowChange>* result);
+
+    // Reset drops all changes, eg. after applying a snapshot
+    void Reset(uint64_t base_index);
+
+    uint64_t BaseIndex();
+
+private:
+    const size_t capacity_;
+
+    std::mutex mu_;
+    uint64_t base_index_ = 0;
+    std::deque<RowChange> changes_;
+};
+
+}  // namespace watch
+}  // namespace dataserver
+}  // namespace sharkstore
@@ -1,4 +1,5 @@
 #include <gtest/gtest.h>
+#include <chrono>
 
 #include "helper/range_test_fixture.h"
 #include "helper/helper_util.h"
@@ -239,5 +240,143 @@ TEST_F(RangeTestFixture, CURD) {
     }
 }
 
+TEST_F(RangeTestFixture, ConditionalInsert) {
+    SetLeader(GetNodeID());
+
+    kvrpcpb::InsertRequest origin;
+    {
+        DsInsertRequest req;
+        MakeHeader(req.mutable_header());
+        InsertRequestBuilder builder(table_.get());
+        builder.AddRows({{"1", "user1", "111"}, {"2", "user2", "222"}});
+        origin = builder.Build();
+        req.mutable_req()->CopyFrom(origin);
+        DsInsertResponse resp;
+        auto s = TestInsert(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        ASSERT_FALSE(resp.header().has_error()) << resp.header().error().ShortDebugString();
+        ASSERT_EQ(resp.resp().affected_keys(), 2);
+    }
+    // only the row whose current value is the expected one is written
+    {
+        DsInsertRequest req;
+        MakeHeader(req.mutable_header());
+        InsertRequestBuilder builder(table_.get());
+        builder.AddRows({{"1", "user11", "1111"}, {"2", "user22", "2222"}, {"3", "user33", "3333"}});
+        req.mutable_req()->CopyFrom(builder.Build());
+        req.mutable_req()->add_expected_values(origin.rows(0).value());
+        req.mutable_req()->add_expected_values("changed");
+        req.mutable_req()->add_expected_values(origin.rows(1).value());
+        DsInsertResponse resp;
+        auto s = TestInsert(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        ASSERT_FALSE(resp.header().has_error()) << resp.header().error().ShortDebugString();
+        ASSERT_EQ(resp.resp().code(), 0);
+        ASSERT_EQ(resp.resp().affected_keys(), 1);
+    }
+    {
+        DsSelectRequest req;
+        MakeHeader(req.mutable_header());
+        SelectRequestBuilder builder(table_.get());
+        builder.AddAllFields();
+        *req.mutable_req() = builder.Build();
+        DsSelectResponse resp;
+        auto s = TestSelect(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        SelectResultParser parser(req.req(), resp.resp());
+        s = parser.Match({{"1", "user11", "1111"}, {"2", "user2", "222"}});
+        ASSERT_TRUE(s.ok()) << s.ToString();
+    }
+}
+
+TEST_F(RangeTestFixture, MatchUnset) {
+    SetLeader(GetNodeID());
+
+    std::vector<std::vector<std::string>> rows = {
+            {"1", "user1", "111"},
+            {"2", "user2", "222"},
+    };
+    {
+        DsInsertRequest req;
+        MakeHeader(req.mutable_header());
+        InsertRequestBuilder builder(table_.get());
+        builder.AddRows(rows);
+        req.mutable_req()->CopyFrom(builder.Build());
+        DsInsertResponse resp;
+        auto s = TestInsert(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        ASSERT_EQ(resp.resp().affected_keys(), rows.size());
+    }
+    // the rows have no value of the column, only the match with match_unset passes
+    for (bool unset : {false, true}) {
+        DsSelectRequest req;
+        MakeHeader(req.mutable_header());
+        SelectRequestBuilder builder(table_.get());
+        builder.AddAllFields();
+        *req.mutable_req() = builder.Build();
+        auto m = req.mutable_req()->add_where_filters();
+        m->mutable_column()->set_id(1000);
+        m->mutable_column()->set_data_type(metapb::BigInt);
+        m->set_threshold("100");
+        m->set_match_type(kvrpcpb::Larger);
+        m->set_match_unset(unset);
+        DsSelectResponse resp;
+        auto s = TestSelect(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        ASSERT_FALSE(resp.header().has_error()) << resp.header().error().ShortDebugString();
+        SelectResultParser parser(req.req(), resp.resp());
+        if (unset) {
+            s = parser.Match(rows);
+        } else {
+            s = parser.Match({});
+        }
+        ASSERT_TRUE(s.ok()) << s.ToString();
+    }
+}
+
+TEST_F(RangeTestFixture, SnapshotRead) {
+    SetLeader(GetNodeID());
+
+    std::vector<std::vector<std::string>> rows = {
+            {"1", "user1", "111"},
+    };
+    {
+        DsInsertRequest req;
+        MakeHeader(req.mutable_header());
+        InsertRequestBuilder builder(table_.get());
+        builder.AddRows(rows);
+        req.mutable_req()->CopyFrom(builder.Build());
+        DsInsertResponse resp;
+        auto s = TestInsert(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        ASSERT_EQ(resp.resp().affected_keys(), rows.size());
+    }
+    // the insert has no timestamp, so it is taken as written now
+    auto now = std::chrono::duration_cast<std::chrono::nanoseconds>(
+            std::chrono::system_clock::now().time_since_epoch()).count();
+    const int64_t hour = 3600LL * 1000 * 1000 * 1000;
+    for (bool before : {true, false}) {
+        DsSelectRequest req;
+        MakeHeader(req.mutable_header());
+        SelectRequestBuilder builder(table_.get());
+        builder.AddAllFields();
+        *req.mutable_req() = builder.Build();
+        req.mutable_req()->set_snapshot_read(true);
+        req.mutable_req()->mutable_timestamp()->set_wall_time(before ? now - hour : now + hour);
+        DsSelectResponse resp;
+        auto s = TestSelect(req, &resp);
+        ASSERT_TRUE(s.ok()) << s.ToString();
+        ASSERT_FALSE(resp.header().has_error()) << resp.header().error().ShortDebugString();
+        if (before) {
+            ASSERT_EQ(resp.resp().code(), Status::kExpired);
+            ASSERT_EQ(resp.resp().rows_size(), 0);
+        } else {
+            ASSERT_EQ(resp.resp().code(), 0);
+            SelectResultParser parser(req.req(), resp.resp());
+            s = parser.Match(rows);
+            ASSERT_TRUE(s.ok()) << s.ToString();
+        }
+    }
 }
 
+}
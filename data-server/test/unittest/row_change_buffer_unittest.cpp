@@ -0,0 +1,70 @@
+#include <gtest/gtest.h>
+
+#include "watch/row_change_buffer.h"
+
+int main(int argc, char* argv[]) {
+    testing::InitGoogleTest(&argc, argv);
+    return RUN_ALL_TESTS();
+}
+
+namespace {
+
+using namespace sharkstore::dataserver::watch;
+
+std::vector<RowChange> makeChanges(uint64_t index, int count) {
+    std::vector<RowChange> changes(count);
+    for (int i = 0; i < count; ++i) {
+        changes[i].index = index;
+        changes[i].key = std::to_string(index) + "-" + std::to_string(i);
+    }
+    return changes;
+}
+
+TEST(RowChangeBuffer, Load) {
+    RowChangeBuffer buffer(100, 10);
+    buffer.Append(makeChanges(11, 2));
+    buffer.Append(makeChanges(12, 3));
+    buffer.Append(makeChanges(15, 1));
+
+    std::vector<RowChange> result;
+    ASSERT_TRUE(buffer.Load(10, 100, &result));
+    ASSERT_EQ(result.size(), 6U);
+
+    result.clear();
+    ASSERT_TRUE(buffer.Load(11, 100, &result));
+    ASSERT_EQ(result.size(), 4U);
+    ASSERT_EQ(result[0].index, 12U);
+
+    // changes of one index are not split by the limit
+    result.clear();
+    ASSERT_TRUE(buffer.Load(10, 3, &result));
+    ASSERT_EQ(result.size(), 5U);
+    ASSERT_EQ(result.back().index, 12U);
+
+    result.clear();
+    ASSERT_TRUE(buffer.Load(15, 100, &result));
+    ASSERT_TRUE(result.empty());
+
+    result.clear();
+    ASSERT_FALSE(buffer.Load(9, 100, &result));
+}
+
+TEST(RowChangeBuffer, Evict) {
+    RowChangeBuffer buffer(3, 0);
+    buffer.Append(makeChanges(1, 2));
+    buffer.Append(makeChanges(2, 2));
+    ASSERT_EQ(buffer.BaseIndex(), 1U);
+
+    std::vector<RowChange> result;
+    ASSERT_FALSE(buffer.Load(0, 100, &result));
+    ASSERT_TRUE(buffer.Load(1, 100, &result));
+    ASSERT_EQ(result.size(), 2U);
+
+    buffer.Reset(20);
+    result.clear();
+    ASSERT_FALSE(buffer.Load(2, 100, &result));
+    ASSERT_TRUE(buffer.Load(20, 100, &result));
+    ASSERT_TRUE(result.empty());
+}
+
+} /* namespace  */
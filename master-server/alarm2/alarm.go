@@ -29,10 +29,7 @@ func (s *Server) timingDbPulling() {
 	for {
 		select {
 		case <-t.C:
-			s.tableAppPulling()
-			s.tableGlobalRulePulling()
-			s.tableClusterRulePulling()
-			s.tableReceiverPulling()
+			s.tablePulling()
 
 			t.Reset(duration)
 		case <-ctx.Done():
@@ -41,6 +38,13 @@ func (s *Server) timingDbPulling() {
 	}
 }
 
+func (s *Server) tablePulling() {
+	s.tableAppPulling()
+	s.tableGlobalRulePulling()
+	s.tableClusterRulePulling()
+	s.tableReceiverPulling()
+}
+
 func (s *Server) tableAppPulling() {
 	ret, err := s.dbOpImpl.getTableAppData()
 	if err != nil {
@@ -81,10 +85,19 @@ func (s *Server) tableReceiverPulling() {
 	s.updateMapReceiver(ret)
 }
 
+// isMysqlStore returns true when the tables are pulled from mysql, an empty
+// pull there is rather a broken table than no data, so the cache is kept.
+// The bolt store is local and may really be empty after a delete.
+func (s *Server) isMysqlStore() bool {
+	return s.conf.StoreType == STORETYPE_MYSQL || s.conf.StoreType == ""
+}
+
 func (s *Server) updateMapApp(data []TableApp) {
 	if len(data) == 0 {
 		log.Warn("no data in table app")
-		return
+		if s.isMysqlStore() {
+			return
+		}
 	}
 
 	newMap := make(appClusterMap)
@@ -112,7 +125,9 @@ func (s *Server) getMapApp() (ret appClusterMap) {
 func (s *Server) updateMapGlobalRule(data []TableGlobalRule) {
 	if len(data) == 0 {
 		log.Warn("no data in table global rule")
-		return
+		if s.isMysqlStore() {
+			return
+		}
 	}
 
 	newMap := make(globalRuleMap)
@@ -136,7 +151,9 @@ func (s *Server) getMapGlobalRule() (ret globalRuleMap) {
 func (s *Server) updateMapClusterRule(data []TableClusterRule) {
 	if len(data) == 0 {
 		log.Warn("no data in table cluster rule")
-		return
+		if s.isMysqlStore() {
+			return
+		}
 	}
 
 	newMap := make(ruleClusterMap)
@@ -148,8 +165,8 @@ func (s *Server) updateMapClusterRule(data []TableClusterRule) {
 		dd[d.Name] = d
 	}
 
-	s.globalRuleLock.Lock()
-	defer s.globalRuleLock.Unlock()
+	s.clusterRuleLock.Lock()
+	defer s.clusterRuleLock.Unlock()
 	s.clusterRule = newMap
 }
 
@@ -164,7 +181,9 @@ func (s *Server) getMapClusterRule() (ret ruleClusterMap) {
 func (s *Server) updateMapReceiver(data []TableReceiver) {
 	if len(data) == 0 {
 		log.Warn("no data in table receiver")
-		return
+		if s.isMysqlStore() {
+			return
+		}
 	}
 
 	newMap := make(receiverClusterMap)
@@ -176,8 +195,8 @@ func (s *Server) updateMapReceiver(data []TableReceiver) {
 		dd[d.Erp] = d
 	}
 
-	s.globalRuleLock.Lock()
-	defer s.globalRuleLock.Unlock()
+	s.receiverLock.Lock()
+	defer s.receiverLock.Unlock()
 	s.clusterReceiver = newMap
 }
 
@@ -220,4 +220,23 @@ func TestHandle(t *testing.T) {
 	}
 	fmt.Printf("receiver mail: %v, sms: %v", mail, sms)
 
-}
\ No newline at end of file
+}
+func TestUpdateMapEmpty(t *testing.T) {
+	receivers := []TableReceiver{{Erp: "erp1", ClusterId: 1}}
+
+	// an empty pull from mysql keeps the cached receivers
+	s := &Server{conf: &Alarm2ServerConfig{StoreType: STORETYPE_MYSQL}}
+	s.updateMapReceiver(receivers)
+	s.updateMapReceiver(nil)
+	if len(s.getMapReceiver()) != 1 {
+		t.Fatalf("mysql receivers dropped on empty pull: %v", s.getMapReceiver())
+	}
+
+	// the bolt store is local, all receivers may be deleted
+	s = &Server{conf: &Alarm2ServerConfig{StoreType: STORETYPE_BOLT}}
+	s.updateMapReceiver(receivers)
+	s.updateMapReceiver(nil)
+	if len(s.getMapReceiver()) != 0 {
+		t.Fatalf("bolt receivers kept on empty pull: %v", s.getMapReceiver())
+	}
+}
@@ -0,0 +1,269 @@
+package alarm2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"util/log"
+)
+
+const DefaultResolveSec = 600
+
+var ErrAlertNotExist = errors.New("alert not exist")
+
+// Alert is an alarm reported to the notifiers, the alarms of the same rule,
+// app and address are one alert until it is resolved.
+type Alert struct {
+	Id         string   `json:"id"`
+	ClusterId  int64    `json:"cluster_id"`
+	AppName    string   `json:"app_name"`
+	IpAddr     string   `json:"ip_addr"`
+	RuleName   string   `json:"rule_name"`
+	AlarmValue float64  `json:"alarm_value"`
+	Threshold  float64  `json:"threshold"`
+	Remark     []string `json:"remark"`
+	// times of the alarm reported
+	Count      int64  `json:"count"`
+	FirstTime  int64  `json:"first_time"`
+	LastTime   int64  `json:"last_time"`
+	NotifyTime int64  `json:"notify_time"`
+	Level      int    `json:"level"`
+	Acked      bool   `json:"acked"`
+	AckedBy    string `json:"acked_by"`
+
+	msg alarmMessage
+	// waiting in a group to be notified
+	pending bool
+}
+
+type alertGroup struct {
+	clusterId int64
+	ruleName  string
+	start     int64
+	alerts    []*Alert
+}
+
+func alertId(msg alarmMessage) string {
+	id, err := encodeCacheKey(cacheKey{msg.GetRuleName(), msg.appName, msg.clusterId, msg.ipAddr})
+	if err != nil {
+		id = fmt.Sprintf("%s%s%v%s%v%s%v", msg.GetRuleName(),
+			APPNAME_JOIN_LETTER, msg.appName,
+			APPNAME_JOIN_LETTER, msg.clusterId,
+			APPNAME_JOIN_LETTER, msg.ipAddr)
+	}
+	return id
+}
+
+func alertGroupKey(clusterId int64, ruleName string) string {
+	return fmt.Sprintf("%v%s%v", clusterId, APPNAME_JOIN_LETTER, ruleName)
+}
+
+func (s *Server) ruleAlarmReportCron() {
+	ctx, cancel := context.WithCancel(s.context)
+	defer cancel()
+
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case msg := <-s.reportQueue:
+			s.receiveAlarm(msg, time.Now().Unix())
+		case now := <-t.C:
+			s.checkAlerts(now.Unix())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Server) checkAlerts(now int64) {
+	s.flushAlertGroups(now)
+	s.escalateAlerts(now)
+	s.resolveAlerts(now)
+	s.expireSilences(now)
+}
+
+// receiveAlarm adds the alarm to its group, the alarm is dropped if it is
+// silenced or its alert is notified in the dedup window.
+func (s *Server) receiveAlarm(msg alarmMessage, now int64) {
+	id := alertId(msg)
+	if si := s.findSilence(msg.clusterId, msg.appName, msg.ipAddr, msg.GetRuleName(), now); si != nil {
+		log.Info("alarm %v is silenced by silence[%v]", id, si.Id)
+		return
+	}
+
+	s.alertLock.Lock()
+	defer s.alertLock.Unlock()
+	a, find := s.alerts[id]
+	if !find {
+		a = &Alert{
+			Id:        id,
+			ClusterId: msg.clusterId,
+			AppName:   msg.appName,
+			IpAddr:    msg.ipAddr,
+			RuleName:  msg.GetRuleName(),
+			FirstTime: now,
+		}
+		s.alerts[id] = a
+	}
+	a.AlarmValue = msg.GetAlarmValue()
+	a.Threshold = msg.ruleThreshold
+	a.Remark = msg.GetRemark()
+	a.Count++
+	a.LastTime = now
+	a.msg = msg
+
+	if a.pending {
+		return
+	}
+	if a.NotifyTime != 0 && now-a.NotifyTime < int64(s.conf.DedupWindowSec) {
+		log.Debug("alarm %v is notified at %v, dedup", id, a.NotifyTime)
+		return
+	}
+	// notified again as a new alert after the dedup window
+	a.Level = 0
+	a.Acked = false
+	a.AckedBy = ""
+	a.pending = true
+
+	key := alertGroupKey(a.ClusterId, a.RuleName)
+	g, find := s.alertGroups[key]
+	if !find {
+		g = &alertGroup{clusterId: a.ClusterId, ruleName: a.RuleName, start: now}
+		s.alertGroups[key] = g
+	}
+	g.alerts = append(g.alerts, a)
+}
+
+func copyAlerts(alerts []*Alert) []*Alert {
+	ret := make([]*Alert, 0, len(alerts))
+	for _, a := range alerts {
+		c := *a
+		ret = append(ret, &c)
+	}
+	return ret
+}
+
+func (s *Server) flushAlertGroups(now int64) {
+	var groups []*alertGroup
+	s.alertLock.Lock()
+	for key, g := range s.alertGroups {
+		if now-g.start < int64(s.conf.GroupWaitSec) {
+			continue
+		}
+		for _, a := range g.alerts {
+			a.pending = false
+			a.NotifyTime = now
+		}
+		groups = append(groups, &alertGroup{
+			clusterId: g.clusterId,
+			ruleName:  g.ruleName,
+			alerts:    copyAlerts(g.alerts),
+		})
+		delete(s.alertGroups, key)
+	}
+	s.alertLock.Unlock()
+
+	for _, g := range groups {
+		s.notify(g.clusterId, g.ruleName, 0, g.alerts)
+	}
+}
+
+// escalateAlerts notifies the alerts not acknowledged in the escalation
+// time again, with the escalation notifiers.
+func (s *Server) escalateAlerts(now int64) {
+	if s.conf.EscalationSec <= 0 {
+		return
+	}
+	var alerts []*Alert
+	s.alertLock.Lock()
+	for _, a := range s.alerts {
+		if a.Acked || a.pending || a.NotifyTime == 0 || now-a.NotifyTime < int64(s.conf.EscalationSec) {
+			continue
+		}
+		if si := s.findSilence(a.ClusterId, a.AppName, a.IpAddr, a.RuleName, now); si != nil {
+			continue
+		}
+		a.Level++
+		a.NotifyTime = now
+		alerts = append(alerts, a)
+	}
+	alerts = copyAlerts(alerts)
+	s.alertLock.Unlock()
+
+	for _, a := range alerts {
+		log.Warn("alert %v is not acknowledged, escalate to level %v", a.Id, a.Level)
+		s.notify(a.ClusterId, a.RuleName, a.Level, []*Alert{a})
+	}
+}
+
+func (s *Server) resolveAlerts(now int64) {
+	resolve := int64(s.conf.ResolveSec)
+	if resolve <= 0 {
+		resolve = DefaultResolveSec
+	}
+	s.alertLock.Lock()
+	defer s.alertLock.Unlock()
+	for id, a := range s.alerts {
+		if !a.pending && now-a.LastTime >= resolve {
+			log.Info("alert %v is resolved", id)
+			delete(s.alerts, id)
+		}
+	}
+}
+
+func (s *Server) notify(clusterId int64, ruleName string, level int, alerts []*Alert) {
+	title := "SHARKSTORE ALARM " + ruleName
+	if level > 0 {
+		title = fmt.Sprintf("SHARKSTORE ALARM ESCALATION[%v] %v", level, ruleName)
+	}
+	n := &Notification{
+		Title:     title,
+		ClusterId: clusterId,
+		RuleName:  ruleName,
+		Level:     level,
+		Alerts:    alerts,
+	}
+	mails, tels, err := s.getReportReceivers(clusterId)
+	if err != nil {
+		log.Warn("alarm report: %v", err)
+	}
+	n.Mails, n.Tels = mails, tels
+
+	for _, notifier := range s.getNotifiers(level > 0) {
+		if err := notifier.Notify(n); err != nil {
+			log.Error("alarm notifier[%v] notify %v error: %v", notifier.Name(), title, err)
+		}
+	}
+}
+
+func (s *Server) getAlerts() []*Alert {
+	s.alertLock.Lock()
+	defer s.alertLock.Unlock()
+	var alerts []*Alert
+	for _, a := range s.alerts {
+		alerts = append(alerts, a)
+	}
+	alerts = copyAlerts(alerts)
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Id < alerts[j].Id
+	})
+	return alerts
+}
+
+// ackAlert stops the escalation of the alert.
+func (s *Server) ackAlert(id, operator string) error {
+	s.alertLock.Lock()
+	defer s.alertLock.Unlock()
+	a, find := s.alerts[id]
+	if !find {
+		return ErrAlertNotExist
+	}
+	a.Acked = true
+	a.AckedBy = operator
+	log.Info("alert %v is acknowledged by %v", id, operator)
+	return nil
+}
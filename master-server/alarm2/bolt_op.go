@@ -0,0 +1,196 @@
+package alarm2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+var (
+	BUCKET_APP          = []byte(TABLENAME_APP)
+	BUCKET_GLOBAL_RULE  = []byte(TABLENAME_GLOBAL_RULE)
+	BUCKET_CLUSTER_RULE = []byte(TABLENAME_CLUSTER_RULE)
+	BUCKET_RECEIVER     = []byte(TABLENAME_RECEIVER)
+	BUCKET_CACHE        = []byte("sharkstore_alarm_cache")
+)
+
+var errCacheKeyNotExist = errors.New("cache key not exists")
+
+// boltOpImpl keeps the tables and the cache in a bolt file, so the alarm
+// server runs without mysql and jim. the rows are json values keyed by the
+// primary keys of the tables.
+type boltOpImpl struct {
+	db *bolt.DB
+}
+
+type boltCacheValue struct {
+	Value string `json:"value"`
+	// unix seconds, 0 never expires
+	ExpireAt int64 `json:"expire_at"`
+}
+
+func newBoltOpImpl(path string) (*boltOpImpl, error) {
+	db, err := bolt.Open(path, 0664, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{BUCKET_APP, BUCKET_GLOBAL_RULE, BUCKET_CLUSTER_RULE, BUCKET_RECEIVER, BUCKET_CACHE} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return fmt.Errorf("create bucket: %s", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltOpImpl{db: db}, nil
+}
+
+func clusterKey(clusterId int64, name string) []byte {
+	return []byte(fmt.Sprintf("%v%s%v", clusterId, APPNAME_JOIN_LETTER, name))
+}
+
+func (opImpl *boltOpImpl) put(bucket, key []byte, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return opImpl.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, value)
+	})
+}
+
+func (opImpl *boltOpImpl) delete(bucket, key []byte) error {
+	return opImpl.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete(key)
+	})
+}
+
+// scan calls decode with every value of the bucket in the order of keys.
+func (opImpl *boltOpImpl) scan(bucket []byte, decode func(value []byte) error) error {
+	return opImpl.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(k, v []byte) error {
+			return decode(v)
+		})
+	})
+}
+
+func (opImpl *boltOpImpl) getTableAppData() (ret []TableApp, err error) {
+	err = opImpl.scan(BUCKET_APP, func(v []byte) error {
+		var app TableApp
+		if err := json.Unmarshal(v, &app); err != nil {
+			return err
+		}
+		ret = append(ret, app)
+		return nil
+	})
+	return
+}
+
+func (opImpl *boltOpImpl) getTableGlobalRuleData() (ret []TableGlobalRule, err error) {
+	err = opImpl.scan(BUCKET_GLOBAL_RULE, func(v []byte) error {
+		var rule TableGlobalRule
+		if err := json.Unmarshal(v, &rule); err != nil {
+			return err
+		}
+		ret = append(ret, rule)
+		return nil
+	})
+	return
+}
+
+func (opImpl *boltOpImpl) getTableClusterRuleData() (ret []TableClusterRule, err error) {
+	err = opImpl.scan(BUCKET_CLUSTER_RULE, func(v []byte) error {
+		var rule TableClusterRule
+		if err := json.Unmarshal(v, &rule); err != nil {
+			return err
+		}
+		ret = append(ret, rule)
+		return nil
+	})
+	return
+}
+
+func (opImpl *boltOpImpl) getTableReceiveData() (ret []TableReceiver, err error) {
+	err = opImpl.scan(BUCKET_RECEIVER, func(v []byte) error {
+		var receiver TableReceiver
+		if err := json.Unmarshal(v, &receiver); err != nil {
+			return err
+		}
+		ret = append(ret, receiver)
+		return nil
+	})
+	return
+}
+
+func (opImpl *boltOpImpl) setTableApp(app TableApp) error {
+	return opImpl.put(BUCKET_APP, clusterKey(app.ClusterId, app.IpAddr), app)
+}
+
+func (opImpl *boltOpImpl) deleteTableApp(clusterId int64, ipAddr string) error {
+	return opImpl.delete(BUCKET_APP, clusterKey(clusterId, ipAddr))
+}
+
+func (opImpl *boltOpImpl) setTableGlobalRule(rule TableGlobalRule) error {
+	return opImpl.put(BUCKET_GLOBAL_RULE, []byte(rule.Name), rule)
+}
+
+func (opImpl *boltOpImpl) deleteTableGlobalRule(name string) error {
+	return opImpl.delete(BUCKET_GLOBAL_RULE, []byte(name))
+}
+
+func (opImpl *boltOpImpl) setTableClusterRule(rule TableClusterRule) error {
+	return opImpl.put(BUCKET_CLUSTER_RULE, clusterKey(rule.ClusterId, rule.Name), rule)
+}
+
+func (opImpl *boltOpImpl) deleteTableClusterRule(clusterId int64, name string) error {
+	return opImpl.delete(BUCKET_CLUSTER_RULE, clusterKey(clusterId, name))
+}
+
+func (opImpl *boltOpImpl) setTableReceiver(receiver TableReceiver) error {
+	return opImpl.put(BUCKET_RECEIVER, clusterKey(receiver.ClusterId, receiver.Erp), receiver)
+}
+
+func (opImpl *boltOpImpl) deleteTableReceiver(clusterId int64, erp string) error {
+	return opImpl.delete(BUCKET_RECEIVER, clusterKey(clusterId, erp))
+}
+
+// get returns an error if the key doesn't exist or is expired like the jim
+// cache, an expired key is deleted when it is read.
+func (opImpl *boltOpImpl) get(key string) (string, error) {
+	var value boltCacheValue
+	err := opImpl.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(BUCKET_CACHE).Get([]byte(key))
+		if v == nil {
+			return errCacheKeyNotExist
+		}
+		return json.Unmarshal(v, &value)
+	})
+	if err != nil {
+		return "", err
+	}
+	if value.ExpireAt != 0 && value.ExpireAt <= time.Now().Unix() {
+		opImpl.delete(BUCKET_CACHE, []byte(key))
+		return "", errCacheKeyNotExist
+	}
+	return value.Value, nil
+}
+
+func (opImpl *boltOpImpl) setex(key, value string, expireTime int64) error {
+	v := boltCacheValue{Value: value}
+	if expireTime > 0 {
+		v.ExpireAt = time.Now().Unix() + expireTime
+	}
+	return opImpl.put(BUCKET_CACHE, []byte(key), v)
+}
+
+func (opImpl *boltOpImpl) exists(key string) error {
+	_, err := opImpl.get(key)
+	return err
+}
@@ -0,0 +1,71 @@
+package alarm2
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestBoltOpImpl(t *testing.T) (*boltOpImpl, func()) {
+	dir, err := ioutil.TempDir("", "alarm2_bolt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := newBoltOpImpl(filepath.Join(dir, "alarm.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return store, func() {
+		store.db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestBoltOpTables(t *testing.T) {
+	store, clean := newTestBoltOpImpl(t)
+	defer clean()
+
+	rule := TableClusterRule{ClusterId: 1, Rule: Rule{Name: ALARMRULE_RANGE_NO_HEARTBEAT, Threshold: 1, Enable: 1}}
+	if err := store.setTableClusterRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	rule.Rule.Threshold = 2
+	if err := store.setTableClusterRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := store.getTableClusterRuleData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Threshold != 2 {
+		t.Fatalf("unexpected cluster rules %v", rules)
+	}
+
+	if err := store.deleteTableClusterRule(1, ALARMRULE_RANGE_NO_HEARTBEAT); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _ = store.getTableClusterRuleData(); len(rules) != 0 {
+		t.Fatalf("cluster rule not deleted %v", rules)
+	}
+}
+
+func TestBoltOpCache(t *testing.T) {
+	store, clean := newTestBoltOpImpl(t)
+	defer clean()
+
+	if err := store.exists("k"); err == nil {
+		t.Fatal("expect key not exists")
+	}
+	if err := store.setex("k", "v", 0); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := store.get("k"); err != nil || v != "v" {
+		t.Fatalf("get k: %v %v", v, err)
+	}
+	store.put(BUCKET_CACHE, []byte("k"), boltCacheValue{Value: "v", ExpireAt: 1})
+	if err := store.exists("k"); err == nil {
+		t.Fatal("expect key expired")
+	}
+}
@@ -9,6 +9,10 @@ type Alarm2ClientConfig struct {
 type Alarm2ServerConfig struct {
 	ServerPort int   		`toml:"server-port" json:"server-port"`
 
+	// mysql with jim, or bolt which keeps the rules, receivers and cache in a local file
+	StoreType string 		`toml:"store-type" json:"store-type"`
+	BoltPath string 		`toml:"bolt-path" json:"bolt-path"`
+
 	MysqlArgs string		`toml:"mysql-args" json:"mysql-args"`
 	MysqlPullingDurationSec time.Duration		`toml:"mysql-pulling-duration-sec" json:"mysql-pulling-duration-sec"`
 	AppAliveCheckingDurationSec time.Duration	`toml:"app-alive-checking-duration-sec" json:"app-alive-checking-duration-sec"`
@@ -20,5 +24,31 @@ type Alarm2ServerConfig struct {
 	JimWriteTimeoutSec time.Duration	`toml:"jim-write-timeout-sec" json:"jim-write-timeout-sec"`
 	JimReadTimeoutSec time.Duration		`toml:"jim-read-timeout-sec" json:"jim-read-timeout-sec"`
 
+	// the http api of the silences and alerts, disabled when it is 0
+	HttpPort int 						`toml:"http-port" json:"http-port"`
+	// the alarm gateway is used when no notifier is configured
+	Notifiers []NotifierConfig 			`toml:"notifier" json:"notifier"`
+	// alarms of a cluster and rule fired in the wait are sent in one notification
+	GroupWaitSec time.Duration 			`toml:"group-wait-sec" json:"group-wait-sec"`
+	// an alarm is not notified again in the window after it is notified
+	DedupWindowSec time.Duration 		`toml:"dedup-window-sec" json:"dedup-window-sec"`
+	// an alarm not acknowledged in the time is notified to the escalation notifiers, 0 is disabled
+	EscalationSec time.Duration 		`toml:"escalation-sec" json:"escalation-sec"`
+	// an alarm not fired in the time is resolved
+	ResolveSec time.Duration 			`toml:"resolve-sec" json:"resolve-sec"`
+}
+
+type NotifierConfig struct {
+	// gateway, webhook, chat or email
+	Type string 			`toml:"type" json:"type"`
+	// the url of the webhook, chat webhook or alarm gateway
+	Url string 				`toml:"url" json:"url"`
+
+	SmtpAddr string 		`toml:"smtp-addr" json:"smtp-addr"`
+	SmtpUser string 		`toml:"smtp-user" json:"smtp-user"`
+	SmtpPassword string 	`toml:"smtp-password" json:"smtp-password"`
+	MailFrom string 		`toml:"mail-from" json:"mail-from"`
 
+	// only notified when an alarm is escalated
+	Escalation bool 		`toml:"escalation" json:"escalation"`
 }
\ No newline at end of file
@@ -12,6 +12,11 @@ const (
 	TABLESCHEMA_RECEIVER 			= "erp, cluster_id, role, mail, tel" // erp, cluster_id pk
 )
 
+const (
+	STORETYPE_MYSQL 	= "mysql"
+	STORETYPE_BOLT 		= "bolt"
+)
+
 const (
 	APPNAME_GATEWAY 		= "gateway"
 	APPNAME_MASTER 			= "master"
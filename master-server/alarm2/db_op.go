@@ -27,6 +27,19 @@ type dbOp interface {
 	getTableReceiveData() (ret []TableReceiver, err error)
 }
 
+// dbWriteOp is implemented by the stores which the rules, receivers and
+// apps can be changed by the http api.
+type dbWriteOp interface {
+	setTableApp(app TableApp) error
+	deleteTableApp(clusterId int64, ipAddr string) error
+	setTableGlobalRule(rule TableGlobalRule) error
+	deleteTableGlobalRule(name string) error
+	setTableClusterRule(rule TableClusterRule) error
+	deleteTableClusterRule(clusterId int64, name string) error
+	setTableReceiver(receiver TableReceiver) error
+	deleteTableReceiver(clusterId int64, erp string) error
+}
+
 type dbOpImpl struct {
 	db *sql.DB
 }
@@ -221,3 +234,46 @@ func (opImpl *dbOpImpl) getTableReceiveData() (ret []TableReceiver, err error) {
 
 	return
 }
+
+func (opImpl *dbOpImpl) exec(query string, args ...interface{}) error {
+	_, err := opImpl.db.Exec(query, args...)
+	return err
+}
+
+func (opImpl *dbOpImpl) setTableApp(app TableApp) error {
+	return opImpl.exec("replace into " + TABLENAME_APP + "(" + TABLESCHEMA_APP + ") values(?, ?, ?)",
+		app.ClusterId, app.IpAddr, app.ProcessName)
+}
+
+func (opImpl *dbOpImpl) deleteTableApp(clusterId int64, ipAddr string) error {
+	return opImpl.exec("delete from " + TABLENAME_APP + " where cluster_id = ? and ip_addr = ?", clusterId, ipAddr)
+}
+
+func (opImpl *dbOpImpl) setTableGlobalRule(rule TableGlobalRule) error {
+	r := rule.Rule
+	return opImpl.exec("replace into " + TABLENAME_GLOBAL_RULE + "(" + TABLESCHEMA_GLOBAL_RULE + ") values(?, ?, ?, ?, ?, ?, ?)",
+		r.Name, r.Threshold, r.Durable, r.Count, r.Interval, r.ReceiverRole, r.Enable)
+}
+
+func (opImpl *dbOpImpl) deleteTableGlobalRule(name string) error {
+	return opImpl.exec("delete from " + TABLENAME_GLOBAL_RULE + " where name = ?", name)
+}
+
+func (opImpl *dbOpImpl) setTableClusterRule(rule TableClusterRule) error {
+	r := rule.Rule
+	return opImpl.exec("replace into " + TABLENAME_CLUSTER_RULE + "(" + TABLESCHEMA_CLUSTER_RULE + ") values(?, ?, ?, ?, ?, ?, ?, ?)",
+		rule.ClusterId, r.Name, r.Threshold, r.Durable, r.Count, r.Interval, r.ReceiverRole, r.Enable)
+}
+
+func (opImpl *dbOpImpl) deleteTableClusterRule(clusterId int64, name string) error {
+	return opImpl.exec("delete from " + TABLENAME_CLUSTER_RULE + " where cluster_id = ? and rule_name = ?", clusterId, name)
+}
+
+func (opImpl *dbOpImpl) setTableReceiver(receiver TableReceiver) error {
+	return opImpl.exec("replace into " + TABLENAME_RECEIVER + "(" + TABLESCHEMA_RECEIVER + ") values(?, ?, ?, ?, ?)",
+		receiver.Erp, receiver.ClusterId, receiver.Role, receiver.Mail, receiver.Tel)
+}
+
+func (opImpl *dbOpImpl) deleteTableReceiver(clusterId int64, erp string) error {
+	return opImpl.exec("delete from " + TABLENAME_RECEIVER + " where erp = ? and cluster_id = ?", erp, clusterId)
+}
@@ -0,0 +1,345 @@
+package alarm2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"util/log"
+)
+
+const (
+	HTTP_OK = iota
+	HTTP_ERROR
+	HTTP_ERROR_PARAMETER
+)
+
+type httpReply struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+func sendReply(w http.ResponseWriter, r *httpReply) {
+	reply, err := json.Marshal(r)
+	if err != nil {
+		log.Error("http reply marshal error: %s", err)
+		w.WriteHeader(500)
+	}
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(reply)))
+	if _, err := w.Write(reply); err != nil {
+		log.Error("http reply[%s] len[%d] write error: %v", string(reply), len(reply), err)
+	}
+}
+
+func (s *Server) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/alarm/silence/create", s.handleSilenceCreate)
+	mux.HandleFunc("/alarm/silence/delete", s.handleSilenceDelete)
+	mux.HandleFunc("/alarm/silence/list", s.handleSilenceList)
+	mux.HandleFunc("/alarm/alert/list", s.handleAlertList)
+	mux.HandleFunc("/alarm/alert/ack", s.handleAlertAck)
+	mux.HandleFunc("/alarm/app/list", s.handleAppList)
+	mux.HandleFunc("/alarm/app/set", s.handleAppSet)
+	mux.HandleFunc("/alarm/app/delete", s.handleAppDelete)
+	mux.HandleFunc("/alarm/rule/global/list", s.handleGlobalRuleList)
+	mux.HandleFunc("/alarm/rule/global/set", s.handleGlobalRuleSet)
+	mux.HandleFunc("/alarm/rule/global/delete", s.handleGlobalRuleDelete)
+	mux.HandleFunc("/alarm/rule/cluster/list", s.handleClusterRuleList)
+	mux.HandleFunc("/alarm/rule/cluster/set", s.handleClusterRuleSet)
+	mux.HandleFunc("/alarm/rule/cluster/delete", s.handleClusterRuleDelete)
+	mux.HandleFunc("/alarm/receiver/list", s.handleReceiverList)
+	mux.HandleFunc("/alarm/receiver/set", s.handleReceiverSet)
+	mux.HandleFunc("/alarm/receiver/delete", s.handleReceiverDelete)
+	return mux
+}
+
+func formInt(r *http.Request, name string) (int64, error) {
+	v := r.FormValue(name)
+	if len(v) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// handleSilenceCreate creates a silence from start_time to end_time in unix
+// seconds, the start time is now by default and a duration in seconds can be
+// given instead of the end time.
+func (s *Server) handleSilenceCreate(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	si := &Silence{
+		AppName:  r.FormValue("app_name"),
+		IpAddr:   r.FormValue("ip_addr"),
+		RuleName: r.FormValue("rule_name"),
+		Creator:  r.FormValue("creator"),
+		Comment:  r.FormValue("comment"),
+	}
+	var duration int64
+	var err error
+	for name, v := range map[string]*int64{
+		"cluster_id": &si.ClusterId,
+		"start_time": &si.StartTime,
+		"end_time":   &si.EndTime,
+		"duration":   &duration,
+	} {
+		if *v, err = formInt(r, name); err != nil {
+			reply.Code = HTTP_ERROR_PARAMETER
+			reply.Message = "invalid " + name
+			return
+		}
+	}
+	if si.StartTime == 0 {
+		si.StartTime = time.Now().Unix()
+	}
+	if si.EndTime == 0 {
+		si.EndTime = si.StartTime + duration
+	}
+	if err := s.addSilence(si); err != nil {
+		reply.Code = HTTP_ERROR_PARAMETER
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = si
+}
+
+func (s *Server) handleSilenceDelete(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	id, err := formInt(r, "id")
+	if err != nil {
+		reply.Code = HTTP_ERROR_PARAMETER
+		reply.Message = "invalid id"
+		return
+	}
+	if err := s.deleteSilence(id); err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+}
+
+func (s *Server) handleSilenceList(w http.ResponseWriter, r *http.Request) {
+	sendReply(w, &httpReply{Data: s.getSilences()})
+}
+
+func (s *Server) handleAlertList(w http.ResponseWriter, r *http.Request) {
+	sendReply(w, &httpReply{Data: s.getAlerts()})
+}
+
+func (s *Server) handleAlertAck(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	id := r.FormValue("id")
+	if len(id) == 0 {
+		reply.Code = HTTP_ERROR_PARAMETER
+		reply.Message = "id is required"
+		return
+	}
+	if err := s.ackAlert(id, r.FormValue("operator")); err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+}
+
+// replyList replies the rows read from the store.
+func replyList(w http.ResponseWriter, data interface{}, err error) {
+	reply := &httpReply{Data: data}
+	if err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+	}
+	sendReply(w, reply)
+}
+
+// writeTable changes the store and reloads the table by the pulling
+// function, the store must implement dbWriteOp.
+func (s *Server) writeTable(w http.ResponseWriter, write func(op dbWriteOp) error, pulling func()) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	op, ok := s.dbOpImpl.(dbWriteOp)
+	if !ok {
+		reply.Code = HTTP_ERROR
+		reply.Message = "alarm store is read only"
+		return
+	}
+	if err := write(op); err != nil {
+		log.Error("write alarm store error: %v", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	pulling()
+}
+
+func paramError(w http.ResponseWriter, msg string) {
+	sendReply(w, &httpReply{Code: HTTP_ERROR_PARAMETER, Message: msg})
+}
+
+// formClusterKey parses the cluster id and the required name of a row.
+func formClusterKey(r *http.Request, name string) (int64, string, error) {
+	clusterId, err := formInt(r, "cluster_id")
+	if err != nil {
+		return 0, "", errors.New("invalid cluster_id")
+	}
+	v := r.FormValue(name)
+	if len(v) == 0 {
+		return 0, "", errors.New(name + " is required")
+	}
+	return clusterId, v, nil
+}
+
+func formRule(r *http.Request) (rule Rule, err error) {
+	rule.Name = r.FormValue("name")
+	rule.ReceiverRole = r.FormValue("receiver_role")
+	if len(rule.Name) == 0 {
+		return rule, errors.New("name is required")
+	}
+	if v := r.FormValue("threshold"); len(v) != 0 {
+		if rule.Threshold, err = strconv.ParseFloat(v, 64); err != nil {
+			return rule, errors.New("invalid threshold")
+		}
+	}
+	for name, v := range map[string]*int64{
+		"durable":  &rule.Durable,
+		"count":    &rule.Count,
+		"interval": &rule.Interval,
+		"enable":   &rule.Enable,
+	} {
+		if *v, err = formInt(r, name); err != nil {
+			return rule, errors.New("invalid " + name)
+		}
+	}
+	return rule, nil
+}
+
+func (s *Server) handleAppList(w http.ResponseWriter, r *http.Request) {
+	data, err := s.dbOpImpl.getTableAppData()
+	replyList(w, data, err)
+}
+
+func (s *Server) handleAppSet(w http.ResponseWriter, r *http.Request) {
+	clusterId, ipAddr, err := formClusterKey(r, "ip_addr")
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	app := TableApp{ClusterId: clusterId, IpAddr: ipAddr, ProcessName: r.FormValue("process_name")}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.setTableApp(app)
+	}, s.tableAppPulling)
+}
+
+func (s *Server) handleAppDelete(w http.ResponseWriter, r *http.Request) {
+	clusterId, ipAddr, err := formClusterKey(r, "ip_addr")
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.deleteTableApp(clusterId, ipAddr)
+	}, s.tableAppPulling)
+}
+
+func (s *Server) handleGlobalRuleList(w http.ResponseWriter, r *http.Request) {
+	data, err := s.dbOpImpl.getTableGlobalRuleData()
+	replyList(w, data, err)
+}
+
+func (s *Server) handleGlobalRuleSet(w http.ResponseWriter, r *http.Request) {
+	rule, err := formRule(r)
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.setTableGlobalRule(TableGlobalRule{Rule: rule})
+	}, s.tableGlobalRulePulling)
+}
+
+func (s *Server) handleGlobalRuleDelete(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("name")
+	if len(name) == 0 {
+		paramError(w, "name is required")
+		return
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.deleteTableGlobalRule(name)
+	}, s.tableGlobalRulePulling)
+}
+
+func (s *Server) handleClusterRuleList(w http.ResponseWriter, r *http.Request) {
+	data, err := s.dbOpImpl.getTableClusterRuleData()
+	replyList(w, data, err)
+}
+
+func (s *Server) handleClusterRuleSet(w http.ResponseWriter, r *http.Request) {
+	clusterId, err := formInt(r, "cluster_id")
+	if err != nil || clusterId == 0 {
+		paramError(w, "invalid cluster_id")
+		return
+	}
+	rule, err := formRule(r)
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.setTableClusterRule(TableClusterRule{ClusterId: clusterId, Rule: rule})
+	}, s.tableClusterRulePulling)
+}
+
+func (s *Server) handleClusterRuleDelete(w http.ResponseWriter, r *http.Request) {
+	clusterId, name, err := formClusterKey(r, "name")
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.deleteTableClusterRule(clusterId, name)
+	}, s.tableClusterRulePulling)
+}
+
+func (s *Server) handleReceiverList(w http.ResponseWriter, r *http.Request) {
+	data, err := s.dbOpImpl.getTableReceiveData()
+	replyList(w, data, err)
+}
+
+// handleReceiverSet sets a receiver of the cluster, the receivers of cluster
+// 0 receive the alarms of all the clusters.
+func (s *Server) handleReceiverSet(w http.ResponseWriter, r *http.Request) {
+	clusterId, erp, err := formClusterKey(r, "erp")
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	receiver := TableReceiver{
+		Erp:       erp,
+		ClusterId: clusterId,
+		Role:      r.FormValue("role"),
+		Mail:      r.FormValue("mail"),
+		Tel:       r.FormValue("tel"),
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.setTableReceiver(receiver)
+	}, s.tableReceiverPulling)
+}
+
+func (s *Server) handleReceiverDelete(w http.ResponseWriter, r *http.Request) {
+	clusterId, erp, err := formClusterKey(r, "erp")
+	if err != nil {
+		paramError(w, err.Error())
+		return
+	}
+	s.writeTable(w, func(op dbWriteOp) error {
+		return op.deleteTableReceiver(clusterId, erp)
+	}, s.tableReceiverPulling)
+}
@@ -0,0 +1,203 @@
+package alarm2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+)
+
+const (
+	NOTIFIER_GATEWAY = "gateway"
+	NOTIFIER_WEBHOOK = "webhook"
+	NOTIFIER_CHAT    = "chat"
+	NOTIFIER_EMAIL   = "email"
+)
+
+// Notifier sends the notifications of the alarms, a notification holds the
+// alarms of a cluster and rule grouped in the group wait.
+type Notifier interface {
+	Name() string
+	Notify(n *Notification) error
+}
+
+type Notification struct {
+	Title     string `json:"title"`
+	ClusterId int64  `json:"cluster_id"`
+	RuleName  string `json:"rule_name"`
+	// 0 for the first notification, increased by every escalation
+	Level  int      `json:"level"`
+	Alerts []*Alert `json:"alerts"`
+	Mails  []string `json:"mails"`
+	Tels   []string `json:"tels"`
+}
+
+func (n *Notification) text() string {
+	lines := []string{n.Title}
+	for _, a := range n.Alerts {
+		lines = append(lines, genAlarmSmsContent(a.msg))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *Server) newNotifier(conf NotifierConfig) (Notifier, error) {
+	switch conf.Type {
+	case NOTIFIER_GATEWAY:
+		return &gatewayNotifier{url: conf.Url, client: s.reportClient}, nil
+	case NOTIFIER_WEBHOOK:
+		return &webhookNotifier{url: conf.Url, client: s.reportClient}, nil
+	case NOTIFIER_CHAT:
+		return &chatNotifier{url: conf.Url, client: s.reportClient}, nil
+	case NOTIFIER_EMAIL:
+		if len(conf.SmtpAddr) == 0 || len(conf.MailFrom) == 0 {
+			return nil, errors.New("email notifier needs smtp addr and mail from")
+		}
+		return &emailNotifier{conf: conf}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", conf.Type)
+	}
+}
+
+func (s *Server) initNotifiers() error {
+	confs := s.conf.Notifiers
+	if len(confs) == 0 && len(s.conf.AlarmGatewayAddr) != 0 {
+		confs = []NotifierConfig{{Type: NOTIFIER_GATEWAY, Url: s.conf.AlarmGatewayAddr}}
+	}
+	for _, c := range confs {
+		n, err := s.newNotifier(c)
+		if err != nil {
+			return err
+		}
+		s.RegisterNotifier(n, c.Escalation)
+	}
+	return nil
+}
+
+// RegisterNotifier adds a notifier, an escalation notifier is only notified
+// when an alarm is not acknowledged in the escalation time.
+func (s *Server) RegisterNotifier(n Notifier, escalation bool) {
+	s.notifierLock.Lock()
+	defer s.notifierLock.Unlock()
+	if escalation {
+		s.escalationNotifiers = append(s.escalationNotifiers, n)
+	} else {
+		s.notifiers = append(s.notifiers, n)
+	}
+}
+
+func (s *Server) getNotifiers(escalation bool) []Notifier {
+	s.notifierLock.RLock()
+	defer s.notifierLock.RUnlock()
+	ret := append([]Notifier{}, s.notifiers...)
+	if escalation {
+		ret = append(ret, s.escalationNotifiers...)
+	}
+	return ret
+}
+
+func postJson(client *http.Client, url string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json;charset=utf-8")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("post %v status %v", url, resp.Status)
+	}
+	return nil
+}
+
+// gatewayNotifier sends a mail and a sms by the alarm gateway
+type gatewayNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *gatewayNotifier) Name() string {
+	return NOTIFIER_GATEWAY
+}
+
+func (n *gatewayNotifier) Notify(no *Notification) error {
+	if len(no.Mails) == 0 || len(no.Tels) == 0 {
+		return fmt.Errorf("cluster[%v] no receiver", no.ClusterId)
+	}
+	var mail string
+	for _, a := range no.Alerts {
+		mail += genAlarmMailContent(a.msg)
+	}
+	if err := doReport(n.client, n.url, reportMessage{
+		Title:   no.Title,
+		Content: mail,
+		MailTo:  strings.Join(no.Mails, ","),
+	}); err != nil {
+		return err
+	}
+	return doReport(n.client, n.url, reportMessage{
+		Title:   no.Title,
+		Content: no.text(),
+		SmsTo:   strings.Join(no.Tels, ","),
+	})
+}
+
+// webhookNotifier posts the notification in json
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookNotifier) Name() string {
+	return NOTIFIER_WEBHOOK
+}
+
+func (n *webhookNotifier) Notify(no *Notification) error {
+	return postJson(n.client, n.url, no)
+}
+
+// chatNotifier posts the text of the notification to an incoming webhook of
+// a chat, which accepts a json with the text like slack.
+type chatNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *chatNotifier) Name() string {
+	return NOTIFIER_CHAT
+}
+
+func (n *chatNotifier) Notify(no *Notification) error {
+	return postJson(n.client, n.url, map[string]string{"text": no.text()})
+}
+
+type emailNotifier struct {
+	conf NotifierConfig
+}
+
+func (n *emailNotifier) Name() string {
+	return NOTIFIER_EMAIL
+}
+
+func (n *emailNotifier) Notify(no *Notification) error {
+	if len(no.Mails) == 0 {
+		return fmt.Errorf("cluster[%v] no mail receiver", no.ClusterId)
+	}
+	var auth smtp.Auth
+	if len(n.conf.SmtpUser) != 0 {
+		host := strings.Split(n.conf.SmtpAddr, ":")[0]
+		auth = smtp.PlainAuth("", n.conf.SmtpUser, n.conf.SmtpPassword, host)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		n.conf.MailFrom, strings.Join(no.Mails, ","), no.Title, no.text())
+	return smtp.SendMail(n.conf.SmtpAddr, auth, n.conf.MailFrom, no.Mails, []byte(msg))
+}
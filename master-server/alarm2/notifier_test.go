@@ -0,0 +1,212 @@
+package alarm2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"model/pkg/alarmpb2"
+)
+
+// webhookStandIn records the json bodies posted to it
+type webhookStandIn struct {
+	lock   sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func (h *webhookStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, _ := ioutil.ReadAll(r.Body)
+	body := make(map[string]interface{})
+	json.Unmarshal(data, &body)
+	h.lock.Lock()
+	h.bodies = append(h.bodies, body)
+	h.lock.Unlock()
+}
+
+func (h *webhookStandIn) take() []map[string]interface{} {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	ret := h.bodies
+	h.bodies = nil
+	return ret
+}
+
+func newNotifyTestServer(t *testing.T, webhook, chat, pager string) *Server {
+	c := Alarm2ServerConfig{
+		GroupWaitSec:   5,
+		DedupWindowSec: 60,
+		EscalationSec:  30,
+		ResolveSec:     300,
+		Notifiers: []NotifierConfig{
+			{Type: NOTIFIER_WEBHOOK, Url: webhook},
+			{Type: NOTIFIER_CHAT, Url: chat},
+			{Type: NOTIFIER_WEBHOOK, Url: pager, Escalation: true},
+		},
+	}
+	s, err := NewAlarmServer2(&c)
+	if err != nil {
+		t.Fatalf("new alarm server error: %v", err)
+	}
+	s.updateMapReceiver([]TableReceiver{{Erp: "erp1", ClusterId: 1, Mail: "mail1", Tel: "tel1"}})
+	return s
+}
+
+func newTestAlarm(ipAddr, ruleName string) alarmMessage {
+	return alarmMessage{
+		clusterId: 1,
+		appName:   APPNAME_GATEWAY,
+		ipAddr:    ipAddr,
+		RuleAlarmRequest: alarmpb2.RuleAlarmRequest{
+			RuleName:   ruleName,
+			AlarmValue: 10,
+			CmpType:    alarmpb2.AlarmValueCompareType_GREATER_THAN,
+		},
+		ruleThreshold: 5,
+	}
+}
+
+func TestNotifyGroupAndDedup(t *testing.T) {
+	webhook, chat, pager := new(webhookStandIn), new(webhookStandIn), new(webhookStandIn)
+	ws, cs, ps := httptest.NewServer(webhook), httptest.NewServer(chat), httptest.NewServer(pager)
+	defer ws.Close()
+	defer cs.Close()
+	defer ps.Close()
+	s := newNotifyTestServer(t, ws.URL, cs.URL, ps.URL)
+
+	var now int64 = 1000
+	s.receiveAlarm(newTestAlarm("1.1.1.1", ALARMRULE_GATEWAY_SLOWLOG), now)
+	s.receiveAlarm(newTestAlarm("1.1.1.2", ALARMRULE_GATEWAY_SLOWLOG), now+1)
+	s.receiveAlarm(newTestAlarm("1.1.1.1", ALARMRULE_GATEWAY_SLOWLOG), now+2)
+	s.checkAlerts(now + 4)
+	if len(webhook.take()) != 0 {
+		t.Fatal("alarms should wait in the group")
+	}
+
+	s.checkAlerts(now + 5)
+	bodies := webhook.take()
+	if len(bodies) != 1 {
+		t.Fatalf("expect 1 grouped notification, got %v", len(bodies))
+	}
+	if alerts := bodies[0]["alerts"].([]interface{}); len(alerts) != 2 {
+		t.Fatalf("expect 2 alerts in the group, got %v", alerts)
+	}
+	if mails := bodies[0]["mails"].([]interface{}); len(mails) != 1 || mails[0] != "mail1" {
+		t.Fatalf("unexpected receivers %v", mails)
+	}
+	texts := chat.take()
+	if len(texts) != 1 || !strings.Contains(texts[0]["text"].(string), "IpAddr[1.1.1.2]") {
+		t.Fatalf("unexpected chat message %v", texts)
+	}
+	for _, a := range s.getAlerts() {
+		s.ackAlert(a.Id, "erp1")
+	}
+
+	// dedup in the window
+	s.receiveAlarm(newTestAlarm("1.1.1.1", ALARMRULE_GATEWAY_SLOWLOG), now+10)
+	s.checkAlerts(now + 20)
+	if len(webhook.take()) != 0 {
+		t.Fatal("alarm should be deduplicated")
+	}
+	alerts := s.getAlerts()
+	if len(alerts) != 2 || alerts[0].Count != 3 {
+		t.Fatalf("unexpected alerts %+v", alerts)
+	}
+
+	// notified again after the window
+	s.receiveAlarm(newTestAlarm("1.1.1.1", ALARMRULE_GATEWAY_SLOWLOG), now+70)
+	s.checkAlerts(now + 75)
+	if bodies := webhook.take(); len(bodies) != 1 || len(bodies[0]["alerts"].([]interface{})) != 1 {
+		t.Fatalf("expect the alarm notified again, got %v", bodies)
+	}
+	if len(pager.take()) != 0 {
+		t.Fatal("escalation notifier should not be notified")
+	}
+
+	// resolved when it is not fired
+	s.checkAlerts(now + 75 + 300)
+	if alerts := s.getAlerts(); len(alerts) != 0 {
+		t.Fatalf("alerts should be resolved, got %+v", alerts)
+	}
+}
+
+func TestEscalationAndSilence(t *testing.T) {
+	webhook, chat, pager := new(webhookStandIn), new(webhookStandIn), new(webhookStandIn)
+	ws, cs, ps := httptest.NewServer(webhook), httptest.NewServer(chat), httptest.NewServer(pager)
+	defer ws.Close()
+	defer cs.Close()
+	defer ps.Close()
+	s := newNotifyTestServer(t, ws.URL, cs.URL, ps.URL)
+	api := httptest.NewServer(s.httpHandler())
+	defer api.Close()
+
+	call := func(path string, form url.Values) *httpReply {
+		resp, err := http.PostForm(api.URL+path, form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		reply := new(httpReply)
+		if err := json.NewDecoder(resp.Body).Decode(reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply
+	}
+
+	var now int64 = 1000
+	s.receiveAlarm(newTestAlarm("1.1.1.1", ALARMRULE_GATEWAY_ERRORLOG), now)
+	s.receiveAlarm(newTestAlarm("1.1.1.2", ALARMRULE_GATEWAY_ERRORLOG), now)
+	s.checkAlerts(now + 5)
+	webhook.take()
+
+	id := s.getAlerts()[0].Id
+	if reply := call("/alarm/alert/ack", url.Values{"id": {id}, "operator": {"erp1"}}); reply.Code != HTTP_OK {
+		t.Fatalf("ack alert failed: %v", reply.Message)
+	}
+	s.checkAlerts(now + 35)
+	bodies := pager.take()
+	if len(bodies) != 1 || bodies[0]["level"].(float64) != 1 {
+		t.Fatalf("expect the unacknowledged alert escalated, got %v", bodies)
+	}
+	if alerts := bodies[0]["alerts"].([]interface{}); alerts[0].(map[string]interface{})["ip_addr"] != "1.1.1.2" {
+		t.Fatalf("unexpected escalated alert %v", alerts)
+	}
+	if len(webhook.take()) != 1 {
+		t.Fatal("escalation should notify the notifiers too")
+	}
+
+	// a maintenance window of the cluster stops the escalation and the alarms
+	reply := call("/alarm/silence/create", url.Values{
+		"cluster_id": {"1"}, "start_time": {"1040"}, "duration": {"100"}, "comment": {"maintenance"}})
+	if reply.Code != HTTP_OK {
+		t.Fatalf("create silence failed: %v", reply.Message)
+	}
+	s.checkAlerts(now + 70)
+	if len(pager.take()) != 0 {
+		t.Fatal("silenced alert should not be escalated")
+	}
+	s.receiveAlarm(newTestAlarm("1.1.1.3", ALARMRULE_GATEWAY_ERRORLOG), now+70)
+	s.checkAlerts(now + 80)
+	if len(webhook.take()) != 0 || len(s.getAlerts()) != 2 {
+		t.Fatal("silenced alarm should be dropped")
+	}
+
+	reply = call("/alarm/silence/list", nil)
+	silences := reply.Data.([]interface{})
+	if len(silences) != 1 {
+		t.Fatalf("expect 1 silence, got %v", silences)
+	}
+	if reply := call("/alarm/silence/delete", url.Values{"id": {"1"}}); reply.Code != HTTP_OK {
+		t.Fatalf("delete silence failed: %v", reply.Message)
+	}
+	if reply := call("/alarm/silence/delete", url.Values{"id": {"1"}}); reply.Code != HTTP_ERROR {
+		t.Fatal("silence should be deleted")
+	}
+	if reply := call("/alarm/silence/create", url.Values{"cluster_id": {"1"}}); reply.Code != HTTP_ERROR_PARAMETER {
+		t.Fatal("silence without end time should be invalid")
+	}
+}
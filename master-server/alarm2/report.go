@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 	"net/http"
-	"context"
 	"encoding/json"
 
 	"util/log"
@@ -39,22 +38,6 @@ func (s *Server) ruleAlarmReportAppend(clusterId int64, appName, ipAddr string,
 	}
 }
 
-func (s *Server) ruleAlarmReportCron() {
-	ctx, cancel := context.WithCancel(s.context)
-	defer cancel()
-
-	for {
-		select {
-		case msg := <-s.reportQueue:
-			if err := s.report(msg); err != nil {
-				log.Error("alarm report error: %v", err)
-			}
-		case <-ctx.Done():
-			return
-		}
-	}
-}
-
 func (s *Server) getReportReceivers(clusterId int64) ([]string, []string, error) {
 	var mail 	[]string
 	var sms 	[]string
@@ -102,64 +85,13 @@ func genAlarmSmsContent(msg alarmMessage) (content string) {
 	return
 }
 
-func (s *Server) report(msg alarmMessage) error {
-	log.Debug("alarm report message: %+v", msg)
-	var receiverMail []string
-	var receiverSms []string
-
-	receiverMail, receiverSms, err := s.getReportReceivers(msg.clusterId)
-	if err != nil {
-		return err
-	}
-
-	if err := s.mailReport(msg, receiverMail); err != nil {
-		return err
-	}
-	if err := s.smsReport(msg, receiverSms); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (s *Server) mailReport(msg alarmMessage, receiverMail []string) error {
-	content := genAlarmMailContent(msg)
-
-	reportMsg := reportMessage{
-		Title:   "SHARKSTORE ALARM " + msg.GetRuleName(),
-		Content: content,
-		MailTo:  strings.Join(receiverMail, ","),
-	}
-	log.Debug("alarm report title is: %v", reportMsg.Title)
-	log.Debug("alarm report content is: %v", reportMsg.Content)
-	log.Debug("alarm report mail is: %v", reportMsg.MailTo)
-
-	return s.doReport(reportMsg)
-}
-
-func (s *Server) smsReport(msg alarmMessage, receiverSms []string) error {
-	content := "SHARKSTORE ALARM " + msg.GetRuleName()
-	content += genAlarmSmsContent(msg)
-
-	reportMsg := reportMessage{
-		Title:   "SHARKSTORE ALARM " + msg.GetRuleName(),
-		Content: content,
-		SmsTo:   strings.Join(receiverSms, ","),
-	}
-	log.Debug("alarm report title is: %v", reportMsg.Title)
-	log.Debug("alarm report content is: %v", reportMsg.Content)
-	log.Debug("alarm report sms is: %v", reportMsg.SmsTo)
-
-	return s.doReport(reportMsg)
-}
-
-func (s *Server) doReport(reportMsg reportMessage) error {
+func doReport(client *http.Client, url string, reportMsg reportMessage) error {
 	data, err := json.Marshal(reportMsg)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", s.conf.AlarmGatewayAddr, strings.NewReader(string(data)))
+	req, err := http.NewRequest("POST", url, strings.NewReader(string(data)))
 	if err != nil {
 		return err
 	}
@@ -170,11 +102,10 @@ func (s *Server) doReport(reportMsg reportMessage) error {
 	req.Header.Set("Accept-Charset", "utf-8,GBK")
 	req.Header.Set("Connection", "close")
 
-	resp, err := s.reportClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	return nil
 }
-
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 	"context"
+	"time"
 
 	"github.com/gomodule/redigo/redis"
 	"database/sql"
@@ -48,6 +49,18 @@ type Server struct {
 	globalRuleLock 	sync.RWMutex
 	clusterRuleLock sync.RWMutex
 	receiverLock 	sync.RWMutex
+
+	notifiers 			[]Notifier
+	escalationNotifiers []Notifier
+	notifierLock 		sync.RWMutex
+
+	alerts 		map[string]*Alert
+	alertGroups map[string]*alertGroup
+	alertLock 	sync.Mutex
+
+	silences 	map[int64]*Silence
+	silenceId 	int64
+	silenceLock sync.RWMutex
 }
 
 func NewAlarmServer2(conf *Alarm2ServerConfig) (*Server, error) {
@@ -55,16 +68,34 @@ func NewAlarmServer2(conf *Alarm2ServerConfig) (*Server, error) {
 	s.conf = conf
 
 	var err error
-	s.jimClient = s.newJimClient()
-	s.mysqlClient, err = s.newMysqlClient()
-	if err != nil {
-		return nil, err
+	switch conf.StoreType {
+	case STORETYPE_BOLT:
+		store, err := newBoltOpImpl(conf.BoltPath)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheOpImpl = store
+		s.dbOpImpl = store
+	case STORETYPE_MYSQL, "":
+		s.jimClient = s.newJimClient()
+		s.mysqlClient, err = s.newMysqlClient()
+		if err != nil {
+			return nil, err
+		}
+		s.cacheOpImpl = s.newCacheOpImpl()
+		s.dbOpImpl = s.newDbOpImpl()
+	default:
+		return nil, fmt.Errorf("unknown alarm store type %v", conf.StoreType)
 	}
-	s.cacheOpImpl = s.newCacheOpImpl()
-	s.dbOpImpl = s.newDbOpImpl()
-	s.reportClient = &http.Client{}
+	s.reportClient = &http.Client{Timeout: 10 * time.Second}
 	s.reportQueue = make(chan alarmMessage, 10000)
 	s.context = context.Background()
+	s.alerts = make(map[string]*Alert)
+	s.alertGroups = make(map[string]*alertGroup)
+	s.silences = make(map[int64]*Silence)
+	if err = s.initNotifiers(); err != nil {
+		return nil, err
+	}
 
 	return s, nil
 }
@@ -79,6 +110,15 @@ func (s *Server) Run() error {
 	reflection.Register(server)
 	go server.Serve(lis)
 
+	if s.conf.HttpPort > 0 {
+		httpLis, err := net.Listen("tcp", fmt.Sprintf(":%v", s.conf.HttpPort))
+		if err != nil {
+			return err
+		}
+		go http.Serve(httpLis, s.httpHandler())
+	}
+
+	s.tablePulling()
 	go s.timingDbPulling()
 	go s.aliveChecking()
 	go s.ruleAlarmReportCron()
@@ -0,0 +1,111 @@
+package alarm2
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"util/log"
+)
+
+var ErrSilenceNotExist = errors.New("silence not exist")
+
+// Silence drops the alarms matched in its time, a maintenance window of a
+// cluster is a silence of the cluster starting in the future. the empty
+// fields match all.
+type Silence struct {
+	Id        int64  `json:"id"`
+	ClusterId int64  `json:"cluster_id"`
+	AppName   string `json:"app_name"`
+	IpAddr    string `json:"ip_addr"`
+	RuleName  string `json:"rule_name"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Creator   string `json:"creator"`
+	Comment   string `json:"comment"`
+}
+
+func (si *Silence) validate() error {
+	if si.EndTime <= si.StartTime {
+		return errors.New("end time of silence should be after start time")
+	}
+	return nil
+}
+
+func (si *Silence) match(clusterId int64, appName, ipAddr, ruleName string, now int64) bool {
+	if now < si.StartTime || now >= si.EndTime {
+		return false
+	}
+	if si.ClusterId != 0 && si.ClusterId != clusterId {
+		return false
+	}
+	if len(si.AppName) != 0 && !strings.HasPrefix(strings.ToLower(appName), strings.ToLower(si.AppName)) {
+		return false
+	}
+	if len(si.IpAddr) != 0 && si.IpAddr != ipAddr {
+		return false
+	}
+	if len(si.RuleName) != 0 && si.RuleName != ruleName {
+		return false
+	}
+	return true
+}
+
+func (s *Server) findSilence(clusterId int64, appName, ipAddr, ruleName string, now int64) *Silence {
+	s.silenceLock.RLock()
+	defer s.silenceLock.RUnlock()
+	for _, si := range s.silences {
+		if si.match(clusterId, appName, ipAddr, ruleName, now) {
+			return si
+		}
+	}
+	return nil
+}
+
+func (s *Server) addSilence(si *Silence) error {
+	if err := si.validate(); err != nil {
+		return err
+	}
+	s.silenceLock.Lock()
+	defer s.silenceLock.Unlock()
+	s.silenceId++
+	si.Id = s.silenceId
+	s.silences[si.Id] = si
+	log.Info("add silence: %+v", si)
+	return nil
+}
+
+func (s *Server) deleteSilence(id int64) error {
+	s.silenceLock.Lock()
+	defer s.silenceLock.Unlock()
+	if _, find := s.silences[id]; !find {
+		return ErrSilenceNotExist
+	}
+	delete(s.silences, id)
+	log.Info("delete silence[%v]", id)
+	return nil
+}
+
+func (s *Server) getSilences() []Silence {
+	s.silenceLock.RLock()
+	defer s.silenceLock.RUnlock()
+	ret := make([]Silence, 0, len(s.silences))
+	for _, si := range s.silences {
+		ret = append(ret, *si)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Id < ret[j].Id
+	})
+	return ret
+}
+
+func (s *Server) expireSilences(now int64) {
+	s.silenceLock.Lock()
+	defer s.silenceLock.Unlock()
+	for id, si := range s.silences {
+		if now >= si.EndTime {
+			log.Info("silence[%v] is expired", id)
+			delete(s.silences, id)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"model/pkg/timestamp"
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"proxy/store/dskv"
+	"util"
+	"util/encoding"
+	"util/hlc"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultBatchSize   = 1000
+	defaultConcurrency = 4
+	tablePrefixLen     = 9
+)
+
+// Env is what backup and restore need to reach the data servers, rows are
+// read and written through the same proxy the gateway uses.
+type Env struct {
+	MsClient     msClient.Client
+	KvClient     dsClient.KvClient
+	Clock        *hlc.Clock
+	BatchSize    uint64
+	Concurrency  int
+	WriteTimeout time.Duration
+	ReadTimeout  time.Duration
+	// ReadWait is how long a backup waits after taking its timestamp, the
+	// writes in flight with an earlier timestamp are applied meanwhile
+	ReadWait time.Duration
+}
+
+// GetBatchSize returns the rows of a read or write batch.
+func (env *Env) GetBatchSize() uint64 {
+	if env.BatchSize == 0 {
+		return defaultBatchSize
+	}
+	return env.BatchSize
+}
+
+// GetConcurrency returns the ranges handled at the same time.
+func (env *Env) GetConcurrency() int {
+	if env.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+	return env.Concurrency
+}
+
+// NewKvProxy returns a proxy to read and write the rows of the table.
+func (env *Env) NewKvProxy(dbId, tableId uint64) *dskv.KvProxy {
+	proxy := new(dskv.KvProxy)
+	cache := dskv.NewRangeCache(dbId, tableId, env.MsClient, dskv.NewNodeCache(env.MsClient))
+	proxy.Init(env.KvClient, env.Clock, cache, env.WriteTimeout, env.ReadTimeout)
+	return proxy
+}
+
+// Table is the table to back up together with its range layout.
+type Table struct {
+	ClusterId  uint64
+	DbId       uint64
+	DbName     string
+	TableId    uint64
+	TableName  string
+	Columns    []*metapb.Column
+	Regxs      []*metapb.Column
+	PkDupCheck bool
+	Ranges     []*metapb.Range
+}
+
+type Progress struct {
+	TotalFiles uint64 `json:"total_files"`
+	DoneFiles  uint64 `json:"done_files"`
+	Rows       uint64 `json:"rows"`
+	Bytes      uint64 `json:"bytes"`
+}
+
+func (p *Progress) Snapshot() Progress {
+	return Progress{
+		TotalFiles: atomic.LoadUint64(&p.TotalFiles),
+		DoneFiles:  atomic.LoadUint64(&p.DoneFiles),
+		Rows:       atomic.LoadUint64(&p.Rows),
+		Bytes:      atomic.LoadUint64(&p.Bytes),
+	}
+}
+
+func dataFileName(rangeId uint64) string {
+	return fmt.Sprintf("range-%020d.sst", rangeId)
+}
+
+func trimTablePrefix(key []byte) []byte {
+	if len(key) <= tablePrefixLen {
+		return nil
+	}
+	return append([]byte(nil), key[tablePrefixLen:]...)
+}
+
+// Backup scans every range of the table and writes one data file for each,
+// the manifest is written last. Every range is read at the timestamp of the
+// manifest, a range written after it fails the backup, so a finished backup
+// is consistent at that timestamp. Retry when the table is less busy.
+func Backup(ctx context.Context, env *Env, t *Table, store Storage, progress *Progress) (*Manifest, error) {
+	if len(t.Ranges) == 0 {
+		return nil, errors.New("table has no range")
+	}
+	ranges := make([]*metapb.Range, len(t.Ranges))
+	copy(ranges, t.Ranges)
+	sort.Sort(byStartKey(ranges))
+
+	now := env.Clock.Now()
+	m := &Manifest{
+		Version:    ManifestVersion,
+		ClusterId:  t.ClusterId,
+		DbName:     t.DbName,
+		TableName:  t.TableName,
+		TableId:    t.TableId,
+		Columns:    t.Columns,
+		Regxs:      t.Regxs,
+		PkDupCheck: t.PkDupCheck,
+		Timestamp:  now.WallTime,
+		Logical:    now.Logical,
+		Files:      make([]*DataFile, len(ranges)),
+		CreateTime: time.Now().Unix(),
+	}
+	for i, r := range ranges {
+		if i > 0 {
+			m.SplitKeys = append(m.SplitKeys, trimTablePrefix(r.GetStartKey()))
+		}
+		m.Files[i] = &DataFile{
+			Name:     dataFileName(r.GetId()),
+			RangeId:  r.GetId(),
+			StartKey: r.GetStartKey(),
+			EndKey:   r.GetEndKey(),
+		}
+	}
+	atomic.StoreUint64(&progress.TotalFiles, uint64(len(m.Files)))
+
+	proxy := env.NewKvProxy(t.DbId, t.TableId)
+	fieldList := make([]*kvrpcpb.SelectField, 0, len(t.Columns))
+	for _, col := range t.Columns {
+		fieldList = append(fieldList, &kvrpcpb.SelectField{Typ: kvrpcpb.SelectField_Column, Column: col})
+	}
+	if env.ReadWait > 0 {
+		select {
+		case <-time.After(env.ReadWait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	req := &kvrpcpb.SelectRequest{
+		FieldList:    fieldList,
+		Timestamp:    &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
+		SnapshotRead: true,
+	}
+	err := dskv.Parallel(ctx, env.GetConcurrency(), len(m.Files), func(i int) error {
+		return backupRange(ctx, env, proxy, t, req, m.Files[i], store, progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = WriteManifest(store, m); err != nil {
+		return nil, fmt.Errorf("write manifest failed, err[%v]", err)
+	}
+	log.Info("backup table[%s:%s] to %s finished, files[%d] rows[%d]", t.DbName, t.TableName, store, len(m.Files), m.Rows())
+	return m, nil
+}
+
+func backupRange(ctx context.Context, env *Env, proxy *dskv.KvProxy, t *Table, req *kvrpcpb.SelectRequest,
+	f *DataFile, store Storage, progress *Progress) error {
+	w, err := store.Create(f.Name)
+	if err != nil {
+		return err
+	}
+	// a failed file is removed, so it is never published under its name
+	if err = writeRange(ctx, env, proxy, t, req, f, w, progress); err != nil {
+		w.Abort()
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	atomic.AddUint64(&progress.Bytes, f.Size)
+	atomic.AddUint64(&progress.DoneFiles, 1)
+	log.Debug("backup range[%d] to %s finished, rows[%d] size[%d]", f.RangeId, f.Name, f.Rows, f.Size)
+	return nil
+}
+
+func writeRange(ctx context.Context, env *Env, proxy *dskv.KvProxy, t *Table, req *kvrpcpb.SelectRequest,
+	f *DataFile, w io.Writer, progress *Progress) error {
+	fw, err := NewFileWriter(w)
+	if err != nil {
+		return err
+	}
+
+	err = proxy.ScanScope(ctx, req, f.StartKey, f.EndKey, env.GetBatchSize(), func(rows []*kvrpcpb.Row) error {
+		for _, row := range rows {
+			kv, err := rowToKeyValue(t.Columns, row)
+			if err != nil {
+				return fmt.Errorf("decode row failed, err[%v]", err)
+			}
+			if err = fw.Append(kv); err != nil {
+				return err
+			}
+		}
+		atomic.AddUint64(&progress.Rows, uint64(len(rows)))
+		return nil
+	})
+	if err != nil {
+		if err == ctx.Err() {
+			return err
+		}
+		if err == dskv.ErrWrittenAfterRead {
+			return fmt.Errorf("range[%d] is written after the backup timestamp, retry the backup", f.RangeId)
+		}
+		return fmt.Errorf("scan range[%d] failed, err[%v]", f.RangeId, err)
+	}
+
+	if err = fw.Finish(); err != nil {
+		return err
+	}
+	f.Rows, f.Size, f.Checksum = fw.Rows(), fw.Size(), fw.Checksum()
+	return nil
+}
+
+// rowToKeyValue converts a selected row to the stored format, the fields
+// returned by select carry no column id, so non primary key values are
+// encoded again with their column id.
+func rowToKeyValue(columns []*metapb.Column, row *kvrpcpb.Row) (*kvrpcpb.KeyValue, error) {
+	var value []byte
+	data := row.GetFields()
+	for _, col := range columns {
+		var v interface{}
+		var err error
+		data, v, err = util.DecodeColumnValue(data, col)
+		if err != nil {
+			return nil, err
+		}
+		if col.GetPrimaryKey() == 1 || v == nil {
+			continue
+		}
+		switch val := v.(type) {
+		case int64:
+			value = encoding.EncodeIntValue(value, uint32(col.GetId()), val)
+		case uint64:
+			value = encoding.EncodeIntValue(value, uint32(col.GetId()), int64(val))
+		case float64:
+			value = encoding.EncodeFloatValue(value, uint32(col.GetId()), val)
+		case []byte:
+			value = encoding.EncodeBytesValue(value, uint32(col.GetId()), val)
+		default:
+			return nil, fmt.Errorf("unsupported value type %T of column %s", v, col.GetName())
+		}
+	}
+	return &kvrpcpb.KeyValue{Key: trimTablePrefix(row.GetKey()), Value: value}, nil
+}
+
+// Restore writes all rows of the backup into the table, which must have been
+// created with the columns and split keys of the manifest.
+func Restore(ctx context.Context, env *Env, m *Manifest, store Storage, dbId, tableId uint64, progress *Progress) error {
+	atomic.StoreUint64(&progress.TotalFiles, uint64(len(m.Files)))
+	proxy := env.NewKvProxy(dbId, tableId)
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, tableId)
+	err := dskv.Parallel(ctx, env.GetConcurrency(), len(m.Files), func(i int) error {
+		return restoreFile(ctx, env, proxy, prefix, m.Files[i], store, progress)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("restore table[%d] from %s finished, files[%d] rows[%d]", tableId, store, len(m.Files), m.Rows())
+	return nil
+}
+
+// verifyFile reads the whole file and checks its checksum, so that no row of
+// a corrupted file is restored.
+func verifyFile(store Storage, f *DataFile) error {
+	r, err := store.Open(f.Name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	fr, err := NewFileReader(r)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err = fr.Next(); err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return fr.Verify(f)
+}
+
+func restoreFile(ctx context.Context, env *Env, proxy *dskv.KvProxy, prefix []byte, f *DataFile, store Storage, progress *Progress) error {
+	if err := verifyFile(store, f); err != nil {
+		return fmt.Errorf("verify %s failed, err[%v]", f.Name, err)
+	}
+	r, err := store.Open(f.Name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	fr, err := NewFileReader(r)
+	if err != nil {
+		return fmt.Errorf("open %s failed, err[%v]", f.Name, err)
+	}
+
+	batch := make([]*kvrpcpb.KeyValue, 0, env.GetBatchSize())
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		kv, err := fr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read %s failed, err[%v]", f.Name, err)
+		}
+		kv.Key = append(append(make([]byte, 0, len(prefix)+len(kv.Key)), prefix...), kv.Key...)
+		batch = append(batch, kv)
+		if uint64(len(batch)) >= env.GetBatchSize() {
+			if err = proxy.InsertRows(batch); err != nil {
+				return err
+			}
+			atomic.AddUint64(&progress.Rows, uint64(len(batch)))
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err = proxy.InsertRows(batch); err != nil {
+			return err
+		}
+		atomic.AddUint64(&progress.Rows, uint64(len(batch)))
+	}
+	atomic.AddUint64(&progress.Bytes, f.Size)
+	atomic.AddUint64(&progress.DoneFiles, 1)
+	return nil
+}
+
+type byStartKey []*metapb.Range
+
+func (s byStartKey) Len() int      { return len(s) }
+func (s byStartKey) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byStartKey) Less(i, j int) bool {
+	return bytes.Compare(s[i].GetStartKey(), s[j].GetStartKey()) < 0
+}
@@ -0,0 +1,215 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"util"
+	"util/encoding"
+)
+
+func writeDataFile(t *testing.T, store Storage, name string, n int) *DataFile {
+	w, err := store.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw, err := NewFileWriter(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		kv := &kvrpcpb.KeyValue{Key: []byte(fmt.Sprintf("key-%04d", i)), Value: []byte(fmt.Sprintf("value-%d", i))}
+		if err := fw.Append(kv); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &DataFile{Name: name, Rows: fw.Rows(), Size: fw.Size(), Checksum: fw.Checksum()}
+}
+
+func readDataFile(store Storage, f *DataFile) ([]*kvrpcpb.KeyValue, error) {
+	r, err := store.Open(f.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	fr, err := NewFileReader(r)
+	if err != nil {
+		return nil, err
+	}
+	var kvs []*kvrpcpb.KeyValue
+	for {
+		kv, err := fr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs, fr.Verify(f)
+}
+
+func TestLocalStorageRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := NewStorage("file://" + dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := writeDataFile(t, store, dataFileName(1), 100)
+	kvs, err := readDataFile(store, f)
+	if err != nil {
+		t.Fatalf("read data file failed, err %v", err)
+	}
+	if len(kvs) != 100 || string(kvs[99].Key) != "key-0099" || string(kvs[99].Value) != "value-99" {
+		t.Fatalf("unexpected records %d", len(kvs))
+	}
+
+	// an aborted file is never published
+	w, err := store.Create(dataFileName(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write([]byte("partial")); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Abort(); err != nil {
+		t.Fatalf("abort failed, err %v", err)
+	}
+	if _, err = store.Open(dataFileName(2)); err == nil {
+		t.Fatal("aborted file is visible")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Fatalf("unexpected files %d after abort", len(files))
+	}
+
+	// a changed checksum must be detected
+	f.Checksum++
+	if _, err = readDataFile(store, f); err != ErrChecksumMismatch {
+		t.Fatalf("expect checksum mismatch, got %v", err)
+	}
+	f.Checksum--
+
+	m := &Manifest{
+		Version:   ManifestVersion,
+		DbName:    "db",
+		TableName: "t",
+		Columns:   []*metapb.Column{{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1}},
+		SplitKeys: [][]byte{[]byte("key-0050")},
+		Files:     []*DataFile{f},
+	}
+	if err = WriteManifest(store, m); err != nil {
+		t.Fatal(err)
+	}
+	m2, err := ReadManifest(store)
+	if err != nil {
+		t.Fatalf("read manifest failed, err %v", err)
+	}
+	if m2.Rows() != 100 || len(m2.SplitKeys) != 1 || !bytes.Equal(m2.SplitKeys[0], []byte("key-0050")) ||
+		m2.Columns[0].Name != "id" {
+		t.Fatalf("unexpected manifest %+v", m2)
+	}
+}
+
+type s3StandIn struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *s3StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=ak/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		s.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	}
+}
+
+func TestS3StorageRoundTrip(t *testing.T) {
+	standIn := &s3StandIn{objects: make(map[string][]byte)}
+	ts := httptest.NewServer(standIn)
+	defer ts.Close()
+
+	store, err := NewStorage("s3://bucket/backup/t1?access-key=ak&secret-key=sk&endpoint=" + ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := writeDataFile(t, store, dataFileName(2), 10)
+	if _, ok := standIn.objects["/bucket/backup/t1/"+f.Name]; !ok {
+		t.Fatalf("object not uploaded, objects %v", standIn.objects)
+	}
+	kvs, err := readDataFile(store, f)
+	if err != nil || len(kvs) != 10 {
+		t.Fatalf("read data file failed, records %d err %v", len(kvs), err)
+	}
+	if _, err = store.Open("not-exist"); err == nil {
+		t.Fatal("expect error for missing object")
+	}
+}
+
+func TestRowToKeyValue(t *testing.T) {
+	columns := []*metapb.Column{
+		{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+		{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+		{Name: "score", Id: 3, DataType: metapb.DataType_Double},
+		{Name: "age", Id: 4, DataType: metapb.DataType_Int, Unsigned: true},
+	}
+	key := util.EncodeStorePrefix(util.Store_Prefix_KV, 10)
+	key, err := util.EncodePrimaryKey(key, columns[0], []byte("7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fields as returned by select, without column id and with a null
+	var fields []byte
+	fields = encoding.EncodeIntValue(fields, 0, 7)
+	fields = encoding.EncodeBytesValue(fields, 0, []byte("shark"))
+	fields = encoding.EncodeNullValue(fields, 0)
+	fields = encoding.EncodeIntValue(fields, 0, 18)
+
+	kv, err := rowToKeyValue(columns, &kvrpcpb.Row{Key: key, Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(kv.Key, key[tablePrefixLen:]) {
+		t.Fatalf("unexpected key %v", kv.Key)
+	}
+	var expect []byte
+	expect = encoding.EncodeBytesValue(expect, 2, []byte("shark"))
+	expect = encoding.EncodeIntValue(expect, 4, 18)
+	if !bytes.Equal(kv.Value, expect) {
+		t.Fatalf("unexpected value %v, expect %v", kv.Value, expect)
+	}
+}
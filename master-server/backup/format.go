@@ -0,0 +1,137 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"model/pkg/kvrpcpb"
+)
+
+// A data file is a header followed by length framed records:
+//
+//	magic(4) | version(1) | { uvarint(len) | kvrpcpb.KeyValue }*
+//
+// the key of every record has no table prefix, so that a file could be
+// restored into any table with the same schema.
+var fileMagic = []byte("SSBK")
+
+const (
+	fileVersion   = 1
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+var ErrChecksumMismatch = errors.New("backup file checksum mismatch")
+
+type FileWriter struct {
+	w    *bufio.Writer
+	crc  hash.Hash32
+	buf  []byte
+	rows uint64
+	size uint64
+}
+
+func NewFileWriter(w io.Writer) (*FileWriter, error) {
+	crc := crc32.NewIEEE()
+	fw := &FileWriter{
+		w:   bufio.NewWriter(io.MultiWriter(w, crc)),
+		crc: crc,
+		buf: make([]byte, binary.MaxVarintLen64),
+	}
+	if err := fw.write(fileMagic); err != nil {
+		return nil, err
+	}
+	if err := fw.write([]byte{fileVersion}); err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+func (fw *FileWriter) write(data []byte) error {
+	n, err := fw.w.Write(data)
+	fw.size += uint64(n)
+	return err
+}
+
+func (fw *FileWriter) Append(kv *kvrpcpb.KeyValue) error {
+	data, err := kv.Marshal()
+	if err != nil {
+		return err
+	}
+	n := binary.PutUvarint(fw.buf, uint64(len(data)))
+	if err = fw.write(fw.buf[:n]); err != nil {
+		return err
+	}
+	if err = fw.write(data); err != nil {
+		return err
+	}
+	fw.rows++
+	return nil
+}
+
+// Finish flushes the buffered records, the writer could not be used any more.
+func (fw *FileWriter) Finish() error {
+	return fw.w.Flush()
+}
+
+func (fw *FileWriter) Rows() uint64     { return fw.rows }
+func (fw *FileWriter) Size() uint64     { return fw.size }
+func (fw *FileWriter) Checksum() uint32 { return fw.crc.Sum32() }
+
+type FileReader struct {
+	r    *bufio.Reader
+	crc  hash.Hash32
+	rows uint64
+}
+
+func NewFileReader(r io.Reader) (*FileReader, error) {
+	crc := crc32.NewIEEE()
+	fr := &FileReader{r: bufio.NewReader(io.TeeReader(r, crc)), crc: crc}
+	header := make([]byte, len(fileMagic)+1)
+	if _, err := io.ReadFull(fr.r, header); err != nil {
+		return nil, fmt.Errorf("read backup file header failed, err[%v]", err)
+	}
+	if string(header[:len(fileMagic)]) != string(fileMagic) {
+		return nil, errors.New("invalid backup file magic")
+	}
+	if header[len(fileMagic)] != fileVersion {
+		return nil, fmt.Errorf("unsupported backup file version %d", header[len(fileMagic)])
+	}
+	return fr, nil
+}
+
+// Next returns the next record, io.EOF means all records have been read.
+func (fr *FileReader) Next() (*kvrpcpb.KeyValue, error) {
+	length, err := binary.ReadUvarint(fr.r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("read record length failed, err[%v]", err)
+	}
+	if length > maxRecordSize {
+		return nil, fmt.Errorf("invalid record length %d", length)
+	}
+	data := make([]byte, length)
+	if _, err = io.ReadFull(fr.r, data); err != nil {
+		return nil, fmt.Errorf("read record failed, err[%v]", err)
+	}
+	kv := new(kvrpcpb.KeyValue)
+	if err = kv.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("decode record failed, err[%v]", err)
+	}
+	fr.rows++
+	return kv, nil
+}
+
+// Verify must be called after Next returned io.EOF.
+func (fr *FileReader) Verify(f *DataFile) error {
+	if fr.rows != f.Rows || fr.crc.Sum32() != f.Checksum {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
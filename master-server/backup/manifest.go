@@ -0,0 +1,106 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"model/pkg/metapb"
+)
+
+const (
+	ManifestName    = "MANIFEST"
+	ManifestVersion = 1
+)
+
+// DataFile describes one data file of a backup, every file holds the rows of
+// one range of the table at backup time.
+type DataFile struct {
+	Name     string `json:"name"`
+	RangeId  uint64 `json:"range_id"`
+	StartKey []byte `json:"start_key"`
+	EndKey   []byte `json:"end_key"`
+	Rows     uint64 `json:"rows"`
+	Size     uint64 `json:"size"`
+	Checksum uint32 `json:"checksum"`
+}
+
+// Manifest is written after all data files, a backup without manifest is
+// incomplete and can not be restored.
+type Manifest struct {
+	Version    int              `json:"version"`
+	ClusterId  uint64           `json:"cluster_id"`
+	DbName     string           `json:"db_name"`
+	TableName  string           `json:"table_name"`
+	TableId    uint64           `json:"table_id"`
+	Columns    []*metapb.Column `json:"columns"`
+	Regxs      []*metapb.Column `json:"regxs,omitempty"`
+	PkDupCheck bool             `json:"pk_dup_check"`
+	// split keys of the table at backup time, without table prefix
+	SplitKeys [][]byte `json:"split_keys"`
+	// hlc time the backup started, the rows are not a snapshot at this time
+	Timestamp  int64       `json:"timestamp"`
+	Logical    int32       `json:"logical"`
+	Files      []*DataFile `json:"files"`
+	CreateTime int64       `json:"create_time"`
+}
+
+func (m *Manifest) Rows() uint64 {
+	var rows uint64
+	for _, f := range m.Files {
+		rows += f.Rows
+	}
+	return rows
+}
+
+func (m *Manifest) Validate() error {
+	if m.Version != ManifestVersion {
+		return fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+	if len(m.Columns) == 0 {
+		return errors.New("manifest has no column")
+	}
+	for _, f := range m.Files {
+		if f.Name == "" {
+			return errors.New("manifest has file without name")
+		}
+	}
+	return nil
+}
+
+func WriteManifest(store Storage, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	w, err := store.Create(ManifestName)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		w.Abort()
+		return err
+	}
+	return w.Close()
+}
+
+func ReadManifest(store Storage) (*Manifest, error) {
+	r, err := store.Open(ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	m := new(Manifest)
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("decode manifest failed, err[%v]", err)
+	}
+	if err = m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,294 @@
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Storage is the directory a backup is written to, local path or s3 compatible bucket:
+//
+//	/data/backup/t1
+//	file:///data/backup/t1
+//	s3://bucket/prefix?endpoint=http://127.0.0.1:9000&region=us-east-1
+//
+// the s3 credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+// unless access-key and secret-key are given in the url.
+type Storage interface {
+	Create(name string) (Writer, error)
+	Open(name string) (io.ReadCloser, error)
+	String() string
+}
+
+// Writer is a file being written, Close publishes it and Abort discards it,
+// a file is never visible under its name before Close succeeds.
+type Writer interface {
+	io.WriteCloser
+	Abort() error
+}
+
+func NewStorage(rawurl string) (Storage, error) {
+	if rawurl == "" {
+		return nil, errors.New("empty backup storage")
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "", "file":
+		dir := u.Path
+		if u.Scheme == "" {
+			dir = rawurl
+		}
+		return newLocalStorage(dir)
+	case "s3":
+		return newS3Storage(u)
+	default:
+		return nil, fmt.Errorf("unsupported backup storage %s", u.Scheme)
+	}
+}
+
+type localStorage struct {
+	dir string
+}
+
+func newLocalStorage(dir string) (*localStorage, error) {
+	if dir == "" {
+		return nil, errors.New("empty backup dir")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &localStorage{dir: dir}, nil
+}
+
+type localFile struct {
+	*os.File
+	name string
+}
+
+// Close publishes the file by renaming, a half written file is never visible.
+func (f *localFile) Close() error {
+	if err := f.File.Sync(); err != nil {
+		f.File.Close()
+		return err
+	}
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.File.Name(), f.name)
+}
+
+// Abort removes the temp file.
+func (f *localFile) Abort() error {
+	f.File.Close()
+	return os.Remove(f.File.Name())
+}
+
+func (s *localStorage) Create(name string) (Writer, error) {
+	name = filepath.Join(s.dir, name)
+	f, err := os.OpenFile(name+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &localFile{File: f, name: name}, nil
+}
+
+func (s *localStorage) Open(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, name))
+}
+
+func (s *localStorage) String() string {
+	return "file://" + s.dir
+}
+
+type s3Storage struct {
+	endpoint  *url.URL
+	bucket    string
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func newS3Storage(u *url.URL) (*s3Storage, error) {
+	if u.Host == "" {
+		return nil, errors.New("empty s3 bucket")
+	}
+	q := u.Query()
+	endpoint := q.Get("endpoint")
+	if endpoint == "" {
+		endpoint = "https://s3.amazonaws.com"
+	}
+	ep, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	s := &s3Storage{
+		endpoint:  ep,
+		bucket:    u.Host,
+		prefix:    strings.Trim(u.Path, "/"),
+		region:    q.Get("region"),
+		accessKey: q.Get("access-key"),
+		secretKey: q.Get("secret-key"),
+		client:    &http.Client{Timeout: 30 * time.Minute},
+	}
+	if s.region == "" {
+		s.region = "us-east-1"
+	}
+	if s.accessKey == "" {
+		s.accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	if s.secretKey == "" {
+		s.secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	return s, nil
+}
+
+// objectURL uses path style addressing which is supported by every s3
+// compatible server.
+func (s *s3Storage) objectURL(name string) *url.URL {
+	u := *s.endpoint
+	u.Path = "/" + path.Join(s.bucket, s.prefix, name)
+	return &u
+}
+
+// s3Writer spools the object to a local temp file and uploads it on Close,
+// so that the content length is known.
+type s3Writer struct {
+	*os.File
+	s    *s3Storage
+	name string
+}
+
+func (w *s3Writer) Close() error {
+	defer os.Remove(w.File.Name())
+	defer w.File.Close()
+	info, err := w.File.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err = w.File.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, w.s.objectURL(w.name).String(), w.File)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	resp, err := w.s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Abort removes the spooled file without uploading.
+func (w *s3Writer) Abort() error {
+	w.File.Close()
+	return os.Remove(w.File.Name())
+}
+
+func (s *s3Storage) Create(name string) (Writer, error) {
+	f, err := ioutil.TempFile("", "sharkstore-backup-")
+	if err != nil {
+		return nil, err
+	}
+	return &s3Writer{File: f, s: s, name: name}, nil
+}
+
+func (s *s3Storage) Open(name string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(name).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Storage) String() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.prefix)
+}
+
+func (s *s3Storage) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, time.Now().UTC())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 %s %s status %d: %s", req.Method, req.URL.Path, resp.StatusCode, string(msg))
+	}
+	return resp, nil
+}
+
+// sign signs the request with aws signature version 4, the payload is not signed.
+func (s *s3Storage) sign(req *http.Request, now time.Time) {
+	if s.accessKey == "" {
+		return
+	}
+	const payload = "UNSIGNED-PAYLOAD"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payload + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payload,
+	}, "\n")
+	scope := strings.Join([]string{date, s.region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSha256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSha256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSha256(key, s.region)
+	key = hmacSha256(key, "s3")
+	key = hmacSha256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSha256(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
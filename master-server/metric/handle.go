@@ -68,6 +68,27 @@ func (m *Metric) doSlowLogMetric(ctx *Context, data []byte) error {
 	return nil
 }
 
+func (m *Metric) doStatementMetric(ctx *Context, data []byte) error {
+	stmtStats := new(statspb.StatementStats)
+	err := json.Unmarshal(data, stmtStats)
+	if err != nil {
+		log.Warn("encode statement stats[%s] failed, err[%v]", string(data), err)
+		return err
+	}
+	cluster := m.getCluster(ctx.clusterId)
+	if cluster == nil {
+		log.Warn("invalid cluster")
+		return nil
+	}
+	item := cluster.GetProcessItem(ctx.subsystem)
+	if item == nil {
+		log.Warn("Statement: process %s not found", ctx.subsystem)
+		return nil
+	}
+	item.Statement = stmtStats
+	return nil
+}
+
 func (m *Metric) doMacMetric(ctx *Context, data []byte) error {
 	macStats := new(statspb.MacStats)
 	err := json.Unmarshal(data, macStats)
@@ -580,6 +601,46 @@ func (m *Metric) handleSlowLogMetric(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+func (m *Metric) handleStatementMetric(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	clusterId, err := strconv.ParseUint(r.FormValue("clusterId"), 10, 64)
+	if err != nil {
+		reply.Code = -1
+		reply.Message = "invalid param"
+		log.Warn("invalid param clusterId, err[%v]", err)
+		return
+	}
+	namespace := r.FormValue("namespace")
+	addr := r.FormValue("subsystem")
+	log.Debug("recv cluster[%d] statement metric, type[%s], addr[%s]", clusterId, namespace, addr)
+	bufferLen := int(r.ContentLength)
+	if bufferLen <= 0 || bufferLen > 1024*1024*10 {
+		bufferLen = 512
+	}
+	buffer := bufalloc.AllocBuffer(bufferLen)
+	defer bufalloc.FreeBuffer(buffer)
+	if _, err = buffer.ReadFrom(r.Body); err != nil {
+		reply.Code = -1
+		reply.Message = err.Error()
+		log.Warn("read request failed, err[%v]", err)
+		return
+	}
+	ctx := &Context{
+		clusterId: clusterId,
+		namespace: namespace,
+		subsystem: addr,
+	}
+	err = m.doStatementMetric(ctx, buffer.Bytes())
+	if err != nil {
+		reply.Code = -1
+		reply.Message = err.Error()
+		log.Warn("do statement metric failed, err[%v]", err)
+		return
+	}
+	return
+}
+
 func (m *Metric) handleDbMetric(w http.ResponseWriter, r *http.Request) {
 	reply := &httpReply{}
 	defer sendReply(w, reply)
@@ -1265,6 +1326,45 @@ func (m *Metric) handleGetClusterMetric(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+// handleGetStatementMetric returns the top n statements of all the gateways
+// in the order of latency, count, errors, examined or last_seen.
+func (m *Metric) handleGetStatementMetric(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	clusterId, err := strconv.ParseUint(r.FormValue("clusterId"), 10, 64)
+	if err != nil {
+		reply.Code = -1
+		reply.Message = "invalid param"
+		log.Warn("invalid param clusterId, err[%v]", err)
+		return
+	}
+	var topN int
+	if value := r.FormValue("topN"); len(value) > 0 {
+		if topN, err = strconv.Atoi(value); err != nil || topN < 0 {
+			reply.Code = -1
+			reply.Message = "invalid param"
+			log.Warn("invalid param topN %s", value)
+			return
+		}
+	}
+	cluster := m.getCluster(clusterId)
+	if cluster == nil {
+		msg := fmt.Sprintf("invalid cluster %d", clusterId)
+		log.Warn("%s", msg)
+		reply.Code = -1
+		reply.Message = msg
+		return
+	}
+	var stats []*statspb.StatementStats
+	for _, proc := range cluster.ProcessCache.GetAll() {
+		if proc.Type == "GS" && proc.Statement != nil {
+			stats = append(stats, proc.Statement)
+		}
+	}
+	reply.Code = 0
+	reply.Data = topStatements(mergeStatements(stats), r.FormValue("order"), topN)
+	return
+}
 
 type httpReply struct {
 	Code    int         `json:"code"`
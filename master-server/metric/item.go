@@ -256,6 +256,8 @@ type ProcessItem struct {
 	Item *statspb.ProcessStats
 
 	SlowLog *statspb.SlowLogStats
+	// 网关上报的语句统计, 累计值, 只保留最新一次
+	Statement *statspb.StatementStats
 }
 
 type SlowLogItem struct {
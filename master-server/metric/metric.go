@@ -18,6 +18,7 @@ import (
 const (
 	TYPE_SQL           = "sql"
 	TYPE_ELASTICSEARCH = "elasticsearch"
+	TYPE_TSDB          = "tsdb"
 
 	NAMESPACE_CLUSTER  = "cluster"
 	NAMESPACE_MAC      = "mac"
@@ -128,6 +129,7 @@ func (m *Metric) initHttpHandle() {
 	svr.Handle("/metric/mac", m.handleMacMetric)
 	svr.Handle("/metric/process", m.handleProcessMetric)
 	svr.Handle("/metric/slowlog", m.handleSlowLogMetric)
+	svr.Handle("/metric/statement", m.handleStatementMetric)
 	svr.Handle("/metric/db", m.handleDbMetric)
 	svr.Handle("/metric/table", m.handleTableMetric)
 	svr.Handle("/metric/cluster", m.handleClusterMetric)
@@ -141,6 +143,7 @@ func (m *Metric) initHttpHandle() {
 	svr.Handle("/metric/get/db", m.handleGetDbMetric)
 	svr.Handle("/metric/get/table", m.handleGetTableMetric)
 	svr.Handle("/metric/get/cluster", m.handleGetClusterMetric)
+	svr.Handle("/metric/get/statement", m.handleGetStatementMetric)
 	svr.Handle("/metric/tcp/process", m.handleTcpProcessMetric)
 	svr.Handle("/metric/tcp/mac", m.handleTcpMacMetric)
 	svr.Handle("/metric/tcp/cluster", m.handleTcpClusterMetric)
@@ -0,0 +1,169 @@
+package metric
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"util/log"
+)
+
+const (
+	spillFilePrefix = "spill-"
+	spillFileSuffix = ".log"
+)
+
+// spillQueue is a disk backed FIFO of point batches which could not be
+// delivered to the sink, every batch is kept in its own segment file so
+// that a replayed batch can be acknowledged by removing the file.
+type spillQueue struct {
+	dir     string
+	maxSize uint64
+
+	lock sync.Mutex
+	seq  uint64
+}
+
+func newSpillQueue(dir string, maxSize uint64) (*spillQueue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	q := &spillQueue{dir: dir, maxSize: maxSize}
+	// continue the sequence of segments left by the last process
+	for _, name := range q.segments() {
+		var seq uint64
+		if _, err := fmt.Sscanf(strings.TrimSuffix(strings.TrimPrefix(name, spillFilePrefix), spillFileSuffix), "%d", &seq); err == nil && seq > q.seq {
+			q.seq = seq
+		}
+	}
+	return q, nil
+}
+
+// segments returns the segment file names in FIFO order.
+func (q *spillQueue) segments() []string {
+	infos, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		log.Warn("read spill dir[%s] failed, err[%v]", q.dir, err)
+		return nil
+	}
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasPrefix(info.Name(), spillFilePrefix) || !strings.HasSuffix(info.Name(), spillFileSuffix) {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (q *spillQueue) size() uint64 {
+	var size uint64
+	for _, name := range q.segments() {
+		if info, err := os.Stat(filepath.Join(q.dir, name)); err == nil {
+			size += uint64(info.Size())
+		}
+	}
+	return size
+}
+
+// Push appends a batch to the tail of the queue, the oldest segments are
+// dropped when the queue grows over its size limit.
+func (q *spillQueue) Push(points []*TsdbPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.seq++
+	name := filepath.Join(q.dir, fmt.Sprintf("%s%020d%s", spillFilePrefix, q.seq, spillFileSuffix))
+	f, err := os.OpenFile(name+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, p := range points {
+		if err = enc.Encode(p); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		os.Remove(name + ".tmp")
+		return err
+	}
+	if err = os.Rename(name+".tmp", name); err != nil {
+		return err
+	}
+
+	if q.maxSize > 0 {
+		for size := q.size(); size > q.maxSize; size = q.size() {
+			segments := q.segments()
+			if len(segments) <= 1 {
+				break
+			}
+			log.Error("metric spill queue is full, size[%d] limit[%d], drop segment[%s]", size, q.maxSize, segments[0])
+			os.Remove(filepath.Join(q.dir, segments[0]))
+		}
+	}
+	return nil
+}
+
+// Peek returns the batch at the head of the queue together with the
+// segment name used to acknowledge it, an empty name means the queue is empty.
+func (q *spillQueue) Peek() (string, []*TsdbPoint, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	segments := q.segments()
+	if len(segments) == 0 {
+		return "", nil, nil
+	}
+	name := segments[0]
+	f, err := os.Open(filepath.Join(q.dir, name))
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	var points []*TsdbPoint
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		p := new(TsdbPoint)
+		if err := dec.Decode(p); err != nil {
+			// a broken segment could never be replayed, drop it
+			log.Error("decode spill segment[%s] failed, err[%v], drop it", name, err)
+			os.Remove(filepath.Join(q.dir, name))
+			return "", nil, err
+		}
+		points = append(points, p)
+	}
+	return name, points, nil
+}
+
+// Ack removes the segment returned by Peek.
+func (q *spillQueue) Ack(name string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return os.Remove(filepath.Join(q.dir, name))
+}
+
+func (q *spillQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.segments())
+}
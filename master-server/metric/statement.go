@@ -0,0 +1,103 @@
+package metric
+
+import (
+	"sort"
+
+	"model/pkg/statspb"
+	"util/metrics"
+)
+
+// StatementItem is a statement digest merged from all the gateways, the
+// latencies are in microseconds.
+type StatementItem struct {
+	*statspb.StatementDigest
+	// 上报该语句的网关数
+	Gateways    int    `json:"gateways"`
+	AvgLatency  uint64 `json:"avg_latency"`
+	P50Latency  uint64 `json:"p50_latency"`
+	P95Latency  uint64 `json:"p95_latency"`
+	P99Latency  uint64 `json:"p99_latency"`
+	AvgExamined uint64 `json:"avg_rows_examined"`
+	AvgReturned uint64 `json:"avg_rows_returned"`
+}
+
+// mergeStatements merges the digests of the same database and digest
+// reported by different gateways.
+func mergeStatements(stats []*statspb.StatementStats) []*StatementItem {
+	type key struct {
+		db     string
+		digest string
+	}
+	merged := make(map[key]*StatementItem)
+	var items []*StatementItem
+	for _, s := range stats {
+		for _, d := range s.GetDigests() {
+			k := key{db: d.GetDb(), digest: d.GetDigest()}
+			item, find := merged[k]
+			if !find {
+				digest := *d
+				digest.LatencyBuckets = append([]uint64(nil), d.GetLatencyBuckets()...)
+				item = &StatementItem{StatementDigest: &digest, Gateways: 1}
+				merged[k] = item
+				items = append(items, item)
+				continue
+			}
+			m := item.StatementDigest
+			m.Count += d.GetCount()
+			m.Errors += d.GetErrors()
+			m.SumLatency += d.GetSumLatency()
+			if d.GetMaxLatency() > m.MaxLatency {
+				m.MaxLatency = d.GetMaxLatency()
+			}
+			if d.GetMinLatency() < m.MinLatency {
+				m.MinLatency = d.GetMinLatency()
+			}
+			m.LatencyBuckets = metrics.MergeLatencyBuckets(m.LatencyBuckets, d.GetLatencyBuckets())
+			m.RowsExamined += d.GetRowsExamined()
+			m.RowsReturned += d.GetRowsReturned()
+			if d.GetFirstSeen() < m.FirstSeen {
+				m.FirstSeen = d.GetFirstSeen()
+			}
+			if d.GetLastSeen() > m.LastSeen {
+				m.LastSeen = d.GetLastSeen()
+			}
+			item.Gateways++
+		}
+	}
+	for _, item := range items {
+		d := item.StatementDigest
+		if d.Count > 0 {
+			item.AvgLatency = d.SumLatency / d.Count
+			item.AvgExamined = d.RowsExamined / d.Count
+			item.AvgReturned = d.RowsReturned / d.Count
+		}
+		item.P50Latency = metrics.LatencyPercentile(d.LatencyBuckets, d.MaxLatency, 0.5)
+		item.P95Latency = metrics.LatencyPercentile(d.LatencyBuckets, d.MaxLatency, 0.95)
+		item.P99Latency = metrics.LatencyPercentile(d.LatencyBuckets, d.MaxLatency, 0.99)
+	}
+	return items
+}
+
+// topStatements returns the top n statements in descending order of the
+// total latency, count, errors, examined rows or last seen, n is not
+// limited if 0.
+func topStatements(items []*StatementItem, order string, n int) []*StatementItem {
+	var value func(item *StatementItem) uint64
+	switch order {
+	case "count":
+		value = func(item *StatementItem) uint64 { return item.Count }
+	case "errors":
+		value = func(item *StatementItem) uint64 { return item.Errors }
+	case "examined":
+		value = func(item *StatementItem) uint64 { return item.RowsExamined }
+	case "last_seen":
+		value = func(item *StatementItem) uint64 { return uint64(item.LastSeen) }
+	default:
+		value = func(item *StatementItem) uint64 { return item.SumLatency }
+	}
+	sort.SliceStable(items, func(i, j int) bool { return value(items[i]) > value(items[j]) })
+	if n > 0 && len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
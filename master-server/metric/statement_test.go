@@ -0,0 +1,52 @@
+package metric
+
+import (
+	"testing"
+
+	"model/pkg/statspb"
+)
+
+func TestMergeStatements(t *testing.T) {
+	gs1 := &statspb.StatementStats{Digests: []*statspb.StatementDigest{
+		{Digest: "a", Db: "db1", Count: 2, Errors: 1, SumLatency: 3000, MaxLatency: 2000, MinLatency: 1000,
+			LatencyBuckets: []uint64{0, 1, 1}, RowsExamined: 10, FirstSeen: 100, LastSeen: 200},
+		{Digest: "b", Db: "db1", Count: 10, SumLatency: 1000, MaxLatency: 100, MinLatency: 100,
+			LatencyBuckets: []uint64{10}, LastSeen: 300},
+	}}
+	gs2 := &statspb.StatementStats{Digests: []*statspb.StatementDigest{
+		{Digest: "a", Db: "db1", Count: 2, SumLatency: 5000, MaxLatency: 4000, MinLatency: 500,
+			LatencyBuckets: []uint64{1, 0, 0, 1}, RowsExamined: 6, FirstSeen: 50, LastSeen: 150},
+		{Digest: "a", Db: "db2", Count: 1, SumLatency: 100, MaxLatency: 100, MinLatency: 100,
+			LatencyBuckets: []uint64{1}, LastSeen: 400},
+	}}
+
+	items := mergeStatements([]*statspb.StatementStats{gs1, gs2})
+	if len(items) != 3 {
+		t.Fatalf("expected 3 digests, actual %d", len(items))
+	}
+	a := items[0]
+	if a.Gateways != 2 || a.Count != 4 || a.Errors != 1 || a.SumLatency != 8000 || a.MaxLatency != 4000 ||
+		a.MinLatency != 500 || a.RowsExamined != 16 || a.FirstSeen != 50 || a.LastSeen != 200 {
+		t.Fatalf("unexpected merged digest %+v", a)
+	}
+	if a.AvgLatency != 2000 || a.AvgExamined != 4 || a.P50Latency != 1000 || a.P99Latency != 4000 {
+		t.Fatalf("unexpected merged digest %+v", a)
+	}
+	// 合并不修改上报的数据
+	if gs1.Digests[0].Count != 2 || len(gs1.Digests[0].LatencyBuckets) != 3 {
+		t.Fatalf("reported digest modified %+v", gs1.Digests[0])
+	}
+
+	top := topStatements(items, "", 2)
+	if len(top) != 2 || top[0].Digest != "a" || top[0].Db != "db1" || top[1].Digest != "b" {
+		t.Fatalf("unexpected top by latency %v", top)
+	}
+	top = topStatements(items, "count", 1)
+	if len(top) != 1 || top[0].Digest != "b" {
+		t.Fatalf("unexpected top by count %v", top)
+	}
+	top = topStatements(items, "last_seen", 0)
+	if len(top) != 3 || top[0].Db != "db2" {
+		t.Fatalf("unexpected top by last seen %v", top)
+	}
+}
@@ -0,0 +1,416 @@
+package metric
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"util"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultTsdbBatchSize     = 500
+	defaultTsdbFlushInterval = 5 * time.Second
+	defaultTsdbMaxRetry      = 3
+	defaultTsdbRetryInterval = time.Second
+	defaultTsdbSpillDir      = "/tmp/sharkstore/metric-spill"
+	defaultTsdbSpillMaxSize  = 1024 * 1024 * 1024
+	defaultTsdbPutPath       = "/api/put"
+
+	tsdbMetricPrefix = "sharkstore"
+)
+
+// fields which are numeric but identify the series rather than measure it
+var tsdbTagFields = map[string]bool{
+	"cluster_id": true,
+	"node_id":    true,
+	"range_id":   true,
+	"task_id":    true,
+}
+
+// fields which are never written as metric or tag
+var tsdbSkipFields = map[string]bool{
+	"update_time": true,
+	"su":          true,
+	"slowlog":     true,
+	"describe":    true,
+}
+
+type TsdbConfig struct {
+	// http path of the put api, relative to every store url
+	PutPath       string        `toml:"put-path,omitempty" json:"put-path"`
+	BatchSize     uint64        `toml:"batch-size,omitempty" json:"batch-size"`
+	FlushInterval util.Duration `toml:"flush-interval,omitempty" json:"flush-interval"`
+	MaxRetry      uint64        `toml:"max-retry,omitempty" json:"max-retry"`
+	RetryInterval util.Duration `toml:"retry-interval,omitempty" json:"retry-interval"`
+	// batches which could not be delivered are spilled here and replayed later
+	SpillDir     string `toml:"spill-dir,omitempty" json:"spill-dir"`
+	SpillMaxSize uint64 `toml:"spill-max-size,omitempty" json:"spill-max-size"`
+}
+
+func (c *TsdbConfig) adjust() {
+	if c.PutPath == "" {
+		c.PutPath = defaultTsdbPutPath
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultTsdbBatchSize
+	}
+	if c.FlushInterval.Duration == 0 {
+		c.FlushInterval.Duration = defaultTsdbFlushInterval
+	}
+	if c.MaxRetry == 0 {
+		c.MaxRetry = defaultTsdbMaxRetry
+	}
+	if c.RetryInterval.Duration == 0 {
+		c.RetryInterval.Duration = defaultTsdbRetryInterval
+	}
+	if c.SpillDir == "" {
+		c.SpillDir = defaultTsdbSpillDir
+	}
+	if c.SpillMaxSize == 0 {
+		c.SpillMaxSize = defaultTsdbSpillMaxSize
+	}
+}
+
+// TsdbPoint is one sample in the OpenTSDB put format, which is accepted by
+// OpenTSDB as well as most remote-write compatible time series databases.
+type TsdbPoint struct {
+	Metric    string            `json:"metric"`
+	Timestamp int64             `json:"timestamp"`
+	Value     float64           `json:"value"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// TsdbStore pushes every numeric field of a message item as one time series:
+//
+//	metric: sharkstore.<subsystem>.<field>
+//	tags:   string fields and the id fields of the item
+type TsdbStore struct {
+	urls   []string
+	conf   TsdbConfig
+	client *http.Client
+	spill  *spillQueue
+
+	message chan *Message
+	next    uint64
+
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewTsdbStore(urls []string, conf TsdbConfig) Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	conf.adjust()
+	return &TsdbStore{
+		urls:    urls,
+		conf:    conf,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		message: make(chan *Message, 100000),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (s *TsdbStore) Open() error {
+	if len(s.urls) == 0 {
+		return errors.New("invalid tsdb store url")
+	}
+	spill, err := newSpillQueue(s.conf.SpillDir, s.conf.SpillMaxSize)
+	if err != nil {
+		log.Error("open tsdb spill queue[%s] failed, err[%v]", s.conf.SpillDir, err)
+		return err
+	}
+	s.spill = spill
+
+	s.wg.Add(2)
+	go s.work()
+	go s.replay()
+	return nil
+}
+
+func (s *TsdbStore) Put(message *Message) error {
+	select {
+	case s.message <- message:
+	default:
+		log.Error("metric message queue is full!!!")
+		return errors.New("message queue is full")
+	}
+	return nil
+}
+
+func (s *TsdbStore) Close() {
+	s.cancel()
+	s.wg.Wait()
+	return
+}
+
+func (s *TsdbStore) work() {
+	log.Info("start pusher metric to tsdb")
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.conf.FlushInterval.Duration)
+	defer ticker.Stop()
+	batch := make([]*TsdbPoint, 0, s.conf.BatchSize)
+	for {
+		select {
+		case <-s.ctx.Done():
+			// keep what is left for the next process, including the messages
+			// still queued
+			s.spillQueued(batch)
+			log.Info("pusher stopped to tsdb: %v", s.ctx.Err())
+			return
+		case msg, ok := <-s.message:
+			if !ok {
+				continue
+			}
+			points, err := messageToPoints(msg)
+			if err != nil {
+				log.Warn("convert metric[%s] to tsdb points failed, err[%v]", msg.Subsystem, err)
+				continue
+			}
+			batch = append(batch, points...)
+			if uint64(len(batch)) >= s.conf.BatchSize {
+				s.flush(batch)
+				batch = make([]*TsdbPoint, 0, s.conf.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				s.flush(batch)
+				batch = make([]*TsdbPoint, 0, s.conf.BatchSize)
+			}
+		}
+	}
+}
+
+// spillQueued moves the batch and the queued messages to the spill queue.
+func (s *TsdbStore) spillQueued(batch []*TsdbPoint) {
+	for {
+		select {
+		case msg := <-s.message:
+			points, err := messageToPoints(msg)
+			if err != nil {
+				log.Warn("convert metric[%s] to tsdb points failed, err[%v]", msg.Subsystem, err)
+				continue
+			}
+			batch = append(batch, points...)
+			if uint64(len(batch)) >= s.conf.BatchSize {
+				s.spillPoints(batch)
+				batch = make([]*TsdbPoint, 0, s.conf.BatchSize)
+			}
+		default:
+			if len(batch) > 0 {
+				s.spillPoints(batch)
+			}
+			return
+		}
+	}
+}
+
+func (s *TsdbStore) spillPoints(batch []*TsdbPoint) {
+	if err := s.spill.Push(batch); err != nil {
+		log.Error("spill %d metric points failed, err[%v]", len(batch), err)
+	}
+}
+
+func (s *TsdbStore) flush(batch []*TsdbPoint) {
+	if err := s.sendWithRetry(batch); err != nil {
+		log.Warn("push %d metric points to tsdb failed, spill to disk, err[%v]", len(batch), err)
+		s.spillPoints(batch)
+	}
+}
+
+// replay drains the spill queue while the sink is healthy.
+func (s *TsdbStore) replay() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.conf.FlushInterval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				name, points, err := s.spill.Peek()
+				if err != nil {
+					log.Warn("read metric spill queue failed, err[%v]", err)
+					break
+				}
+				if name == "" {
+					break
+				}
+				if err := s.send(points); err != nil {
+					log.Debug("replay spilled metric[%s] failed, err[%v]", name, err)
+					break
+				}
+				if err := s.spill.Ack(name); err != nil {
+					log.Warn("ack spilled metric[%s] failed, err[%v]", name, err)
+					break
+				}
+				log.Info("replay spilled metric[%s] success, %d points", name, len(points))
+				if s.ctx.Err() != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *TsdbStore) sendWithRetry(points []*TsdbPoint) error {
+	var err error
+	for i := uint64(0); i < s.conf.MaxRetry; i++ {
+		if err = s.send(points); err == nil {
+			return nil
+		}
+		log.Debug("push metric points to tsdb failed, retry %d, err[%v]", i+1, err)
+		select {
+		case <-s.ctx.Done():
+			return err
+		case <-time.After(s.conf.RetryInterval.Duration * time.Duration(i+1)):
+		}
+	}
+	return err
+}
+
+// send posts the batch to the next sink url, urls are used round robin
+func (s *TsdbStore) send(points []*TsdbPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(points)
+	if err != nil {
+		return err
+	}
+	url := s.urls[atomic.AddUint64(&s.next, 1)%uint64(len(s.urls))]
+	url = strings.TrimSuffix(url, "/") + s.conf.PutPath
+	resp, err := s.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("tsdb[%s] response status %d: %s", url, resp.StatusCode, string(msg))
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+func messageToPoints(msg *Message) ([]*TsdbPoint, error) {
+	if msg.Items == nil {
+		return nil, errors.New("empty items")
+	}
+	var points []*TsdbPoint
+	switch reflect.TypeOf(msg.Items).Kind() {
+	case reflect.Slice:
+		values, ok := msg.Items.([]interface{})
+		if !ok || len(values) == 0 {
+			return nil, errors.New("empty items")
+		}
+		for _, value := range values {
+			item, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("not support")
+			}
+			points = append(points, itemToPoints(msg.ClusterId, msg.Subsystem, item)...)
+		}
+	case reflect.Map:
+		item, ok := msg.Items.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("not support")
+		}
+		points = itemToPoints(msg.ClusterId, msg.Subsystem, item)
+	default:
+		return nil, errors.New("not support")
+	}
+	if len(points) == 0 {
+		return nil, errors.New("empty items")
+	}
+	return points, nil
+}
+
+func itemToPoints(clusterId uint64, subsystem string, item map[string]interface{}) []*TsdbPoint {
+	tags := make(map[string]string)
+	tags["cluster_id"] = fmt.Sprintf("%d", clusterId)
+	values := make(map[string]float64)
+	timestamp := time.Now().Unix()
+	for key, value := range item {
+		if value == nil {
+			continue
+		}
+		if key == "update_time" {
+			if v, ok := toFloat(value); ok && v > 0 {
+				timestamp = int64(v)
+			}
+			continue
+		}
+		if tsdbSkipFields[key] {
+			continue
+		}
+		if tsdbTagFields[key] {
+			tags[key] = sanitizeTsdb(fmt.Sprintf("%v", value))
+			continue
+		}
+		if v, ok := toFloat(value); ok {
+			values[key] = v
+			continue
+		}
+		if v, ok := value.(string); ok && v != "" {
+			tags[key] = sanitizeTsdb(v)
+		}
+	}
+
+	points := make([]*TsdbPoint, 0, len(values))
+	for key, value := range values {
+		points = append(points, &TsdbPoint{
+			Metric:    fmt.Sprintf("%s.%s.%s", tsdbMetricPrefix, subsystem, key),
+			Timestamp: timestamp,
+			Value:     value,
+			Tags:      tags,
+		})
+	}
+	return points
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// opentsdb only accepts a-z, A-Z, 0-9, -, _, . and / in tag values
+func sanitizeTsdb(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '-', r == '_', r == '.', r == '/':
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,203 @@
+package metric
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"util"
+)
+
+type tsdbStandIn struct {
+	lock   sync.Mutex
+	points []*TsdbPoint
+	down   int32
+}
+
+func (t *tsdbStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&t.down) == 1 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.URL.Path != defaultTsdbPutPath {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, _ := ioutil.ReadAll(r.Body)
+	var points []*TsdbPoint
+	if err := json.Unmarshal(data, &points); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	t.lock.Lock()
+	t.points = append(t.points, points...)
+	t.lock.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (t *tsdbStandIn) count() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.points)
+}
+
+func newTestTsdbStore(t *testing.T, url string) (*TsdbStore, string) {
+	dir, err := ioutil.TempDir("", "tsdb_spill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewTsdbStore([]string{url}, TsdbConfig{
+		BatchSize:     4,
+		FlushInterval: util.NewDuration(50 * time.Millisecond),
+		MaxRetry:      2,
+		RetryInterval: util.NewDuration(10 * time.Millisecond),
+		SpillDir:      dir,
+	}).(*TsdbStore)
+	if err := store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return store, dir
+}
+
+func waitFor(cond func() bool) bool {
+	for i := 0; i < 200; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestMessageToPoints(t *testing.T) {
+	meta := make(map[string]interface{})
+	meta["cluster_id"] = uint64(1)
+	meta["node_id"] = uint64(3)
+	meta["node_addr"] = "127.0.0.1:6060"
+	meta["total_written_bytes_as_peer"] = uint64(100)
+	meta["hot_write_region_as_leader"] = uint32(2)
+	meta["isbusy"] = true
+	meta["update_time"] = int64(1500000000)
+
+	points, err := messageToPoints(&Message{
+		ClusterId: 1,
+		Namespace: NAMESPACE_HOTSPOT,
+		Subsystem: METRIC_HOTSPOT,
+		Items:     meta,
+	})
+	if err != nil {
+		t.Fatalf("convert failed, err %v", err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("expect 3 points, got %d", len(points))
+	}
+	for _, p := range points {
+		if p.Timestamp != 1500000000 {
+			t.Errorf("invalid timestamp %d", p.Timestamp)
+		}
+		if p.Tags["node_id"] != "3" || p.Tags["node_addr"] != "127.0.0.1_6060" || p.Tags["cluster_id"] != "1" {
+			t.Errorf("invalid tags %v", p.Tags)
+		}
+		switch p.Metric {
+		case "sharkstore.hotspot_stats.total_written_bytes_as_peer":
+			if p.Value != 100 {
+				t.Errorf("invalid value %v", p.Value)
+			}
+		case "sharkstore.hotspot_stats.hot_write_region_as_leader":
+		case "sharkstore.hotspot_stats.isbusy":
+			if p.Value != 1 {
+				t.Errorf("invalid value %v", p.Value)
+			}
+		default:
+			t.Errorf("unexpected metric %s", p.Metric)
+		}
+	}
+
+	var stats []interface{}
+	for i := 0; i < 3; i++ {
+		stats = append(stats, map[string]interface{}{"range_id": uint64(i), "bytes_written": uint64(i)})
+	}
+	points, err = messageToPoints(&Message{ClusterId: 1, Subsystem: METRIC_RANGE_STATS, Items: stats})
+	if err != nil || len(points) != 3 {
+		t.Fatalf("convert range stats failed, points %d err %v", len(points), err)
+	}
+
+	if _, err = messageToPoints(&Message{Subsystem: METRIC_RANGE_STATS, Items: []interface{}{}}); err == nil {
+		t.Error("expect error for empty items")
+	}
+}
+
+func TestTsdbStorePush(t *testing.T) {
+	sink := &tsdbStandIn{}
+	ts := httptest.NewServer(sink)
+	defer ts.Close()
+	store, dir := newTestTsdbStore(t, ts.URL)
+	defer os.RemoveAll(dir)
+	defer store.Close()
+
+	for i := 0; i < 10; i++ {
+		store.Put(&Message{
+			ClusterId: 1,
+			Subsystem: METRIC_NODE_STATS,
+			Items:     map[string]interface{}{"node_id": uint64(i), "range_count": uint64(i)},
+		})
+	}
+	if !waitFor(func() bool { return sink.count() == 10 }) {
+		t.Fatalf("expect 10 points, got %d", sink.count())
+	}
+}
+
+func TestTsdbStoreSpill(t *testing.T) {
+	sink := &tsdbStandIn{down: 1}
+	ts := httptest.NewServer(sink)
+	defer ts.Close()
+	store, dir := newTestTsdbStore(t, ts.URL)
+	defer os.RemoveAll(dir)
+	defer store.Close()
+
+	for i := 0; i < 8; i++ {
+		store.Put(&Message{
+			ClusterId: 1,
+			Subsystem: METRIC_NODE_STATS,
+			Items:     map[string]interface{}{"node_id": uint64(i), "range_count": uint64(i)},
+		})
+	}
+	if !waitFor(func() bool { return store.spill.Len() == 2 }) {
+		t.Fatalf("expect 2 spilled batches, got %d", store.spill.Len())
+	}
+
+	atomic.StoreInt32(&sink.down, 0)
+	if !waitFor(func() bool { return sink.count() == 8 && store.spill.Len() == 0 }) {
+		t.Fatalf("expect spilled points replayed, got %d points, %d batches left", sink.count(), store.spill.Len())
+	}
+}
+
+func TestTsdbStoreCloseSpill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tsdb_spill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := NewTsdbStore([]string{"http://127.0.0.1:0"}, TsdbConfig{BatchSize: 4, SpillDir: dir}).(*TsdbStore)
+	if store.spill, err = newSpillQueue(dir, defaultTsdbSpillMaxSize); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 6; i++ {
+		store.Put(&Message{
+			ClusterId: 1,
+			Subsystem: METRIC_NODE_STATS,
+			Items:     map[string]interface{}{"node_id": uint64(i), "range_count": uint64(i)},
+		})
+	}
+	store.spillQueued(nil)
+	if store.spill.Len() != 2 || len(store.message) != 0 {
+		t.Fatalf("expect queued messages spilled in 2 batches, got %d batches, %d queued", store.spill.Len(), len(store.message))
+	}
+}
@@ -0,0 +1,409 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"master-server/backup"
+	"model/pkg/metapb"
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"util/hlc"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	HTTP_STORAGE = "storage"
+	HTTP_JOB_ID  = "jobId"
+
+	BACKUP_JOB_BACKUP  = "backup"
+	BACKUP_JOB_RESTORE = "restore"
+
+	BACKUP_JOB_RUNNING  = "running"
+	BACKUP_JOB_SUCCESS  = "success"
+	BACKUP_JOB_FAILED   = "failed"
+	BACKUP_JOB_CANCELED = "canceled"
+
+	// finished jobs kept for query
+	maxFinishedBackupJobs = 100
+	// how long restore waits for the new table to be created
+	restoreWaitTableTimeout = 10 * time.Minute
+)
+
+var ErrBackupJobNotExist = errors.New("backup job not exist")
+
+type BackupJob struct {
+	Id        uint64          `json:"id"`
+	Type      string          `json:"type"`
+	DbName    string          `json:"db_name"`
+	TableName string          `json:"table_name"`
+	TableId   uint64          `json:"table_id"`
+	Storage   string          `json:"storage"`
+	State     string          `json:"state"`
+	Error     string          `json:"error,omitempty"`
+	Progress  backup.Progress `json:"progress"`
+	StartTime int64           `json:"start_time"`
+	EndTime   int64           `json:"end_time,omitempty"`
+
+	progress *backup.Progress
+	cancel   context.CancelFunc
+}
+
+// BackupManager runs backup and restore jobs on the master leader, the jobs
+// only live in memory, a job interrupted by leader change must be started again.
+type BackupManager struct {
+	conf *BackupConfig
+	// addresses of the master rpc servers, used to route the requests
+	msAddrs []string
+
+	lock   sync.Mutex
+	jobs   map[uint64]*BackupJob
+	nextId uint64
+	env    *backup.Env
+}
+
+func NewBackupManager(conf *BackupConfig, msAddrs []string) *BackupManager {
+	return &BackupManager{
+		conf:    conf,
+		msAddrs: msAddrs,
+		jobs:    make(map[uint64]*BackupJob),
+	}
+}
+
+// getEnv connects the master and data servers on first use, the rpc server
+// of the master is not serving yet when the manager is created.
+func (m *BackupManager) getEnv() (*backup.Env, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.env != nil {
+		return m.env, nil
+	}
+	msCli, err := msClient.NewClient(m.msAddrs)
+	if err != nil {
+		return nil, err
+	}
+	m.env = &backup.Env{
+		MsClient:     msCli,
+		KvClient:     dsClient.NewRPCClient(int(m.conf.GrpcPoolSize)),
+		Clock:        hlc.NewClock(hlc.UnixNano, 0),
+		BatchSize:    m.conf.BatchSize,
+		Concurrency:  int(m.conf.Concurrency),
+		WriteTimeout: m.conf.Timeout.Duration,
+		ReadTimeout:  m.conf.Timeout.Duration,
+		ReadWait:     m.conf.ReadWait.Duration,
+	}
+	return m.env, nil
+}
+
+func (m *BackupManager) addJob(job *BackupJob) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.nextId++
+	job.Id = m.nextId
+	job.State = BACKUP_JOB_RUNNING
+	job.StartTime = time.Now().Unix()
+	job.progress = new(backup.Progress)
+	m.jobs[job.Id] = job
+
+	// drop the oldest finished jobs
+	var finished []*BackupJob
+	for _, j := range m.jobs {
+		if j.State != BACKUP_JOB_RUNNING {
+			finished = append(finished, j)
+		}
+	}
+	if len(finished) > maxFinishedBackupJobs {
+		sort.Slice(finished, func(i, j int) bool { return finished[i].Id < finished[j].Id })
+		for _, j := range finished[:len(finished)-maxFinishedBackupJobs] {
+			delete(m.jobs, j.Id)
+		}
+	}
+}
+
+func (m *BackupManager) finishJob(job *BackupJob, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job.EndTime = time.Now().Unix()
+	switch {
+	case err == nil:
+		job.State = BACKUP_JOB_SUCCESS
+	case err == context.Canceled:
+		job.State = BACKUP_JOB_CANCELED
+	default:
+		job.State = BACKUP_JOB_FAILED
+		job.Error = err.Error()
+	}
+	log.Info("%s job[%d] table[%s:%s] storage[%s] finished, state[%s] err[%v]",
+		job.Type, job.Id, job.DbName, job.TableName, job.Storage, job.State, err)
+}
+
+func (m *BackupManager) snapshot(job *BackupJob) *BackupJob {
+	j := *job
+	j.Progress = job.progress.Snapshot()
+	return &j
+}
+
+func (m *BackupManager) GetJob(id uint64) (*BackupJob, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, find := m.jobs[id]
+	if !find {
+		return nil, ErrBackupJobNotExist
+	}
+	return m.snapshot(job), nil
+}
+
+func (m *BackupManager) GetAllJobs() []*BackupJob {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	jobs := make([]*BackupJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, m.snapshot(job))
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+	return jobs
+}
+
+func (m *BackupManager) CancelJob(id uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, find := m.jobs[id]
+	if !find {
+		return ErrBackupJobNotExist
+	}
+	if job.State == BACKUP_JOB_RUNNING {
+		job.cancel()
+	}
+	return nil
+}
+
+// StartBackup backs up the table in background and returns the job.
+func (m *BackupManager) StartBackup(cluster *Cluster, table *Table, storage string) (*BackupJob, error) {
+	store, err := backup.NewStorage(storage)
+	if err != nil {
+		return nil, err
+	}
+	env, err := m.getEnv()
+	if err != nil {
+		return nil, err
+	}
+	var ranges []*metapb.Range
+	for _, r := range cluster.GetTableAllRanges(table.GetId()) {
+		ranges = append(ranges, r.Range)
+	}
+	t := &backup.Table{
+		ClusterId:  cluster.GetClusterId(),
+		DbId:       table.GetDbId(),
+		DbName:     table.GetDbName(),
+		TableId:    table.GetId(),
+		TableName:  table.GetName(),
+		Columns:    table.GetColumns(),
+		Regxs:      table.GetRegxs(),
+		PkDupCheck: table.GetPkDupCheck(),
+		Ranges:     ranges,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &BackupJob{
+		Type:      BACKUP_JOB_BACKUP,
+		DbName:    t.DbName,
+		TableName: t.TableName,
+		TableId:   t.TableId,
+		Storage:   store.String(),
+		cancel:    cancel,
+	}
+	m.addJob(job)
+	go func() {
+		defer cancel()
+		_, err := backup.Backup(ctx, env, t, store, job.progress)
+		m.finishJob(job, err)
+	}()
+	return m.snapshot(job), nil
+}
+
+// StartRestore creates a new table with the schema and split keys of the
+// backup, and writes the rows into it in background once it is running.
+func (m *BackupManager) StartRestore(cluster *Cluster, dbName, tableName, storage string) (*BackupJob, error) {
+	store, err := backup.NewStorage(storage)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := backup.ReadManifest(store)
+	if err != nil {
+		return nil, fmt.Errorf("read backup manifest from %s failed, err[%v]", store, err)
+	}
+	env, err := m.getEnv()
+	if err != nil {
+		return nil, err
+	}
+	if dbName == "" {
+		dbName = manifest.DbName
+	}
+	table, err := cluster.CreateTableWithEncodedKeys(dbName, tableName, manifest.Columns, manifest.Regxs,
+		manifest.PkDupCheck, manifest.SplitKeys, 0)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("restore table[%s:%s] from %s, create table[%d] with %d split keys",
+		dbName, tableName, store, table.GetId(), len(manifest.SplitKeys))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &BackupJob{
+		Type:      BACKUP_JOB_RESTORE,
+		DbName:    dbName,
+		TableName: tableName,
+		TableId:   table.GetId(),
+		Storage:   store.String(),
+		cancel:    cancel,
+	}
+	m.addJob(job)
+	go func() {
+		defer cancel()
+		err := waitTableRunning(ctx, cluster, table.GetId())
+		if err == nil {
+			err = backup.Restore(ctx, env, manifest, store, table.GetDbId(), table.GetId(), job.progress)
+		}
+		m.finishJob(job, err)
+	}()
+	return m.snapshot(job), nil
+}
+
+func waitTableRunning(ctx context.Context, cluster *Cluster, tableId uint64) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	timeout := time.After(restoreWaitTableTimeout)
+	for {
+		table, find := cluster.FindTableById(tableId)
+		if !find {
+			return ErrNotExistTable
+		}
+		if table.GetStatus() == metapb.TableStatus_TableRunning {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return fmt.Errorf("wait table[%d] running timeout, status[%v]", tableId, table.GetStatus())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (service *Server) handleTableBackup(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	dbName := r.FormValue(HTTP_DB_NAME)
+	tName := r.FormValue(HTTP_TABLE_NAME)
+	storage := r.FormValue(HTTP_STORAGE)
+	if dbName == "" || tName == "" || storage == "" {
+		log.Error("http backup table: %s", http_error_parameter_not_enough)
+		reply.Code = HTTP_ERROR_PARAMETER_NOT_ENOUGH
+		reply.Message = http_error_parameter_not_enough
+		return
+	}
+	db, find := service.cluster.FindDatabase(dbName)
+	if !find {
+		reply.Code = HTTP_ERROR_DATABASE_FIND
+		reply.Message = http_error_database_find
+		return
+	}
+	table, find := db.FindTable(tName)
+	if !find {
+		reply.Code = HTTP_ERROR_TABLE_FIND
+		reply.Message = http_error_table_find
+		return
+	}
+	if table.GetStatus() != metapb.TableStatus_TableRunning {
+		reply.Code = HTTP_ERROR
+		reply.Message = fmt.Sprintf("table status is %v", table.GetStatus())
+		return
+	}
+	job, err := service.backupManager.StartBackup(service.cluster, table, storage)
+	if err != nil {
+		log.Error("http backup table[%s:%s] to %s failed, err[%v]", dbName, tName, storage, err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http backup table[%s:%s] to %s, job[%d]", dbName, tName, storage, job.Id)
+	reply.Data = job
+}
+
+func (service *Server) handleTableRestore(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	dbName := r.FormValue(HTTP_DB_NAME)
+	tName := r.FormValue(HTTP_TABLE_NAME)
+	storage := r.FormValue(HTTP_STORAGE)
+	if tName == "" || storage == "" {
+		log.Error("http restore table: %s", http_error_parameter_not_enough)
+		reply.Code = HTTP_ERROR_PARAMETER_NOT_ENOUGH
+		reply.Message = http_error_parameter_not_enough
+		return
+	}
+	if len(service.cluster.GetAllActiveNode()) == 0 {
+		reply.Code = HTTP_ERROR
+		reply.Message = "cluster has no node"
+		return
+	}
+	job, err := service.backupManager.StartRestore(service.cluster, dbName, tName, storage)
+	if err != nil {
+		log.Error("http restore table[%s:%s] from %s failed, err[%v]", dbName, tName, storage, err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http restore table[%s:%s] from %s, job[%d]", job.DbName, tName, storage, job.Id)
+	reply.Data = job
+}
+
+func (service *Server) handleBackupJobQuery(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	if r.FormValue(HTTP_JOB_ID) == "" {
+		reply.Data = service.backupManager.GetAllJobs()
+		return
+	}
+	jobId, err := strconv.ParseUint(r.FormValue(HTTP_JOB_ID), 10, 64)
+	if err != nil {
+		reply.Code = HTTP_ERROR_INVALID_PARAM
+		reply.Message = http_error_invalid_parameter
+		return
+	}
+	job, err := service.backupManager.GetJob(jobId)
+	if err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = job
+}
+
+func (service *Server) handleBackupJobCancel(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	jobId, err := strconv.ParseUint(r.FormValue(HTTP_JOB_ID), 10, 64)
+	if err != nil {
+		reply.Code = HTTP_ERROR_INVALID_PARAM
+		reply.Message = http_error_invalid_parameter
+		return
+	}
+	if err = service.backupManager.CancelJob(jobId); err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http cancel backup job[%d]", jobId)
+}
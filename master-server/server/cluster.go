@@ -14,9 +14,11 @@ import (
 
 	sErr "master-server/engine/errors"
 	"model/pkg/metapb"
+	"model/pkg/mspb"
 	"model/pkg/taskpb"
 	"util"
 	"master-server/alarm2"
+	"master-server/backup"
 	"util/deepcopy"
 	"util/log"
 	"util/ttlcache"
@@ -39,6 +41,13 @@ var PREFIX_AUTO_TRANSFER_UNABLE string = fmt.Sprintf("schema%sauto_transfer_unab
 var PREFIX_AUTO_FAILOVER_UNABLE string = fmt.Sprintf("schema%sauto_failover_unable%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
 var PREFIX_AUTO_SPLIT_UNABLE string = fmt.Sprintf("schema%sauto_split_unable%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
 var PREFIX_METRIC string = fmt.Sprintf("schema%smetric_send%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_META_RECOVERY string = fmt.Sprintf("schema%smeta_recovery%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_UPGRADE string = fmt.Sprintf("schema%supgrade%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_UPGRADE_HISTORY string = fmt.Sprintf("schema%supgrade_history%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_SCHEDULER string = fmt.Sprintf("schema%sscheduler%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_DRY_RUN string = fmt.Sprintf("schema%sdry_run%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_TASK_HISTORY string = fmt.Sprintf("schema%stask_history%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+var PREFIX_RESOURCE_GROUP string = fmt.Sprintf("schema%sresource_group%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
 
 const (
 	dsAdminPoolSize = 2
@@ -69,6 +78,8 @@ type Cluster struct {
 	unhealthyRanges *ttlcache.TTLCache
 	//副本数不满足要求的range记录[不是实时的，不落盘]
 	unstableRanges *ttlcache.TTLCache
+	//SplitTable请求分裂中的range和请求时的version[不落盘]
+	splittingRanges *ttlcache.TTLCache
 
 	//落盘，切换leader时，load
 	preGCRanges *GlobalPreGCRange
@@ -96,6 +107,35 @@ type Cluster struct {
 	autoSplitUnable    bool
 
 	alarmCli *alarm2.Client
+
+	recoveryLock sync.RWMutex
+	// 元数据恢复中，不为nil
+	recovery *MetaRecovery
+
+	// 访问data server读写表数据, 用于清理已删除列的数据
+	backupEnv func() (*backup.Env, error)
+
+	upgradeLock sync.Mutex
+	// 进行中的滚动升级，没有时为nil
+	upgrade *UpgradePlan
+
+	schedulerLock sync.RWMutex
+	// 运行时添加的调度器，落盘，切换leader时load
+	schedulers map[string]*SchedulerConfig
+
+	dryRunLock sync.RWMutex
+	// dry-run的调度器，只记录任务不下发，落盘
+	dryRunWorkers map[string]bool
+	// 最近dry-run调度器提出的任务，不落盘
+	dryRunTasks []*DryRunTask
+
+	taskHistoryLock sync.RWMutex
+	// 已结束的任务和人工操作, 按结束时间排序, 落盘
+	taskHistory []*TaskRecord
+
+	resourceGroupLock sync.RWMutex
+	// gateway的资源组, 落盘, gateway定期拉取
+	resourceGroups map[string]*mspb.ResourceGroup
 }
 
 func NewCluster(clusterId, nodeId uint64, store Store, opt *scheduleOption) *Cluster {
@@ -114,10 +154,14 @@ func NewCluster(clusterId, nodeId uint64, store Store, opt *scheduleOption) *Clu
 		workingTables:   NewGlobalTableCache(),
 		deletingTables:  NewGlobalTableCache(),
 		unhealthyRanges: ttlcache.NewTTLCache(2 * time.Second * 60),
+		splittingRanges: ttlcache.NewTTLCache(time.Second * 60),
 		unstableRanges:  ttlcache.NewTTLCache(2 * time.Second * 60),
 		preGCRanges:     NewGlobalPreGCRange(),
 		deletedRanges:   NewGlobalDeletedRange(),
 		idGener:         NewClusterIDGenerator(store),
+		schedulers:      make(map[string]*SchedulerConfig),
+		dryRunWorkers:   make(map[string]bool),
+		resourceGroups:  make(map[string]*mspb.ResourceGroup),
 	}
 	cluster.workerPool = initWorkerPool()
 	cluster.workerManger = NewWorkerManager(cluster, opt)
@@ -167,6 +211,12 @@ func (c *Cluster) LoadCache() error {
 		return err
 	}
 
+	err = c.loadDroppedColumns()
+	if err != nil {
+		log.Error("load dropped columns from store failed, err[%v]", err)
+		return err
+	}
+
 	err = c.loadRanges()
 	if err != nil {
 		log.Error("load range from store failed, err[%v]", err)
@@ -197,6 +247,42 @@ func (c *Cluster) LoadCache() error {
 		return err
 	}
 
+	err = c.loadMetaRecovery()
+	if err != nil {
+		log.Error("load meta recovery from store failed, err[%v]", err)
+		return err
+	}
+
+	err = c.loadUpgrade()
+	if err != nil {
+		log.Error("load upgrade from store failed, err[%v]", err)
+		return err
+	}
+
+	err = c.loadSchedulers()
+	if err != nil {
+		log.Error("load schedulers from store failed, err[%v]", err)
+		return err
+	}
+
+	err = c.loadDryRunWorkers()
+	if err != nil {
+		log.Error("load dry-run workers from store failed, err[%v]", err)
+		return err
+	}
+
+	err = c.loadTaskHistory()
+	if err != nil {
+		log.Error("load task history from store failed, err[%v]", err)
+		return err
+	}
+
+	err = c.loadResourceGroups()
+	if err != nil {
+		log.Error("load resource groups from store failed, err[%v]", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -314,13 +400,23 @@ func (c *Cluster) FindPreGCRangeById(rangeId uint64) (*metapb.Range, bool) {
 }
 
 func (c *Cluster) EditTable(t *Table, properties string) error {
-	columns, err := EditProperties(properties)
+	columns, ttl, placement, err := EditProperties(properties)
 	if err != nil {
 		return err
 	}
-	err = t.MergeColumn(columns, c)
-	if err != nil {
-		return err
+	if columns != nil {
+		err = t.MergeColumn(columns, c)
+		if err != nil {
+			return err
+		}
+	}
+	if ttl != nil {
+		if err = t.UpdateTTL(*ttl, c); err != nil {
+			return err
+		}
+	}
+	if placement != nil {
+		return t.UpdatePlacement(placement, c)
 	}
 	return nil
 }
@@ -370,6 +466,22 @@ func encodeSplitKeys(keys [][]byte, columns []*metapb.Column) ([][]byte, error)
 // step 2. create range in remote
 // step 3. add range in cache and disk
 func (c *Cluster) CreateTable(dbName, tableName string, columns, regxs []*metapb.Column, pkDupCheck bool, sliceKeys [][]byte) (*Table, error) {
+	var keys [][]byte
+	if len(sliceKeys) != 0 {
+		_keys, err := encodeSplitKeys(sliceKeys, columns)
+		if err != nil {
+			log.Error("encode table preSplit keys failed(%v), keys: %v", err, _keys)
+			return nil, err
+		}
+		keys = _keys
+	}
+	return c.CreateTableWithEncodedKeys(dbName, tableName, columns, regxs, pkDupCheck, keys, 0)
+}
+
+// CreateTableWithEncodedKeys creates the table pre-split by keys which are
+// already encoded but have no table prefix, e.g. the split keys of a backup.
+// The rows of the table expire in ttl seconds unless it is zero.
+func (c *Cluster) CreateTableWithEncodedKeys(dbName, tableName string, columns, regxs []*metapb.Column, pkDupCheck bool, encodedKeys [][]byte, ttl uint64) (*Table, error) {
 	for _, col := range columns {
 		if isSqlReservedWord(col.Name) {
 			log.Warn("col[%s] is sql reserved word", col.Name)
@@ -411,20 +523,22 @@ func (c *Cluster) CreateTable(dbName, tableName string, columns, regxs []*metapb
 		CreateTime: time.Now().Unix(),
 		PkDupCheck: pkDupCheck,
 	}
+	if ttl > 0 {
+		props, err := ToTableProperty(columns, ttl, nil)
+		if err != nil {
+			return nil, err
+		}
+		t.Properties = props
+	}
 
 	var sharingKeys [][]byte
 	table := NewTable(t)
 	table.Status = metapb.TableStatus_TableInit
 	db.AddTable(table)
 	c.workingTables.Add(table)
-	if len(sliceKeys) != 0 {
-		_keys, err := encodeSplitKeys(sliceKeys, columns)
-		if err != nil {
-			log.Error("encode table preSplit keys failed(%v), keys: %v", err, _keys)
-			db.DeleteTableById(tableId)
-			c.workingTables.DeleteById(tableId)
-			return nil, err
-		}
+	if len(encodedKeys) != 0 {
+		_keys := make([][]byte, len(encodedKeys))
+		copy(_keys, encodedKeys)
 		sort.Sort(ByLetter(_keys))
 		var _sliceKeys [][]byte
 		for _, key := range _keys {
@@ -596,6 +710,10 @@ func (c *Cluster) GetAllWorker() map[string]bool {
 		_, found := temp[s]
 		workers[s] = found
 	}
+	// 运行时添加的调度器
+	for s := range temp {
+		workers[s] = true
+	}
 	return workers
 }
 
@@ -623,6 +741,26 @@ func (c *Cluster) AddRangeHbCheckWorker() {
 	c.workerManger.addWorker(NewRangeHbCheckWorker(c.workerManger, 2*time.Minute))
 }
 
+func (c *Cluster) AddDroppedColumnCleanWorker() {
+	c.workerManger.addWorker(NewDroppedColumnCleanWorker(c.workerManger, 10*time.Minute))
+}
+
+func (c *Cluster) AddTTLExpireWorker() {
+	c.workerManger.addWorker(NewTTLExpireWorker(c.workerManger, 10*time.Minute))
+}
+
+func (c *Cluster) AddDrainNodeWorker() {
+	c.workerManger.addWorker(NewDrainNodeWorker(c.workerManger, 5*time.Second))
+}
+
+func (c *Cluster) AddEvictLeaderWorker() {
+	c.workerManger.addWorker(NewEvictLeaderWorker(c.workerManger, 5*time.Second))
+}
+
+func (c *Cluster) AddUpgradeWorker() {
+	c.workerManger.addWorker(NewUpgradeWorker(c.workerManger, 5*time.Second))
+}
+
 func (c *Cluster) AddBalanceLeaderWorker() {
 	c.workerManger.addWorker(NewBalanceNodeLeaderWorker(c.workerManger, 5*defaultWorkerInterval))
 }
@@ -636,7 +774,10 @@ func (c *Cluster) AddBalanceNodeOpsWorker() {
 }
 
 func (c *Cluster) RemoveWorker(name string) error {
-	return c.workerManger.removeWorker(name)
+	if err := c.workerManger.removeWorker(name); err != nil {
+		return err
+	}
+	return c.removeScheduler(name)
 }
 
 // GetAllTasks return all tasks
@@ -1226,16 +1367,18 @@ func (c *Cluster) allocPeer(nodeId uint64, isLearner bool) (*metapb.Peer, error)
 /**
 选择节点需要排除已有peer相同的IP
 优先table的range分布少的node
+keepLearner的副本按learner的放置规则选择节点, 且不会被提升为投票成员
 */
-func (c *Cluster) allocPeerAndSelectNode(rng *Range, isLearner bool) (*metapb.Peer, error) {
-	node := c.selectNodeForAddPeer(rng)
+func (c *Cluster) allocPeerAndSelectNode(rng *Range, isLearner, keepLearner bool) (*metapb.Peer, error) {
+	node := c.selectNodeForAddPeer(rng, keepLearner)
 	if node == nil {
 		return nil, ERR_NO_SELECTED_NODE
 	}
-	newPeer, err := c.allocPeer(node.GetId(), isLearner)
+	newPeer, err := c.allocPeer(node.GetId(), isLearner || keepLearner)
 	if err != nil {
 		return nil, err
 	}
+	newPeer.KeepLearner = keepLearner
 
 	if node != nil {
 		node.stats.RangeCount = atomic.AddUint32(&node.stats.RangeCount, 1)
@@ -1264,7 +1407,7 @@ func (c *Cluster) newRangeByScope(startKey, endKey []byte, table *Table) (*Range
 		return nil, err
 	}
 	region := NewRange(rng, nil)
-	newPeer, err := c.allocPeerAndSelectNode(region, false)
+	newPeer, err := c.allocPeerAndSelectNode(region, false, false)
 	if err != nil {
 		return nil, err
 	}
@@ -1294,6 +1437,11 @@ func initWorkerPool() map[string]bool {
 	pool[trashReplicaGcWorkerName] = true
 	pool[createTableWorkerName] = true
 	pool[rangeHbCheckWorkerName] = true
+	pool[droppedColumnCleanWorkerName] = true
+	pool[ttlExpireWorkerName] = true
+	pool[drainNodeWorkerName] = true
+	pool[evictLeaderWorkerName] = true
+	pool[upgradeWorkerName] = true
 
 	pool[balanceRangeWorkerName] = true
 	pool[balanceLeaderWorkerName] = true
@@ -1301,11 +1449,23 @@ func initWorkerPool() map[string]bool {
 	return pool
 }
 
-func (c *Cluster) selectNodeForAddPeer(rng *Range) *Node {
-	candidateNodes := c.selectBestNodesForAddPeer(rng)
+func (c *Cluster) selectNodeForAddPeer(rng *Range, learner bool) *Node {
+	candidateNodes := c.selectBestNodesForAddPeer(rng, learner)
 	if len(candidateNodes) == 0 {
 		return nil
 	}
+	// 优先放置到placement规则偏好的节点
+	if rule := c.rangePlacement(rng); !learner && len(rule.PreferredLabels) > 0 {
+		var preferred []*Node
+		for _, node := range candidateNodes {
+			if rule.prefers(node) {
+				preferred = append(preferred, node)
+			}
+		}
+		if len(preferred) > 0 {
+			candidateNodes = preferred
+		}
+	}
 	if len(candidateNodes) == 1 {
 		return candidateNodes[0]
 	}
@@ -1354,10 +1514,11 @@ func (c *Cluster) selectBestNodeST(candidateNodes []*Node, rng *Range, rngStat m
 	return c.FindNodeById(nodeScope[0].nodeId)
 }
 
-func (c *Cluster) selectBestNodesForAddPeer(rng *Range) []*Node {
+func (c *Cluster) selectBestNodesForAddPeer(rng *Range, learner bool) []*Node {
 	newSelectors := []NodeSelector{
 		NewNodeLoginSelector(c.opt),
 		NewDifferIPSelector(rng.GetNodes(c)),
+		NewPlacementSelector(c.rangePlacement(rng), learner),
 		NewWriterOpsThresholdSelector(c.opt),
 		NewStorageThresholdSelector(c.opt),
 	}
@@ -1398,47 +1559,67 @@ func (c *Cluster) checkSameIpNode(nodes []*Node) (string, bool) {
 	return "", false
 }
 
-func (c *Cluster) selectWorstPeer(rng *Range) *metapb.Peer {
-	downs := rng.GetDownPeers()
-	if len(downs) > 0 {
-		return downs[0].Peer
+// selectWorstPeer 选择要删除的投票副本或者保持learner的副本
+func (c *Cluster) selectWorstPeer(rng *Range, learner bool) *metapb.Peer {
+	var peers []*metapb.Peer
+	for _, peer := range rng.GetPeers() {
+		if peer.GetKeepLearner() == learner {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) == 0 {
+		return nil
 	}
 
-	// 优先删除learner：
-	peers := rng.GetPeers()
+	for _, down := range rng.GetDownPeers() {
+		if peer := rng.GetPeer(down.Peer.GetId()); peer != nil && peer.GetKeepLearner() == learner {
+			return peer
+		}
+	}
+
+	// 优先删除不符合放置规则的副本
+	rule := c.rangePlacement(rng)
 	for _, peer := range peers {
-		if peer.GetType() == metapb.PeerType_PeerType_Learner {
+		if node := c.FindNodeById(peer.GetNodeId()); node != nil && !rule.allows(node, learner) {
 			return peer
 		}
 	}
 
+	// 优先删除learner：
+	if !learner {
+		for _, peer := range peers {
+			if peer.GetType() == metapb.PeerType_PeerType_Learner {
+				return peer
+			}
+		}
+	}
+
 	//TODO:复制位置落后的peer
 
-	// 检查相同ip的peer
 	var nodes []*Node
-	allNodes := rng.GetNodes(c)
-	ip, ok := c.checkSameIpNode(allNodes)
-	if ok {
-		nodes = func() (ret []*Node) {
-			for _, n := range allNodes {
-				nIp := strings.Split(n.GetServerAddr(), ":")[0]
-				if nIp == ip {
-					ret = append(ret, n)
-				}
+	for _, peer := range peers {
+		if node := c.FindNodeById(peer.GetNodeId()); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	// 检查相同ip的peer
+	if ip, ok := c.checkSameIpNode(rng.GetNodes(c)); ok {
+		var sameIpNodes []*Node
+		for _, n := range nodes {
+			nIp := strings.Split(n.GetServerAddr(), ":")[0]
+			if nIp == ip {
+				sameIpNodes = append(sameIpNodes, n)
 			}
-			return
-		}()
-	} else {
-		nodes = c.getRangeNodes(rng)
+		}
+		if len(sameIpNodes) > 0 {
+			nodes = sameIpNodes
+		}
 	}
 
 	var worstNode *Node
-
-	if worstNode == nil {
-		for _, node := range nodes {
-			if worstNode == nil || node.availableRatio() < worstNode.availableRatio() {
-				worstNode = node
-			}
+	for _, node := range nodes {
+		if worstNode == nil || node.availableRatio() < worstNode.availableRatio() {
+			worstNode = node
 		}
 	}
 
@@ -1446,7 +1627,7 @@ func (c *Cluster) selectWorstPeer(rng *Range) *metapb.Peer {
 		return nil
 	}
 
-	for _, peer := range rng.Peers {
+	for _, peer := range peers {
 		if peer.NodeId == worstNode.GetId() {
 			return peer
 		}
@@ -1460,7 +1641,7 @@ func (c *Cluster) dispatchOne(r *Range) (task *taskpb.Task, over bool) {
 	if tc == nil {
 		tc = c.hbManager.CheckRange(c, r)
 		if tc != nil {
-			if !c.taskManager.Add(tc) {
+			if !c.addTask(heartbeatTaskWorker, tc) {
 				log.Warn("add tasks for range(%d) failed. maybe other tasks is running.", r.GetId())
 				tc = nil
 			} else {
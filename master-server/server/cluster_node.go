@@ -19,10 +19,14 @@ func (c *Cluster) NodeLogin(nodeId uint64) error {
 	}
 
 	// TODO version update
-	if err := c.UpdateNodeState(node, metapb.NodeState_N_Login); err != nil {
-		return err
+	// 下线中的节点重启后继续迁移
+	if !node.isDraining() {
+		if err := c.UpdateNodeState(node, metapb.NodeState_N_Login); err != nil {
+			return err
+		}
 	}
 	node.LastHeartbeatTS = time.Now()
+	node.loginTS = node.LastHeartbeatTS
 	return nil
 }
 
@@ -179,3 +179,118 @@ func TestFindNode(t *testing.T) {
 		return
 	}
 }
+
+func TestNodeDrain(t *testing.T) {
+	cluster := newBoltDbCluster(t, newMockIDAllocator())
+	if cluster == nil {
+		return
+	}
+	defer closeLocalCluster(cluster)
+
+	node, _, err := cluster.GetNodeId("127.0.0.1:6060", "127.0.0.1:6061", "127.0.0.1:6062", "v1")
+	if err != nil {
+		t.Fatalf("get node ID failed, err %v", err)
+	}
+	// 未上线的节点不能下线
+	if err := cluster.DrainNode(node.GetId()); err != ErrNodeStateConfused {
+		t.Fatalf("expect %v, got %v", ErrNodeStateConfused, err)
+	}
+	if err := cluster.NodeLogin(node.GetId()); err != nil {
+		t.Fatalf("node %d login failed, err %v", node.GetId(), err)
+	}
+	if err := cluster.DrainNode(node.GetId()); err != nil {
+		t.Fatalf("drain node failed, err %v", err)
+	}
+	if node.State != metapb.NodeState_N_Draining || node.IsLogin() {
+		t.Fatalf("unexpected node state %v", node.State)
+	}
+	// 下线中的节点重启后继续下线
+	if err := cluster.NodeLogin(node.GetId()); err != nil || node.State != metapb.NodeState_N_Draining {
+		t.Fatalf("unexpected node state %v after login, err %v", node.State, err)
+	}
+	if err := cluster.CancelDrainNode(node.GetId()); err != nil || node.State != metapb.NodeState_N_Login {
+		t.Fatalf("unexpected node state %v after cancel, err %v", node.State, err)
+	}
+
+	if err := cluster.DrainNode(node.GetId()); err != nil {
+		t.Fatalf("drain node failed, err %v", err)
+	}
+	progress, err := cluster.GetDrainProgress(node.GetId())
+	if err != nil || !progress.Removable || progress.RemainRanges != 0 {
+		t.Fatalf("unexpected drain progress %v, err %v", progress, err)
+	}
+	w := &DrainNodeWorker{name: drainNodeWorkerName}
+	w.drain(cluster, node)
+	if !node.IsLogout() {
+		t.Fatalf("expect drained node logout, got %v", node.State)
+	}
+}
+
+func TestNodeUpgrade(t *testing.T) {
+	cluster := newBoltDbCluster(t, newMockIDAllocator())
+	if cluster == nil {
+		return
+	}
+	defer closeLocalCluster(cluster)
+
+	node, _, err := cluster.GetNodeId("127.0.0.1:6060", "127.0.0.1:6061", "127.0.0.1:6062", "v1")
+	if err != nil {
+		t.Fatalf("get node ID failed, err %v", err)
+	}
+	if err := cluster.NodeLogin(node.GetId()); err != nil {
+		t.Fatalf("node %d login failed, err %v", node.GetId(), err)
+	}
+	if _, err := cluster.StartUpgrade(nil, 1); err != nil {
+		t.Fatalf("start upgrade failed, err %v", err)
+	}
+	if _, err := cluster.StartUpgrade(nil, 1); err != ErrUpgradeStarted {
+		t.Fatalf("expect %v, got %v", ErrUpgradeStarted, err)
+	}
+
+	step := func(expect string) {
+		cluster.advanceUpgrade()
+		plan := cluster.GetUpgrade()
+		if plan == nil || plan.Nodes[0].Step != expect {
+			t.Fatalf("expect step %s, got %v", expect, plan)
+		}
+	}
+	step(UpgradeStepEvicting)
+	if !node.isUpgrading() {
+		t.Fatalf("unexpected node state %v", node.State)
+	}
+	// 没有leader, 可以重启
+	step(UpgradeStepReady)
+	step(UpgradeStepReady)
+	if err := cluster.NodeLogin(node.GetId()); err != nil || !node.IsLogin() {
+		t.Fatalf("unexpected node state %v after login, err %v", node.State, err)
+	}
+	step(UpgradeStepRecovering)
+	cluster.advanceUpgrade()
+	if plan := cluster.GetUpgrade(); plan != nil {
+		t.Fatalf("expect upgrade finished, got %v", plan)
+	}
+
+	// 暂停后不再开始升级新的节点, 取消后节点恢复login
+	if _, err := cluster.StartUpgrade([]uint64{node.GetId()}, 1); err != nil {
+		t.Fatalf("start upgrade failed, err %v", err)
+	}
+	if err := cluster.PauseUpgrade(); err != nil {
+		t.Fatalf("pause upgrade failed, err %v", err)
+	}
+	step(UpgradeStepPending)
+	if err := cluster.ResumeUpgrade(); err != nil {
+		t.Fatalf("resume upgrade failed, err %v", err)
+	}
+	step(UpgradeStepEvicting)
+	if _, err := cluster.AbortUpgrade(); err != nil || !node.IsLogin() {
+		t.Fatalf("unexpected node state %v after abort, err %v", node.State, err)
+	}
+
+	plans, err := cluster.GetUpgradeHistory()
+	if err != nil || len(plans) != 2 {
+		t.Fatalf("unexpected upgrade history %v, err %v", plans, err)
+	}
+	if plans[0].State != UpgradeAborted || plans[1].State != UpgradeFinished {
+		t.Fatalf("unexpected upgrade history states %s %s", plans[0].State, plans[1].State)
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"fmt"
 	"master-server/http_reply"
 	"model/pkg/ds_admin"
@@ -223,7 +224,7 @@ func (c *Cluster) rangeRecreate(r *Range, peerId uint64) (err error) {
 		toGcPeer = append(toGcPeer, peer)
 	}
 	if needNewPeer {
-		newPeer, err = c.allocPeerAndSelectNode(r, false)
+		newPeer, err = c.allocPeerAndSelectNode(r, false, false)
 		if err != nil {
 			return
 		}
@@ -375,6 +376,56 @@ func (c *Cluster) ForceSplitRemote(addr string, rangeId uint64, version uint64)
 	return err
 }
 
+func (c *Cluster) SplitAtRemote(addr string, rangeId uint64, version uint64, splitKey []byte) error {
+	err := c.adminCli.SplitAt(addr, rangeId, version, splitKey)
+	if err != nil {
+		log.Warn("split range[%d] of node[%s] at key[%v] failed, error[%v]", rangeId, addr, splitKey, err)
+	}
+	return err
+}
+
+// SplitTable 在keys处分裂表的range, 返回还不是range起始key的个数.
+// 一个range的分裂完成前epoch不变, 每次只请求分裂一次, 调用方重复调用直到返回0
+func (c *Cluster) SplitTable(t *Table, keys [][]byte) (uint32, error) {
+	var pending uint32
+	splitting := make(map[uint64]bool)
+	for _, key := range keys {
+		r := c.SearchRange(key)
+		if r == nil || r.GetTableId() != t.GetId() {
+			return 0, ErrNotExistRange
+		}
+		if bytes.Equal(r.GetStartKey(), key) {
+			continue
+		}
+		pending++
+		version := r.GetRangeEpoch().GetVersion()
+		if splitting[r.GetId()] {
+			continue
+		}
+		splitting[r.GetId()] = true
+		if v, ok := c.splittingRanges.Get(r.GetId()); ok && v.(uint64) == version {
+			// 上次请求的分裂还未完成
+			continue
+		}
+		leader := r.GetLeader()
+		if leader == nil {
+			continue
+		}
+		node := c.FindNodeById(leader.GetNodeId())
+		if node == nil {
+			continue
+		}
+		errMsg := ""
+		if err := c.SplitAtRemote(node.GetAdminAddr(), r.GetId(), version, key); err != nil {
+			errMsg = err.Error()
+		} else {
+			c.splittingRanges.Put(r.GetId(), version)
+		}
+		c.recordRangeOperation(r.Range, "split-table", errMsg)
+	}
+	return pending, nil
+}
+
 func (c *Cluster) ForceCompactRemote(addr string, rangeId uint64) (resp *ds_adminpb.CompactionResponse, err error) {
 	transactionID := time.Now().Unix()
 	for i := 0; i < 3; i++ {
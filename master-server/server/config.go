@@ -20,6 +20,7 @@ const (
 	defaultLeaderScheduleLimit       = 64
 	defaultRegionScheduleLimit       = 12
 	defaultReplicaScheduleLimit      = 16
+	defaultDrainScheduleLimit        = 4
 	defaultRaftHbInterval            = time.Millisecond * 500
 	defaultRaftRetainLogsCount       = 100
 	defaultMaxTaskWaitTime           = 5 * time.Minute
@@ -27,6 +28,11 @@ const (
 	defaultNodeRangeBalanceTime      = 2 * time.Minute
 	defaultStorageAvailableThreshold = 20
 	defaultWriteByteOpsThreshold     = 30 * 1024 * 1024
+	defaultBackupConcurrency         = 4
+	defaultBackupBatchSize           = 1000
+	defaultBackupGrpcPoolSize        = 4
+	defaultBackupTimeout             = 30 * time.Second
+	defaultBackupReadWait            = 10 * time.Second
 )
 const DefaultFactor = 0.75
 
@@ -82,6 +88,8 @@ max-node-down-time = "1h"
 leader-schedule-limit = 64
 region-schedule-limit = 16
 replica-schedule-limit = 24
+# 每个下线中的节点同时迁移的副本数
+drain-schedule-limit = 4
 max-task-timeout = "300s"
 # 12 times of region heartbeat time
 max-range-down-time = "600s"
@@ -97,6 +105,14 @@ max-replicas = 3
 # For example, ["zone", "rack"] means that we should place replicas to
 # different zones first, then to different racks if we don't have enough zones.
 location-labels = []
+
+[backup]
+# ranges scanned or restored at the same time by one backup job
+concurrency = 4
+# rows read or written by one request
+batch-size = 1000
+# wait before scanning, so the writes before the backup timestamp are applied
+read-wait = "10s"
 `
 
 type Config struct {
@@ -115,6 +131,7 @@ type Config struct {
 	Raft        RaftConfig        `toml:"raft,omitempty" json:"raft"`
 	Schedule    ScheduleConfig    `toml:"schedule,omitempty" json:"schedule"`
 	Replication ReplicationConfig `toml:"replication,omitempty" json:"replication"`
+	Backup      BackupConfig      `toml:"backup,omitempty" json:"backup"`
 
 	Log         LogConfig         `toml:"log,omitempty" json:"log"`
 	Metric      MetricConfig      `toml:"metric,omitempty" json:"metric"`
@@ -199,6 +216,7 @@ func (c *Config) adjust() error {
 		}
 		c.Schedule.adjust()
 		c.Replication.adjust()
+		c.Backup.adjust()
 	}
 	return nil
 }
@@ -288,6 +306,8 @@ type MetricServer struct {
 	QueueNum  uint64   `toml:"queue-num,omitempty" json:"queue-num"`
 	StoreType string   `toml:"store-type,omitempty" json:"store-type"`
 	StoreUrl  []string `toml:"store-url,omitempty" json:"store-url"`
+	// only used by the tsdb store type
+	Tsdb metric.TsdbConfig `toml:"tsdb,omitempty" json:"tsdb"`
 }
 
 type MetricConfig struct {
@@ -324,6 +344,8 @@ type ScheduleConfig struct {
 	RegionScheduleLimit uint64 `toml:"region-schedule-limit,omitempty" json:"region-schedule-limit"`
 	// ReplicaScheduleLimit is the max coexist replica schedules.
 	ReplicaScheduleLimit      uint64        `toml:"replica-schedule-limit,omitempty" json:"replica-schedule-limit"`
+	// DrainScheduleLimit is the max coexist replica moves of one draining node.
+	DrainScheduleLimit        uint64        `toml:"drain-schedule-limit,omitempty" json:"drain-schedule-limit"`
 	MaxTaskTimeout            util.Duration `toml:"max-task-timeout,omitempty" json:"max-task-timeout"`
 	MaxRangeDownTime          util.Duration `toml:"max-range-down-time,omitempty" json:"max-range-down-time"`
 	NodeRangeBalanceTime      util.Duration `toml:"node-range-balance-time,omitempty" json:"node-range-balance-time"`
@@ -337,6 +359,7 @@ func (c *ScheduleConfig) adjust() {
 	adjustUint64(&c.LeaderScheduleLimit, defaultLeaderScheduleLimit)
 	adjustUint64(&c.RegionScheduleLimit, defaultRegionScheduleLimit)
 	adjustUint64(&c.ReplicaScheduleLimit, defaultReplicaScheduleLimit)
+	adjustUint64(&c.DrainScheduleLimit, defaultDrainScheduleLimit)
 	adjustDuration(&c.MaxTaskTimeout, defaultMaxTaskWaitTime)
 	adjustDuration(&c.MaxRangeDownTime, defaultMaxRangeDownTime)
 	adjustDuration(&c.NodeRangeBalanceTime, defaultNodeRangeBalanceTime)
@@ -370,6 +393,22 @@ func (c *ReplicationConfig) adjust() {
 	adjustUint64(&c.MaxReplicas, defaultMaxReplicas)
 }
 
+type BackupConfig struct {
+	Concurrency  uint64        `toml:"concurrency,omitempty" json:"concurrency"`
+	BatchSize    uint64        `toml:"batch-size,omitempty" json:"batch-size"`
+	GrpcPoolSize uint64        `toml:"grpc-pool-size,omitempty" json:"grpc-pool-size"`
+	Timeout      util.Duration `toml:"timeout,omitempty" json:"timeout"`
+	ReadWait     util.Duration `toml:"read-wait,omitempty" json:"read-wait"`
+}
+
+func (c *BackupConfig) adjust() {
+	adjustUint64(&c.Concurrency, defaultBackupConcurrency)
+	adjustUint64(&c.BatchSize, defaultBackupBatchSize)
+	adjustUint64(&c.GrpcPoolSize, defaultBackupGrpcPoolSize)
+	adjustDuration(&c.Timeout, defaultBackupTimeout)
+	adjustDuration(&c.ReadWait, defaultBackupReadWait)
+}
+
 // scheduleOption is a wrapper to access the configuration safely.
 type scheduleOption struct {
 	MaxSnapshotCount          uint64
@@ -381,6 +420,7 @@ type scheduleOption struct {
 	LeaderScheduleLimit       uint64
 	RegionScheduleLimit       uint64
 	ReplicaScheduleLimit      uint64
+	DrainScheduleLimit        uint64
 	StorageAvailableThreshold uint64
 	WriteByteOpsThreshold     uint64
 	//rep *Replication
@@ -397,6 +437,7 @@ func newScheduleOption(cfg *Config) *scheduleOption {
 		LeaderScheduleLimit:       cfg.Schedule.LeaderScheduleLimit,
 		RegionScheduleLimit:       cfg.Schedule.RegionScheduleLimit,
 		ReplicaScheduleLimit:      cfg.Schedule.ReplicaScheduleLimit,
+		DrainScheduleLimit:        cfg.Schedule.DrainScheduleLimit,
 		MaxTaskTimeout:            cfg.Schedule.MaxTaskTimeout.Duration,
 		NodeRangeBalanceTime:      cfg.Schedule.NodeRangeBalanceTime.Duration,
 		StorageAvailableThreshold: cfg.Schedule.StorageAvailableThreshold,
@@ -462,6 +503,10 @@ func (o *scheduleOption) GetReplicaScheduleLimit() uint64 {
 	return o.ReplicaScheduleLimit
 }
 
+func (o *scheduleOption) GetDrainScheduleLimit() uint64 {
+	return o.DrainScheduleLimit
+}
+
 func (o *scheduleOption) GetMetricAddress() string {
 	return o.MetricAddr
 }
@@ -39,6 +39,13 @@ var (
 	ErrNotAllowSplit      = errors.New("not allow split")
 	ErrNotCancel          = errors.New("not allow cancel")
 	ErrNotAllowDelete     = errors.New("not allow delete")
+	ErrNotExistColumn     = errors.New("column not exist")
+	ErrTableEpochChanged  = errors.New("table epoch changed")
+	ErrPkNotAllowAlter    = errors.New("primary key column is not allowed to alter")
+	ErrIncompatibleColumn = errors.New("incompatible column type change")
+	ErrInvalidPlacement   = errors.New("invalid placement rules")
+	ErrUpgradeStarted     = errors.New("upgrade is in progress")
+	ErrUpgradeNotStarted  = errors.New("upgrade is not in progress")
 
 
 	ErrRangeStatusErr = errors.New("range status is invalid")
@@ -49,6 +49,9 @@ func (f *FailoverWorker) Work(cluster *Cluster) {
 			continue
 		case metapb.NodeState_N_Logout:
 			continue
+		case metapb.NodeState_N_Draining:
+			// 计划下线中的节点宕机后继续迁移副本, 宕机的副本由range心跳处理
+			continue
 		case metapb.NodeState_N_Login:
 			if time.Since(n.LastHeartbeatTS) > DefaultDownTimeLimit {
 				cluster.UpdateNodeState(n, metapb.NodeState_N_Offline)
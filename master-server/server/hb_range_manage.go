@@ -116,9 +116,12 @@ func (manager *hb_range_manager) CheckRange(cluster *Cluster, r *Range) *TaskCha
 		}
 	}
 
+	rule := cluster.rangePlacement(r)
+	voters, learners := countPeers(r.GetPeers())
+
 	// lack of peer
-	if len(r.GetPeers()) < cluster.opt.GetMaxReplicas() {
-		log.Info("range %d peer %d less than %d", r.GetId(), len(r.GetPeers()), cluster.opt.GetMaxReplicas())
+	if voters < rule.Replicas {
+		log.Info("range %d peer %d less than %d", r.GetId(), voters, rule.Replicas)
 		id, err := cluster.GenId()
 		if err != nil {
 			log.Error("rangeId:%d,%s", r.GetId(), err.Error())
@@ -128,23 +131,45 @@ func (manager *hb_range_manager) CheckRange(cluster *Cluster, r *Range) *TaskCha
 	}
 
 	// too many peers
-	if len(r.GetPeers()) > cluster.opt.GetMaxReplicas() {
-		log.Info("range %d peer %d more than %d", r.GetId(), len(r.GetPeers()), cluster.opt.GetMaxReplicas())
+	if voters > rule.Replicas {
+		log.Info("range %d peer %d more than %d", r.GetId(), voters, rule.Replicas)
+		return manager.removeWorstPeer(cluster, r, false, "hb-overmuch-peer")
+	}
 
-		if len(r.GetPendingPeers()) != 0 {
-			log.Info("range %v peer number %v / pending peer number %v: ", r.GetId(), len(r.GetPeers()), len(r.GetPendingPeers()))
-			return nil
-		}
-		// 优先下掉ip相同的副本
-		oldPeer := cluster.selectWorstPeer(r)
-		if oldPeer == nil {
-			return nil
-		}
+	// lack of learner
+	if learners < rule.Learners {
+		log.Info("range %d learner %d less than %d", r.GetId(), learners, rule.Learners)
 		id, err := cluster.GenId()
 		if err != nil {
+			log.Error("rangeId:%d,%s", r.GetId(), err.Error())
 			return nil
 		}
-		return manager.createDelPeerTask(id, r, oldPeer, "hb-overmuch-peer")
+		return NewTaskChain(id, r.GetId(), "hb-lack-learner", NewAddLearnerTask())
+	}
+
+	// too many learners
+	if learners > rule.Learners {
+		log.Info("range %d learner %d more than %d", r.GetId(), learners, rule.Learners)
+		return manager.removeWorstPeer(cluster, r, true, "hb-overmuch-learner")
+	}
+
+	// 不符合放置规则的副本, 先添加，后面再自动删除
+	if peer := cluster.misplacedPeer(r, rule); peer != nil {
+		if cluster.selectNodeForAddPeer(r, peer.GetKeepLearner()) == nil {
+			log.Debug("range %d peer %d misplaced, but no node to move to", r.GetId(), peer.GetId())
+		} else {
+			log.Info("range %d peer %d on node %d misplaced", r.GetId(), peer.GetId(), peer.GetNodeId())
+			id, err := cluster.GenId()
+			if err != nil {
+				log.Error("rangeId:%d,%s", r.GetId(), err.Error())
+				return nil
+			}
+			addPeerTask := NewAddPeerTask()
+			if peer.GetKeepLearner() {
+				addPeerTask = NewAddLearnerTask()
+			}
+			return NewTaskChain(id, r.GetId(), "hb-misplaced-peer", addPeerTask)
+		}
 	}
 
 	// 检查是否有ip相同的副本
@@ -159,5 +184,39 @@ func (manager *hb_range_manager) CheckRange(cluster *Cluster, r *Range) *TaskCha
 		return NewTaskChain(id, r.GetId(), "hb-same-ip", NewAddPeerTask())
 	}
 
+	// leader不在规则要求的节点上
+	if leader := r.GetLeader(); leader != nil && len(rule.LeaderLabels) > 0 {
+		if !rule.allowsLeader(cluster.FindNodeById(leader.GetNodeId())) {
+			if nodes := cluster.leaderCandidateNodes(r, rule); len(nodes) > 0 {
+				log.Info("range %d leader on node %d against placement", r.GetId(), leader.GetNodeId())
+				id, err := cluster.GenId()
+				if err != nil {
+					log.Error("rangeId:%d,%s", r.GetId(), err.Error())
+					return nil
+				}
+				return NewTaskChain(id, r.GetId(), "hb-leader-placement",
+					NewChangeLeaderTask(leader.GetNodeId(), nodes[0].GetId()))
+			}
+		}
+	}
+
 	return nil
 }
+
+// removeWorstPeer 删除多余的投票副本或learner副本
+func (manager *hb_range_manager) removeWorstPeer(cluster *Cluster, r *Range, learner bool, creator string) *TaskChain {
+	if len(r.GetPendingPeers()) != 0 {
+		log.Info("range %v peer number %v / pending peer number %v: ", r.GetId(), len(r.GetPeers()), len(r.GetPendingPeers()))
+		return nil
+	}
+	// 优先下掉ip相同的副本
+	oldPeer := cluster.selectWorstPeer(r, learner)
+	if oldPeer == nil {
+		return nil
+	}
+	id, err := cluster.GenId()
+	if err != nil {
+		return nil
+	}
+	return manager.createDelPeerTask(id, r, oldPeer, creator)
+}
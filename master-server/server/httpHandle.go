@@ -82,9 +82,18 @@ const (
 	HTTP_RANGE_ID                   = "rangeId"
 	HTTP_NODE_ID                    = "nodeId"
 	HTTP_NODE_IDS                   = "nodeIds"
+	HTTP_BATCH_SIZE                 = "batchSize"
+	HTTP_LABELS                     = "labels"
+	HTTP_ENABLE                     = "enable"
+	HTTP_TICKS                      = "ticks"
+	HTTP_WORKERS                    = "workers"
+	HTTP_START_TIME                 = "startTime"
+	HTTP_END_TIME                   = "endTime"
+	HTTP_LIMIT                      = "limit"
 	HTTP_PEER_ID                    = "peerId"
 	HTTP_NAME                       = "name"
 	HTTP_PROPERTIES                 = "properties"
+	HTTP_PLACEMENT                  = "placement"
 	HTTP_PKDUPCHECK                 = "pkDupCheck"
 	HTTP_RANGEKEYS_NUM              = "rangeKeysNum"
 	HTTP_RANGEKEYS_START            = "rangeKeysStart"
@@ -325,7 +334,22 @@ func (service *Server) handleTableCreate(w http.ResponseWriter, r *http.Request)
 			return
 		}
 	}
-	table, err := service.cluster.CreateTable(dbName, tName, columns, regxs, pkDupCheck != "false", sliceKeys)
+	ttl, err := ParseTTL(properties)
+	if err != nil {
+		reply.Code = HTTP_ERROR_INVALID_PARAM
+		reply.Message = err.Error()
+		return
+	}
+	var keys [][]byte
+	if len(sliceKeys) != 0 {
+		if keys, err = encodeSplitKeys(sliceKeys, columns); err != nil {
+			log.Error("encode table preSplit keys failed(%v), keys: %v", err, sliceKeys)
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+	}
+	table, err := service.cluster.CreateTableWithEncodedKeys(dbName, tName, columns, regxs, pkDupCheck != "false", keys, ttl)
 	if err != nil {
 		if err == ErrDupTable {
 			log.Warn("http create table repeat %s", tName)
@@ -404,6 +428,92 @@ func (service *Server) handleNodeUpgrade(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+// handleUpgradeStart 开始滚动升级, nodeIds为空时按id顺序升级所有login的节点
+func (service *Server) handleUpgradeStart(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	var nodeIds []uint64
+	if ids := r.FormValue(HTTP_NODE_IDS); ids != "" {
+		if err := json.Unmarshal([]byte(ids), &nodeIds); err != nil {
+			log.Error("http upgrade start: %v", err.Error())
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+	}
+	batchSize := 1
+	if size := r.FormValue(HTTP_BATCH_SIZE); size != "" {
+		var err error
+		if batchSize, err = strconv.Atoi(size); err != nil {
+			log.Error("http upgrade start: %v", err.Error())
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+	}
+	plan, err := service.cluster.StartUpgrade(nodeIds, batchSize)
+	if err != nil {
+		log.Error("http upgrade start failed. error:[%v]", err.Error())
+		reply.Code = -1
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = plan
+}
+
+func (service *Server) handleUpgradePause(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	if err := service.cluster.PauseUpgrade(); err != nil {
+		log.Error("http upgrade pause failed. error:[%v]", err.Error())
+		reply.Code = -1
+		reply.Message = err.Error()
+	}
+}
+
+func (service *Server) handleUpgradeResume(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	if err := service.cluster.ResumeUpgrade(); err != nil {
+		log.Error("http upgrade resume failed. error:[%v]", err.Error())
+		reply.Code = -1
+		reply.Message = err.Error()
+	}
+}
+
+func (service *Server) handleUpgradeAbort(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	plan, err := service.cluster.AbortUpgrade()
+	if err != nil {
+		log.Error("http upgrade abort failed. error:[%v]", err.Error())
+		reply.Code = -1
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = plan
+}
+
+// handleUpgradeStatus 查询进行中的滚动升级, step为ready的节点可以重启
+func (service *Server) handleUpgradeStatus(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	reply.Data = service.cluster.GetUpgrade()
+}
+
+func (service *Server) handleUpgradeHistory(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	plans, err := service.cluster.GetUpgradeHistory()
+	if err != nil {
+		log.Error("http upgrade history failed. error:[%v]", err.Error())
+		reply.Code = -1
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = plans
+}
+
 func (service *Server) handleNodeSetLogLevel(w http.ResponseWriter, r *http.Request) {
 	reply := &httpReply{}
 	defer sendReply(w, reply)
@@ -580,6 +690,7 @@ func (service *Server) handleRangeForceSplit(w http.ResponseWriter, r *http.Requ
 		reply.Message = http_error_range_find
 		return
 	}
+	defer service.recordRangeOperation(rng, "console-force-split", reply)
 
 	node := service.cluster.FindNodeById(rng.Leader.NodeId)
 	if node == nil {
@@ -618,6 +729,7 @@ func (service *Server) handleRangeForceCompact(w http.ResponseWriter, r *http.Re
 		reply.Message = http_error_range_find
 		return
 	}
+	defer service.recordRangeOperation(rng, "console-force-compact", reply)
 
 	node := service.cluster.FindNodeById(rng.Leader.NodeId)
 	if node == nil {
@@ -787,6 +899,36 @@ func (service *Server) handleAddScheduler(w http.ResponseWriter, r *http.Request
 	defer sendReply(w, reply)
 	cluster := service.cluster
 	name := r.FormValue("name")
+	// 带参数的调度器: nodeId或者labels指定目标节点
+	target := new(SchedulerTarget)
+	if idStr := r.FormValue(HTTP_NODE_ID); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+		target.NodeId = id
+	}
+	if labels := r.FormValue(HTTP_LABELS); labels != "" {
+		if err := json.Unmarshal([]byte(labels), &target.Labels); err != nil {
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+	}
+	switch {
+	case !target.isEmpty(), name == grantLeaderWorkerName, name == shuffleLeaderWorkerName, name == shuffleRangeWorkerName:
+		if target.isEmpty() {
+			target = nil
+		}
+		if err := cluster.AddScheduler(&SchedulerConfig{Kind: name, Target: target}); err != nil {
+			log.Warn("add scheduler %s failed, err[%v]", name, err)
+			reply.Code = -1
+			reply.Message = err.Error()
+		}
+		return
+	}
 	switch name {
 	case failoverWorkerName:
 		cluster.AddFailoverWorker()
@@ -798,6 +940,16 @@ func (service *Server) handleAddScheduler(w http.ResponseWriter, r *http.Request
 		cluster.AddCreateTableWorker()
 	case rangeHbCheckWorkerName:
 		cluster.AddRangeHbCheckWorker()
+	case droppedColumnCleanWorkerName:
+		cluster.AddDroppedColumnCleanWorker()
+	case ttlExpireWorkerName:
+		cluster.AddTTLExpireWorker()
+	case drainNodeWorkerName:
+		cluster.AddDrainNodeWorker()
+	case evictLeaderWorkerName:
+		cluster.AddEvictLeaderWorker()
+	case upgradeWorkerName:
+		cluster.AddUpgradeWorker()
 	case balanceRangeWorkerName:
 		cluster.AddBalanceRangeWorker()
 	case balanceLeaderWorkerName:
@@ -826,6 +978,35 @@ func (service *Server) handleRemoveScheduler(w http.ResponseWriter, r *http.Requ
 	return
 }
 
+// handleSchedulerDryRun 设置调度器dry-run, 只记录调度产生的任务不下发
+func (service *Server) handleSchedulerDryRun(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	name := r.FormValue(HTTP_NAME)
+	enable, err := strconv.ParseBool(r.FormValue(HTTP_ENABLE))
+	if name == "" || err != nil {
+		reply.Code = HTTP_ERROR_INVALID_PARAM
+		reply.Message = http_error_invalid_parameter
+		return
+	}
+	if err := service.cluster.SetWorkerDryRun(name, enable); err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http set worker[%s] dry-run[%v] success", name, enable)
+}
+
+func (service *Server) handleSchedulerDryRunTasks(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	cluster := service.cluster
+	reply.Data = map[string]interface{}{
+		"workers": cluster.GetDryRunWorkers(),
+		"tasks":   cluster.GetDryRunTasks(r.FormValue(HTTP_NAME)),
+	}
+}
+
 func (service *Server) handleDBGetAll(w http.ResponseWriter, r *http.Request) {
 	reply := &httpReply{}
 	defer sendReply(w, reply)
@@ -1267,6 +1448,7 @@ func (service *Server) handleRangeDelete(w http.ResponseWriter, r *http.Request)
 		reply.Message = http_error_range_find
 		return
 	}
+	defer service.recordRangeOperation(region, "console-delete-range", reply)
 
 	rngCopy := deepcopy.Iface(region.Range).(*metapb.Range)
 	if err := cluster.storeDeleteRange(rngCopy); err != nil {
@@ -1298,7 +1480,7 @@ func (service *Server) handleRangeAddPeer(w http.ResponseWriter, r *http.Request
 		reply.Message = http_error_range_find
 		return
 	}
-	newPeer, err := cluster.allocPeerAndSelectNode(rng, true)
+	newPeer, err := cluster.allocPeerAndSelectNode(rng, true, false)
 	if newPeer == nil || err != nil {
 		reply.Code = -1
 		reply.Message = "can not find best node to add peer"
@@ -1311,8 +1493,12 @@ func (service *Server) handleRangeAddPeer(w http.ResponseWriter, r *http.Request
 		return
 	}
 	tc := NewTaskChain(id, rng.GetId(), "console-add-peer", NewAddPeerTask())
-	// TOOD: check return
-	cluster.taskManager.Add(tc)
+	if !cluster.addTask(manualTaskWorker, tc) {
+		log.Error("http add peer: range[%d] has running task", rangeId)
+		reply.Code = HTTP_ERROR_RANGE_BUSY
+		reply.Message = http_error_range_busy
+		return
+	}
 	log.Info("add range<%v> peer create task success", rangeId)
 }
 
@@ -1355,8 +1541,12 @@ func (service *Server) handleRangeDelPeer(w http.ResponseWriter, r *http.Request
 		return
 	}
 	tc := cluster.hbManager.createDelPeerTask(id, rng, peer, "console-del-peer")
-	// TODO: check return
-	cluster.taskManager.Add(tc)
+	if !cluster.addTask(manualTaskWorker, tc) {
+		log.Error("http del peer: range[%d] has running task", rangeId)
+		reply.Code = HTTP_ERROR_RANGE_BUSY
+		reply.Message = http_error_range_busy
+		return
+	}
 	log.Info("del range<%v> peer<%v> create task success", rangeId, peerId)
 }
 
@@ -2179,6 +2369,85 @@ func (service *Server) handleTableEdit(w http.ResponseWriter, r *http.Request) {
 	log.Info("edit table[%s:%s] success", dbName, tName)
 }
 
+// rangePlacementState is the replicas of a range against its placement rule
+type rangePlacementState struct {
+	RangeId        uint64         `json:"range_id"`
+	Rule           *PlacementRule `json:"rule"`
+	Voters         int            `json:"voters"`
+	Learners       int            `json:"learners"`
+	MisplacedPeers []uint64       `json:"misplaced_peers,omitempty"`
+	LeaderNode     uint64         `json:"leader_node"`
+	LeaderPlaced   bool           `json:"leader_placed"`
+}
+
+// handleTablePlacement 查询表的放置规则及各range的副本分布, 带placement参数时修改规则, {}恢复集群默认
+func (service *Server) handleTablePlacement(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	dbName := r.FormValue(HTTP_DB_NAME)
+	tName := r.FormValue(HTTP_TABLE_NAME)
+	if dbName == "" || tName == "" {
+		log.Error("http table placement: %s", http_error_parameter_not_enough)
+		reply.Code = HTTP_ERROR_PARAMETER_NOT_ENOUGH
+		reply.Message = http_error_parameter_not_enough
+		return
+	}
+	cluster := service.cluster
+	db, find := cluster.FindDatabase(dbName)
+	if !find {
+		log.Warn("db[%s] not exist", dbName)
+		reply.Code = HTTP_ERROR
+		reply.Message = ErrNotExistTable.Error()
+		return
+	}
+	table, find := db.FindTable(tName)
+	if !find {
+		log.Warn("table[%s:%s] not exist", dbName, tName)
+		reply.Code = HTTP_ERROR
+		reply.Message = ErrNotExistTable.Error()
+		return
+	}
+
+	if placementStr := r.FormValue(HTTP_PLACEMENT); placementStr != "" {
+		placement := new(Placement)
+		if err := json.Unmarshal([]byte(placementStr), placement); err != nil {
+			log.Warn("http table placement: invalid placement[%s], err[%v]", placementStr, err)
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = ErrInvalidPlacement.Error()
+			return
+		}
+		if err := table.UpdatePlacement(placement, cluster); err != nil {
+			log.Warn("update table[%s:%s] placement failed, err[%v]", dbName, tName, err)
+			reply.Code = HTTP_ERROR
+			reply.Message = err.Error()
+			return
+		}
+		log.Info("update table[%s:%s] placement success", dbName, tName)
+	}
+
+	var states []*rangePlacementState
+	for _, rng := range cluster.GetTableAllRanges(table.GetId()) {
+		rule := cluster.rangePlacement(rng)
+		state := &rangePlacementState{RangeId: rng.GetId(), Rule: rule}
+		state.Voters, state.Learners = countPeers(rng.GetPeers())
+		for _, peer := range rng.GetPeers() {
+			if node := cluster.FindNodeById(peer.GetNodeId()); node != nil && !rule.allows(node, peer.GetKeepLearner()) {
+				state.MisplacedPeers = append(state.MisplacedPeers, peer.GetId())
+			}
+		}
+		if leader := rng.GetLeader(); leader != nil {
+			state.LeaderNode = leader.GetNodeId()
+			state.LeaderPlaced = rule.allowsLeader(cluster.FindNodeById(leader.GetNodeId()))
+		}
+		states = append(states, state)
+	}
+	reply.Data = map[string]interface{}{
+		"placement": table.Placement(),
+		"ranges":    states,
+	}
+}
+
 func (service *Server) handleNodeDelete(w http.ResponseWriter, r *http.Request) {
 	reply := &httpReply{}
 	defer sendReply(w, reply)
@@ -2252,6 +2521,67 @@ func (service *Server) handleHttpNodeLogout(w http.ResponseWriter, r *http.Reque
 	return
 }
 
+// handleHttpNodeDrain 计划下线节点, cancel=true时取消下线
+func (service *Server) handleHttpNodeDrain(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	var id uint64
+	var err error
+	if id, err = strconv.ParseUint(r.FormValue(HTTP_NODE_ID), 10, 64); err != nil {
+		log.Error("http drain node: %v", err.Error())
+		reply.Code = HTTP_ERROR_INVALID_PARAM
+		reply.Message = err.Error()
+		return
+	}
+	if r.FormValue("cancel") == "true" {
+		err = service.cluster.CancelDrainNode(id)
+	} else {
+		err = service.cluster.DrainNode(id)
+	}
+	if err != nil {
+		log.Error("http drain node failed. error:[%v]", err.Error())
+		reply.Code = -1
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http drain node %d success, cancel[%s]", id, r.FormValue("cancel"))
+	return
+}
+
+// handleHttpNodeDrainProgress 查询节点的下线进度, 不指定节点时返回所有下线中的节点
+func (service *Server) handleHttpNodeDrainProgress(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	cluster := service.cluster
+	if idStr := r.FormValue(HTTP_NODE_ID); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			log.Error("http drain progress: %v", err.Error())
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+		progress, err := cluster.GetDrainProgress(id)
+		if err != nil {
+			reply.Code = -1
+			reply.Message = err.Error()
+			return
+		}
+		reply.Data = []*DrainProgress{progress}
+		return
+	}
+	progresses := make([]*DrainProgress, 0)
+	for _, node := range cluster.GetAllNode() {
+		if !node.isDraining() {
+			continue
+		}
+		if progress, err := cluster.GetDrainProgress(node.GetId()); err == nil {
+			progresses = append(progresses, progress)
+		}
+	}
+	reply.Data = progresses
+}
+
 func (service *Server) handleHttpNodeDelete(w http.ResponseWriter, r *http.Request) {
 	reply := &httpReply{}
 	defer sendReply(w, reply)
@@ -2344,8 +2674,12 @@ func (service *Server) handleRangeLeaderChange(w http.ResponseWriter, r *http.Re
 	}
 	tc := NewTaskChain(taskID, rng.GetId(), "console-change-leader",
 		NewChangeLeaderTask(rng.GetLeader().GetNodeId(), newLeader.GetNodeId()))
-	// TODO: check return
-	cluster.taskManager.Add(tc)
+	if !cluster.addTask(manualTaskWorker, tc) {
+		log.Error("http change leader: range[%d] has running task", rangeId)
+		reply.Code = HTTP_ERROR_RANGE_BUSY
+		reply.Message = http_error_range_busy
+		return
+	}
 	log.Info("to change leader range[%s] success", rng.SString())
 	return
 }
@@ -2408,8 +2742,12 @@ func (service *Server) handleRangeTransfer(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	tc := NewTransferPeerTasks(taskID, rng, "console-transfer-peer", oldPeer)
-	// TODO: check tc
-	cluster.taskManager.Add(tc)
+	if !cluster.addTask(manualTaskWorker, tc) {
+		log.Error("http transfer peer: range[%d] has running task", rangeId)
+		reply.Code = HTTP_ERROR_RANGE_BUSY
+		reply.Message = http_error_range_busy
+		return
+	}
 	log.Info("to transfer range[%s] peer success", rng.SString())
 	return
 }
@@ -2481,9 +2819,14 @@ func (service *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request)
 	}
 	cluster := service.cluster
 	task := cluster.taskManager.Find(rangeId)
-	if task.GetID() == taskId {
-		cluster.taskManager.Remove(task, cluster)
+	if task == nil || task.GetID() != taskId {
+		log.Error("http delete task: range %v task[%v] not found", rangeId, taskId)
+		reply.Code = HTTP_ERROR_TASK_FIND
+		reply.Message = "task is not existed"
+		return
 	}
+	task.Cancel("deleted by operator")
+	cluster.taskManager.Remove(task, cluster)
 	log.Info("delete range %v task[%s] success", rangeId, task.String())
 
 	return
@@ -2505,6 +2848,46 @@ func (service *Server) handleGetAllTask(w http.ResponseWriter, r *http.Request)
 	reply.Data = resp
 }
 
+func (service *Server) handleTaskHistory(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	// 参数都是可选的
+	parse := func(key string) (uint64, error) {
+		if len(r.FormValue(key)) == 0 {
+			return 0, nil
+		}
+		return strconv.ParseUint(r.FormValue(key), 10, 64)
+	}
+	var values [6]uint64
+	for i, key := range []string{HTTP_RANGE_ID, HTTP_NODE_ID, HTTP_TABLE_ID, HTTP_START_TIME, HTTP_END_TIME, HTTP_LIMIT} {
+		v, err := parse(key)
+		if err != nil {
+			log.Error("http task history: %s %s", key, http_error_invalid_parameter)
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = http_error_invalid_parameter
+			return
+		}
+		values[i] = v
+	}
+	reply.Data = service.cluster.GetTaskHistory(&TaskHistoryFilter{
+		RangeId: values[0],
+		NodeId:  values[1],
+		TableId: values[2],
+		Start:   int64(values[3]),
+		End:     int64(values[4]),
+		Limit:   int(values[5]),
+	})
+}
+
+// recordRangeOperation 记录/manage/range/*中不产生任务的人工操作
+func (service *Server) recordRangeOperation(rng *Range, name string, reply *httpReply) {
+	var reason string
+	if reply.Code != HTTP_OK {
+		reason = reply.Message
+	}
+	service.cluster.recordRangeOperation(rng.Range, name, reason)
+}
+
 func (service *Server) handleRangeTaskQuery(w http.ResponseWriter, r *http.Request) {
 	reply := &httpReply{}
 	defer sendReply(w, reply)
@@ -2658,6 +3041,7 @@ func (service *Server) handleRangeRecreate(w http.ResponseWriter, r *http.Reques
 		reply.Message = fmt.Sprintf("range[%v] not found", rangeId)
 		return
 	}
+	defer service.recordRangeOperation(rng, "console-recreate-range", reply)
 
 	if err := service.cluster.rangeRecreate(rng, peerId); err != nil {
 		reply.Code = HTTP_ERROR
@@ -2952,6 +3336,7 @@ func (service *Server) handleUnhealthyRangeUpdate(w http.ResponseWriter, r *http
 		reply.Message = http_error_range_find
 		return
 	}
+	defer service.recordRangeOperation(range_, "console-update-range", reply)
 
 	if range_.State != metapb.RangeState_R_Abnormal {
 		reply.Code = HTTP_ERROR
@@ -3020,6 +3405,7 @@ func (service *Server) handleUpdateRangeEpoch(w http.ResponseWriter, r *http.Req
 		reply.Message = http_error_range_find
 		return
 	}
+	defer service.recordRangeOperation(range_, "console-update-epoch", reply)
 
 	if range_.State != metapb.RangeState_R_Abnormal {
 		reply.Code = HTTP_ERROR
@@ -3085,6 +3471,7 @@ func (service *Server) handleRangeOffline(w http.ResponseWriter, r *http.Request
 		reply.Message = http_error_range_find
 		return
 	}
+	defer service.recordRangeOperation(range_, "console-offline-range", reply)
 
 	db, find := cluster.FindDatabase(dbName)
 	if !find {
@@ -0,0 +1,684 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"model/pkg/metapb"
+	"util/log"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	// format of the meta snapshot, checked by import
+	metaSnapshotFormat = 1
+	// keys written in one raft batch by import
+	metaImportBatchSize = 100
+	// concurrent ranges probed by GetPeerInfo
+	metaProbeConcurrency = 16
+)
+
+var (
+	ErrMetaSnapshotFormat  = errors.New("unknown meta snapshot format")
+	ErrMetaNotEmpty        = errors.New("cluster meta is not empty")
+	ErrMetaInvalidRange    = errors.New("invalid range in meta snapshot")
+	ErrMetaRecoveryStarted = errors.New("meta recovery is started")
+	ErrMetaRecoveryStopped = errors.New("meta recovery is not started")
+)
+
+// MetaEntry is a raw key of the master store which has no typed field in the
+// snapshot, e.g. the id generators and the schedule switches.
+type MetaEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// MetaSnapshot is the dump of all the metadata in the master store, it is
+// read in one store iterator so the sections are consistent with each other.
+type MetaSnapshot struct {
+	Format        int                `json:"format"`
+	ClusterId     uint64             `json:"cluster_id"`
+	CreateTime    int64              `json:"create_time"`
+	Databases     []*metapb.DataBase `json:"databases"`
+	Tables        []*metapb.Table    `json:"tables"`
+	Nodes         []*metapb.Node     `json:"nodes"`
+	Ranges        []*metapb.Range    `json:"ranges"`
+	DeletedRanges []*metapb.Range    `json:"deleted_ranges"`
+	PreGCRanges   []*metapb.Range    `json:"pre_gc_ranges"`
+	TrashReplicas []*metapb.Replica  `json:"trash_replicas"`
+	Entries       []*MetaEntry       `json:"entries"`
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
+
+// ExportMeta dumps the metadata of the cluster from the store.
+func (c *Cluster) ExportMeta() (*MetaSnapshot, error) {
+	snap := &MetaSnapshot{
+		Format:     metaSnapshotFormat,
+		ClusterId:  c.clusterId,
+		CreateTime: time.Now().Unix(),
+	}
+	it := c.store.Scan([]byte{0x00}, []byte{0xff})
+	defer it.Release()
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if key == nil {
+			continue
+		}
+		var err error
+		switch {
+		case bytes.HasPrefix(key, []byte(PREFIX_META_RECOVERY)),
+			bytes.HasPrefix(key, []byte(PREFIX_UPGRADE)),
+			bytes.HasPrefix(key, []byte(PREFIX_TASK_HISTORY)):
+			// the recovery, upgrade and task history of the source cluster is not the business of the target
+			continue
+		case bytes.HasPrefix(key, []byte(PREFIX_DB)):
+			db := new(metapb.DataBase)
+			err = proto.Unmarshal(value, db)
+			snap.Databases = append(snap.Databases, db)
+		case bytes.HasPrefix(key, []byte(PREFIX_TABLE)):
+			t := new(metapb.Table)
+			err = proto.Unmarshal(value, t)
+			snap.Tables = append(snap.Tables, t)
+		case bytes.HasPrefix(key, []byte(PREFIX_NODE)):
+			n := new(metapb.Node)
+			err = proto.Unmarshal(value, n)
+			snap.Nodes = append(snap.Nodes, n)
+		case bytes.HasPrefix(key, []byte(PREFIX_RANGE)):
+			r := new(metapb.Range)
+			err = proto.Unmarshal(value, r)
+			snap.Ranges = append(snap.Ranges, r)
+		case bytes.HasPrefix(key, []byte(PREFIX_DELETED_RANGE)):
+			r := new(metapb.Range)
+			err = proto.Unmarshal(value, r)
+			snap.DeletedRanges = append(snap.DeletedRanges, r)
+		case bytes.HasPrefix(key, []byte(PREFIX_PRE_GC)):
+			r := new(metapb.Range)
+			err = proto.Unmarshal(value, r)
+			snap.PreGCRanges = append(snap.PreGCRanges, r)
+		case bytes.HasPrefix(key, []byte(PREFIX_REPLICA)):
+			rep := new(metapb.Replica)
+			err = proto.Unmarshal(value, rep)
+			snap.TrashReplicas = append(snap.TrashReplicas, rep)
+		default:
+			snap.Entries = append(snap.Entries, &MetaEntry{Key: cloneBytes(key), Value: cloneBytes(value)})
+		}
+		if err != nil {
+			log.Error("export meta: decode key[%s] failed, err[%v]", string(key), err)
+			return nil, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+type metaBatch struct {
+	store Store
+	batch Batch
+	count int
+}
+
+func (b *metaBatch) put(key []byte, msg proto.Message) error {
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return b.putRaw(key, value)
+}
+
+func (b *metaBatch) putRaw(key, value []byte) error {
+	if b.batch == nil {
+		b.batch = b.store.NewBatch()
+	}
+	b.batch.Put(key, value)
+	b.count++
+	if b.count < metaImportBatchSize {
+		return nil
+	}
+	return b.commit()
+}
+
+func (b *metaBatch) commit() error {
+	if b.count == 0 {
+		return nil
+	}
+	err := b.batch.Commit()
+	b.batch = nil
+	b.count = 0
+	return err
+}
+
+// isIdGeneratorKey returns true for the keys of the id generators, which
+// never go back when imported.
+func isIdGeneratorKey(key []byte) bool {
+	return bytes.Equal(key, []byte(AUTO_INCREMENT_ID)) || bytes.HasPrefix(key, []byte(TABLE_AUTO_INCREMENT_ID))
+}
+
+// checkRanges makes sure every range of the snapshot can be loaded, it must
+// have an epoch and peers placed on the nodes of the snapshot.
+func (snap *MetaSnapshot) checkRanges() error {
+	nodes := make(map[uint64]bool, len(snap.Nodes))
+	for _, n := range snap.Nodes {
+		nodes[n.GetId()] = true
+	}
+	for _, r := range snap.Ranges {
+		epoch := r.GetRangeEpoch()
+		if epoch == nil || epoch.GetVersion() == 0 || epoch.GetConfVer() == 0 {
+			log.Error("import meta: range[%d] has invalid epoch %v", r.GetId(), epoch)
+			return ErrMetaInvalidRange
+		}
+		if len(r.GetPeers()) == 0 {
+			log.Error("import meta: range[%d] has no peer", r.GetId())
+			return ErrMetaInvalidRange
+		}
+		for _, peer := range r.GetPeers() {
+			if peer.GetId() == 0 || !nodes[peer.GetNodeId()] {
+				log.Error("import meta: range[%d] peer %v is not on a node of the snapshot", r.GetId(), peer)
+				return ErrMetaInvalidRange
+			}
+		}
+	}
+	return nil
+}
+
+// ImportMeta writes the snapshot into the store of a new cluster, the cluster
+// must have no database, table and range. The caller reloads the cache.
+func (c *Cluster) ImportMeta(snap *MetaSnapshot) error {
+	if snap.Format != metaSnapshotFormat {
+		return ErrMetaSnapshotFormat
+	}
+	if snap.ClusterId != c.clusterId {
+		log.Error("import meta: snapshot of cluster[%d], but this is cluster[%d]", snap.ClusterId, c.clusterId)
+		return ErrInvalidParam
+	}
+	if len(c.GetAllDatabase()) != 0 || len(c.GetAllRanges()) != 0 {
+		return ErrMetaNotEmpty
+	}
+	if err := snap.checkRanges(); err != nil {
+		return err
+	}
+
+	b := &metaBatch{store: c.store}
+	for _, db := range snap.Databases {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_DB, db.GetId())), db); err != nil {
+			return err
+		}
+	}
+	for _, t := range snap.Tables {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_TABLE, t.GetId())), t); err != nil {
+			return err
+		}
+	}
+	for _, n := range snap.Nodes {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_NODE, n.GetId())), n); err != nil {
+			return err
+		}
+	}
+	for _, r := range snap.Ranges {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_RANGE, r.GetId())), r); err != nil {
+			return err
+		}
+	}
+	for _, r := range snap.DeletedRanges {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_DELETED_RANGE, r.GetId())), r); err != nil {
+			return err
+		}
+	}
+	for _, r := range snap.PreGCRanges {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_PRE_GC, r.GetId())), r); err != nil {
+			return err
+		}
+	}
+	for _, rep := range snap.TrashReplicas {
+		if err := b.put([]byte(fmt.Sprintf("%s%d", PREFIX_REPLICA, rep.GetPeer().GetId())), rep); err != nil {
+			return err
+		}
+	}
+	for _, e := range snap.Entries {
+		value := e.Value
+		if isIdGeneratorKey(e.Key) {
+			// the new cluster may have allocated ids already, keep the larger one
+			imported, err := bytesToUint64(e.Value)
+			if err != nil {
+				return err
+			}
+			if current, err := c.store.Get(e.Key); err == nil && current != nil {
+				if v, err := bytesToUint64(current); err == nil && v > imported {
+					value = current
+				}
+			}
+		}
+		if err := b.putRaw(e.Key, value); err != nil {
+			return err
+		}
+	}
+	if err := b.commit(); err != nil {
+		return err
+	}
+	log.Info("import meta: databases[%d] tables[%d] nodes[%d] ranges[%d] entries[%d]",
+		len(snap.Databases), len(snap.Tables), len(snap.Nodes), len(snap.Ranges), len(snap.Entries))
+	return nil
+}
+
+// MetaRangeConflict is a range reported during recovery which overlaps a
+// range of the meta, the one with the larger epoch wins.
+type MetaRangeConflict struct {
+	Reported *metapb.Range `json:"reported"`
+	Existed  *metapb.Range `json:"existed"`
+}
+
+// MetaRecovery rebuilds the range metadata from the heartbeats of the data
+// servers. While it runs the replicas unknown to the master are never
+// deleted and no task is dispatched.
+type MetaRecovery struct {
+	lock      sync.Mutex
+	startTime int64
+	// ranges which reported heartbeat since the recovery started
+	reported map[uint64]bool
+	// ranges reported by the data servers whose table is unknown
+	orphans map[uint64]*metapb.Range
+	// reported ranges which are not adopted because of overlapping
+	conflicts map[uint64]*MetaRangeConflict
+	// range id -> ids of the nodes reporting it as isolated replica
+	isolated map[uint64][]uint64
+}
+
+func NewMetaRecovery(startTime int64) *MetaRecovery {
+	return &MetaRecovery{
+		startTime: startTime,
+		reported:  make(map[uint64]bool),
+		orphans:   make(map[uint64]*metapb.Range),
+		conflicts: make(map[uint64]*MetaRangeConflict),
+		isolated:  make(map[uint64][]uint64),
+	}
+}
+
+// epochNewer returns true if the epoch of a is newer than b, the version
+// goes first as split and merge change the key scope.
+func epochNewer(a, b *metapb.Range) bool {
+	if a.GetRangeEpoch().GetVersion() != b.GetRangeEpoch().GetVersion() {
+		return a.GetRangeEpoch().GetVersion() > b.GetRangeEpoch().GetVersion()
+	}
+	return a.GetRangeEpoch().GetConfVer() > b.GetRangeEpoch().GetConfVer()
+}
+
+// rangeOverlap returns true if the key scopes of a and b overlap, an empty
+// end key means no limit.
+func rangeOverlap(a, b *metapb.Range) bool {
+	if len(a.GetEndKey()) != 0 && bytes.Compare(a.GetEndKey(), b.GetStartKey()) <= 0 {
+		return false
+	}
+	if len(b.GetEndKey()) != 0 && bytes.Compare(b.GetEndKey(), a.GetStartKey()) <= 0 {
+		return false
+	}
+	return true
+}
+
+func (m *MetaRecovery) markReported(rangeId uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.reported[rangeId] = true
+	delete(m.orphans, rangeId)
+	delete(m.conflicts, rangeId)
+}
+
+// addOrphan keeps the newest epoch of the range reported by the replicas.
+func (m *MetaRecovery) addOrphan(r *metapb.Range) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if old, find := m.orphans[r.GetId()]; find && !epochNewer(r, old) {
+		return
+	}
+	m.orphans[r.GetId()] = r
+}
+
+func (m *MetaRecovery) addConflict(reported, existed *metapb.Range) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if old, find := m.conflicts[reported.GetId()]; find && !epochNewer(reported, old.Reported) {
+		return
+	}
+	m.conflicts[reported.GetId()] = &MetaRangeConflict{Reported: reported, Existed: existed}
+}
+
+func (m *MetaRecovery) addIsolated(nodeId uint64, rangeIds []uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, id := range rangeIds {
+		nodes := m.isolated[id]
+		find := false
+		for _, n := range nodes {
+			if n == nodeId {
+				find = true
+				break
+			}
+		}
+		if !find {
+			m.isolated[id] = append(nodes, nodeId)
+		}
+	}
+}
+
+// MetaRecoveryStatus is the progress of the recovery.
+type MetaRecoveryStatus struct {
+	StartTime int64 `json:"start_time"`
+	Ranges    int   `json:"ranges"`
+	Reported  int   `json:"reported"`
+	// ranges of the meta without heartbeat since the recovery started
+	Missing          []uint64             `json:"missing"`
+	Orphans          []*metapb.Range      `json:"orphans"`
+	Conflicts        []*MetaRangeConflict `json:"conflicts"`
+	IsolatedReplicas map[uint64][]uint64  `json:"isolated_replicas"`
+	Probes           []*MetaRangeProbe    `json:"probes,omitempty"`
+}
+
+type uint64Slice []uint64
+
+func (s uint64Slice) Len() int           { return len(s) }
+func (s uint64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s uint64Slice) Less(i, j int) bool { return s[i] < s[j] }
+
+func (m *MetaRecovery) status(ranges []*Range) *MetaRecoveryStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	status := &MetaRecoveryStatus{
+		StartTime:        m.startTime,
+		Ranges:           len(ranges),
+		IsolatedReplicas: make(map[uint64][]uint64),
+	}
+	for _, r := range ranges {
+		if m.reported[r.GetId()] {
+			status.Reported++
+		} else {
+			status.Missing = append(status.Missing, r.GetId())
+		}
+	}
+	sort.Sort(uint64Slice(status.Missing))
+	for _, r := range m.orphans {
+		status.Orphans = append(status.Orphans, r)
+	}
+	for _, c := range m.conflicts {
+		status.Conflicts = append(status.Conflicts, c)
+	}
+	for id, nodes := range m.isolated {
+		status.IsolatedReplicas[id] = append([]uint64(nil), nodes...)
+	}
+	return status
+}
+
+func (c *Cluster) GetMetaRecovery() *MetaRecovery {
+	c.recoveryLock.RLock()
+	defer c.recoveryLock.RUnlock()
+	return c.recovery
+}
+
+// StartMetaRecovery persists the recovery flag, so the recovery goes on
+// after the leader changes.
+func (c *Cluster) StartMetaRecovery() error {
+	c.recoveryLock.Lock()
+	defer c.recoveryLock.Unlock()
+	if c.recovery != nil {
+		return ErrMetaRecoveryStarted
+	}
+	now := time.Now().Unix()
+	key := []byte(fmt.Sprintf("%s%d", PREFIX_META_RECOVERY, c.clusterId))
+	if err := c.store.Put(key, uint64ToBytes(uint64(now))); err != nil {
+		return err
+	}
+	c.recovery = NewMetaRecovery(now)
+	log.Info("meta recovery started")
+	return nil
+}
+
+func (c *Cluster) StopMetaRecovery() (*MetaRecoveryStatus, error) {
+	c.recoveryLock.Lock()
+	defer c.recoveryLock.Unlock()
+	if c.recovery == nil {
+		return nil, ErrMetaRecoveryStopped
+	}
+	key := []byte(fmt.Sprintf("%s%d", PREFIX_META_RECOVERY, c.clusterId))
+	if err := c.store.Delete(key); err != nil {
+		return nil, err
+	}
+	status := c.recovery.status(c.GetAllRanges())
+	c.recovery = nil
+	log.Info("meta recovery stopped, ranges[%d] reported[%d] orphans[%d] conflicts[%d]",
+		status.Ranges, status.Reported, len(status.Orphans), len(status.Conflicts))
+	return status, nil
+}
+
+func (c *Cluster) loadMetaRecovery() error {
+	key := []byte(fmt.Sprintf("%s%d", PREFIX_META_RECOVERY, c.clusterId))
+	value, err := c.store.Get(key)
+	if err != nil || value == nil {
+		// not found
+		return nil
+	}
+	startTime, err := bytesToUint64(value)
+	if err != nil {
+		return err
+	}
+	c.recovery = NewMetaRecovery(int64(startTime))
+	log.Info("meta recovery is in progress since %v", time.Unix(int64(startTime), 0))
+	return nil
+}
+
+// recoverRange checks the heartbeat of the range during recovery, it returns
+// false if the range must not be handled as usual.
+func (c *Cluster) recoverRange(m *MetaRecovery, r *metapb.Range, tableFound bool) bool {
+	if !tableFound {
+		m.addOrphan(r)
+		return false
+	}
+	if c.FindRange(r.GetId()) == nil {
+		// a new range since the snapshot, adopt it unless a newer range covers it
+		for _, existed := range c.GetTableAllRanges(r.GetTableId()) {
+			if rangeOverlap(r, existed.Range) && !epochNewer(r, existed.Range) {
+				log.Warn("meta recovery: range[%v] overlaps range[%v]", r, existed.Range)
+				m.addConflict(r, existed.Range)
+				return false
+			}
+		}
+	}
+	m.markReported(r.GetId())
+	return true
+}
+
+// MetaPeerProbe is the replica of a peer queried by GetPeerInfo.
+type MetaPeerProbe struct {
+	PeerId   uint64 `json:"peer_id"`
+	NodeId   uint64 `json:"node_id"`
+	Exist    bool   `json:"exist"`
+	KeyMatch bool   `json:"key_match"`
+	Index    uint64 `json:"index"`
+	Error    string `json:"error,omitempty"`
+}
+
+type MetaRangeProbe struct {
+	RangeId uint64           `json:"range_id"`
+	TableId uint64           `json:"table_id"`
+	Peers   []*MetaPeerProbe `json:"peers"`
+}
+
+func (c *Cluster) probeRange(r *metapb.Range) *MetaRangeProbe {
+	probe := &MetaRangeProbe{RangeId: r.GetId(), TableId: r.GetTableId()}
+	for _, p := range r.GetPeers() {
+		pp := &MetaPeerProbe{PeerId: p.GetId(), NodeId: p.GetNodeId()}
+		probe.Peers = append(probe.Peers, pp)
+		node := c.FindNodeById(p.GetNodeId())
+		if node == nil {
+			pp.Error = http_error_node_find
+			continue
+		}
+		resp, err := c.cli.GetPeerInfo(node.GetServerAddr(), r.GetId())
+		if err != nil {
+			pp.Error = err.Error()
+			continue
+		}
+		if rep := resp.GetReplica(); rep != nil {
+			pp.Exist = true
+			pp.Index = resp.GetIndex()
+			pp.KeyMatch = bytes.Equal(rep.GetStartKey(), r.GetStartKey()) && bytes.Equal(rep.GetEndKey(), r.GetEndKey())
+		}
+	}
+	return probe
+}
+
+// ProbeMetaRecovery queries the replicas of the ranges which have not
+// reported heartbeat yet.
+func (c *Cluster) ProbeMetaRecovery() (*MetaRecoveryStatus, error) {
+	m := c.GetMetaRecovery()
+	if m == nil {
+		return nil, ErrMetaRecoveryStopped
+	}
+	status := m.status(c.GetAllRanges())
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, metaProbeConcurrency)
+	for _, id := range status.Missing {
+		r := c.FindRange(id)
+		if r == nil {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r *metapb.Range) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			probe := c.probeRange(r)
+			lock.Lock()
+			status.Probes = append(status.Probes, probe)
+			lock.Unlock()
+		}(r.Range)
+	}
+	wg.Wait()
+	return status, nil
+}
+
+// reloadCluster loads the cache from the store into a new cluster like a
+// leader change does.
+func (service *Server) reloadCluster() error {
+	cluster := NewCluster(uint64(service.conf.Cluster.ClusterID), uint64(service.conf.NodeId), service.store, service.opt)
+	if err := cluster.LoadCache(); err != nil {
+		return err
+	}
+	old := service.cluster
+	old.Close()
+	cluster.UpdateLeader(old.GetLeader())
+	cluster.Start()
+	cluster.alarmCli = service.alarmClient
+	service.cluster = cluster
+	return nil
+}
+
+func (service *Server) handleMetaExport(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	snap, err := service.cluster.ExportMeta()
+	if err != nil {
+		log.Error("http export meta failed, err[%v]", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http export meta: databases[%d] tables[%d] nodes[%d] ranges[%d]",
+		len(snap.Databases), len(snap.Tables), len(snap.Nodes), len(snap.Ranges))
+	reply.Data = snap
+}
+
+// handleMetaImport reads the snapshot from the json body, the signature is
+// in the url query.
+func (service *Server) handleMetaImport(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	snap := new(MetaSnapshot)
+	if err := json.NewDecoder(r.Body).Decode(snap); err != nil {
+		log.Error("http import meta: decode snapshot failed, err[%v]", err)
+		reply.Code = HTTP_ERROR_INVALID_PARAM
+		reply.Message = http_error_invalid_parameter
+		return
+	}
+	if err := service.cluster.ImportMeta(snap); err != nil {
+		log.Error("http import meta failed, err[%v]", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	if err := service.reloadCluster(); err != nil {
+		log.Error("http import meta: reload cluster failed, err[%v]", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+}
+
+func (service *Server) handleMetaRecoverStart(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	if err := service.cluster.StartMetaRecovery(); err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+}
+
+func (service *Server) handleMetaRecoverStatus(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	recovery := service.cluster.GetMetaRecovery()
+	if recovery == nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = ErrMetaRecoveryStopped.Error()
+		return
+	}
+	reply.Data = recovery.status(service.cluster.GetAllRanges())
+}
+
+func (service *Server) handleMetaRecoverProbe(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	status, err := service.cluster.ProbeMetaRecovery()
+	if err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = status
+}
+
+func (service *Server) handleMetaRecoverStop(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	status, err := service.cluster.StopMetaRecovery()
+	if err != nil {
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	reply.Data = status
+}
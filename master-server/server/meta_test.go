@@ -0,0 +1,103 @@
+package server
+
+import (
+	"testing"
+
+	"model/pkg/metapb"
+)
+
+func newMetaTestRange(id uint64, start, end string, version, confVer uint64) *metapb.Range {
+	return &metapb.Range{
+		Id:         id,
+		TableId:    1,
+		StartKey:   []byte(start),
+		EndKey:     []byte(end),
+		RangeEpoch: &metapb.RangeEpoch{Version: version, ConfVer: confVer},
+		Peers:      []*metapb.Peer{{Id: id*10 + 1, NodeId: 1}},
+	}
+}
+
+func TestMetaExportImport(t *testing.T) {
+	// export and import only touch the store, no raft server is needed
+	cluster := newLevelDbCluster(t, newMockIDAllocator())
+	if _, err := cluster.CreateDatabase(DB_NAME, ""); err != nil {
+		t.Fatalf("create db error: %v", err)
+	}
+	node := &metapb.Node{Id: 1, ServerAddr: "127.0.0.1:6060", State: metapb.NodeState_N_Login}
+	if err := cluster.storeNode(node); err != nil {
+		t.Fatalf("store node error: %v", err)
+	}
+	rng := newMetaTestRange(10, "a", "z", 1, 1)
+	if err := cluster.storeRange(rng); err != nil {
+		t.Fatalf("store range error: %v", err)
+	}
+	snap, err := cluster.ExportMeta()
+	if err != nil {
+		t.Fatalf("export meta error: %v", err)
+	}
+	closeLocalCluster(cluster)
+	if len(snap.Databases) != 1 || len(snap.Nodes) != 1 || len(snap.Ranges) != 1 || snap.Ranges[0].GetId() != rng.GetId() {
+		t.Fatalf("unexpected snapshot %v", snap)
+	}
+
+	cluster = newLevelDbCluster(t, newMockIDAllocator())
+	defer closeLocalCluster(cluster)
+	// a range which can not be loaded is rejected before anything is written
+	bad := *snap
+	bad.Ranges = []*metapb.Range{newMetaTestRange(11, "z", "", 1, 1)}
+	bad.Ranges[0].Peers = nil
+	if err := cluster.ImportMeta(&bad); err != ErrMetaInvalidRange {
+		t.Fatalf("expect %v, got %v", ErrMetaInvalidRange, err)
+	}
+	if err := cluster.ImportMeta(snap); err != nil {
+		t.Fatalf("import meta error: %v", err)
+	}
+	if err := cluster.LoadCache(); err != nil {
+		t.Fatalf("load cache error: %v", err)
+	}
+	if _, find := cluster.FindDatabase(DB_NAME); !find {
+		t.Fatal("database not imported")
+	}
+	if cluster.FindRange(rng.GetId()) == nil {
+		t.Fatal("range not imported")
+	}
+	if err := cluster.ImportMeta(snap); err != ErrMetaNotEmpty {
+		t.Fatalf("expect %v, got %v", ErrMetaNotEmpty, err)
+	}
+}
+
+func TestMetaRecoveryOrphan(t *testing.T) {
+	m := NewMetaRecovery(0)
+	m.addOrphan(newMetaTestRange(1, "a", "z", 2, 1))
+	// a stale replica must not override the newer one
+	m.addOrphan(newMetaTestRange(1, "a", "m", 1, 3))
+	if r := m.orphans[1]; r.GetRangeEpoch().GetVersion() != 2 {
+		t.Fatalf("unexpected orphan %v", r)
+	}
+	m.addOrphan(newMetaTestRange(1, "a", "m", 3, 1))
+	if r := m.orphans[1]; string(r.GetEndKey()) != "m" {
+		t.Fatalf("unexpected orphan %v", r)
+	}
+
+	m.markReported(1)
+	if len(m.orphans) != 0 {
+		t.Fatal("reported range is still orphan")
+	}
+	status := m.status([]*Range{NewRange(newMetaTestRange(1, "a", "m", 3, 1), nil), NewRange(newMetaTestRange(2, "m", "z", 1, 1), nil)})
+	if status.Reported != 1 || len(status.Missing) != 1 || status.Missing[0] != 2 {
+		t.Fatalf("unexpected status %v", status)
+	}
+}
+
+func TestRangeOverlap(t *testing.T) {
+	a := newMetaTestRange(1, "a", "m", 1, 1)
+	if rangeOverlap(a, newMetaTestRange(2, "m", "z", 1, 1)) {
+		t.Fatal("adjacent ranges overlap")
+	}
+	if !rangeOverlap(a, newMetaTestRange(2, "c", "d", 1, 1)) {
+		t.Fatal("nested ranges not overlap")
+	}
+	if !rangeOverlap(a, newMetaTestRange(2, "b", "", 1, 1)) {
+		t.Fatal("unbounded range not overlap")
+	}
+}
@@ -181,6 +181,12 @@ func (m *Metric) CollectEvent(task *TaskChain) {
 }
 
 func (m *Metric) CollectScheduleCounter(name, label string) {
+	m.AddScheduleCounter(name, label, 1)
+}
+
+// AddScheduleCounter adds delta to the counter, e.g. the rows or bytes
+// processed by a worker.
+func (m *Metric) AddScheduleCounter(name, label string, delta uint64) {
 	if m == nil {
 		return
 	}
@@ -194,7 +200,7 @@ func (m *Metric) CollectScheduleCounter(name, label string) {
 		m.scheduleCounter[name] = labels
 	}
 	count = labels[label]
-	count++
+	count += delta
 	labels[label] = count
 }
 
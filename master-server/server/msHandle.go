@@ -118,6 +118,11 @@ func (service *Server) handleNodeHeartbeat(ctx context.Context, req *mspb.NodeHe
 			delete_ranges = append(delete_ranges, rangeId)
 		}
 	}
+	if recovery := cluster.GetMetaRecovery(); recovery != nil && delete_ranges != nil {
+		// 元数据恢复中，未知副本可能是丢失的元数据，不能删除
+		recovery.addIsolated(nodeId, delete_ranges)
+		delete_ranges = nil
+	}
 	resp.DeleteReplicas = delete_ranges
 	if delete_ranges != nil {
 		log.Warn("node[%s] need delete replicas[%v]", node.GetServerAddr(), node.stats, delete_ranges)
@@ -161,6 +166,10 @@ func (service *Server) handleRangeHeartbeat(ctx context.Context, req *mspb.Range
 			delFlag = true
 		}
 	}
+	recovery := cluster.GetMetaRecovery()
+	if recovery != nil && !cluster.recoverRange(recovery, r, !delFlag) {
+		return
+	}
 	if _, found := cluster.deletedRanges.FindRange(r.GetId()); found {
 		log.Error("range[%v] had been deleted, but still exist heartbeat from nodeId[%d]. Please check ds log for more detail!", r.GetId(), req.GetLeader().GetNodeId())
 		return
@@ -298,6 +307,10 @@ func (service *Server) handleRangeHeartbeat(ctx context.Context, req *mspb.Range
 	if table != nil && table.Status == metapb.TableStatus_TableInit {
 		return
 	}
+	// 元数据恢复中不调度
+	if recovery != nil {
+		return
+	}
 	task := cluster.Dispatch(rng)
 	if task != nil {
 		if rng.Trace || log.IsEnableInfo() {
@@ -471,6 +484,57 @@ func (service *Server) handleGetTable(ctx context.Context, req *mspb.GetTableReq
 	return
 }
 
+func (service *Server) handleGetDbs(ctx context.Context, req *mspb.GetDBsRequest) (resp *mspb.GetDBsResponse, err error) {
+	resp = new(mspb.GetDBsResponse)
+	resp.Header = &mspb.ResponseHeader{}
+	for _, db := range service.cluster.GetAllDatabase() {
+		resp.Dbs = append(resp.Dbs, db.DataBase)
+	}
+	return
+}
+
+func (service *Server) handleGetTables(ctx context.Context, req *mspb.GetTablesRequest) (resp *mspb.GetTablesResponse, err error) {
+	resp = new(mspb.GetTablesResponse)
+	resp.Header = &mspb.ResponseHeader{}
+	dbName := req.GetDbName()
+	if dbName == "" {
+		return nil, ErrInvalidParam
+	}
+	d, ok := service.cluster.FindDatabase(dbName)
+	if !ok {
+		log.Error("invalid database[%s]", dbName)
+		return nil, ErrNotExistDatabase
+	}
+	for _, t := range d.GetAllTable() {
+		// 只返回可用的表, 与GetTable一致
+		if t.Status != metapb.TableStatus_TableRunning {
+			continue
+		}
+		resp.Tables = append(resp.Tables, deepcopy.Iface(t.Table).(*metapb.Table))
+	}
+	return
+}
+
+func (service *Server) handleSplitTable(ctx context.Context, req *mspb.SplitTableRequest) (resp *mspb.SplitTableResponse, err error) {
+	resp = new(mspb.SplitTableResponse)
+	resp.Header = &mspb.ResponseHeader{}
+	dbId := req.GetDbId()
+	tId := req.GetTableId()
+
+	if dbId == 0 || tId == 0 {
+		return nil, ErrInvalidParam
+	}
+	t, ok := service.cluster.FindTableById(tId)
+	if !ok || t.GetDbId() != dbId {
+		return nil, ErrNotExistTable
+	}
+	if resp.Pending, err = service.cluster.SplitTable(t, req.GetSplitKeys()); err != nil {
+		log.Warn("split table[%s:%s] failed, err[%v]", t.GetDbName(), t.GetName(), err)
+		return nil, err
+	}
+	return
+}
+
 func (service *Server) handleGetTableById(ctx context.Context, req *mspb.GetTableByIdRequest) (resp *mspb.GetTableByIdResponse, err error) {
 	resp = new(mspb.GetTableByIdResponse)
 	resp.Header = &mspb.ResponseHeader{}
@@ -542,6 +606,27 @@ func (service *Server) handleAddColumns(ctx context.Context, req *mspb.AddColumn
 	return
 }
 
+func (service *Server) handleAlterColumn(ctx context.Context, req *mspb.AlterColumnRequest) (resp *mspb.AlterColumnResponse, err error) {
+	resp = new(mspb.AlterColumnResponse)
+	resp.Header = &mspb.ResponseHeader{}
+	dbId := req.GetDbId()
+	tId := req.GetTableId()
+
+	if dbId == 0 || tId == 0 || req.GetName() == "" {
+		return nil, errors.New("parameter is nil")
+	}
+	t, ok := service.cluster.FindTableById(tId)
+	if !ok {
+		return nil, ErrNotExistTable
+	}
+
+	if err = t.AlterColumn(req, service.cluster); err != nil {
+		return nil, fmt.Errorf("column alter err %s", err.Error())
+	}
+	resp.Table = deepcopy.Iface(t.Table).(*metapb.Table)
+	return
+}
+
 func (service *Server) handleGetColumnByName(ctx context.Context, req *mspb.GetColumnByNameRequest) (resp *mspb.GetColumnByNameResponse, err error) {
 	resp = new(mspb.GetColumnByNameResponse)
 	resp.Header = &mspb.ResponseHeader{}
@@ -648,7 +733,17 @@ func (service *Server) handleCreateTable(ctx context.Context, req *mspb.CreateTa
 		err = errors.New("invalid properties")
 		return
 	}
-	if _, err = service.cluster.CreateTable(req.GetDbName(), req.GetTableName(), columns, regxs, false, nil); err != nil {
+	splitKeys, err := ParseSplitKeys(req.GetProperties())
+	if err != nil {
+		err = errors.New("invalid properties")
+		return
+	}
+	ttl, err := ParseTTL(req.GetProperties())
+	if err != nil {
+		err = errors.New("invalid properties")
+		return
+	}
+	if _, err = service.cluster.CreateTableWithEncodedKeys(req.GetDbName(), req.GetTableName(), columns, regxs, false, splitKeys, ttl); err != nil {
 		log.Error("http sql table create : %v", err)
 		return
 	}
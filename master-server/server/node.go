@@ -35,6 +35,11 @@ type Node struct {
 	// 创建/删除range副本失败产生的垃圾副本,n/2以上的节点挂掉、手动处理不可用的副本
 	// 远程GC DS上的垃圾range副本
 	trashReplicas *ReplicaCache
+	// 开始下线迁移的时间和当时的range数, 用于统计迁移进度
+	drainStart  time.Time
+	drainRanges int
+	// 最近一次NodeLogin的时间, 用于判断滚动升级中的节点已经重启
+	loginTS time.Time
 }
 
 const CacheSize = 100
@@ -255,6 +260,20 @@ func (n *Node) isTombstone() bool {
 	return n.GetState() == metapb.NodeState_N_Tombstone
 }
 
+func (n *Node) isDraining() bool {
+	if n == nil {
+		return false
+	}
+	return n.GetState() == metapb.NodeState_N_Draining
+}
+
+func (n *Node) isUpgrading() bool {
+	if n == nil {
+		return false
+	}
+	return n.GetState() == metapb.NodeState_N_Upgrade
+}
+
 func (n *Node) isDown() bool {
 	if n == nil {
 		return false
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"time"
+
+	"model/pkg/metapb"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	drainLeaderTaskName   = "drain-node-leader"
+	drainTransferTaskName = "drain-node-transfer"
+)
+
+// DrainProgress is the progress of moving the ranges out of a draining node.
+type DrainProgress struct {
+	NodeId     uint64 `json:"node_id"`
+	ServerAddr string `json:"server_addr"`
+	State      string `json:"state"`
+	StartTime  int64  `json:"start_time,omitempty"`
+	// range数: 开始下线时, 当前剩余
+	TotalRanges  int `json:"total_ranges"`
+	RemainRanges int `json:"remain_ranges"`
+	Leaders      int `json:"leaders"`
+	// 正在迁移的leader和副本
+	MovingLeaders  int `json:"moving_leaders"`
+	MovingReplicas int `json:"moving_replicas"`
+	// 副本全部迁出, 节点可以删除
+	Removable bool `json:"removable"`
+}
+
+// DrainNode 计划下线节点, 节点不再分配新的副本, 由DrainNodeWorker逐步迁出leader和副本,
+// 迁出全部副本后节点变为logout状态, 可以删除
+func (c *Cluster) DrainNode(nodeId uint64) error {
+	node := c.FindNodeById(nodeId)
+	if node == nil {
+		return ErrNotExistNode
+	}
+	if node.isDraining() {
+		log.Info("node:[%d] is already draining", nodeId)
+		return nil
+	}
+	if !node.isUp() {
+		log.Error("Cannot drain this node. current state:[%s] is not login", node.State.String())
+		return ErrNodeStateConfused
+	}
+	if err := c.UpdateNodeState(node, metapb.NodeState_N_Draining); err != nil {
+		return err
+	}
+	node.drainStart = time.Now()
+	node.drainRanges = node.GetRangesSize()
+	log.Info("node[%s] start draining, ranges[%d]", node.GetServerAddr(), node.drainRanges)
+	return nil
+}
+
+// CancelDrainNode 取消下线, 节点恢复服务, 已迁出的副本由均衡调度迁回
+func (c *Cluster) CancelDrainNode(nodeId uint64) error {
+	node := c.FindNodeById(nodeId)
+	if node == nil {
+		return ErrNotExistNode
+	}
+	if !node.isDraining() {
+		log.Error("Cannot cancel draining this node. current state:[%s] is not draining", node.State.String())
+		return ErrNodeStateConfused
+	}
+	if err := c.UpdateNodeState(node, metapb.NodeState_N_Login); err != nil {
+		return err
+	}
+	node.drainStart = time.Time{}
+	node.drainRanges = 0
+	log.Info("node[%s] stop draining", node.GetServerAddr())
+	return nil
+}
+
+// drainingTasks returns the running leader and replica moves of the node.
+func (c *Cluster) drainingTasks(nodeId uint64) (leaders, replicas int) {
+	for _, tc := range c.taskManager.GetAll() {
+		var leader bool
+		switch tc.GetName() {
+		case drainLeaderTaskName:
+			leader = true
+		case drainTransferTaskName:
+		default:
+			continue
+		}
+		r := c.FindRange(tc.GetRangeID())
+		if r == nil || r.GetNodePeer(nodeId) == nil {
+			continue
+		}
+		if leader {
+			leaders++
+		} else {
+			replicas++
+		}
+	}
+	return
+}
+
+// GetDrainProgress returns the draining progress of the node.
+func (c *Cluster) GetDrainProgress(nodeId uint64) (*DrainProgress, error) {
+	node := c.FindNodeById(nodeId)
+	if node == nil {
+		return nil, ErrNotExistNode
+	}
+	progress := &DrainProgress{
+		NodeId:       node.GetId(),
+		ServerAddr:   node.GetServerAddr(),
+		State:        node.GetState().String(),
+		TotalRanges:  node.drainRanges,
+		RemainRanges: node.GetRangesSize(),
+	}
+	if !node.drainStart.IsZero() {
+		progress.StartTime = node.drainStart.Unix()
+	}
+	progress.Leaders = leaderRanges(node)
+	progress.MovingLeaders, progress.MovingReplicas = c.drainingTasks(node.GetId())
+	progress.Removable = progress.RemainRanges == 0 && (node.isDraining() || node.IsLogout())
+	return progress, nil
+}
+
+// DrainNodeWorker 按drain-schedule-limit限速迁出下线中节点的leader和副本
+type DrainNodeWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+}
+
+func NewDrainNodeWorker(wm *WorkerManager, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &DrainNodeWorker{
+		name:     drainNodeWorkerName,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+	}
+}
+
+func (w *DrainNodeWorker) GetName() string {
+	return w.name
+}
+
+func (w *DrainNodeWorker) Work(cluster *Cluster) {
+	for _, node := range cluster.GetAllNode() {
+		select {
+		case <-w.ctx.Done():
+			return
+		default:
+		}
+		if node.isDraining() {
+			w.drain(cluster, node)
+		}
+	}
+}
+
+func (w *DrainNodeWorker) drain(cluster *Cluster, node *Node) {
+	if node.drainStart.IsZero() {
+		// master重启后重新统计
+		node.drainStart = time.Now()
+		node.drainRanges = node.GetRangesSize()
+	}
+	if node.GetRangesSize() == 0 {
+		if err := cluster.UpdateNodeState(node, metapb.NodeState_N_Logout); err != nil {
+			return
+		}
+		cluster.metric.CollectScheduleCounter(w.GetName(), "drained")
+		log.Info("node[%s] drained in %v, it can be deleted now", node.GetServerAddr(), time.Since(node.drainStart))
+		return
+	}
+
+	movingLeaders, movingReplicas := cluster.drainingTasks(node.GetId())
+	leaderLimit := int(cluster.opt.GetLeaderScheduleLimit())
+	replicaLimit := int(cluster.opt.GetDrainScheduleLimit())
+	for _, r := range node.GetAllRanges() {
+		if movingLeaders >= leaderLimit && movingReplicas >= replicaLimit {
+			break
+		}
+		if cluster.taskManager.Find(r.GetId()) != nil {
+			continue
+		}
+		peer := r.GetNodePeer(node.GetId())
+		if peer == nil {
+			continue
+		}
+
+		var tc *TaskChain
+		// 先迁出leader, 减少迁移副本时对读写的影响
+		if leader := r.GetLeader(); leader != nil && leader.GetId() == peer.GetId() {
+			if movingLeaders >= leaderLimit {
+				continue
+			}
+			nodes := cluster.leaderCandidateNodes(r, cluster.rangePlacement(r))
+			if len(nodes) > 0 {
+				id, err := cluster.GenId()
+				if err != nil {
+					return
+				}
+				tc = NewTaskChain(id, r.GetId(), drainLeaderTaskName,
+					NewChangeLeaderTask(node.GetId(), nodes[0].GetId()))
+			}
+		}
+		if tc == nil {
+			if movingReplicas >= replicaLimit {
+				continue
+			}
+			if !r.require(cluster) || cluster.selectNodeForAddPeer(r, peer.GetKeepLearner()) == nil {
+				cluster.metric.CollectScheduleCounter(w.GetName(), "no_node")
+				continue
+			}
+			id, err := cluster.GenId()
+			if err != nil {
+				return
+			}
+			tc = NewTransferPeerTasks(id, r, drainTransferTaskName, peer)
+		}
+		if !cluster.addTask(w.GetName(), tc) {
+			continue
+		}
+		cluster.metric.CollectScheduleCounter(w.GetName(), "new_operator")
+		log.Info("%s created to drain node[%s]", tc.GetLogID(), node.GetServerAddr())
+		if tc.GetName() == drainLeaderTaskName {
+			movingLeaders++
+		} else {
+			movingReplicas++
+		}
+	}
+}
+
+func (w *DrainNodeWorker) AllowWork(cluster *Cluster) bool {
+	return true
+}
+
+func (w *DrainNodeWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *DrainNodeWorker) Stop() {
+	w.cancel()
+}
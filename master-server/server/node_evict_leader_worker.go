@@ -0,0 +1,127 @@
+package server
+
+import (
+	"time"
+
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const evictLeaderTaskName = "evict-node-leader"
+
+// leaderRanges returns the number of ranges whose leader is on the node.
+func leaderRanges(node *Node) int {
+	var count int
+	for _, r := range node.GetAllRanges() {
+		if r.GetLeader().GetNodeId() == node.GetId() {
+			count++
+		}
+	}
+	return count
+}
+
+// EvictLeaderWorker 迁出升级中节点上的leader, 节点重启时不影响读写.
+// 指定target时迁出目标节点上的leader, 用于运维
+type EvictLeaderWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+	target   *SchedulerTarget
+}
+
+func NewEvictLeaderWorker(wm *WorkerManager, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &EvictLeaderWorker{
+		name:     evictLeaderWorkerName,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+	}
+}
+
+func newTargetEvictLeaderWorker(wm *WorkerManager, name string, target *SchedulerTarget, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &EvictLeaderWorker{
+		name:     name,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+		target:   target,
+	}
+}
+
+func (w *EvictLeaderWorker) GetName() string {
+	return w.name
+}
+
+func (w *EvictLeaderWorker) Work(cluster *Cluster) {
+	for _, node := range cluster.GetAllNode() {
+		select {
+		case <-w.ctx.Done():
+			return
+		default:
+		}
+		if w.target == nil && node.isUpgrading() || w.target != nil && w.target.match(node) {
+			w.evict(cluster, node)
+		}
+	}
+}
+
+func (w *EvictLeaderWorker) evict(cluster *Cluster, node *Node) {
+	var moving int
+	for _, tc := range cluster.taskManager.GetAll() {
+		if tc.GetName() != evictLeaderTaskName {
+			continue
+		}
+		if r := cluster.FindRange(tc.GetRangeID()); r != nil && r.GetNodePeer(node.GetId()) != nil {
+			moving++
+		}
+	}
+	limit := int(cluster.opt.GetLeaderScheduleLimit())
+	for _, r := range node.GetAllRanges() {
+		if moving >= limit {
+			return
+		}
+		if r.GetLeader().GetNodeId() != node.GetId() {
+			continue
+		}
+		if cluster.taskManager.Find(r.GetId()) != nil {
+			continue
+		}
+		nodes := cluster.leaderCandidateNodes(r, cluster.rangePlacement(r))
+		if len(nodes) == 0 {
+			// 没有满足leader标签的副本时也要迁出, 避免节点重启时range不可用
+			nodes = cluster.leaderCandidateNodes(r, &PlacementRule{})
+		}
+		if len(nodes) == 0 {
+			cluster.metric.CollectScheduleCounter(w.GetName(), "no_node")
+			continue
+		}
+		id, err := cluster.GenId()
+		if err != nil {
+			return
+		}
+		tc := NewTaskChain(id, r.GetId(), evictLeaderTaskName,
+			NewChangeLeaderTask(node.GetId(), nodes[0].GetId()))
+		if !cluster.addTask(w.GetName(), tc) {
+			continue
+		}
+		cluster.metric.CollectScheduleCounter(w.GetName(), "new_operator")
+		log.Info("%s created to evict leader from node[%s]", tc.GetLogID(), node.GetServerAddr())
+		moving++
+	}
+}
+
+func (w *EvictLeaderWorker) AllowWork(cluster *Cluster) bool {
+	return true
+}
+
+func (w *EvictLeaderWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *EvictLeaderWorker) Stop() {
+	w.cancel()
+}
@@ -60,7 +60,7 @@ func (w *balanceNodeLeaderWorker) Work(cluster *Cluster) {
 	tc := NewTaskChain(id, rng.GetId(), "balance-change-leader",
 		NewChangeLeaderTask(rng.GetLeader().GetNodeId(), newLeader.GetNodeId()))
 	// TODO: check return
-	cluster.taskManager.Add(tc)
+	cluster.addTask(w.GetName(), tc)
 	return
 }
 
@@ -94,6 +94,7 @@ func (w *balanceNodeLeaderWorker) selectChangeLeader(cluster *Cluster) (*Range,
 		NewWriterOpsThresholdSelector(cluster.opt),
 		NewStorageThresholdSelector(cluster.opt),
 		NewDifferCacheNodeSelector(cluster.hbManager.dealIngNodes),
+		NewLeaderSchedulerSelector(cluster),
 	}
 
 	mostLeaderNode, leastLeaderNode, avgLeaderNum := SelectMostAndLeastLeaderNode(nodes, newSelectors)
@@ -112,11 +113,12 @@ func (w *balanceNodeLeaderWorker) selectChangeLeader(cluster *Cluster) (*Range,
 	w.adjustNextInterval(mostLeaderNum, leastLeaderNum, avgLeaderNum)
 
 	balanceThreshold := maxFloat64(avgLeaderNum/10, float64(Min_leader_balance_num))
-	if (mostLeaderNum - avgLeaderNum) > balanceThreshold {
+	// grant-leader调度的节点不迁出leader
+	if (mostLeaderNum-avgLeaderNum) > balanceThreshold && !cluster.leaderGranted(mostLeaderNode) {
 		// mostLeaderNum节点上为leader的分片，在follow Nodes上选择副本切换为leader
 		for _, r := range mostLeaderNode.GetAllRanges() {
 			if r.GetLeader().GetNodeId() == mostLeaderNode.GetId() && r.require(cluster) {
-				tarGetAllNode := cluster.getFollowerNodes(r)
+				tarGetAllNode := cluster.leaderCandidateNodes(r, cluster.rangePlacement(r))
 				node := SelectLeaderNode(tarGetAllNode, newSelectors, mostLeaderNum)
 				if node != nil {
 					return r, r.GetNodePeer(node.GetId())
@@ -129,7 +131,15 @@ func (w *balanceNodeLeaderWorker) selectChangeLeader(cluster *Cluster) (*Range,
 		// 在leastLeaderNum节点 非leader的分片，切换为leader
 		for _, r := range leastLeaderNode.GetAllRanges() {
 			if r.GetLeader().GetNodeId() != leastLeaderNode.GetId() && r.require(cluster) {
+				// learner和不符合放置规则的节点不能成为leader
+				peer := r.GetNodePeer(leastLeaderNode.GetId())
+				if peer.GetType() != metapb.PeerType_PeerType_Normal || !cluster.rangePlacement(r).allowsLeader(leastLeaderNode) {
+					continue
+				}
 				leaderNode := cluster.getLeaderNode(r)
+				if cluster.leaderGranted(leaderNode) {
+					continue
+				}
 				if float64(leaderNode.GetLeaderCount()-leastLeaderNode.GetLeaderCount()) > float64(Min_leader_balance_num) {
 					return r, r.GetNodePeer(leastLeaderNode.GetId())
 				}
@@ -51,7 +51,7 @@ func (w *balanceNodeRangeWorker) Work(cluster *Cluster) {
 		rng.GetId(), oldPeer.GetId(), oldPeer.GetNodeId(), targetNodeId)
 	tc := NewTransferPeerTasks(id, rng, "balance-range-transfer", oldPeer)
 	// TODO: check return
-	cluster.taskManager.Add(tc)
+	cluster.addTask(w.GetName(), tc)
 	return
 }
 
@@ -113,6 +113,10 @@ func (w *balanceNodeRangeWorker) selectRemovePeer(cluster *Cluster) (*Range, *me
 			if !ipSelector.CanSelect(leastRangeNode) {
 				continue
 			}
+			learner := r.GetNodePeer(mostRangeNode.GetId()).GetKeepLearner()
+			if !cluster.rangePlacement(r).allows(leastRangeNode, learner) {
+				continue
+			}
 			rng = r
 			break
 		}
@@ -127,6 +131,10 @@ func (w *balanceNodeRangeWorker) selectRemovePeer(cluster *Cluster) (*Range, *me
 				if !ipSelector.CanSelect(leastRangeNode) {
 					continue
 				}
+				learner := r.GetNodePeer(mostRangeNode.GetId()).GetKeepLearner()
+				if !cluster.rangePlacement(r).allows(leastRangeNode, learner) {
+					continue
+				}
 				rng = r
 				break
 			}
@@ -137,7 +145,7 @@ func (w *balanceNodeRangeWorker) selectRemovePeer(cluster *Cluster) (*Range, *me
 		log.Debug("%v: select leader range that exclude leastRangeNode %v is nil ", w.GetName(), leastRangeNode)
 		for _, r := range mostRangeNode.GetAllRanges() {
 			if r.GetLeader().GetNodeId() != mostRangeNode.GetId() && r.require(cluster) {
-				leastRangeNode = cluster.selectNodeForAddPeer(r)
+				leastRangeNode = cluster.selectNodeForAddPeer(r, r.GetNodePeer(mostRangeNode.GetId()).GetKeepLearner())
 				if leastRangeNode != nil {
 					rng = r
 					break
@@ -150,7 +158,7 @@ func (w *balanceNodeRangeWorker) selectRemovePeer(cluster *Cluster) (*Range, *me
 		log.Debug("%v: select follow range to best node is nil  %v", w.GetName(), leastRangeNode)
 		for _, r := range mostRangeNode.GetAllRanges() {
 			if r.GetLeader().GetNodeId() == mostRangeNode.GetId() && r.require(cluster) {
-				leastRangeNode = cluster.selectNodeForAddPeer(r)
+				leastRangeNode = cluster.selectNodeForAddPeer(r, r.GetNodePeer(mostRangeNode.GetId()).GetKeepLearner())
 				if leastRangeNode != nil {
 					rng = r
 					break
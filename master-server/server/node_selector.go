@@ -81,6 +81,24 @@ func (sel *DifferCacheNodeSelector) CanSelect(node *Node) bool {
 }
 
 
+// PlacementSelector selects the nodes allowed by the placement rule of the range.
+type PlacementSelector struct {
+	rule    *PlacementRule
+	learner bool
+}
+
+func NewPlacementSelector(rule *PlacementRule, learner bool) *PlacementSelector {
+	return &PlacementSelector{rule: rule, learner: learner}
+}
+
+func (sel *PlacementSelector) Name() string {
+	return "placement"
+}
+
+func (sel *PlacementSelector) CanSelect(node *Node) bool {
+	return sel.rule.allows(node, sel.learner)
+}
+
 type NodeLoginSelector struct {
 	opt *scheduleOption
 }
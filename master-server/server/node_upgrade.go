@@ -0,0 +1,477 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"model/pkg/metapb"
+	"util/deepcopy"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+// 滚动升级的状态
+const (
+	UpgradeRunning  = "running"
+	UpgradePaused   = "paused"
+	UpgradeFinished = "finished"
+	UpgradeAborted  = "aborted"
+)
+
+// 节点的升级步骤
+const (
+	UpgradeStepPending = "pending"
+	// 节点处于N_Upgrade状态, 由EvictLeaderWorker迁出leader
+	UpgradeStepEvicting = "evicting"
+	// leader已经全部迁出, 等待运维重启节点
+	UpgradeStepReady = "ready"
+	// 节点已经重启, 等待range心跳恢复
+	UpgradeStepRecovering = "recovering"
+	UpgradeStepDone       = "done"
+	// 节点已被删除
+	UpgradeStepSkipped = "skipped"
+)
+
+// 保留的升级历史数
+const maxUpgradeHistory = 20
+
+// UpgradeNode is the upgrade progress of one node.
+type UpgradeNode struct {
+	NodeId     uint64 `json:"node_id"`
+	ServerAddr string `json:"server_addr"`
+	Step       string `json:"step"`
+	// 节点上剩余的leader数和重启后还没有恢复心跳的range数
+	Leaders   int `json:"leaders"`
+	Unhealthy int `json:"unhealthy"`
+
+	BeginTime   int64  `json:"begin_time,omitempty"`
+	ReadyTime   int64  `json:"ready_time,omitempty"`
+	RestartTime int64  `json:"restart_time,omitempty"`
+	FinishTime  int64  `json:"finish_time,omitempty"`
+	Message     string `json:"message,omitempty"`
+	// ready时节点最近一次login的时间(纳秒), 再次login说明节点已经重启
+	LoginTS int64 `json:"login_ts,omitempty"`
+}
+
+// UpgradePlan is a rolling upgrade of the data servers, at most BatchSize
+// nodes are upgrading at the same time.
+type UpgradePlan struct {
+	Id         uint64         `json:"id"`
+	State      string         `json:"state"`
+	BatchSize  int            `json:"batch_size"`
+	Nodes      []*UpgradeNode `json:"nodes"`
+	CreateTime int64          `json:"create_time"`
+	FinishTime int64          `json:"finish_time,omitempty"`
+	Message    string         `json:"message,omitempty"`
+}
+
+func (p *UpgradePlan) active() int {
+	var count int
+	for _, u := range p.Nodes {
+		switch u.Step {
+		case UpgradeStepEvicting, UpgradeStepReady, UpgradeStepRecovering:
+			count++
+		}
+	}
+	return count
+}
+
+func (p *UpgradePlan) finished() bool {
+	for _, u := range p.Nodes {
+		if u.Step != UpgradeStepDone && u.Step != UpgradeStepSkipped {
+			return false
+		}
+	}
+	return true
+}
+
+type upgradePlanSlice []*UpgradePlan
+
+func (s upgradePlanSlice) Len() int           { return len(s) }
+func (s upgradePlanSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s upgradePlanSlice) Less(i, j int) bool { return s[i].Id > s[j].Id }
+
+// StartUpgrade starts a rolling upgrade of the nodes in order, all the login
+// nodes are upgraded if nodeIds is empty.
+func (c *Cluster) StartUpgrade(nodeIds []uint64, batchSize int) (*UpgradePlan, error) {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	if c.upgrade != nil {
+		return nil, ErrUpgradeStarted
+	}
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if len(nodeIds) == 0 {
+		for _, node := range c.GetAllNode() {
+			if node.isUp() {
+				nodeIds = append(nodeIds, node.GetId())
+			}
+		}
+		sort.Sort(uint64Slice(nodeIds))
+	}
+	if len(nodeIds) == 0 {
+		return nil, ErrNotActiveNode
+	}
+
+	id, err := c.GenId()
+	if err != nil {
+		return nil, err
+	}
+	plan := &UpgradePlan{
+		Id:         id,
+		State:      UpgradeRunning,
+		BatchSize:  batchSize,
+		CreateTime: time.Now().Unix(),
+	}
+	added := make(map[uint64]bool)
+	for _, nodeId := range nodeIds {
+		if added[nodeId] {
+			continue
+		}
+		node := c.FindNodeById(nodeId)
+		if node == nil {
+			return nil, ErrNotExistNode
+		}
+		if !node.isUp() {
+			log.Error("Cannot upgrade node[%d]. current state:[%s] is not login", nodeId, node.State.String())
+			return nil, ErrNotActiveNode
+		}
+		added[nodeId] = true
+		plan.Nodes = append(plan.Nodes, &UpgradeNode{
+			NodeId:     nodeId,
+			ServerAddr: node.GetServerAddr(),
+			Step:       UpgradeStepPending,
+		})
+	}
+	if err := c.storeUpgrade(plan); err != nil {
+		return nil, err
+	}
+	c.upgrade = plan
+	log.Info("upgrade[%d] started, nodes[%d] batch[%d]", plan.Id, len(plan.Nodes), batchSize)
+	return deepcopy.Iface(plan).(*UpgradePlan), nil
+}
+
+// PauseUpgrade stops upgrading the pending nodes, the upgrading ones go on.
+func (c *Cluster) PauseUpgrade() error {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	if c.upgrade == nil {
+		return ErrUpgradeNotStarted
+	}
+	return c.setUpgradeState(c.upgrade, UpgradePaused, "paused by operator")
+}
+
+func (c *Cluster) ResumeUpgrade() error {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	if c.upgrade == nil {
+		return ErrUpgradeNotStarted
+	}
+	return c.setUpgradeState(c.upgrade, UpgradeRunning, "")
+}
+
+// AbortUpgrade stops the upgrade, the nodes which are not restarted yet go
+// back to login.
+func (c *Cluster) AbortUpgrade() (*UpgradePlan, error) {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	plan := c.upgrade
+	if plan == nil {
+		return nil, ErrUpgradeNotStarted
+	}
+	for _, u := range plan.Nodes {
+		if u.Step != UpgradeStepEvicting && u.Step != UpgradeStepReady {
+			continue
+		}
+		if node := c.FindNodeById(u.NodeId); node != nil && node.isUpgrading() {
+			if err := c.UpdateNodeState(node, metapb.NodeState_N_Login); err != nil {
+				return nil, err
+			}
+		}
+		u.Message = "aborted"
+	}
+	if err := c.archiveUpgrade(plan, UpgradeAborted); err != nil {
+		return nil, err
+	}
+	return deepcopy.Iface(plan).(*UpgradePlan), nil
+}
+
+// GetUpgrade returns the upgrade in progress, nil if there is none.
+func (c *Cluster) GetUpgrade() *UpgradePlan {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	if c.upgrade == nil {
+		return nil
+	}
+	return deepcopy.Iface(c.upgrade).(*UpgradePlan)
+}
+
+// GetUpgradeHistory returns the finished and aborted upgrades, the latest first.
+func (c *Cluster) GetUpgradeHistory() ([]*UpgradePlan, error) {
+	startKey, limitKey := bytesPrefix([]byte(PREFIX_UPGRADE_HISTORY))
+	it := c.store.Scan(startKey, limitKey)
+	defer it.Release()
+	plans := make([]*UpgradePlan, 0)
+	for it.Next() {
+		if it.Key() == nil {
+			continue
+		}
+		plan := new(UpgradePlan)
+		if err := json.Unmarshal(it.Value(), plan); err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	sort.Sort(upgradePlanSlice(plans))
+	return plans, nil
+}
+
+func (c *Cluster) setUpgradeState(plan *UpgradePlan, state, message string) error {
+	oldState, oldMessage := plan.State, plan.Message
+	plan.State, plan.Message = state, message
+	if err := c.storeUpgrade(plan); err != nil {
+		plan.State, plan.Message = oldState, oldMessage
+		return err
+	}
+	log.Info("upgrade[%d] state had changed [%s]===>[%s] %s", plan.Id, oldState, state, message)
+	return nil
+}
+
+func (c *Cluster) storeUpgrade(plan *UpgradePlan) error {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	key := []byte(fmt.Sprintf("%s%d", PREFIX_UPGRADE, c.clusterId))
+	return c.store.Put(key, data)
+}
+
+// archiveUpgrade moves the upgrade to the history.
+func (c *Cluster) archiveUpgrade(plan *UpgradePlan, state string) error {
+	plan.State = state
+	plan.FinishTime = time.Now().Unix()
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	historyKey := []byte(fmt.Sprintf("%s%d", PREFIX_UPGRADE_HISTORY, plan.Id))
+	if err := c.store.Put(historyKey, data); err != nil {
+		return err
+	}
+	key := []byte(fmt.Sprintf("%s%d", PREFIX_UPGRADE, c.clusterId))
+	if err := c.store.Delete(key); err != nil {
+		return err
+	}
+	c.upgrade = nil
+	log.Info("upgrade[%d] %s", plan.Id, state)
+
+	plans, err := c.GetUpgradeHistory()
+	if err != nil {
+		log.Warn("load upgrade history failed, err[%v]", err)
+		return nil
+	}
+	for i := maxUpgradeHistory; i < len(plans); i++ {
+		c.store.Delete([]byte(fmt.Sprintf("%s%d", PREFIX_UPGRADE_HISTORY, plans[i].Id)))
+	}
+	return nil
+}
+
+func (c *Cluster) loadUpgrade() error {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	c.upgrade = nil
+	key := []byte(fmt.Sprintf("%s%d", PREFIX_UPGRADE, c.clusterId))
+	value, err := c.store.Get(key)
+	if err != nil || value == nil {
+		// not found
+		return nil
+	}
+	plan := new(UpgradePlan)
+	if err := json.Unmarshal(value, plan); err != nil {
+		return err
+	}
+	c.upgrade = plan
+	log.Info("upgrade[%d] is in progress, state[%s]", plan.Id, plan.State)
+	return nil
+}
+
+// unrecoveredRanges returns the number of the ranges on the node which have
+// no healthy heartbeat since the time.
+func unrecoveredRanges(node *Node, since time.Time) int {
+	var count int
+	for _, r := range node.GetAllRanges() {
+		peer := r.GetNodePeer(node.GetId())
+		if peer == nil {
+			continue
+		}
+		if r.LastHbTimeTS.Before(since) || r.GetDownPeer(peer.GetId()) != nil || r.GetPendingPeer(peer.GetId()) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// beginUpgradeNode marks the node upgrading, the plan is paused if the node
+// is not up.
+func (c *Cluster) beginUpgradeNode(plan *UpgradePlan, u *UpgradeNode) bool {
+	node := c.FindNodeById(u.NodeId)
+	if node == nil {
+		u.Step = UpgradeStepSkipped
+		u.Message = ErrNotExistNode.Error()
+		return true
+	}
+	if !node.isUp() {
+		plan.State = UpgradePaused
+		plan.Message = fmt.Sprintf("node[%d] state is %s, not login", u.NodeId, node.State.String())
+		log.Warn("upgrade[%d] paused, %s", plan.Id, plan.Message)
+		return false
+	}
+	if err := c.UpdateNodeState(node, metapb.NodeState_N_Upgrade); err != nil {
+		return false
+	}
+	u.Step = UpgradeStepEvicting
+	u.BeginTime = time.Now().Unix()
+	log.Info("upgrade[%d] node[%s] begin to evict leaders", plan.Id, node.GetServerAddr())
+	return true
+}
+
+// stepUpgradeNode checks the progress of the upgrading node, it returns true
+// if the node goes to the next step.
+func (c *Cluster) stepUpgradeNode(plan *UpgradePlan, u *UpgradeNode) bool {
+	node := c.FindNodeById(u.NodeId)
+	if node == nil {
+		u.Step = UpgradeStepSkipped
+		u.Message = ErrNotExistNode.Error()
+		return true
+	}
+	switch u.Step {
+	case UpgradeStepEvicting:
+		if node.IsLogin() {
+			// 迁出leader时节点被提前重启了, 重新迁出
+			if err := c.UpdateNodeState(node, metapb.NodeState_N_Upgrade); err != nil {
+				return false
+			}
+		}
+		u.Leaders = leaderRanges(node)
+		if u.Leaders > 0 || !node.isUpgrading() {
+			return false
+		}
+		u.Step = UpgradeStepReady
+		u.ReadyTime = time.Now().Unix()
+		if !node.loginTS.IsZero() {
+			u.LoginTS = node.loginTS.UnixNano()
+		}
+		log.Warn("upgrade[%d] node[%s] has no leader, it is ready to restart", plan.Id, node.GetServerAddr())
+		return true
+	case UpgradeStepReady:
+		// 重启后NodeLogin将节点恢复为login状态
+		if !node.IsLogin() || node.loginTS.IsZero() || node.loginTS.UnixNano() <= u.LoginTS {
+			return false
+		}
+		u.Step = UpgradeStepRecovering
+		u.RestartTime = node.loginTS.Unix()
+		log.Info("upgrade[%d] node[%s] restarted", plan.Id, node.GetServerAddr())
+		return true
+	case UpgradeStepRecovering:
+		if !node.IsLogin() {
+			return false
+		}
+		u.Unhealthy = unrecoveredRanges(node, node.loginTS)
+		if u.Unhealthy > 0 {
+			return false
+		}
+		u.Step = UpgradeStepDone
+		u.FinishTime = time.Now().Unix()
+		log.Info("upgrade[%d] node[%s] upgraded", plan.Id, node.GetServerAddr())
+		return true
+	}
+	return false
+}
+
+// advanceUpgrade 推进滚动升级, 同时升级的节点不超过BatchSize, 暂停时只跟踪升级中的节点
+func (c *Cluster) advanceUpgrade() {
+	c.upgradeLock.Lock()
+	defer c.upgradeLock.Unlock()
+	plan := c.upgrade
+	if plan == nil {
+		return
+	}
+	var changed bool
+	for _, u := range plan.Nodes {
+		if c.stepUpgradeNode(plan, u) {
+			changed = true
+		}
+	}
+	if plan.State == UpgradeRunning {
+		active := plan.active()
+		for _, u := range plan.Nodes {
+			if active >= plan.BatchSize {
+				break
+			}
+			if u.Step != UpgradeStepPending {
+				continue
+			}
+			if !c.beginUpgradeNode(plan, u) {
+				changed = true
+				break
+			}
+			if u.Step == UpgradeStepEvicting {
+				active++
+			}
+			changed = true
+		}
+	}
+	if plan.finished() {
+		if err := c.archiveUpgrade(plan, UpgradeFinished); err != nil {
+			log.Error("archive upgrade[%d] failed, err[%v]", plan.Id, err)
+		}
+		return
+	}
+	if changed {
+		if err := c.storeUpgrade(plan); err != nil {
+			log.Error("store upgrade[%d] failed, err[%v]", plan.Id, err)
+		}
+	}
+}
+
+// UpgradeWorker 按升级计划逐批升级节点
+type UpgradeWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+}
+
+func NewUpgradeWorker(wm *WorkerManager, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &UpgradeWorker{
+		name:     upgradeWorkerName,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+	}
+}
+
+func (w *UpgradeWorker) GetName() string {
+	return w.name
+}
+
+func (w *UpgradeWorker) Work(cluster *Cluster) {
+	cluster.advanceUpgrade()
+}
+
+func (w *UpgradeWorker) AllowWork(cluster *Cluster) bool {
+	return true
+}
+
+func (w *UpgradeWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *UpgradeWorker) Stop() {
+	w.cancel()
+}
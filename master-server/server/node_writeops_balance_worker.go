@@ -41,7 +41,7 @@ func (w *balanceNodeOpsWorker) Work(cluster *Cluster) {
 	}
 
 	sourceNode := cluster.FindNodeById(oldPeer.GetNodeId())
-	newPeer, err := cluster.allocPeerAndSelectNode(rng, true)
+	newPeer, err := cluster.allocPeerAndSelectNode(rng, true, oldPeer.GetKeepLearner())
 	if newPeer == nil || err != nil {
 		cluster.metric.CollectScheduleCounter(w.GetName(), "no_peer")
 		log.Error("alloc peer failure rngId:%d err:%s", rng.GetId(), err.Error())
@@ -62,7 +62,7 @@ func (w *balanceNodeOpsWorker) Work(cluster *Cluster) {
 		rng.GetId(), oldPeer.GetId(), oldPeer.GetNodeId(), newPeer.GetNodeId())
 	tc := NewTransferPeerTasks(taskID, rng, "ops-range-tranfer", oldPeer)
 	// TODO: check return
-	cluster.taskManager.Add(tc)
+	cluster.addTask(w.GetName(), tc)
 	return
 }
 
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"model/pkg/metapb"
+	"util"
+	"util/deepcopy"
+	"util/log"
+)
+
+// PlacementRule 副本放置规则, 未设置的字段使用上一级(key范围->表->集群)的设置
+type PlacementRule struct {
+	// 投票副本数, 0表示使用集群的max-replicas
+	Replicas int `json:"replicas,omitempty"`
+	// 投票副本所在节点必须带有的标签
+	RequiredLabels []*metapb.NodeLabel `json:"required_labels,omitempty"`
+	// 投票副本优先放置的节点标签
+	PreferredLabels []*metapb.NodeLabel `json:"preferred_labels,omitempty"`
+	// leader所在节点必须带有的标签, 没有满足的副本时不迁移leader
+	LeaderLabels []*metapb.NodeLabel `json:"leader_labels,omitempty"`
+	// 不参与投票的learner副本数, 如分析用的副本
+	Learners int `json:"learners,omitempty"`
+	// learner副本所在节点必须带有的标签
+	LearnerLabels []*metapb.NodeLabel `json:"learner_labels,omitempty"`
+}
+
+// KeyRangePlacement is the placement rule of the rows in [StartKey, EndKey).
+type KeyRangePlacement struct {
+	// encoded primary keys without table prefix like split_keys,
+	// empty end key means the end of the table
+	StartKey []byte `json:"start_key,omitempty"`
+	EndKey   []byte `json:"end_key,omitempty"`
+	PlacementRule
+}
+
+// Placement is the placement rules in the table properties.
+type Placement struct {
+	PlacementRule
+	// 按range的start key匹配, 先匹配的生效
+	KeyRanges []*KeyRangePlacement `json:"key_ranges,omitempty"`
+}
+
+type cachedPlacement struct {
+	properties string
+	placement  *Placement
+}
+
+func validLabels(labels []*metapb.NodeLabel) bool {
+	for _, label := range labels {
+		if label == nil || len(label.GetKey()) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *PlacementRule) validate() error {
+	if r.Replicas < 0 || r.Learners < 0 {
+		return ErrInvalidPlacement
+	}
+	if !validLabels(r.RequiredLabels) || !validLabels(r.PreferredLabels) ||
+		!validLabels(r.LeaderLabels) || !validLabels(r.LearnerLabels) {
+		return ErrInvalidPlacement
+	}
+	return nil
+}
+
+func (r *PlacementRule) isEmpty() bool {
+	return r.Replicas == 0 && r.Learners == 0 &&
+		len(r.RequiredLabels) == 0 && len(r.PreferredLabels) == 0 &&
+		len(r.LeaderLabels) == 0 && len(r.LearnerLabels) == 0
+}
+
+// merge 用other中设置了的字段覆盖
+func (r *PlacementRule) merge(other *PlacementRule) {
+	if other.Replicas > 0 {
+		r.Replicas = other.Replicas
+	}
+	if len(other.RequiredLabels) > 0 {
+		r.RequiredLabels = other.RequiredLabels
+	}
+	if len(other.PreferredLabels) > 0 {
+		r.PreferredLabels = other.PreferredLabels
+	}
+	if len(other.LeaderLabels) > 0 {
+		r.LeaderLabels = other.LeaderLabels
+	}
+	if other.Learners > 0 {
+		r.Learners = other.Learners
+	}
+	if len(other.LearnerLabels) > 0 {
+		r.LearnerLabels = other.LearnerLabels
+	}
+}
+
+func (p *Placement) validate() error {
+	if err := p.PlacementRule.validate(); err != nil {
+		return err
+	}
+	for _, kr := range p.KeyRanges {
+		if kr == nil {
+			return ErrInvalidPlacement
+		}
+		if len(kr.EndKey) > 0 && bytes.Compare(kr.StartKey, kr.EndKey) >= 0 {
+			return ErrInvalidPlacement
+		}
+		if err := kr.PlacementRule.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Placement) isEmpty() bool {
+	return p == nil || (p.PlacementRule.isEmpty() && len(p.KeyRanges) == 0)
+}
+
+// rule returns the rule of the range starting at startKey, the key range
+// rule overrides the table rule.
+func (p *Placement) rule(tableId uint64, startKey []byte) PlacementRule {
+	rule := p.PlacementRule
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, tableId)
+	for _, kr := range p.KeyRanges {
+		start := append(append([]byte(nil), prefix...), kr.StartKey...)
+		if bytes.Compare(startKey, start) < 0 {
+			continue
+		}
+		if len(kr.EndKey) > 0 {
+			end := append(append([]byte(nil), prefix...), kr.EndKey...)
+			if bytes.Compare(startKey, end) >= 0 {
+				continue
+			}
+		}
+		rule.merge(&kr.PlacementRule)
+		break
+	}
+	return rule
+}
+
+// ParsePlacement returns the placement rules in the table properties, nil if
+// there is none.
+func ParsePlacement(properties string) (*Placement, error) {
+	if len(properties) == 0 {
+		return nil, nil
+	}
+	tp := new(struct {
+		Placement *Placement `json:"placement"`
+	})
+	if err := json.Unmarshal([]byte(properties), tp); err != nil {
+		return nil, err
+	}
+	if tp.Placement.isEmpty() {
+		return nil, nil
+	}
+	if err := tp.Placement.validate(); err != nil {
+		return nil, err
+	}
+	return tp.Placement, nil
+}
+
+// Placement returns the placement rules of the table, nil means the defaults
+// of the cluster.
+func (t *Table) Placement() *Placement {
+	props := t.GetProperties()
+	if cached, ok := t.placement.Load().(*cachedPlacement); ok && cached.properties == props {
+		return cached.placement
+	}
+	placement, err := ParsePlacement(props)
+	if err != nil {
+		log.Warn("table[%s:%s] invalid placement, err[%v]", t.GetDbName(), t.GetName(), err)
+		placement = nil
+	}
+	t.placement.Store(&cachedPlacement{properties: props, placement: placement})
+	return placement
+}
+
+// UpdatePlacement changes the placement rules of the table, nil or empty
+// rules restore the defaults of the cluster. The replicas are moved by the
+// range heartbeat afterwards.
+func (t *Table) UpdatePlacement(placement *Placement, cluster *Cluster) error {
+	if placement.isEmpty() {
+		placement = nil
+	} else if err := placement.validate(); err != nil {
+		return err
+	}
+	t.schemaLock.Lock()
+	defer t.schemaLock.Unlock()
+	table := deepcopy.Iface(t.Table).(*metapb.Table)
+	props, err := ToTableProperty(table.Columns, t.TTL(), placement)
+	if err != nil {
+		return err
+	}
+	table.Properties = props
+	if table.Epoch == nil {
+		table.Epoch = &metapb.TableEpoch{}
+	}
+	table.Epoch.ConfVer++
+	if err = cluster.storeTable(table); err != nil {
+		log.Error("store table failed, err[%v]", err)
+		return err
+	}
+	t.Table = table
+	log.Info("table[%s:%s] placement changed to %s", table.GetDbName(), table.GetName(), props)
+	return nil
+}
+
+// rangePlacement returns the placement rule of the range.
+func (c *Cluster) rangePlacement(rng *Range) *PlacementRule {
+	var rule PlacementRule
+	if t, ok := c.FindTableById(rng.GetTableId()); ok {
+		if p := t.Placement(); p != nil {
+			rule = p.rule(rng.GetTableId(), rng.GetStartKey())
+		}
+	}
+	if rule.Replicas == 0 {
+		rule.Replicas = c.opt.GetMaxReplicas()
+	}
+	return &rule
+}
+
+func matchLabels(node *Node, labels []*metapb.NodeLabel) bool {
+	for _, label := range labels {
+		if node.getLabelValue(label.GetKey()) != label.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+// allows reports whether the node can hold a voter or a learner of the range.
+func (r *PlacementRule) allows(node *Node, learner bool) bool {
+	if learner {
+		return matchLabels(node, r.LearnerLabels)
+	}
+	return matchLabels(node, r.RequiredLabels)
+}
+
+func (r *PlacementRule) prefers(node *Node) bool {
+	return len(r.PreferredLabels) > 0 && matchLabels(node, r.PreferredLabels)
+}
+
+func (r *PlacementRule) allowsLeader(node *Node) bool {
+	return matchLabels(node, r.LeaderLabels)
+}
+
+// countPeers 返回投票副本(包括正在追日志的learner)和保持learner的副本数
+func countPeers(peers []*metapb.Peer) (voters, learners int) {
+	for _, peer := range peers {
+		if peer.GetKeepLearner() {
+			learners++
+		} else {
+			voters++
+		}
+	}
+	return
+}
+
+// misplacedPeer returns a peer on the node which the rule does not allow.
+func (c *Cluster) misplacedPeer(rng *Range, rule *PlacementRule) *metapb.Peer {
+	for _, peer := range rng.GetPeers() {
+		node := c.FindNodeById(peer.GetNodeId())
+		if node != nil && !rule.allows(node, peer.GetKeepLearner()) {
+			return peer
+		}
+	}
+	return nil
+}
+
+// leaderCandidateNodes returns the nodes of the healthy voters which can be
+// the leader of the range.
+func (c *Cluster) leaderCandidateNodes(rng *Range, rule *PlacementRule) []*Node {
+	var nodes []*Node
+	for _, peer := range rng.GetPeers() {
+		if peer.GetType() != metapb.PeerType_PeerType_Normal {
+			continue
+		}
+		if leader := rng.GetLeader(); leader != nil && leader.GetId() == peer.GetId() {
+			continue
+		}
+		if rng.GetDownPeer(peer.GetId()) != nil {
+			continue
+		}
+		node := c.FindNodeById(peer.GetNodeId())
+		if node == nil || !node.IsLogin() || !rule.allowsLeader(node) || c.leaderEvicted(node) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
@@ -567,6 +567,13 @@ func (c *idCache) set(id uint64) {
 	c.expireRegionCache.set(id, nil)
 }
 
+// reset removes all the ids.
+func (c *idCache) reset() {
+	c.expireRegionCache.Lock()
+	c.expireRegionCache.items = make(map[uint64]cacheItem)
+	c.expireRegionCache.Unlock()
+}
+
 func (c *idCache) get(id uint64) bool {
 	_, ok := c.expireRegionCache.get(id)
 	return ok
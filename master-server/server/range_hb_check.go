@@ -82,7 +82,9 @@ func (hb *RangeHbCheckWorker) Work(cluster *Cluster) {
 						table.GetId(), r.GetId(), leader.GetNodeId(), r.GetDownPeers())
 					//TODO: alarm
 				} else {
-					if len(r.Peers) != cluster.opt.GetMaxReplicas() || len(r.GetDownPeers()) > 0 {
+					rule := cluster.rangePlacement(r)
+					voters, learners := countPeers(r.GetPeers())
+					if voters != rule.Replicas || learners != rule.Learners || len(r.GetDownPeers()) > 0 {
 						cluster.unstableRanges.Put(r.GetId(), r)
 					}
 				}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"model/pkg/mspb"
+	"util/log"
+
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+)
+
+const (
+	// 按mysql登录用户匹配
+	resourceGroupUser = "user"
+	// 按数据库匹配
+	resourceGroupDb = "db"
+	// 按REST请求的Sign匹配
+	resourceGroupSign = "sign"
+)
+
+func validResourceGroup(g *mspb.ResourceGroup) error {
+	if len(g.GetName()) == 0 {
+		return fmt.Errorf("resource group name is required")
+	}
+	switch g.GetKind() {
+	case resourceGroupUser, resourceGroupDb, resourceGroupSign:
+	default:
+		return fmt.Errorf("invalid resource group kind %s, expect %s, %s or %s",
+			g.GetKind(), resourceGroupUser, resourceGroupDb, resourceGroupSign)
+	}
+	if len(g.GetKey()) == 0 {
+		return fmt.Errorf("resource group %s: %s is required", g.GetName(), g.GetKind())
+	}
+	return nil
+}
+
+// SetResourceGroup adds the group or replaces the limits of the group with
+// the same name and persists it, the gateways pull the groups periodically.
+func (c *Cluster) SetResourceGroup(g *mspb.ResourceGroup) error {
+	if err := validResourceGroup(g); err != nil {
+		return err
+	}
+	c.resourceGroupLock.Lock()
+	defer c.resourceGroupLock.Unlock()
+	for _, o := range c.resourceGroups {
+		if o.GetName() != g.GetName() && o.GetKind() == g.GetKind() && o.GetKey() == g.GetKey() {
+			return fmt.Errorf("%s %s is already in resource group %s", g.GetKind(), g.GetKey(), o.GetName())
+		}
+	}
+	data, err := proto.Marshal(g)
+	if err != nil {
+		return err
+	}
+	key := []byte(fmt.Sprintf("%s%s", PREFIX_RESOURCE_GROUP, g.GetName()))
+	if err := c.store.Put(key, data); err != nil {
+		log.Error("store resource group[%s] failed, err[%v]", g.GetName(), err)
+		return err
+	}
+	c.resourceGroups[g.GetName()] = g
+	return nil
+}
+
+func (c *Cluster) DeleteResourceGroup(name string) error {
+	c.resourceGroupLock.Lock()
+	defer c.resourceGroupLock.Unlock()
+	if _, find := c.resourceGroups[name]; !find {
+		return fmt.Errorf("resource group %s not exist", name)
+	}
+	key := []byte(fmt.Sprintf("%s%s", PREFIX_RESOURCE_GROUP, name))
+	if err := c.store.Delete(key); err != nil {
+		log.Error("delete resource group[%s] failed, err[%v]", name, err)
+		return err
+	}
+	delete(c.resourceGroups, name)
+	return nil
+}
+
+// GetResourceGroups returns all groups order by name.
+func (c *Cluster) GetResourceGroups() []*mspb.ResourceGroup {
+	c.resourceGroupLock.RLock()
+	groups := make([]*mspb.ResourceGroup, 0, len(c.resourceGroups))
+	for _, g := range c.resourceGroups {
+		groups = append(groups, g)
+	}
+	c.resourceGroupLock.RUnlock()
+	sort.Slice(groups, func(i, j int) bool { return groups[i].GetName() < groups[j].GetName() })
+	return groups
+}
+
+func (c *Cluster) loadResourceGroups() error {
+	groups := make(map[string]*mspb.ResourceGroup)
+	startKey, limitKey := bytesPrefix([]byte(PREFIX_RESOURCE_GROUP))
+	it := c.store.Scan(startKey, limitKey)
+	defer it.Release()
+	for it.Next() {
+		if it.Key() == nil {
+			log.Error("load resource group key is nil")
+			continue
+		}
+		g := new(mspb.ResourceGroup)
+		if err := proto.Unmarshal(it.Value(), g); err != nil {
+			return err
+		}
+		groups[g.GetName()] = g
+	}
+	c.resourceGroupLock.Lock()
+	c.resourceGroups = groups
+	c.resourceGroupLock.Unlock()
+	return nil
+}
+
+func (service *Server) handleGetResourceGroups(ctx context.Context, req *mspb.GetResourceGroupsRequest) (*mspb.GetResourceGroupsResponse, error) {
+	return &mspb.GetResourceGroupsResponse{
+		Header: &mspb.ResponseHeader{},
+		Groups: service.cluster.GetResourceGroups(),
+	}, nil
+}
+
+// handleResourceGroupSet 添加或者修改资源组, 限制为每秒的值, 0或者不填表示不限制
+func (service *Server) handleResourceGroupSet(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	var limits [3]uint64
+	for i, name := range []string{"qps", "read_rows", "write_bytes"} {
+		value := r.FormValue(name)
+		if len(value) == 0 {
+			continue
+		}
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = fmt.Sprintf("invalid %s %s", name, value)
+			return
+		}
+		limits[i] = limit
+	}
+	g := &mspb.ResourceGroup{
+		Name:       r.FormValue(HTTP_NAME),
+		Kind:       r.FormValue("kind"),
+		Key:        r.FormValue("key"),
+		Qps:        limits[0],
+		ReadRows:   limits[1],
+		WriteBytes: limits[2],
+	}
+	if err := service.cluster.SetResourceGroup(g); err != nil {
+		log.Warn("http set resource group failed, err[%v]", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http set resource group %s %s=%s, limits %v", g.GetName(), g.GetKind(), g.GetKey(), limits)
+}
+
+func (service *Server) handleResourceGroupDel(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	name := r.FormValue(HTTP_NAME)
+	if err := service.cluster.DeleteResourceGroup(name); err != nil {
+		log.Warn("http delete resource group failed, err[%v]", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("http delete resource group %s", name)
+}
+
+func (service *Server) handleResourceGroupList(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	reply.Data = service.cluster.GetResourceGroups()
+}
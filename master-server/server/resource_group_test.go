@@ -0,0 +1,47 @@
+package server
+
+import (
+	"testing"
+
+	"model/pkg/mspb"
+)
+
+func TestResourceGroupPersist(t *testing.T) {
+	cluster := newLevelDbCluster(t, newMockIDAllocator())
+	defer closeLocalCluster(cluster)
+
+	if err := cluster.SetResourceGroup(&mspb.ResourceGroup{Name: "g1", Kind: "table", Key: "t1"}); err == nil {
+		t.Fatal("invalid kind is accepted")
+	}
+	if err := cluster.SetResourceGroup(&mspb.ResourceGroup{Name: "g1", Kind: resourceGroupUser, Key: "u1", Qps: 10}); err != nil {
+		t.Fatalf("set resource group error: %v", err)
+	}
+	if err := cluster.SetResourceGroup(&mspb.ResourceGroup{Name: "g2", Kind: resourceGroupUser, Key: "u1"}); err == nil {
+		t.Fatal("user in two resource groups")
+	}
+	if err := cluster.SetResourceGroup(&mspb.ResourceGroup{Name: "g0", Kind: resourceGroupDb, Key: "d1", ReadRows: 100}); err != nil {
+		t.Fatalf("set resource group error: %v", err)
+	}
+
+	// the groups are loaded again after the leader changes
+	if err := cluster.loadResourceGroups(); err != nil {
+		t.Fatalf("load resource groups error: %v", err)
+	}
+	groups := cluster.GetResourceGroups()
+	if len(groups) != 2 || groups[0].GetName() != "g0" || groups[0].GetReadRows() != 100 || groups[1].GetQps() != 10 {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+
+	if err := cluster.DeleteResourceGroup("g1"); err != nil {
+		t.Fatalf("delete resource group error: %v", err)
+	}
+	if err := cluster.DeleteResourceGroup("g1"); err == nil {
+		t.Fatal("delete a deleted resource group")
+	}
+	if err := cluster.loadResourceGroups(); err != nil {
+		t.Fatalf("load resource groups error: %v", err)
+	}
+	if groups = cluster.GetResourceGroups(); len(groups) != 1 || groups[0].GetName() != "g0" {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+}
@@ -152,6 +152,46 @@ func (service *Server) AddColumn(ctx context.Context, req *mspb.AddColumnRequest
 	return service.handleAddColumns(ctx, req)
 }
 
+func (service *Server) AlterColumn(ctx context.Context, req *mspb.AlterColumnRequest) (*mspb.AlterColumnResponse, error) {
+	if err := service.checkClusterValid(); err != nil {
+		resp := &mspb.AlterColumnResponse{Header: &mspb.ResponseHeader{Error: err}}
+		return resp, nil
+	}
+	return service.handleAlterColumn(ctx, req)
+}
+
+func (service *Server) GetDBs(ctx context.Context, req *mspb.GetDBsRequest) (*mspb.GetDBsResponse, error) {
+	if err := service.checkClusterValid(); err != nil {
+		resp := &mspb.GetDBsResponse{Header: &mspb.ResponseHeader{Error: err}}
+		return resp, nil
+	}
+	return service.handleGetDbs(ctx, req)
+}
+
+func (service *Server) GetTables(ctx context.Context, req *mspb.GetTablesRequest) (*mspb.GetTablesResponse, error) {
+	if err := service.checkClusterValid(); err != nil {
+		resp := &mspb.GetTablesResponse{Header: &mspb.ResponseHeader{Error: err}}
+		return resp, nil
+	}
+	return service.handleGetTables(ctx, req)
+}
+
+func (service *Server) SplitTable(ctx context.Context, req *mspb.SplitTableRequest) (*mspb.SplitTableResponse, error) {
+	if err := service.checkClusterValid(); err != nil {
+		resp := &mspb.SplitTableResponse{Header: &mspb.ResponseHeader{Error: err}}
+		return resp, nil
+	}
+	return service.handleSplitTable(ctx, req)
+}
+
+func (service *Server) GetResourceGroups(ctx context.Context, req *mspb.GetResourceGroupsRequest) (*mspb.GetResourceGroupsResponse, error) {
+	if err := service.checkClusterValid(); err != nil {
+		resp := &mspb.GetResourceGroupsResponse{Header: &mspb.ResponseHeader{Error: err}}
+		return resp, nil
+	}
+	return service.handleGetResourceGroups(ctx, req)
+}
+
 func (service *Server) CreateDatabase(ctx context.Context, req *mspb.CreateDatabaseRequest) (*mspb.CreateDatabaseResponse, error) {
 	if err := service.checkClusterValid(); err != nil {
 		resp := &mspb.CreateDatabaseResponse{Header: &mspb.ResponseHeader{Error: err}}
@@ -0,0 +1,471 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"model/pkg/metapb"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	grantLeaderTaskName   = "grant-node-leader"
+	shuffleLeaderTaskName = "shuffle-leader"
+	shuffleRangeTaskName  = "shuffle-range"
+
+	defaultLeaderSchedulerInterval  = 5 * time.Second
+	defaultShuffleSchedulerInterval = 10 * time.Second
+)
+
+// SchedulerTarget is the nodes a scheduler works on, selected by the node id
+// or the labels.
+type SchedulerTarget struct {
+	NodeId uint64              `json:"node_id,omitempty"`
+	Labels []*metapb.NodeLabel `json:"labels,omitempty"`
+}
+
+func (t *SchedulerTarget) isEmpty() bool {
+	return t == nil || (t.NodeId == 0 && len(t.Labels) == 0)
+}
+
+// match returns true if the node is the target, an empty target matches all
+// the nodes.
+func (t *SchedulerTarget) match(node *Node) bool {
+	if node == nil {
+		return false
+	}
+	if t.isEmpty() {
+		return true
+	}
+	if t.NodeId != 0 && node.GetId() != t.NodeId {
+		return false
+	}
+	return matchLabels(node, t.Labels)
+}
+
+func (t *SchedulerTarget) String() string {
+	var parts []string
+	if t.NodeId != 0 {
+		parts = append(parts, fmt.Sprintf("node=%d", t.NodeId))
+	}
+	for _, label := range t.Labels {
+		parts = append(parts, fmt.Sprintf("%s=%s", label.GetKey(), label.GetValue()))
+	}
+	return strings.Join(parts, ",")
+}
+
+// SchedulerConfig is a scheduler added at runtime, it is persisted so the
+// scheduler goes on after the leader changes.
+type SchedulerConfig struct {
+	Kind   string           `json:"kind"`
+	Target *SchedulerTarget `json:"target,omitempty"`
+}
+
+// Name is the worker name of the scheduler, the kind without target is the
+// scheduler of all the nodes.
+func (cfg *SchedulerConfig) Name() string {
+	if cfg.Target.isEmpty() {
+		return cfg.Kind
+	}
+	return fmt.Sprintf("%s-%s", cfg.Kind, cfg.Target.String())
+}
+
+func (cfg *SchedulerConfig) validate() error {
+	switch cfg.Kind {
+	case grantLeaderWorkerName, evictLeaderWorkerName:
+		// 没有目标的evict_leader_worker是迁出升级节点leader的内置worker
+		if cfg.Target.isEmpty() {
+			return ErrInvalidParam
+		}
+	case shuffleLeaderWorkerName, shuffleRangeWorkerName:
+	default:
+		return ErrSchedulerNotFound
+	}
+	if cfg.Target != nil && !validLabels(cfg.Target.Labels) {
+		return ErrInvalidParam
+	}
+	return nil
+}
+
+func (cfg *SchedulerConfig) newWorker(wm *WorkerManager) Worker {
+	switch cfg.Kind {
+	case grantLeaderWorkerName:
+		return newGrantLeaderWorker(wm, cfg.Name(), cfg.Target, defaultLeaderSchedulerInterval)
+	case evictLeaderWorkerName:
+		return newTargetEvictLeaderWorker(wm, cfg.Name(), cfg.Target, defaultLeaderSchedulerInterval)
+	case shuffleLeaderWorkerName:
+		return newShuffleLeaderWorker(wm, cfg.Name(), cfg.Target, defaultShuffleSchedulerInterval)
+	case shuffleRangeWorkerName:
+		return newShuffleRangeWorker(wm, cfg.Name(), cfg.Target, defaultShuffleSchedulerInterval)
+	}
+	return nil
+}
+
+// AddScheduler creates the scheduler and persists it.
+func (c *Cluster) AddScheduler(cfg *SchedulerConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	if cfg.Target != nil && cfg.Target.NodeId != 0 && c.FindNodeById(cfg.Target.NodeId) == nil {
+		return ErrNotExistNode
+	}
+	name := cfg.Name()
+	if c.workerManger.isExistWorker(name) {
+		return ErrSchedulerExisted
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	key := []byte(fmt.Sprintf("%s%s", PREFIX_SCHEDULER, name))
+	if err := c.store.Put(key, data); err != nil {
+		log.Error("store scheduler[%s] failed, err[%v]", name, err)
+		return err
+	}
+	c.schedulerLock.Lock()
+	c.schedulers[name] = cfg
+	c.schedulerLock.Unlock()
+	log.Info("scheduler[%s] added", name)
+	return c.workerManger.addWorker(cfg.newWorker(c.workerManger))
+}
+
+// removeScheduler deletes the persisted scheduler, nothing is done for the
+// built-in workers.
+func (c *Cluster) removeScheduler(name string) error {
+	c.schedulerLock.Lock()
+	defer c.schedulerLock.Unlock()
+	if _, find := c.schedulers[name]; !find {
+		return nil
+	}
+	key := []byte(fmt.Sprintf("%s%s", PREFIX_SCHEDULER, name))
+	if err := c.store.Delete(key); err != nil {
+		log.Error("delete scheduler[%s] failed, err[%v]", name, err)
+		return err
+	}
+	delete(c.schedulers, name)
+	log.Info("scheduler[%s] removed", name)
+	return nil
+}
+
+func (c *Cluster) getSchedulers() []*SchedulerConfig {
+	c.schedulerLock.RLock()
+	defer c.schedulerLock.RUnlock()
+	var cfgs []*SchedulerConfig
+	for _, cfg := range c.schedulers {
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs
+}
+
+func (c *Cluster) loadSchedulers() error {
+	schedulers := make(map[string]*SchedulerConfig)
+	startKey, limitKey := bytesPrefix([]byte(PREFIX_SCHEDULER))
+	it := c.store.Scan(startKey, limitKey)
+	defer it.Release()
+	for it.Next() {
+		if it.Key() == nil {
+			log.Error("load scheduler key is nil")
+			continue
+		}
+		cfg := new(SchedulerConfig)
+		if err := json.Unmarshal(it.Value(), cfg); err != nil {
+			return err
+		}
+		if err := cfg.validate(); err != nil {
+			log.Warn("invalid scheduler %s, err[%v]", string(it.Value()), err)
+			continue
+		}
+		schedulers[cfg.Name()] = cfg
+	}
+	c.schedulerLock.Lock()
+	c.schedulers = schedulers
+	c.schedulerLock.Unlock()
+	return nil
+}
+
+// leaderEvicted returns true if an evict-leader scheduler works on the node.
+func (c *Cluster) leaderEvicted(node *Node) bool {
+	return c.matchScheduler(evictLeaderWorkerName, node)
+}
+
+// leaderGranted returns true if a grant-leader scheduler works on the node.
+func (c *Cluster) leaderGranted(node *Node) bool {
+	return c.matchScheduler(grantLeaderWorkerName, node)
+}
+
+func (c *Cluster) matchScheduler(kind string, node *Node) bool {
+	c.schedulerLock.RLock()
+	defer c.schedulerLock.RUnlock()
+	for _, cfg := range c.schedulers {
+		if cfg.Kind == kind && cfg.Target.match(node) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeaderSchedulerSelector excludes the nodes whose leaders are evicted.
+type LeaderSchedulerSelector struct {
+	cluster *Cluster
+}
+
+func NewLeaderSchedulerSelector(cluster *Cluster) *LeaderSchedulerSelector {
+	return &LeaderSchedulerSelector{cluster: cluster}
+}
+
+func (sel *LeaderSchedulerSelector) Name() string {
+	return "leader_scheduler"
+}
+
+func (sel *LeaderSchedulerSelector) CanSelect(node *Node) bool {
+	return !sel.cluster.leaderEvicted(node)
+}
+
+func countTasks(cluster *Cluster, name string) int {
+	var count int
+	for _, tc := range cluster.taskManager.GetAll() {
+		if tc.GetName() == name {
+			count++
+		}
+	}
+	return count
+}
+
+// randomNode returns a random login node of the target.
+func randomNode(cluster *Cluster, target *SchedulerTarget) *Node {
+	var nodes []*Node
+	for _, node := range cluster.GetAllActiveNode() {
+		if target.match(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[rand.Intn(len(nodes))]
+}
+
+// grantLeaderWorker 把目标节点上的副本切换为leader
+type grantLeaderWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+	target   *SchedulerTarget
+}
+
+func newGrantLeaderWorker(wm *WorkerManager, name string, target *SchedulerTarget, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &grantLeaderWorker{
+		name:     name,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+		target:   target,
+	}
+}
+
+func (w *grantLeaderWorker) GetName() string {
+	return w.name
+}
+
+func (w *grantLeaderWorker) Work(cluster *Cluster) {
+	moving := countTasks(cluster, grantLeaderTaskName)
+	limit := int(cluster.opt.GetLeaderScheduleLimit())
+	for _, node := range cluster.GetAllActiveNode() {
+		select {
+		case <-w.ctx.Done():
+			return
+		default:
+		}
+		if !w.target.match(node) || cluster.leaderEvicted(node) {
+			continue
+		}
+		for _, r := range node.GetAllRanges() {
+			if moving >= limit {
+				return
+			}
+			leader := r.GetLeader()
+			if leader == nil || leader.GetNodeId() == node.GetId() {
+				continue
+			}
+			// leader已经在目标节点上
+			if w.target.match(cluster.FindNodeById(leader.GetNodeId())) {
+				continue
+			}
+			peer := r.GetNodePeer(node.GetId())
+			if peer.GetType() != metapb.PeerType_PeerType_Normal ||
+				r.GetDownPeer(peer.GetId()) != nil || r.GetPendingPeer(peer.GetId()) != nil {
+				continue
+			}
+			if !r.require(cluster) || !cluster.rangePlacement(r).allowsLeader(node) {
+				continue
+			}
+			if cluster.taskManager.Find(r.GetId()) != nil {
+				continue
+			}
+			id, err := cluster.GenId()
+			if err != nil {
+				return
+			}
+			tc := NewTaskChain(id, r.GetId(), grantLeaderTaskName,
+				NewChangeLeaderTask(leader.GetNodeId(), node.GetId()))
+			if !cluster.addTask(w.GetName(), tc) {
+				continue
+			}
+			cluster.metric.CollectScheduleCounter(w.GetName(), "new_operator")
+			log.Info("%s created to grant leader to node[%s]", tc.GetLogID(), node.GetServerAddr())
+			moving++
+		}
+	}
+}
+
+func (w *grantLeaderWorker) AllowWork(cluster *Cluster) bool {
+	return true
+}
+
+func (w *grantLeaderWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *grantLeaderWorker) Stop() {
+	w.cancel()
+}
+
+// shuffleLeaderWorker 随机切换目标节点上的leader, 用于测试
+type shuffleLeaderWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+	target   *SchedulerTarget
+}
+
+func newShuffleLeaderWorker(wm *WorkerManager, name string, target *SchedulerTarget, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &shuffleLeaderWorker{
+		name:     name,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+		target:   target,
+	}
+}
+
+func (w *shuffleLeaderWorker) GetName() string {
+	return w.name
+}
+
+func (w *shuffleLeaderWorker) Work(cluster *Cluster) {
+	node := randomNode(cluster, w.target)
+	if node == nil {
+		cluster.metric.CollectScheduleCounter(w.GetName(), "no_node")
+		return
+	}
+	r := node.randLeaderRange()
+	if r == nil || !r.require(cluster) || cluster.taskManager.Find(r.GetId()) != nil {
+		return
+	}
+	nodes := cluster.leaderCandidateNodes(r, cluster.rangePlacement(r))
+	if len(nodes) == 0 {
+		cluster.metric.CollectScheduleCounter(w.GetName(), "no_node")
+		return
+	}
+	to := nodes[rand.Intn(len(nodes))]
+	id, err := cluster.GenId()
+	if err != nil {
+		return
+	}
+	tc := NewTaskChain(id, r.GetId(), shuffleLeaderTaskName, NewChangeLeaderTask(node.GetId(), to.GetId()))
+	if !cluster.addTask(w.GetName(), tc) {
+		return
+	}
+	cluster.metric.CollectScheduleCounter(w.GetName(), "new_operator")
+	log.Info("%s created to shuffle leader from node[%s] to node[%s]", tc.GetLogID(), node.GetServerAddr(), to.GetServerAddr())
+}
+
+func (w *shuffleLeaderWorker) AllowWork(cluster *Cluster) bool {
+	if cluster.autoTransferUnable {
+		return false
+	}
+	return true
+}
+
+func (w *shuffleLeaderWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *shuffleLeaderWorker) Stop() {
+	w.cancel()
+}
+
+// shuffleRangeWorker 随机迁移目标节点上的副本, 用于测试
+type shuffleRangeWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+	target   *SchedulerTarget
+}
+
+func newShuffleRangeWorker(wm *WorkerManager, name string, target *SchedulerTarget, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &shuffleRangeWorker{
+		name:     name,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+		target:   target,
+	}
+}
+
+func (w *shuffleRangeWorker) GetName() string {
+	return w.name
+}
+
+func (w *shuffleRangeWorker) Work(cluster *Cluster) {
+	node := randomNode(cluster, w.target)
+	if node == nil {
+		cluster.metric.CollectScheduleCounter(w.GetName(), "no_node")
+		return
+	}
+	r := node.ranges.GetRandomRange()
+	if r == nil || !r.require(cluster) || cluster.taskManager.Find(r.GetId()) != nil {
+		return
+	}
+	peer := r.GetNodePeer(node.GetId())
+	if peer == nil || cluster.selectNodeForAddPeer(r, peer.GetKeepLearner()) == nil {
+		cluster.metric.CollectScheduleCounter(w.GetName(), "no_node")
+		return
+	}
+	id, err := cluster.GenId()
+	if err != nil {
+		return
+	}
+	tc := NewTransferPeerTasks(id, r, shuffleRangeTaskName, peer)
+	if !cluster.addTask(w.GetName(), tc) {
+		return
+	}
+	cluster.metric.CollectScheduleCounter(w.GetName(), "new_operator")
+	log.Info("%s created to shuffle range from node[%s]", tc.GetLogID(), node.GetServerAddr())
+}
+
+func (w *shuffleRangeWorker) AllowWork(cluster *Cluster) bool {
+	if cluster.autoTransferUnable {
+		return false
+	}
+	return true
+}
+
+func (w *shuffleRangeWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *shuffleRangeWorker) Stop() {
+	w.cancel()
+}
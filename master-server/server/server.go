@@ -35,6 +35,8 @@ type Server struct {
 	alarmServer  *alarm2.Server
 	alarmClient  *alarm2.Client
 
+	backupManager *BackupManager
+
 	leaderChangeNotify chan uint64
 	wg                 sync.WaitGroup
 	ctx                context.Context
@@ -68,12 +70,31 @@ func (service *Server) initHttpHandler() () {
 
 	s.Handle("/manage/table/cancel", NewHandler(service.validRequest, service.handleTableCancel))
 	s.Handle("/manage/table/edit", NewHandler(service.validRequest, service.handleTableEdit))
+	s.Handle("/manage/table/placement", NewHandler(service.validRequest, service.handleTablePlacement))
 	s.Handle("/manage/table/delete", NewHandler(service.validRequest, service.handleTableDelete))
 	s.Handle("/manage/table/delete/fast", NewHandler(service.validRequest, service.handleTableFastDelete))
+	s.Handle("/manage/table/backup", NewHandler(service.validRequest, service.handleTableBackup))
+	s.Handle("/manage/table/restore", NewHandler(service.validRequest, service.handleTableRestore))
+	s.Handle("/manage/table/backup/query", NewHandler(service.validRequest, service.handleBackupJobQuery))
+	s.Handle("/manage/table/backup/cancel", NewHandler(service.validRequest, service.handleBackupJobCancel))
+	s.Handle("/manage/meta/export", NewHandler(service.validRequest, service.handleMetaExport))
+	s.Handle("/manage/meta/import", NewHandler(service.validRequest, service.handleMetaImport))
+	s.Handle("/manage/meta/recover/start", NewHandler(service.validRequest, service.handleMetaRecoverStart))
+	s.Handle("/manage/meta/recover/status", NewHandler(service.validRequest, service.handleMetaRecoverStatus))
+	s.Handle("/manage/meta/recover/probe", NewHandler(service.validRequest, service.handleMetaRecoverProbe))
+	s.Handle("/manage/meta/recover/stop", NewHandler(service.validRequest, service.handleMetaRecoverStop))
 	s.Handle("/manage/node/login", NewHandler(service.validRequest, service.handleHttpNodeLogin))
 	s.Handle("/manage/node/logout", NewHandler(service.validRequest, service.handleHttpNodeLogout))
+	s.Handle("/manage/node/drain", NewHandler(service.validRequest, service.handleHttpNodeDrain))
+	s.Handle("/manage/node/drain/progress", NewHandler(service.validRequest, service.handleHttpNodeDrainProgress))
 	s.Handle("/manage/node/delete", NewHandler(service.validRequest, service.handleHttpNodeDelete))
 	s.Handle("/manage/node/upgrade", NewHandler(service.validRequest, service.handleNodeUpgrade))
+	s.Handle("/manage/upgrade/start", NewHandler(service.validRequest, service.handleUpgradeStart))
+	s.Handle("/manage/upgrade/pause", NewHandler(service.validRequest, service.handleUpgradePause))
+	s.Handle("/manage/upgrade/resume", NewHandler(service.validRequest, service.handleUpgradeResume))
+	s.Handle("/manage/upgrade/abort", NewHandler(service.validRequest, service.handleUpgradeAbort))
+	s.Handle("/manage/upgrade/status", NewHandler(service.validRequest, service.handleUpgradeStatus))
+	s.Handle("/manage/upgrade/history", NewHandler(service.validRequest, service.handleUpgradeHistory))
 	s.Handle("/manage/node/setLogLevel", NewHandler(service.validRequest, service.handleNodeSetLogLevel))
 	s.Handle("/manage/node/getRangeTopo", NewHandler(service.validRequest, service.handleNodeGetRangeTopo))
 	s.Handle("/manage/node/getConfigOfNode", NewHandler(service.validRequest, service.handleNodeGetConfig))
@@ -87,6 +108,13 @@ func (service *Server) initHttpHandler() () {
 	s.Handle("/manage/scheduler/add", NewHandler(service.validRequest, service.handleAddScheduler))
 	s.Handle("/manage/scheduler/remove", NewHandler(service.validRequest, service.handleRemoveScheduler))
 	s.Handle("/manage/scheduler/detail", NewHandler(service.validRequest, service.handleQuerySchedulerDetail))
+	s.Handle("/manage/scheduler/dryrun", NewHandler(service.validRequest, service.handleSchedulerDryRun))
+	s.Handle("/manage/scheduler/dryrun/tasks", NewHandler(service.validRequest, service.handleSchedulerDryRunTasks))
+	s.Handle("/manage/resource_group/set", NewHandler(service.validRequest, service.handleResourceGroupSet))
+	s.Handle("/manage/resource_group/del", NewHandler(service.validRequest, service.handleResourceGroupDel))
+	s.Handle("/manage/resource_group/list", NewHandler(service.validRequest, service.handleResourceGroupList))
+	s.Handle("/manage/simulate/snapshot", NewHandler(service.validRequest, service.handleSimulateSnapshot))
+	s.Handle("/manage/simulate/run", NewHandler(service.validRequest, service.handleSimulateRun))
 
 	s.Handle("/manage/database/getall", NewHandler(service.validRequest, service.handleDBGetAll))
 	s.Handle("/manage/table/getall", NewHandler(service.validRequest, service.handleTableGetAll))
@@ -126,6 +154,7 @@ func (service *Server) initHttpHandler() () {
 
 	s.Handle("/manage/task/getall", NewHandler(service.validRequest, service.handleGetAllTask))
 	s.Handle("/manage/task/delete", NewHandler(service.validRequest, service.handleDeleteTask))
+	s.Handle("/manage/task/history", NewHandler(service.validRequest, service.handleTaskHistory))
 
 	s.Handle("/manage/range/leader/query", NewHandler(service.validRequest, service.handleRangeLeaderQuery))
 	s.Handle("/manage/topology/query", NewHandler(service.validRequest, service.handleTopologyQuery))
@@ -186,6 +215,12 @@ func (service *Server) InitMasterServer(conf *Config) {
 	opt := newScheduleOption(conf)
 	service.opt = opt
 	service.cluster = NewCluster(uint64(conf.Cluster.ClusterID), uint64(conf.NodeId), saveStore, opt)
+	var msAddrs []string
+	for _, peer := range peers {
+		msAddrs = append(msAddrs, peer.RpcServerAddr)
+	}
+	service.backupManager = NewBackupManager(&conf.Backup, msAddrs)
+	service.cluster.backupEnv = service.backupManager.getEnv
 	if service.server == nil {
 		s := server.NewServer()
 		s.Init("master", &server.ServerConfig{
@@ -226,11 +261,14 @@ func (service *Server) InitMetricServer(conf *Config) {
 		service.server = s
 	}
 	var store metric.Store
-	if conf.Metric.Server.StoreType == metric.TYPE_SQL {
+	switch conf.Metric.Server.StoreType {
+	case metric.TYPE_SQL:
 		dns := fmt.Sprintf("%s/%s?readTimeout=5s&writeTimeout=5s&timeout=10s",
 			conf.Metric.Server.StoreUrl[0], "fbase")
 		store = metric.NewSqlStore(dns, int(conf.Metric.Server.QueueNum))
-	} else { //默认为es存储
+	case metric.TYPE_TSDB:
+		store = metric.NewTsdbStore(conf.Metric.Server.StoreUrl, conf.Metric.Server.Tsdb)
+	default: //默认为es存储
 		store = metric.NewEsStore(conf.Metric.Server.StoreUrl, int(conf.Metric.Server.QueueNum))
 	}
 	service.metricServer = metric.NewMetric(service.server, store, conf.Threshold)
@@ -0,0 +1,497 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"master-server/simulate"
+	"model/pkg/metapb"
+	"model/pkg/mspb"
+	"util/deepcopy"
+	"util/log"
+)
+
+const (
+	defaultSimulateTicks = 60
+	maxSimulateTicks     = 3600
+	// 一个虚拟tick的时长, 调度器按各自的interval在虚拟时间上执行
+	simulateTickInterval = time.Second
+	// 调度中节点的缓存10秒过期, 对应10个tick
+	simulateDealingTicks = 10
+)
+
+// 不指定时模拟的调度器, 另外包括快照中带参数的调度器
+var defaultSimulateWorkers = []string{
+	balanceLeaderWorkerName,
+	balanceRangeWorkerName,
+	drainNodeWorkerName,
+	evictLeaderWorkerName,
+}
+
+// SimNode is a node and its last heartbeat stats in the snapshot.
+type SimNode struct {
+	Node  *metapb.Node    `json:"node"`
+	Stats *mspb.NodeStats `json:"stats,omitempty"`
+}
+
+// SimSnapshot 模拟调度用的集群快照
+type SimSnapshot struct {
+	Nodes  []*SimNode      `json:"nodes"`
+	Tables []*metapb.Table `json:"tables,omitempty"`
+	// 与/manage/topology/query的返回相同
+	Ranges []*metapb.Range `json:"ranges"`
+	// range id -> leader所在的节点, 没有时第一个副本为leader
+	Leaders map[uint64]uint64 `json:"leaders,omitempty"`
+	// range id -> 估算的数据量
+	RangeSizes map[uint64]uint64  `json:"range_sizes,omitempty"`
+	Schedulers []*SchedulerConfig `json:"schedulers,omitempty"`
+}
+
+// SimTask is a task chain created during the simulation.
+type SimTask struct {
+	Tick    int    `json:"tick"`
+	Worker  string `json:"worker"`
+	RangeId uint64 `json:"range_id"`
+	Name    string `json:"name"`
+	Detail  string `json:"detail"`
+}
+
+// SimNodeScore is the load of a node.
+type SimNodeScore struct {
+	NodeId     uint64 `json:"node_id"`
+	ServerAddr string `json:"server_addr"`
+	State      string `json:"state"`
+	Ranges     uint32 `json:"ranges"`
+	Leaders    uint32 `json:"leaders"`
+	UsedSize   uint64 `json:"used_size"`
+}
+
+// BalanceScore 节点负载的分布, 标准差只统计login状态的节点, 越小越均衡
+type BalanceScore struct {
+	Nodes        []*SimNodeScore `json:"nodes"`
+	RangeStddev  float64         `json:"range_stddev"`
+	LeaderStddev float64         `json:"leader_stddev"`
+	SizeStddev   float64         `json:"size_stddev"`
+}
+
+// SimResult is the result of a simulation.
+type SimResult struct {
+	Ticks   int        `json:"ticks"`
+	Workers []string   `json:"workers"`
+	Tasks   []*SimTask `json:"tasks"`
+	// 模拟结束时未完成的任务数
+	Running int           `json:"running"`
+	Before  *BalanceScore `json:"before"`
+	After   *BalanceScore `json:"after"`
+}
+
+// SimulationSnapshot returns the snapshot of the nodes and ranges for the
+// simulation.
+func (c *Cluster) SimulationSnapshot() *SimSnapshot {
+	snap := &SimSnapshot{
+		Leaders:    make(map[uint64]uint64),
+		RangeSizes: make(map[uint64]uint64),
+		Schedulers: c.getSchedulers(),
+	}
+	for _, n := range c.GetAllNode() {
+		n.lock.RLock()
+		node := deepcopy.Iface(n.Node).(*metapb.Node)
+		n.lock.RUnlock()
+		snap.Nodes = append(snap.Nodes, &SimNode{Node: node, Stats: deepcopy.Iface(n.stats).(*mspb.NodeStats)})
+	}
+	sort.Slice(snap.Nodes, func(i, j int) bool { return snap.Nodes[i].Node.GetId() < snap.Nodes[j].Node.GetId() })
+	for _, t := range c.workingTables.GetAllTable() {
+		snap.Tables = append(snap.Tables, deepcopy.Iface(t.Table).(*metapb.Table))
+	}
+	for _, r := range c.GetAllRanges() {
+		snap.Ranges = append(snap.Ranges, deepcopy.Iface(r.Range).(*metapb.Range))
+		if leader := r.GetLeader(); leader != nil {
+			snap.Leaders[r.GetId()] = leader.GetNodeId()
+		}
+		if r.ApproximateSize > 0 {
+			snap.RangeSizes[r.GetId()] = r.ApproximateSize
+		}
+	}
+	sort.Slice(snap.Ranges, func(i, j int) bool { return snap.Ranges[i].GetId() < snap.Ranges[j].GetId() })
+	return snap
+}
+
+func stddev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	avg := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - avg) * (v - avg)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
+
+// balanceScore returns the load of the nodes from their heartbeat stats.
+func (c *Cluster) balanceScore() *BalanceScore {
+	score := new(BalanceScore)
+	var ranges, leaders, sizes []float64
+	for _, node := range c.GetAllNode() {
+		s := &SimNodeScore{
+			NodeId:     node.GetId(),
+			ServerAddr: node.GetServerAddr(),
+			State:      node.GetState().String(),
+			Ranges:     node.GetRangesCount(),
+			Leaders:    node.GetLeaderCount(),
+			UsedSize:   node.storageSize(),
+		}
+		score.Nodes = append(score.Nodes, s)
+		if node.IsLogin() {
+			ranges = append(ranges, float64(s.Ranges))
+			leaders = append(leaders, float64(s.Leaders))
+			sizes = append(sizes, float64(s.UsedSize))
+		}
+	}
+	sort.Slice(score.Nodes, func(i, j int) bool { return score.Nodes[i].NodeId < score.Nodes[j].NodeId })
+	score.RangeStddev = stddev(ranges)
+	score.LeaderStddev = stddev(leaders)
+	score.SizeStddev = stddev(sizes)
+	return score
+}
+
+// Simulator 在内存中的集群上按虚拟tick运行调度器, 由模拟的data server执行任务,
+// 用于评估调度策略和参数, 不影响真实的集群
+type Simulator struct {
+	cluster *Cluster
+	ds      *simulate.DataServer
+	workers []Worker
+	// 调度器下次执行的虚拟时间
+	nextRun map[string]time.Duration
+	// 已记录的task chain
+	seen map[uint64]bool
+	// 节点心跳统计的数据量, 按range数据量的变化更新
+	baseUsed  map[uint64]uint64
+	baseAvail map[uint64]uint64
+	baseSize  map[uint64]uint64
+	tick      int
+	result    *SimResult
+}
+
+// NewSimulator loads the snapshot into an in-memory cluster, the workers are
+// defaultSimulateWorkers and the schedulers of the snapshot if none is given.
+func NewSimulator(snap *SimSnapshot, opt *scheduleOption, workers []string) (*Simulator, error) {
+	o := *opt
+	o.MetricAddr = ""
+	o.MetricInterval = 0
+	cluster := NewCluster(0, 0, newSimStore(), &o)
+	cluster.cli.Close()
+	ds := simulate.NewDataServer(simNodes{cluster: cluster})
+	cluster.cli = ds
+
+	var maxId uint64
+	for _, sn := range snap.Nodes {
+		if sn == nil || sn.Node == nil {
+			return nil, ErrInvalidParam
+		}
+		node := NewNode(deepcopy.Iface(sn.Node).(*metapb.Node))
+		if sn.Stats != nil {
+			node.stats = deepcopy.Iface(sn.Stats).(*mspb.NodeStats)
+		}
+		cluster.nodes.Add(node)
+		maxId = maxUint64(maxId, node.GetId())
+	}
+	for _, t := range snap.Tables {
+		cluster.workingTables.Add(NewTable(deepcopy.Iface(t).(*metapb.Table)))
+		maxId = maxUint64(maxId, t.GetId())
+	}
+	for _, r := range snap.Ranges {
+		if len(r.GetPeers()) == 0 {
+			log.Warn("simulate: range[%d] has no peer, ignored", r.GetId())
+			continue
+		}
+		rng := deepcopy.Iface(r).(*metapb.Range)
+		if rng.RangeEpoch == nil {
+			rng.RangeEpoch = &metapb.RangeEpoch{}
+		}
+		var leader *metapb.Peer
+		for _, peer := range rng.GetPeers() {
+			if cluster.FindNodeById(peer.GetNodeId()) == nil {
+				log.Error("simulate: node[%d] of range[%d] not found", peer.GetNodeId(), rng.GetId())
+				return nil, ErrNotExistNode
+			}
+			if peer.GetNodeId() == snap.Leaders[rng.GetId()] {
+				leader = deepcopy.Iface(peer).(*metapb.Peer)
+			}
+			maxId = maxUint64(maxId, peer.GetId())
+		}
+		rr := NewRange(rng, leader)
+		rr.State = metapb.RangeState_R_Normal
+		rr.ApproximateSize = snap.RangeSizes[rng.GetId()]
+		cluster.AddRange(rr)
+		ds.AddRange(rng, rr.GetLeader().GetNodeId())
+		maxId = maxUint64(maxId, rng.GetId())
+	}
+	for _, cfg := range snap.Schedulers {
+		if cfg == nil || cfg.validate() != nil {
+			return nil, ErrInvalidParam
+		}
+		cluster.schedulers[cfg.Name()] = cfg
+	}
+	cluster.idGener = &simIDGenerator{id: maxId}
+
+	s := &Simulator{
+		cluster:   cluster,
+		ds:        ds,
+		nextRun:   make(map[string]time.Duration),
+		seen:      make(map[uint64]bool),
+		baseUsed:  make(map[uint64]uint64),
+		baseAvail: make(map[uint64]uint64),
+		baseSize:  make(map[uint64]uint64),
+		result:    new(SimResult),
+	}
+	if len(workers) == 0 {
+		workers = append(workers, defaultSimulateWorkers...)
+		for _, cfg := range snap.Schedulers {
+			workers = append(workers, cfg.Name())
+		}
+	}
+	for _, name := range workers {
+		w := s.newWorker(name)
+		if w == nil {
+			log.Error("simulate: worker[%s] not supported", name)
+			s.Close()
+			return nil, ErrWorkerNotFound
+		}
+		s.workers = append(s.workers, w)
+		s.result.Workers = append(s.result.Workers, name)
+	}
+	for _, node := range cluster.GetAllNode() {
+		s.baseUsed[node.GetId()] = node.stats.GetUsedSize()
+		s.baseAvail[node.GetId()] = node.stats.GetAvailable()
+		s.baseSize[node.GetId()] = s.rangesSize(node)
+	}
+	s.updateNodeStats()
+	return s, nil
+}
+
+func (s *Simulator) newWorker(name string) Worker {
+	wm := s.cluster.workerManger
+	switch name {
+	case balanceLeaderWorkerName:
+		return NewBalanceNodeLeaderWorker(wm, 5*defaultWorkerInterval)
+	case balanceRangeWorkerName:
+		return NewBalanceNodeRangeWorker(wm, 2*defaultWorkerInterval)
+	case balanceNodeOpsWorkerName:
+		return NewBalanceNodeOpsWorker(wm, defaultWorkerInterval)
+	case drainNodeWorkerName:
+		return NewDrainNodeWorker(wm, 5*time.Second)
+	case evictLeaderWorkerName:
+		return NewEvictLeaderWorker(wm, 5*time.Second)
+	}
+	if cfg, find := s.cluster.schedulers[name]; find {
+		return cfg.newWorker(wm)
+	}
+	return nil
+}
+
+// Run runs the workers for the ticks and returns the tasks created and the
+// balance scores before and after.
+func (s *Simulator) Run(ticks int) *SimResult {
+	s.result.Before = s.cluster.balanceScore()
+	for i := 0; i < ticks; i++ {
+		s.step()
+	}
+	s.result.Ticks = s.tick
+	s.result.Running = len(s.cluster.taskManager.GetAll())
+	s.result.After = s.cluster.balanceScore()
+	return s.result
+}
+
+// Close stops the workers of the in-memory cluster.
+func (s *Simulator) Close() {
+	s.cluster.workerManger.Stop()
+}
+
+func (s *Simulator) step() {
+	s.tick++
+	now := time.Duration(s.tick) * simulateTickInterval
+	if s.tick%simulateDealingTicks == 0 {
+		s.cluster.hbManager.dealIngNodes.reset()
+	}
+	for _, w := range s.workers {
+		if now < s.nextRun[w.GetName()] {
+			continue
+		}
+		s.nextRun[w.GetName()] = now + w.GetInterval()
+		if !w.AllowWork(s.cluster) {
+			continue
+		}
+		w.Work(s.cluster)
+		s.recordTasks()
+	}
+	for _, sr := range s.ds.Ranges() {
+		rng := s.cluster.FindRange(sr.Range.GetId())
+		if rng == nil {
+			continue
+		}
+		s.ds.Progress(sr)
+		s.heartbeat(rng, sr)
+		task := s.cluster.Dispatch(rng)
+		s.recordTasks()
+		s.ds.Execute(sr, task)
+	}
+	s.updateNodeStats()
+}
+
+func (s *Simulator) recordTasks() {
+	var tasks []*TaskChain
+	for _, tc := range s.cluster.taskManager.GetAll() {
+		if !s.seen[tc.GetID()] {
+			tasks = append(tasks, tc)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].GetID() < tasks[j].GetID() })
+	for _, tc := range tasks {
+		s.seen[tc.GetID()] = true
+		s.result.Tasks = append(s.result.Tasks, &SimTask{
+			Tick:    s.tick,
+			Worker:  tc.GetWorker(),
+			RangeId: tc.GetRangeID(),
+			Name:    tc.GetName(),
+			Detail:  tc.String(),
+		})
+	}
+}
+
+// heartbeat updates the range cache like a range heartbeat of the leader.
+func (s *Simulator) heartbeat(rng *Range, sr *simulate.Range) {
+	r := deepcopy.Iface(sr.Range).(*metapb.Range)
+	var leader *metapb.Peer
+	for _, peer := range r.GetPeers() {
+		if peer.GetNodeId() == sr.Leader {
+			leader = deepcopy.Iface(peer).(*metapb.Peer)
+		}
+	}
+	for _, p := range rng.GetPeers() {
+		var find bool
+		for _, peer := range r.GetPeers() {
+			if p.GetId() == peer.GetId() {
+				find = true
+				break
+			}
+		}
+		if !find {
+			s.cluster.FindNodeById(p.GetNodeId()).DeleteRange(rng.GetId())
+		}
+	}
+	rng.Range = r
+	rng.Leader = leader
+	rng.LastHbTimeTS = time.Now()
+	s.cluster.AddRange(rng)
+}
+
+func (s *Simulator) rangesSize(node *Node) uint64 {
+	var size uint64
+	for _, r := range node.GetAllRanges() {
+		size += r.ApproximateSize
+	}
+	return size
+}
+
+// updateNodeStats updates the node stats like a node heartbeat, the used size
+// changes with the size of the ranges moved in and out.
+func (s *Simulator) updateNodeStats() {
+	for _, node := range s.cluster.GetAllNode() {
+		stats := deepcopy.Iface(node.stats).(*mspb.NodeStats)
+		stats.RangeCount = uint32(node.GetRangesSize())
+		stats.RangeLeaderCount = uint32(leaderRanges(node))
+		size, base := s.rangesSize(node), s.baseSize[node.GetId()]
+		used, avail := s.baseUsed[node.GetId()]+size, s.baseAvail[node.GetId()]+base
+		stats.UsedSize, stats.Available = 0, 0
+		if used > base {
+			stats.UsedSize = used - base
+		}
+		if avail > size {
+			stats.Available = avail - size
+		}
+		node.stats = stats
+		node.LastHeartbeatTS = time.Now()
+	}
+}
+
+// simNodes resolves the nodes of the simulated cluster for the data servers.
+type simNodes struct {
+	cluster *Cluster
+}
+
+func (n simNodes) NodeAddr(nodeId uint64) (string, bool) {
+	node := n.cluster.FindNodeById(nodeId)
+	if node == nil {
+		return "", false
+	}
+	return node.GetServerAddr(), true
+}
+
+func (n simNodes) NodeId(addr string) (uint64, bool) {
+	node, find := n.cluster.nodes.FindNodeByAddr(addr)
+	if !find {
+		return 0, false
+	}
+	return node.GetId(), true
+}
+
+func (service *Server) handleSimulateSnapshot(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+	reply.Data = service.cluster.SimulationSnapshot()
+}
+
+// handleSimulateRun 模拟调度, body为快照, 没有时使用当前集群的快照
+func (service *Server) handleSimulateRun(w http.ResponseWriter, r *http.Request) {
+	reply := &httpReply{}
+	defer sendReply(w, reply)
+
+	ticks := defaultSimulateTicks
+	if str := r.FormValue(HTTP_TICKS); str != "" {
+		n, err := strconv.Atoi(str)
+		if err != nil || n <= 0 || n > maxSimulateTicks {
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = http_error_invalid_parameter
+			return
+		}
+		ticks = n
+	}
+	var workers []string
+	if str := r.FormValue(HTTP_WORKERS); str != "" {
+		if err := json.Unmarshal([]byte(str), &workers); err != nil {
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = err.Error()
+			return
+		}
+	}
+	var snap *SimSnapshot
+	if r.Method == http.MethodPost && r.ContentLength != 0 {
+		snap = new(SimSnapshot)
+		if err := json.NewDecoder(r.Body).Decode(snap); err != nil {
+			log.Error("http simulate: decode snapshot failed, err[%v]", err)
+			reply.Code = HTTP_ERROR_INVALID_PARAM
+			reply.Message = http_error_invalid_parameter
+			return
+		}
+	} else {
+		snap = service.cluster.SimulationSnapshot()
+	}
+	sim, err := NewSimulator(snap, service.cluster.opt, workers)
+	if err != nil {
+		log.Error("http simulate failed, err[%v]", err)
+		reply.Code = HTTP_ERROR
+		reply.Message = err.Error()
+		return
+	}
+	defer sim.Close()
+	reply.Data = sim.Run(ticks)
+}
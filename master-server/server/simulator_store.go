@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// The in-memory meta store and id generator of the simulated cluster, the
+// simulation never touches the store of the real cluster.
+
+type simIDGenerator struct {
+	id uint64
+}
+
+func (g *simIDGenerator) GenID() (uint64, error) {
+	return atomic.AddUint64(&g.id, 1), nil
+}
+
+func (g *simIDGenerator) GetBatchIds(size uint32) ([]uint64, error) {
+	ids := make([]uint64, 0, size)
+	for i := uint32(0); i < size; i++ {
+		ids = append(ids, atomic.AddUint64(&g.id, 1))
+	}
+	return ids, nil
+}
+
+// simStore is an in-memory store of the simulated cluster.
+type simStore struct {
+	lock sync.RWMutex
+	kvs  map[string][]byte
+}
+
+func newSimStore() *simStore {
+	return &simStore{kvs: make(map[string][]byte)}
+}
+
+func (s *simStore) Open() error {
+	return nil
+}
+
+func (s *simStore) Put(key, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.kvs[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *simStore) Delete(key []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.kvs, string(key))
+	return nil
+}
+
+func (s *simStore) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.kvs[string(key)], nil
+}
+
+func (s *simStore) Scan(startKey, limitKey []byte) Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	it := &simIterator{index: -1}
+	for k := range s.kvs {
+		key := []byte(k)
+		if bytes.Compare(key, startKey) >= 0 && (len(limitKey) == 0 || bytes.Compare(key, limitKey) < 0) {
+			it.keys = append(it.keys, k)
+		}
+	}
+	sort.Strings(it.keys)
+	for _, k := range it.keys {
+		it.values = append(it.values, s.kvs[k])
+	}
+	return it
+}
+
+func (s *simStore) NewBatch() Batch {
+	return &simBatch{store: s}
+}
+
+func (s *simStore) Close() error {
+	return nil
+}
+
+type simIterator struct {
+	keys   []string
+	values [][]byte
+	index  int
+}
+
+func (i *simIterator) Next() bool {
+	i.index++
+	return i.index < len(i.keys)
+}
+
+func (i *simIterator) Key() []byte {
+	return []byte(i.keys[i.index])
+}
+
+func (i *simIterator) Value() []byte {
+	return i.values[i.index]
+}
+
+func (i *simIterator) Error() error {
+	return nil
+}
+
+func (i *simIterator) Release() {
+}
+
+type simBatch struct {
+	store *simStore
+	puts  map[string][]byte
+	dels  []string
+}
+
+func (b *simBatch) Put(key []byte, value []byte) {
+	if b.puts == nil {
+		b.puts = make(map[string][]byte)
+	}
+	b.puts[string(key)] = append([]byte(nil), value...)
+}
+
+func (b *simBatch) Delete(key []byte) {
+	delete(b.puts, string(key))
+	b.dels = append(b.dels, string(key))
+}
+
+func (b *simBatch) Commit() error {
+	b.store.lock.Lock()
+	defer b.store.lock.Unlock()
+	for _, k := range b.dels {
+		delete(b.store.kvs, k)
+	}
+	for k, v := range b.puts {
+		b.store.kvs[k] = v
+	}
+	return nil
+}
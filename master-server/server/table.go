@@ -7,9 +7,11 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"model/pkg/metapb"
+	"util"
 	"util/deepcopy"
 	"util/log"
 
@@ -35,9 +37,13 @@ type Table struct {
 	// 路由锁
 	lock     sync.Mutex
 	maxColId uint64
+	// 已删除但数据未清理的列, 受schemaLock保护
+	droppedColumns []*DroppedColumn
 
 	// 删除时间,删除的table会保留三天，之后正式删除
 	deleteTime time.Time
+	// 解析过的放置规则, *cachedPlacement
+	placement atomic.Value
 }
 
 type rangeToCreate struct {
@@ -84,6 +90,12 @@ func (t *Table) getGenerator(store Store) {
 type TableProperty struct {
 	Columns []*metapb.Column `json:"columns"`
 	Regxs   []*metapb.Column `json:"regxs"`
+	// encoded primary keys without table prefix to pre-split the table
+	SplitKeys [][]byte `json:"split_keys,omitempty"`
+	// the rows expire in ttl seconds after written, zero means never expire
+	Ttl uint64 `json:"ttl,omitempty"`
+	// the replicas placement of the table, nil means the defaults of the cluster
+	Placement *Placement `json:"placement,omitempty"`
 }
 
 func (t *Table) Name() string {
@@ -204,6 +216,40 @@ func (t *Table) MergeColumn(source []*metapb.Column, cluster *Cluster) error {
 	return nil
 }
 
+// TTL returns the ttl(seconds) of the rows, zero means never expire.
+func (t *Table) TTL() uint64 {
+	ttl, err := util.ParseTableTTL(t.GetProperties())
+	if err != nil {
+		log.Warn("table[%s:%s] invalid properties, err[%v]", t.GetDbName(), t.GetName(), err)
+		return 0
+	}
+	return ttl
+}
+
+// UpdateTTL changes the ttl of the table, the rows written before keep their
+// expire time.
+func (t *Table) UpdateTTL(ttl uint64, cluster *Cluster) error {
+	t.schemaLock.Lock()
+	defer t.schemaLock.Unlock()
+	table := deepcopy.Iface(t.Table).(*metapb.Table)
+	props, err := ToTableProperty(table.Columns, ttl, t.Placement())
+	if err != nil {
+		return err
+	}
+	table.Properties = props
+	if table.Epoch == nil {
+		table.Epoch = &metapb.TableEpoch{}
+	}
+	table.Epoch.ConfVer++
+	if err = cluster.storeTable(table); err != nil {
+		log.Error("store table failed, err[%v]", err)
+		return err
+	}
+	t.Table = table
+	log.Info("table[%s:%s] ttl changed to %d", table.GetDbName(), table.GetName(), ttl)
+	return nil
+}
+
 func checkTTLDataType(dataType metapb.DataType) bool {
 	return metapb.DataType_BigInt == dataType
 }
@@ -249,7 +295,7 @@ func (t *Table) UpdateSchema(columns []*metapb.Column, cluster *Cluster) ([]*met
 	if match == false {
 		return nil, errors.New("none of columns matches")
 	}
-	props, err := ToTableProperty(allCols)
+	props, err := ToTableProperty(allCols, t.TTL(), t.Placement())
 	if err != nil {
 		return nil, err
 	}
@@ -479,9 +525,11 @@ func (tc *CreateTableCache) Size() int {
 	return len(tc.tableIs)
 }
 
-func ToTableProperty(cols []*metapb.Column) (string, error) {
+func ToTableProperty(cols []*metapb.Column, ttl uint64, placement *Placement) (string, error) {
 	tp := &TableProperty{
-		Columns: make([]*metapb.Column, 0),
+		Columns:   make([]*metapb.Column, 0),
+		Ttl:       ttl,
+		Placement: placement,
 	}
 	for _, c := range cols {
 		tp.Columns = append(tp.Columns, c)
@@ -494,18 +542,29 @@ func ToTableProperty(cols []*metapb.Column) (string, error) {
 	}
 }
 
-func EditProperties(properties string) ([]*metapb.Column, error) {
-	tp := new(TableProperty)
+// EditProperties returns the columns, the ttl and the placement to edit, nil
+// if absent. An empty placement restores the defaults of the cluster.
+func EditProperties(properties string) ([]*metapb.Column, *uint64, *Placement, error) {
+	tp := new(struct {
+		Columns   []*metapb.Column `json:"columns"`
+		Ttl       *uint64          `json:"ttl"`
+		Placement *Placement       `json:"placement"`
+	})
 	log.Debug("edit properties string: %v", properties)
 	if err := json.Unmarshal([]byte(properties), tp); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	log.Debug("edit properties struct: %v", *tp)
-	if tp.Columns == nil {
-		return nil, ErrInvalidColumn
+	if tp.Columns == nil && tp.Ttl == nil && tp.Placement == nil {
+		return nil, nil, nil, ErrInvalidColumn
+	}
+	if tp.Placement != nil {
+		if err := tp.Placement.validate(); err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
-	return tp.Columns, nil
+	return tp.Columns, tp.Ttl, tp.Placement, nil
 }
 
 func parseColumn(cols []*metapb.Column) error {
@@ -578,6 +637,24 @@ func ParseProperties(properties string) ([]*metapb.Column, []*metapb.Column, err
 	return tp.Columns, tp.Regxs, nil
 }
 
+func ParseTTL(properties string) (uint64, error) {
+	ttl, err := util.ParseTableTTL(properties)
+	if err != nil {
+		log.Error("deserialize table property failed, err:[%v]", err)
+		return 0, err
+	}
+	return ttl, nil
+}
+
+func ParseSplitKeys(properties string) ([][]byte, error) {
+	tp := new(TableProperty)
+	if err := json.Unmarshal([]byte(properties), tp); err != nil {
+		log.Error("deserialize table property failed, err:[%v]", err)
+		return nil, err
+	}
+	return tp.SplitKeys, nil
+}
+
 func GetTypeByName(name string) metapb.DataType {
 	for k, v := range metapb.DataType_name {
 		if strings.Compare(strings.ToLower(v), strings.ToLower(name)) == 0 {
@@ -801,9 +878,11 @@ func (dt *CreateTableWorker) waitRangePeerReady(c *Cluster, table *CreateTable)
 	log.Debug("create table %v ranges: %v", table.GetName(), table.GetAllRanges())
 	for _, r := range table.GetAllRanges() {
 		log.Debug("check table: %v, range: %v peer number", table.GetName(), r.GetId())
-		if len(r.GetPeers()) < c.opt.GetMaxReplicas() {
+		// learner副本不影响建表完成
+		replicas := c.rangePlacement(r).Replicas
+		if voters, _ := countPeers(r.GetPeers()); voters < replicas {
 			log.Info("check table: %v, range: %v peers number %v != %v",
-				table.GetName(), r.GetId(), len(r.GetPeers()), c.opt.GetMaxReplicas())
+				table.GetName(), r.GetId(), voters, replicas)
 			return
 		}
 	}
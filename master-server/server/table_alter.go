@@ -0,0 +1,498 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"master-server/backup"
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"model/pkg/mspb"
+	"proxy/store/dskv"
+	"util"
+	"util/deepcopy"
+	"util/encoding"
+	"util/log"
+
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+)
+
+var PREFIX_DROPPED_COLUMN string = fmt.Sprintf("schema%sdropped_column%s", SCHEMA_SPLITOR, SCHEMA_SPLITOR)
+
+// the gateways refresh the table cache in five minutes, a dropped column may
+// still be written by a stale gateway before that
+var droppedColumnCleanDelay = 10 * time.Minute
+
+// DroppedColumn is a column dropped from the table whose data is not cleaned
+// yet, the column id must not be reused until then.
+type DroppedColumn struct {
+	TableId  uint64         `json:"table_id"`
+	Column   *metapb.Column `json:"column"`
+	DropTime int64          `json:"drop_time"`
+}
+
+func droppedColumnKey(tableId, columnId uint64) []byte {
+	return []byte(fmt.Sprintf("%s%d%s%d", PREFIX_DROPPED_COLUMN, tableId, SCHEMA_SPLITOR, columnId))
+}
+
+// AlterColumn changes the column online, the conf version of the table epoch
+// is increased so that the gateways know the schema is changed.
+// The stored rows are not rewritten: the column id is kept on rename, and
+// modify only allows the widening whose values decode the same as before.
+func (t *Table) AlterColumn(req *mspb.AlterColumnRequest, cluster *Cluster) error {
+	t.schemaLock.Lock()
+	defer t.schemaLock.Unlock()
+	if req.GetConfVer() != 0 && req.GetConfVer() != t.GetEpoch().GetConfVer() {
+		return ErrTableEpochChanged
+	}
+	table := deepcopy.Iface(t.Table).(*metapb.Table)
+	if table.Epoch == nil {
+		table.Epoch = &metapb.TableEpoch{}
+	}
+
+	name := strings.ToLower(req.GetName())
+	index := -1
+	for i, c := range table.Columns {
+		if c.GetName() == name {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		log.Warn("column[%s:%s:%s] is not exist", table.GetDbName(), table.GetName(), name)
+		return ErrNotExistColumn
+	}
+	col := table.Columns[index]
+	newCol := req.GetColumn()
+
+	var dropped *DroppedColumn
+	switch req.GetType() {
+	case mspb.AlterColumnType_DropColumn:
+		if col.GetPrimaryKey() > 0 {
+			return ErrPkNotAllowAlter
+		}
+		table.Columns = append(table.Columns[:index], table.Columns[index+1:]...)
+		dropped = &DroppedColumn{TableId: table.GetId(), Column: col, DropTime: time.Now().Unix()}
+	case mspb.AlterColumnType_RenameColumn:
+		if newCol == nil {
+			return ErrInvalidParam
+		}
+		newName := strings.ToLower(newCol.GetName())
+		if len(newName) == 0 {
+			return ErrInvalidColumn
+		}
+		if len(newName) > MAX_COLUMN_NAME_LENGTH {
+			return ErrColumnNameTooLong
+		}
+		if isSqlReservedWord(newName) {
+			log.Warn("col[%s] is sql reserved word", newName)
+			return ErrSqlReservedWord
+		}
+		if newName == col.GetName() {
+			return nil
+		}
+		for _, c := range table.Columns {
+			if c.GetName() == newName {
+				return ErrDupColumnName
+			}
+		}
+		col.Name = newName
+	case mspb.AlterColumnType_SetDefault:
+		if newCol == nil {
+			return ErrInvalidParam
+		}
+		if col.GetPrimaryKey() > 0 {
+			return ErrPkMustNotSetDefaultValue
+		}
+		col.DefaultValue = append([]byte(nil), newCol.GetDefaultValue()...)
+	case mspb.AlterColumnType_DropDefault:
+		col.DefaultValue = nil
+	case mspb.AlterColumnType_ModifyColumn:
+		if newCol == nil {
+			return ErrInvalidParam
+		}
+		if col.GetPrimaryKey() > 0 {
+			return ErrPkNotAllowAlter
+		}
+		if !isColumnWidening(col, newCol) {
+			log.Warn("column[%s:%s:%s] change from %v to %v is incompatible",
+				table.GetDbName(), table.GetName(), name, col, newCol)
+			return ErrIncompatibleColumn
+		}
+		col.DataType = newCol.GetDataType()
+		col.Scale = newCol.GetScale()
+		col.Precision = newCol.GetPrecision()
+		col.Nullable = newCol.GetNullable()
+	default:
+		return ErrInvalidParam
+	}
+
+	props, err := ToTableProperty(table.Columns, t.TTL(), t.Placement())
+	if err != nil {
+		return err
+	}
+	table.Properties = props
+	table.Epoch.ConfVer++
+
+	data, err := proto.Marshal(table)
+	if err != nil {
+		return err
+	}
+	batch := cluster.store.NewBatch()
+	batch.Put([]byte(fmt.Sprintf("%s%d", PREFIX_TABLE, table.GetId())), data)
+	if dropped != nil {
+		value, err := json.Marshal(dropped)
+		if err != nil {
+			return err
+		}
+		batch.Put(droppedColumnKey(table.GetId(), col.GetId()), value)
+	}
+	if err = batch.Commit(); err != nil {
+		log.Error("store table failed, err[%v]", err)
+		return err
+	}
+	if dropped != nil {
+		t.droppedColumns = append(t.droppedColumns, dropped)
+	}
+	t.Table = table
+	log.Info("table[%s:%s] alter column[%s] %v, conf version[%d]",
+		table.GetDbName(), table.GetName(), name, req.GetType(), table.GetEpoch().GetConfVer())
+	return nil
+}
+
+var intColumnRank = map[metapb.DataType]int{
+	metapb.DataType_Tinyint:  1,
+	metapb.DataType_Smallint: 2,
+	metapb.DataType_Int:      3,
+	metapb.DataType_BigInt:   4,
+}
+
+// isColumnWidening reports whether the values stored by the old column are
+// still valid for the new one.
+func isColumnWidening(old, new *metapb.Column) bool {
+	if old.GetNullable() && !new.GetNullable() {
+		return false
+	}
+	if old.GetUnsigned() != new.GetUnsigned() {
+		return false
+	}
+	switch old.GetDataType() {
+	case metapb.DataType_Tinyint, metapb.DataType_Smallint, metapb.DataType_Int, metapb.DataType_BigInt:
+		rank, ok := intColumnRank[new.GetDataType()]
+		return ok && rank >= intColumnRank[old.GetDataType()]
+	case metapb.DataType_Float:
+		return new.GetDataType() == metapb.DataType_Float || new.GetDataType() == metapb.DataType_Double
+	case metapb.DataType_Varchar:
+		if new.GetDataType() != metapb.DataType_Varchar {
+			return false
+		}
+		// zero scale means no limit
+		return new.GetScale() == 0 || (old.GetScale() != 0 && new.GetScale() >= old.GetScale())
+	default:
+		return new.GetDataType() == old.GetDataType()
+	}
+}
+
+// DroppedColumns returns the dropped columns whose data is not cleaned yet.
+func (t *Table) DroppedColumns() []*DroppedColumn {
+	t.schemaLock.RLock()
+	defer t.schemaLock.RUnlock()
+	columns := make([]*DroppedColumn, len(t.droppedColumns))
+	copy(columns, t.droppedColumns)
+	return columns
+}
+
+func (t *Table) addDroppedColumn(c *DroppedColumn) {
+	t.schemaLock.Lock()
+	defer t.schemaLock.Unlock()
+	t.droppedColumns = append(t.droppedColumns, c)
+	t.lock.Lock()
+	if c.Column.GetId() > t.maxColId {
+		t.maxColId = c.Column.GetId()
+	}
+	t.lock.Unlock()
+}
+
+// cleanDroppedColumns forgets the dropped columns after their data is cleaned.
+func (t *Table) cleanDroppedColumns(columns []*DroppedColumn, cluster *Cluster) error {
+	t.schemaLock.Lock()
+	defer t.schemaLock.Unlock()
+	batch := cluster.store.NewBatch()
+	cleaned := make(map[uint64]bool)
+	for _, c := range columns {
+		batch.Delete(droppedColumnKey(t.GetId(), c.Column.GetId()))
+		cleaned[c.Column.GetId()] = true
+	}
+	if err := batch.Commit(); err != nil {
+		return err
+	}
+	var remain []*DroppedColumn
+	for _, c := range t.droppedColumns {
+		if !cleaned[c.Column.GetId()] {
+			remain = append(remain, c)
+		}
+	}
+	t.droppedColumns = remain
+	return nil
+}
+
+func (c *Cluster) loadDroppedColumns() error {
+	prefix := []byte(PREFIX_DROPPED_COLUMN)
+	startKey, limitKey := bytesPrefix(prefix)
+	it := c.store.Scan(startKey, limitKey)
+	var stale [][]byte
+	for it.Next() {
+		dropped := new(DroppedColumn)
+		if err := json.Unmarshal(it.Value(), dropped); err != nil {
+			it.Release()
+			return err
+		}
+		table, find := c.FindTableById(dropped.TableId)
+		if !find {
+			table, find = c.FindDeleteTableById(dropped.TableId)
+		}
+		if !find {
+			// the table is deleted together with its data
+			stale = append(stale, append([]byte(nil), it.Key()...))
+			continue
+		}
+		table.addDroppedColumn(dropped)
+	}
+	it.Release()
+	for _, key := range stale {
+		if err := c.store.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type DroppedColumnCleanWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+}
+
+func NewDroppedColumnCleanWorker(wm *WorkerManager, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &DroppedColumnCleanWorker{
+		name:     droppedColumnCleanWorkerName,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+	}
+}
+
+func (w *DroppedColumnCleanWorker) GetName() string {
+	return w.name
+}
+
+func (w *DroppedColumnCleanWorker) Work(cluster *Cluster) {
+	for _, table := range cluster.workingTables.GetAllTable() {
+		select {
+		case <-w.ctx.Done():
+			return
+		default:
+		}
+		var columns []*metapb.Column
+		var cleaning []*DroppedColumn
+		for _, c := range table.DroppedColumns() {
+			if time.Since(time.Unix(c.DropTime, 0)) > droppedColumnCleanDelay {
+				columns = append(columns, c.Column)
+				cleaning = append(cleaning, c)
+			}
+		}
+		if len(cleaning) == 0 {
+			continue
+		}
+		if cluster.backupEnv == nil {
+			log.Warn("no data server env to clean dropped columns")
+			return
+		}
+		env, err := cluster.backupEnv()
+		if err != nil {
+			log.Error("get data server env failed, err[%v]", err)
+			return
+		}
+
+		var ranges []*metapb.Range
+		for _, r := range cluster.GetTableAllRanges(table.GetId()) {
+			ranges = append(ranges, r.Range)
+		}
+		cleaned, skipped, err := cleanColumnValues(w.ctx, env, table, ranges, columns)
+		if err != nil {
+			log.Error("clean dropped columns of table[%s:%s] failed, err[%v]", table.GetDbName(), table.GetName(), err)
+			continue
+		}
+		if cleaned > 0 || skipped > 0 {
+			// the columns are forgotten after a pass finding no value of them
+			log.Info("clean dropped columns of table[%s:%s], rows[%d], skipped[%d]",
+				table.GetDbName(), table.GetName(), cleaned, skipped)
+			continue
+		}
+		if err = table.cleanDroppedColumns(cleaning, cluster); err != nil {
+			log.Error("store table[%s:%s] dropped columns failed, err[%v]", table.GetDbName(), table.GetName(), err)
+			continue
+		}
+		log.Info("dropped columns of table[%s:%s] cleaned", table.GetDbName(), table.GetName())
+	}
+}
+
+func (w *DroppedColumnCleanWorker) AllowWork(cluster *Cluster) bool {
+	// the ranges may be incomplete in the recovery
+	return cluster.GetMetaRecovery() == nil
+}
+
+func (w *DroppedColumnCleanWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *DroppedColumnCleanWorker) Stop() {
+	w.cancel()
+}
+
+// codeNotFound is the status code of the data server for a missing key.
+const codeNotFound = 1
+
+// cleanColumnValues removes the values of the dropped columns from the rows
+// of the table and returns the number of rows rewritten and skipped.
+// The rows with dropped values are found by a select with the live columns
+// followed by the dropped ones. The stored value of such a row is read, the
+// dropped values are cut out of it and the rest, including the hidden expire
+// time, is written back only if the stored value is not changed meanwhile.
+// The skipped rows are picked up by the next clean of the table.
+func cleanColumnValues(ctx context.Context, env *backup.Env, table *Table, ranges []*metapb.Range,
+	dropped []*metapb.Column) (uint64, uint64, error) {
+	if len(ranges) == 0 {
+		return 0, 0, errors.New("table has no range")
+	}
+	if len(dropped) == 0 {
+		return 0, 0, nil
+	}
+	columns := table.GetColumns()
+	proxy := env.NewKvProxy(table.GetDbId(), table.GetId())
+	req := &kvrpcpb.SelectRequest{FieldList: make([]*kvrpcpb.SelectField, 0, len(columns)+len(dropped))}
+	for _, col := range columns {
+		req.FieldList = append(req.FieldList, &kvrpcpb.SelectField{Typ: kvrpcpb.SelectField_Column, Column: col})
+	}
+	for _, col := range dropped {
+		req.FieldList = append(req.FieldList, &kvrpcpb.SelectField{Typ: kvrpcpb.SelectField_Column, Column: col})
+	}
+	var cleaned, skipped uint64
+	err := dskv.Parallel(ctx, env.GetConcurrency(), len(ranges), func(i int) error {
+		r := ranges[i]
+		err := proxy.ScanScope(ctx, req, r.GetStartKey(), r.GetEndKey(), env.GetBatchSize(), func(rows []*kvrpcpb.Row) error {
+			for _, row := range rows {
+				has, err := hasDroppedValue(columns, dropped, row)
+				if err != nil {
+					return fmt.Errorf("decode row failed, err[%v]", err)
+				}
+				if !has {
+					continue
+				}
+				ok, err := cleanRow(proxy, dropped, row.GetKey(), time.Now().Unix())
+				if err != nil {
+					return fmt.Errorf("clean row failed, err[%v]", err)
+				}
+				if ok {
+					atomic.AddUint64(&cleaned, 1)
+				} else {
+					atomic.AddUint64(&skipped, 1)
+				}
+			}
+			return nil
+		})
+		if err != nil && err != ctx.Err() {
+			return fmt.Errorf("clean range[%d] failed, err[%v]", r.GetId(), err)
+		}
+		return err
+	})
+	return atomic.LoadUint64(&cleaned), atomic.LoadUint64(&skipped), err
+}
+
+// cleanRow rewrites the stored value of the row without the dropped values,
+// false is returned if the row is changed or deleted since it is read.
+func cleanRow(proxy *dskv.KvProxy, dropped []*metapb.Column, key []byte, now int64) (bool, error) {
+	resp, err := proxy.RawGet(&kvrpcpb.KvRawGetRequest{Key: key})
+	if err != nil {
+		return false, err
+	}
+	if resp.GetCode() == codeNotFound {
+		return false, nil
+	}
+	if resp.GetCode() != 0 {
+		return false, fmt.Errorf("get row failed, code[%d]", resp.GetCode())
+	}
+	value, expireAt, err := stripColumnValues(resp.GetValue(), dropped)
+	if err != nil {
+		return false, err
+	}
+	kv := &kvrpcpb.KeyValue{Key: key, Value: value}
+	if expireAt > 0 {
+		// keep the row expiring at the same time
+		kv.TTL = 1
+		if expireAt > now {
+			kv.TTL = uint64(expireAt - now)
+		}
+	}
+	return proxy.CompareAndInsert(kv, resp.GetValue())
+}
+
+// stripColumnValues removes the values of the columns from the stored row
+// value, and returns the expire time of the row, 0 if it has none.
+func stripColumnValues(value []byte, columns []*metapb.Column) ([]byte, int64, error) {
+	ids := make(map[uint32]bool, len(columns))
+	for _, col := range columns {
+		ids[uint32(col.GetId())] = true
+	}
+	stripped := make([]byte, 0, len(value))
+	var expireAt int64
+	for len(value) > 0 {
+		_, _, colId, _, err := encoding.DecodeValueTag(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		_, length, err := encoding.PeekValueLength(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		if colId == uint32(util.TTL_EXPIRE_COL_ID) {
+			if _, expireAt, err = encoding.DecodeIntValue(value); err != nil {
+				return nil, 0, err
+			}
+		}
+		if !ids[colId] {
+			stripped = append(stripped, value[:length]...)
+		}
+		value = value[length:]
+	}
+	return stripped, expireAt, nil
+}
+
+// hasDroppedValue reports whether the row selected with the columns followed
+// by the dropped columns has any value of the dropped columns.
+func hasDroppedValue(columns, dropped []*metapb.Column, row *kvrpcpb.Row) (bool, error) {
+	data := row.GetFields()
+	var err error
+	for _, col := range columns {
+		if data, _, err = util.DecodeColumnValue(data, col); err != nil {
+			return false, err
+		}
+	}
+	for _, col := range dropped {
+		var v interface{}
+		if data, v, err = util.DecodeColumnValue(data, col); err != nil {
+			return false, err
+		}
+		if v != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -1,9 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"testing"
+	"model/pkg/kvrpcpb"
 	"model/pkg/metapb"
+	"model/pkg/mspb"
 	"time"
+	"util"
+	"util/encoding"
 )
 
 func TestCreatingTable(t *testing.T) {
@@ -212,3 +217,230 @@ func TestDeletingTable(t *testing.T) {
 		return
 	}
 }
+
+func TestTableAlterColumn(t *testing.T) {
+	cluster := newBoltDbCluster(t, newMockIDAllocator())
+	defer closeLocalCluster(cluster)
+	if _, err := cluster.CreateDatabase(DB_NAME, ""); err != nil {
+		t.Fatalf("create db error: %v", err)
+	}
+	mt := &metapb.Table{
+		Name:   TABLE_NAME,
+		DbName: DB_NAME,
+		DbId:   1,
+		Id:     10,
+		Columns: []*metapb.Column{
+			{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+			{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+			{Name: "age", Id: 3, DataType: metapb.DataType_Int},
+		},
+		Epoch:  &metapb.TableEpoch{ConfVer: 1, Version: 1},
+		Status: metapb.TableStatus_TableRunning,
+	}
+	if err := cluster.storeTable(mt); err != nil {
+		t.Fatalf("store table error: %v", err)
+	}
+	table := NewTable(mt)
+
+	alter := func(typ mspb.AlterColumnType, name string, col *metapb.Column, confVer uint64) error {
+		return table.AlterColumn(&mspb.AlterColumnRequest{Type: typ, Name: name, Column: col, ConfVer: confVer}, cluster)
+	}
+	if err := alter(mspb.AlterColumnType_RenameColumn, "name", &metapb.Column{Name: "nick"}, 1); err != nil {
+		t.Fatalf("rename column error: %v", err)
+	}
+	if c, find := table.GetColumnByName("nick"); !find || c.GetId() != 2 {
+		t.Fatalf("unexpected renamed column %v", c)
+	}
+	if err := alter(mspb.AlterColumnType_DropColumn, "age", nil, 1); err != ErrTableEpochChanged {
+		t.Fatalf("expect %v, got %v", ErrTableEpochChanged, err)
+	}
+	if err := alter(mspb.AlterColumnType_ModifyColumn, "age", &metapb.Column{DataType: metapb.DataType_BigInt}, 0); err != nil {
+		t.Fatalf("modify column error: %v", err)
+	}
+	if err := alter(mspb.AlterColumnType_ModifyColumn, "age", &metapb.Column{DataType: metapb.DataType_Int}, 0); err != ErrIncompatibleColumn {
+		t.Fatalf("expect %v, got %v", ErrIncompatibleColumn, err)
+	}
+	if err := alter(mspb.AlterColumnType_DropColumn, "id", nil, 0); err != ErrPkNotAllowAlter {
+		t.Fatalf("expect %v, got %v", ErrPkNotAllowAlter, err)
+	}
+	if err := alter(mspb.AlterColumnType_DropColumn, "age", nil, 0); err != nil {
+		t.Fatalf("drop column error: %v", err)
+	}
+	if _, find := table.GetColumnByName("age"); find {
+		t.Fatal("column is not dropped")
+	}
+	if ver := table.GetEpoch().GetConfVer(); ver != 4 {
+		t.Fatalf("expect conf version 4, got %d", ver)
+	}
+
+	// the id of the dropped column is not reused after reload
+	if err := cluster.LoadCache(); err != nil {
+		t.Fatalf("load cache error: %v", err)
+	}
+	table, find := cluster.FindTableById(mt.GetId())
+	if !find {
+		t.Fatal("table not found")
+	}
+	if dropped := table.DroppedColumns(); len(dropped) != 1 || dropped[0].Column.GetName() != "age" {
+		t.Fatalf("unexpected dropped columns %v", dropped)
+	}
+	if id := table.GenColId(); id != 4 {
+		t.Fatalf("expect column id 4, got %d", id)
+	}
+}
+
+func TestHasDroppedValue(t *testing.T) {
+	columns := []*metapb.Column{
+		{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+		{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+	}
+	dropped := []*metapb.Column{
+		{Name: "age", Id: 3, DataType: metapb.DataType_Int},
+		{Name: "score", Id: 4, DataType: metapb.DataType_Double},
+	}
+	var fields []byte
+	fields = encoding.EncodeIntValue(fields, 0, 7)
+	fields = encoding.EncodeBytesValue(fields, 0, []byte("shark"))
+	fields = encoding.EncodeNullValue(fields, 0)
+	fields = encoding.EncodeNullValue(fields, 0)
+	if has, err := hasDroppedValue(columns, dropped, &kvrpcpb.Row{Fields: fields}); err != nil || has {
+		t.Fatalf("expect no dropped value, got %v %v", has, err)
+	}
+
+	fields = fields[:0]
+	fields = encoding.EncodeIntValue(fields, 0, 7)
+	fields = encoding.EncodeNullValue(fields, 0)
+	fields = encoding.EncodeNullValue(fields, 0)
+	fields = encoding.EncodeFloatValue(fields, 0, 1.5)
+	if has, err := hasDroppedValue(columns, dropped, &kvrpcpb.Row{Fields: fields}); err != nil || !has {
+		t.Fatalf("expect dropped value, got %v %v", has, err)
+	}
+}
+
+func TestStripColumnValues(t *testing.T) {
+	dropped := []*metapb.Column{{Name: "age", Id: 3, DataType: metapb.DataType_Int}}
+	var value, expect []byte
+	value = encoding.EncodeBytesValue(value, 2, []byte("shark"))
+	value = encoding.EncodeIntValue(value, 3, 18)
+	value = util.EncodeExpireValue(value, 1000)
+	expect = encoding.EncodeBytesValue(expect, 2, []byte("shark"))
+	expect = util.EncodeExpireValue(expect, 1000)
+
+	stripped, expireAt, err := stripColumnValues(value, dropped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stripped, expect) || expireAt != 1000 {
+		t.Fatalf("unexpected value %v expire %d, expect %v", stripped, expireAt, expect)
+	}
+}
+
+func TestTableTTL(t *testing.T) {
+	cluster := newBoltDbCluster(t, newMockIDAllocator())
+	defer closeLocalCluster(cluster)
+	mt := &metapb.Table{
+		Name:   TABLE_NAME,
+		DbName: DB_NAME,
+		DbId:   1,
+		Id:     10,
+		Columns: []*metapb.Column{
+			{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+			{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+		},
+		Epoch:  &metapb.TableEpoch{ConfVer: 1, Version: 1},
+		Status: metapb.TableStatus_TableRunning,
+	}
+	table := NewTable(mt)
+	if table.TTL() != 0 {
+		t.Fatalf("expect no ttl, got %d", table.TTL())
+	}
+	if err := cluster.EditTable(table, `{"ttl":3600}`); err != nil {
+		t.Fatalf("edit table ttl error: %v", err)
+	}
+	if table.TTL() != 3600 || table.GetEpoch().GetConfVer() != 2 {
+		t.Fatalf("unexpected ttl %d, conf version %d", table.TTL(), table.GetEpoch().GetConfVer())
+	}
+	// 修改列不影响ttl
+	if err := table.AlterColumn(&mspb.AlterColumnRequest{Type: mspb.AlterColumnType_RenameColumn,
+		Name: "name", Column: &metapb.Column{Name: "nick"}}, cluster); err != nil {
+		t.Fatalf("rename column error: %v", err)
+	}
+	if table.TTL() != 3600 {
+		t.Fatalf("expect ttl 3600 after alter column, got %d", table.TTL())
+	}
+	if err := cluster.EditTable(table, `{"ttl":0}`); err != nil {
+		t.Fatalf("edit table ttl error: %v", err)
+	}
+	if table.TTL() != 0 {
+		t.Fatalf("expect ttl cleared, got %d", table.TTL())
+	}
+	if err := cluster.EditTable(table, `{}`); err != ErrInvalidColumn {
+		t.Fatalf("expect %v, got %v", ErrInvalidColumn, err)
+	}
+}
+
+func TestTablePlacement(t *testing.T) {
+	cluster := newBoltDbCluster(t, newMockIDAllocator())
+	defer closeLocalCluster(cluster)
+	mt := &metapb.Table{
+		Name:   TABLE_NAME,
+		DbName: DB_NAME,
+		DbId:   1,
+		Id:     10,
+		Columns: []*metapb.Column{
+			{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+			{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+		},
+		Epoch:  &metapb.TableEpoch{ConfVer: 1, Version: 1},
+		Status: metapb.TableStatus_TableRunning,
+	}
+	table := NewTable(mt)
+	if table.Placement() != nil {
+		t.Fatalf("expect no placement, got %v", table.Placement())
+	}
+	// key range [0x01, 0x02)放在ssd节点上, 表的其余部分使用表的规则
+	props := `{"placement":{"replicas":5,"required_labels":[{"key":"zone","value":"z1"}],"learners":1,
+		"learner_labels":[{"key":"role","value":"olap"}],
+		"key_ranges":[{"start_key":"AQ==","end_key":"Ag==","replicas":3,"required_labels":[{"key":"disk","value":"ssd"}]}]}}`
+	if err := cluster.EditTable(table, props); err != nil {
+		t.Fatalf("edit table placement error: %v", err)
+	}
+	p := table.Placement()
+	if p == nil || p.Replicas != 5 || p.Learners != 1 || table.GetEpoch().GetConfVer() != 2 {
+		t.Fatalf("unexpected placement %v, conf version %d", p, table.GetEpoch().GetConfVer())
+	}
+
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId())
+	rule := p.rule(table.GetId(), append(append([]byte(nil), prefix...), 0x01, 0x05))
+	if rule.Replicas != 3 || rule.Learners != 1 || rule.RequiredLabels[0].GetKey() != "disk" {
+		t.Fatalf("unexpected key range rule %v", rule)
+	}
+	rule = p.rule(table.GetId(), append(append([]byte(nil), prefix...), 0x02))
+	if rule.Replicas != 5 || rule.RequiredLabels[0].GetKey() != "zone" {
+		t.Fatalf("unexpected table rule %v", rule)
+	}
+
+	ssd := NewNode(&metapb.Node{Id: 1, Labels: []*metapb.NodeLabel{{Key: "disk", Value: "ssd"}}})
+	olap := NewNode(&metapb.Node{Id: 2, Labels: []*metapb.NodeLabel{{Key: "role", Value: "olap"}}})
+	if rule.allows(ssd, false) || !rule.allows(olap, true) || rule.allows(ssd, true) {
+		t.Fatalf("unexpected node selection of rule %v", rule)
+	}
+
+	// 修改ttl不影响放置规则
+	if err := cluster.EditTable(table, `{"ttl":3600}`); err != nil {
+		t.Fatalf("edit table ttl error: %v", err)
+	}
+	if table.Placement() == nil || table.Placement().Replicas != 5 {
+		t.Fatalf("expect placement kept after ttl changed, got %v", table.Placement())
+	}
+
+	if err := cluster.EditTable(table, `{"placement":{"replicas":-1}}`); err != ErrInvalidPlacement {
+		t.Fatalf("expect %v, got %v", ErrInvalidPlacement, err)
+	}
+	if err := cluster.EditTable(table, `{"placement":{}}`); err != nil {
+		t.Fatalf("clear table placement error: %v", err)
+	}
+	if table.Placement() != nil || table.TTL() != 3600 {
+		t.Fatalf("expect placement cleared, got %v, ttl %d", table.Placement(), table.TTL())
+	}
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"master-server/backup"
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"proxy/store/dskv"
+	"util"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+// TTLExpireWorker deletes the expired rows of the tables with ttl, the
+// gateways hide them from the queries until then.
+type TTLExpireWorker struct {
+	name     string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	interval time.Duration
+}
+
+func NewTTLExpireWorker(wm *WorkerManager, interval time.Duration) Worker {
+	ctx, cancel := context.WithCancel(wm.ctx)
+	return &TTLExpireWorker{
+		name:     ttlExpireWorkerName,
+		ctx:      ctx,
+		cancel:   cancel,
+		interval: interval,
+	}
+}
+
+func (w *TTLExpireWorker) GetName() string {
+	return w.name
+}
+
+func (w *TTLExpireWorker) Work(cluster *Cluster) {
+	for _, table := range cluster.workingTables.GetAllTable() {
+		select {
+		case <-w.ctx.Done():
+			return
+		default:
+		}
+		if table.TTL() == 0 || table.Status != metapb.TableStatus_TableRunning {
+			continue
+		}
+		if cluster.backupEnv == nil {
+			log.Warn("no data server env to delete expired rows")
+			return
+		}
+		env, err := cluster.backupEnv()
+		if err != nil {
+			log.Error("get data server env failed, err[%v]", err)
+			return
+		}
+
+		var ranges []*metapb.Range
+		for _, r := range cluster.GetTableAllRanges(table.GetId()) {
+			ranges = append(ranges, r.Range)
+		}
+		rows, size, err := deleteExpiredRows(w.ctx, env, table, ranges, time.Now().Unix())
+		cluster.metric.AddScheduleCounter(w.GetName(), "expired_rows", rows)
+		cluster.metric.AddScheduleCounter(w.GetName(), "reclaimed_bytes", size)
+		if err != nil {
+			log.Error("delete expired rows of table[%s:%s] failed, err[%v]", table.GetDbName(), table.GetName(), err)
+			continue
+		}
+		log.Info("expired rows of table[%s:%s] deleted, rows[%d], bytes[%d]",
+			table.GetDbName(), table.GetName(), rows, size)
+	}
+}
+
+func (w *TTLExpireWorker) AllowWork(cluster *Cluster) bool {
+	// the ranges may be incomplete in the recovery
+	return cluster.GetMetaRecovery() == nil
+}
+
+func (w *TTLExpireWorker) GetInterval() time.Duration {
+	return w.interval
+}
+
+func (w *TTLExpireWorker) Stop() {
+	w.cancel()
+}
+
+// deleteExpiredRows deletes the rows of the table expired before now(unix
+// seconds) and returns the number of rows and the bytes reclaimed.
+// The expired rows are selected batch by batch to count their size, then the
+// scope of the batch is deleted with the same expire filter, so a row written
+// again meanwhile is kept.
+func deleteExpiredRows(ctx context.Context, env *backup.Env, table *Table, ranges []*metapb.Range,
+	now int64) (uint64, uint64, error) {
+	if len(ranges) == 0 {
+		return 0, 0, errors.New("table has no range")
+	}
+	proxy := env.NewKvProxy(table.GetDbId(), table.GetId())
+	expireCol := util.TTLExpireColumn()
+	req := &kvrpcpb.SelectRequest{
+		WhereFilters: []*kvrpcpb.Match{
+			// the rows written before the ttl is set have no expire time
+			{Column: expireCol, Threshold: []byte("0"), MatchType: kvrpcpb.MatchType_Larger},
+			{Column: expireCol, Threshold: []byte(strconv.FormatInt(now, 10)), MatchType: kvrpcpb.MatchType_LessOrEqual},
+		},
+	}
+	for _, col := range table.GetColumns() {
+		req.FieldList = append(req.FieldList, &kvrpcpb.SelectField{Typ: kvrpcpb.SelectField_Column, Column: col})
+	}
+	var deleted, reclaimed uint64
+	err := dskv.Parallel(ctx, env.GetConcurrency(), len(ranges), func(i int) error {
+		r := ranges[i]
+		err := proxy.ScanScope(ctx, req, r.GetStartKey(), r.GetEndKey(), env.GetBatchSize(), func(rows []*kvrpcpb.Row) error {
+			var size uint64
+			for _, row := range rows {
+				size += uint64(len(row.GetKey()) + len(row.GetFields()))
+			}
+			last := append(append([]byte(nil), rows[len(rows)-1].GetKey()...), 0)
+			n, err := deleteExpiredScope(proxy, req.WhereFilters, rows[0].GetKey(), last)
+			if err != nil {
+				return err
+			}
+			// rows rewritten since the scan are kept, count the deleted only
+			if n < uint64(len(rows)) {
+				size = size * n / uint64(len(rows))
+			}
+			atomic.AddUint64(&deleted, n)
+			atomic.AddUint64(&reclaimed, size)
+			return nil
+		})
+		if err != nil && err != ctx.Err() {
+			return fmt.Errorf("delete expired rows of range[%d] failed, err[%v]", r.GetId(), err)
+		}
+		return err
+	})
+	return atomic.LoadUint64(&deleted), atomic.LoadUint64(&reclaimed), err
+}
+
+func deleteExpiredScope(proxy *dskv.KvProxy, filters []*kvrpcpb.Match, start, limit []byte) (uint64, error) {
+	req := &kvrpcpb.DeleteRequest{
+		Scope:        &kvrpcpb.Scope{Start: start, Limit: limit},
+		WhereFilters: filters,
+	}
+	resps, err := proxy.SqlDelete(req, req.Scope)
+	if err != nil {
+		return 0, err
+	}
+	var affected uint64
+	for _, resp := range resps {
+		if resp.GetCode() != 0 {
+			return affected, fmt.Errorf("delete failed, code[%d]", resp.GetCode())
+		}
+		affected += resp.GetAffectedKeys()
+	}
+	return affected, nil
+}
@@ -20,6 +20,8 @@ const (
 type AddPeerTask struct {
 	*BaseTask
 	peer *metapb.Peer // peer to add
+	// 添加保持learner的副本
+	learner bool
 
 	confRetries   int // TODO: limit max retry
 	createRetries int // TODO: limit max retry
@@ -32,6 +34,14 @@ func NewAddPeerTask() *AddPeerTask {
 	}
 }
 
+// NewAddLearnerTask new add peer task which adds a learner never promoted
+func NewAddLearnerTask() *AddPeerTask {
+	return &AddPeerTask{
+		BaseTask: newBaseTask(TaskTypeAddPeer, defaultAddPeerTaskTimeout),
+		learner:  true,
+	}
+}
+
 func (t *AddPeerTask) String() string {
 	return fmt.Sprintf("{%s, \"to_add\":\"%s\"}", t.BaseTask.String(), t.peer.String())
 }
@@ -68,7 +78,7 @@ func (t *AddPeerTask) stepStart(cluster *Cluster, r *Range) (task *taskpb.Task)
 	// not alloc new peer yet
 	if t.peer == nil {
 		var err error
-		t.peer, err = cluster.allocPeerAndSelectNode(r, true)
+		t.peer, err = cluster.allocPeerAndSelectNode(r, true, t.learner)
 		if err != nil {
 			log.Error("%s alloc peer failed: %s", t.logID, err.Error())
 			return nil
@@ -179,7 +189,7 @@ func (t *AddPeerTask) stepWaitSync(r *Range) bool {
 	log.Info("%s added peer[id:%d, node:%d] current type: %v, status: %s",
 		t.logID, t.peer.GetId(), t.peer.GetNodeId(), peer.Type, t.getProgressInfo(r))
 
-	if peer.Type == metapb.PeerType_PeerType_Learner {
+	if peer.Type == metapb.PeerType_PeerType_Learner && !peer.GetKeepLearner() {
 		return false
 	}
 
@@ -23,6 +23,12 @@ type TaskChain struct {
 	lastUpdate time.Time
 	running    uint64
 	logID      string
+
+	// 产生任务的调度器, 人工操作为manualTaskWorker
+	worker string
+	// 任务结束时的状态和失败原因
+	result TaskState
+	err    string
 }
 
 // NewTaskChain new taskchain
@@ -67,6 +73,25 @@ func (c *TaskChain) GetLogID() string {
 	return c.logID
 }
 
+// GetWorker return the worker which created the taskchain
+func (c *TaskChain) GetWorker() string {
+	return c.worker
+}
+
+// GetResult return the final state and the failure reason, TaskStateStart if not over
+func (c *TaskChain) GetResult() (TaskState, string) {
+	if c.result == 0 {
+		return TaskStateStart, ""
+	}
+	return c.result, c.err
+}
+
+// Cancel mark the taskchain canceled before removing it
+func (c *TaskChain) Cancel(reason string) {
+	c.result = TaskStateCanceled
+	c.err = reason
+}
+
 // Elapsed time since begin
 func (c *TaskChain) Elapsed() time.Duration {
 	return time.Since(c.begin)
@@ -85,6 +110,9 @@ func (c *TaskChain) Next(cluster *Cluster, r *Range) (over bool, task *taskpb.Ta
 
 	for {
 		if c.curIdx >= len(c.tasks) {
+			if c.result == 0 {
+				c.result = TaskStateFinished
+			}
 			return true, nil
 		}
 
@@ -104,6 +132,8 @@ func (c *TaskChain) Next(cluster *Cluster, r *Range) (over bool, task *taskpb.Ta
 			if !t.AllowFail() {
 				log.Error("%s run %s task failed at %s, detail: %s",
 					c.logID, t.GetType().String(), t.GetState().String(), t.String())
+				c.result = t.GetState()
+				c.err = fmt.Sprintf("%s task %s", t.GetType().String(), t.GetState().String())
 				return true, nil
 			}
 			log.Warn("%s skip %s task failed at %s, detail: %s",
@@ -113,6 +143,7 @@ func (c *TaskChain) Next(cluster *Cluster, r *Range) (over bool, task *taskpb.Ta
 		// current task finished successfully and current is the last one
 		if c.curIdx == len(c.tasks)-1 {
 			log.Info("%s finished. used: %v, last task: %s", c.logID, c.Elapsed(), t.String())
+			c.result = TaskStateFinished
 			return true, nil
 		}
 
@@ -133,6 +164,9 @@ func (c *TaskChain) String() string {
 // NewTransferPeerTasks new transfer peer tasks
 func NewTransferPeerTasks(id uint64, r *Range, name string, from *metapb.Peer) *TaskChain {
 	addPeerTask := NewAddPeerTask()
+	if from.GetKeepLearner() {
+		addPeerTask = NewAddLearnerTask()
+	}
 	delPeerTask := NewDeletePeerTask(from)
 
 	if r.GetLeader() != nil && r.GetLeader().GetId() == from.GetId() {
@@ -0,0 +1,245 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"model/pkg/metapb"
+	"util/log"
+)
+
+const (
+	// 落盘的任务历史条数, 超过时删除最早结束的
+	maxTaskHistory = 10000
+	// 查询任务历史默认返回的条数
+	defaultTaskHistoryLimit = 100
+
+	// range心跳检查产生的任务
+	heartbeatTaskWorker = "range_heartbeat"
+	// /manage/range/*等人工操作
+	manualTaskWorker = "manual"
+)
+
+// TaskRecord is a finished task chain or a manual operation of a range.
+type TaskRecord struct {
+	Id      uint64 `json:"id"`
+	RangeId uint64 `json:"range_id"`
+	TableId uint64 `json:"table_id"`
+	// 任务涉及的节点和range副本所在的节点
+	NodeIds []uint64 `json:"node_ids"`
+	Name    string   `json:"name"`
+	Worker  string   `json:"worker"`
+	Steps   []string `json:"steps,omitempty"`
+	// unix秒
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Outcome   string `json:"outcome"`
+	Error     string `json:"error,omitempty"`
+}
+
+// TaskHistoryFilter selects the task records, zero fields match all.
+type TaskHistoryFilter struct {
+	RangeId uint64
+	NodeId  uint64
+	TableId uint64
+	// 与[Start, End]有交集的任务
+	Start int64
+	End   int64
+	Limit int
+}
+
+func (f *TaskHistoryFilter) match(record *TaskRecord) bool {
+	if f.RangeId != 0 && record.RangeId != f.RangeId {
+		return false
+	}
+	if f.TableId != 0 && record.TableId != f.TableId {
+		return false
+	}
+	if f.Start != 0 && record.EndTime < f.Start {
+		return false
+	}
+	if f.End != 0 && record.StartTime > f.End {
+		return false
+	}
+	if f.NodeId != 0 {
+		for _, id := range record.NodeIds {
+			if id == f.NodeId {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func taskHistoryKey(id uint64) []byte {
+	// 定长, 按id顺序扫描
+	return []byte(fmt.Sprintf("%s%020d", PREFIX_TASK_HISTORY, id))
+}
+
+func appendNodeId(ids []uint64, id uint64) []uint64 {
+	if id == 0 {
+		return ids
+	}
+	for _, i := range ids {
+		if i == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+func newTaskRecord(tc *TaskChain, r *Range) *TaskRecord {
+	state, reason := tc.GetResult()
+	record := &TaskRecord{
+		Id:        tc.GetID(),
+		RangeId:   tc.GetRangeID(),
+		Name:      tc.GetName(),
+		Worker:    tc.GetWorker(),
+		StartTime: tc.begin.Unix(),
+		EndTime:   time.Now().Unix(),
+		Outcome:   state.String(),
+		Error:     reason,
+	}
+	for i, t := range tc.tasks {
+		if i > tc.curIdx {
+			break
+		}
+		record.Steps = append(record.Steps, t.String())
+		switch task := t.(type) {
+		case *AddPeerTask:
+			record.NodeIds = appendNodeId(record.NodeIds, task.peer.GetNodeId())
+		case *DeletePeerTask:
+			record.NodeIds = appendNodeId(record.NodeIds, task.peer.GetNodeId())
+		case *ChangeLeaderTask:
+			record.NodeIds = appendNodeId(record.NodeIds, task.fromNodeID)
+			record.NodeIds = appendNodeId(record.NodeIds, task.toNodeID)
+		}
+	}
+	if r != nil {
+		record.TableId = r.GetTableId()
+		for _, peer := range r.GetPeers() {
+			record.NodeIds = appendNodeId(record.NodeIds, peer.GetNodeId())
+		}
+	}
+	return record
+}
+
+// recordTask 任务结束时记录到任务历史
+func (c *Cluster) recordTask(tc *TaskChain) {
+	c.addTaskRecord(newTaskRecord(tc, c.FindRange(tc.GetRangeID())))
+}
+
+// recordRangeOperation 记录不产生任务的range人工操作, 失败时err为失败原因
+func (c *Cluster) recordRangeOperation(r *metapb.Range, name string, err string) {
+	id, genErr := c.GenId()
+	if genErr != nil {
+		log.Warn("record operation[%s] of range[%d] failed, err[%v]", name, r.GetId(), genErr)
+		return
+	}
+	now := time.Now().Unix()
+	record := &TaskRecord{
+		Id:        id,
+		RangeId:   r.GetId(),
+		TableId:   r.GetTableId(),
+		Name:      name,
+		Worker:    manualTaskWorker,
+		StartTime: now,
+		EndTime:   now,
+		Outcome:   TaskStateFinished.String(),
+		Error:     err,
+	}
+	if len(err) > 0 {
+		record.Outcome = TaskStateFailed.String()
+	}
+	for _, peer := range r.GetPeers() {
+		record.NodeIds = appendNodeId(record.NodeIds, peer.GetNodeId())
+	}
+	c.addTaskRecord(record)
+}
+
+func (c *Cluster) addTaskRecord(record *TaskRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Warn("marshal task record[%d] failed, err[%v]", record.Id, err)
+		return
+	}
+	c.taskHistoryLock.Lock()
+	defer c.taskHistoryLock.Unlock()
+	if err = c.store.Put(taskHistoryKey(record.Id), data); err != nil {
+		log.Warn("store task record[%d] failed, err[%v]", record.Id, err)
+		return
+	}
+	c.taskHistory = append(c.taskHistory, record)
+	c.trimTaskHistory()
+}
+
+// trimTaskHistory 删除超出上限的最早的记录, 需持有taskHistoryLock
+func (c *Cluster) trimTaskHistory() {
+	if len(c.taskHistory) <= maxTaskHistory {
+		return
+	}
+	expired := c.taskHistory[:len(c.taskHistory)-maxTaskHistory]
+	for _, record := range expired {
+		if err := c.store.Delete(taskHistoryKey(record.Id)); err != nil {
+			log.Warn("delete task record[%d] failed, err[%v]", record.Id, err)
+		}
+	}
+	c.taskHistory = append([]*TaskRecord(nil), c.taskHistory[len(expired):]...)
+}
+
+// GetTaskHistory returns the task records matching the filter, the latest first.
+func (c *Cluster) GetTaskHistory(filter *TaskHistoryFilter) []*TaskRecord {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultTaskHistoryLimit
+	}
+	c.taskHistoryLock.RLock()
+	defer c.taskHistoryLock.RUnlock()
+	records := make([]*TaskRecord, 0)
+	for i := len(c.taskHistory) - 1; i >= 0 && len(records) < limit; i-- {
+		if filter.match(c.taskHistory[i]) {
+			record := *c.taskHistory[i]
+			records = append(records, &record)
+		}
+	}
+	return records
+}
+
+type taskRecordSlice []*TaskRecord
+
+func (s taskRecordSlice) Len() int      { return len(s) }
+func (s taskRecordSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s taskRecordSlice) Less(i, j int) bool {
+	if s[i].EndTime == s[j].EndTime {
+		return s[i].Id < s[j].Id
+	}
+	return s[i].EndTime < s[j].EndTime
+}
+
+func (c *Cluster) loadTaskHistory() error {
+	startKey, limitKey := bytesPrefix([]byte(PREFIX_TASK_HISTORY))
+	it := c.store.Scan(startKey, limitKey)
+	defer it.Release()
+	records := make([]*TaskRecord, 0)
+	for it.Next() {
+		if it.Key() == nil {
+			log.Error("load task history key is nil")
+			continue
+		}
+		record := new(TaskRecord)
+		if err := json.Unmarshal(it.Value(), record); err != nil {
+			log.Warn("unmarshal task record[%s] failed, err[%v]", string(it.Key()), err)
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Sort(taskRecordSlice(records))
+	c.taskHistoryLock.Lock()
+	defer c.taskHistoryLock.Unlock()
+	c.taskHistory = records
+	c.trimTaskHistory()
+	return nil
+}
@@ -34,17 +34,17 @@ func (m *TaskManager) Add(tc *TaskChain) bool {
 // Remove remove
 func (m *TaskManager) Remove(tc *TaskChain, cluster *Cluster) bool {
 	m.Lock()
-	defer m.Unlock()
-
 	old, ok := m.tasks[tc.GetRangeID()]
-	if !ok {
-		return false
-	}
-	if old.GetID() != tc.GetID() {
+	if !ok || old.GetID() != tc.GetID() {
+		m.Unlock()
 		return false
 	}
 	delete(m.tasks, tc.GetRangeID())
+	m.Unlock()
+
 	cluster.metric.CollectEvent(tc)
+	// 写store较慢, 不持有锁
+	cluster.recordTask(tc)
 	return true
 }
 
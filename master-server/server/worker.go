@@ -22,17 +22,23 @@ const (
 	trashReplicaGcWorkerName	 = "trash_replica_gc_worker"
 	createTableWorkerName		 = "create_table_worker"
 	rangeHbCheckWorkerName		 = "range_hbcheck_worker"
+	droppedColumnCleanWorkerName = "dropped_column_clean_worker"
+	ttlExpireWorkerName          = "ttl_expire_worker"
+	drainNodeWorkerName          = "drain_node_worker"
+	evictLeaderWorkerName        = "evict_leader_worker"
+	upgradeWorkerName            = "upgrade_worker"
 
 	balanceRangeWorkerName   	 = "balance_range_worker"
 	balanceLeaderWorkerName   	 = "balance_leader_worker"
 	balanceNodeOpsWorkerName     = "balance_node_ops_worker"
 
+	// 带参数的调度器, 通过/manage/scheduler/add添加
+	grantLeaderWorkerName        = "grant_leader_worker"
+	shuffleLeaderWorkerName      = "shuffle_leader_worker"
+	shuffleRangeWorkerName       = "shuffle_range_worker"
+
 	//balanceStorageWorkerName 	= "balance_node_storage_worker"
 	//hotRegionWorkerName        = "balance_hotregion_worker"
-	//grantLeaderWorkerName      = "grant_leader_worker"
-	//evictLeaderWorkerName      = "evict_leader_worker"
-	//shuffleLeaderWorkerName    = "shuffle_leader_worker"
-	//shuffleRangeWorkerName    = "shuffle_range_worker"
 )
 
 
@@ -73,10 +79,19 @@ func (wm *WorkerManager) Run() {
 	wm.addWorker(NewTrashReplicaGCWorker(wm, time.Minute))
 	wm.addWorker(NewCreateTableWorker(wm, time.Second))
 	wm.addWorker(NewRangeHbCheckWorker(wm, 2 * time.Minute))
+	wm.addWorker(NewDroppedColumnCleanWorker(wm, 10 * time.Minute))
+	wm.addWorker(NewTTLExpireWorker(wm, 10 * time.Minute))
+	wm.addWorker(NewDrainNodeWorker(wm, 5 * time.Second))
+	wm.addWorker(NewEvictLeaderWorker(wm, 5 * time.Second))
+	wm.addWorker(NewUpgradeWorker(wm, 5 * time.Second))
 
 	wm.addWorker(NewBalanceNodeLeaderWorker(wm, 5 * defaultWorkerInterval))
 	wm.addWorker(NewBalanceNodeRangeWorker(wm, 2 * defaultWorkerInterval))
 	wm.addWorker(NewBalanceNodeOpsWorker(wm, defaultWorkerInterval))
+
+	for _, cfg := range wm.cluster.getSchedulers() {
+		wm.addWorker(cfg.newWorker(wm))
+	}
 }
 
 func (wm *WorkerManager) Stop() {
@@ -46,7 +46,8 @@ func (rng *Range) require(cluster *Cluster) bool {
 	if _, ok := cluster.FindTableById(rng.GetTableId()); !ok {
 		return false
 	}
-	if len(rng.GetPeers()) != cluster.opt.GetMaxReplicas() {
+	rule := cluster.rangePlacement(rng)
+	if voters, learners := countPeers(rng.GetPeers()); voters != rule.Replicas || learners != rule.Learners {
 		log.Debug("range peer is abnormal, cannot be scheduled")
 		return false
 	}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"util/log"
+)
+
+const maxDryRunTasks = 200
+
+// DryRunTask is a task chain proposed by a dry-run worker but not dispatched.
+type DryRunTask struct {
+	Worker  string `json:"worker"`
+	RangeId uint64 `json:"range_id"`
+	Name    string `json:"name"`
+	Detail  string `json:"detail"`
+	// 第一次和最近一次提出的时间, 以及次数. 任务不下发, 调度器会重复提出相同的任务
+	FirstTime int64 `json:"first_time"`
+	LastTime  int64 `json:"last_time"`
+	Times     int   `json:"times"`
+}
+
+// SetWorkerDryRun 设置调度器dry-run, 调度器产生的任务只记录日志不下发
+func (c *Cluster) SetWorkerDryRun(name string, enable bool) error {
+	if _, find := c.GetAllWorker()[name]; !find {
+		return ErrWorkerNotFound
+	}
+	key := []byte(fmt.Sprintf("%s%s", PREFIX_DRY_RUN, name))
+	c.dryRunLock.Lock()
+	defer c.dryRunLock.Unlock()
+	if enable {
+		if err := c.store.Put(key, uint64ToBytes(uint64(1))); err != nil {
+			log.Error("store dry-run of worker[%s] failed, err[%v]", name, err)
+			return err
+		}
+		c.dryRunWorkers[name] = true
+	} else {
+		if err := c.store.Delete(key); err != nil {
+			log.Error("delete dry-run of worker[%s] failed, err[%v]", name, err)
+			return err
+		}
+		delete(c.dryRunWorkers, name)
+	}
+	log.Info("worker[%s] dry-run: %v", name, enable)
+	return nil
+}
+
+// GetDryRunWorkers returns the names of the dry-run workers.
+func (c *Cluster) GetDryRunWorkers() []string {
+	c.dryRunLock.RLock()
+	defer c.dryRunLock.RUnlock()
+	names := make([]string, 0, len(c.dryRunWorkers))
+	for name := range c.dryRunWorkers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// GetDryRunTasks returns the recent tasks proposed by the dry-run workers,
+// all workers if name is empty.
+func (c *Cluster) GetDryRunTasks(name string) []*DryRunTask {
+	c.dryRunLock.RLock()
+	defer c.dryRunLock.RUnlock()
+	tasks := make([]*DryRunTask, 0, len(c.dryRunTasks))
+	for _, t := range c.dryRunTasks {
+		if len(name) == 0 || t.Worker == name {
+			task := *t
+			tasks = append(tasks, &task)
+		}
+	}
+	return tasks
+}
+
+func (c *Cluster) isDryRun(worker string) bool {
+	c.dryRunLock.RLock()
+	defer c.dryRunLock.RUnlock()
+	return c.dryRunWorkers[worker]
+}
+
+func (c *Cluster) recordDryRun(worker string, tc *TaskChain) {
+	c.dryRunLock.Lock()
+	defer c.dryRunLock.Unlock()
+	now := time.Now().Unix()
+	for _, t := range c.dryRunTasks {
+		if t.Worker == worker && t.RangeId == tc.GetRangeID() && t.Name == tc.GetName() {
+			t.Detail = tc.String()
+			t.LastTime = now
+			t.Times++
+			return
+		}
+	}
+	log.Info("[dry-run] worker[%s] %s: %s", worker, tc.GetLogID(), tc.String())
+	if len(c.dryRunTasks) >= maxDryRunTasks {
+		c.dryRunTasks = c.dryRunTasks[1:]
+	}
+	c.dryRunTasks = append(c.dryRunTasks, &DryRunTask{
+		Worker:    worker,
+		RangeId:   tc.GetRangeID(),
+		Name:      tc.GetName(),
+		Detail:    tc.String(),
+		FirstTime: now,
+		LastTime:  now,
+		Times:     1,
+	})
+}
+
+// addTask 添加调度器产生的任务, 调度器dry-run时只记录不下发, 返回值与taskManager.Add相同
+func (c *Cluster) addTask(worker string, tc *TaskChain) bool {
+	tc.worker = worker
+	if c.isDryRun(worker) {
+		if c.taskManager.Find(tc.GetRangeID()) != nil {
+			return false
+		}
+		c.recordDryRun(worker, tc)
+		return true
+	}
+	return c.taskManager.Add(tc)
+}
+
+func (c *Cluster) loadDryRunWorkers() error {
+	workers := make(map[string]bool)
+	startKey, limitKey := bytesPrefix([]byte(PREFIX_DRY_RUN))
+	it := c.store.Scan(startKey, limitKey)
+	defer it.Release()
+	for it.Next() {
+		if it.Key() == nil {
+			log.Error("load dry-run worker key is nil")
+			continue
+		}
+		workers[string(it.Key()[len(PREFIX_DRY_RUN):])] = true
+	}
+	c.dryRunLock.Lock()
+	c.dryRunWorkers = workers
+	c.dryRunTasks = nil
+	c.dryRunLock.Unlock()
+	return nil
+}
@@ -94,6 +94,199 @@ func TestBalanceRangeOps(t *testing.T) {
 	wg.Wait()
 }
 
+func TestScheduler(t *testing.T) {
+	cluster := newBoltDbCluster(t, newMockIDAllocator())
+	if cluster == nil {
+		return
+	}
+	defer closeLocalCluster(cluster)
+
+	node, _, err := cluster.GetNodeId("127.0.0.1:6060", "127.0.0.1:6061", "127.0.0.1:6062", "v1")
+	if err != nil {
+		t.Fatalf("get node ID failed, err %v", err)
+	}
+	if err := cluster.NodeLogin(node.GetId()); err != nil {
+		t.Fatalf("node %d login failed, err %v", node.GetId(), err)
+	}
+	// grant leader必须指定目标节点
+	if err := cluster.AddScheduler(&SchedulerConfig{Kind: grantLeaderWorkerName}); err != ErrInvalidParam {
+		t.Fatalf("expect %v, got %v", ErrInvalidParam, err)
+	}
+	cfg := &SchedulerConfig{Kind: evictLeaderWorkerName, Target: &SchedulerTarget{NodeId: node.GetId()}}
+	if err := cluster.AddScheduler(cfg); err != nil {
+		t.Fatalf("add scheduler failed, err %v", err)
+	}
+	name := fmt.Sprintf("%s-node=%d", evictLeaderWorkerName, node.GetId())
+	if cfg.Name() != name || !cluster.GetAllWorker()[name] {
+		t.Fatalf("scheduler %s is not running", name)
+	}
+	if err := cluster.AddScheduler(cfg); err != ErrSchedulerExisted {
+		t.Fatalf("expect %v, got %v", ErrSchedulerExisted, err)
+	}
+	if !cluster.leaderEvicted(node) || cluster.leaderGranted(node) {
+		t.Fatal("unexpected leader scheduler of node")
+	}
+
+	// 切换leader后恢复
+	if err := cluster.loadSchedulers(); err != nil || len(cluster.getSchedulers()) != 1 {
+		t.Fatalf("unexpected schedulers %v, err %v", cluster.getSchedulers(), err)
+	}
+	if err := cluster.RemoveWorker(name); err != nil {
+		t.Fatalf("remove scheduler failed, err %v", err)
+	}
+	if err := cluster.loadSchedulers(); err != nil || len(cluster.getSchedulers()) != 0 {
+		t.Fatalf("unexpected schedulers %v, err %v", cluster.getSchedulers(), err)
+	}
+	if cluster.leaderEvicted(node) {
+		t.Fatal("unexpected evicted node")
+	}
+}
+
+func newSimSnapshot() *SimSnapshot {
+	snap := &SimSnapshot{
+		Tables: []*metapb.Table{{Id: 1, DbId: 1, DbName: "db", Name: "t", Status: metapb.TableStatus_TableRunning}},
+	}
+	for i := uint64(1); i <= 4; i++ {
+		snap.Nodes = append(snap.Nodes, &SimNode{
+			Node:  &metapb.Node{Id: i, ServerAddr: fmt.Sprintf("127.0.0.%d:6060", i), State: metapb.NodeState_N_Login},
+			Stats: &mspb.NodeStats{Capacity: 100 << 30, Available: 90 << 30, UsedSize: 10 << 30},
+		})
+	}
+	// 节点1-3各有60个副本, leader都在节点1, 节点4为空
+	for i := uint64(0); i < 60; i++ {
+		id := 100 + i*10
+		snap.Ranges = append(snap.Ranges, &metapb.Range{
+			Id:         id,
+			TableId:    1,
+			RangeEpoch: &metapb.RangeEpoch{ConfVer: 1, Version: 1},
+			Peers: []*metapb.Peer{
+				{Id: id + 1, NodeId: 1, Type: metapb.PeerType_PeerType_Normal},
+				{Id: id + 2, NodeId: 2, Type: metapb.PeerType_PeerType_Normal},
+				{Id: id + 3, NodeId: 3, Type: metapb.PeerType_PeerType_Normal},
+			},
+		})
+	}
+	return snap
+}
+
+func TestSimulator(t *testing.T) {
+	cfg := NewDefaultConfig()
+	log.InitFileLog(cfg.Log.Dir, cfg.Log.Module, cfg.Log.Level)
+
+	sim, err := NewSimulator(newSimSnapshot(), newScheduleOption(cfg), nil)
+	if err != nil {
+		t.Fatalf("new simulator failed, err %v", err)
+	}
+	defer sim.Close()
+	result := sim.Run(120)
+	if len(result.Tasks) == 0 {
+		t.Fatal("no task created")
+	}
+	if result.After.LeaderStddev >= result.Before.LeaderStddev || result.After.RangeStddev >= result.Before.RangeStddev {
+		t.Fatalf("not balanced, before %v, after %v", result.Before, result.After)
+	}
+	if _, err := NewSimulator(newSimSnapshot(), newScheduleOption(cfg), []string{"unknown_worker"}); err != ErrWorkerNotFound {
+		t.Fatalf("expect %v, got %v", ErrWorkerNotFound, err)
+	}
+}
+
+func TestWorkerDryRun(t *testing.T) {
+	cfg := NewDefaultConfig()
+	log.InitFileLog(cfg.Log.Dir, cfg.Log.Module, cfg.Log.Level)
+
+	sim, err := NewSimulator(newSimSnapshot(), newScheduleOption(cfg), []string{balanceLeaderWorkerName})
+	if err != nil {
+		t.Fatalf("new simulator failed, err %v", err)
+	}
+	defer sim.Close()
+	cluster := sim.cluster
+	if err := cluster.SetWorkerDryRun("unknown_worker", true); err != ErrWorkerNotFound {
+		t.Fatalf("expect %v, got %v", ErrWorkerNotFound, err)
+	}
+	if err := cluster.SetWorkerDryRun(balanceLeaderWorkerName, true); err != nil {
+		t.Fatalf("set dry-run failed, err %v", err)
+	}
+	sim.workers[0].Work(cluster)
+	if len(cluster.GetAllTasks()) != 0 {
+		t.Fatalf("dry-run worker dispatched tasks %v", cluster.GetAllTasks())
+	}
+	if len(cluster.GetDryRunTasks(balanceLeaderWorkerName)) != 1 {
+		t.Fatalf("unexpected dry-run tasks %v", cluster.GetDryRunTasks(""))
+	}
+
+	// 切换leader后恢复
+	if err := cluster.loadDryRunWorkers(); err != nil || !cluster.isDryRun(balanceLeaderWorkerName) {
+		t.Fatalf("dry-run worker not loaded, err %v", err)
+	}
+	if err := cluster.SetWorkerDryRun(balanceLeaderWorkerName, false); err != nil {
+		t.Fatalf("unset dry-run failed, err %v", err)
+	}
+	cluster.hbManager.dealIngNodes.reset()
+	sim.workers[0].Work(cluster)
+	if len(cluster.GetAllTasks()) != 1 {
+		t.Fatalf("unexpected tasks %v", cluster.GetAllTasks())
+	}
+}
+
+func TestTaskHistory(t *testing.T) {
+	cfg := NewDefaultConfig()
+	log.InitFileLog(cfg.Log.Dir, cfg.Log.Module, cfg.Log.Level)
+
+	sim, err := NewSimulator(newSimSnapshot(), newScheduleOption(cfg), []string{balanceLeaderWorkerName})
+	if err != nil {
+		t.Fatalf("new simulator failed, err %v", err)
+	}
+	defer sim.Close()
+	cluster := sim.cluster
+	sim.Run(30)
+	records := cluster.GetTaskHistory(&TaskHistoryFilter{Limit: maxTaskHistory})
+	if len(records) == 0 {
+		t.Fatal("no task recorded")
+	}
+	record := records[0]
+	if record.Worker != balanceLeaderWorkerName || record.Outcome != TaskStateFinished.String() || record.TableId != 1 {
+		t.Fatalf("unexpected task record %v", record)
+	}
+	for _, r := range cluster.GetTaskHistory(&TaskHistoryFilter{RangeId: record.RangeId}) {
+		if r.RangeId != record.RangeId {
+			t.Fatalf("range filter mismatch %v", r)
+		}
+	}
+	if len(cluster.GetTaskHistory(&TaskHistoryFilter{NodeId: 100})) != 0 {
+		t.Fatal("node filter mismatch")
+	}
+	if len(cluster.GetTaskHistory(&TaskHistoryFilter{End: record.StartTime - 1})) != 0 {
+		t.Fatal("time filter mismatch")
+	}
+
+	// 人工操作
+	rng := cluster.FindRange(record.RangeId)
+	cluster.recordRangeOperation(rng.Range, "console-force-split", "failed")
+	records = cluster.GetTaskHistory(&TaskHistoryFilter{RangeId: rng.GetId(), Limit: 1})
+	if len(records) != 1 || records[0].Worker != manualTaskWorker || records[0].Outcome != TaskStateFailed.String() {
+		t.Fatalf("unexpected operation record %v", records)
+	}
+
+	// 切换leader后恢复, 超出上限时删除最早的
+	count := len(cluster.GetTaskHistory(&TaskHistoryFilter{Limit: maxTaskHistory}))
+	if err := cluster.loadTaskHistory(); err != nil {
+		t.Fatalf("load task history failed, err %v", err)
+	}
+	if len(cluster.GetTaskHistory(&TaskHistoryFilter{Limit: maxTaskHistory})) != count {
+		t.Fatal("task history not loaded")
+	}
+	for i := 0; i < maxTaskHistory; i++ {
+		cluster.recordRangeOperation(rng.Range, "console-force-compact", "")
+	}
+	if err := cluster.loadTaskHistory(); err != nil {
+		t.Fatalf("load task history failed, err %v", err)
+	}
+	records = cluster.GetTaskHistory(&TaskHistoryFilter{Limit: 2 * maxTaskHistory})
+	if len(records) != maxTaskHistory || records[len(records)-1].Name != "console-force-compact" {
+		t.Fatalf("task history not trimmed, %d records", len(records))
+	}
+}
+
 func MockCluster(t *testing.T) *Cluster {
 	mockCluster := newBoltDbCluster(t, newMockIDAllocator())
 	addNodes(mockCluster)
@@ -226,7 +419,7 @@ func addRangeForRangeBalance(cluster *Cluster, rangeSize int, t *testing.T) {
 func addPeersBalance(cluster *Cluster, rng *Range, t *testing.T) {
 	for index := 0; index < cluster.opt.GetMaxReplicas(); index++ {
 		peers := rng.GetPeers()
-		newPeer, err := cluster.allocPeerAndSelectNode(rng, false)
+		newPeer, err := cluster.allocPeerAndSelectNode(rng, false, false)
 		if err != nil {
 			t.Errorf("errr: %v", err)
 			continue
@@ -0,0 +1,169 @@
+// Package simulate provides the data servers of the scheduling simulator, the
+// tasks sent by the master are applied to the replicas kept in memory instead
+// of a real cluster.
+package simulate
+
+import (
+	"fmt"
+	"sort"
+
+	"model/pkg/metapb"
+	"model/pkg/schpb"
+	"model/pkg/taskpb"
+	"util/deepcopy"
+	"util/log"
+)
+
+// Nodes resolves the nodes of the simulated cluster.
+type Nodes interface {
+	NodeAddr(nodeId uint64) (string, bool)
+	NodeId(addr string) (uint64, bool)
+}
+
+// Range is the replicas of a range on the data servers.
+type Range struct {
+	Range *metapb.Range
+	// leader所在的节点
+	Leader uint64
+}
+
+// DataServer 模拟data server执行master下发的任务, 副本创建后下一个tick追上日志
+type DataServer struct {
+	nodes  Nodes
+	ranges []*Range
+	// range id和节点地址, 已创建的副本
+	created map[string]bool
+	// range id -> 目标节点地址, 待执行的切换leader
+	transfers map[uint64]string
+}
+
+func NewDataServer(nodes Nodes) *DataServer {
+	return &DataServer{
+		nodes:     nodes,
+		created:   make(map[string]bool),
+		transfers: make(map[uint64]string),
+	}
+}
+
+// AddRange adds the replicas of the range, the range is copied.
+func (ds *DataServer) AddRange(r *metapb.Range, leader uint64) {
+	i := sort.Search(len(ds.ranges), func(i int) bool { return ds.ranges[i].Range.GetId() >= r.GetId() })
+	ds.ranges = append(ds.ranges, nil)
+	copy(ds.ranges[i+1:], ds.ranges[i:])
+	ds.ranges[i] = &Range{Range: deepcopy.Iface(r).(*metapb.Range), Leader: leader}
+}
+
+// Ranges returns the ranges ordered by id.
+func (ds *DataServer) Ranges() []*Range {
+	return ds.ranges
+}
+
+func replicaKey(rangeId uint64, addr string) string {
+	return fmt.Sprintf("%d@%s", rangeId, addr)
+}
+
+// Progress promotes the created learners and transfers the leader.
+func (ds *DataServer) Progress(r *Range) {
+	for _, peer := range r.Range.GetPeers() {
+		if peer.GetType() != metapb.PeerType_PeerType_Learner || peer.GetKeepLearner() {
+			continue
+		}
+		addr, find := ds.nodes.NodeAddr(peer.GetNodeId())
+		if find && ds.created[replicaKey(r.Range.GetId(), addr)] {
+			peer.Type = metapb.PeerType_PeerType_Normal
+			r.Range.RangeEpoch.ConfVer++
+		}
+	}
+	addr, find := ds.transfers[r.Range.GetId()]
+	if !find {
+		return
+	}
+	delete(ds.transfers, r.Range.GetId())
+	nodeId, find := ds.nodes.NodeId(addr)
+	if !find {
+		return
+	}
+	for _, peer := range r.Range.GetPeers() {
+		if peer.GetNodeId() == nodeId && peer.GetType() == metapb.PeerType_PeerType_Normal {
+			r.Leader = nodeId
+		}
+	}
+}
+
+// Execute applies the task of the range heartbeat response.
+func (ds *DataServer) Execute(r *Range, task *taskpb.Task) {
+	if task == nil {
+		return
+	}
+	switch task.GetType() {
+	case taskpb.TaskType_RangeAddPeer:
+		peer := task.GetRangeAddPeer().GetPeer()
+		for _, p := range r.Range.GetPeers() {
+			if p.GetId() == peer.GetId() {
+				return
+			}
+		}
+		r.Range.Peers = append(r.Range.Peers, deepcopy.Iface(peer).(*metapb.Peer))
+		r.Range.RangeEpoch.ConfVer++
+	case taskpb.TaskType_RangeDelPeer:
+		peer := task.GetRangeDelPeer().GetPeer()
+		var peers []*metapb.Peer
+		for _, p := range r.Range.GetPeers() {
+			if p.GetId() != peer.GetId() {
+				peers = append(peers, p)
+			}
+		}
+		if len(peers) == len(r.Range.GetPeers()) || len(peers) == 0 {
+			return
+		}
+		r.Range.Peers = peers
+		r.Range.RangeEpoch.ConfVer++
+		if r.Leader == peer.GetNodeId() {
+			r.Leader = peers[0].GetNodeId()
+		}
+	default:
+		log.Warn("simulate: range[%d] task %v not supported", r.Range.GetId(), task.GetType())
+	}
+}
+
+// The methods below implement the client the master sends the range commands
+// to the data servers by.
+
+func (ds *DataServer) Close() error {
+	return nil
+}
+
+func (ds *DataServer) CreateRange(addr string, r *metapb.Range) error {
+	ds.created[replicaKey(r.GetId(), addr)] = true
+	return nil
+}
+
+func (ds *DataServer) DeleteRange(addr string, rangeId uint64, peerID uint64) error {
+	delete(ds.created, replicaKey(rangeId, addr))
+	return nil
+}
+
+func (ds *DataServer) TransferLeader(addr string, rangeId uint64) error {
+	ds.transfers[rangeId] = addr
+	return nil
+}
+
+func (ds *DataServer) UpdateRange(addr string, r *metapb.Range) error {
+	return nil
+}
+
+func (ds *DataServer) GetPeerInfo(addr string, rangeId uint64) (*schpb.GetPeerInfoResponse, error) {
+	return &schpb.GetPeerInfoResponse{}, nil
+}
+
+func (ds *DataServer) SetNodeLogLevel(addr string, level string) error {
+	return nil
+}
+
+func (ds *DataServer) OffLineRange(addr string, rangeId uint64) error {
+	return nil
+}
+
+func (ds *DataServer) ReplaceRange(addr string, oldRangeId uint64, newRange *metapb.Range) error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package simulate
+
+import (
+	"testing"
+
+	"model/pkg/metapb"
+	"model/pkg/taskpb"
+)
+
+type testNodes map[uint64]string
+
+func (n testNodes) NodeAddr(nodeId uint64) (string, bool) {
+	addr, find := n[nodeId]
+	return addr, find
+}
+
+func (n testNodes) NodeId(addr string) (uint64, bool) {
+	for id, a := range n {
+		if a == addr {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func TestDataServer(t *testing.T) {
+	ds := NewDataServer(testNodes{1: "n1", 2: "n2", 3: "n3"})
+	ds.AddRange(&metapb.Range{Id: 2, RangeEpoch: &metapb.RangeEpoch{}, Peers: []*metapb.Peer{{Id: 20, NodeId: 1}}}, 1)
+	ds.AddRange(&metapb.Range{Id: 1, RangeEpoch: &metapb.RangeEpoch{}, Peers: []*metapb.Peer{{Id: 10, NodeId: 1}}}, 1)
+	if rs := ds.Ranges(); len(rs) != 2 || rs[0].Range.GetId() != 1 || rs[1].Range.GetId() != 2 {
+		t.Fatalf("expected ranges ordered by id: %v", rs)
+	}
+	r := ds.Ranges()[0]
+
+	learner := &metapb.Peer{Id: 11, NodeId: 2, Type: metapb.PeerType_PeerType_Learner}
+	ds.Execute(r, &taskpb.Task{Type: taskpb.TaskType_RangeAddPeer, RangeAddPeer: &taskpb.TaskRangeAddPeer{Peer: learner}})
+	if len(r.Range.GetPeers()) != 2 || r.Range.GetRangeEpoch().GetConfVer() != 1 {
+		t.Fatalf("expected peer added: %v", r.Range)
+	}
+	// the learner is promoted after its replica is created
+	ds.Progress(r)
+	if r.Range.GetPeers()[1].GetType() != metapb.PeerType_PeerType_Learner {
+		t.Fatal("expected learner before the replica is created")
+	}
+	ds.CreateRange("n2", r.Range)
+	ds.Progress(r)
+	if r.Range.GetPeers()[1].GetType() != metapb.PeerType_PeerType_Normal || r.Range.GetRangeEpoch().GetConfVer() != 2 {
+		t.Fatalf("expected learner promoted: %v", r.Range)
+	}
+
+	ds.TransferLeader("n2", r.Range.GetId())
+	ds.Progress(r)
+	if r.Leader != 2 {
+		t.Fatalf("expected leader on node 2, actual %d", r.Leader)
+	}
+	ds.Execute(r, &taskpb.Task{Type: taskpb.TaskType_RangeDelPeer, RangeDelPeer: &taskpb.TaskRangeDelPeer{Peer: learner}})
+	if len(r.Range.GetPeers()) != 1 || r.Leader != 1 {
+		t.Fatalf("expected peer deleted and leader moved: %v leader %d", r.Range, r.Leader)
+	}
+}
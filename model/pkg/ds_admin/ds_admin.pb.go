@@ -521,6 +521,8 @@ func (m *GetInfoResponse) GetData() string {
 type ForceSplitRequest struct {
 	RangeId uint64 `protobuf:"varint,1,opt,name=range_id,json=rangeId,proto3" json:"range_id,omitempty"`
 	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// split at this key instead of the middle key of the range if it's not empty
+	SplitKey []byte `protobuf:"bytes,3,opt,name=split_key,json=splitKey,proto3" json:"split_key,omitempty"`
 }
 
 func (m *ForceSplitRequest) Reset()                    { *m = ForceSplitRequest{} }
@@ -542,6 +544,13 @@ func (m *ForceSplitRequest) GetVersion() uint64 {
 	return 0
 }
 
+func (m *ForceSplitRequest) GetSplitKey() []byte {
+	if m != nil {
+		return m.SplitKey
+	}
+	return nil
+}
+
 type ForceSplitResponse struct {
 	SplitKey []byte `protobuf:"bytes,1,opt,name=split_key,json=splitKey,proto3" json:"split_key,omitempty"`
 }
@@ -1238,6 +1247,12 @@ func (m *ForceSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintDsAdmin(dAtA, i, uint64(m.Version))
 	}
+	if len(m.SplitKey) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDsAdmin(dAtA, i, uint64(len(m.SplitKey)))
+		i += copy(dAtA[i:], m.SplitKey)
+	}
 	return i, nil
 }
 
@@ -1681,6 +1696,10 @@ func (m *ForceSplitRequest) Size() (n int) {
 	if m.Version != 0 {
 		n += 1 + sovDsAdmin(uint64(m.Version))
 	}
+	l = len(m.SplitKey)
+	if l > 0 {
+		n += 1 + l + sovDsAdmin(uint64(l))
+	}
 	return n
 }
 
@@ -3371,6 +3390,37 @@ func (m *ForceSplitRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDsAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDsAdmin
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SplitKey = append(m.SplitKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.SplitKey == nil {
+				m.SplitKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDsAdmin(dAtA[iNdEx:])
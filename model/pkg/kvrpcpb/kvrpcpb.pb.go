@@ -839,6 +839,8 @@ type Match struct {
 	// 匹配对象
 	Threshold []byte    `protobuf:"bytes,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
 	MatchType MatchType `protobuf:"varint,3,opt,name=match_type,json=matchType,proto3,enum=kvrpcpb.MatchType" json:"match_type,omitempty"`
+	// 为true时, 列没有值或值为零的行也视为匹配
+	MatchUnset bool `protobuf:"varint,4,opt,name=match_unset,json=matchUnset,proto3" json:"match_unset,omitempty"`
 }
 
 func (m *Match) Reset()                    { *m = Match{} }
@@ -867,6 +869,13 @@ func (m *Match) GetMatchType() MatchType {
 	return MatchType_Invalid
 }
 
+func (m *Match) GetMatchUnset() bool {
+	if m != nil {
+		return m.MatchUnset
+	}
+	return false
+}
+
 type Limit struct {
 	Offset uint64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
 	Count  uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
@@ -923,6 +932,8 @@ type SelectRequest struct {
 	GroupBys     []*metapb.Column     `protobuf:"bytes,5,rep,name=group_bys,json=groupBys" json:"group_bys,omitempty"`
 	Limit        *Limit               `protobuf:"bytes,6,opt,name=limit" json:"limit,omitempty"`
 	Timestamp    *timestamp.Timestamp `protobuf:"bytes,7,opt,name=timestamp" json:"timestamp,omitempty"`
+	Reverse      bool                 `protobuf:"varint,8,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	SnapshotRead bool                 `protobuf:"varint,9,opt,name=snapshot_read,json=snapshotRead,proto3" json:"snapshot_read,omitempty"`
 }
 
 func (m *SelectRequest) Reset()                    { *m = SelectRequest{} }
@@ -979,6 +990,20 @@ func (m *SelectRequest) GetTimestamp() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *SelectRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *SelectRequest) GetSnapshotRead() bool {
+	if m != nil {
+		return m.SnapshotRead
+	}
+	return false
+}
+
 type Row struct {
 	Key          []byte  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Fields       []byte  `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
@@ -1155,6 +1180,8 @@ type InsertRequest struct {
 	Rows           []*KeyValue          `protobuf:"bytes,1,rep,name=rows" json:"rows,omitempty"`
 	CheckDuplicate bool                 `protobuf:"varint,2,opt,name=check_duplicate,json=checkDuplicate,proto3" json:"check_duplicate,omitempty"`
 	Timestamp      *timestamp.Timestamp `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	// 不为空时, 行只在当前值与expected_values[i]相同时写入, 不存在或不同的行跳过, 不计入affected_keys
+	ExpectedValues [][]byte `protobuf:"bytes,4,rep,name=expected_values,json=expectedValues" json:"expected_values,omitempty"`
 }
 
 func (m *InsertRequest) Reset()                    { *m = InsertRequest{} }
@@ -1183,6 +1210,13 @@ func (m *InsertRequest) GetTimestamp() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *InsertRequest) GetExpectedValues() [][]byte {
+	if m != nil {
+		return m.ExpectedValues
+	}
+	return nil
+}
+
 type InsertResponse struct {
 	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	// 受影响的KV
@@ -3769,6 +3803,16 @@ func (m *Match) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintKvrpcpb(dAtA, i, uint64(m.MatchType))
 	}
+	if m.MatchUnset {
+		dAtA[i] = 0x20
+		i++
+		if m.MatchUnset {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -3925,6 +3969,26 @@ func (m *SelectRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n29
 	}
+	if m.Reverse {
+		dAtA[i] = 0x40
+		i++
+		if m.Reverse {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.SnapshotRead {
+		dAtA[i] = 0x48
+		i++
+		if m.SnapshotRead {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -4212,6 +4276,14 @@ func (m *InsertRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n38
 	}
+	if len(m.ExpectedValues) > 0 {
+		for _, b := range m.ExpectedValues {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintKvrpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
 	return i, nil
 }
 
@@ -6817,6 +6889,9 @@ func (m *Match) Size() (n int) {
 	if m.MatchType != 0 {
 		n += 1 + sovKvrpcpb(uint64(m.MatchType))
 	}
+	if m.MatchUnset {
+		n += 2
+	}
 	return n
 }
 
@@ -6883,6 +6958,12 @@ func (m *SelectRequest) Size() (n int) {
 		l = m.Timestamp.Size()
 		n += 1 + l + sovKvrpcpb(uint64(l))
 	}
+	if m.Reverse {
+		n += 2
+	}
+	if m.SnapshotRead {
+		n += 2
+	}
 	return n
 }
 
@@ -7000,6 +7081,12 @@ func (m *InsertRequest) Size() (n int) {
 		l = m.Timestamp.Size()
 		n += 1 + l + sovKvrpcpb(uint64(l))
 	}
+	if len(m.ExpectedValues) > 0 {
+		for _, b := range m.ExpectedValues {
+			l = len(b)
+			n += 1 + l + sovKvrpcpb(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -10511,6 +10598,26 @@ func (m *Match) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MatchUnset", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKvrpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MatchUnset = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKvrpcpb(dAtA[iNdEx:])
@@ -10988,6 +11095,46 @@ func (m *SelectRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reverse", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKvrpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reverse = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotRead", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKvrpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SnapshotRead = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKvrpcpb(dAtA[iNdEx:])
@@ -11894,6 +12041,35 @@ func (m *InsertRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedValues", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKvrpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKvrpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpectedValues = append(m.ExpectedValues, make([]byte, postIndex-iNdEx))
+			copy(m.ExpectedValues[len(m.ExpectedValues)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKvrpcpb(dAtA[iNdEx:])
@@ -63,6 +63,8 @@ const (
 	NodeState_N_Upgrade NodeState = 5
 	// 初始状态
 	NodeState_N_Initial NodeState = 6
+	// 计划下线，节点继续服务，但不分配新的range，逐步迁出leader和range
+	NodeState_N_Draining NodeState = 7
 )
 
 var NodeState_name = map[int32]string{
@@ -73,6 +75,7 @@ var NodeState_name = map[int32]string{
 	4: "N_Tombstone",
 	5: "N_Upgrade",
 	6: "N_Initial",
+	7: "N_Draining",
 }
 var NodeState_value = map[string]int32{
 	"N_Invalid":   0,
@@ -82,6 +85,7 @@ var NodeState_value = map[string]int32{
 	"N_Tombstone": 4,
 	"N_Upgrade":   5,
 	"N_Initial":   6,
+	"N_Draining":  7,
 }
 
 func (x NodeState) String() string {
@@ -375,6 +379,8 @@ type Peer struct {
 	Id     uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	NodeId uint64   `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	Type   PeerType `protobuf:"varint,3,opt,name=type,proto3,enum=metapb.PeerType" json:"type,omitempty"`
+	// 不提升为投票成员的learner, 如分析用的副本
+	KeepLearner bool `protobuf:"varint,4,opt,name=keep_learner,json=keepLearner,proto3" json:"keep_learner,omitempty"`
 }
 
 func (m *Peer) Reset()                    { *m = Peer{} }
@@ -403,6 +409,13 @@ func (m *Peer) GetType() PeerType {
 	return PeerType_PeerType_Invalid
 }
 
+func (m *Peer) GetKeepLearner() bool {
+	if m != nil {
+		return m.KeepLearner
+	}
+	return false
+}
+
 type PeerStatus struct {
 	Peer         *Peer  `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
 	Index        uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
@@ -1151,6 +1164,16 @@ func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.Type))
 	}
+	if m.KeepLearner {
+		dAtA[i] = 0x20
+		i++
+		if m.KeepLearner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -1831,6 +1854,9 @@ func (m *Peer) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovMetapb(uint64(m.Type))
 	}
+	if m.KeepLearner {
+		n += 2
+	}
 	return n
 }
 
@@ -2638,6 +2664,26 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepLearner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.KeepLearner = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
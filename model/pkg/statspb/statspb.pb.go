@@ -31,6 +31,8 @@
 		SqlSlow
 		SqlTp
 		RangeInfo
+		StatementDigest
+		StatementStats
 */
 package statspb
 
@@ -1543,6 +1545,140 @@ func (m *RangeInfo) GetStats() *mspb.RangeStats {
 	return nil
 }
 
+type StatementDigest struct {
+	Digest         string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Db             string   `protobuf:"bytes,2,opt,name=db,proto3" json:"db,omitempty"`
+	Text           string   `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Sample         string   `protobuf:"bytes,4,opt,name=sample,proto3" json:"sample,omitempty"`
+	Count          uint64   `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Errors         uint64   `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`
+	SumLatency     uint64   `protobuf:"varint,7,opt,name=sum_latency,json=sumLatency,proto3" json:"sum_latency,omitempty"`
+	MaxLatency     uint64   `protobuf:"varint,8,opt,name=max_latency,json=maxLatency,proto3" json:"max_latency,omitempty"`
+	MinLatency     uint64   `protobuf:"varint,9,opt,name=min_latency,json=minLatency,proto3" json:"min_latency,omitempty"`
+	LatencyBuckets []uint64 `protobuf:"varint,10,rep,packed,name=latency_buckets,json=latencyBuckets" json:"latency_buckets,omitempty"`
+	RowsExamined   uint64   `protobuf:"varint,11,opt,name=rows_examined,json=rowsExamined,proto3" json:"rows_examined,omitempty"`
+	RowsReturned   uint64   `protobuf:"varint,12,opt,name=rows_returned,json=rowsReturned,proto3" json:"rows_returned,omitempty"`
+	FirstSeen      int64    `protobuf:"varint,13,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen       int64    `protobuf:"varint,14,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+}
+
+func (m *StatementDigest) Reset()         { *m = StatementDigest{} }
+func (m *StatementDigest) String() string { return proto.CompactTextString(m) }
+func (*StatementDigest) ProtoMessage()    {}
+
+func (m *StatementDigest) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *StatementDigest) GetDb() string {
+	if m != nil {
+		return m.Db
+	}
+	return ""
+}
+
+func (m *StatementDigest) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *StatementDigest) GetSample() string {
+	if m != nil {
+		return m.Sample
+	}
+	return ""
+}
+
+func (m *StatementDigest) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetSumLatency() uint64 {
+	if m != nil {
+		return m.SumLatency
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetMaxLatency() uint64 {
+	if m != nil {
+		return m.MaxLatency
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetMinLatency() uint64 {
+	if m != nil {
+		return m.MinLatency
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetLatencyBuckets() []uint64 {
+	if m != nil {
+		return m.LatencyBuckets
+	}
+	return nil
+}
+
+func (m *StatementDigest) GetRowsExamined() uint64 {
+	if m != nil {
+		return m.RowsExamined
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetRowsReturned() uint64 {
+	if m != nil {
+		return m.RowsReturned
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetFirstSeen() int64 {
+	if m != nil {
+		return m.FirstSeen
+	}
+	return 0
+}
+
+func (m *StatementDigest) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+type StatementStats struct {
+	Digests []*StatementDigest `protobuf:"bytes,1,rep,name=digests" json:"digests,omitempty"`
+}
+
+func (m *StatementStats) Reset()         { *m = StatementStats{} }
+func (m *StatementStats) String() string { return proto.CompactTextString(m) }
+func (*StatementStats) ProtoMessage()    {}
+
+func (m *StatementStats) GetDigests() []*StatementDigest {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ClusterStats)(nil), "statspb.ClusterStats")
 	proto.RegisterType((*DatabaseStats)(nil), "statspb.DatabaseStats")
@@ -1567,6 +1703,8 @@ func init() {
 	proto.RegisterType((*SqlSlow)(nil), "statspb.SqlSlow")
 	proto.RegisterType((*SqlTp)(nil), "statspb.SqlTp")
 	proto.RegisterType((*RangeInfo)(nil), "statspb.RangeInfo")
+	proto.RegisterType((*StatementDigest)(nil), "statspb.StatementDigest")
+	proto.RegisterType((*StatementStats)(nil), "statspb.StatementStats")
 	proto.RegisterEnum("statspb.TpArgs", TpArgs_name, TpArgs_value)
 }
 func (m *ClusterStats) Marshal() (dAtA []byte, err error) {
@@ -2902,6 +3040,140 @@ func (m *RangeInfo) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *StatementDigest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatementDigest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Digest) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(len(m.Digest)))
+		i += copy(dAtA[i:], m.Digest)
+	}
+	if len(m.Db) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(len(m.Db)))
+		i += copy(dAtA[i:], m.Db)
+	}
+	if len(m.Text) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(len(m.Text)))
+		i += copy(dAtA[i:], m.Text)
+	}
+	if len(m.Sample) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(len(m.Sample)))
+		i += copy(dAtA[i:], m.Sample)
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.Count))
+	}
+	if m.Errors != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.Errors))
+	}
+	if m.SumLatency != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.SumLatency))
+	}
+	if m.MaxLatency != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.MaxLatency))
+	}
+	if m.MinLatency != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.MinLatency))
+	}
+	if len(m.LatencyBuckets) > 0 {
+		dAtA36 := make([]byte, len(m.LatencyBuckets)*10)
+		var j35 int
+		for _, num := range m.LatencyBuckets {
+			for num >= 1<<7 {
+				dAtA36[j35] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j35++
+			}
+			dAtA36[j35] = uint8(num)
+			j35++
+		}
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(j35))
+		i += copy(dAtA[i:], dAtA36[:j35])
+	}
+	if m.RowsExamined != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.RowsExamined))
+	}
+	if m.RowsReturned != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.RowsReturned))
+	}
+	if m.FirstSeen != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.FirstSeen))
+	}
+	if m.LastSeen != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintStatspb(dAtA, i, uint64(m.LastSeen))
+	}
+	return i, nil
+}
+
+func (m *StatementStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatementStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Digests) > 0 {
+		for _, msg := range m.Digests {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintStatspb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func encodeVarintStatspb(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -3560,6 +3832,74 @@ func (m *RangeInfo) Size() (n int) {
 	return n
 }
 
+func (m *StatementDigest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Digest)
+	if l > 0 {
+		n += 1 + l + sovStatspb(uint64(l))
+	}
+	l = len(m.Db)
+	if l > 0 {
+		n += 1 + l + sovStatspb(uint64(l))
+	}
+	l = len(m.Text)
+	if l > 0 {
+		n += 1 + l + sovStatspb(uint64(l))
+	}
+	l = len(m.Sample)
+	if l > 0 {
+		n += 1 + l + sovStatspb(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovStatspb(uint64(m.Count))
+	}
+	if m.Errors != 0 {
+		n += 1 + sovStatspb(uint64(m.Errors))
+	}
+	if m.SumLatency != 0 {
+		n += 1 + sovStatspb(uint64(m.SumLatency))
+	}
+	if m.MaxLatency != 0 {
+		n += 1 + sovStatspb(uint64(m.MaxLatency))
+	}
+	if m.MinLatency != 0 {
+		n += 1 + sovStatspb(uint64(m.MinLatency))
+	}
+	if len(m.LatencyBuckets) > 0 {
+		l = 0
+		for _, e := range m.LatencyBuckets {
+			l += sovStatspb(uint64(e))
+		}
+		n += 1 + sovStatspb(uint64(l)) + l
+	}
+	if m.RowsExamined != 0 {
+		n += 1 + sovStatspb(uint64(m.RowsExamined))
+	}
+	if m.RowsReturned != 0 {
+		n += 1 + sovStatspb(uint64(m.RowsReturned))
+	}
+	if m.FirstSeen != 0 {
+		n += 1 + sovStatspb(uint64(m.FirstSeen))
+	}
+	if m.LastSeen != 0 {
+		n += 1 + sovStatspb(uint64(m.LastSeen))
+	}
+	return n
+}
+
+func (m *StatementStats) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Digests) > 0 {
+		for _, e := range m.Digests {
+			l = e.Size()
+			n += 1 + l + sovStatspb(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovStatspb(x uint64) (n int) {
 	for {
 		n++
@@ -7908,6 +8248,488 @@ func (m *RangeInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *StatementDigest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatementDigest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatementDigest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digest = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Db", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Db = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Text = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sample", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sample = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			m.Errors = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Errors |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SumLatency", wireType)
+			}
+			m.SumLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SumLatency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLatency", wireType)
+			}
+			m.MaxLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxLatency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLatency", wireType)
+			}
+			m.MinLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinLatency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStatspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.LatencyBuckets = append(m.LatencyBuckets, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStatspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthStatspb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStatspb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.LatencyBuckets = append(m.LatencyBuckets, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatencyBuckets", wireType)
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowsExamined", wireType)
+			}
+			m.RowsExamined = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RowsExamined |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowsReturned", wireType)
+			}
+			m.RowsReturned = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RowsReturned |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstSeen", wireType)
+			}
+			m.FirstSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstSeen |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+			}
+			m.LastSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeen |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *StatementStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStatspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatementStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatementStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStatspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digests = append(m.Digests, &StatementDigest{})
+			if err := m.Digests[len(m.Digests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStatspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStatspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func skipStatspb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
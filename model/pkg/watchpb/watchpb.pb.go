@@ -31,6 +31,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 import kvrpcpb "model/pkg/kvrpcpb"
+import timestamp "model/pkg/timestamp"
 
 import io "io"
 
@@ -121,6 +122,12 @@ type Event struct {
 	// A DELETE/EXPIRE event contains the deleted key with
 	// its modification revision set to the revision of deletion.
 	Kv *WatchKeyValue `protobuf:"bytes,2,opt,name=kv" json:"kv,omitempty"`
+	// prevKv holds the KeyValue before the event, it is set only by the
+	// row watch of sql tables, which is a prefix watch without keys.
+	// kv.key[0] is the encoded row key and kv.version is the raft index.
+	PrevKv *WatchKeyValue `protobuf:"bytes,3,opt,name=prevKv" json:"prevKv,omitempty"`
+	// commitTs is the timestamp of the write request, set by the row watch.
+	CommitTs *timestamp.Timestamp `protobuf:"bytes,4,opt,name=commitTs" json:"commitTs,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -142,6 +149,20 @@ func (m *Event) GetKv() *WatchKeyValue {
 	return nil
 }
 
+func (m *Event) GetPrevKv() *WatchKeyValue {
+	if m != nil {
+		return m.PrevKv
+	}
+	return nil
+}
+
+func (m *Event) GetCommitTs() *timestamp.Timestamp {
+	if m != nil {
+		return m.CommitTs
+	}
+	return nil
+}
+
 // simple key
 type WatchKeyValue struct {
 	TableId int64 `protobuf:"varint,1,opt,name=tableId,proto3" json:"tableId,omitempty"`
@@ -656,6 +677,26 @@ func (m *Event) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n1
 	}
+	if m.PrevKv != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintWatchpb(dAtA, i, uint64(m.PrevKv.Size()))
+		n2, err := m.PrevKv.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.CommitTs != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintWatchpb(dAtA, i, uint64(m.CommitTs.Size()))
+		n3, err := m.CommitTs.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
 	return i, nil
 }
 
@@ -731,21 +772,21 @@ func (m *DsWatchRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n2, err := m.Header.MarshalTo(dAtA[i:])
+		n4, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n2
+		i += n4
 	}
 	if m.Req != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Req.Size()))
-		n3, err := m.Req.MarshalTo(dAtA[i:])
+		n5, err := m.Req.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n5
 	}
 	return i, nil
 }
@@ -769,11 +810,11 @@ func (m *WatchCreateRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Kv.Size()))
-		n4, err := m.Kv.MarshalTo(dAtA[i:])
+		n6, err := m.Kv.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n6
 	}
 	if m.StartVersion != 0 {
 		dAtA[i] = 0x10
@@ -781,21 +822,21 @@ func (m *WatchCreateRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.StartVersion))
 	}
 	if len(m.Filters) > 0 {
-		dAtA6 := make([]byte, len(m.Filters)*10)
-		var j5 int
+		dAtA8 := make([]byte, len(m.Filters)*10)
+		var j7 int
 		for _, num := range m.Filters {
 			for num >= 1<<7 {
-				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j5++
+				j7++
 			}
-			dAtA6[j5] = uint8(num)
-			j5++
+			dAtA8[j7] = uint8(num)
+			j7++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintWatchpb(dAtA, i, uint64(j5))
-		i += copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintWatchpb(dAtA, i, uint64(j7))
+		i += copy(dAtA[i:], dAtA8[:j7])
 	}
 	if m.WatchId != 0 {
 		dAtA[i] = 0x20
@@ -844,21 +885,21 @@ func (m *DsWatchResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n7, err := m.Header.MarshalTo(dAtA[i:])
+		n9, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n9
 	}
 	if m.Resp != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Resp.Size()))
-		n8, err := m.Resp.MarshalTo(dAtA[i:])
+		n10, err := m.Resp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n10
 	}
 	return i, nil
 }
@@ -927,21 +968,21 @@ func (m *DsKvWatchPutRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n9, err := m.Header.MarshalTo(dAtA[i:])
+		n11, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n11
 	}
 	if m.Req != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Req.Size()))
-		n10, err := m.Req.MarshalTo(dAtA[i:])
+		n12, err := m.Req.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n12
 	}
 	return i, nil
 }
@@ -965,21 +1006,21 @@ func (m *DsKvWatchPutResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n11, err := m.Header.MarshalTo(dAtA[i:])
+		n13, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n13
 	}
 	if m.Resp != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Resp.Size()))
-		n12, err := m.Resp.MarshalTo(dAtA[i:])
+		n14, err := m.Resp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n14
 	}
 	return i, nil
 }
@@ -1003,11 +1044,11 @@ func (m *KvWatchPutRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Kv.Size()))
-		n13, err := m.Kv.MarshalTo(dAtA[i:])
+		n15, err := m.Kv.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n15
 	}
 	return i, nil
 }
@@ -1031,21 +1072,21 @@ func (m *DsKvWatchDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n14, err := m.Header.MarshalTo(dAtA[i:])
+		n16, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n16
 	}
 	if m.Req != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Req.Size()))
-		n15, err := m.Req.MarshalTo(dAtA[i:])
+		n17, err := m.Req.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n17
 	}
 	return i, nil
 }
@@ -1069,21 +1110,21 @@ func (m *DsKvWatchDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n16, err := m.Header.MarshalTo(dAtA[i:])
+		n18, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n18
 	}
 	if m.Resp != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Resp.Size()))
-		n17, err := m.Resp.MarshalTo(dAtA[i:])
+		n19, err := m.Resp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n19
 	}
 	return i, nil
 }
@@ -1107,11 +1148,11 @@ func (m *KvWatchDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Kv.Size()))
-		n18, err := m.Kv.MarshalTo(dAtA[i:])
+		n20, err := m.Kv.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n20
 	}
 	if m.Prefix {
 		dAtA[i] = 0x10
@@ -1168,21 +1209,21 @@ func (m *DsKvWatchGetMultiRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n19, err := m.Header.MarshalTo(dAtA[i:])
+		n21, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n21
 	}
 	if m.Kv != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Kv.Size()))
-		n20, err := m.Kv.MarshalTo(dAtA[i:])
+		n22, err := m.Kv.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n22
 	}
 	if m.Prefix {
 		dAtA[i] = 0x18
@@ -1221,11 +1262,11 @@ func (m *DsKvWatchGetMultiResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintWatchpb(dAtA, i, uint64(m.Header.Size()))
-		n21, err := m.Header.MarshalTo(dAtA[i:])
+		n23, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n23
 	}
 	if m.Code != 0 {
 		dAtA[i] = 0x10
@@ -1266,6 +1307,14 @@ func (m *Event) Size() (n int) {
 		l = m.Kv.Size()
 		n += 1 + l + sovWatchpb(uint64(l))
 	}
+	if m.PrevKv != nil {
+		l = m.PrevKv.Size()
+		n += 1 + l + sovWatchpb(uint64(l))
+	}
+	if m.CommitTs != nil {
+		l = m.CommitTs.Size()
+		n += 1 + l + sovWatchpb(uint64(l))
+	}
 	return n
 }
 
@@ -1600,6 +1649,72 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevKv", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatchpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWatchpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PrevKv == nil {
+				m.PrevKv = &WatchKeyValue{}
+			}
+			if err := m.PrevKv.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWatchpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWatchpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CommitTs == nil {
+				m.CommitTs = &timestamp.Timestamp{}
+			}
+			if err := m.CommitTs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWatchpb(dAtA[iNdEx:])
@@ -3545,54 +3660,56 @@ var (
 func init() { proto.RegisterFile("watchpb.proto", fileDescriptorWatchpb) }
 
 var fileDescriptorWatchpb = []byte{
-	// 776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x95, 0xcd, 0x4e, 0xdb, 0x4a,
-	0x14, 0xc7, 0x99, 0x38, 0x1f, 0x70, 0x48, 0x42, 0x18, 0x22, 0xf0, 0xcd, 0xd5, 0x8d, 0x7c, 0x2d,
-	0x81, 0xac, 0x08, 0x8c, 0x6e, 0x58, 0xde, 0x15, 0x6d, 0xd2, 0x16, 0x41, 0x21, 0x1a, 0x28, 0x2c,
-	0xab, 0x90, 0x0c, 0xc1, 0x8a, 0x13, 0x1b, 0x7b, 0x62, 0x12, 0xf5, 0x0d, 0xfa, 0x04, 0xed, 0xae,
-	0x8f, 0xd3, 0x65, 0x1f, 0xa1, 0xa2, 0x4f, 0xd1, 0x5d, 0x35, 0xe3, 0x8f, 0xd8, 0x09, 0x55, 0x69,
-	0x16, 0x5d, 0xc5, 0x67, 0xce, 0x7f, 0xce, 0xc7, 0x2f, 0x67, 0x66, 0xa0, 0x70, 0xdf, 0x66, 0x9d,
-	0x5b, 0xfb, 0x5a, 0xb7, 0x1d, 0x8b, 0x59, 0x38, 0x17, 0x98, 0x95, 0x72, 0xcf, 0xea, 0x59, 0x62,
-	0x6d, 0x9f, 0x7f, 0xf9, 0xee, 0x4a, 0xa1, 0xef, 0x39, 0x76, 0x27, 0x54, 0xab, 0x57, 0x90, 0x69,
-	0x7a, 0x74, 0xc8, 0xf0, 0x0e, 0xa4, 0xd9, 0xc4, 0xa6, 0x32, 0x52, 0x90, 0x56, 0xac, 0x63, 0x3d,
-	0x0c, 0x2a, 0xbc, 0x17, 0x13, 0x9b, 0x12, 0xe1, 0xc7, 0x3b, 0x90, 0xea, 0x7b, 0x72, 0x4a, 0x41,
-	0xda, 0x6a, 0x7d, 0x33, 0x52, 0x5d, 0xf1, 0xdf, 0x63, 0x3a, 0xb9, 0x6c, 0x9b, 0x23, 0x4a, 0x52,
-	0x7d, 0x4f, 0xfd, 0x88, 0xa0, 0x90, 0x58, 0xc5, 0x32, 0xe4, 0x58, 0xfb, 0xda, 0xa4, 0x47, 0x5d,
-	0x91, 0x44, 0x22, 0xa1, 0x89, 0x4b, 0x20, 0xf5, 0xe9, 0x44, 0x4e, 0x29, 0x92, 0x96, 0x27, 0xfc,
-	0x93, 0x6b, 0x3d, 0xea, 0xb8, 0x86, 0x35, 0x94, 0x25, 0x5f, 0x1b, 0x98, 0xb8, 0x0c, 0x19, 0x8f,
-	0x87, 0x93, 0xd3, 0x0a, 0xd2, 0xf2, 0xc4, 0x37, 0x70, 0x05, 0x96, 0xe9, 0xd8, 0x36, 0x1c, 0x7a,
-	0xc8, 0xe4, 0x8c, 0x82, 0xb4, 0x34, 0x89, 0x6c, 0x1e, 0x9d, 0x8e, 0x99, 0x9c, 0x15, 0x7a, 0xfe,
-	0xa9, 0x5a, 0x50, 0x6c, 0xb8, 0xa2, 0x38, 0x42, 0xef, 0x46, 0xd4, 0x65, 0x58, 0x87, 0xec, 0x2d,
-	0x6d, 0x77, 0xa9, 0x23, 0x4a, 0xe3, 0x9d, 0x85, 0x98, 0x02, 0xc5, 0x2b, 0xe1, 0x25, 0x81, 0x0a,
-	0xef, 0x81, 0xe4, 0xd0, 0xbb, 0x00, 0xc3, 0xdf, 0x49, 0x0c, 0xcf, 0x1d, 0xda, 0x66, 0x34, 0xd8,
-	0x47, 0xb8, 0x4e, 0xfd, 0x8e, 0x00, 0xcf, 0xfb, 0x02, 0x96, 0xe8, 0x57, 0x2c, 0xb1, 0x0a, 0x79,
-	0x97, 0xb5, 0x1d, 0x76, 0x19, 0x20, 0x49, 0x09, 0x24, 0x89, 0x35, 0xbc, 0x07, 0xb9, 0x1b, 0xc3,
-	0x64, 0xd4, 0x71, 0x65, 0x49, 0x91, 0xb4, 0x62, 0x7d, 0x23, 0x0a, 0xf8, 0x42, 0xac, 0x8b, 0xff,
-	0x30, 0xd4, 0x70, 0xc0, 0xc2, 0x7d, 0xd4, 0x15, 0x20, 0x25, 0x12, 0x9a, 0x73, 0x28, 0xa5, 0x18,
-	0xca, 0x4d, 0xc8, 0xda, 0x0e, 0xbd, 0x31, 0xc6, 0x82, 0xe6, 0x32, 0x09, 0x2c, 0xbe, 0xc7, 0xb4,
-	0x86, 0xbd, 0xd6, 0xc8, 0x34, 0xe5, 0x9c, 0xbf, 0x27, 0xb4, 0xd5, 0x21, 0xac, 0x45, 0xb0, 0x5d,
-	0xdb, 0x1a, 0xba, 0x14, 0xef, 0xcf, 0xd0, 0xde, 0x8a, 0xd1, 0xf6, 0x25, 0x33, 0xb8, 0x6b, 0x90,
-	0x76, 0xa8, 0x6b, 0x3f, 0x3e, 0x76, 0xe1, 0x1e, 0x22, 0x34, 0xea, 0xbb, 0x60, 0xee, 0xa2, 0x6c,
-	0xb1, 0x56, 0x51, 0xb2, 0x55, 0x0c, 0xe9, 0x8e, 0xd5, 0xa5, 0x62, 0xc4, 0x32, 0x44, 0x7c, 0xf3,
-	0xf9, 0x72, 0x3b, 0x96, 0x4d, 0x45, 0x87, 0x19, 0xe2, 0x1b, 0x78, 0x07, 0xb2, 0x94, 0x1f, 0x04,
-	0x57, 0x5e, 0x51, 0x24, 0x6d, 0xb5, 0x5e, 0x4c, 0x9e, 0x0f, 0x12, 0x78, 0x55, 0x17, 0x36, 0x1a,
-	0xee, 0xb1, 0x27, 0x0a, 0x68, 0x8d, 0xd8, 0xa2, 0xe3, 0xb5, 0x1b, 0x1f, 0xaf, 0x4a, 0x94, 0x6b,
-	0x2e, 0xb0, 0x3f, 0x5d, 0x23, 0x28, 0x27, 0x93, 0x2e, 0x8a, 0x79, 0x37, 0x81, 0x59, 0x9e, 0xcd,
-	0x3b, 0x03, 0xfa, 0x7f, 0x58, 0x9f, 0xef, 0xf4, 0x89, 0x23, 0xad, 0x4e, 0x60, 0x33, 0xaa, 0xb9,
-	0x41, 0x4d, 0x3a, 0x3d, 0x14, 0xbf, 0xcb, 0x6a, 0x3f, 0xce, 0xea, 0x9f, 0xd9, 0x9a, 0x13, 0xb1,
-	0x7d, 0x5c, 0x63, 0xd8, 0x9a, 0x4b, 0xfd, 0x67, 0x88, 0x5d, 0x42, 0xf9, 0xd1, 0x96, 0x9f, 0x7a,
-	0x0f, 0x4c, 0x8f, 0x5f, 0x2a, 0x7e, 0xfc, 0xd4, 0x6d, 0x58, 0x9b, 0x49, 0x18, 0x8d, 0x36, 0x9a,
-	0x8e, 0xb6, 0xfa, 0x09, 0x81, 0x1c, 0x75, 0xfe, 0x92, 0xb2, 0xd7, 0x23, 0x93, 0x19, 0x8b, 0x62,
-	0x7f, 0xe2, 0x3b, 0x10, 0xab, 0x59, 0x4a, 0x5c, 0x19, 0x65, 0xc8, 0x98, 0xc6, 0xc0, 0x60, 0xe2,
-	0xfa, 0x29, 0x10, 0xdf, 0x50, 0xdf, 0x23, 0xf8, 0xeb, 0x91, 0x12, 0x17, 0xfd, 0x7b, 0x42, 0x0a,
-	0xa9, 0xd8, 0x01, 0xd7, 0x40, 0xea, 0x7b, 0xfe, 0x25, 0xf9, 0xf3, 0xca, 0xb9, 0xa4, 0xa6, 0xc0,
-	0x4a, 0xf4, 0xfa, 0xe1, 0x1c, 0x48, 0xad, 0x37, 0x17, 0xa5, 0x25, 0x0c, 0x90, 0x6d, 0x34, 0x4f,
-	0x9a, 0x17, 0xcd, 0x12, 0xaa, 0xfd, 0x07, 0x70, 0xce, 0xef, 0x07, 0xff, 0x81, 0x5b, 0x87, 0x02,
-	0x69, 0x9e, 0xb7, 0xce, 0x4e, 0xcf, 0x9b, 0x6f, 0x5b, 0x87, 0x84, 0x8b, 0x4b, 0x90, 0x8f, 0x96,
-	0x0e, 0x4f, 0x4e, 0x4a, 0xa8, 0xb6, 0x0d, 0x30, 0xbd, 0x8f, 0xf1, 0x0a, 0x64, 0x4e, 0xcf, 0xfc,
-	0xb8, 0x79, 0x58, 0x3e, 0x3d, 0x0b, 0x23, 0x3f, 0x3b, 0xf8, 0xfc, 0x50, 0x45, 0x5f, 0x1e, 0xaa,
-	0xe8, 0xeb, 0x43, 0x15, 0x7d, 0xf8, 0x56, 0x5d, 0x82, 0x7f, 0x3b, 0xd6, 0x40, 0x67, 0x46, 0x4f,
-	0x77, 0x6f, 0xdb, 0x4e, 0x5f, 0xef, 0x58, 0x83, 0x81, 0x35, 0xd4, 0x87, 0x94, 0xdd, 0x5b, 0x4e,
-	0x5f, 0xef, 0x39, 0x76, 0xe7, 0x3a, 0x2b, 0xde, 0xf4, 0x83, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xd3, 0x63, 0xb1, 0xfa, 0x12, 0x08, 0x00, 0x00,
+	// 811 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x96, 0xdd, 0x6e, 0x12, 0x41,
+	0x14, 0xc7, 0x3b, 0x2c, 0x5f, 0x3d, 0x05, 0x4a, 0xa7, 0xa4, 0x5d, 0x31, 0x12, 0xdc, 0xa4, 0x0d,
+	0x21, 0xed, 0x56, 0xe9, 0xa5, 0x57, 0x55, 0x50, 0x9b, 0xd6, 0x96, 0x4c, 0xb1, 0x5e, 0x9a, 0x2d,
+	0x4c, 0xe9, 0x86, 0x85, 0xdd, 0xee, 0x0e, 0x5b, 0x88, 0x6f, 0xe0, 0x13, 0xe8, 0x9d, 0xaf, 0xe1,
+	0x1b, 0x78, 0xe9, 0x23, 0x98, 0xfa, 0x14, 0xde, 0x99, 0x99, 0xfd, 0x80, 0x85, 0xaa, 0x0d, 0x17,
+	0x5e, 0x31, 0x67, 0xce, 0x7f, 0xce, 0x9c, 0xf3, 0x9b, 0x33, 0xb3, 0x40, 0xf6, 0x46, 0x63, 0xed,
+	0x2b, 0xeb, 0x42, 0xb5, 0x6c, 0x93, 0x99, 0x38, 0xe5, 0x9b, 0xc5, 0x42, 0xd7, 0xec, 0x9a, 0x62,
+	0x6e, 0x8f, 0x8f, 0x3c, 0x77, 0x31, 0xdb, 0x73, 0x6d, 0xab, 0x1d, 0xa8, 0x8b, 0xab, 0x4c, 0xef,
+	0x53, 0x87, 0x69, 0x7d, 0xcb, 0x9b, 0x50, 0xbe, 0x22, 0x48, 0x34, 0x5c, 0x3a, 0x60, 0x78, 0x1b,
+	0xe2, 0x6c, 0x6c, 0x51, 0x19, 0x95, 0x51, 0x25, 0x57, 0xc3, 0x6a, 0xb0, 0x8d, 0xf0, 0xb6, 0xc6,
+	0x16, 0x25, 0xc2, 0x8f, 0xb7, 0x21, 0xd6, 0x73, 0xe5, 0x58, 0x19, 0x55, 0x56, 0x6a, 0x1b, 0xa1,
+	0xea, 0x1d, 0xff, 0x3d, 0xa2, 0xe3, 0x73, 0xcd, 0x18, 0x52, 0x12, 0xeb, 0xb9, 0x58, 0x85, 0xa4,
+	0x65, 0x53, 0xf7, 0xc8, 0x95, 0xa5, 0xbf, 0x6a, 0x7d, 0x15, 0x7e, 0x02, 0xe9, 0xb6, 0xd9, 0xef,
+	0xeb, 0xac, 0xe5, 0xc8, 0x71, 0xb1, 0xa2, 0xa0, 0x4e, 0xb2, 0x6d, 0x05, 0x23, 0x12, 0xaa, 0x94,
+	0xcf, 0x08, 0xb2, 0x91, 0x58, 0x58, 0x86, 0x14, 0xd3, 0x2e, 0x0c, 0x7a, 0xd8, 0x11, 0x65, 0x48,
+	0x24, 0x30, 0x71, 0x1e, 0xa4, 0x1e, 0x1d, 0xcb, 0xb1, 0xb2, 0x54, 0xc9, 0x10, 0x3e, 0xe4, 0x5a,
+	0x97, 0xda, 0x8e, 0x6e, 0x0e, 0x44, 0x82, 0x12, 0x09, 0x4c, 0x5c, 0x80, 0x84, 0xcb, 0xc3, 0x89,
+	0x34, 0x32, 0xc4, 0x33, 0x70, 0x11, 0xd2, 0x74, 0x64, 0xe9, 0x36, 0x3d, 0x60, 0x72, 0xa2, 0x8c,
+	0x2a, 0x71, 0x12, 0xda, 0x3c, 0x3a, 0x1d, 0x31, 0x39, 0x29, 0xf4, 0x7c, 0xa8, 0x98, 0x90, 0xab,
+	0x3b, 0x22, 0x39, 0x42, 0xaf, 0x87, 0xd4, 0x61, 0x9c, 0xc7, 0x15, 0xd5, 0x3a, 0xd4, 0x96, 0x91,
+	0xcf, 0x23, 0x38, 0x1a, 0x5f, 0xf1, 0x5a, 0x78, 0x89, 0xaf, 0xc2, 0xbb, 0x20, 0xd9, 0xf4, 0xda,
+	0x07, 0xfd, 0x30, 0x0a, 0xef, 0x85, 0x4d, 0x35, 0x46, 0xfd, 0x75, 0x84, 0xeb, 0x94, 0x5f, 0x08,
+	0xf0, 0xbc, 0xcf, 0x3f, 0x2d, 0xf4, 0xcf, 0xd3, 0x52, 0x20, 0xe3, 0x30, 0xcd, 0x66, 0xe7, 0x3e,
+	0x92, 0x98, 0x40, 0x12, 0x99, 0xc3, 0xbb, 0x90, 0xba, 0xd4, 0x0d, 0x46, 0x6d, 0x47, 0x96, 0xca,
+	0x52, 0x25, 0x57, 0x5b, 0x0f, 0x03, 0xbe, 0x14, 0xf3, 0xa2, 0x4b, 0x02, 0x0d, 0x07, 0x2c, 0xdc,
+	0x87, 0x1d, 0x01, 0x52, 0x22, 0x81, 0x39, 0x87, 0x52, 0x9a, 0x42, 0xb9, 0x21, 0xda, 0xe6, 0x52,
+	0x1f, 0x09, 0x9a, 0x69, 0xe2, 0x5b, 0x7c, 0x8d, 0x61, 0x0e, 0xba, 0xcd, 0xa1, 0x61, 0xc8, 0x29,
+	0x6f, 0x4d, 0x60, 0x2b, 0x03, 0x58, 0x0d, 0x61, 0x3b, 0x96, 0x39, 0x70, 0x28, 0xde, 0x9b, 0xa1,
+	0xbd, 0x39, 0x45, 0xdb, 0x93, 0xcc, 0xe0, 0xae, 0x42, 0xdc, 0xa6, 0x8e, 0x75, 0x77, 0x63, 0x07,
+	0x6b, 0x88, 0xd0, 0x28, 0x1f, 0x20, 0x1b, 0x99, 0x9e, 0x2e, 0x15, 0x45, 0x4b, 0xc5, 0x10, 0x6f,
+	0x9b, 0x1d, 0x2a, 0x5a, 0x2c, 0x41, 0xc4, 0x98, 0xf7, 0x97, 0xd3, 0x36, 0x2d, 0x2a, 0x2a, 0x4c,
+	0x10, 0xcf, 0xc0, 0xdb, 0x90, 0xa4, 0xfc, 0xaa, 0x39, 0xf2, 0x72, 0x59, 0xaa, 0xac, 0xd4, 0x72,
+	0xd1, 0x1b, 0x48, 0x7c, 0xaf, 0xe2, 0xc0, 0x7a, 0xdd, 0x39, 0x72, 0x45, 0x02, 0xcd, 0x21, 0x5b,
+	0xb4, 0xbd, 0x76, 0xa6, 0xdb, 0xab, 0x18, 0xee, 0x35, 0x17, 0xd8, 0xeb, 0xae, 0x21, 0x14, 0xa2,
+	0x9b, 0x2e, 0x8a, 0x79, 0x27, 0x82, 0x59, 0x9e, 0xdd, 0x77, 0x06, 0xf4, 0x33, 0x58, 0x9b, 0xaf,
+	0xf4, 0x9e, 0x2d, 0xad, 0x8c, 0x61, 0x23, 0xcc, 0xb9, 0x4e, 0x0d, 0xca, 0xe8, 0xa2, 0xac, 0xf6,
+	0xa6, 0x59, 0x3d, 0x9a, 0xcd, 0x39, 0x12, 0xdb, 0xc3, 0x35, 0x82, 0xcd, 0xb9, 0xad, 0xff, 0x0f,
+	0xb1, 0x73, 0x28, 0xdc, 0x59, 0xf2, 0x7d, 0xdf, 0x81, 0xc9, 0xf5, 0x8b, 0x4d, 0x5f, 0x3f, 0x65,
+	0x0b, 0x56, 0x67, 0x36, 0x0c, 0x5b, 0x1b, 0x4d, 0x5a, 0x5b, 0xf9, 0x82, 0x40, 0x0e, 0x2b, 0x7f,
+	0x45, 0xd9, 0x9b, 0xa1, 0xc1, 0xf4, 0x45, 0xb1, 0xdf, 0xf7, 0x4b, 0x33, 0xc9, 0x59, 0x8a, 0x3c,
+	0x19, 0x05, 0x48, 0x18, 0x7a, 0x5f, 0x67, 0xe2, 0xf9, 0xc9, 0x12, 0xcf, 0x50, 0x3e, 0x22, 0x78,
+	0x70, 0x47, 0x8a, 0x8b, 0x1e, 0x4f, 0x40, 0x21, 0x36, 0x75, 0xc1, 0x2b, 0x20, 0xf5, 0x5c, 0xef,
+	0x91, 0xfc, 0x73, 0xe6, 0x5c, 0x52, 0x2d, 0xc3, 0x72, 0xf8, 0x7d, 0xc5, 0x29, 0x90, 0x9a, 0x6f,
+	0x5b, 0xf9, 0x25, 0x0c, 0x90, 0xac, 0x37, 0x8e, 0x1b, 0xad, 0x46, 0x1e, 0x55, 0x9f, 0x02, 0x9c,
+	0xf1, 0xf7, 0x41, 0x2c, 0xc2, 0x6b, 0x90, 0x25, 0x8d, 0xb3, 0xe6, 0xe9, 0xc9, 0x59, 0xe3, 0x7d,
+	0xf3, 0x80, 0x70, 0x71, 0x1e, 0x32, 0xe1, 0xd4, 0xc1, 0xf1, 0x71, 0x1e, 0x55, 0xb7, 0x00, 0x26,
+	0xef, 0x31, 0x5e, 0x86, 0xc4, 0xc9, 0xa9, 0x17, 0x37, 0x03, 0xe9, 0x93, 0xd3, 0x20, 0xf2, 0xf3,
+	0xfd, 0x6f, 0xb7, 0x25, 0xf4, 0xfd, 0xb6, 0x84, 0x7e, 0xdc, 0x96, 0xd0, 0xa7, 0x9f, 0xa5, 0x25,
+	0x78, 0xdc, 0x36, 0xfb, 0x2a, 0xd3, 0xbb, 0xaa, 0x73, 0xa5, 0xd9, 0x3d, 0x95, 0x7f, 0x68, 0xcd,
+	0x81, 0x3a, 0xa0, 0xec, 0xc6, 0xb4, 0x7b, 0x6a, 0xd7, 0xb6, 0xda, 0x17, 0x49, 0xf1, 0xb7, 0x61,
+	0xff, 0xf7, 0x00, 0xc3, 0xef, 0x58, 0x06, 0x86, 0x08, 0x00, 0x00,
 }
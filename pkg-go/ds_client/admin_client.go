@@ -29,6 +29,9 @@ type AdminClient interface {
 	// ForceSplit force split
 	ForceSplit(addr string, rangeID uint64, version uint64) error
 
+	// SplitAt force split at the key
+	SplitAt(addr string, rangeID uint64, version uint64, splitKey []byte) error
+
 	// ForceCompact force compact
 	ForceCompact(addr string, rangeID uint64, transactionID int64) (*ds_adminpb.CompactionResponse, error)
 
@@ -151,6 +154,18 @@ func (c *adminClient) ForceSplit(addr string, rangeID uint64, version uint64) er
 	return err
 }
 
+// SplitAt force split at the key
+func (c *adminClient) SplitAt(addr string, rangeID uint64, version uint64, splitKey []byte) error {
+	req := c.newRequest(ds_adminpb.AdminType_FORCE_SPLIT)
+	req.ForceSplitReq = &ds_adminpb.ForceSplitRequest{
+		RangeId:  rangeID,
+		Version:  version,
+		SplitKey: splitKey,
+	}
+	_, err := c.send(addr, req)
+	return err
+}
+
 // ForceCompact force compact
 func (c *adminClient) ForceCompact(addr string, rangeID uint64, transID int64) (*ds_adminpb.CompactionResponse, error) {
 	req := c.newRequest(ds_adminpb.AdminType_COMPACTION)
@@ -30,16 +30,25 @@ type Client interface {
 	GetDB(dbName string) (*metapb.DataBase, error)
 	GetTable(dbName, tableName string) (*metapb.Table, error)
 	GetTableById(dbId, tableId uint64) (*metapb.Table, error)
+	// 列举所有的库和库下可用的表
+	GetDBs() ([]*metapb.DataBase, error)
+	GetTables(dbName string) ([]*metapb.Table, error)
 	GetColumns(dbId, tableId uint64) ([]*metapb.Column, error)
 	GetColumnByName(dbId, tableId uint64, columnName string) (*metapb.Column, error)
 	GetColumnByID(dbId, tableId uint64, columnId uint64) (*metapb.Column, error)
 	// columns输入参数只需要填写name和data type即可
 	// 返回master server处理后的columns列表(本次新增部分)
 	AddColumns(dbId, tableId uint64, columns []*metapb.Column) ([]*metapb.Column, error)
+	// 修改列(删除, 重命名, 修改默认值, 类型扩展), 返回修改后的table
+	AlterColumn(req *mspb.AlterColumnRequest) (*metapb.Table, error)
 	TruncateTable(dbId, tableId uint64) error
 	CreateDatabase(dbName string) error
 	CreateTable(dbName, tableName, properties string) error
 	GetAutoIncId(dbId, tableId uint64, size uint32) ([]uint64, error)
+	// 在splitKeys(带表前缀)处分裂表的range, 分裂是异步的, 返回还不是range起始key的个数
+	SplitTable(dbId, tableId uint64, splitKeys [][]byte) (uint32, error)
+	// gateway的资源组, gateway定期拉取
+	GetResourceGroups() ([]*mspb.ResourceGroup, error)
 
 	NodeHeartbeat(*mspb.NodeHeartbeatRequest) (*mspb.NodeHeartbeatResponse, error)
 	RangeHeartbeat(*mspb.RangeHeartbeatRequest) (*mspb.RangeHeartbeatResponse, error)
@@ -173,6 +182,41 @@ func (c *RPCClient) GetTableById(dbId uint64, tableId uint64) (*metapb.Table, er
 	return nil, errInvalidResponse
 }
 
+func (c *RPCClient) GetDBs() ([]*metapb.DataBase, error) {
+	req := &mspb.GetDBsRequest{
+		Header: &mspb.RequestHeader{},
+	}
+	resp, err := c.callRPC(req, RequestMSTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errInvalidResponse
+	}
+	if _resp, ok := resp.(*mspb.GetDBsResponse); ok {
+		return _resp.GetDbs(), nil
+	}
+	return nil, errInvalidResponse
+}
+
+func (c *RPCClient) GetTables(dbName string) ([]*metapb.Table, error) {
+	req := &mspb.GetTablesRequest{
+		Header: &mspb.RequestHeader{},
+		DbName: dbName,
+	}
+	resp, err := c.callRPC(req, RequestMSTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errInvalidResponse
+	}
+	if _resp, ok := resp.(*mspb.GetTablesResponse); ok {
+		return _resp.GetTables(), nil
+	}
+	return nil, errInvalidResponse
+}
+
 func (c *RPCClient) GetColumns(dbId, tableId uint64) ([]*metapb.Column, error) {
 	req := &mspb.GetColumnsRequest{
 		Header:  &mspb.RequestHeader{},
@@ -254,6 +298,58 @@ func (c *RPCClient) AddColumns(dbId, tableId uint64, columns []*metapb.Column) (
 	return nil, errInvalidResponse
 }
 
+func (c *RPCClient) AlterColumn(req *mspb.AlterColumnRequest) (*metapb.Table, error) {
+	if req.Header == nil {
+		req.Header = &mspb.RequestHeader{}
+	}
+	resp, err := c.callRPC(req, RequestMSTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errInvalidResponse
+	}
+	if _resp, ok := resp.(*mspb.AlterColumnResponse); ok {
+		return _resp.GetTable(), nil
+	}
+	return nil, errInvalidResponse
+}
+
+func (c *RPCClient) SplitTable(dbId, tableId uint64, splitKeys [][]byte) (uint32, error) {
+	req := &mspb.SplitTableRequest{
+		Header:    &mspb.RequestHeader{},
+		DbId:      dbId,
+		TableId:   tableId,
+		SplitKeys: splitKeys,
+	}
+	resp, err := c.callRPC(req, RequestMSTimeout)
+	if err != nil {
+		return 0, err
+	}
+	if resp == nil {
+		return 0, errInvalidResponse
+	}
+	if _resp, ok := resp.(*mspb.SplitTableResponse); ok {
+		return _resp.GetPending(), nil
+	}
+	return 0, errInvalidResponse
+}
+
+func (c *RPCClient) GetResourceGroups() ([]*mspb.ResourceGroup, error) {
+	req := &mspb.GetResourceGroupsRequest{Header: &mspb.RequestHeader{}}
+	resp, err := c.callRPC(req, RequestMSTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errInvalidResponse
+	}
+	if _resp, ok := resp.(*mspb.GetResourceGroupsResponse); ok {
+		return _resp.GetGroups(), nil
+	}
+	return nil, errInvalidResponse
+}
+
 func (c *RPCClient) NodeHeartbeat(req *mspb.NodeHeartbeatRequest) (*mspb.NodeHeartbeatResponse, error) {
 	resp, err := c.callRPC(req, RequestMSTimeout)
 	if err != nil {
@@ -634,6 +730,81 @@ func (c *RPCClient) callRPC(req interface{}, timeout time.Duration) (resp interf
 			if pbErr == nil {
 				return out, nil
 			}
+		case *mspb.AlterColumnRequest:
+			out, _err := conn.Cli.AlterColumn(ctx, in)
+			cancel()
+			if _err != nil {
+				return nil, errors.New(grpc.ErrorDesc(_err))
+			}
+			header = out.GetHeader()
+			if header == nil {
+				err = errInvalidResponseHeader
+				return
+			}
+			pbErr = header.GetError()
+			if pbErr == nil {
+				return out, nil
+			}
+		case *mspb.GetDBsRequest:
+			out, _err := conn.Cli.GetDBs(ctx, in)
+			cancel()
+			if _err != nil {
+				return nil, errors.New(grpc.ErrorDesc(_err))
+			}
+			header = out.GetHeader()
+			if header == nil {
+				err = errInvalidResponseHeader
+				return
+			}
+			pbErr = header.GetError()
+			if pbErr == nil {
+				return out, nil
+			}
+		case *mspb.GetTablesRequest:
+			out, _err := conn.Cli.GetTables(ctx, in)
+			cancel()
+			if _err != nil {
+				return nil, errors.New(grpc.ErrorDesc(_err))
+			}
+			header = out.GetHeader()
+			if header == nil {
+				err = errInvalidResponseHeader
+				return
+			}
+			pbErr = header.GetError()
+			if pbErr == nil {
+				return out, nil
+			}
+		case *mspb.SplitTableRequest:
+			out, _err := conn.Cli.SplitTable(ctx, in)
+			cancel()
+			if _err != nil {
+				return nil, errors.New(grpc.ErrorDesc(_err))
+			}
+			header = out.GetHeader()
+			if header == nil {
+				err = errInvalidResponseHeader
+				return
+			}
+			pbErr = header.GetError()
+			if pbErr == nil {
+				return out, nil
+			}
+		case *mspb.GetResourceGroupsRequest:
+			out, _err := conn.Cli.GetResourceGroups(ctx, in)
+			cancel()
+			if _err != nil {
+				return nil, errors.New(grpc.ErrorDesc(_err))
+			}
+			header = out.GetHeader()
+			if header == nil {
+				err = errInvalidResponseHeader
+				return
+			}
+			pbErr = header.GetError()
+			if pbErr == nil {
+				return out, nil
+			}
 		case *mspb.CreateTableRequest:
 			out, _err := conn.Cli.CreateTable(ctx, in)
 			cancel()
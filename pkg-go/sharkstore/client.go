@@ -0,0 +1,145 @@
+// Package sharkstore is a client which reads and writes the tables of a
+// sharkstore cluster on the data servers directly, without a gateway. The
+// routes of the tables are loaded from the master and cached, they are
+// refreshed when a range is split or moved.
+package sharkstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"util/hlc"
+	"util/log"
+)
+
+const (
+	DefaultScanBatchSize = 100
+	// maximum total sleep in milliseconds before a request gives up retrying
+	DefaultMaxBackoff = 20000
+	// interval to check the schema of a cached table against the master
+	DefaultTableCheckInterval = time.Minute
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrDuplicateKey = errors.New("duplicate primary key")
+	ErrLockExist    = errors.New("lock exist")
+	ErrLockNotExist = errors.New("lock not exist")
+	ErrNotLockOwner = errors.New("not lock owner")
+)
+
+type Config struct {
+	MasterAddrs []string
+	// rows or keys fetched by one request when scanning
+	ScanBatchSize int
+	// maximum total sleep in milliseconds when a request is retried, the
+	// deadline of the context stops the retries too
+	MaxBackoff int
+	// connections to each data server, 0 is the default of the rpc client
+	PoolSize int
+	// interval to check the epoch of a cached table against the master, the
+	// schema is reloaded when the table is altered
+	TableCheckInterval time.Duration
+}
+
+func (c *Config) validate() error {
+	if len(c.MasterAddrs) == 0 {
+		return errors.New("master addresses are required")
+	}
+	if c.ScanBatchSize <= 0 {
+		c.ScanBatchSize = DefaultScanBatchSize
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	if c.TableCheckInterval <= 0 {
+		c.TableCheckInterval = DefaultTableCheckInterval
+	}
+	return nil
+}
+
+// Client is safe for concurrent use, it should be shared by the goroutines
+// and closed when it is no longer used.
+type Client struct {
+	conf  Config
+	ms    msClient.Client
+	ds    dsClient.KvClient
+	clock *hlc.Clock
+
+	nodeLock sync.RWMutex
+	nodes    map[uint64]string
+
+	lock   sync.Mutex
+	tables map[string]*Table
+}
+
+func NewClient(conf *Config) (*Client, error) {
+	c := &Client{conf: *conf, nodes: make(map[uint64]string), tables: make(map[string]*Table)}
+	if err := c.conf.validate(); err != nil {
+		return nil, err
+	}
+	ms, err := msClient.NewClient(c.conf.MasterAddrs)
+	if err != nil {
+		return nil, err
+	}
+	c.ms = ms
+	if c.conf.PoolSize > 0 {
+		c.ds = dsClient.NewRPCClient(c.conf.PoolSize)
+	} else {
+		c.ds = dsClient.NewRPCClient()
+	}
+	c.clock = hlc.NewClock(hlc.UnixNano, 0)
+	return c, nil
+}
+
+// Table returns the table cached by the client, its schema is checked
+// against the master every TableCheckInterval and reloaded when the epoch of
+// the table changes. A table which is dropped or created again must be got
+// again from the client.
+func (c *Client) Table(db, name string) (*Table, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := db + "." + name
+	if t, find := c.tables[key]; find {
+		return t, nil
+	}
+	meta, err := c.ms.GetTable(db, name)
+	if err != nil {
+		log.Error("get table %s.%s from master failed, err[%v]", db, name, err)
+		return nil, err
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("Table '%s.%s' doesn't exist", db, name)
+	}
+	t := newTable(c, meta)
+	c.tables[key] = t
+	return t, nil
+}
+
+// dropTable removes the table from the cache if it is still cached.
+func (c *Client) dropTable(t *Table) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := t.DbName() + "." + t.Name()
+	if c.tables[key] == t {
+		delete(c.tables, key)
+	}
+}
+
+// KV returns the raw key value view of the table.
+func (c *Client) KV(db, name string) (*KV, error) {
+	t, err := c.Table(db, name)
+	if err != nil {
+		return nil, err
+	}
+	return &KV{t: t}, nil
+}
+
+func (c *Client) Close() {
+	c.ms.Close()
+	c.ds.Close()
+}
@@ -0,0 +1,110 @@
+package sharkstore
+
+import (
+	"bytes"
+
+	"model/pkg/kvrpcpb"
+	"util"
+
+	"golang.org/x/net/context"
+)
+
+// Iterator reads the rows of a scan in primary key order, the rows are
+// fetched from the ranges one batch at a time.
+//
+//	it := t.Scan(ctx, Key{1}, nil)
+//	for it.Next() {
+//		row := it.Row()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator struct {
+	t   *Table
+	s   *schema
+	ctx context.Context
+	// next key to fetch and the exclusive end of the scan
+	key []byte
+	end []byte
+
+	rows []Row
+	pos  int
+	row  Row
+	done bool
+	err  error
+}
+
+// Scan returns the rows from the start key to the end key, the end key is
+// exclusive. both of them may be a prefix of the primary key, a nil start
+// or end is the first or the last row of the table.
+func (t *Table) Scan(ctx context.Context, start, end Key) *Iterator {
+	it := &Iterator{t: t, ctx: ctx}
+	if it.s, it.err = t.schema(); it.err != nil {
+		return it
+	}
+	if it.key, it.err = it.s.encodeKey(start, true); it.err != nil {
+		return it
+	}
+	if len(end) > 0 {
+		it.end, it.err = it.s.encodeKey(end, true)
+	} else {
+		it.end = util.BytesPrefix(t.prefix).Limit
+	}
+	return it
+}
+
+func (it *Iterator) Next() bool {
+	for it.err == nil {
+		if it.pos < len(it.rows) {
+			it.row = it.rows[it.pos]
+			it.pos++
+			return true
+		}
+		if it.done {
+			break
+		}
+		it.err = it.fetch()
+	}
+	it.row = nil
+	return false
+}
+
+func (it *Iterator) fetch() error {
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+	if bytes.Compare(it.key, it.end) >= 0 {
+		it.done = true
+		return nil
+	}
+	batch := it.t.c.conf.ScanBatchSize
+	sreq := &kvrpcpb.SelectRequest{
+		Scope:     &kvrpcpb.Scope{Start: it.key, Limit: it.end},
+		FieldList: it.s.fields,
+		Limit:     &kvrpcpb.Limit{Count: uint64(batch)},
+		Timestamp: it.t.now(),
+	}
+	rows, resp, r, err := it.t.selectRows(it.ctx, it.s, sreq, it.key)
+	if err != nil {
+		return err
+	}
+	it.rows, it.pos = rows, 0
+	if len(rows) >= batch {
+		// the range may have more rows after the last one
+		last := resp.GetRows()[len(rows)-1].GetKey()
+		it.key = append(append([]byte{}, last...), 0)
+	} else if len(r.endKey()) == 0 || bytes.Compare(r.endKey(), it.end) >= 0 {
+		it.done = true
+	} else {
+		it.key = r.endKey()
+	}
+	return nil
+}
+
+func (it *Iterator) Row() Row {
+	return it.row
+}
+
+// Err returns the error which stopped the iteration.
+func (it *Iterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,132 @@
+package sharkstore
+
+import (
+	"bytes"
+	"fmt"
+
+	"model/pkg/errorpb"
+	"model/pkg/kvrpcpb"
+	"pkg-go/ds_client"
+	"util"
+
+	"golang.org/x/net/context"
+)
+
+// KV reads and writes raw keys in the key space of a table, the keys are
+// stored after the prefix of the table. a table should hold either rows or
+// raw keys, because a raw key may be read as a row by a scan.
+type KV struct {
+	t *Table
+}
+
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+func (kv *KV) encodeKey(key []byte) []byte {
+	return append(append([]byte{}, kv.t.prefix...), key...)
+}
+
+func (kv *KV) Put(ctx context.Context, key, value []byte) error {
+	k := kv.encodeKey(key)
+	req := &kvrpcpb.KvSetRequest{
+		Kv:   &kvrpcpb.RedisKeyValue{Key: k, Value: value},
+		Case: kvrpcpb.ExistCase_EC_Force,
+	}
+	var resp *kvrpcpb.DsKvSetResponse
+	_, err := kv.t.do(ctx, k, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+		var err error
+		resp, err = kv.t.c.ds.KvSet(ctx, addr, &kvrpcpb.DsKvSetRequest{Header: header, Req: req})
+		return resp.GetHeader().GetError(), err
+	})
+	if err != nil {
+		return err
+	}
+	if code := resp.GetResp().GetCode(); code != 0 {
+		return fmt.Errorf("put key %q failed, code %d", key, code)
+	}
+	return nil
+}
+
+// Get returns the value of the key, nil if the key doesn't exist.
+func (kv *KV) Get(ctx context.Context, key []byte) ([]byte, error) {
+	k := kv.encodeKey(key)
+	var resp *kvrpcpb.DsKvGetResponse
+	_, err := kv.t.do(ctx, k, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+		var err error
+		resp, err = kv.t.c.ds.KvGet(ctx, addr, &kvrpcpb.DsKvGetRequest{Header: header, Req: &kvrpcpb.KvGetRequest{Key: k}})
+		return resp.GetHeader().GetError(), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetResp().GetValue(), nil
+}
+
+func (kv *KV) Delete(ctx context.Context, key []byte) error {
+	k := kv.encodeKey(key)
+	var resp *kvrpcpb.DsKvDeleteResponse
+	_, err := kv.t.do(ctx, k, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+		var err error
+		resp, err = kv.t.c.ds.KvDelete(ctx, addr, &kvrpcpb.DsKvDeleteRequest{Header: header, Req: &kvrpcpb.KvDeleteRequest{Key: k}})
+		return resp.GetHeader().GetError(), err
+	})
+	if err != nil {
+		return err
+	}
+	if code := resp.GetResp().GetCode(); code != 0 {
+		return fmt.Errorf("delete key %q failed, code %d", key, code)
+	}
+	return nil
+}
+
+// Scan returns at most limit keys from start to end in order, end is
+// exclusive. a nil end is the end of the table and a limit of 0 returns all
+// the keys.
+func (kv *KV) Scan(ctx context.Context, start, end []byte, limit int) ([]*KeyValue, error) {
+	key := kv.encodeKey(start)
+	stop := util.BytesPrefix(kv.t.prefix).Limit
+	if len(end) > 0 {
+		stop = kv.encodeKey(end)
+	}
+	var kvs []*KeyValue
+	for bytes.Compare(key, stop) < 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		batch := kv.t.c.conf.ScanBatchSize
+		if limit > 0 && limit-len(kvs) < batch {
+			batch = limit - len(kvs)
+		}
+		req := &kvrpcpb.KvScanRequest{Start: key, Limit: stop, MaxCount: int64(batch)}
+		var resp *kvrpcpb.DsKvScanResponse
+		r, err := kv.t.do(ctx, key, client.ReadTimeoutMedium, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+			var err error
+			resp, err = kv.t.c.ds.KvScan(ctx, addr, &kvrpcpb.DsKvScanRequest{Header: header, Req: req})
+			return resp.GetHeader().GetError(), err
+		})
+		if err != nil {
+			return nil, err
+		}
+		sresp := resp.GetResp()
+		if sresp.GetCode() != 0 {
+			return nil, fmt.Errorf("scan keys failed, code %d", sresp.GetCode())
+		}
+		for _, p := range sresp.GetKvs() {
+			kvs = append(kvs, &KeyValue{Key: p.GetKey()[len(kv.t.prefix):], Value: p.GetValue()})
+		}
+		if limit > 0 && len(kvs) >= limit {
+			break
+		}
+		if len(sresp.GetKvs()) >= batch {
+			last := sresp.GetKvs()[len(sresp.GetKvs())-1].GetKey()
+			key = append(append([]byte{}, last...), 0)
+		} else if len(r.endKey()) == 0 {
+			break
+		} else {
+			key = r.endKey()
+		}
+	}
+	return kvs, nil
+}
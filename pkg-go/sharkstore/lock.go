@@ -0,0 +1,84 @@
+package sharkstore
+
+import (
+	"fmt"
+	"time"
+
+	"model/pkg/errorpb"
+	"model/pkg/kvrpcpb"
+	"pkg-go/ds_client"
+	"util/encoding"
+
+	"golang.org/x/net/context"
+)
+
+// the codes of the lock responses of the data server
+const (
+	lockOk = iota
+	lockExist
+	lockNotExist
+	lockNotOwner
+)
+
+// lockKey encodes the lock name like the gateway, so the locks are shared
+// with the lock service of the gateway on the same table.
+func (t *Table) lockKey(name string) []byte {
+	return encoding.EncodeBytesAscending(append([]byte{}, t.prefix...), []byte(name))
+}
+
+func lockError(resp *kvrpcpb.LockResponse) error {
+	switch resp.GetCode() {
+	case lockOk:
+		return nil
+	case lockExist:
+		return ErrLockExist
+	case lockNotExist:
+		return ErrLockNotExist
+	case lockNotOwner:
+		return ErrNotLockOwner
+	default:
+		return fmt.Errorf("lock failed, code %d: %s", resp.GetCode(), resp.GetError())
+	}
+}
+
+// Lock takes the lock of the name for the id, ErrLockExist is returned if
+// another id holds it. the lock is released after the timeout, a timeout of
+// 0 holds it until it is unlocked.
+func (t *Table) Lock(ctx context.Context, name, id string, timeout time.Duration, value []byte) error {
+	k := t.lockKey(name)
+	req := &kvrpcpb.LockRequest{
+		Key: k,
+		Value: &kvrpcpb.LockValue{
+			Value:      value,
+			Id:         id,
+			DeleteTime: int64(timeout / time.Millisecond),
+		},
+		Timestamp: t.now(),
+	}
+	var resp *kvrpcpb.DsLockResponse
+	_, err := t.do(ctx, k, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+		var err error
+		resp, err = t.c.ds.Lock(ctx, addr, &kvrpcpb.DsLockRequest{Header: header, Req: req})
+		return resp.GetHeader().GetError(), err
+	})
+	if err != nil {
+		return err
+	}
+	return lockError(resp.GetResp())
+}
+
+// Unlock releases the lock of the name held by the id.
+func (t *Table) Unlock(ctx context.Context, name, id string) error {
+	k := t.lockKey(name)
+	req := &kvrpcpb.UnlockRequest{Key: k, Id: id, Timestamp: t.now()}
+	var resp *kvrpcpb.DsUnlockResponse
+	_, err := t.do(ctx, k, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+		var err error
+		resp, err = t.c.ds.Unlock(ctx, addr, &kvrpcpb.DsUnlockRequest{Header: header, Req: req})
+		return resp.GetHeader().GetError(), err
+	})
+	if err != nil {
+		return err
+	}
+	return lockError(resp.GetResp())
+}
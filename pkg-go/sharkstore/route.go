@@ -0,0 +1,334 @@
+package sharkstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"model/pkg/errorpb"
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+// the first and the maximum sleep in milliseconds between the retries
+const (
+	backoffBase = 2
+	backoffCap  = 500
+)
+
+// errRouteChange means the request should be routed and sent again, the
+// range is split, moved or its leader changed, or the node is busy.
+var errRouteChange = errors.New("route changed")
+
+// backoffer sleeps longer on every retry of a request, until the total sleep
+// reaches maxSleep milliseconds or the context is done.
+type backoffer struct {
+	ctx      context.Context
+	maxSleep int
+	total    int
+	attempts uint
+}
+
+func (b *backoffer) backoff(err error) error {
+	if b.ctx.Err() != nil {
+		return b.ctx.Err()
+	}
+	if b.total >= b.maxSleep {
+		return fmt.Errorf("retry timeout after %d ms, err[%v]", b.total, err)
+	}
+	sleep := backoffCap
+	if b.attempts < 8 && backoffBase<<b.attempts < backoffCap {
+		sleep = backoffBase << b.attempts
+	}
+	b.attempts++
+	b.total += sleep
+	select {
+	case <-b.ctx.Done():
+		return b.ctx.Err()
+	case <-time.After(time.Duration(sleep) * time.Millisecond):
+	}
+	return nil
+}
+
+// route is a range of the table and the node of its leader, it is replaced
+// rather than changed, so a route returned by the cache is safe to read.
+type route struct {
+	meta   *metapb.Range
+	leader uint64
+}
+
+// newRoute returns the route of the range, the first peer is taken as the
+// leader if the leader is unknown.
+func newRoute(meta *metapb.Range, leader uint64) *route {
+	r := &route{meta: meta}
+	for _, p := range meta.GetPeers() {
+		if p.GetNodeId() == leader {
+			r.leader = leader
+			return r
+		}
+	}
+	if len(meta.GetPeers()) > 0 {
+		r.leader = meta.GetPeers()[0].GetNodeId()
+	}
+	return r
+}
+
+func (r *route) endKey() []byte {
+	return r.meta.GetEndKey()
+}
+
+// contains checks if the key is in [StartKey, EndKey), an empty end key is
+// the end of the key space.
+func (r *route) contains(key []byte) bool {
+	return bytes.Compare(r.meta.GetStartKey(), key) <= 0 &&
+		(len(r.meta.GetEndKey()) == 0 || bytes.Compare(key, r.meta.GetEndKey()) < 0)
+}
+
+func (r *route) overlaps(o *route) bool {
+	return (len(o.meta.GetEndKey()) == 0 || bytes.Compare(r.meta.GetStartKey(), o.meta.GetEndKey()) < 0) &&
+		(len(r.meta.GetEndKey()) == 0 || bytes.Compare(o.meta.GetStartKey(), r.meta.GetEndKey()) < 0)
+}
+
+// rangeCache caches the routes of a table in start key order, a route is
+// loaded from the master on a miss and replaced when a data server reports
+// it stale.
+type rangeCache struct {
+	c       *Client
+	dbId    uint64
+	tableId uint64
+
+	lock   sync.RWMutex
+	routes []*route
+}
+
+func newRangeCache(c *Client, dbId, tableId uint64) *rangeCache {
+	return &rangeCache{c: c, dbId: dbId, tableId: tableId}
+}
+
+func (rc *rangeCache) find(key []byte) *route {
+	rc.lock.RLock()
+	defer rc.lock.RUnlock()
+	i := sort.Search(len(rc.routes), func(i int) bool {
+		return bytes.Compare(rc.routes[i].meta.GetStartKey(), key) > 0
+	})
+	if i > 0 && rc.routes[i-1].contains(key) {
+		return rc.routes[i-1]
+	}
+	return nil
+}
+
+// put caches the routes and drops the cached ones overlapping them.
+func (rc *rangeCache) put(rs ...*route) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	routes := rc.routes[:0:0]
+	for _, old := range rc.routes {
+		keep := true
+		for _, r := range rs {
+			if r.overlaps(old) || r.meta.GetId() == old.meta.GetId() {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			routes = append(routes, old)
+		}
+	}
+	routes = append(routes, rs...)
+	sort.Slice(routes, func(i, j int) bool {
+		return bytes.Compare(routes[i].meta.GetStartKey(), routes[j].meta.GetStartKey()) < 0
+	})
+	rc.routes = routes
+}
+
+// drop drops the route if it is still cached, it is loaded again on the
+// next request.
+func (rc *rangeCache) drop(r *route) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	for i, old := range rc.routes {
+		if old == r {
+			rc.routes = append(rc.routes[:i:i], rc.routes[i+1:]...)
+			return
+		}
+	}
+}
+
+// locate returns the route of the range holding the key.
+func (rc *rangeCache) locate(bo *backoffer, key []byte) (*route, error) {
+	if r := rc.find(key); r != nil {
+		return r, nil
+	}
+	for {
+		rs, err := rc.c.ms.GetRoute(rc.dbId, rc.tableId, key)
+		if err == nil && len(rs) == 0 {
+			err = fmt.Errorf("range not found for key %q", key)
+		}
+		if err != nil {
+			if err = bo.backoff(fmt.Errorf("load route of key %q failed, err[%v]", key, err)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		routes := make([]*route, 0, len(rs))
+		for _, r := range rs {
+			if len(r.GetRange().GetPeers()) == 0 {
+				return nil, fmt.Errorf("range %d has no peer", r.GetRange().GetId())
+			}
+			routes = append(routes, newRoute(r.GetRange(), r.GetLeader().GetNodeId()))
+		}
+		rc.put(routes...)
+		for _, r := range routes {
+			if r.contains(key) {
+				return r, nil
+			}
+		}
+		return routes[0], nil
+	}
+}
+
+// nodeAddr returns the address of the node, it is loaded from the master
+// once and cached by the client.
+func (c *Client) nodeAddr(bo *backoffer, id uint64) (string, error) {
+	c.nodeLock.RLock()
+	addr, find := c.nodes[id]
+	c.nodeLock.RUnlock()
+	if find {
+		return addr, nil
+	}
+	for {
+		node, err := c.ms.GetNode(id)
+		if err == nil && node == nil {
+			err = fmt.Errorf("node %d not found", id)
+		}
+		if err == nil {
+			c.nodeLock.Lock()
+			c.nodes[id] = node.GetServerAddr()
+			c.nodeLock.Unlock()
+			return node.GetServerAddr(), nil
+		}
+		if err = bo.backoff(fmt.Errorf("load node %d failed, err[%v]", id, err)); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (c *Client) dropNode(id uint64) {
+	c.nodeLock.Lock()
+	delete(c.nodes, id)
+	c.nodeLock.Unlock()
+}
+
+// rpc sends a request of a range to the address of its leader, and returns
+// the range error of the response header.
+type rpc func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error)
+
+// call sends the request once to the range of the route. errRouteChange is
+// returned after the cached route is updated, if the range is split, moved
+// or its leader changed.
+func (t *Table) call(bo *backoffer, r *route, timeout time.Duration, fn rpc) error {
+	addr, err := t.c.nodeAddr(bo, r.leader)
+	if err != nil {
+		return err
+	}
+	header := &kvrpcpb.RequestHeader{
+		RangeId:    r.meta.GetId(),
+		RangeEpoch: r.meta.GetRangeEpoch(),
+		Timestamp:  t.now(),
+	}
+	ctx, cancel := context.WithTimeout(bo.ctx, timeout)
+	rangeErr, err := fn(ctx, addr, header)
+	cancel()
+	if err != nil {
+		if bo.ctx.Err() != nil {
+			return bo.ctx.Err()
+		}
+		// the node may be down, the leader is asked from the master again
+		log.Warn("send to range[%d] on %s failed, err[%v]", r.meta.GetId(), addr, err)
+		t.c.dropNode(r.leader)
+		t.ranges.drop(r)
+		return errRouteChange
+	}
+	if rangeErr == nil {
+		return nil
+	}
+	return t.onRangeError(r, addr, rangeErr)
+}
+
+func (t *Table) onRangeError(r *route, addr string, rangeErr *errorpb.Error) error {
+	switch {
+	case rangeErr.GetNotLeader() != nil:
+		leader := rangeErr.GetNotLeader().GetLeader()
+		log.Warn("range[%d] on %s is not leader, new leader %v", r.meta.GetId(), addr, leader.GetNodeId())
+		if leader == nil {
+			t.ranges.drop(r)
+		} else {
+			t.ranges.put(newRoute(r.meta, leader.GetNodeId()))
+		}
+		return errRouteChange
+	case rangeErr.GetStaleEpoch() != nil:
+		stale := rangeErr.GetStaleEpoch()
+		log.Warn("range[%d] on %s is stale, [%d %d]", r.meta.GetId(), addr,
+			stale.GetOldRange().GetId(), stale.GetNewRange().GetId())
+		var routes []*route
+		for _, meta := range []*metapb.Range{stale.GetOldRange(), stale.GetNewRange()} {
+			if len(meta.GetPeers()) > 0 {
+				routes = append(routes, newRoute(meta, r.leader))
+			}
+		}
+		if len(routes) == 0 {
+			t.ranges.drop(r)
+		} else {
+			t.ranges.put(routes...)
+		}
+		return errRouteChange
+	case rangeErr.GetServerIsBusy() != nil, rangeErr.GetStaleCommand() != nil:
+		log.Warn("range[%d] on %s reports %s, retry later", r.meta.GetId(), addr, rangeErr.GetMessage())
+		return errRouteChange
+	case rangeErr.GetRangeNotFound() != nil, rangeErr.GetKeyNotInRange() != nil:
+		log.Warn("range[%d] on %s reports %s", r.meta.GetId(), addr, rangeErr.GetMessage())
+		t.ranges.drop(r)
+		return errRouteChange
+	case rangeErr.GetEntryTooLarge() != nil:
+		return errors.New(rangeErr.String())
+	default:
+		t.ranges.drop(r)
+		return errors.New(rangeErr.String())
+	}
+}
+
+// send sends the request once to the range holding the key.
+func (t *Table) send(bo *backoffer, key []byte, timeout time.Duration, fn rpc) (*route, error) {
+	r, err := t.ranges.locate(bo, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.call(bo, r, timeout, fn); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// do sends the request to the range holding the key, it is sent again after
+// the route is refreshed.
+func (t *Table) do(ctx context.Context, key []byte, timeout time.Duration, fn rpc) (*route, error) {
+	bo := t.backoffer(ctx)
+	for {
+		r, err := t.send(bo, key, timeout, fn)
+		if err == nil {
+			return r, nil
+		}
+		if err == errRouteChange {
+			err = bo.backoff(err)
+		}
+		if err != nil {
+			return nil, t.ctxErr(ctx, err)
+		}
+	}
+}
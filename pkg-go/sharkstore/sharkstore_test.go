@@ -0,0 +1,428 @@
+package sharkstore
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"model/pkg/metapb"
+	"proxy/store/dskv/mock_ds"
+	"proxy/store/dskv/mock_ms"
+	"util"
+	"util/deepcopy"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	testMsAddr = "127.0.0.1:18987"
+	testDsAddr = "127.0.0.1:16160"
+	testDsPath = "/tmp/sharkstore_sdk_test"
+	testDb     = "db1"
+)
+
+var (
+	setupOnce sync.Once
+	mockMs    *mock_ms.Cluster
+	mockDs    *mock_ds.DsRpcServer
+
+	testClient    *Client
+	testClientErr error
+)
+
+func makeTestTable(id uint64, name string) *metapb.Table {
+	return &metapb.Table{
+		Name:   name,
+		DbName: testDb,
+		DbId:   1,
+		Id:     id,
+		Columns: []*metapb.Column{
+			{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, Unsigned: true, PrimaryKey: 1},
+			{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+			{Name: "balance", Id: 3, DataType: metapb.DataType_Double},
+			{Name: "data", Id: 4, DataType: metapb.DataType_Binary},
+		},
+		Epoch: &metapb.TableEpoch{ConfVer: 1, Version: 1},
+	}
+}
+
+func splitKey(table *metapb.Table, id string) []byte {
+	key, _ := util.EncodePrimaryKey(util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId()), table.Columns[0], []byte(id))
+	return key
+}
+
+func makeTestRange(id uint64, table *metapb.Table, start, end []byte) *metapb.Range {
+	return &metapb.Range{
+		Id:          id,
+		TableId:     table.GetId(),
+		StartKey:    start,
+		EndKey:      end,
+		RangeEpoch:  &metapb.RangeEpoch{ConfVer: 1, Version: 1},
+		Peers:       []*metapb.Peer{{Id: id + 100, NodeId: 1}},
+		PrimaryKeys: table.Columns[:1],
+	}
+}
+
+// t1 has two ranges split at id 5, t2 has one range which is split by
+// the tests, t3 holds the raw keys and t4 is altered by the tests.
+func setupMock() {
+	db := &metapb.DataBase{Name: testDb, Id: 1}
+	t1 := makeTestTable(1, "t1")
+	t2 := makeTestTable(2, "t2")
+	t3 := makeTestTable(3, "t3")
+	t4 := makeTestTable(4, "t4")
+	r1 := util.BytesPrefix(util.EncodeStorePrefix(util.Store_Prefix_KV, t1.GetId()))
+	r2 := util.BytesPrefix(util.EncodeStorePrefix(util.Store_Prefix_KV, t2.GetId()))
+	r3 := util.BytesPrefix(util.EncodeStorePrefix(util.Store_Prefix_KV, t3.GetId()))
+	r4 := util.BytesPrefix(util.EncodeStorePrefix(util.Store_Prefix_KV, t4.GetId()))
+	ranges := []*metapb.Range{
+		makeTestRange(1, t1, r1.Start, splitKey(t1, "5")),
+		makeTestRange(2, t1, splitKey(t1, "5"), r1.Limit),
+		makeTestRange(10, t2, r2.Start, r2.Limit),
+		makeTestRange(20, t3, r3.Start, []byte(string(r3.Start)+"c")),
+		makeTestRange(21, t3, []byte(string(r3.Start)+"c"), r3.Limit),
+		makeTestRange(30, t4, r4.Start, r4.Limit),
+	}
+
+	mockMs = mock_ms.NewCluster(testMsAddr, "127.0.0.1:18988")
+	mockMs.SetDb(db)
+	mockMs.SetTable(t1)
+	mockMs.SetTable(t2)
+	mockMs.SetTable(t3)
+	mockMs.SetTable(t4)
+	mockMs.SetNode(&metapb.Node{Id: 1, ServerAddr: testDsAddr})
+	for _, r := range ranges {
+		mockMs.SetRange(deepcopy.Iface(r).(*metapb.Range))
+	}
+	go mockMs.Start()
+
+	os.RemoveAll(testDsPath)
+	mockDs = mock_ds.NewDsRpcServer(testDsAddr, testDsPath)
+	for _, r := range ranges {
+		mockDs.SetRange(deepcopy.Iface(r).(*metapb.Range))
+	}
+	go mockDs.Start()
+	time.Sleep(time.Second)
+
+	testClient, testClientErr = NewClient(&Config{MasterAddrs: []string{testMsAddr}, ScanBatchSize: 2})
+}
+
+// newTestClient returns the client shared by the tests, closing a client
+// waits for the read timeout of its connections. a small scan batch makes
+// the scans page inside a range and cross the ranges.
+func newTestClient(t *testing.T) *Client {
+	setupOnce.Do(setupMock)
+	if testClient == nil {
+		t.Fatal(testClientErr)
+	}
+	return testClient
+}
+
+func testRow(id uint64) Row {
+	return Row{"id": id, "name": "name" + string('a'+byte(id)), "balance": float64(id) / 2, "data": []byte{byte(id)}}
+}
+
+func checkRow(t *testing.T, row Row, id uint64) {
+	expect := testRow(id)
+	if row["id"] != id || row["name"] != expect["name"] || row["balance"] != expect["balance"] {
+		t.Fatalf("row %v, expect %v", row, expect)
+	}
+	if data, ok := row["data"].([]byte); !ok || len(data) != 1 || data[0] != byte(id) {
+		t.Fatalf("row %v data, expect %v", row, expect["data"])
+	}
+}
+
+func TestTableGetInsertDelete(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	table, err := c.Table(testDb, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []Row
+	for id := uint64(1); id <= 8; id++ {
+		rows = append(rows, testRow(id))
+	}
+	// the rows are written to both ranges
+	affected, err := table.Insert(ctx, rows...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected != 8 {
+		t.Fatalf("affected %d, expect 8", affected)
+	}
+
+	row, err := table.Get(ctx, Key{3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRow(t, row, 3)
+	if _, err = table.Get(ctx, Key{100}); err != ErrNotFound {
+		t.Fatalf("get missing row err %v, expect ErrNotFound", err)
+	}
+	if _, err = table.Get(ctx, Key{1, 2}); err == nil {
+		t.Fatal("get with too many key values should fail")
+	}
+
+	// a row of the primary key only has an empty value
+	if _, err = table.Insert(ctx, Row{"id": uint64(20)}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := table.BatchGet(ctx, []Key{{7}, {100}, {2}, {20}, {3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRow(t, got[0], 7)
+	if got[1] != nil {
+		t.Fatalf("missing row %v, expect nil", got[1])
+	}
+	checkRow(t, got[2], 2)
+	if got[3] == nil || got[3]["id"] != uint64(20) {
+		t.Fatalf("primary key only row %v", got[3])
+	}
+	checkRow(t, got[4], 3)
+
+	if affected, err = table.Delete(ctx, Key{2}, Key{6}, Key{20}); err != nil {
+		t.Fatal(err)
+	}
+	if affected != 3 {
+		t.Fatalf("deleted %d, expect 3", affected)
+	}
+	if _, err = table.Get(ctx, Key{6}); err != ErrNotFound {
+		t.Fatalf("get deleted row err %v, expect ErrNotFound", err)
+	}
+
+	if _, err = table.Insert(ctx, Row{"name": "no pk"}); err == nil {
+		t.Fatal("insert without primary key should fail")
+	}
+	if _, err = table.Insert(ctx, Row{"id": 1, "unknown": 1}); err == nil {
+		t.Fatal("insert unknown column should fail")
+	}
+	if _, err = c.Table(testDb, "not_exist"); err == nil {
+		t.Fatal("missing table should fail")
+	}
+}
+
+func TestTableScan(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	table, err := c.Table(testDb, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []Row
+	for id := uint64(11); id <= 17; id++ {
+		rows = append(rows, testRow(id))
+	}
+	for id := uint64(1); id <= 4; id++ {
+		rows = append(rows, testRow(id))
+	}
+	if _, err = table.Insert(ctx, rows...); err != nil {
+		t.Fatal(err)
+	}
+
+	scan := func(start, end Key) []uint64 {
+		var ids []uint64
+		it := table.Scan(ctx, start, end)
+		for it.Next() {
+			id := it.Row()["id"].(uint64)
+			checkRow(t, it.Row(), id)
+			ids = append(ids, id)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return ids
+	}
+	check := func(ids []uint64, from, to uint64) {
+		if len(ids) != int(to-from+1) {
+			t.Fatalf("scan ids %v, expect %d to %d", ids, from, to)
+		}
+		for i, id := range ids {
+			if id != from+uint64(i) {
+				t.Fatalf("scan ids %v, expect %d to %d", ids, from, to)
+			}
+		}
+	}
+	check(scan(Key{11}, Key{18}), 11, 17)
+	check(scan(Key{2}, Key{4}), 2, 3)
+	check(scan(Key{12}, nil), 12, 17)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	it := table.Scan(cancelled, nil, nil)
+	if it.Next() || it.Err() != context.Canceled {
+		t.Fatalf("scan with cancelled context err %v", it.Err())
+	}
+}
+
+func TestTableRangeSplit(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	table, err := c.Table(testDb, "t2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = table.Insert(ctx, testRow(1), testRow(9)); err != nil {
+		t.Fatal(err)
+	}
+
+	// split the range on the data server only, the client learns the new
+	// ranges from the stale epoch error
+	oldRng := deepcopy.Iface(mockDs.GetRange(10)).(*metapb.Range)
+	newRng := deepcopy.Iface(oldRng).(*metapb.Range)
+	newRng.Id = 11
+	newRng.StartKey = splitKey(table.current().meta, "5")
+	oldRng.EndKey = newRng.StartKey
+	oldRng.RangeEpoch.Version++
+	mockDs.RangeSplit(oldRng, newRng)
+
+	affected, err := table.Insert(ctx, testRow(2), testRow(3), testRow(7), testRow(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if affected != 4 {
+		t.Fatalf("affected %d, expect 4", affected)
+	}
+	for _, id := range []uint64{1, 2, 3, 7, 8, 9} {
+		row, err := table.Get(ctx, Key{id})
+		if err != nil {
+			t.Fatalf("get %d after split err %v", id, err)
+		}
+		checkRow(t, row, id)
+	}
+	var count int
+	it := table.Scan(ctx, nil, nil)
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil || count != 6 {
+		t.Fatalf("scan after split %d rows, err %v", count, it.Err())
+	}
+}
+
+func TestTableSchemaRefresh(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	table, err := c.Table(testDb, "t4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	row := testRow(1)
+	row["extra"] = "x"
+	if _, err = table.Insert(ctx, row); err == nil {
+		t.Fatal("insert unknown column should fail")
+	}
+
+	// add a column on the master, the schema is reloaded at the next check
+	meta := makeTestTable(4, "t4")
+	meta.Columns = append(meta.Columns, &metapb.Column{Name: "extra", Id: 5, DataType: metapb.DataType_Varchar})
+	meta.Epoch.ConfVer++
+	mockMs.SetTable(meta)
+	table.lock.Lock()
+	table.checkAt = time.Time{}
+	table.lock.Unlock()
+
+	if _, err = table.Insert(ctx, row); err != nil {
+		t.Fatal(err)
+	}
+	got, err := table.Get(ctx, Key{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["extra"] != "x" {
+		t.Fatalf("row %v, expect extra column", got)
+	}
+	if len(table.Columns()) != 5 {
+		t.Fatalf("columns %v, expect 5", table.Columns())
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	c := newTestClient(t)
+	table, err := c.Table(testDb, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+	if _, err = table.Get(ctx, Key{1}); err != context.DeadlineExceeded {
+		t.Fatalf("get after deadline err %v, expect %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestKV(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	kv, err := c.KV(testDb, "t3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the ranges of t3 are split at key c
+	keys := []string{"a", "b", "c", "d", "e"}
+	for _, k := range keys {
+		if err = kv.Put(ctx, []byte(k), []byte("v"+k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	value, err := kv.Get(ctx, []byte("c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "vc" {
+		t.Fatalf("get value %q", value)
+	}
+	if err = kv.Delete(ctx, []byte("c")); err != nil {
+		t.Fatal(err)
+	}
+	if value, err = kv.Get(ctx, []byte("c")); err != nil || value != nil {
+		t.Fatalf("get deleted key %q, err %v", value, err)
+	}
+
+	kvs, err := kv.Scan(ctx, []byte("a"), []byte("e"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kvs) != 3 || string(kvs[0].Key) != "a" || string(kvs[1].Key) != "b" || string(kvs[2].Key) != "d" {
+		t.Fatalf("scan keys %v", kvs)
+	}
+	if kvs, err = kv.Scan(ctx, []byte("b"), nil, 2); err != nil || len(kvs) != 2 {
+		t.Fatalf("scan with limit %v, err %v", kvs, err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	table, err := c.Table(testDb, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = table.Lock(ctx, "job", "owner1", 0, []byte("cond")); err != nil {
+		t.Fatal(err)
+	}
+	if err = table.Lock(ctx, "job", "owner2", 0, nil); err != ErrLockExist {
+		t.Fatalf("lock held by another id err %v", err)
+	}
+	if err = table.Unlock(ctx, "job", "owner2"); err != ErrNotLockOwner {
+		t.Fatalf("unlock by another id err %v", err)
+	}
+	if err = table.Unlock(ctx, "job", "owner1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = table.Unlock(ctx, "job", "owner1"); err != ErrLockNotExist {
+		t.Fatalf("unlock again err %v", err)
+	}
+
+	// the expired lock can be taken by another id
+	if err = table.Lock(ctx, "expire", "owner1", 10*time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err = table.Lock(ctx, "expire", "owner2", 0, nil); err != nil {
+		t.Fatalf("lock after expired err %v", err)
+	}
+}
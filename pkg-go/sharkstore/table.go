@@ -0,0 +1,556 @@
+package sharkstore
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"model/pkg/errorpb"
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"model/pkg/timestamp"
+	"pkg-go/ds_client"
+	"util"
+	"util/encoding"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+// Row holds the column values of a row by column name. integers are int64,
+// or uint64 for the unsigned columns, floats are float64, binary columns are
+// []byte and the other columns are string. a NULL column is nil.
+type Row map[string]interface{}
+
+// Key holds the values of the primary key columns in the order of the
+// table. a scan accepts a prefix of the primary key columns.
+type Key []interface{}
+
+// schema is the columns of a table at an epoch, it is replaced as a whole
+// when the table is altered.
+type schema struct {
+	meta    *metapb.Table
+	prefix  []byte
+	pks     []*metapb.Column
+	columns map[string]*metapb.Column
+	colIds  map[uint64]*metapb.Column
+	fields  []*kvrpcpb.SelectField
+}
+
+func newSchema(meta *metapb.Table) *schema {
+	s := &schema{
+		meta:    meta,
+		prefix:  util.EncodeStorePrefix(util.Store_Prefix_KV, meta.GetId()),
+		columns: make(map[string]*metapb.Column),
+		colIds:  make(map[uint64]*metapb.Column),
+	}
+	for _, col := range meta.GetColumns() {
+		s.columns[col.GetName()] = col
+		s.colIds[col.GetId()] = col
+		if col.GetPrimaryKey() > 0 {
+			s.pks = append(s.pks, col)
+		}
+		s.fields = append(s.fields, &kvrpcpb.SelectField{Typ: kvrpcpb.SelectField_Column, Column: col})
+	}
+	return s
+}
+
+func (s *schema) DbName() string {
+	return s.meta.GetDbName()
+}
+
+func (s *schema) Name() string {
+	return s.meta.GetName()
+}
+
+type Table struct {
+	c      *Client
+	db     string
+	name   string
+	prefix []byte
+	ranges *rangeCache
+
+	lock sync.Mutex
+	s    *schema
+	// the schema is checked against the master after checkAt
+	checkAt time.Time
+}
+
+func newTable(c *Client, meta *metapb.Table) *Table {
+	return &Table{
+		c:       c,
+		db:      meta.GetDbName(),
+		name:    meta.GetName(),
+		prefix:  util.EncodeStorePrefix(util.Store_Prefix_KV, meta.GetId()),
+		ranges:  newRangeCache(c, meta.GetDbId(), meta.GetId()),
+		s:       newSchema(meta),
+		checkAt: time.Now().Add(c.conf.TableCheckInterval),
+	}
+}
+
+func (t *Table) DbName() string {
+	return t.db
+}
+
+func (t *Table) Name() string {
+	return t.name
+}
+
+func (t *Table) current() *schema {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.s
+}
+
+func (t *Table) Columns() []*metapb.Column {
+	return t.current().meta.GetColumns()
+}
+
+func (t *Table) PrimaryKeys() []*metapb.Column {
+	return t.current().pks
+}
+
+// schema returns the schema of the table, it is loaded from the master
+// again after the check interval and replaced if the epoch is changed. The
+// cached schema is used while the master can't be reached.
+func (t *Table) schema() (*schema, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if time.Now().Before(t.checkAt) {
+		return t.s, nil
+	}
+	meta, err := t.c.ms.GetTable(t.db, t.name)
+	if err != nil {
+		log.Warn("check table %s.%s failed, err[%v]", t.db, t.name, err)
+		return t.s, nil
+	}
+	if meta == nil || meta.GetId() != t.s.meta.GetId() {
+		t.c.dropTable(t)
+		return nil, fmt.Errorf("Table '%s.%s' doesn't exist", t.db, t.name)
+	}
+	old := t.s.meta.GetEpoch()
+	if meta.GetEpoch().GetConfVer() != old.GetConfVer() || meta.GetEpoch().GetVersion() != old.GetVersion() {
+		log.Info("table %s.%s epoch changed from %v to %v, reload the schema", t.db, t.name, old, meta.GetEpoch())
+		t.s = newSchema(meta)
+	}
+	t.checkAt = time.Now().Add(t.c.conf.TableCheckInterval)
+	return t.s, nil
+}
+
+func (t *Table) backoffer(ctx context.Context) *backoffer {
+	return &backoffer{ctx: ctx, maxSleep: t.c.conf.MaxBackoff}
+}
+
+func (t *Table) now() *timestamp.Timestamp {
+	now := t.c.clock.Now()
+	return &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical}
+}
+
+// formatValue formats the value as the text of the column, which is the
+// input of the encoding functions in util.
+func formatValue(col *metapb.Column, v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return x, nil
+	case string:
+		return []byte(x), nil
+	case bool:
+		if x {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	case int:
+		return strconv.AppendInt(nil, int64(x), 10), nil
+	case int8:
+		return strconv.AppendInt(nil, int64(x), 10), nil
+	case int16:
+		return strconv.AppendInt(nil, int64(x), 10), nil
+	case int32:
+		return strconv.AppendInt(nil, int64(x), 10), nil
+	case int64:
+		return strconv.AppendInt(nil, x, 10), nil
+	case uint:
+		return strconv.AppendUint(nil, uint64(x), 10), nil
+	case uint8:
+		return strconv.AppendUint(nil, uint64(x), 10), nil
+	case uint16:
+		return strconv.AppendUint(nil, uint64(x), 10), nil
+	case uint32:
+		return strconv.AppendUint(nil, uint64(x), 10), nil
+	case uint64:
+		return strconv.AppendUint(nil, x, 10), nil
+	case float32:
+		return strconv.AppendFloat(nil, float64(x), 'f', -1, 32), nil
+	case float64:
+		return strconv.AppendFloat(nil, x, 'f', -1, 64), nil
+	case fmt.Stringer:
+		return []byte(x.String()), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T of column %s", v, col.GetName())
+	}
+}
+
+func rowValue(col *metapb.Column, v interface{}) interface{} {
+	if b, ok := v.([]byte); ok && col.GetDataType() != metapb.DataType_Binary {
+		return string(b)
+	}
+	return v
+}
+
+// encodeKey encodes the primary key of a row, or a prefix of it when partial
+// is true.
+func (s *schema) encodeKey(key Key, partial bool) ([]byte, error) {
+	if len(key) > len(s.pks) || (!partial && len(key) != len(s.pks)) {
+		return nil, fmt.Errorf("table %s.%s has %d primary key columns, got %d values", s.DbName(), s.Name(), len(s.pks), len(key))
+	}
+	buf := append([]byte{}, s.prefix...)
+	for i, v := range key {
+		sval, err := formatValue(s.pks[i], v)
+		if err != nil {
+			return nil, err
+		}
+		if sval == nil {
+			return nil, fmt.Errorf("pk(%s) could not be NULL", s.pks[i].GetName())
+		}
+		if buf, err = util.EncodePrimaryKey(buf, s.pks[i], sval); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+func (s *schema) encodeRow(row Row) (*kvrpcpb.KeyValue, error) {
+	key := make(Key, 0, len(s.pks))
+	for _, pk := range s.pks {
+		v, find := row[pk.GetName()]
+		if !find {
+			return nil, fmt.Errorf("pk(%s) is missing", pk.GetName())
+		}
+		key = append(key, v)
+	}
+	kv := &kvrpcpb.KeyValue{}
+	var err error
+	if kv.Key, err = s.encodeKey(key, false); err != nil {
+		return nil, err
+	}
+	for name, v := range row {
+		col, find := s.columns[name]
+		if !find {
+			return nil, fmt.Errorf("invalid table(%s) column(%s)", s.Name(), name)
+		}
+		if col.GetPrimaryKey() > 0 {
+			continue
+		}
+		sval, err := formatValue(col, v)
+		if err != nil {
+			return nil, err
+		}
+		if kv.Value, err = util.EncodeColumnValue(kv.Value, col, sval); err != nil {
+			return nil, err
+		}
+	}
+	return kv, nil
+}
+
+// decodeRow decodes the primary key columns from the key and the other
+// columns from the tagged fields, columns unknown to the schema are skipped.
+func (s *schema) decodeRow(key, fields []byte) (Row, error) {
+	if len(key) < len(s.prefix) {
+		return nil, fmt.Errorf("invalid row key %v of table %s.%s", key, s.DbName(), s.Name())
+	}
+	row := make(Row, len(s.columns))
+	buf := key[len(s.prefix):]
+	for _, col := range s.pks {
+		var v interface{}
+		var err error
+		if buf, v, err = util.DecodePrimaryKey2(buf, col); err != nil {
+			return nil, fmt.Errorf("decode pk %s of table %s.%s failed, err[%v]", col.GetName(), s.DbName(), s.Name(), err)
+		}
+		row[col.GetName()] = rowValue(col, v)
+	}
+	for len(fields) > 0 {
+		_, _, colId, _, err := encoding.DecodeValueTag(fields)
+		if err != nil {
+			return nil, fmt.Errorf("decode value tag of table %s.%s failed, err[%v]", s.DbName(), s.Name(), err)
+		}
+		col, find := s.colIds[uint64(colId)]
+		if !find {
+			_, length, err := encoding.PeekValueLength(fields)
+			if err != nil || length == 0 {
+				return nil, fmt.Errorf("skip column %d of table %s.%s failed, err[%v]", colId, s.DbName(), s.Name(), err)
+			}
+			fields = fields[length:]
+			continue
+		}
+		var v interface{}
+		if fields, v, err = util.DecodeColumnValue(fields, col); err != nil {
+			return nil, err
+		}
+		if col.GetPrimaryKey() == 0 {
+			row[col.GetName()] = rowValue(col, v)
+		}
+	}
+	return row, nil
+}
+
+func (t *Table) selectRows(ctx context.Context, s *schema, sreq *kvrpcpb.SelectRequest, key []byte) ([]Row, *kvrpcpb.SelectResponse, *route, error) {
+	var resp *kvrpcpb.DsSelectResponse
+	r, err := t.do(ctx, key, client.ReadTimeoutMedium, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+		var err error
+		resp, err = t.c.ds.Select(ctx, addr, &kvrpcpb.DsSelectRequest{Header: header, Req: sreq})
+		return resp.GetHeader().GetError(), err
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sresp := resp.GetResp()
+	if sresp.GetCode() != 0 {
+		return nil, nil, nil, fmt.Errorf("select from table %s.%s failed, code %d", s.DbName(), s.Name(), sresp.GetCode())
+	}
+	rows := make([]Row, 0, len(sresp.GetRows()))
+	for _, pbRow := range sresp.GetRows() {
+		row, err := s.decodeRow(pbRow.GetKey(), pbRow.GetFields())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, sresp, r, nil
+}
+
+func (t *Table) get(ctx context.Context, s *schema, k []byte) (Row, error) {
+	sreq := &kvrpcpb.SelectRequest{
+		Key:       k,
+		FieldList: s.fields,
+		Limit:     &kvrpcpb.Limit{Count: 1},
+		Timestamp: t.now(),
+	}
+	rows, _, _, err := t.selectRows(ctx, s, sreq, k)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrNotFound
+	}
+	return rows[0], nil
+}
+
+// Get returns the row of the primary key, ErrNotFound if it doesn't exist.
+func (t *Table) Get(ctx context.Context, key Key) (Row, error) {
+	s, err := t.schema()
+	if err != nil {
+		return nil, err
+	}
+	k, err := s.encodeKey(key, false)
+	if err != nil {
+		return nil, err
+	}
+	return t.get(ctx, s, k)
+}
+
+// BatchGet returns the rows of the primary keys in the same order, the row
+// of a key which doesn't exist is nil. The keys of a range are read by one
+// request, a row without any column but the primary key reads as an empty
+// value like a missing one, so it is selected again by its key.
+func (t *Table) BatchGet(ctx context.Context, keys []Key) ([]Row, error) {
+	s, err := t.schema()
+	if err != nil {
+		return nil, err
+	}
+	encoded := make([][]byte, len(keys))
+	for i, key := range keys {
+		if encoded[i], err = s.encodeKey(key, false); err != nil {
+			return nil, err
+		}
+	}
+	// the indexes of the keys in key order
+	pending := make([]int, len(keys))
+	for i := range pending {
+		pending[i] = i
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return bytes.Compare(encoded[pending[i]], encoded[pending[j]]) < 0
+	})
+
+	rows := make([]Row, len(keys))
+	var empty []int
+	bo := t.backoffer(ctx)
+	for len(pending) > 0 {
+		r, err := t.ranges.locate(bo, encoded[pending[0]])
+		if err != nil {
+			return nil, t.ctxErr(ctx, err)
+		}
+		n := 1
+		for n < len(pending) && r.contains(encoded[pending[n]]) {
+			n++
+		}
+		req := &kvrpcpb.KvBatchGetRequest{Keys: make([][]byte, 0, n)}
+		for _, i := range pending[:n] {
+			req.Keys = append(req.Keys, encoded[i])
+		}
+		var resp *kvrpcpb.DsKvBatchGetResponse
+		err = t.call(bo, r, client.ReadTimeoutMedium, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+			var err error
+			resp, err = t.c.ds.KvBatchGet(ctx, addr, &kvrpcpb.DsKvBatchGetRequest{Header: header, Req: req})
+			return resp.GetHeader().GetError(), err
+		})
+		if err == errRouteChange {
+			if err = bo.backoff(err); err != nil {
+				return nil, t.ctxErr(ctx, err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, t.ctxErr(ctx, err)
+		}
+		if code := resp.GetResp().GetCode(); code != 0 {
+			return nil, fmt.Errorf("batch get from table %s.%s failed, code %d", s.DbName(), s.Name(), code)
+		}
+		values := make(map[string][]byte, n)
+		for _, kv := range resp.GetResp().GetKvs() {
+			values[string(kv.GetKey())] = kv.GetValue()
+		}
+		// the data server skips the keys out of the range, they are routed
+		// again if the range is split
+		var missed []int
+		for _, i := range pending[:n] {
+			value, find := values[string(encoded[i])]
+			switch {
+			case !find:
+				missed = append(missed, i)
+			case len(value) == 0:
+				empty = append(empty, i)
+			default:
+				if rows[i], err = s.decodeRow(encoded[i], value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		pending = append(missed, pending[n:]...)
+		if len(missed) > 0 {
+			t.ranges.drop(r)
+			if err = bo.backoff(errRouteChange); err != nil {
+				return nil, t.ctxErr(ctx, err)
+			}
+		}
+	}
+	for _, i := range empty {
+		row, err := t.get(ctx, s, encoded[i])
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// Insert writes the rows and returns the number of them. the rows are
+// grouped by range, a group is sent again after the route is refreshed.
+// with the duplicate check of the table, ErrDuplicateKey is returned if a
+// primary key exists, the rows of other groups may be written.
+func (t *Table) Insert(ctx context.Context, rows ...Row) (uint64, error) {
+	s, err := t.schema()
+	if err != nil {
+		return 0, err
+	}
+	kvs := make([]*kvrpcpb.KeyValue, 0, len(rows))
+	for i, row := range rows {
+		kv, err := s.encodeRow(row)
+		if err != nil {
+			return 0, fmt.Errorf("encode row %d of table %s.%s failed, err[%v]", i, s.DbName(), s.Name(), err)
+		}
+		kvs = append(kvs, kv)
+	}
+	sort.Slice(kvs, func(i, j int) bool { return bytes.Compare(kvs[i].GetKey(), kvs[j].GetKey()) < 0 })
+
+	bo := t.backoffer(ctx)
+	var affected uint64
+	for len(kvs) > 0 {
+		r, err := t.ranges.locate(bo, kvs[0].GetKey())
+		if err != nil {
+			return affected, t.ctxErr(ctx, err)
+		}
+		n := 1
+		for n < len(kvs) && r.contains(kvs[n].GetKey()) {
+			n++
+		}
+		req := &kvrpcpb.InsertRequest{
+			Rows:           kvs[:n],
+			CheckDuplicate: s.meta.GetPkDupCheck(),
+			Timestamp:      t.now(),
+		}
+		var resp *kvrpcpb.DsInsertResponse
+		err = t.call(bo, r, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+			var err error
+			resp, err = t.c.ds.Insert(ctx, addr, &kvrpcpb.DsInsertRequest{Header: header, Req: req})
+			return resp.GetHeader().GetError(), err
+		})
+		if err == errRouteChange {
+			// the range was split or moved, group the rows again
+			if err = bo.backoff(err); err != nil {
+				return affected, t.ctxErr(ctx, err)
+			}
+			continue
+		}
+		if err != nil {
+			return affected, t.ctxErr(ctx, err)
+		}
+		iresp := resp.GetResp()
+		if len(iresp.GetDuplicateKey()) > 0 {
+			return affected, ErrDuplicateKey
+		}
+		if iresp.GetCode() != 0 {
+			return affected, fmt.Errorf("insert into table %s.%s failed, code %d", s.DbName(), s.Name(), iresp.GetCode())
+		}
+		affected += iresp.GetAffectedKeys()
+		kvs = kvs[n:]
+	}
+	return affected, nil
+}
+
+// Delete deletes the rows of the primary keys and returns the number of
+// the deleted rows.
+func (t *Table) Delete(ctx context.Context, keys ...Key) (uint64, error) {
+	s, err := t.schema()
+	if err != nil {
+		return 0, err
+	}
+	var affected uint64
+	for _, key := range keys {
+		k, err := s.encodeKey(key, false)
+		if err != nil {
+			return affected, err
+		}
+		req := &kvrpcpb.DeleteRequest{Key: k, Timestamp: t.now()}
+		var resp *kvrpcpb.DsDeleteResponse
+		_, err = t.do(ctx, k, client.ReadTimeoutShort, func(ctx context.Context, addr string, header *kvrpcpb.RequestHeader) (*errorpb.Error, error) {
+			var err error
+			resp, err = t.c.ds.Delete(ctx, addr, &kvrpcpb.DsDeleteRequest{Header: header, Req: req})
+			return resp.GetHeader().GetError(), err
+		})
+		if err != nil {
+			return affected, err
+		}
+		dresp := resp.GetResp()
+		if dresp.GetCode() != 0 {
+			return affected, fmt.Errorf("delete from table %s.%s failed, code %d", s.DbName(), s.Name(), dresp.GetCode())
+		}
+		affected += dresp.GetAffectedKeys()
+	}
+	return affected, nil
+}
+
+func (t *Table) ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,223 @@
+package sqldriver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"model/pkg/metapb"
+
+	"golang.org/x/net/context"
+)
+
+var ErrTxUnsupported = errors.New("sharkstore: transactions are not supported")
+
+// the rest protocol of the gateway, see gateway-server/server/rest.go
+
+type field struct {
+	Column string      `json:"column"`
+	Value  interface{} `json:"value"`
+}
+
+type and struct {
+	Field  *field `json:"field"`
+	Relate string `json:"relate"`
+}
+
+type limit struct {
+	Offset   uint64 `json:"offset"`
+	RowCount uint64 `json:"rowcount"`
+}
+
+type order struct {
+	By   string `json:"by"`
+	Desc bool   `json:"desc"`
+}
+
+type filter struct {
+	And   []*and   `json:"and,omitempty"`
+	Limit *limit   `json:"limit,omitempty"`
+	Order []*order `json:"order,omitempty"`
+}
+
+type aggreFunc struct {
+	Function string `json:"func"`
+	Field    string `json:"field"`
+}
+
+type command struct {
+	Type      string          `json:"type"`
+	Field     []string        `json:"field,omitempty"`
+	Values    [][]interface{} `json:"values,omitempty"`
+	Filter    *filter         `json:"filter,omitempty"`
+	AggreFunc []*aggreFunc    `json:"aggrefunc,omitempty"`
+}
+
+type query struct {
+	Sign         string   `json:"sign"`
+	DatabaseName string   `json:"databasename"`
+	TableName    string   `json:"tablename"`
+	Command      *command `json:"command"`
+}
+
+type reply struct {
+	Code         int             `json:"code"`
+	RowsAffected uint64          `json:"rowsaffected"`
+	Values       [][]interface{} `json:"values"`
+	Message      string          `json:"message"`
+}
+
+type columnInfo struct {
+	Name     string `json:"column_name"`
+	DataType string `json:"data_type"`
+}
+
+type tableInfo struct {
+	Primarys []string      `json:"primarys"`
+	Columns  []*columnInfo `json:"columns"`
+}
+
+type tableInfoReply struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *tableInfo `json:"data"`
+}
+
+// table is the schema of a table loaded by the /tableinfo api
+type table struct {
+	columns []string
+	types   map[string]metapb.DataType
+}
+
+type conn struct {
+	conf   *Config
+	client *http.Client
+
+	lock   sync.Mutex
+	tables map[string]*table
+}
+
+func newConn(conf *Config) *conn {
+	dialer := &net.Dialer{Timeout: conf.DialTimeout}
+	return &conn{
+		conf: conf,
+		client: &http.Client{
+			Timeout:   conf.Timeout,
+			Transport: &http.Transport{Dial: dialer.Dial},
+		},
+		tables: make(map[string]*table),
+	}
+}
+
+func (c *conn) Prepare(sql string) (driver.Stmt, error) {
+	s, err := parseStmt(sql)
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{c: c, s: s}, nil
+}
+
+func (c *conn) Close() error {
+	if t, ok := c.client.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, ErrTxUnsupported
+}
+
+func (c *conn) newRequest(ctx context.Context, path string, body []byte) (*http.Request, error) {
+	u := "http://" + c.conf.Addr + path
+	req, err := http.NewRequest("POST", u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(headerProtocol, "json")
+	if c.conf.ClusterID != 0 {
+		req.Header.Set(headerClusterID, strconv.FormatUint(c.conf.ClusterID, 10))
+	}
+	return req.WithContext(ctx), nil
+}
+
+func (c *conn) post(ctx context.Context, path string, body []byte, v interface{}) error {
+	req, err := c.newRequest(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("sharkstore: gateway http status %s", resp.Status)
+	}
+	dec := json.NewDecoder(resp.Body)
+	// the numbers are converted by the types of the columns
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// table returns the schema of the table, it is loaded once by the connection.
+func (c *conn) table(ctx context.Context, db, name string) (*table, error) {
+	key := db + "." + name
+	c.lock.Lock()
+	t, find := c.tables[key]
+	c.lock.Unlock()
+	if find {
+		return t, nil
+	}
+
+	form := url.Values{"dbname": {db}, "tablename": {name}}
+	info := new(tableInfoReply)
+	if err := c.post(ctx, "/tableinfo?"+form.Encode(), nil, info); err != nil {
+		return nil, err
+	}
+	if info.Code != 0 {
+		return nil, &Error{Code: info.Code, Message: fmt.Sprintf("table '%s.%s': %s", db, name, info.Message)}
+	}
+	if info.Data == nil {
+		return nil, fmt.Errorf("sharkstore: no schema of table '%s.%s'", db, name)
+	}
+	t = &table{types: make(map[string]metapb.DataType)}
+	for _, col := range info.Data.Columns {
+		t.columns = append(t.columns, col.Name)
+		t.types[col.Name] = metapb.DataType(metapb.DataType_value[col.DataType])
+	}
+	c.lock.Lock()
+	c.tables[key] = t
+	c.lock.Unlock()
+	return t, nil
+}
+
+func (c *conn) do(ctx context.Context, db, name string, cmd *command) (*reply, error) {
+	body, err := json.Marshal(&query{
+		Sign:         c.conf.Token,
+		DatabaseName: db,
+		TableName:    name,
+		Command:      cmd,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := new(reply)
+	if err := c.post(ctx, "/kvcommand", body, r); err != nil {
+		return nil, err
+	}
+	if r.Code != 0 {
+		return nil, &Error{Code: r.Code, Message: r.Message}
+	}
+	return r, nil
+}
@@ -0,0 +1,46 @@
+// Package sqldriver is a database/sql driver which sends the statements to
+// the rest api of a sharkstore gateway. the statements are parsed by the
+// driver and sent as the commands of the gateway, so only the statements
+// with a command are supported: insert, select and delete of one table.
+//
+//	db, err := sql.Open("sharkstore", "sharkstore://token@127.0.0.1:8080/db?cluster_id=1")
+package sqldriver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+const DriverName = "sharkstore"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// the http headers of the gateway
+const (
+	headerProtocol  = "fbase-protocol-type"
+	headerClusterID = "fbase-cluster-id"
+)
+
+// Error is an error replied by the gateway, the message holds the error of
+// the range when a command failed on a data server.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("sharkstore: code %d: %s", e.Code, e.Message)
+}
+
+type Driver struct{}
+
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	conf, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conf), nil
+}
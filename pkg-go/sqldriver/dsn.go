@@ -0,0 +1,77 @@
+package sqldriver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+// Config is parsed from a dsn of the form
+//
+//	sharkstore://[token@]host:port/database[?options]
+//
+// the options are cluster_id, timeout, dial_timeout and parse_time. The
+// gateway always reads from the range leader, so there is no consistency
+// option.
+type Config struct {
+	Addr     string
+	Database string
+	// sent as the sign of every command
+	Token     string
+	ClusterID uint64
+	// timeout of a whole http request, the deadline of the context is used
+	// too when it is earlier
+	Timeout     time.Duration
+	DialTimeout time.Duration
+	// decode the date and timestamp columns to time.Time instead of string
+	ParseTime bool
+}
+
+func ParseDSN(dsn string) (*Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "sharkstore" {
+		return nil, fmt.Errorf("invalid dsn scheme %q", u.Scheme)
+	}
+	if len(u.Host) == 0 {
+		return nil, errors.New("gateway address is required")
+	}
+	conf := &Config{
+		Addr:        u.Host,
+		Database:    strings.TrimPrefix(u.Path, "/"),
+		Timeout:     DefaultTimeout,
+		DialTimeout: DefaultTimeout,
+	}
+	if len(conf.Database) == 0 || strings.Contains(conf.Database, "/") {
+		return nil, fmt.Errorf("invalid database %q", conf.Database)
+	}
+	if u.User != nil {
+		conf.Token = u.User.Username()
+	}
+	for k, vs := range u.Query() {
+		v := vs[len(vs)-1]
+		switch k {
+		case "cluster_id":
+			conf.ClusterID, err = strconv.ParseUint(v, 10, 64)
+		case "timeout":
+			conf.Timeout, err = time.ParseDuration(v)
+		case "dial_timeout":
+			conf.DialTimeout, err = time.ParseDuration(v)
+		case "parse_time":
+			conf.ParseTime, err = strconv.ParseBool(v)
+		default:
+			return nil, fmt.Errorf("unknown dsn option %q", k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid dsn option %s=%q: %v", k, v, err)
+		}
+	}
+	return conf, nil
+}
@@ -0,0 +1,292 @@
+package sqldriver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"proxy/gateway-server/sqlparser"
+)
+
+// parsed is a statement parsed by the driver, the values are bound to the
+// command when the statement is executed.
+type parsed struct {
+	typ   string
+	db    string
+	table string
+
+	// the columns and rows of an insert
+	fields []string
+	rows   [][]sqlparser.ValExpr
+
+	// the columns of a select, an empty list is *
+	selects []*selectExpr
+	where   []*cond
+	order   []*order
+	offset  sqlparser.ValExpr
+	count   sqlparser.ValExpr
+
+	numInput int
+}
+
+type selectExpr struct {
+	name string
+	// the aggregate function, the column is * for count(*)
+	function string
+	column   string
+}
+
+type cond struct {
+	column string
+	relate string
+	value  sqlparser.ValExpr
+}
+
+// the relations of the conditions with the column on the right side
+var reverseRelates = map[string]string{
+	"=":  "=",
+	"!=": "!=",
+	"<":  ">",
+	"<=": ">=",
+	">":  "<",
+	">=": "<=",
+}
+
+var aggreFuncs = map[string]bool{
+	"count": true,
+	"sum":   true,
+	"min":   true,
+	"max":   true,
+	"avg":   true,
+}
+
+func parseStmt(sql string) (*parsed, error) {
+	node, err := sqlparser.Parse(sql)
+	if err != nil {
+		return nil, fmt.Errorf("sharkstore: parse %q failed, err[%v]", sql, err)
+	}
+	p := new(parsed)
+	switch node := node.(type) {
+	case *sqlparser.Insert:
+		err = p.parseInsert(node)
+	case *sqlparser.Select:
+		err = p.parseSelect(node)
+	case *sqlparser.Delete:
+		err = p.parseDelete(node)
+	default:
+		err = fmt.Errorf("statement %T", node)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sharkstore: unsupported %v", err)
+	}
+	return p, nil
+}
+
+func (p *parsed) setTable(name *sqlparser.TableName) {
+	p.table = string(name.Name)
+	p.db = string(name.Qualifier)
+}
+
+// addValue checks the value and counts the placeholders, the n-th ? is
+// tokenized as :vn.
+func (p *parsed) addValue(v sqlparser.ValExpr) error {
+	switch v := v.(type) {
+	case sqlparser.ValArg:
+		n, err := strconv.Atoi(string(v[2:]))
+		if err != nil {
+			return fmt.Errorf("placeholder %s", v)
+		}
+		if n > p.numInput {
+			p.numInput = n
+		}
+	case sqlparser.StrVal, sqlparser.NumVal, *sqlparser.NullVal:
+	case *sqlparser.UnaryExpr:
+		if _, ok := v.Expr.(sqlparser.NumVal); !ok || v.Operator != sqlparser.AST_UMINUS {
+			return fmt.Errorf("value %s", sqlparser.String(v))
+		}
+	default:
+		return fmt.Errorf("value %s", sqlparser.String(v))
+	}
+	return nil
+}
+
+func columnName(e sqlparser.SQLNode) (string, error) {
+	switch e := e.(type) {
+	case *sqlparser.NonStarExpr:
+		return columnName(e.Expr)
+	case *sqlparser.ColName:
+		return string(e.Name), nil
+	}
+	return "", fmt.Errorf("column %s", sqlparser.String(e))
+}
+
+func (p *parsed) parseInsert(node *sqlparser.Insert) error {
+	p.typ = "set"
+	p.setTable(node.Table)
+	if len(node.Columns) == 0 {
+		return fmt.Errorf("insert without columns")
+	}
+	if len(node.OnDup) > 0 {
+		return fmt.Errorf("on duplicate key update")
+	}
+	for _, c := range node.Columns {
+		name, err := columnName(c)
+		if err != nil {
+			return err
+		}
+		p.fields = append(p.fields, name)
+	}
+	values, ok := node.Rows.(sqlparser.Values)
+	if !ok {
+		return fmt.Errorf("insert rows %s", sqlparser.String(node.Rows))
+	}
+	for _, tuple := range values {
+		vs, ok := tuple.(sqlparser.ValTuple)
+		if !ok || len(vs) != len(p.fields) {
+			return fmt.Errorf("insert row %s", sqlparser.String(tuple))
+		}
+		for _, v := range vs {
+			if err := p.addValue(v); err != nil {
+				return err
+			}
+		}
+		p.rows = append(p.rows, vs)
+	}
+	return nil
+}
+
+func (p *parsed) parseSelect(node *sqlparser.Select) error {
+	p.typ = "get"
+	if len(node.From) != 1 {
+		return fmt.Errorf("select from %d tables", len(node.From))
+	}
+	from, ok := node.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return fmt.Errorf("table %s", sqlparser.String(node.From[0]))
+	}
+	name, ok := from.Expr.(*sqlparser.TableName)
+	if !ok {
+		return fmt.Errorf("table %s", sqlparser.String(from.Expr))
+	}
+	p.setTable(name)
+	if len(node.Distinct) > 0 || len(node.GroupBy) > 0 || node.Having != nil || len(node.Lock) > 0 {
+		return fmt.Errorf("select clause of %s", sqlparser.String(node))
+	}
+
+	for _, e := range node.SelectExprs {
+		if _, ok := e.(*sqlparser.StarExpr); ok {
+			if len(node.SelectExprs) > 1 {
+				return fmt.Errorf("* with other columns")
+			}
+			break
+		}
+		if err := p.parseSelectExpr(e); err != nil {
+			return err
+		}
+	}
+	if err := p.parseWhere(node.Where); err != nil {
+		return err
+	}
+	for _, o := range node.OrderBy {
+		name, err := columnName(o.Expr)
+		if err != nil {
+			return err
+		}
+		p.order = append(p.order, &order{By: name, Desc: o.Direction == sqlparser.AST_DESC})
+	}
+	if node.Limit != nil {
+		p.offset, p.count = node.Limit.Offset, node.Limit.Rowcount
+		for _, v := range []sqlparser.ValExpr{p.offset, p.count} {
+			if v == nil {
+				continue
+			}
+			if err := p.addValue(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parsed) parseSelectExpr(e sqlparser.SelectExpr) error {
+	expr, ok := e.(*sqlparser.NonStarExpr)
+	if !ok {
+		return fmt.Errorf("column %s", sqlparser.String(e))
+	}
+	s := &selectExpr{name: sqlparser.String(expr.Expr)}
+	if len(expr.As) > 0 {
+		s.name = string(expr.As)
+	}
+	switch c := expr.Expr.(type) {
+	case *sqlparser.ColName:
+		s.column = string(c.Name)
+	case *sqlparser.FuncExpr:
+		s.function = strings.ToLower(string(c.Name))
+		if !aggreFuncs[s.function] || c.Distinct || len(c.Exprs) != 1 {
+			return fmt.Errorf("function %s", sqlparser.String(c))
+		}
+		if _, ok := c.Exprs[0].(*sqlparser.StarExpr); ok && s.function == "count" {
+			s.column = "*"
+		} else {
+			name, err := columnName(c.Exprs[0])
+			if err != nil {
+				return err
+			}
+			s.column = name
+		}
+	default:
+		return fmt.Errorf("column %s", sqlparser.String(e))
+	}
+	p.selects = append(p.selects, s)
+	return nil
+}
+
+func (p *parsed) parseDelete(node *sqlparser.Delete) error {
+	p.typ = "del"
+	p.setTable(node.Table)
+	if len(node.OrderBy) > 0 || node.Limit != nil {
+		return fmt.Errorf("delete with order by or limit")
+	}
+	return p.parseWhere(node.Where)
+}
+
+func (p *parsed) parseWhere(where *sqlparser.Where) error {
+	if where == nil {
+		return nil
+	}
+	return p.parseBoolExpr(where.Expr)
+}
+
+// parseBoolExpr accepts the comparisons of a column and a value joined by
+// and, which are the conditions of the commands.
+func (p *parsed) parseBoolExpr(e sqlparser.BoolExpr) error {
+	switch e := e.(type) {
+	case *sqlparser.AndExpr:
+		if err := p.parseBoolExpr(e.Left); err != nil {
+			return err
+		}
+		return p.parseBoolExpr(e.Right)
+	case *sqlparser.ParenBoolExpr:
+		return p.parseBoolExpr(e.Expr)
+	case *sqlparser.ComparisonExpr:
+		relate, find := reverseRelates[e.Operator]
+		if !find {
+			return fmt.Errorf("condition %s", sqlparser.String(e))
+		}
+		c := &cond{relate: e.Operator, value: e.Right}
+		col, ok := e.Left.(*sqlparser.ColName)
+		if !ok {
+			if col, ok = e.Right.(*sqlparser.ColName); !ok {
+				return fmt.Errorf("condition %s", sqlparser.String(e))
+			}
+			c.relate, c.value = relate, e.Left
+		}
+		c.column = string(col.Name)
+		if err := p.addValue(c.value); err != nil {
+			return err
+		}
+		p.where = append(p.where, c)
+		return nil
+	}
+	return fmt.Errorf("condition %s", sqlparser.String(e))
+}
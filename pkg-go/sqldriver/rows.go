@@ -0,0 +1,162 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"model/pkg/metapb"
+)
+
+type column struct {
+	name     string
+	function string
+	column   string
+	typ      metapb.DataType
+	// index of the column in the reply, the gateway replies the columns
+	// before the aggregate functions
+	index int
+}
+
+type rows struct {
+	conf    *Config
+	columns []*column
+	values  [][]interface{}
+	next    int
+}
+
+func newRows(conf *Config, t *table, selects []*selectExpr) *rows {
+	rs := &rows{conf: conf}
+	if len(selects) == 0 {
+		for _, name := range t.columns {
+			selects = append(selects, &selectExpr{name: name, column: name})
+		}
+	}
+	var fields int
+	for _, s := range selects {
+		col := &column{name: s.name, function: s.function, column: s.column}
+		if len(s.function) == 0 {
+			col.typ = t.types[lower(s.column)]
+			col.index = fields
+			fields++
+		} else if s.function == "min" || s.function == "max" {
+			col.typ = t.types[lower(s.column)]
+		}
+		rs.columns = append(rs.columns, col)
+	}
+	for _, col := range rs.columns {
+		if len(col.function) > 0 {
+			col.index = fields
+			fields++
+		}
+	}
+	return rs
+}
+
+func (rs *rows) Columns() []string {
+	names := make([]string, len(rs.columns))
+	for i, col := range rs.columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+// ColumnTypeDatabaseTypeName returns the name of the data type of the
+// column, it is empty for count, sum and avg.
+func (rs *rows) ColumnTypeDatabaseTypeName(index int) string {
+	if rs.columns[index].typ == metapb.DataType_Invalid {
+		return ""
+	}
+	return rs.columns[index].typ.String()
+}
+
+func (rs *rows) Close() error {
+	rs.values = nil
+	return nil
+}
+
+func (rs *rows) Next(dest []driver.Value) error {
+	if rs.next >= len(rs.values) {
+		return io.EOF
+	}
+	row := rs.values[rs.next]
+	rs.next++
+	for i, col := range rs.columns {
+		if col.index >= len(row) {
+			return fmt.Errorf("sharkstore: column %s is missing in the reply", col.name)
+		}
+		v, err := rs.decode(col, row[col.index])
+		if err != nil {
+			return fmt.Errorf("sharkstore: column %s: %v", col.name, err)
+		}
+		dest[i] = v
+	}
+	return nil
+}
+
+// decode converts a value of the json reply by the type of the column.
+func (rs *rows) decode(col *column, v interface{}) (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch col.typ {
+	case metapb.DataType_Tinyint, metapb.DataType_Smallint, metapb.DataType_Int, metapb.DataType_BigInt:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		// an unsigned value out of the range of int64 is scanned from the text
+		return []byte(n.String()), nil
+	case metapb.DataType_Float, metapb.DataType_Double:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a float", v)
+		}
+		return n.Float64()
+	case metapb.DataType_Varchar:
+		return stringValue(v)
+	case metapb.DataType_Date, metapb.DataType_TimeStamp:
+		s, err := stringValue(v)
+		if err != nil || !rs.conf.ParseTime {
+			return s, err
+		}
+		return parseTime(s)
+	case metapb.DataType_Binary:
+		s, err := stringValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.DecodeString(s)
+	}
+	// the aggregate functions
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	}
+	return v, nil
+}
+
+func stringValue(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%v is not a string", v)
+	}
+	return s, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{timeFormat, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
@@ -0,0 +1,265 @@
+package sqldriver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gateway is a fake gateway which records the commands and replies the
+// prepared reply.
+type gateway struct {
+	lock    sync.Mutex
+	queries []*query
+	headers []http.Header
+	reply   *reply
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	switch r.URL.Path {
+	case "/tableinfo":
+		var resp interface{}
+		if r.FormValue("tablename") != "t1" {
+			resp = &tableInfoReply{Code: 1, Message: "table not exist"}
+		} else {
+			resp = &tableInfoReply{Data: &tableInfo{
+				Primarys: []string{"id"},
+				Columns: []*columnInfo{
+					{Name: "id", DataType: "BigInt"},
+					{Name: "name", DataType: "Varchar"},
+					{Name: "score", DataType: "Double"},
+					{Name: "data", DataType: "Binary"},
+					{Name: "created", DataType: "TimeStamp"},
+				},
+			}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	case "/kvcommand":
+		q := new(query)
+		if err := json.NewDecoder(r.Body).Decode(q); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		g.queries = append(g.queries, q)
+		g.headers = append(g.headers, r.Header)
+		json.NewEncoder(w).Encode(g.reply)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (g *gateway) last() (*query, http.Header) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.queries[len(g.queries)-1], g.headers[len(g.headers)-1]
+}
+
+func (g *gateway) setReply(r *reply) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.reply = r
+}
+
+func openTestDB(t *testing.T, options string) (*sql.DB, *gateway, func()) {
+	g := &gateway{reply: &reply{}}
+	s := httptest.NewServer(g)
+	addr := strings.TrimPrefix(s.URL, "http://")
+	db, err := sql.Open(DriverName, "sharkstore://tok@"+addr+"/db1"+options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, g, func() {
+		db.Close()
+		s.Close()
+	}
+}
+
+func TestParseDSN(t *testing.T) {
+	conf, err := ParseDSN("sharkstore://tok@127.0.0.1:8080/db1?cluster_id=7&timeout=3s&parse_time=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := &Config{
+		Addr:        "127.0.0.1:8080",
+		Database:    "db1",
+		Token:       "tok",
+		ClusterID:   7,
+		Timeout:     3 * time.Second,
+		DialTimeout: DefaultTimeout,
+		ParseTime:   true,
+	}
+	if !reflect.DeepEqual(conf, expect) {
+		t.Fatalf("expect %+v, got %+v", expect, conf)
+	}
+
+	for _, dsn := range []string{
+		"mysql://127.0.0.1:8080/db1",
+		"sharkstore:///db1",
+		"sharkstore://127.0.0.1:8080/",
+		"sharkstore://127.0.0.1:8080/db1?consistency=weak",
+		"sharkstore://127.0.0.1:8080/db1?timeout=3",
+		"sharkstore://127.0.0.1:8080/db1?unknown=1",
+	} {
+		if _, err := ParseDSN(dsn); err == nil {
+			t.Errorf("dsn %s should be invalid", dsn)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	db, g, closer := openTestDB(t, "?cluster_id=7")
+	defer closer()
+
+	g.setReply(&reply{RowsAffected: 2})
+	res, err := db.Exec("insert into t1 (id, name, score) values (?, ?, 1.5), (?, 'b', ?)", 1, "a", 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := res.RowsAffected(); n != 2 {
+		t.Fatalf("expect 2 rows affected, got %d", n)
+	}
+	q, header := g.last()
+	if q.Sign != "tok" || q.DatabaseName != "db1" || q.TableName != "t1" || q.Command.Type != "set" {
+		t.Fatalf("unexpected query %+v", q)
+	}
+	if !reflect.DeepEqual(q.Command.Field, []string{"id", "name", "score"}) {
+		t.Fatalf("unexpected fields %v", q.Command.Field)
+	}
+	expect := [][]interface{}{{1.0, "a", 1.5}, {2.0, "b", nil}}
+	if !reflect.DeepEqual(q.Command.Values, expect) {
+		t.Fatalf("expect values %v, got %v", expect, q.Command.Values)
+	}
+	if header.Get(headerClusterID) != "7" {
+		t.Fatalf("unexpected headers %v", header)
+	}
+
+	g.setReply(&reply{RowsAffected: 1})
+	if _, err := db.Exec("delete from db2.t1 where id >= ? and 10 > id", 3); err != nil {
+		t.Fatal(err)
+	}
+	q, _ = g.last()
+	if q.DatabaseName != "db2" || q.Command.Type != "del" || len(q.Command.Filter.And) != 2 {
+		t.Fatalf("unexpected query %+v", q.Command)
+	}
+	for i, r := range []string{">=", "<"} {
+		and := q.Command.Filter.And[i]
+		if and.Field.Column != "id" || and.Relate != r {
+			t.Fatalf("unexpected condition %+v %+v", and, and.Field)
+		}
+	}
+
+	g.setReply(&reply{Code: 3, Message: "run error: no route"})
+	_, err = db.Exec("delete from t1")
+	if e, ok := err.(*Error); !ok || e.Code != 3 || e.Message != "run error: no route" {
+		t.Fatalf("expect error of the gateway, got %v", err)
+	}
+
+	for _, s := range []string{
+		"update t1 set name = 'a'",
+		"select * from t1 where id = 1 or id = 2",
+		"delete from t1 limit 1",
+		"insert into t1 values (1)",
+	} {
+		if _, err := db.Exec(s); err == nil {
+			t.Errorf("%s should be unsupported", s)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	db, g, closer := openTestDB(t, "?parse_time=true")
+	defer closer()
+
+	g.setReply(&reply{Values: [][]interface{}{
+		{1, "a", 1.5, []byte("x"), "2018-01-02 03:04:05"},
+		{uint64(1 << 63), nil, 2, nil, nil},
+	}})
+	rows, err := db.Query("select * from t1 where name != ? order by id desc limit ?, ?", "c", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cols, []string{"id", "name", "score", "data", "created"}) {
+		t.Fatalf("unexpected columns %v", cols)
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types[0].DatabaseTypeName() != "BigInt" {
+		t.Fatalf("unexpected type %s", types[0].DatabaseTypeName())
+	}
+
+	var (
+		id      uint64
+		name    sql.NullString
+		score   float64
+		data    []byte
+		created *time.Time
+	)
+	if !rows.Next() {
+		t.Fatal(rows.Err())
+	}
+	if err := rows.Scan(&id, &name, &score, &data, &created); err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 || name.String != "a" || score != 1.5 || string(data) != "x" ||
+		!created.Equal(time.Date(2018, 1, 2, 3, 4, 5, 0, time.Local)) {
+		t.Fatalf("unexpected row %v %v %v %q %v", id, name, score, data, created)
+	}
+	if !rows.Next() {
+		t.Fatal(rows.Err())
+	}
+	if err := rows.Scan(&id, &name, &score, &data, &created); err != nil {
+		t.Fatal(err)
+	}
+	if id != 1<<63 || name.Valid || score != 2 || data != nil || created != nil {
+		t.Fatalf("unexpected row %v %v %v %q %v", id, name, score, data, created)
+	}
+	if rows.Next() {
+		t.Fatal("expect 2 rows")
+	}
+	rows.Close()
+
+	q, _ := g.last()
+	f := q.Command.Filter
+	if q.Command.Type != "get" || len(f.And) != 1 || f.And[0].Relate != "!=" || f.And[0].Field.Value != "c" {
+		t.Fatalf("unexpected query %+v", q.Command)
+	}
+	if *f.Limit != (limit{Offset: 1, RowCount: 2}) || len(f.Order) != 1 || *f.Order[0] != (order{By: "id", Desc: true}) {
+		t.Fatalf("unexpected filter %+v", f)
+	}
+
+	// the aggregate functions are replied after the columns
+	g.setReply(&reply{Values: [][]interface{}{{"a", 3}}})
+	var (
+		n     int64
+		first string
+	)
+	if err := db.QueryRow("select count(*), name from t1").Scan(&n, &first); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || first != "a" {
+		t.Fatalf("unexpected row %v %v", n, first)
+	}
+	q, _ = g.last()
+	if !reflect.DeepEqual(q.Command.Field, []string{"name"}) || len(q.Command.AggreFunc) != 1 ||
+		*q.Command.AggreFunc[0] != (aggreFunc{Function: "count", Field: "*"}) {
+		t.Fatalf("unexpected command %+v", q.Command)
+	}
+
+	if _, err := db.Query("select * from t2"); err == nil {
+		t.Fatal("expect error of the missing table")
+	}
+}
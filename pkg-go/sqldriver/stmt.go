@@ -0,0 +1,221 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"proxy/gateway-server/sqlparser"
+
+	"golang.org/x/net/context"
+)
+
+const timeFormat = "2006-01-02 15:04:05.999999999"
+
+// stmt is prepared by the driver, preparing doesn't send a request to the
+// gateway.
+type stmt struct {
+	c *conn
+	s *parsed
+}
+
+func (st *stmt) Close() error {
+	return nil
+}
+
+func (st *stmt) NumInput() int {
+	return st.s.numInput
+}
+
+func (st *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return st.ExecContext(context.Background(), namedValues(args))
+}
+
+func (st *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return st.QueryContext(context.Background(), namedValues(args))
+}
+
+func (st *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if st.s.typ == "get" {
+		return nil, errors.New("sharkstore: exec of a select, use query")
+	}
+	cmd, err := st.command(args)
+	if err != nil {
+		return nil, err
+	}
+	r, err := st.c.do(ctx, st.db(), st.s.table, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return result(r.RowsAffected), nil
+}
+
+func (st *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if st.s.typ != "get" {
+		return nil, errors.New("sharkstore: query of an insert or delete, use exec")
+	}
+	t, err := st.c.table(ctx, st.db(), st.s.table)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := st.command(args)
+	if err != nil {
+		return nil, err
+	}
+	rs := newRows(st.c.conf, t, st.s.selects)
+	for _, col := range rs.columns {
+		if len(col.function) > 0 {
+			cmd.AggreFunc = append(cmd.AggreFunc, &aggreFunc{Function: col.function, Field: col.column})
+		} else {
+			cmd.Field = append(cmd.Field, col.column)
+		}
+	}
+	r, err := st.c.do(ctx, st.db(), st.s.table, cmd)
+	if err != nil {
+		return nil, err
+	}
+	rs.values = r.Values
+	return rs, nil
+}
+
+func (st *stmt) db() string {
+	if len(st.s.db) > 0 {
+		return st.s.db
+	}
+	return st.c.conf.Database
+}
+
+// command binds the arguments to the values of the statement.
+func (st *stmt) command(args []driver.NamedValue) (*command, error) {
+	if len(args) != st.s.numInput {
+		return nil, fmt.Errorf("sharkstore: expected %d arguments, got %d", st.s.numInput, len(args))
+	}
+	var err error
+	cmd := &command{Type: st.s.typ}
+	switch st.s.typ {
+	case "set":
+		cmd.Field = st.s.fields
+		cmd.Values = make([][]interface{}, 0, len(st.s.rows))
+		for _, row := range st.s.rows {
+			values := make([]interface{}, len(row))
+			for i, v := range row {
+				if values[i], err = bindValue(v, args); err != nil {
+					return nil, err
+				}
+			}
+			cmd.Values = append(cmd.Values, values)
+		}
+		return cmd, nil
+	case "get", "del":
+		f := &filter{Order: st.s.order}
+		for _, c := range st.s.where {
+			v, err := bindValue(c.value, args)
+			if err != nil {
+				return nil, err
+			}
+			f.And = append(f.And, &and{Field: &field{Column: c.column, Value: v}, Relate: c.relate})
+		}
+		if st.s.count != nil {
+			f.Limit = new(limit)
+			if f.Limit.RowCount, err = bindUint(st.s.count, args); err != nil {
+				return nil, err
+			}
+			if st.s.offset != nil {
+				if f.Limit.Offset, err = bindUint(st.s.offset, args); err != nil {
+					return nil, err
+				}
+			}
+		}
+		cmd.Filter = f
+		return cmd, nil
+	}
+	return nil, fmt.Errorf("sharkstore: unknown command %s", st.s.typ)
+}
+
+func bindValue(v sqlparser.ValExpr, args []driver.NamedValue) (interface{}, error) {
+	switch v := v.(type) {
+	case sqlparser.ValArg:
+		n, _ := strconv.Atoi(string(v[2:]))
+		return argValue(args[n-1].Value)
+	case sqlparser.StrVal:
+		return string(v), nil
+	case sqlparser.NumVal:
+		return numValue(string(v))
+	case *sqlparser.UnaryExpr:
+		return numValue("-" + string(v.Expr.(sqlparser.NumVal)))
+	case *sqlparser.NullVal:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("sharkstore: unsupported value %s", sqlparser.String(v))
+}
+
+func bindUint(v sqlparser.ValExpr, args []driver.NamedValue) (uint64, error) {
+	value, err := bindValue(v, args)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(fmt.Sprintf("%v", value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sharkstore: invalid limit %v", value)
+	}
+	return n, nil
+}
+
+func numValue(s string) (interface{}, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return u, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("sharkstore: invalid number %s", s)
+	}
+	return f, nil
+}
+
+// argValue converts an argument to the value of a command, the gateway
+// formats the values to strings and parses them by the types of the columns.
+func argValue(v driver.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, int64, float64, string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case bool:
+		if v {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case time.Time:
+		return v.Format(timeFormat), nil
+	}
+	return nil, fmt.Errorf("sharkstore: unsupported argument type %T", v)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+type result uint64
+
+func (r result) LastInsertId() (int64, error) {
+	return 0, errors.New("sharkstore: last insert id is not supported")
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+// lower is the name of a column in the commands and the replies.
+func lower(name string) string {
+	return strings.ToLower(name)
+}
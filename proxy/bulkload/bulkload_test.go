@@ -0,0 +1,226 @@
+package bulkload
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"model/pkg/metapb"
+	"pkg-go/ms_client"
+	"util"
+
+	"golang.org/x/net/context"
+)
+
+func testColumns() []*metapb.Column {
+	return []*metapb.Column{
+		{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+		{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+		{Name: "age", Id: 3, DataType: metapb.DataType_Int},
+	}
+}
+
+func readAll(t *testing.T, r rowReader) [][][]byte {
+	var rows [][][]byte
+	for {
+		values, err := r.Next()
+		if err == io.EOF {
+			return rows
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, values)
+	}
+}
+
+func TestRowReader(t *testing.T) {
+	csv := "name,ID,age\nbob,2,\"30\"\n,1,\n"
+	r, err := newRowReader(FORMAT_CSV, strings.NewReader(csv), testColumns())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := readAll(t, r)
+	if len(rows) != 2 {
+		t.Fatalf("expect 2 rows, got %d", len(rows))
+	}
+	if string(rows[0][0]) != "2" || string(rows[0][1]) != "bob" || string(rows[0][2]) != "30" {
+		t.Errorf("unexpected csv row %q", rows[0])
+	}
+	if rows[1][1] != nil || rows[1][2] != nil {
+		t.Errorf("empty csv field should be NULL, got %q", rows[1])
+	}
+
+	json := "{\"id\": 3, \"name\": \"tom\", \"age\": null}\n\n{\"id\": 4}\n"
+	r, err = newRowReader(FORMAT_JSON, strings.NewReader(json), testColumns())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows = readAll(t, r)
+	if len(rows) != 2 {
+		t.Fatalf("expect 2 rows, got %d", len(rows))
+	}
+	if string(rows[0][0]) != "3" || string(rows[0][1]) != "tom" || rows[0][2] != nil {
+		t.Errorf("unexpected json row %q", rows[0])
+	}
+
+	if _, err = newRowReader(FORMAT_CSV, strings.NewReader("id,unknown\n"), testColumns()); err == nil {
+		t.Error("expect error of unknown column")
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	records := []*record{
+		{key: []byte("k1"), values: [][]byte{[]byte("1"), nil, []byte("")}},
+		{key: []byte("k2"), values: [][]byte{nil}},
+	}
+	var buf bytes.Buffer
+	w := newRecordWriter(&buf)
+	for _, r := range records {
+		if err := w.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	reader := newRecordReader(&buf)
+	for _, expect := range records {
+		r, err := reader.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(r.key, expect.key) || len(r.values) != len(expect.values) {
+			t.Fatalf("expect %v, got %v", expect, r)
+		}
+		for i := range r.values {
+			if (r.values[i] == nil) != (expect.values[i] == nil) || !bytes.Equal(r.values[i], expect.values[i]) {
+				t.Fatalf("value %d: expect %q, got %q", i, expect.values[i], r.values[i])
+			}
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("expect EOF, got %v", err)
+	}
+}
+
+func TestSortAndPartition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulkload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// ids in reverse order, sorted into runs of 7 rows
+	var input bytes.Buffer
+	input.WriteString("id,name\n")
+	const rows = 100
+	for i := rows; i > 0; i-- {
+		input.WriteString(strconv.Itoa(i) + ",n" + strconv.Itoa(i) + "\n")
+	}
+	columns := testColumns()
+	reader, err := newRowReader(FORMAT_CSV, &input, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	progress := new(Progress)
+	result, err := sortInput(context.Background(), reader, columns, dir, 7, 5, progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.rows != rows || len(result.runs) != 15 || progress.SortedRuns != 15 {
+		t.Fatalf("unexpected sort result, rows %d runs %d", result.rows, len(result.runs))
+	}
+	splitKeys := computeSplitKeys(result.samples, 4)
+	if len(splitKeys) != 3 {
+		t.Fatalf("expect 3 split keys, got %d", len(splitKeys))
+	}
+	counts, err := partition(context.Background(), dir, result.runs, splitKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total uint64
+	var last []byte
+	for i, count := range counts {
+		f, err := os.Open(filepath.Join(dir, partFileName(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := newRecordReader(f)
+		var n uint64
+		for {
+			rec, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last != nil && bytes.Compare(last, rec.key) >= 0 {
+				t.Fatalf("partition %d is not sorted", i)
+			}
+			if i > 0 && bytes.Compare(rec.key, splitKeys[i-1]) < 0 {
+				t.Fatalf("key of partition %d is less than its split key", i)
+			}
+			if i < len(splitKeys) && bytes.Compare(rec.key, splitKeys[i]) >= 0 {
+				t.Fatalf("key of partition %d is not less than the next split key", i)
+			}
+			last = rec.key
+			n++
+		}
+		f.Close()
+		if n != count || n == 0 {
+			t.Fatalf("partition %d: expect %d records, got %d", i, count, n)
+		}
+		total += n
+	}
+	if total != rows {
+		t.Fatalf("expect %d records, got %d", rows, total)
+	}
+}
+
+func TestComputeSplitKeys(t *testing.T) {
+	samples := [][]byte{[]byte("a"), []byte("a"), []byte("a"), []byte("b")}
+	keys := computeSplitKeys(samples, 4)
+	if len(keys) != 1 || string(keys[0]) != "b" {
+		t.Fatalf("unexpected split keys %q", keys)
+	}
+	if keys = computeSplitKeys(samples, 1); len(keys) != 0 {
+		t.Fatalf("expect no split key, got %q", keys)
+	}
+}
+
+type fakeSplitClient struct {
+	client.Client
+	calls   int
+	pending []uint32
+	keys    [][]byte
+}
+
+func (c *fakeSplitClient) SplitTable(dbId, tableId uint64, splitKeys [][]byte) (uint32, error) {
+	c.keys = splitKeys
+	pending := c.pending[c.calls]
+	c.calls++
+	return pending, nil
+}
+
+func TestSplitExistingTable(t *testing.T) {
+	cli := &fakeSplitClient{pending: []uint32{2, 0}}
+	l := NewLoader(Config{WorkDir: os.TempDir()}, cli, nil)
+	table := &metapb.Table{DbId: 1, Id: 2, DbName: "db", Name: "t"}
+	if err := l.splitTable(context.Background(), table, [][]byte{[]byte("a"), []byte("b")}); err != nil {
+		t.Fatal(err)
+	}
+	if cli.calls != 2 {
+		t.Fatalf("expect to wait until no key is pending, split called %d times", cli.calls)
+	}
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, 2)
+	if len(cli.keys) != 2 || !bytes.Equal(cli.keys[1], append(prefix, 'b')) {
+		t.Fatalf("split keys must have the table prefix, got %v", cli.keys)
+	}
+}
@@ -0,0 +1,504 @@
+package bulkload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"proxy/store/dskv"
+	"util"
+	"util/hlc"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	PHASE_SORT      = "sort"
+	PHASE_PARTITION = "partition"
+	PHASE_CREATE    = "create"
+	PHASE_LOAD      = "load"
+	PHASE_DONE      = "done"
+
+	checkpointName = "checkpoint.json"
+
+	defaultChunkRows     = 1000000
+	defaultSampleEvery   = 1000
+	defaultRowsPerRegion = 1000000
+	defaultMaxRegions    = 1024
+	defaultBatchSize     = 2000
+	defaultConcurrency   = 8
+	defaultWaitTable     = 10 * time.Minute
+	defaultTimeout       = 30 * time.Second
+)
+
+var ErrNeedProperties = errors.New("table not exist, properties is required to create it")
+
+type Config struct {
+	// all intermediate files and the checkpoint are kept here, run the
+	// loader again with the same dir to resume
+	WorkDir string
+	Format  string
+	// number of ranges to pre-split the table into, 0 means one range
+	// every RowsPerRegion rows
+	Regions       int
+	RowsPerRegion int
+	// rows sorted in memory
+	ChunkRows   int
+	BatchSize   int
+	Concurrency int
+	Timeout     time.Duration
+}
+
+func (c *Config) adjust() {
+	if c.Format == "" {
+		c.Format = FORMAT_CSV
+	}
+	if c.RowsPerRegion <= 0 {
+		c.RowsPerRegion = defaultRowsPerRegion
+	}
+	if c.ChunkRows <= 0 {
+		c.ChunkRows = defaultChunkRows
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultConcurrency
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+type Progress struct {
+	Phase      string `json:"phase"`
+	ReadRows   uint64 `json:"read_rows"`
+	SortedRuns uint64 `json:"sorted_runs"`
+	TotalRows  uint64 `json:"total_rows"`
+	LoadedRows uint64 `json:"loaded_rows"`
+	Partitions int    `json:"partitions"`
+}
+
+// checkpoint is persisted after every step, so that a failed load could be
+// resumed without reading the input again once it is sorted.
+type checkpoint struct {
+	Phase     string   `json:"phase"`
+	DbName    string   `json:"db_name"`
+	TableName string   `json:"table_name"`
+	Columns   []string `json:"columns"`
+	Runs      []string `json:"runs"`
+	Rows      uint64   `json:"rows"`
+	SplitKeys [][]byte `json:"split_keys"`
+	// record count and loaded record count of every partition
+	Counts []uint64 `json:"counts"`
+	Loaded []uint64 `json:"loaded"`
+}
+
+// TableProperty is the properties used to create the table, the same as
+// the table create api of the master.
+type TableProperty struct {
+	Columns   []*metapb.Column `json:"columns"`
+	Regxs     []*metapb.Column `json:"regxs,omitempty"`
+	SplitKeys [][]byte         `json:"split_keys,omitempty"`
+}
+
+type Loader struct {
+	conf  Config
+	msCli msClient.Client
+	kvCli dsClient.KvClient
+	clock *hlc.Clock
+
+	lock     sync.Mutex
+	cp       *checkpoint
+	phase    string
+	progress Progress
+}
+
+func NewLoader(conf Config, msCli msClient.Client, kvCli dsClient.KvClient) *Loader {
+	conf.adjust()
+	return &Loader{
+		conf:  conf,
+		msCli: msCli,
+		kvCli: kvCli,
+		clock: hlc.NewClock(hlc.UnixNano, 0),
+	}
+}
+
+func (l *Loader) Progress() Progress {
+	l.lock.Lock()
+	phase := l.phase
+	partitions := 0
+	if l.cp != nil {
+		partitions = len(l.cp.Counts)
+	}
+	l.lock.Unlock()
+	return Progress{
+		Phase:      phase,
+		ReadRows:   atomic.LoadUint64(&l.progress.ReadRows),
+		SortedRuns: atomic.LoadUint64(&l.progress.SortedRuns),
+		TotalRows:  atomic.LoadUint64(&l.progress.TotalRows),
+		LoadedRows: atomic.LoadUint64(&l.progress.LoadedRows),
+		Partitions: partitions,
+	}
+}
+
+func (l *Loader) setPhase(phase string) {
+	l.lock.Lock()
+	l.phase = phase
+	l.lock.Unlock()
+}
+
+func (l *Loader) loadCheckpoint() (*checkpoint, error) {
+	data, err := ioutil.ReadFile(filepath.Join(l.conf.WorkDir, checkpointName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cp := new(checkpoint)
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint failed, err[%v]", err)
+	}
+	return cp, nil
+}
+
+// saveCheckpoint must be called with the lock held.
+func (l *Loader) saveCheckpoint() error {
+	data, err := json.Marshal(l.cp)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(l.conf.WorkDir, checkpointName)
+	if err = ioutil.WriteFile(name+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+func (l *Loader) advance(phase string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.cp.Phase = phase
+	l.phase = phase
+	return l.saveCheckpoint()
+}
+
+// Run loads the input into the table, properties is needed only if the
+// table does not exist. If the work dir has a checkpoint of the same table
+// the load is resumed from it and input is not read again after it was sorted.
+func (l *Loader) Run(ctx context.Context, dbName, tableName string, properties *TableProperty, input io.Reader) error {
+	if err := os.MkdirAll(l.conf.WorkDir, 0755); err != nil {
+		return err
+	}
+	cp, err := l.loadCheckpoint()
+	if err != nil {
+		return err
+	}
+	if cp != nil && (cp.DbName != dbName || cp.TableName != tableName) {
+		return fmt.Errorf("work dir %s belongs to table %s.%s", l.conf.WorkDir, cp.DbName, cp.TableName)
+	}
+	if cp == nil || cp.Phase == PHASE_SORT {
+		// the sort phase is not resumable, start over
+		cp = &checkpoint{Phase: PHASE_SORT, DbName: dbName, TableName: tableName}
+	} else {
+		log.Info("bulk load %s.%s resume from phase %s", dbName, tableName, cp.Phase)
+	}
+	l.lock.Lock()
+	l.cp = cp
+	l.phase = cp.Phase
+	l.lock.Unlock()
+	atomic.StoreUint64(&l.progress.TotalRows, cp.Rows)
+
+	table, err := l.msCli.GetTable(dbName, tableName)
+	if err != nil {
+		return err
+	}
+	for {
+		switch cp.Phase {
+		case PHASE_SORT:
+			if input == nil {
+				return errors.New("input is required")
+			}
+			columns, err := sortColumns(table, properties)
+			if err != nil {
+				return err
+			}
+			if err = l.sort(ctx, columns, input); err != nil {
+				return err
+			}
+		case PHASE_PARTITION:
+			counts, err := partition(ctx, l.conf.WorkDir, cp.Runs, cp.SplitKeys)
+			if err != nil {
+				return fmt.Errorf("partition failed, err[%v]", err)
+			}
+			for _, name := range cp.Runs {
+				os.Remove(filepath.Join(l.conf.WorkDir, name))
+			}
+			cp.Counts = counts
+			cp.Loaded = make([]uint64, len(counts))
+			if err = l.advance(PHASE_CREATE); err != nil {
+				return err
+			}
+		case PHASE_CREATE:
+			if table == nil {
+				if table, err = l.createTable(ctx, dbName, tableName, properties, cp.SplitKeys); err != nil {
+					return err
+				}
+			} else if err = l.splitTable(ctx, table, cp.SplitKeys); err != nil {
+				return err
+			}
+			if err = l.advance(PHASE_LOAD); err != nil {
+				return err
+			}
+		case PHASE_LOAD:
+			if table == nil {
+				if table, err = l.msCli.GetTable(dbName, tableName); err != nil {
+					return err
+				}
+				if table == nil {
+					return fmt.Errorf("table %s.%s not exist", dbName, tableName)
+				}
+			}
+			if err = l.load(ctx, table); err != nil {
+				return err
+			}
+			if err = l.advance(PHASE_DONE); err != nil {
+				return err
+			}
+		case PHASE_DONE:
+			log.Info("bulk load %s.%s finished, %d rows", dbName, tableName, cp.Rows)
+			return nil
+		default:
+			return fmt.Errorf("invalid checkpoint phase %s", cp.Phase)
+		}
+	}
+}
+
+// sortColumns returns the columns of the input rows, from the table if it
+// exists or from the properties to create it.
+func sortColumns(table *metapb.Table, properties *TableProperty) ([]*metapb.Column, error) {
+	if table != nil {
+		return table.GetColumns(), nil
+	}
+	if properties == nil || len(properties.Columns) == 0 {
+		return nil, ErrNeedProperties
+	}
+	for _, col := range properties.Columns {
+		col.Name = strings.ToLower(col.Name)
+	}
+	return properties.Columns, nil
+}
+
+func (l *Loader) sort(ctx context.Context, columns []*metapb.Column, input io.Reader) error {
+	reader, err := newRowReader(l.conf.Format, input, columns)
+	if err != nil {
+		return err
+	}
+	result, err := sortInput(ctx, reader, columns, l.conf.WorkDir, l.conf.ChunkRows, defaultSampleEvery, &l.progress)
+	if err != nil {
+		return err
+	}
+	cp := l.cp
+	cp.Runs = result.runs
+	cp.Rows = result.rows
+	cp.Columns = make([]string, 0, len(columns))
+	for _, col := range columns {
+		cp.Columns = append(cp.Columns, col.GetName())
+	}
+	// split keys are used both to pre-split the table and to load it in
+	// parallel
+	regions := l.conf.Regions
+	if regions <= 0 {
+		regions = int(result.rows/uint64(l.conf.RowsPerRegion)) + 1
+		if regions > defaultMaxRegions {
+			regions = defaultMaxRegions
+		}
+	}
+	cp.SplitKeys = computeSplitKeys(result.samples, regions)
+	atomic.StoreUint64(&l.progress.TotalRows, cp.Rows)
+	log.Info("bulk load %s.%s sorted %d rows into %d runs, %d split keys",
+		cp.DbName, cp.TableName, cp.Rows, len(cp.Runs), len(cp.SplitKeys))
+	return l.advance(PHASE_PARTITION)
+}
+
+func (l *Loader) createTable(ctx context.Context, dbName, tableName string, properties *TableProperty, splitKeys [][]byte) (*metapb.Table, error) {
+	if properties == nil {
+		return nil, ErrNeedProperties
+	}
+	tp := *properties
+	tp.SplitKeys = splitKeys
+	data, err := json.Marshal(&tp)
+	if err != nil {
+		return nil, err
+	}
+	if err = l.msCli.CreateTable(dbName, tableName, string(data)); err != nil {
+		return nil, fmt.Errorf("create table %s.%s failed, err[%v]", dbName, tableName, err)
+	}
+	log.Info("bulk load create table %s.%s with %d split keys", dbName, tableName, len(splitKeys))
+
+	// the table is returned by the master once all ranges are created
+	timeout := time.After(defaultWaitTable)
+	for {
+		table, err := l.msCli.GetTable(dbName, tableName)
+		if err == nil && table != nil {
+			return table, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return nil, fmt.Errorf("wait table %s.%s created timeout", dbName, tableName)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// splitTable splits the ranges of the existing table at the split keys and
+// waits until every split key is the start key of a range.
+func (l *Loader) splitTable(ctx context.Context, table *metapb.Table, splitKeys [][]byte) error {
+	if len(splitKeys) == 0 {
+		return nil
+	}
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId())
+	keys := make([][]byte, 0, len(splitKeys))
+	for _, key := range splitKeys {
+		k := make([]byte, 0, len(prefix)+len(key))
+		keys = append(keys, append(append(k, prefix...), key...))
+	}
+	timeout := time.After(defaultWaitTable)
+	for {
+		pending, err := l.msCli.SplitTable(table.GetDbId(), table.GetId(), keys)
+		if err != nil {
+			return fmt.Errorf("split table %s.%s failed, err[%v]", table.GetDbName(), table.GetName(), err)
+		}
+		if pending == 0 {
+			log.Info("bulk load split table %s.%s at %d keys", table.GetDbName(), table.GetName(), len(keys))
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return fmt.Errorf("wait table %s.%s split timeout, %d keys pending", table.GetDbName(), table.GetName(), pending)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (l *Loader) load(ctx context.Context, table *metapb.Table) error {
+	columns, err := loadColumns(table, l.cp.Columns)
+	if err != nil {
+		return err
+	}
+	proxy := new(dskv.KvProxy)
+	cache := dskv.NewRangeCache(table.GetDbId(), table.GetId(), l.msCli, dskv.NewNodeCache(l.msCli))
+	proxy.Init(l.kvCli, l.clock, cache, l.conf.Timeout, l.conf.Timeout)
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId())
+
+	var loaded uint64
+	for _, n := range l.cp.Loaded {
+		loaded += n
+	}
+	atomic.StoreUint64(&l.progress.LoadedRows, loaded)
+
+	var parts []int
+	for i := range l.cp.Counts {
+		if l.cp.Loaded[i] < l.cp.Counts[i] {
+			parts = append(parts, i)
+		}
+	}
+	return dskv.Parallel(ctx, l.conf.Concurrency, len(parts), func(i int) error {
+		return l.loadPartition(ctx, proxy, columns, prefix, parts[i])
+	})
+}
+
+// loadColumns returns the columns of the table in the order of the input values.
+func loadColumns(table *metapb.Table, names []string) ([]*metapb.Column, error) {
+	byName := make(map[string]*metapb.Column)
+	for _, col := range table.GetColumns() {
+		byName[strings.ToLower(col.GetName())] = col
+	}
+	columns := make([]*metapb.Column, 0, len(names))
+	for _, name := range names {
+		col, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("column %s not exist in table %s", name, table.GetName())
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func (l *Loader) loadPartition(ctx context.Context, proxy *dskv.KvProxy, columns []*metapb.Column, prefix []byte, part int) error {
+	f, err := os.Open(filepath.Join(l.conf.WorkDir, partFileName(part)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	reader := newRecordReader(f)
+
+	l.lock.Lock()
+	skip := l.cp.Loaded[part]
+	l.lock.Unlock()
+	for i := uint64(0); i < skip; i++ {
+		if _, err = reader.Next(); err != nil {
+			return fmt.Errorf("skip loaded records of partition %d failed, err[%v]", part, err)
+		}
+	}
+
+	batch := make([]*kvrpcpb.KeyValue, 0, l.conf.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := proxy.InsertRows(batch); err != nil {
+			return fmt.Errorf("load partition %d failed, err[%v]", part, err)
+		}
+		atomic.AddUint64(&l.progress.LoadedRows, uint64(len(batch)))
+		l.lock.Lock()
+		l.cp.Loaded[part] += uint64(len(batch))
+		err := l.saveCheckpoint()
+		l.lock.Unlock()
+		batch = batch[:0]
+		return err
+	}
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read partition %d failed, err[%v]", part, err)
+		}
+		value, err := encodeValue(columns, r.values)
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 0, len(prefix)+len(r.key))
+		key = append(append(key, prefix...), r.key...)
+		batch = append(batch, &kvrpcpb.KeyValue{Key: key, Value: value})
+		if len(batch) >= l.conf.BatchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,293 @@
+package bulkload
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"model/pkg/metapb"
+	"util"
+)
+
+const (
+	FORMAT_CSV  = "csv"
+	FORMAT_JSON = "json"
+
+	maxLineSize   = 64 * 1024 * 1024
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+// record is one input row, key is the encoded primary key without table
+// prefix and values are the raw column values in table column order, a nil
+// value is NULL. Values are encoded only when loading, because the column ids
+// are not known before the table is created.
+type record struct {
+	key    []byte
+	values [][]byte
+}
+
+func (r *record) encode(buf []byte) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64(len(r.key)))
+	buf = append(buf, tmp[:n]...)
+	buf = append(buf, r.key...)
+	n = binary.PutUvarint(tmp[:], uint64(len(r.values)))
+	buf = append(buf, tmp[:n]...)
+	for _, v := range r.values {
+		if v == nil {
+			buf = append(buf, 0)
+			continue
+		}
+		n = binary.PutUvarint(tmp[:], uint64(len(v))+1)
+		buf = append(buf, tmp[:n]...)
+		buf = append(buf, v...)
+	}
+	return buf
+}
+
+func decodeRecord(data []byte) (*record, error) {
+	r := new(record)
+	var err error
+	if r.key, data, err = readBytes(data); err != nil {
+		return nil, err
+	}
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("invalid record value count")
+	}
+	data = data[n:]
+	r.values = make([][]byte, count)
+	for i := range r.values {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n)+1 < length {
+			return nil, errors.New("invalid record value")
+		}
+		data = data[n:]
+		if length == 0 {
+			continue
+		}
+		r.values[i] = data[:length-1]
+		data = data[length-1:]
+	}
+	return r, nil
+}
+
+func readBytes(data []byte) ([]byte, []byte, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return nil, nil, errors.New("invalid record key")
+	}
+	return data[n : n+int(length)], data[n+int(length):], nil
+}
+
+// recordWriter writes length framed records.
+type recordWriter struct {
+	w   *bufio.Writer
+	buf []byte
+	tmp [binary.MaxVarintLen64]byte
+}
+
+func newRecordWriter(w io.Writer) *recordWriter {
+	return &recordWriter{w: bufio.NewWriterSize(w, 1024*1024)}
+}
+
+func (rw *recordWriter) Write(r *record) error {
+	rw.buf = r.encode(rw.buf[:0])
+	n := binary.PutUvarint(rw.tmp[:], uint64(len(rw.buf)))
+	if _, err := rw.w.Write(rw.tmp[:n]); err != nil {
+		return err
+	}
+	_, err := rw.w.Write(rw.buf)
+	return err
+}
+
+func (rw *recordWriter) Flush() error {
+	return rw.w.Flush()
+}
+
+type recordReader struct {
+	r *bufio.Reader
+}
+
+func newRecordReader(r io.Reader) *recordReader {
+	return &recordReader{r: bufio.NewReaderSize(r, 1024*1024)}
+}
+
+// Next returns io.EOF after the last record.
+func (rr *recordReader) Next() (*record, error) {
+	length, err := binary.ReadUvarint(rr.r)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxRecordSize {
+		return nil, fmt.Errorf("invalid record length %d", length)
+	}
+	data := make([]byte, length)
+	if _, err = io.ReadFull(rr.r, data); err != nil {
+		return nil, err
+	}
+	return decodeRecord(data)
+}
+
+// rowReader reads the input rows as raw values in table column order.
+type rowReader interface {
+	Next() ([][]byte, error)
+}
+
+func newRowReader(format string, input io.Reader, columns []*metapb.Column) (rowReader, error) {
+	switch strings.ToLower(format) {
+	case FORMAT_CSV, "":
+		return newCsvReader(input, columns)
+	case FORMAT_JSON, "ndjson":
+		return newJsonReader(input, columns), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+func columnIndex(columns []*metapb.Column) map[string]int {
+	index := make(map[string]int)
+	for i, col := range columns {
+		index[strings.ToLower(col.GetName())] = i
+	}
+	return index
+}
+
+// csvReader reads csv with a header line naming the columns, an empty
+// field is NULL.
+type csvReader struct {
+	r       *csv.Reader
+	columns int
+	fields  []int
+}
+
+func newCsvReader(input io.Reader, columns []*metapb.Column) (*csvReader, error) {
+	r := csv.NewReader(input)
+	r.ReuseRecord = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header failed, err[%v]", err)
+	}
+	index := columnIndex(columns)
+	fields := make([]int, len(header))
+	for i, name := range header {
+		idx, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %s in csv header", name)
+		}
+		fields[i] = idx
+	}
+	return &csvReader{r: r, columns: len(columns), fields: fields}, nil
+}
+
+func (r *csvReader) Next() ([][]byte, error) {
+	fields, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != len(r.fields) {
+		return nil, fmt.Errorf("csv line has %d fields, expect %d", len(fields), len(r.fields))
+	}
+	values := make([][]byte, r.columns)
+	for i, f := range fields {
+		if f == "" {
+			continue
+		}
+		values[r.fields[i]] = []byte(f)
+	}
+	return values, nil
+}
+
+// jsonReader reads newline delimited json objects.
+type jsonReader struct {
+	s     *bufio.Scanner
+	index map[string]int
+	count int
+}
+
+func newJsonReader(input io.Reader, columns []*metapb.Column) *jsonReader {
+	s := bufio.NewScanner(input)
+	s.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &jsonReader{s: s, index: columnIndex(columns), count: len(columns)}
+}
+
+func (r *jsonReader) Next() ([][]byte, error) {
+	for r.s.Scan() {
+		line := strings.TrimSpace(r.s.Text())
+		if line == "" {
+			continue
+		}
+		d := json.NewDecoder(strings.NewReader(line))
+		d.UseNumber()
+		obj := make(map[string]interface{})
+		if err := d.Decode(&obj); err != nil {
+			return nil, fmt.Errorf("decode json line failed, err[%v]", err)
+		}
+		values := make([][]byte, r.count)
+		for name, v := range obj {
+			idx, ok := r.index[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown column %s", name)
+			}
+			switch val := v.(type) {
+			case nil:
+			case string:
+				values[idx] = []byte(val)
+			case json.Number:
+				values[idx] = []byte(val.String())
+			case bool:
+				if val {
+					values[idx] = []byte("1")
+				} else {
+					values[idx] = []byte("0")
+				}
+			default:
+				return nil, fmt.Errorf("unsupported json value of column %s", name)
+			}
+		}
+		return values, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// encodeKey encodes the primary key columns in table column order, the
+// same as the gateway does for insert.
+func encodeKey(columns []*metapb.Column, values [][]byte) ([]byte, error) {
+	var key []byte
+	var err error
+	for i, col := range columns {
+		if col.GetPrimaryKey() == 0 {
+			continue
+		}
+		if values[i] == nil {
+			return nil, fmt.Errorf("pk(%s) could not be NULL", col.GetName())
+		}
+		if key, err = util.EncodePrimaryKey(key, col, values[i]); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// encodeValue encodes the non primary key columns with their column ids.
+func encodeValue(columns []*metapb.Column, values [][]byte) ([]byte, error) {
+	var value []byte
+	var err error
+	for i, col := range columns {
+		if col.GetPrimaryKey() != 0 || i >= len(values) || values[i] == nil {
+			continue
+		}
+		if value, err = util.EncodeColumnValue(value, col, values[i]); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
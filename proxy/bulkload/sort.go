@@ -0,0 +1,303 @@
+package bulkload
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+
+	"model/pkg/metapb"
+
+	"golang.org/x/net/context"
+)
+
+type byKey []*record
+
+func (s byKey) Len() int           { return len(s) }
+func (s byKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byKey) Less(i, j int) bool { return bytes.Compare(s[i].key, s[j].key) < 0 }
+
+func runFileName(i int) string {
+	return fmt.Sprintf("run-%06d.dat", i)
+}
+
+func partFileName(i int) string {
+	return fmt.Sprintf("part-%06d.dat", i)
+}
+
+func writeRecords(name string, records []*record) error {
+	f, err := os.OpenFile(name+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	w := newRecordWriter(f)
+	for _, r := range records {
+		if err = w.Write(r); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		os.Remove(name + ".tmp")
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+// sortResult is the output of the sort phase.
+type sortResult struct {
+	runs    []string
+	rows    uint64
+	samples [][]byte
+}
+
+// sortInput reads all rows, sorts them by encoded primary key in chunks of
+// chunkRows and writes every chunk to a sorted run file. Every sampleEvery
+// key is kept to compute the split points.
+func sortInput(ctx context.Context, reader rowReader, columns []*metapb.Column, dir string, chunkRows, sampleEvery int, progress *Progress) (*sortResult, error) {
+	result := new(sortResult)
+	chunk := make([]*record, 0, chunkRows)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		sort.Stable(byKey(chunk))
+		name := runFileName(len(result.runs))
+		if err := writeRecords(filepath.Join(dir, name), chunk); err != nil {
+			return err
+		}
+		result.runs = append(result.runs, name)
+		atomic.AddUint64(&progress.SortedRuns, 1)
+		chunk = chunk[:0]
+		return nil
+	}
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		values, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row %d failed, err[%v]", result.rows+1, err)
+		}
+		key, err := encodeKey(columns, values)
+		if err != nil {
+			return nil, fmt.Errorf("encode row %d failed, err[%v]", result.rows+1, err)
+		}
+		if result.rows%uint64(sampleEvery) == 0 {
+			result.samples = append(result.samples, key)
+		}
+		result.rows++
+		atomic.AddUint64(&progress.ReadRows, 1)
+		chunk = append(chunk, &record{key: key, values: values})
+		if len(chunk) >= chunkRows {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// computeSplitKeys picks regions-1 evenly spaced keys from the samples.
+func computeSplitKeys(samples [][]byte, regions int) [][]byte {
+	if regions <= 1 || len(samples) == 0 {
+		return nil
+	}
+	sorted := make([][]byte, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	var keys [][]byte
+	for i := 1; i < regions; i++ {
+		key := sorted[i*len(sorted)/regions]
+		// the first key of the table must not be a split key, duplicated
+		// keys would make empty ranges
+		if bytes.Equal(key, sorted[0]) || (len(keys) > 0 && bytes.Equal(key, keys[len(keys)-1])) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type mergeItem struct {
+	r      *record
+	reader *recordReader
+	// position of the run, records with equal keys keep the input order
+	index int
+}
+
+type mergeHeap []*mergeItem
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	c := bytes.Compare(h[i].r.key, h[j].r.key)
+	if c == 0 {
+		return h[i].index < h[j].index
+	}
+	return c < 0
+}
+func (h mergeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(*mergeItem)) }
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// merger merges sorted run files into one sorted stream.
+type merger struct {
+	files []*os.File
+	heap  mergeHeap
+}
+
+func newMerger(dir string, runs []string) (*merger, error) {
+	m := new(merger)
+	for i, name := range runs {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.files = append(m.files, f)
+		reader := newRecordReader(f)
+		r, err := reader.Next()
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			m.Close()
+			return nil, fmt.Errorf("read run %s failed, err[%v]", name, err)
+		}
+		m.heap = append(m.heap, &mergeItem{r: r, reader: reader, index: i})
+	}
+	heap.Init(&m.heap)
+	return m, nil
+}
+
+func (m *merger) Next() (*record, error) {
+	if len(m.heap) == 0 {
+		return nil, io.EOF
+	}
+	item := m.heap[0]
+	r := item.r
+	next, err := item.reader.Next()
+	switch {
+	case err == io.EOF:
+		heap.Pop(&m.heap)
+	case err != nil:
+		return nil, err
+	default:
+		item.r = next
+		heap.Fix(&m.heap, 0)
+	}
+	return r, nil
+}
+
+func (m *merger) Close() {
+	for _, f := range m.files {
+		f.Close()
+	}
+}
+
+// partition merges the runs and writes the records of every split range to
+// its own sorted file, so that the ranges could be loaded in parallel.
+// It returns the record count of every partition.
+func partition(ctx context.Context, dir string, runs []string, splitKeys [][]byte) ([]uint64, error) {
+	m, err := newMerger(dir, runs)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	counts := make([]uint64, len(splitKeys)+1)
+	var part int
+	var f *os.File
+	var w *recordWriter
+	closePart := func() error {
+		if f == nil {
+			return nil
+		}
+		err := w.Flush()
+		if err == nil {
+			err = f.Sync()
+		}
+		f.Close()
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(dir, partFileName(part))
+		f = nil
+		return os.Rename(name+".tmp", name)
+	}
+	openPart := func() error {
+		var err error
+		f, err = os.OpenFile(filepath.Join(dir, partFileName(part))+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		w = newRecordWriter(f)
+		return nil
+	}
+	if err = openPart(); err != nil {
+		return nil, err
+	}
+	for {
+		if ctx.Err() != nil {
+			closePart()
+			return nil, ctx.Err()
+		}
+		r, err := m.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			closePart()
+			return nil, err
+		}
+		for part < len(splitKeys) && bytes.Compare(r.key, splitKeys[part]) >= 0 {
+			if err = closePart(); err != nil {
+				return nil, err
+			}
+			part++
+			if err = openPart(); err != nil {
+				return nil, err
+			}
+		}
+		if err = w.Write(r); err != nil {
+			closePart()
+			return nil, err
+		}
+		counts[part]++
+	}
+	if err = closePart(); err != nil {
+		return nil, err
+	}
+	// partitions after the last record are empty files
+	for part++; part <= len(splitKeys); part++ {
+		if err = openPart(); err != nil {
+			return nil, err
+		}
+		if err = closePart(); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,565 @@
+package cdc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"model/pkg/watchpb"
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"proxy/store/dskv"
+	"util"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	checkpointName = "checkpoint.json"
+
+	// status codes of the data server
+	codeOk           = 0
+	codeNotSupported = 3
+	codeCompacted    = 12
+
+	retryInterval = time.Second
+)
+
+var (
+	ErrRangeNotFound = errors.New("range not found")
+	ErrNotSupported  = errors.New("row watch is disabled on data server, set row_buffer_size in [watch] of ds.conf")
+)
+
+// Capture streams the row changes of sql tables to a sink. every range of
+// the tables is polled from its leader, the position is saved to the
+// checkpoint file after the changes are written to the sink, so events
+// are delivered at least once.
+type Capture struct {
+	conf   *Config
+	msCli  msClient.Client
+	kvCli  dsClient.KvClient
+	sink   Sink
+	nodes  *dskv.NodeCache
+	cpName string
+
+	// protects the checkpoint and the feeds
+	lock sync.Mutex
+	cp   *checkpoint
+	// writes to the sink are serialized
+	sinkLock sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewCapture(conf *Config, msCli msClient.Client, kvCli dsClient.KvClient, sink Sink) (*Capture, error) {
+	cpName := filepath.Join(conf.Capture.CheckpointDir, checkpointName)
+	cp, err := loadCheckpoint(cpName)
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint failed, err[%v]", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Capture{
+		conf:   conf,
+		msCli:  msCli,
+		kvCli:  kvCli,
+		sink:   sink,
+		nodes:  dskv.NewNodeCache(msCli),
+		cpName: cpName,
+		cp:     cp,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// Run captures the tables until Close is called.
+func (c *Capture) Run() error {
+	var tables []*tableCapture
+	for _, name := range c.conf.Capture.Tables {
+		dbName, tableName, _ := splitTableName(name)
+		table, err := c.msCli.GetTable(dbName, tableName)
+		if err != nil {
+			return fmt.Errorf("get table %s failed, err[%v]", name, err)
+		}
+		if table == nil {
+			return fmt.Errorf("table %s not exist", name)
+		}
+		c.lock.Lock()
+		cp, find := c.cp.Tables[name]
+		if !find {
+			cp = newTableCheckpoint()
+			c.cp.Tables[name] = cp
+		}
+		c.lock.Unlock()
+		tables = append(tables, &tableCapture{
+			c:       c,
+			name:    name,
+			table:   table,
+			decoder: newDecoder(table),
+			cp:      cp,
+			feeds:   make(map[uint64]*rangeFeed),
+		})
+	}
+	for _, t := range tables {
+		c.wg.Add(1)
+		go t.run()
+	}
+	log.Info("cdc capture %d tables to %s sink", len(tables), c.conf.Sink.Type)
+	c.wg.Wait()
+	return nil
+}
+
+func (c *Capture) Close() {
+	c.cancel()
+	c.wg.Wait()
+	c.lock.Lock()
+	if err := c.cp.save(c.cpName); err != nil {
+		log.Error("save cdc checkpoint failed, err[%v]", err)
+	}
+	c.lock.Unlock()
+	c.sink.Close()
+}
+
+func (c *Capture) sleep(d time.Duration) bool {
+	select {
+	case <-c.ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// write retries until the events are written, events are never skipped.
+func (c *Capture) write(events []*Event) error {
+	for {
+		c.sinkLock.Lock()
+		err := c.sink.Write(events)
+		c.sinkLock.Unlock()
+		if err == nil {
+			return nil
+		}
+		log.Error("cdc write %d events to sink failed, err[%v]", len(events), err)
+		if !c.sleep(retryInterval) {
+			return c.ctx.Err()
+		}
+	}
+}
+
+func (c *Capture) saveCheckpoint() {
+	if err := c.cp.save(c.cpName); err != nil {
+		log.Error("save cdc checkpoint failed, err[%v]", err)
+	}
+}
+
+func (c *Capture) nodeAddr(nodeId uint64) (string, error) {
+	node, err := c.nodes.GetNode(dskv.NewBackoffer(dskv.MsMaxBackoff, c.ctx), nodeId)
+	if err != nil {
+		return "", err
+	}
+	return node.GetServerAddr(), nil
+}
+
+type tableCapture struct {
+	c     *Capture
+	name  string
+	table *metapb.Table
+
+	// protected by Capture.lock
+	decoder *decoder
+	cp      *tableCheckpoint
+	feeds   map[uint64]*rangeFeed
+}
+
+func (t *tableCapture) run() {
+	defer t.c.wg.Done()
+	for {
+		if err := t.refresh(); err != nil {
+			log.Warn("cdc refresh table %s failed, err[%v]", t.name, err)
+		}
+		if !t.c.sleep(t.c.conf.Capture.RefreshInterval.Duration) {
+			break
+		}
+	}
+	t.c.lock.Lock()
+	for _, f := range t.feeds {
+		f.cancel()
+	}
+	t.c.lock.Unlock()
+}
+
+// listRoutes returns the routes of all ranges of the table in key order.
+func (t *tableCapture) listRoutes() ([]*metapb.Route, error) {
+	tableId := t.table.GetId()
+	key := util.EncodeStorePrefix(util.Store_Prefix_KV, tableId)
+	end := util.EncodeStorePrefix(util.Store_Prefix_KV, tableId+1)
+	var routes []*metapb.Route
+	seen := make(map[uint64]bool)
+	for {
+		rs, err := t.c.msCli.GetRoute(t.table.GetDbId(), tableId, key)
+		if err != nil {
+			return nil, err
+		}
+		next := key
+		for _, r := range rs {
+			rng := r.GetRange()
+			if rng.GetTableId() != tableId || seen[rng.GetId()] {
+				continue
+			}
+			seen[rng.GetId()] = true
+			routes = append(routes, r)
+			next = rng.GetEndKey()
+			if len(next) == 0 || bytes.Compare(next, end) >= 0 {
+				return routes, nil
+			}
+		}
+		if bytes.Equal(next, key) {
+			return nil, fmt.Errorf("route of key %v not found", key)
+		}
+		key = next
+	}
+}
+
+// refresh reloads the schema and the ranges of the table, new ranges split
+// from the known ones wait for them to be drained.
+func (t *tableCapture) refresh() error {
+	table, err := t.c.msCli.GetTable(t.table.GetDbName(), t.table.GetName())
+	if err != nil {
+		return err
+	}
+	if table == nil || table.GetId() != t.table.GetId() {
+		return fmt.Errorf("table %s is dropped", t.name)
+	}
+	routes, err := t.listRoutes()
+	if err != nil {
+		return err
+	}
+
+	c := t.c
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	t.table = table
+	t.decoder = newDecoder(table)
+	first := len(t.cp.Ranges) == 0
+	var changed bool
+	for _, r := range routes {
+		rng := r.GetRange()
+		if _, find := t.cp.Ranges[rng.GetId()]; !find {
+			cp := t.cp.addRange(rng.GetId(), rng.GetStartKey(), rng.GetEndKey(), first)
+			log.Info("cdc table %s add range %d, index %d, wait for %v", t.name, cp.Id, cp.Index, cp.WaitFor)
+			changed = true
+		}
+	}
+	// spans are updated after the new ranges find their parents
+	exist := make(map[uint64]bool)
+	for _, r := range routes {
+		rng := r.GetRange()
+		exist[rng.GetId()] = true
+		cp := t.cp.Ranges[rng.GetId()]
+		cp.StartKey, cp.EndKey = rng.GetStartKey(), rng.GetEndKey()
+		f, find := t.feeds[rng.GetId()]
+		if !find {
+			ctx, cancel := context.WithCancel(c.ctx)
+			f = &rangeFeed{t: t, id: rng.GetId(), ctx: ctx, cancel: cancel}
+			t.feeds[f.id] = f
+			f.update(r)
+			c.wg.Add(1)
+			go f.run()
+			continue
+		}
+		f.update(r)
+	}
+	// the ranges deleted when the capture is stopped
+	for id := range t.cp.Ranges {
+		if _, find := t.feeds[id]; !find && !exist[id] {
+			log.Info("cdc table %s range %d is deleted", t.name, id)
+			t.cp.removeRange(id)
+			changed = true
+		}
+	}
+	if changed {
+		c.saveCheckpoint()
+	}
+	return nil
+}
+
+func (t *tableCapture) getDecoder() *decoder {
+	t.c.lock.Lock()
+	defer t.c.lock.Unlock()
+	return t.decoder
+}
+
+// removeRange is called when the range is deleted.
+func (t *tableCapture) removeRange(id uint64) {
+	c := t.c
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	t.cp.removeRange(id)
+	delete(t.feeds, id)
+	c.saveCheckpoint()
+}
+
+// rangeFeed polls the row changes of a range.
+type rangeFeed struct {
+	t      *tableCapture
+	id     uint64
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock   sync.Mutex
+	meta   *metapb.Range
+	leader uint64
+}
+
+func (f *rangeFeed) update(r *metapb.Route) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.meta = r.GetRange()
+	if r.GetLeader() != nil {
+		f.leader = r.GetLeader().GetNodeId()
+	}
+}
+
+func (f *rangeFeed) target() (*metapb.Range, string, error) {
+	f.lock.Lock()
+	meta, leader := f.meta, f.leader
+	f.lock.Unlock()
+	addr, err := f.t.c.nodeAddr(leader)
+	return meta, addr, err
+}
+
+func (f *rangeFeed) run() {
+	c := f.t.c
+	defer c.wg.Done()
+	for {
+		if f.ctx.Err() != nil {
+			return
+		}
+		c.lock.Lock()
+		cp := f.t.cp.Ranges[f.id]
+		var index int64
+		var waiting bool
+		if cp != nil {
+			index, waiting = cp.Index, len(cp.WaitFor) > 0
+		}
+		c.lock.Unlock()
+		if cp == nil {
+			return
+		}
+		if waiting {
+			if !c.sleep(c.conf.Capture.PollInterval.Duration) {
+				return
+			}
+			continue
+		}
+
+		n, err := f.poll(index)
+		if err == ErrRangeNotFound {
+			log.Info("cdc table %s range %d is deleted", f.t.name, f.id)
+			f.t.removeRange(f.id)
+			return
+		}
+		if err != nil {
+			log.Warn("cdc table %s poll range %d failed, err[%v]", f.t.name, f.id, err)
+			if !c.sleep(retryInterval) {
+				return
+			}
+			continue
+		}
+		if n == 0 && !c.sleep(c.conf.Capture.PollInterval.Duration) {
+			return
+		}
+	}
+}
+
+// poll writes the changes after index to the sink and moves the checkpoint.
+func (f *rangeFeed) poll(index int64) (int, error) {
+	c := f.t.c
+	meta, addr, err := f.target()
+	if err != nil {
+		return 0, err
+	}
+	req := &watchpb.DsWatchRequest{
+		Header: &kvrpcpb.RequestHeader{RangeId: f.id, RangeEpoch: meta.GetRangeEpoch()},
+		Req: &watchpb.WatchCreateRequest{
+			Kv:           &watchpb.WatchKeyValue{TableId: int64(meta.GetTableId())},
+			StartVersion: index,
+			Prefix:       true,
+		},
+	}
+	ctx, cancel := context.WithTimeout(f.ctx, c.conf.Capture.RequestTimeout.Duration)
+	resp, err := c.kvCli.Watch(ctx, addr, req)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	if err = f.checkHeader(resp.GetHeader()); err != nil {
+		return 0, err
+	}
+	applied := int64(resp.GetHeader().GetApplyIndex())
+
+	var events []*Event
+	switch resp.GetResp().GetCode() {
+	case codeOk:
+		d := f.t.getDecoder()
+		events = make([]*Event, 0, len(resp.GetResp().GetEvents()))
+		for _, evt := range resp.GetResp().GetEvents() {
+			e, err := d.decodeEvent(f.id, evt)
+			if err != nil {
+				return 0, err
+			}
+			events = append(events, e)
+		}
+		if len(events) > 0 {
+			if err = c.write(events); err != nil {
+				return 0, err
+			}
+		}
+	case codeCompacted:
+		log.Warn("cdc table %s range %d changes after %d are evicted, scan the range at %d", f.t.name, f.id, index, applied)
+		// the deletes are lost with the evicted changes, so the consumer
+		// drops the rows of the range before it gets the snapshot
+		d := f.t.getDecoder()
+		resync := &Event{
+			Db:       d.db,
+			Table:    d.table,
+			Type:     EVENT_RESYNC,
+			RangeId:  f.id,
+			Index:    uint64(applied),
+			StartKey: meta.GetStartKey(),
+			EndKey:   meta.GetEndKey(),
+		}
+		if err = c.write([]*Event{resync}); err != nil {
+			return 0, err
+		}
+		if err = f.scan(meta); err != nil {
+			return 0, err
+		}
+	case codeNotSupported:
+		return 0, ErrNotSupported
+	default:
+		return 0, fmt.Errorf("watch rows code %d", resp.GetResp().GetCode())
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cp := f.t.cp.Ranges[f.id]
+	if cp == nil {
+		return len(events), nil
+	}
+	changed := applied != cp.Index
+	cp.Index = applied
+	if f.t.cp.drained(f.id, applied) {
+		changed = true
+	}
+	if changed {
+		c.saveCheckpoint()
+	}
+	return len(events), nil
+}
+
+func (f *rangeFeed) checkHeader(header *kvrpcpb.ResponseHeader) error {
+	pbErr := header.GetError()
+	if pbErr == nil {
+		return nil
+	}
+	switch {
+	case pbErr.GetNotLeader() != nil:
+		if leader := pbErr.GetNotLeader().GetLeader(); leader != nil {
+			f.lock.Lock()
+			f.leader = leader.GetNodeId()
+			f.lock.Unlock()
+		}
+	case pbErr.GetRangeNotFound() != nil:
+		return ErrRangeNotFound
+	case pbErr.GetStaleEpoch() != nil:
+		if rng := pbErr.GetStaleEpoch().GetOldRange(); rng != nil && rng.GetId() == f.id {
+			f.lock.Lock()
+			f.meta = rng
+			f.lock.Unlock()
+		}
+	}
+	return fmt.Errorf("range %d error: %s", f.id, pbErr.GetMessage())
+}
+
+// scan writes all rows of the range as snapshot events.
+func (f *rangeFeed) scan(meta *metapb.Range) error {
+	c := f.t.c
+	d := f.t.getDecoder()
+	var fields []*kvrpcpb.SelectField
+	for _, col := range d.all {
+		fields = append(fields, &kvrpcpb.SelectField{Typ: kvrpcpb.SelectField_Column, Column: col})
+	}
+	start := meta.GetStartKey()
+	for {
+		_, addr, err := f.target()
+		if err != nil {
+			return err
+		}
+		req := &kvrpcpb.DsSelectRequest{
+			Header: &kvrpcpb.RequestHeader{RangeId: f.id, RangeEpoch: meta.GetRangeEpoch()},
+			Req: &kvrpcpb.SelectRequest{
+				Scope:     &kvrpcpb.Scope{Start: start, Limit: meta.GetEndKey()},
+				FieldList: fields,
+				Limit:     &kvrpcpb.Limit{Count: uint64(c.conf.Capture.ScanBatch)},
+			},
+		}
+		ctx, cancel := context.WithTimeout(f.ctx, c.conf.Capture.RequestTimeout.Duration)
+		resp, err := c.kvCli.Select(ctx, addr, req)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if err = f.checkHeader(resp.GetHeader()); err != nil {
+			return err
+		}
+		if code := resp.GetResp().GetCode(); code != 0 {
+			return fmt.Errorf("select range %d code %d", f.id, code)
+		}
+		rows := resp.GetResp().GetRows()
+		events := make([]*Event, 0, len(rows))
+		for _, row := range rows {
+			keys, err := d.decodeKey(row.GetKey())
+			if err != nil {
+				return err
+			}
+			after := make(map[string]interface{}, len(fields))
+			data := row.GetFields()
+			for _, field := range fields {
+				if len(data) == 0 {
+					break
+				}
+				var v interface{}
+				if data, v, err = util.DecodeColumnValue(data, field.Column); err != nil {
+					return err
+				}
+				after[field.Column.GetName()] = jsonValue(v)
+			}
+			events = append(events, &Event{
+				Db:      d.db,
+				Table:   d.table,
+				Type:    EVENT_SNAPSHOT,
+				RangeId: f.id,
+				Keys:    keys,
+				After:   after,
+				key:     row.GetKey(),
+			})
+		}
+		if len(events) > 0 {
+			if err = c.write(events); err != nil {
+				return err
+			}
+		}
+		if len(rows) < c.conf.Capture.ScanBatch {
+			return nil
+		}
+		last := rows[len(rows)-1].GetKey()
+		start = append(append(make([]byte, 0, len(last)+1), last...), 0)
+	}
+}
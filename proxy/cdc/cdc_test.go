@@ -0,0 +1,251 @@
+package cdc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"model/pkg/metapb"
+	"model/pkg/timestamp"
+	"model/pkg/watchpb"
+	"util"
+)
+
+func testTable() *metapb.Table {
+	return &metapb.Table{
+		Name:   "t1",
+		DbName: "db1",
+		Id:     10,
+		Columns: []*metapb.Column{
+			{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+			{Name: "name", Id: 2, DataType: metapb.DataType_Varchar},
+			{Name: "age", Id: 3, DataType: metapb.DataType_Int},
+		},
+	}
+}
+
+func encodeRow(t *testing.T, table *metapb.Table, values ...string) ([]byte, []byte) {
+	key := util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId())
+	var value []byte
+	var err error
+	for i, col := range table.GetColumns() {
+		if col.GetPrimaryKey() > 0 {
+			key, err = util.EncodePrimaryKey(key, col, []byte(values[i]))
+		} else {
+			value, err = util.EncodeColumnValue(value, col, []byte(values[i]))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return key, value
+}
+
+func TestDecodeEvent(t *testing.T) {
+	table := testTable()
+	d := newDecoder(table)
+	key, before := encodeRow(t, table, "7", "tom", "20")
+	_, after := encodeRow(t, table, "7", "tom", "21")
+
+	evt := &watchpb.Event{
+		Type:     watchpb.EventType_PUT,
+		Kv:       &watchpb.WatchKeyValue{Key: [][]byte{key}, Value: after, Version: 15},
+		PrevKv:   &watchpb.WatchKeyValue{Value: before},
+		CommitTs: &timestamp.Timestamp{WallTime: 100, Logical: 2},
+	}
+	e, err := d.decodeEvent(3, evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != EVENT_UPDATE || e.RangeId != 3 || e.Index != 15 || e.CommitTs != 100 || e.Logical != 2 {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if e.Keys["id"] != int64(7) || e.Before["age"] != int64(20) || e.After["age"] != int64(21) || e.After["name"] != "tom" {
+		t.Fatalf("unexpected rows keys %v before %v after %v", e.Keys, e.Before, e.After)
+	}
+
+	evt.PrevKv = nil
+	if e, err = d.decodeEvent(3, evt); err != nil || e.Type != EVENT_INSERT || e.Before != nil {
+		t.Fatalf("unexpected insert event %+v, err %v", e, err)
+	}
+
+	evt = &watchpb.Event{
+		Type:   watchpb.EventType_DELETE,
+		Kv:     &watchpb.WatchKeyValue{Key: [][]byte{key}, Version: 16},
+		PrevKv: &watchpb.WatchKeyValue{Value: after},
+	}
+	if e, err = d.decodeEvent(3, evt); err != nil || e.Type != EVENT_DELETE || e.After != nil || e.Before["age"] != int64(21) {
+		t.Fatalf("unexpected delete event %+v, err %v", e, err)
+	}
+
+	// a column added later is decoded with the new schema only
+	table.Columns = table.Columns[:2]
+	if e, err = newDecoder(table).decodeEvent(3, evt); err != nil || len(e.Before) != 2 {
+		t.Fatalf("unexpected event %+v of old schema, err %v", e, err)
+	}
+}
+
+func TestCheckpointSplit(t *testing.T) {
+	tc := newTableCheckpoint()
+	parent := tc.addRange(1, []byte("a"), nil, true)
+	if parent.Index != INDEX_NOW || parent.WaitFor != nil {
+		t.Fatalf("unexpected first range %+v", parent)
+	}
+	parent.Index = 10
+
+	// range 2 split from range 1 at key m
+	child := tc.addRange(2, []byte("m"), nil, false)
+	if child.Index != 0 || len(child.WaitFor) != 1 || child.WaitFor[1] != 0 {
+		t.Fatalf("unexpected child range %+v", child)
+	}
+	parent.EndKey = []byte("m")
+
+	// the parent is polled up to the index of the split
+	parent.Index = 20
+	if !tc.drained(1, 20) || child.WaitFor[1] != 20 {
+		t.Fatalf("unexpected child range %+v", child)
+	}
+	parent.Index = 25
+	if !tc.drained(1, 25) || child.WaitFor != nil {
+		t.Fatalf("child range %+v still waits", child)
+	}
+	if tc.drained(1, 30) {
+		t.Fatal("no range waits for the parent")
+	}
+
+	// a range merged away releases the ranges waiting for it
+	other := tc.addRange(3, []byte("a"), []byte("c"), false)
+	if len(other.WaitFor) != 1 {
+		t.Fatalf("unexpected range %+v", other)
+	}
+	tc.removeRange(1)
+	if other.WaitFor != nil {
+		t.Fatalf("range %+v still waits", other)
+	}
+
+	dir, err := ioutil.TempDir("", "cdc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "cp", checkpointName)
+	cp, err := loadCheckpoint(name)
+	if err != nil || len(cp.Tables) != 0 {
+		t.Fatalf("load empty checkpoint failed, err %v", err)
+	}
+	cp.Tables["db1.t1"] = tc
+	if err = cp.save(name); err != nil {
+		t.Fatal(err)
+	}
+	if cp, err = loadCheckpoint(name); err != nil {
+		t.Fatal(err)
+	}
+	if r := cp.Tables["db1.t1"].Ranges[2]; r == nil || r.Index != 0 || string(r.StartKey) != "m" {
+		t.Fatalf("unexpected loaded range %+v", r)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "out", "events.json")
+	sink, err := NewSink(&SinkConfig{Type: SINK_FILE, File: FileSinkConfig{Path: name}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []*Event{
+		{Db: "db1", Table: "t1", Type: EVENT_INSERT, Index: 1, Keys: map[string]interface{}{"id": 1}},
+		{Db: "db1", Table: "t1", Type: EVENT_DELETE, Index: 2, Keys: map[string]interface{}{"id": 1}},
+	}
+	if err = sink.Write(events); err != nil {
+		t.Fatal(err)
+	}
+	sink.Close()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []*Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := new(Event)
+		if err = json.Unmarshal(scanner.Bytes(), e); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, e)
+	}
+	if len(got) != 2 || got[0].Type != EVENT_INSERT || got[1].Index != 2 {
+		t.Fatalf("unexpected events %v", got)
+	}
+}
+
+func TestKafkaRecordBatch(t *testing.T) {
+	msgs := []*kafkaMessage{
+		{key: []byte("k1"), value: []byte("v1")},
+		{key: []byte("k2"), value: []byte("value2")},
+	}
+	batch := encodeRecordBatch(msgs, time.Unix(1, 0))
+	if length := int(binary.BigEndian.Uint32(batch[8:12])); length != len(batch)-12 {
+		t.Fatalf("batch length %d, expect %d", length, len(batch)-12)
+	}
+	if batch[16] != 2 {
+		t.Fatalf("magic %d", batch[16])
+	}
+	if crc := binary.BigEndian.Uint32(batch[17:21]); crc != crc32.Checksum(batch[21:], castagnoliTable) {
+		t.Fatal("crc mismatch")
+	}
+	d := &kafkaDecoder{buf: batch[21:]}
+	d.int16()
+	if delta := d.int32(); delta != 1 {
+		t.Fatalf("last offset delta %d", delta)
+	}
+	if ts := d.int64(); ts != 1000 {
+		t.Fatalf("first timestamp %d", ts)
+	}
+	d.next(8 + 8 + 2 + 4)
+	if n := d.int32(); n != 2 || d.err != nil {
+		t.Fatalf("record count %d, err %v", n, d.err)
+	}
+	for i, m := range msgs {
+		length, n := binary.Varint(d.buf)
+		rec := d.next(n + int(length))[n:]
+		// attributes, timestamp delta, offset delta
+		rec = rec[1:]
+		_, n = binary.Varint(rec)
+		rec = rec[n:]
+		offset, n := binary.Varint(rec)
+		rec = rec[n:]
+		if offset != int64(i) {
+			t.Fatalf("record %d offset delta %d", i, offset)
+		}
+		keyLen, n := binary.Varint(rec)
+		if key := string(rec[n : n+int(keyLen)]); key != string(m.key) {
+			t.Fatalf("record %d key %s", i, key)
+		}
+		rec = rec[n+int(keyLen):]
+		valueLen, n := binary.Varint(rec)
+		if value := string(rec[n : n+int(valueLen)]); value != string(m.value) {
+			t.Fatalf("record %d value %s", i, value)
+		}
+	}
+	if len(d.buf) != 0 {
+		t.Fatalf("%d bytes left", len(d.buf))
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		if p := kafkaPartition([]byte(key), 3); p != kafkaPartition([]byte(key), 3) || p < 0 || p >= 3 {
+			t.Fatalf("partition %d of key %s", p, key)
+		}
+	}
+}
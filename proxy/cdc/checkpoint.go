@@ -0,0 +1,139 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// the range starts from its current position
+	INDEX_NOW int64 = -1
+)
+
+// rangeCheckpoint is the position of the capture in a range.
+type rangeCheckpoint struct {
+	Id       uint64 `json:"id"`
+	StartKey []byte `json:"start_key"`
+	EndKey   []byte `json:"end_key"`
+	// the last raft index of which the changes are sent to the sink
+	Index int64 `json:"index"`
+	// a range split from others starts after its parents are drained up to
+	// the index, 0 until the index is known
+	WaitFor map[uint64]int64 `json:"wait_for,omitempty"`
+}
+
+func (r *rangeCheckpoint) overlaps(start, end []byte) bool {
+	return (len(end) == 0 || bytes.Compare(r.StartKey, end) < 0) &&
+		(len(r.EndKey) == 0 || bytes.Compare(start, r.EndKey) < 0)
+}
+
+type tableCheckpoint struct {
+	Ranges map[uint64]*rangeCheckpoint `json:"ranges"`
+}
+
+func newTableCheckpoint() *tableCheckpoint {
+	return &tableCheckpoint{Ranges: make(map[uint64]*rangeCheckpoint)}
+}
+
+// addRange adds a range found in the route, it waits for the known ranges
+// it overlaps, which are the ranges it split from. ranges found at the
+// first time start from now.
+func (t *tableCheckpoint) addRange(id uint64, start, end []byte, first bool) *rangeCheckpoint {
+	r := &rangeCheckpoint{Id: id, StartKey: start, EndKey: end}
+	if first {
+		r.Index = INDEX_NOW
+	} else {
+		for _, p := range t.Ranges {
+			if p.overlaps(start, end) {
+				if r.WaitFor == nil {
+					r.WaitFor = make(map[uint64]int64)
+				}
+				r.WaitFor[p.Id] = 0
+			}
+		}
+	}
+	t.Ranges[id] = r
+	return r
+}
+
+// drained is called after range id is polled up to the applied index of
+// the data server, it returns true if the ranges waiting for it changed.
+func (t *tableCheckpoint) drained(id uint64, applied int64) bool {
+	var changed bool
+	for _, r := range t.Ranges {
+		to, find := r.WaitFor[id]
+		if !find {
+			continue
+		}
+		changed = true
+		if to == 0 {
+			// the split is applied, the changes before it are up to applied
+			r.WaitFor[id] = applied
+			continue
+		}
+		if t.Ranges[id].Index >= to {
+			delete(r.WaitFor, id)
+			if len(r.WaitFor) == 0 {
+				r.WaitFor = nil
+			}
+		}
+	}
+	return changed
+}
+
+// removeRange removes a deleted range, no one waits for it anymore.
+func (t *tableCheckpoint) removeRange(id uint64) {
+	delete(t.Ranges, id)
+	for _, r := range t.Ranges {
+		if _, find := r.WaitFor[id]; find {
+			delete(r.WaitFor, id)
+			if len(r.WaitFor) == 0 {
+				r.WaitFor = nil
+			}
+		}
+	}
+}
+
+type checkpoint struct {
+	Tables map[string]*tableCheckpoint `json:"tables"`
+}
+
+func loadCheckpoint(name string) (*checkpoint, error) {
+	cp := &checkpoint{Tables: make(map[string]*tableCheckpoint)}
+	data, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, err
+	}
+	if cp.Tables == nil {
+		cp.Tables = make(map[string]*tableCheckpoint)
+	}
+	for _, t := range cp.Tables {
+		if t.Ranges == nil {
+			t.Ranges = make(map[uint64]*rangeCheckpoint)
+		}
+	}
+	return cp, nil
+}
+
+func (cp *checkpoint) save(name string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(name+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"proxy/cdc"
+	"util/log"
+)
+
+var (
+	configFileName = flag.String("config", "", "Usage : -config conf/config.toml")
+	printVersion   = flag.Bool("v", false, "Usage : -v")
+)
+
+var (
+	// BuildVersion should generate from build script
+	BuildVersion = "unknown"
+	// BuildDate should generate from build script
+	BuildDate = "unknown"
+)
+
+func main() {
+	flag.Parse()
+
+	if *printVersion {
+		fmt.Println("Version:", BuildVersion)
+		fmt.Println("Build Date:", BuildDate)
+		return
+	}
+
+	conf := new(cdc.Config)
+	if err := conf.LoadConfig(*configFileName); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	log.InitFileLog(conf.Log.Dir, conf.Log.Module, conf.Log.Level)
+
+	msCli, err := msClient.NewClient(conf.Cluster.Address)
+	if err != nil {
+		log.Fatal("create master client failed, err[%v]", err)
+		return
+	}
+	sink, err := cdc.NewSink(&conf.Sink)
+	if err != nil {
+		log.Fatal("create %s sink failed, err[%v]", conf.Sink.Type, err)
+		return
+	}
+	capture, err := cdc.NewCapture(conf, msCli, dsClient.NewRPCClient(), sink)
+	if err != nil {
+		log.Fatal("create capture failed, err[%v]", err)
+		return
+	}
+
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	go func() {
+		sig := <-sc
+		log.Info("cdc got signal [%v] to exit", sig)
+		capture.Close()
+	}()
+
+	if err = capture.Run(); err != nil {
+		log.Fatal("cdc capture failed, err[%v]", err)
+	}
+	log.Info("cdc capture stopped")
+}
@@ -0,0 +1,168 @@
+package cdc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"util"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	SINK_FILE    = "file"
+	SINK_KAFKA   = "kafka"
+	SINK_WEBHOOK = "webhook"
+
+	defaultCheckpointDir   = "./cdc"
+	defaultPollInterval    = 500 * time.Millisecond
+	defaultRefreshInterval = 10 * time.Second
+	defaultRequestTimeout  = 10 * time.Second
+	defaultScanBatch       = 1000
+	defaultKafkaTimeout    = 10 * time.Second
+	defaultWebhookTimeout  = 10 * time.Second
+)
+
+type Config struct {
+	Cluster ClusterConfig `toml:"cluster,omitempty" json:"cluster"`
+	Log     LogConfig     `toml:"log,omitempty" json:"log"`
+	Capture CaptureConfig `toml:"capture,omitempty" json:"capture"`
+	Sink    SinkConfig    `toml:"sink,omitempty" json:"sink"`
+}
+
+type ClusterConfig struct {
+	// master rpc addresses
+	Address []string `toml:"address,omitempty" json:"address"`
+}
+
+type LogConfig struct {
+	Dir    string `toml:"dir,omitempty" json:"dir"`
+	Module string `toml:"module,omitempty" json:"module"`
+	Level  string `toml:"level,omitempty" json:"level"`
+}
+
+type CaptureConfig struct {
+	// tables to capture, as db.table
+	Tables []string `toml:"tables,omitempty" json:"tables"`
+	// checkpoints of the ranges are saved here, the capture resumes from them
+	CheckpointDir   string        `toml:"checkpoint-dir,omitempty" json:"checkpoint-dir"`
+	PollInterval    util.Duration `toml:"poll-interval,omitempty" json:"poll-interval"`
+	RefreshInterval util.Duration `toml:"refresh-interval,omitempty" json:"refresh-interval"`
+	RequestTimeout  util.Duration `toml:"request-timeout,omitempty" json:"request-timeout"`
+	// rows per select when a range is scanned again after its changes were evicted
+	ScanBatch int `toml:"scan-batch,omitempty" json:"scan-batch"`
+}
+
+type SinkConfig struct {
+	// file, kafka or webhook
+	Type    string            `toml:"type,omitempty" json:"type"`
+	File    FileSinkConfig    `toml:"file,omitempty" json:"file"`
+	Kafka   KafkaSinkConfig   `toml:"kafka,omitempty" json:"kafka"`
+	Webhook WebhookSinkConfig `toml:"webhook,omitempty" json:"webhook"`
+}
+
+type FileSinkConfig struct {
+	// events are appended as json lines
+	Path string `toml:"path,omitempty" json:"path"`
+}
+
+type KafkaSinkConfig struct {
+	Brokers  []string      `toml:"brokers,omitempty" json:"brokers"`
+	Topic    string        `toml:"topic,omitempty" json:"topic"`
+	ClientId string        `toml:"client-id,omitempty" json:"client-id"`
+	Timeout  util.Duration `toml:"timeout,omitempty" json:"timeout"`
+}
+
+type WebhookSinkConfig struct {
+	Url     string        `toml:"url,omitempty" json:"url"`
+	Timeout util.Duration `toml:"timeout,omitempty" json:"timeout"`
+}
+
+func (c *Config) LoadConfig(fileName string) error {
+	if _, err := toml.DecodeFile(fileName, c); err != nil {
+		return fmt.Errorf("load config file failed, err[%v]", err)
+	}
+	return c.adjust()
+}
+
+func (c *Config) adjust() error {
+	if len(c.Cluster.Address) == 0 {
+		return errors.New("cluster address is required")
+	}
+	if c.Log.Module == "" {
+		c.Log.Module = "cdc"
+	}
+	if c.Log.Level == "" {
+		c.Log.Level = "info"
+	}
+	if err := c.Capture.adjust(); err != nil {
+		return err
+	}
+	return c.Sink.adjust()
+}
+
+func (c *CaptureConfig) adjust() error {
+	if len(c.Tables) == 0 {
+		return errors.New("no table to capture")
+	}
+	for _, t := range c.Tables {
+		if _, _, err := splitTableName(t); err != nil {
+			return err
+		}
+	}
+	if c.CheckpointDir == "" {
+		c.CheckpointDir = defaultCheckpointDir
+	}
+	if c.PollInterval.Duration == 0 {
+		c.PollInterval.Duration = defaultPollInterval
+	}
+	if c.RefreshInterval.Duration == 0 {
+		c.RefreshInterval.Duration = defaultRefreshInterval
+	}
+	if c.RequestTimeout.Duration == 0 {
+		c.RequestTimeout.Duration = defaultRequestTimeout
+	}
+	if c.ScanBatch <= 0 {
+		c.ScanBatch = defaultScanBatch
+	}
+	return nil
+}
+
+func (c *SinkConfig) adjust() error {
+	switch c.Type {
+	case SINK_FILE:
+		if c.File.Path == "" {
+			return errors.New("file sink path is required")
+		}
+	case SINK_KAFKA:
+		if len(c.Kafka.Brokers) == 0 || c.Kafka.Topic == "" {
+			return errors.New("kafka sink brokers and topic are required")
+		}
+		if c.Kafka.ClientId == "" {
+			c.Kafka.ClientId = "sharkstore-cdc"
+		}
+		if c.Kafka.Timeout.Duration == 0 {
+			c.Kafka.Timeout.Duration = defaultKafkaTimeout
+		}
+	case SINK_WEBHOOK:
+		if c.Webhook.Url == "" {
+			return errors.New("webhook sink url is required")
+		}
+		if c.Webhook.Timeout.Duration == 0 {
+			c.Webhook.Timeout.Duration = defaultWebhookTimeout
+		}
+	default:
+		return fmt.Errorf("invalid sink type %s", c.Type)
+	}
+	return nil
+}
+
+func splitTableName(name string) (string, string, error) {
+	parts := strings.Split(name, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid table %s, expect db.table", name)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,172 @@
+package cdc
+
+import (
+	"fmt"
+
+	"model/pkg/metapb"
+	"model/pkg/watchpb"
+	"util"
+	"util/encoding"
+)
+
+const (
+	EVENT_INSERT = "insert"
+	EVENT_UPDATE = "update"
+	EVENT_DELETE = "delete"
+	// the row is read by scanning the range again, because the changes
+	// after the checkpoint were evicted from the data server
+	EVENT_SNAPSHOT = "snapshot"
+	// the changes of the range after the checkpoint were evicted, drop the
+	// rows between start_key and end_key, the snapshot events that follow
+	// hold all rows of the range, deleted rows are not among them
+	EVENT_RESYNC = "resync"
+)
+
+// Event is a change of one row. events of a range are in the order of
+// their raft index, and the changes of a row are never reordered, even
+// when its range splits.
+type Event struct {
+	Db      string `json:"db"`
+	Table   string `json:"table"`
+	Type    string `json:"type"`
+	RangeId uint64 `json:"range_id"`
+	Index   uint64 `json:"index"`
+	// commit timestamp of the write, wall time in nanoseconds
+	CommitTs int64 `json:"commit_ts"`
+	Logical  int32 `json:"logical"`
+
+	// encoded key bounds of the range, only set on a resync event
+	StartKey []byte `json:"start_key,omitempty"`
+	EndKey   []byte `json:"end_key,omitempty"`
+
+	Keys   map[string]interface{} `json:"keys"`
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+
+	// encoded row key
+	key []byte
+}
+
+// decoder decodes rows of a table with its schema.
+type decoder struct {
+	db      string
+	table   string
+	prefix  []byte
+	pks     []*metapb.Column
+	all     []*metapb.Column
+	columns map[uint64]*metapb.Column
+}
+
+func newDecoder(table *metapb.Table) *decoder {
+	d := &decoder{
+		db:      table.GetDbName(),
+		table:   table.GetName(),
+		prefix:  util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId()),
+		all:     table.GetColumns(),
+		columns: make(map[uint64]*metapb.Column),
+	}
+	for _, col := range table.GetColumns() {
+		d.columns[col.GetId()] = col
+		if col.GetPrimaryKey() > 0 {
+			d.pks = append(d.pks, col)
+		}
+	}
+	return d
+}
+
+func jsonValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+// decodeKey decodes the primary key columns from the encoded row key.
+func (d *decoder) decodeKey(key []byte) (map[string]interface{}, error) {
+	if len(key) < len(d.prefix) {
+		return nil, fmt.Errorf("invalid row key %v of table %s.%s", key, d.db, d.table)
+	}
+	buf := key[len(d.prefix):]
+	keys := make(map[string]interface{}, len(d.pks))
+	for _, col := range d.pks {
+		var v interface{}
+		var err error
+		if buf, v, err = util.DecodePrimaryKey2(buf, col); err != nil {
+			return nil, fmt.Errorf("decode pk %s of table %s.%s failed, err[%v]", col.GetName(), d.db, d.table, err)
+		}
+		keys[col.GetName()] = jsonValue(v)
+	}
+	return keys, nil
+}
+
+// decodeRow decodes all columns of a row. value holds the tagged non pk
+// columns, columns unknown to the schema are skipped.
+func (d *decoder) decodeRow(keys map[string]interface{}, value []byte) (map[string]interface{}, error) {
+	row := make(map[string]interface{}, len(d.columns))
+	for name, v := range keys {
+		row[name] = v
+	}
+	for len(value) > 0 {
+		_, _, colId, _, err := encoding.DecodeValueTag(value)
+		if err != nil {
+			return nil, fmt.Errorf("decode value tag of table %s.%s failed, err[%v]", d.db, d.table, err)
+		}
+		col, find := d.columns[uint64(colId)]
+		if !find {
+			_, length, err := encoding.PeekValueLength(value)
+			if err != nil || length == 0 {
+				return nil, fmt.Errorf("skip column %d of table %s.%s failed, err[%v]", colId, d.db, d.table, err)
+			}
+			value = value[length:]
+			continue
+		}
+		var v interface{}
+		if value, v, err = util.DecodeColumnValue(value, col); err != nil {
+			return nil, err
+		}
+		row[col.GetName()] = jsonValue(v)
+	}
+	return row, nil
+}
+
+// decodeEvent decodes the event of a row watch.
+func (d *decoder) decodeEvent(rangeId uint64, evt *watchpb.Event) (*Event, error) {
+	kv := evt.GetKv()
+	if kv == nil || len(kv.GetKey()) == 0 {
+		return nil, fmt.Errorf("invalid row event of range %d", rangeId)
+	}
+	e := &Event{
+		Db:       d.db,
+		Table:    d.table,
+		RangeId:  rangeId,
+		Index:    uint64(kv.GetVersion()),
+		CommitTs: evt.GetCommitTs().GetWallTime(),
+		Logical:  evt.GetCommitTs().GetLogical(),
+		key:      kv.GetKey()[0],
+	}
+	var err error
+	if e.Keys, err = d.decodeKey(e.key); err != nil {
+		return nil, err
+	}
+	if prev := evt.GetPrevKv(); prev != nil {
+		if e.Before, err = d.decodeRow(e.Keys, prev.GetValue()); err != nil {
+			return nil, err
+		}
+	}
+	switch evt.GetType() {
+	case watchpb.EventType_PUT:
+		if e.After, err = d.decodeRow(e.Keys, kv.GetValue()); err != nil {
+			return nil, err
+		}
+		if e.Before != nil {
+			e.Type = EVENT_UPDATE
+		} else {
+			e.Type = EVENT_INSERT
+		}
+	case watchpb.EventType_DELETE:
+		e.Type = EVENT_DELETE
+	default:
+		return nil, fmt.Errorf("invalid event type %v of range %d", evt.GetType(), rangeId)
+	}
+	return e, nil
+}
@@ -0,0 +1,416 @@
+package cdc
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"util/log"
+)
+
+// a minimal kafka producer, only the metadata and produce requests are
+// implemented, which is enough to append the events to a topic.
+
+const (
+	kafkaApiProduce  int16 = 0
+	kafkaApiMetadata int16 = 3
+
+	kafkaProduceVersion  int16 = 3
+	kafkaMetadataVersion int16 = 1
+
+	// wait for all in sync replicas
+	kafkaAcksAll int16 = -1
+)
+
+var (
+	errKafkaShortBuffer = errors.New("kafka response is too short")
+	castagnoliTable     = crc32.MakeTable(crc32.Castagnoli)
+)
+
+type kafkaEncoder struct {
+	buf []byte
+}
+
+func (e *kafkaEncoder) int8(v int8) {
+	e.buf = append(e.buf, byte(v))
+}
+
+func (e *kafkaEncoder) int16(v int16) {
+	e.buf = append(e.buf, byte(uint16(v)>>8), byte(v))
+}
+
+func (e *kafkaEncoder) int32(v int32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *kafkaEncoder) int64(v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *kafkaEncoder) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	e.buf = append(e.buf, b[:n]...)
+}
+
+func (e *kafkaEncoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *kafkaEncoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *kafkaEncoder) varbytes(b []byte) {
+	if b == nil {
+		e.varint(-1)
+		return
+	}
+	e.varint(int64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+type kafkaDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *kafkaDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.buf) < n {
+		d.err = errKafkaShortBuffer
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.next(int(n)))
+}
+
+type kafkaMessage struct {
+	key   []byte
+	value []byte
+}
+
+// encodeRecordBatch encodes the messages as a record batch of magic 2.
+func encodeRecordBatch(msgs []*kafkaMessage, now time.Time) []byte {
+	ts := now.UnixNano() / int64(time.Millisecond)
+
+	// the part covered by the crc
+	body := &kafkaEncoder{}
+	body.int16(0) // attributes, no compression
+	body.int32(int32(len(msgs) - 1))
+	body.int64(ts)
+	body.int64(ts)
+	body.int64(-1) // producer id
+	body.int16(-1) // producer epoch
+	body.int32(-1) // base sequence
+	body.int32(int32(len(msgs)))
+	for i, m := range msgs {
+		rec := &kafkaEncoder{}
+		rec.int8(0)   // attributes
+		rec.varint(0) // timestamp delta
+		rec.varint(int64(i))
+		rec.varbytes(m.key)
+		rec.varbytes(m.value)
+		rec.varint(0) // headers
+		body.varint(int64(len(rec.buf)))
+		body.buf = append(body.buf, rec.buf...)
+	}
+
+	batch := &kafkaEncoder{}
+	batch.int64(0) // base offset
+	batch.int32(int32(4 + 1 + 4 + len(body.buf)))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(2)   // magic
+	batch.int32(int32(crc32.Checksum(body.buf, castagnoliTable)))
+	batch.buf = append(batch.buf, body.buf...)
+	return batch.buf
+}
+
+func kafkaPartition(key []byte, partitions int) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(partitions))
+}
+
+// kafkaSink sends the events as json messages keyed by the row key, the
+// changes of a row go to the same partition and keep their order.
+type kafkaSink struct {
+	conf          *KafkaSinkConfig
+	correlationId int32
+
+	brokers    map[int32]string
+	conns      map[int32]net.Conn
+	partitions []int32
+	leaders    map[int32]int32
+}
+
+func newKafkaSink(conf *KafkaSinkConfig) (*kafkaSink, error) {
+	s := &kafkaSink{
+		conf:  conf,
+		conns: make(map[int32]net.Conn),
+	}
+	if err := s.refreshMetadata(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *kafkaSink) call(conn net.Conn, apiKey, version int16, body []byte) (*kafkaDecoder, error) {
+	s.correlationId++
+	req := &kafkaEncoder{}
+	req.int32(0) // size, filled below
+	req.int16(apiKey)
+	req.int16(version)
+	req.int32(s.correlationId)
+	req.string(s.conf.ClientId)
+	req.buf = append(req.buf, body...)
+	binary.BigEndian.PutUint32(req.buf, uint32(len(req.buf)-4))
+
+	conn.SetDeadline(time.Now().Add(s.conf.Timeout.Duration))
+	if _, err := conn.Write(req.buf); err != nil {
+		return nil, err
+	}
+	var size [4]byte
+	if _, err := io.ReadFull(conn, size[:]); err != nil {
+		return nil, err
+	}
+	resp := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, err
+	}
+	d := &kafkaDecoder{buf: resp}
+	if id := d.int32(); id != s.correlationId {
+		return nil, fmt.Errorf("kafka correlation id %d, expect %d", id, s.correlationId)
+	}
+	return d, nil
+}
+
+func (s *kafkaSink) dial(addr string) (net.Conn, error) {
+	return net.DialTimeout("tcp", addr, s.conf.Timeout.Duration)
+}
+
+func (s *kafkaSink) refreshMetadata() error {
+	var lastErr error
+	for _, addr := range s.conf.Brokers {
+		conn, err := s.dial(addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		err = s.fetchMetadata(conn)
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("fetch kafka metadata failed, err[%v]", lastErr)
+}
+
+func (s *kafkaSink) fetchMetadata(conn net.Conn) error {
+	body := &kafkaEncoder{}
+	body.int32(1)
+	body.string(s.conf.Topic)
+	d, err := s.call(conn, kafkaApiMetadata, kafkaMetadataVersion, body.buf)
+	if err != nil {
+		return err
+	}
+
+	brokers := make(map[int32]string)
+	for n := d.int32(); n > 0 && d.err == nil; n-- {
+		id := d.int32()
+		host := d.string()
+		port := d.int32()
+		d.string() // rack
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	d.int32() // controller id
+
+	var partitions []int32
+	leaders := make(map[int32]int32)
+	for n := d.int32(); n > 0 && d.err == nil; n-- {
+		code := d.int16()
+		topic := d.string()
+		d.int8() // is internal
+		if topic == s.conf.Topic && code != 0 {
+			return fmt.Errorf("kafka topic %s error code %d", topic, code)
+		}
+		for m := d.int32(); m > 0 && d.err == nil; m-- {
+			d.int16() // partition error code
+			id := d.int32()
+			leader := d.int32()
+			for r := d.int32(); r > 0 && d.err == nil; r-- {
+				d.int32()
+			}
+			for r := d.int32(); r > 0 && d.err == nil; r-- {
+				d.int32()
+			}
+			if topic == s.conf.Topic {
+				partitions = append(partitions, id)
+				leaders[id] = leader
+			}
+		}
+	}
+	if d.err != nil {
+		return d.err
+	}
+	if len(partitions) == 0 {
+		return fmt.Errorf("kafka topic %s has no partition", s.conf.Topic)
+	}
+	// the partition of a row must not change between the writes
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	s.brokers, s.partitions, s.leaders = brokers, partitions, leaders
+	return nil
+}
+
+func (s *kafkaSink) conn(broker int32) (net.Conn, error) {
+	if conn, find := s.conns[broker]; find {
+		return conn, nil
+	}
+	addr, find := s.brokers[broker]
+	if !find {
+		return nil, fmt.Errorf("kafka broker %d not found", broker)
+	}
+	conn, err := s.dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[broker] = conn
+	return conn, nil
+}
+
+// reset drops the connections and the metadata after a failure.
+func (s *kafkaSink) reset() {
+	for id, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, id)
+	}
+	s.partitions = nil
+}
+
+func (s *kafkaSink) Write(events []*Event) error {
+	if len(s.partitions) == 0 {
+		if err := s.refreshMetadata(); err != nil {
+			return err
+		}
+	}
+	batches := make(map[int32][]*kafkaMessage)
+	var order []int32
+	for _, e := range events {
+		value, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		msg := &kafkaMessage{key: e.key, value: value}
+		targets := []int32{s.partitions[kafkaPartition(e.key, len(s.partitions))]}
+		if e.Type == EVENT_RESYNC {
+			// the rows of the range are spread over all partitions
+			targets = s.partitions
+		}
+		for _, p := range targets {
+			if _, find := batches[p]; !find {
+				order = append(order, p)
+			}
+			batches[p] = append(batches[p], msg)
+		}
+	}
+	for _, p := range order {
+		if err := s.produce(p, batches[p]); err != nil {
+			log.Warn("kafka produce to %s partition %d failed, err[%v]", s.conf.Topic, p, err)
+			s.reset()
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *kafkaSink) produce(partition int32, msgs []*kafkaMessage) error {
+	conn, err := s.conn(s.leaders[partition])
+	if err != nil {
+		return err
+	}
+	body := &kafkaEncoder{}
+	body.int16(-1) // transactional id
+	body.int16(kafkaAcksAll)
+	body.int32(int32(s.conf.Timeout.Duration / time.Millisecond))
+	body.int32(1)
+	body.string(s.conf.Topic)
+	body.int32(1)
+	body.int32(partition)
+	body.bytes(encodeRecordBatch(msgs, time.Now()))
+	d, err := s.call(conn, kafkaApiProduce, kafkaProduceVersion, body.buf)
+	if err != nil {
+		return err
+	}
+	for n := d.int32(); n > 0 && d.err == nil; n-- {
+		d.string()
+		for m := d.int32(); m > 0 && d.err == nil; m-- {
+			d.int32()
+			code := d.int16()
+			d.int64() // base offset
+			d.int64() // log append time
+			if code != 0 {
+				return fmt.Errorf("kafka produce error code %d", code)
+			}
+		}
+	}
+	return d.err
+}
+
+func (s *kafkaSink) Close() error {
+	s.reset()
+	return nil
+}
@@ -0,0 +1,101 @@
+package cdc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Sink receives the events in order, an event may be written again after
+// a failure or a restart.
+type Sink interface {
+	Write(events []*Event) error
+	Close() error
+}
+
+func NewSink(conf *SinkConfig) (Sink, error) {
+	switch conf.Type {
+	case SINK_FILE:
+		return newFileSink(conf.File.Path)
+	case SINK_KAFKA:
+		return newKafkaSink(&conf.Kafka)
+	case SINK_WEBHOOK:
+		return newWebhookSink(&conf.Webhook), nil
+	default:
+		return nil, fmt.Errorf("invalid sink type %s", conf.Type)
+	}
+}
+
+// fileSink appends the events to a local file as json lines.
+type fileSink struct {
+	f *os.File
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{f: f}, nil
+}
+
+func (s *fileSink) Write(events []*Event) error {
+	w := bufio.NewWriter(s.f)
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
+
+// webhookSink posts the events as a json array, any status other than 2xx
+// is a failure and the events are posted again.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(conf *WebhookSinkConfig) *webhookSink {
+	return &webhookSink{
+		url:    conf.Url,
+		client: &http.Client{Timeout: conf.Timeout.Duration},
+	}
+}
+
+func (s *webhookSink) Write(events []*Event) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s status %d: %s", s.url, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	return nil
+}
@@ -16,6 +16,7 @@ package mysql
 
 import (
 	"bytes"
+	"container/heap"
 	"fmt"
 	"sort"
 
@@ -88,6 +89,12 @@ func (r *resultsetSorter) Less(i, j int) bool {
 	return false
 }
 
+// CompareValue compares two values of the same column in ascending order,
+// nil is less than any value.
+func CompareValue(v1 interface{}, v2 interface{}) int {
+	return cmpValue(v1, v2)
+}
+
 //compare value using asc
 func cmpValue(v1 interface{}, v2 interface{}) int {
 	if v1 == nil && v2 == nil {
@@ -155,3 +162,59 @@ func (r *Resultset) Sort(sk []SortKey) error {
 
 	return nil
 }
+
+// topNHeap is a max heap of the row indexes, the top is the last one of the
+// rows kept.
+type topNHeap struct {
+	s   *resultsetSorter
+	idx []int
+}
+
+func (h *topNHeap) Len() int           { return len(h.idx) }
+func (h *topNHeap) Less(i, j int) bool { return h.s.Less(h.idx[j], h.idx[i]) }
+func (h *topNHeap) Swap(i, j int)      { h.idx[i], h.idx[j] = h.idx[j], h.idx[i] }
+
+func (h *topNHeap) Push(x interface{}) {
+	h.idx = append(h.idx, x.(int))
+}
+
+func (h *topNHeap) Pop() interface{} {
+	n := len(h.idx)
+	x := h.idx[n-1]
+	h.idx = h.idx[:n-1]
+	return x
+}
+
+// TopN sorts the resultset and only keeps the first n rows, the rows are
+// selected by a heap of size n instead of sorting all of them.
+func (r *Resultset) TopN(sk []SortKey, n int) error {
+	s, err := newResultsetSorter(r, sk)
+	if err != nil {
+		return err
+	}
+	if n >= s.Len() {
+		sort.Sort(s)
+		return nil
+	}
+
+	h := &topNHeap{s: s, idx: make([]int, 0, n)}
+	for i := 0; i < s.Len(); i++ {
+		if h.Len() < n {
+			heap.Push(h, i)
+		} else if n > 0 && s.Less(i, h.idx[0]) {
+			h.idx[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+
+	values := make([][]interface{}, h.Len())
+	rowDatas := make([]RowData, h.Len())
+	for i := h.Len() - 1; i >= 0; i-- {
+		j := heap.Pop(h).(int)
+		values[i] = r.Values[j]
+		rowDatas[i] = r.RowDatas[j]
+	}
+	r.Values = values
+	r.RowDatas = rowDatas
+	return nil
+}
@@ -110,3 +110,42 @@ func TestResultsetSort(t *testing.T) {
 	}
 
 }
+
+func TestResultsetTopN(t *testing.T) {
+	newResultset := func() *Resultset {
+		r := &Resultset{FieldNames: map[string]int{"id": 0, "name": 1}}
+		for _, id := range []int64{5, 3, 8, 1, 9, 2} {
+			r.Values = append(r.Values, []interface{}{id, fmt.Sprintf("n%d", id)})
+			r.RowDatas = append(r.RowDatas, RowData(fmt.Sprintf("%d", id)))
+		}
+		return r
+	}
+
+	for _, c := range []struct {
+		direction string
+		n         int
+		expected  []int64
+	}{
+		{SortAsc, 3, []int64{1, 2, 3}},
+		{SortDesc, 2, []int64{9, 8}},
+		{SortAsc, 0, []int64{}},
+		{SortDesc, 10, []int64{9, 8, 5, 3, 2, 1}},
+	} {
+		r := newResultset()
+		if err := r.TopN([]SortKey{{Name: "id", Direction: c.direction}}, c.n); err != nil {
+			t.Fatal(err)
+		}
+		if len(r.Values) != len(c.expected) || len(r.RowDatas) != len(c.expected) {
+			t.Fatalf("%s top %d: unexpected rows %v", c.direction, c.n, r.Values)
+		}
+		for i, id := range c.expected {
+			if r.Values[i][0] != id || string(r.RowDatas[i]) != fmt.Sprintf("%d", id) {
+				t.Fatalf("%s top %d: unexpected rows %v", c.direction, c.n, r.Values)
+			}
+		}
+	}
+
+	if err := newResultset().TopN([]SortKey{{Name: "missing"}}, 1); err == nil {
+		t.Fatal("expected sorting by a missing field failed")
+	}
+}
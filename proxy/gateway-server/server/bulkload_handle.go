@@ -0,0 +1,288 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"proxy/bulkload"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	bulkLoadInputName = "input"
+	bulkLoadJobName   = "job.json"
+
+	BULKLOAD_RUNNING = "running"
+	BULKLOAD_SUCCESS = "success"
+	BULKLOAD_FAILED  = "failed"
+)
+
+var ErrBulkLoadJobNotExist = errors.New("bulk load job not exist")
+
+// BulkLoadJob is persisted in the job dir, so that a failed job could be
+// resumed after the gateway restarts.
+type BulkLoadJob struct {
+	Id         string                  `json:"id"`
+	DbName     string                  `json:"db_name"`
+	TableName  string                  `json:"table_name"`
+	Format     string                  `json:"format"`
+	Regions    int                     `json:"regions"`
+	Properties *bulkload.TableProperty `json:"properties,omitempty"`
+
+	State     string            `json:"state"`
+	Error     string            `json:"error,omitempty"`
+	Progress  bulkload.Progress `json:"progress"`
+	StartTime int64             `json:"start_time"`
+	EndTime   int64             `json:"end_time"`
+
+	loader *bulkload.Loader
+}
+
+type BulkLoadManager struct {
+	lock  sync.Mutex
+	cfg   *BulkLoadConfig
+	proxy *Proxy
+	jobs  map[string]*BulkLoadJob
+}
+
+func NewBulkLoadManager(cfg *BulkLoadConfig, proxy *Proxy) *BulkLoadManager {
+	return &BulkLoadManager{cfg: cfg, proxy: proxy, jobs: make(map[string]*BulkLoadJob)}
+}
+
+func (m *BulkLoadManager) jobDir(id string) string {
+	return filepath.Join(m.cfg.Dir, id)
+}
+
+func (m *BulkLoadManager) saveJob(job *BulkLoadJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(m.jobDir(job.Id), bulkLoadJobName)
+	if err = ioutil.WriteFile(name+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+// Start spools the input to the job dir and loads it in background.
+func (m *BulkLoadManager) Start(job *BulkLoadJob, input io.Reader) error {
+	job.Id = strconv.FormatInt(time.Now().UnixNano(), 10)
+	dir := m.jobDir(job.Id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, bulkLoadInputName))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, input)
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("receive bulk load input failed, err[%v]", err)
+	}
+	if err = m.saveJob(job); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	m.run(job)
+	return nil
+}
+
+// Resume runs a failed job again from its checkpoint.
+func (m *BulkLoadManager) Resume(id string) (*BulkLoadJob, error) {
+	m.lock.Lock()
+	job, find := m.jobs[id]
+	if find && job.State != BULKLOAD_FAILED {
+		m.lock.Unlock()
+		return nil, fmt.Errorf("bulk load job %s is %s", id, job.State)
+	}
+	if find {
+		job.State = BULKLOAD_RUNNING
+	}
+	m.lock.Unlock()
+	if !find {
+		// job id is a part of the path
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, ErrBulkLoadJobNotExist
+		}
+		data, err := ioutil.ReadFile(filepath.Join(m.jobDir(id), bulkLoadJobName))
+		if os.IsNotExist(err) {
+			return nil, ErrBulkLoadJobNotExist
+		}
+		if err != nil {
+			return nil, err
+		}
+		job = new(BulkLoadJob)
+		if err = json.Unmarshal(data, job); err != nil {
+			return nil, err
+		}
+		if job.Id != id {
+			return nil, ErrBulkLoadJobNotExist
+		}
+	}
+	m.run(job)
+	return job, nil
+}
+
+func (m *BulkLoadManager) run(job *BulkLoadJob) {
+	dir := m.jobDir(job.Id)
+	loader := bulkload.NewLoader(bulkload.Config{
+		WorkDir:     dir,
+		Format:      job.Format,
+		Regions:     job.Regions,
+		ChunkRows:   m.cfg.ChunkRows,
+		BatchSize:   m.cfg.BatchSize,
+		Concurrency: m.cfg.Concurrency,
+	}, m.proxy.msCli, m.proxy.dsCli)
+	// GetJob reads the loader of a running job under the lock
+	m.lock.Lock()
+	job.loader = loader
+	job.State = BULKLOAD_RUNNING
+	job.Error = ""
+	job.StartTime = time.Now().Unix()
+	job.EndTime = 0
+	m.jobs[job.Id] = job
+	m.lock.Unlock()
+
+	go func() {
+		err := m.load(job, loader)
+		m.lock.Lock()
+		job.Progress = loader.Progress()
+		job.EndTime = time.Now().Unix()
+		if err != nil {
+			log.Error("bulk load job %s %s.%s failed, err[%v]", job.Id, job.DbName, job.TableName, err)
+			job.State = BULKLOAD_FAILED
+			job.Error = err.Error()
+		} else {
+			log.Info("bulk load job %s %s.%s success, %d rows", job.Id, job.DbName, job.TableName, job.Progress.LoadedRows)
+			job.State = BULKLOAD_SUCCESS
+		}
+		if err = m.saveJob(job); err != nil {
+			log.Warn("save bulk load job %s failed, err[%v]", job.Id, err)
+		}
+		success := job.State == BULKLOAD_SUCCESS
+		m.lock.Unlock()
+		if success {
+			os.Remove(filepath.Join(dir, bulkLoadInputName))
+		}
+	}()
+}
+
+func (m *BulkLoadManager) load(job *BulkLoadJob, loader *bulkload.Loader) error {
+	// the input is read only when the job did not get past the sort phase
+	var input io.Reader
+	f, err := os.Open(filepath.Join(m.jobDir(job.Id), bulkLoadInputName))
+	switch {
+	case err == nil:
+		defer f.Close()
+		input = f
+	case !os.IsNotExist(err):
+		return err
+	}
+	return loader.Run(context.Background(), job.DbName, job.TableName, job.Properties, input)
+}
+
+func (m *BulkLoadManager) GetJob(id string) (*BulkLoadJob, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, find := m.jobs[id]
+	if !find {
+		return nil, false
+	}
+	_job := *job
+	if job.State == BULKLOAD_RUNNING {
+		_job.Progress = job.loader.Progress()
+	}
+	return &_job, true
+}
+
+// handleBulkLoad loads the request body into the table, the body is csv with
+// a header line or newline delimited json, e.g.
+// POST /bulkload?db=db1&table=t1&format=csv&regions=16&properties={"columns":[...]}
+// properties is required only if the table does not exist.
+func (s *Server) handleBulkLoad(w http.ResponseWriter, r *http.Request) {
+	reply := new(Response)
+	defer httpSendReply(w, reply)
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	job := &BulkLoadJob{
+		DbName:    r.URL.Query().Get("db"),
+		TableName: r.URL.Query().Get("table"),
+		Format:    r.URL.Query().Get("format"),
+	}
+	if job.DbName == "" || job.TableName == "" || r.Body == nil {
+		reply.Code = errCommandParse
+		reply.Message = ErrHttpCmdParse.Error()
+		return
+	}
+	if regions := r.URL.Query().Get("regions"); regions != "" {
+		n, err := strconv.Atoi(regions)
+		if err != nil || n < 0 {
+			reply.Code = errCommandParse
+			reply.Message = fmt.Sprintf("invalid regions %s", regions)
+			return
+		}
+		job.Regions = n
+	}
+	if properties := r.URL.Query().Get("properties"); properties != "" {
+		job.Properties = new(bulkload.TableProperty)
+		if err := json.Unmarshal([]byte(properties), job.Properties); err != nil {
+			reply.Code = errCommandParse
+			reply.Message = fmt.Sprintf("invalid properties, err[%v]", err)
+			return
+		}
+	}
+	if err := s.bulkLoad.Start(job, r.Body); err != nil {
+		log.Error("start bulk load %s.%s failed, err[%v]", job.DbName, job.TableName, err)
+		reply.Code = errBulkLoad
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("bulk load job %s %s.%s started", job.Id, job.DbName, job.TableName)
+	reply.Data = job.Id
+}
+
+func (s *Server) handleBulkLoadStatus(w http.ResponseWriter, r *http.Request) {
+	reply := new(Response)
+	defer httpSendReply(w, reply)
+
+	job, find := s.bulkLoad.GetJob(r.FormValue("job"))
+	if !find {
+		reply.Code = errBulkLoad
+		reply.Message = ErrBulkLoadJobNotExist.Error()
+		return
+	}
+	reply.Data = job
+}
+
+func (s *Server) handleBulkLoadResume(w http.ResponseWriter, r *http.Request) {
+	reply := new(Response)
+	defer httpSendReply(w, reply)
+
+	job, err := s.bulkLoad.Resume(r.FormValue("job"))
+	if err != nil {
+		reply.Code = errBulkLoad
+		reply.Message = err.Error()
+		return
+	}
+	log.Info("bulk load job %s %s.%s resumed", job.Id, job.DbName, job.TableName)
+	reply.Data = job.Id
+}
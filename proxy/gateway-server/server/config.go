@@ -32,6 +32,23 @@ const (
 	DefaultMaxSlowLogLen   = 10
 
 	DefaultMaxRawCount = 10000
+
+	DefaultBulkLoadDir         = "./bulkload"
+	DefaultBulkLoadConcurrency = 8
+	DefaultBulkLoadBatchSize   = 2000
+	DefaultBulkLoadChunkRows   = 1000000
+
+	DefaultAutoIncSegmentSize = 1000
+
+	DefaultStmtSummaryMaxDigests   = 3000
+	DefaultStmtSummaryMaxSampleLen = 1024
+	DefaultStmtSummaryPushInterval = time.Minute
+
+	DefaultJoinMaxMemory         = 64 * 1024 * 1024
+	DefaultJoinLookupBatchSize   = 256
+	DefaultJoinLookupConcurrency = 8
+
+	DefaultResourceGroupRefreshInterval = 10 * time.Second
 )
 
 type Config struct {
@@ -47,10 +64,19 @@ type Config struct {
 	Charset  string `toml:"charset,omitempty" json:"charset"`
 
 	Alarm AlarmConfig `toml:"alarm,omitempty" json:"alarm"`
-	Performance PerformConfig `toml:"performance,omitempty" json:"performance"`
-	Cluster     ClusterConfig `toml:"cluster,omitempty" json:"cluster"`
-	Log         LogConfig     `toml:"log,omitempty" json:"log"`
-	Metric      MetricConfig  `toml:"metric,omitempty" json:"metric"`
+	Performance PerformConfig  `toml:"performance,omitempty" json:"performance"`
+	Cluster     ClusterConfig  `toml:"cluster,omitempty" json:"cluster"`
+	Log         LogConfig      `toml:"log,omitempty" json:"log"`
+	Metric      MetricConfig   `toml:"metric,omitempty" json:"metric"`
+	BulkLoad    BulkLoadConfig `toml:"bulkload,omitempty" json:"bulkload"`
+
+	AutoIncrement AutoIncrementConfig `toml:"auto-increment,omitempty" json:"auto-increment"`
+
+	StmtSummary StmtSummaryConfig `toml:"statement-summary,omitempty" json:"statement-summary"`
+
+	Join JoinConfig `toml:"join,omitempty" json:"join"`
+
+	ResourceGroup ResourceGroupConfig `toml:"resource-group,omitempty" json:"resource-group"`
 
 	BenchConfig BenchMarkConfig `toml:"benchmark,omitempty" json:"benchmark"`
 }
@@ -111,6 +137,38 @@ level = "debug"
 interval = "15s"
 # receive metric address, leaves it empty will disable metric.
 address = ""
+
+
+[bulkload]
+# uploaded data and sorted files of bulk load jobs
+dir = "./bulkload"
+# ranges loaded in parallel
+concurrency = 8
+# rows of one insert request
+batch-size = 2000
+# rows sorted in memory
+chunk-rows = 1000000
+
+
+[auto-increment]
+# "unique": ids are cached by segment in the gateway, unique but not increasing across gateways
+# "monotonic": ids are allocated by the master for every insert, increasing across gateways
+mode = "unique"
+# ids fetched from the master at a time in unique mode
+segment-size = 1000
+
+
+[join]
+# bytes of the rows held by a join in the gateway
+max-memory = 67108864
+# keys of the outer rows looked up at a time
+lookup-batch-size = 256
+# keys of a batch looked up at the same time
+lookup-concurrency = 8
+
+[resource-group]
+# interval of pulling the resource groups from the master
+refresh-interval = "10s"
 `
 
 var configFileN *string
@@ -248,6 +306,17 @@ func (c *Config) adjust() error {
 		return err
 	}
 
+	c.BulkLoad.adjust()
+
+	err = c.AutoIncrement.adjust()
+	if err != nil {
+		return err
+	}
+
+	c.StmtSummary.adjust()
+	c.Join.adjust()
+	c.ResourceGroup.adjust()
+
 	return nil
 }
 
@@ -287,6 +356,68 @@ func adjustDuration(v *util.Duration, defValue time.Duration) {
 	}
 }
 
+type BulkLoadConfig struct {
+	Dir         string `toml:"dir,omitempty" json:"dir"`
+	Concurrency int    `toml:"concurrency,omitempty" json:"concurrency"`
+	BatchSize   int    `toml:"batch-size,omitempty" json:"batch-size"`
+	ChunkRows   int    `toml:"chunk-rows,omitempty" json:"chunk-rows"`
+}
+
+func (c *BulkLoadConfig) adjust() {
+	adjustString(&c.Dir, DefaultBulkLoadDir)
+	adjustInt(&c.Concurrency, DefaultBulkLoadConcurrency)
+	adjustInt(&c.BatchSize, DefaultBulkLoadBatchSize)
+	adjustInt(&c.ChunkRows, DefaultBulkLoadChunkRows)
+}
+
+type AutoIncrementConfig struct {
+	Mode        string `toml:"mode,omitempty" json:"mode"`
+	SegmentSize uint32 `toml:"segment-size,omitempty" json:"segment-size"`
+}
+
+func (c *AutoIncrementConfig) adjust() error {
+	adjustString(&c.Mode, AutoIncModeUnique)
+	if c.Mode != AutoIncModeUnique && c.Mode != AutoIncModeMonotonic {
+		return fmt.Errorf("invalid auto-increment.mode %s", c.Mode)
+	}
+	if c.SegmentSize == 0 {
+		c.SegmentSize = DefaultAutoIncSegmentSize
+	}
+	return nil
+}
+
+type StmtSummaryConfig struct {
+	MaxDigests   int           `toml:"max-digests,omitempty" json:"max-digests"`
+	MaxSampleLen int           `toml:"max-sample-len,omitempty" json:"max-sample-len"`
+	PushInterval util.Duration `toml:"push-interval,omitempty" json:"push-interval"`
+}
+
+func (c *StmtSummaryConfig) adjust() {
+	adjustInt(&c.MaxDigests, DefaultStmtSummaryMaxDigests)
+	adjustInt(&c.MaxSampleLen, DefaultStmtSummaryMaxSampleLen)
+	adjustDuration(&c.PushInterval, DefaultStmtSummaryPushInterval)
+}
+
+type JoinConfig struct {
+	MaxMemory         uint64 `toml:"max-memory,omitempty" json:"max-memory"`
+	LookupBatchSize   int    `toml:"lookup-batch-size,omitempty" json:"lookup-batch-size"`
+	LookupConcurrency int    `toml:"lookup-concurrency,omitempty" json:"lookup-concurrency"`
+}
+
+func (c *JoinConfig) adjust() {
+	adjustUint64(&c.MaxMemory, DefaultJoinMaxMemory)
+	adjustInt(&c.LookupBatchSize, DefaultJoinLookupBatchSize)
+	adjustInt(&c.LookupConcurrency, DefaultJoinLookupConcurrency)
+}
+
+type ResourceGroupConfig struct {
+	RefreshInterval util.Duration `toml:"refresh-interval,omitempty" json:"refresh-interval"`
+}
+
+func (c *ResourceGroupConfig) adjust() {
+	adjustDuration(&c.RefreshInterval, DefaultResourceGroupRefreshInterval)
+}
+
 type BenchMarkConfig struct {
 	Type    int    `toml:"type,omitempty" json:"type"`
 	DataLen int    `toml:"data-len,omitempty" json:"data-len"`
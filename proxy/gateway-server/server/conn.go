@@ -58,6 +58,12 @@ type ClientConn struct {
 	stmtId uint32
 
 	stmts map[uint32]*Stmt //prepare相关,client端到proxy的stmt
+
+	// 正在执行的语句, kill时在其他连接的goroutine访问
+	procLock sync.Mutex
+	proc     process
+	// select的最长执行时间(毫秒), 0表示不限制
+	maxExecutionTime uint64
 }
 
 var DEFAULT_CAPABILITY uint32 = mysql.CLIENT_LONG_PASSWORD | mysql.CLIENT_LONG_FLAG |
@@ -292,6 +298,8 @@ func (c *ClientConn) dispatch(data []byte) error {
 		if len(data) > 0 && data[len(data)-1] == 0 {
 			data = data[:len(data)-1]
 		}
+		c.beginStatement(processCommandQuery, string(data))
+		defer c.endStatement()
 		return c.handleQuery(hack.String(data))
 	case mysql.COM_PING:
 		if log.GetFileLogger().IsEnableDebug() {
@@ -239,6 +239,10 @@ func (c *ClientConn) handleAdmin(admin *sqlparser.Admin) error {
 		log.Error("handle admin failed(%v), cmd: %s, args: %s", err, cmd, strings.Join(args, " "))
 		return c.writeError(err)
 	}
+	// 修改类的命令没有结果集
+	if res.Resultset == nil {
+		return c.writeOK(res)
+	}
 
 	return c.writeResultset(res.Status, res.Resultset)
 }
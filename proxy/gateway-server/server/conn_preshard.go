@@ -81,44 +81,3 @@ func (c *ClientConn) preHandleShard(sql string) (bool, error) {
 
 	return true, nil
 }
-
-
-//handle show columns/fields
-func (c *ClientConn) handleShowColumns(sql string, tokens []string,
-	tokensLen int, executeDB *ExecuteDB) error {
-//	var ruleDB string
-//	for i := 0; i < tokensLen; i++ {
-//		tokens[i] = strings.ToLower(tokens[i])
-//		//handle SQL:
-//		//SHOW [FULL] COLUMNS FROM tbl_name [FROM db_name] [like_or_where]
-//		if (strings.ToLower(tokens[i]) == mysql.TK_STR_FIELDS ||
-//			strings.ToLower(tokens[i]) == mysql.TK_STR_COLUMNS) &&
-//			i+2 < tokensLen {
-//			if strings.ToLower(tokens[i+1]) == mysql.TK_STR_FROM {
-//				tableName := strings.Trim(tokens[i+2], "`")
-//				//get the ruleDB
-//				if i+4 < tokensLen && strings.ToLower(tokens[i+1]) == mysql.TK_STR_FROM {
-//					ruleDB = strings.Trim(tokens[i+4], "`")
-//				} else {
-//					ruleDB = c.db
-//				}
-//				showRouter := c.schema.rule
-//				showRule := showRouter.GetRule(ruleDB, tableName)
-//				//this SHOW is sharding SQL
-//				if showRule.Type != router.DefaultRuleType {
-//					if 0 < len(showRule.SubTableIndexs) {
-//						tableIndex := showRule.SubTableIndexs[0]
-//						nodeIndex := showRule.TableToNode[tableIndex]
-//						nodeName := showRule.Nodes[nodeIndex]
-//						tokens[i+2] = fmt.Sprintf("%s_%04d", tableName, tableIndex)
-//						executeDB.sql = strings.Join(tokens, " ")
-//						executeDB.ExecNode = c.schema.nodes[nodeName]
-//						return nil
-//					}
-//				}
-//			}
-//		}
-//	}
-	return nil
-}
-
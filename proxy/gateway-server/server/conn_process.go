@@ -0,0 +1,274 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"proxy/gateway-server/mysql"
+	"proxy/gateway-server/sqlparser"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	processCommandSleep   = "Sleep"
+	processCommandQuery   = "Query"
+	processCommandExecute = "Execute"
+
+	// SHOW PROCESSLIST不带FULL时语句截断的长度
+	processInfoMaxLen = 100
+)
+
+var (
+	ErrQueryKilled  = mysql.NewDefaultError(mysql.ER_QUERY_INTERRUPTED)
+	ErrQueryTimeout = mysql.NewError(mysql.ER_QUERY_INTERRUPTED,
+		"Query execution was interrupted, maximum statement execution time exceeded")
+
+	maxExecutionTimeHint = regexp.MustCompile(`(?i)MAX_EXECUTION_TIME\s*\(\s*(\d+)\s*\)`)
+)
+
+// process is the statement being executed by a client connection, it's
+// shown by SHOW PROCESSLIST and cancelled by KILL.
+type process struct {
+	command string
+	info    string
+	start   time.Time
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stats   *stmtExecStats
+}
+
+// processStmt is SHOW [FULL] PROCESSLIST or KILL [QUERY | CONNECTION] id,
+// they are not supported by the sql parser.
+type processStmt struct {
+	show      bool
+	full      bool
+	killQuery bool
+	id        uint32
+}
+
+func parseProcessStmt(sql string) (*processStmt, bool) {
+	// 只有show和kill开头的语句才需要完整解析
+	tkn := sqlparser.NewStringTokenizer(sql)
+	typ, val := tkn.Scan()
+	for typ == sqlparser.COMMENT {
+		typ, val = tkn.Scan()
+	}
+	if typ != sqlparser.ID {
+		return nil, false
+	}
+	if word := string(bytes.ToLower(val)); word != "show" && word != "kill" {
+		return nil, false
+	}
+
+	p, err := newTokenParser(sql)
+	if err != nil {
+		return nil, false
+	}
+	stmt := new(processStmt)
+	switch {
+	case p.accept(sqlparser.ID, "show"):
+		stmt.show = true
+		stmt.full = p.accept(sqlparser.ID, "full")
+		if !p.accept(sqlparser.ID, "processlist") {
+			return nil, false
+		}
+	case p.accept(sqlparser.ID, "kill"):
+		if p.accept(sqlparser.ID, "query") {
+			stmt.killQuery = true
+		} else {
+			p.accept(sqlparser.ID, "connection")
+		}
+		t := p.next()
+		if t.typ != sqlparser.NUMBER {
+			return nil, false
+		}
+		id, err := strconv.ParseUint(string(t.val), 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		stmt.id = uint32(id)
+	default:
+		return nil, false
+	}
+	if p.pos != len(p.tokens) {
+		return nil, false
+	}
+	return stmt, true
+}
+
+// parseMaxExecutionTime returns the milliseconds of the
+// /*+ MAX_EXECUTION_TIME(n) */ optimizer hint.
+func parseMaxExecutionTime(comments sqlparser.Comments) (uint64, bool) {
+	for _, c := range comments {
+		if !bytes.HasPrefix(c, []byte("/*+")) {
+			continue
+		}
+		m := maxExecutionTimeHint.FindSubmatch(c)
+		if m == nil {
+			continue
+		}
+		if t, err := strconv.ParseUint(string(m[1]), 10, 64); err == nil {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+func (c *ClientConn) beginStatement(command, info string) {
+	stats := new(stmtExecStats)
+	ctx, cancel := context.WithCancel(withStmtExecStats(context.Background(), stats))
+	c.procLock.Lock()
+	c.proc = process{command: command, info: info, start: time.Now(), ctx: ctx, cancel: cancel, stats: stats}
+	c.procLock.Unlock()
+}
+
+func (c *ClientConn) endStatement() {
+	c.procLock.Lock()
+	if c.proc.cancel != nil {
+		c.proc.cancel()
+	}
+	c.proc = process{command: processCommandSleep, start: time.Now()}
+	c.procLock.Unlock()
+}
+
+// statementContext returns the context cancelled when the statement is killed.
+func (c *ClientConn) statementContext() context.Context {
+	c.procLock.Lock()
+	defer c.procLock.Unlock()
+	if c.proc.ctx == nil {
+		return context.Background()
+	}
+	return c.proc.ctx
+}
+
+// statementStats returns the rows counter of the statement, nil if no
+// statement is executing.
+func (c *ClientConn) statementStats() *stmtExecStats {
+	c.procLock.Lock()
+	defer c.procLock.Unlock()
+	return c.proc.stats
+}
+
+// selectContext bounds the select by the MAX_EXECUTION_TIME hint, or the
+// max_execution_time of the session if there is no hint, 0 means no limit.
+func (c *ClientConn) selectContext(stmt *sqlparser.Select) (context.Context, context.CancelFunc) {
+	timeout := c.maxExecutionTime
+	if t, ok := parseMaxExecutionTime(stmt.Comments); ok {
+		timeout = t
+	}
+	if timeout == 0 {
+		return context.WithCancel(c.statementContext())
+	}
+	return context.WithTimeout(c.statementContext(), time.Duration(timeout)*time.Millisecond)
+}
+
+// interruptedError returns the mysql error of a statement killed or timed out.
+func interruptedError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return ErrQueryTimeout
+	case context.Canceled:
+		return ErrQueryKilled
+	}
+	return err
+}
+
+// kill cancels the running statement, and closes the connection unless only
+// the query is killed.
+func (c *ClientConn) kill(query bool) {
+	c.procLock.Lock()
+	if c.proc.cancel != nil {
+		c.proc.cancel()
+	}
+	c.procLock.Unlock()
+	if !query {
+		// 读请求失败后连接退出
+		c.c.Close()
+	}
+}
+
+func (c *ClientConn) processRow(now time.Time, full bool) []interface{} {
+	c.procLock.Lock()
+	command, info, start := c.proc.command, c.proc.info, c.proc.start
+	c.procLock.Unlock()
+
+	state := "executing"
+	if command == processCommandSleep {
+		state = ""
+	}
+	if !full && len(info) > processInfoMaxLen {
+		info = info[:processInfoMaxLen]
+	}
+	return []interface{}{int64(c.connectionId), c.user, c.c.RemoteAddr().String(), c.db,
+		command, int64(now.Sub(start).Seconds()), state, info}
+}
+
+func (c *ClientConn) handleProcessStmt(stmt *processStmt) error {
+	if stmt.show {
+		return c.handleShowProcessList(stmt.full)
+	}
+	target := c.server.findConn(stmt.id)
+	if target == nil {
+		return mysql.NewError(mysql.ER_NO_SUCH_THREAD, fmt.Sprintf("Unknown thread id: %d", stmt.id))
+	}
+	log.Info("connection %d kill connection %d, query only: %v", c.connectionId, stmt.id, stmt.killQuery)
+	if target == c && !stmt.killQuery {
+		c.kill(false)
+		return nil
+	}
+	target.kill(stmt.killQuery)
+	return c.writeOK(nil)
+}
+
+func (c *ClientConn) handleShowProcessList(full bool) error {
+	fieldNames := []string{"Id", "User", "Host", "db", "Command", "Time", "State", "Info"}
+	conns := c.server.processList()
+	now := time.Now()
+	values := make([][]interface{}, 0, len(conns))
+	for _, conn := range conns {
+		values = append(values, conn.processRow(now, full))
+	}
+	// 当前连接一定在列表里
+	rs, err := buildResultset(nil, fieldNames, values)
+	if err != nil {
+		log.Error("build processlist result failed(%v)", err)
+		return err
+	}
+	return c.writeResultset(c.status, rs)
+}
+
+func (s *Server) addConn(c *ClientConn) {
+	s.connLock.Lock()
+	s.conns[c.connectionId] = c
+	s.connLock.Unlock()
+}
+
+func (s *Server) removeConn(c *ClientConn) {
+	s.connLock.Lock()
+	delete(s.conns, c.connectionId)
+	s.connLock.Unlock()
+}
+
+func (s *Server) findConn(id uint32) *ClientConn {
+	s.connLock.RLock()
+	defer s.connLock.RUnlock()
+	return s.conns[id]
+}
+
+// processList returns the connections order by id.
+func (s *Server) processList() []*ClientConn {
+	s.connLock.RLock()
+	conns := make([]*ClientConn, 0, len(s.conns))
+	for _, c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.connLock.RUnlock()
+	sort.Slice(conns, func(i, j int) bool { return conns[i].connectionId < conns[j].connectionId })
+	return conns
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"proxy/gateway-server/sqlparser"
+
+	"golang.org/x/net/context"
+)
+
+func TestParseProcessStmt(t *testing.T) {
+	cases := []struct {
+		sql  string
+		ok   bool
+		stmt processStmt
+	}{
+		{"show processlist", true, processStmt{show: true}},
+		{"SHOW FULL PROCESSLIST", true, processStmt{show: true, full: true}},
+		{"kill 10001", true, processStmt{id: 10001}},
+		{"KILL CONNECTION 10001", true, processStmt{id: 10001}},
+		{"kill query 10002", true, processStmt{killQuery: true, id: 10002}},
+		{"/* comment */ kill query 10002", true, processStmt{killQuery: true, id: 10002}},
+		{"show tables", false, processStmt{}},
+		{"kill query", false, processStmt{}},
+		{"kill query abc", false, processStmt{}},
+		{"kill 1 2", false, processStmt{}},
+		{"select * from show", false, processStmt{}},
+	}
+	for _, c := range cases {
+		stmt, ok := parseProcessStmt(c.sql)
+		if ok != c.ok {
+			t.Fatalf("parse %q: expected %v, actual %v", c.sql, c.ok, ok)
+		}
+		if ok && *stmt != c.stmt {
+			t.Fatalf("parse %q: expected %+v, actual %+v", c.sql, c.stmt, *stmt)
+		}
+	}
+}
+
+func TestParseMaxExecutionTime(t *testing.T) {
+	stmt, err := sqlparser.Parse("select /*+ MAX_EXECUTION_TIME(1000) */ * from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeout, ok := parseMaxExecutionTime(stmt.(*sqlparser.Select).Comments)
+	if !ok || timeout != 1000 {
+		t.Fatalf("expected hint 1000, actual %d %v", timeout, ok)
+	}
+	// 普通注释不是hint
+	if _, ok := parseMaxExecutionTime(sqlparser.Comments{[]byte("/* MAX_EXECUTION_TIME(10) */")}); ok {
+		t.Fatal("expected no hint in plain comment")
+	}
+}
+
+func TestStatementContext(t *testing.T) {
+	c := &ClientConn{maxExecutionTime: 10}
+	c.beginStatement(processCommandQuery, "select 1")
+	ctx, cancel := c.selectContext(&sqlparser.Select{})
+	defer cancel()
+	<-ctx.Done()
+	err := errors.New("rpc error")
+	if interruptedError(ctx, err) != ErrQueryTimeout {
+		t.Fatalf("expected timeout error, actual %v", interruptedError(ctx, err))
+	}
+
+	c.maxExecutionTime = 0
+	ctx, cancel = c.selectContext(&sqlparser.Select{})
+	defer cancel()
+	c.kill(true)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected statement cancelled by kill query")
+	}
+	if interruptedError(ctx, err) != ErrQueryKilled {
+		t.Fatalf("expected killed error, actual %v", interruptedError(ctx, err))
+	}
+	c.endStatement()
+	if c.statementContext().Err() != nil || interruptedError(context.Background(), err) != err {
+		t.Fatal("expected a new statement not cancelled")
+	}
+}
@@ -66,6 +66,8 @@ func (c *ClientConn) handleQuery(sql string) (err error) {
 			metric.GsMetric.SlowLogMetric(sql, delay)
 			golog.Warn("run sql [%v] slow log", sql)
 		}
+		examined, returned := c.statementStats().rows()
+		c.server.proxy.stmts.Record(c.db, sql, err == nil, delay, examined, returned)
 	}()
 
 	sql = strings.TrimRight(sql, ";") //删除sql语句最后的分号
@@ -81,6 +83,18 @@ func (c *ClientConn) handleQuery(sql string) (err error) {
 	//		return nil
 	//	}
 
+	// show processlist和kill同样单独解析
+	if stmt, ok := parseProcessStmt(sql); ok {
+		err = c.handleProcessStmt(stmt)
+		return err
+	}
+	// 其他show语句由元数据返回
+	if isShowStmt(sql) {
+		method = "show"
+		err = c.handleShow(sql)
+		return err
+	}
+
 	var stmt sqlparser.Statement
 	stmt, err = sqlparser.Parse(sql) //解析sql语句,得到的stmt是一个interface
 	if err != nil {
@@ -126,6 +140,11 @@ func (c *ClientConn) handleQuery(sql string) (err error) {
 		err = c.handleExec(stmt, nil,"Truncate")
 	case *sqlparser.Describe:
 		err = c.handleDescribe(v)
+	case *sqlparser.Explain:
+		err = c.handleExplain(v)
+	case *sqlparser.AlterTable:
+		method = "alter"
+		err = c.handleAlterTable(v)
 	default:
 		err = fmt.Errorf("statement %T not support now", v)
 	}
@@ -166,10 +185,15 @@ func (c *ClientConn) handleInsert(stmt *sqlparser.Insert, args []interface{}) er
 	if golog.GetFileLogger().IsEnableDebug() {
 		golog.Debug("table:%v,cols:%v,rows:%v, args:%v", stmt.Table, stmt.Columns, stmt.Rows, args)
 	}
-	ret, err := c.server.proxy.HandleInsert(c.db, stmt, args)
+	ctx := c.statementContext()
+	ret, err := c.server.proxy.HandleInsert(ctx, c.db, stmt, args, c.server.proxy.groups.Match(c.user, c.db, ""))
 	if err != nil {
 		golog.Error("insert failed, err[%v]", err)
-		return c.writeError(err)
+		return c.writeError(interruptedError(ctx, err))
+	}
+	// 只有生成了自增id才更新LAST_INSERT_ID()
+	if ret.InsertId != 0 {
+		c.lastInsertId = int64(ret.InsertId)
 	}
 	golog.Debug("insert success")
 	return c.writeOK(ret)
@@ -182,9 +206,10 @@ func (c *ClientConn) handleDelete(stmt *sqlparser.Delete, args []interface{}) er
 	if golog.GetFileLogger().IsEnableDebug() {
 		golog.Debug("table:%v,where:%v, args:%v", stmt.Table, stmt.Where, args)
 	}
-	ret, err := c.server.proxy.HandleDelete(c.db, stmt, args)
+	ctx := c.statementContext()
+	ret, err := c.server.proxy.HandleDelete(ctx, c.db, stmt, args, c.server.proxy.groups.Match(c.user, c.db, ""))
 	if err != nil {
-		return err
+		return interruptedError(ctx, err)
 	}
 	//TODO:return execut nums
 	return c.writeOK(ret)
@@ -208,6 +233,48 @@ func (c *ClientConn) handleDescribe(stmt *sqlparser.Describe) error {
 	return c.writeResultset(res.Status, res.Resultset)
 }
 
+func (c *ClientConn) handleExplain(stmt *sqlparser.Explain) error {
+	if len(c.db) == 0 {
+		return errors.ErrNoDatabase
+	}
+
+	res, err := c.server.proxy.HandleExplain(c.db, stmt)
+	if err != nil {
+		golog.Error("handle explain failed(%v), sql: %s", err, nstring(stmt))
+		return c.writeError(err)
+	}
+
+	return c.writeResultset(res.Status, res.Resultset)
+}
+
+func (c *ClientConn) handleAlterTable(stmt *sqlparser.AlterTable) error {
+	if len(c.db) == 0 {
+		return errors.ErrNoDatabase
+	}
+
+	res, err := c.server.proxy.HandleAlterTable(c.db, stmt)
+	if err != nil {
+		golog.Error("handle alter table failed(%v), sql: %s", err, nstring(stmt))
+		return c.writeError(err)
+	}
+	return c.writeOK(res)
+}
+
+func (c *ClientConn) handleShow(sql string) error {
+	stmt, err := parseShowStmt(sql)
+	if err != nil {
+		golog.Error("parse show sql:%s, err:%s", sql, err.Error())
+		return err
+	}
+
+	res, err := c.server.proxy.HandleShow(c.db, stmt)
+	if err != nil {
+		golog.Error("handle show failed(%v), sql: %s", err, sql)
+		return c.writeError(err)
+	}
+	return c.writeResultset(c.status, res.Resultset)
+}
+
 func (c *ClientConn) handleTruncate(stmt *sqlparser.Truncate) error {
 	if len(c.db) == 0 {
 		return errors.ErrNoDatabase
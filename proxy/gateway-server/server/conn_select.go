@@ -99,16 +99,22 @@ func (c *ClientConn) writeFieldList(status uint16, fs []*mysql.Field) error {
 
 //处理select语句
 func (c *ClientConn) handleSelect(stmt *sqlparser.Select, args []interface{}) error {
+	// information_schema的虚拟表由元数据返回
+	if _, ok := findInfoSchemaTable(c.db, stmt); ok {
+		return c.handleInfoSchemaSelect(stmt)
+	}
 	if len(c.db) == 0 {
 		return errors.ErrNoDatabase
 	}
 	if golog.GetFileLogger().IsEnableDebug() {
 		golog.Debug("into handleSelect %v", stmt)
 	}
-	ret, err := c.server.proxy.HandleSelect(c.db, stmt, args)
+	ctx, cancel := c.selectContext(stmt)
+	defer cancel()
+	ret, err := c.server.proxy.HandleSelect(ctx, c.db, stmt, args, c.server.proxy.groups.Match(c.user, c.db, ""))
 	if err != nil {
 		golog.Debug("select failed, err[%v]", err)
-		return err
+		return interruptedError(ctx, err)
 	}
 
 	if golog.GetFileLogger().IsEnableDebug() {
@@ -119,6 +125,15 @@ func (c *ClientConn) handleSelect(stmt *sqlparser.Select, args []interface{}) er
 	return c.writeResultset(ret.Status, ret.Resultset)
 }
 
+func (c *ClientConn) handleInfoSchemaSelect(stmt *sqlparser.Select) error {
+	ret, err := c.server.proxy.HandleInfoSchemaSelect(c.db, stmt)
+	if err != nil {
+		golog.Error("select from information_schema failed(%v), sql: %s", err, nstring(stmt))
+		return c.writeError(err)
+	}
+	return c.writeResultset(c.status, ret.Resultset)
+}
+
 func (c *ClientConn) mergeSelectResult(rs []*mysql.Result, stmt *sqlparser.Select) error {
 	var r *mysql.Result
 	var err error
@@ -253,6 +268,14 @@ func (c *ClientConn) handleSimpleSelect(stmt *sqlparser.SimpleSelect) error {
 					cols = append(cols, "database()")
 					quas = append(quas, "database()")
 					vals = append(vals, c.db)
+				} else if strings.EqualFold(LastInsertIdFunc, string(colIns.Name)) {
+					name := "last_insert_id()"
+					if len(colExpr.As) > 0 {
+						name = string(colExpr.As)
+					}
+					cols = append(cols, name)
+					quas = append(quas, name)
+					vals = append(vals, strconv.FormatInt(c.lastInsertId, 10))
 				} else {
 					golog.Error("error sqlparser.FuncExpr not implement type %s", colIns.Name)
 				}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	//"time"
 
@@ -46,6 +47,8 @@ func (c *ClientConn) handleSet(stmt *sqlparser.Set, sql string) (err error) {
 			return c.handleSetNames(stmt.Exprs[0].Expr, stmt.Exprs[1].Expr)
 		}
 		return c.handleSetNames(stmt.Exprs[0].Expr, nil)
+	case `MAX_EXECUTION_TIME`, `@@MAX_EXECUTION_TIME`, `@@SESSION.MAX_EXECUTION_TIME`:
+		return c.handleSetMaxExecutionTime(stmt.Exprs[0].Expr)
 	case `SQL_MODE` :
 			return c.writeOK(nil)
 	case `TRANSACTION` :
@@ -91,6 +94,21 @@ func (c *ClientConn) handleSetAutoCommit(val sqlparser.ValExpr) error {
 	return c.writeOK(nil)
 }
 
+// handleSetMaxExecutionTime sets the milliseconds a select of the session
+// may run, 0 means no limit.
+func (c *ClientConn) handleSetMaxExecutionTime(val sqlparser.ValExpr) error {
+	num, ok := val.(sqlparser.NumVal)
+	if !ok {
+		return fmt.Errorf("Incorrect argument type to variable 'max_execution_time'")
+	}
+	timeout, err := strconv.ParseUint(string(num), 10, 64)
+	if err != nil {
+		return fmt.Errorf("Incorrect argument type to variable 'max_execution_time'")
+	}
+	c.maxExecutionTime = timeout
+	return c.writeOK(nil)
+}
+
 func (c *ClientConn) handleSetNames(ch, ci sqlparser.ValExpr) error {
 	var cid mysql.CollationId
 	var ok bool
@@ -235,6 +235,9 @@ func (c *ClientConn) handleStmtExecute(data []byte) error {
 		}
 	}
 
+	c.beginStatement(processCommandExecute, s.sql)
+	defer c.endStatement()
+
 	var err error
 
 	switch stmt := s.s.(type) {
@@ -121,6 +121,28 @@ func (d *DataBase) FindTable(tableName string) *Table {
 	return t
 }
 
+// UpdateTable replaces the cached table unless the cache already holds a newer
+// schema, the concurrent alters may return out of order.
+func (d *DataBase) UpdateTable(_t *metapb.Table) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	table := NewTable(_t, d.cli, 5*time.Minute)
+	if t, ok := d.tables[_t.GetName()]; ok && t.GetId() == _t.GetId() {
+		if t.GetEpoch().GetConfVer() > _t.GetEpoch().GetConfVer() {
+			return
+		}
+		table.ranges = t.ranges
+	}
+	// the table is cached by the old name if it is renamed
+	for name, t := range d.tables {
+		if t.GetId() == _t.GetId() && name != _t.GetName() {
+			delete(d.tables, name)
+		}
+	}
+	d.tables[table.Name()] = table
+	d.missTables.Delete(table.Name())
+}
+
 func (d *DataBase) AddTable(t *Table) {
 	if t == nil {
 		return
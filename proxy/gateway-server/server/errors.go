@@ -14,15 +14,18 @@ var (
 	ErrInvalidColumn      = errors.New("invalid column")
 	ErrNoRoute            = errors.New("no route")
 	ErrExceedMaxLimit     = errors.New("exceeding the maximum limit")
+	ErrJoinMemoryExceeded = errors.New("join exceeds the memory budget")
 	ErrEmptyRow           = errors.New("empty row")
 	ErrHttpCmdUnknown 	= errors.New("invalid command")
 	ErrHttpCmdParse 	= errors.New("parse error")
 	ErrHttpCmdRun 		= errors.New("run error")
 	ErrHttpCmdEmpty 	= errors.New("command empty")
+	ErrClusterMismatch  = errors.New("cluster id mismatch")
 
 	ErrAffectRows = errors.New("affect rows is not equal")
 	ErrCreateDatabase   = errors.New(" create database err")
 	ErrCreateTable      = errors.New("create table err")
+	ErrBulkLoad         = errors.New("bulk load err")
 )
 
 const (
@@ -34,6 +37,7 @@ const (
 	errCommandEmpty 	= 6
 	errCreateDatabase   = 7
 	errCreateTable      = 8
+	errBulkLoad         = 9
 )
 
 func CodeToErr(code int) error{
@@ -54,6 +58,8 @@ func CodeToErr(code int) error{
 		return ErrCreateDatabase
 	case	errCreateTable  :
 		return ErrCreateTable
+	case	errBulkLoad  :
+		return ErrBulkLoad
 	default:
 		return ErrInternalError
 	}
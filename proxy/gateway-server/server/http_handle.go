@@ -69,6 +69,20 @@ func httpSendReply(w http.ResponseWriter, reply interface{}) error {
 	return nil
 }
 
+// checkCluster rejects the requests sent to the gateway of another cluster,
+// the clients set the cluster id in the header optionally.
+func (s *Server) checkCluster(r *http.Request) error {
+	id := r.Header.Get("fbase-cluster-id")
+	if len(id) == 0 {
+		return nil
+	}
+	if id != strconv.FormatUint(s.proxy.config.Cluster.ID, 10) {
+		log.Warn("request of cluster %s, expect %d", id, s.proxy.config.Cluster.ID)
+		return ErrClusterMismatch
+	}
+	return nil
+}
+
 func (q *Query) commandFieldNameToLower() {
 	if q.Command == nil {
 		return
@@ -136,6 +150,10 @@ func (s *Server) handleKVCommand(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	if err = s.checkCluster(r); err != nil {
+		reply = &Reply{Code: errCommandRun, Message: err.Error()}
+		return
+	}
 	if query, err = httpReadQuery(r); err != nil {
 		log.Error("read query: %v", err)
 		reply = &Reply{Code: errCommandParse, Message: ErrHttpCmdParse.Error()}
@@ -172,6 +190,16 @@ func (s *Server) handleKVCommand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	group := s.proxy.groups.Match("", dbname, query.Sign)
+	var writeBytes uint64
+	if query.Command.Type == "set" {
+		writeBytes = commandValuesSize(query.Command.Values)
+	}
+	if err = group.admit(writeBytes); err != nil {
+		reply = &Reply{Code: errCommandRun, Message: err.Error()}
+		return
+	}
+
 	start := time.Now()
 	var slowLogThreshold util.Duration
 	query.commandFieldNameToLower()
@@ -202,6 +230,13 @@ func (s *Server) handleKVCommand(w http.ResponseWriter, r *http.Request) {
 		log.Error("unknown command")
 		reply = &Reply{Code: errCommandUnknown, Message: ErrHttpCmdUnknown.Error()}
 	}
+	switch {
+	case reply.Code != 0:
+	case commandType == "get":
+		group.chargeRead(uint64(len(reply.Values)))
+	case commandType == "del":
+		group.chargeRead(reply.RowsAffected)
+	}
 
 	delay := time.Since(start)
 	if reply.Code == 0 {
@@ -246,7 +281,17 @@ func (query *Query) getCommand(proxy *Proxy, t *Table) (*Reply, error) {
 		log.Debug("getcommand limit: %v", limit)
 
 		scope := query.parseScope()
-		rowss, err := proxy.doSelect(t, fieldList, matchs, limit, scope)
+		sreq, err := proxy.buildSelectRequest(t, fieldList, matchs, limit, scope)
+		if err != nil {
+			log.Error("getcommand build select request error: %v", err)
+			return nil, err
+		}
+		// 按主键顺序排序时由dataserver按顺序返回, 不需要再排序
+		if pushdown, desc := pkOrder(t, matchs, restOrderColumns(order)); pushdown {
+			sreq.Reverse = desc
+			order = nil
+		}
+		rowss, err := proxy.selectRemote(proxy.ctx, t, sreq)
 
 		if err != nil {
 			log.Error("getcommand doselect error: %v", err)
@@ -293,7 +338,7 @@ func (query *Query) getCommand(proxy *Proxy, t *Table) (*Reply, error) {
 				log.Error("[get] handle parse where error: %v", err)
 				return nil, err
 			}
-			allRows, err = proxy.doSelect(t, fieldList, matchs, nil, nil)
+			allRows, err = proxy.doSelect(proxy.ctx, t, fieldList, matchs, nil, nil)
 			if err != nil {
 				log.Error("select do failed, err[%v]", err)
 				return nil, err
@@ -475,7 +520,7 @@ func (query *Query) setCommand(proxy *Proxy, t *Table) (*Reply, error) {
 	//填充自增id值
 	if len(pkName) > 0 {
 		colMap[pkName] = len(colMap)
-		ids, err := proxy.msCli.GetAutoIncId(t.GetDbId(), t.GetId(), uint32(len(rows)))
+		ids, err := proxy.allocAutoIncIds(t, uint32(len(rows)))
 		if err != nil {
 			log.Error("[insert] table %s.%s get auto_increment value err, %v", db, tableName, err)
 			return nil, err
@@ -490,7 +535,7 @@ func (query *Query) setCommand(proxy *Proxy, t *Table) (*Reply, error) {
 		}
 	}
 
-	affected, duplicateKey, err := proxy.insertRows(t, colMap, rows)
+	affected, duplicateKey, err := proxy.insertRows(proxy.ctx, t, colMap, rows)
 	if err != nil {
 		log.Error("insert error %s- %s:%s", db, tableName, err.Error())
 		return nil, err
@@ -522,7 +567,7 @@ func (query *Query) delCommand(proxy *Proxy, t *Table) (*Reply, error) {
 	}
 
 	// 向dataserver查询
-	affectedRows, err := proxy.doDelete(t, matchs)
+	affectedRows, err := proxy.doDelete(proxy.ctx, t, matchs)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +584,11 @@ func (s *Server) handleTableInfo(w http.ResponseWriter, r *http.Request) {
 	resp := new(Response)
 	defer httpSendReply(w, resp)
 
+	if err := s.checkCluster(r); err != nil {
+		resp.Code = 1
+		resp.Message = err.Error()
+		return
+	}
 	t := s.proxy.router.FindTable(dbname, tname)
 	if t == nil {
 		resp.Code = 1
@@ -795,6 +845,7 @@ func (s *Server) handleCreateTable(w http.ResponseWriter, r *http.Request) {
 			})
 		}
 		properties.Columns = cols
+		properties.Ttl = query.Ttl
 		return properties
 	}()
 
@@ -850,6 +901,30 @@ func (s *Server) handleMetricConfigSet(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
+func (s *Server) handleResourceGroupList(w http.ResponseWriter, r *http.Request) {
+	reply := new(Response)
+	defer httpSendReply(w, reply)
+
+	reply.Data = s.proxy.groups.List()
+}
+
+func (s *Server) handleStatementSummary(w http.ResponseWriter, r *http.Request) {
+	reply := new(Response)
+	defer httpSendReply(w, reply)
+
+	var top int
+	if value := r.FormValue("top"); len(value) > 0 {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			reply.Code = errCommandParse
+			reply.Message = fmt.Sprintf("invalid top %s", value)
+			return
+		}
+		top = n
+	}
+	reply.Data = s.proxy.stmts.Top(r.FormValue("db"), r.FormValue("order"), top)
+}
+
 func httpReadCreateDatabase(r *http.Request) (*CreateDatabase, error) {
 	var err error
 
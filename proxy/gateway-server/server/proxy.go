@@ -23,6 +23,16 @@ type Proxy struct {
 	wg          sync.WaitGroup
 	ctx         context.Context
 	cancel      context.CancelFunc
+
+	// 自增id缓存, key为table id
+	idLock     sync.Mutex
+	idSegments map[uint64]*idSegment
+
+	// 资源组, 按用户、数据库或者Sign限流
+	groups *ResourceGroups
+
+	// 按sql指纹聚合的语句统计
+	stmts *StmtSummary
 }
 
 func NewProxy(msAddrs []string, config *Config) *Proxy {
@@ -52,6 +62,8 @@ func NewProxy(msAddrs []string, config *Config) *Proxy {
 		maxWorkNum: config.Performance.MaxWorkNum,
 		taskQueues: taskQueues,
 		workRecover: make(chan int, config.Performance.MaxWorkNum),
+		groups:      NewResourceGroups(),
+		stmts:       NewStmtSummary(config.StmtSummary.MaxDigests, config.StmtSummary.MaxSampleLen),
 	}
 
 	for i, queue := range taskQueues {
@@ -60,6 +72,10 @@ func NewProxy(msAddrs []string, config *Config) *Proxy {
 	}
 	proxy.wg.Add(1)
 	go proxy.workMonitor()
+	proxy.wg.Add(1)
+	go proxy.pushStmtSummary(config.StmtSummary.PushInterval.Duration)
+	proxy.wg.Add(1)
+	go proxy.refreshResourceGroups(config.ResourceGroup.RefreshInterval.Duration)
 	return proxy
 }
 
@@ -89,6 +89,8 @@ func (p *Proxy) HandleAdmin(db string, cmd string, args []string) (*mysql.Result
 	switch cmd {
 	case "route":
 		return p.handleAdminRoute(db, args)
+	case "group":
+		return p.handleAdminGroup(args)
 	}
 
 	return nil, fmt.Errorf("not implement")
@@ -199,3 +201,44 @@ func formatRouteKey(key []byte) []byte {
 	base64.StdEncoding.Encode(dst, key)
 	return dst
 }
+
+// Usage
+// 资源组在master上配置, 列举本gateway上的资源组及统计:
+// 	  admin group('show')
+func (p *Proxy) handleAdminGroup(args []string) (*mysql.Result, error) {
+	switch args[0] {
+	case "show":
+		return p.handleAdminGroupShow()
+	default:
+		return nil, fmt.Errorf("admin group: unknown subcommand(%v)", args[0])
+	}
+}
+
+func (p *Proxy) handleAdminGroupShow() (*mysql.Result, error) {
+	fieldNames := []string{"Name", "Kind", "Key", "QPS", "ReadRows", "WriteBytes",
+		"Requests", "Rejected", "RowsRead", "BytesWritten"}
+	groups := p.groups.List()
+	if len(groups) == 0 {
+		return &mysql.Result{
+			Status:       0,
+			AffectedRows: 0,
+			Resultset:    newEmptyResultSet(fieldNames),
+		}, nil
+	}
+
+	values := make([][]interface{}, len(groups))
+	for i, g := range groups {
+		values[i] = []interface{}{g.Name, g.Kind, g.Key, g.QPS, g.ReadRows, g.WriteBytes,
+			g.Requests, g.Rejected, g.RowsRead, g.BytesWritten}
+	}
+	rs, err := buildResultset(nil, fieldNames, values)
+	if err != nil {
+		log.Error("build admin group show result failed(%v), columns: %v, values: %v", err, fieldNames, values)
+		return nil, err
+	}
+	return &mysql.Result{
+		Status:       0,
+		AffectedRows: 0,
+		Resultset:    rs,
+	}, nil
+}
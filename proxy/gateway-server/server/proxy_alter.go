@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+
+	"model/pkg/metapb"
+	"model/pkg/mspb"
+	"proxy/gateway-server/mysql"
+	"proxy/gateway-server/sqlparser"
+	"util/log"
+)
+
+var alterDataTypes = map[string]metapb.DataType{
+	"tinyint":   metapb.DataType_Tinyint,
+	"smallint":  metapb.DataType_Smallint,
+	"int":       metapb.DataType_Int,
+	"integer":   metapb.DataType_Int,
+	"bigint":    metapb.DataType_BigInt,
+	"float":     metapb.DataType_Float,
+	"double":    metapb.DataType_Double,
+	"char":      metapb.DataType_Varchar,
+	"varchar":   metapb.DataType_Varchar,
+	"binary":    metapb.DataType_Binary,
+	"date":      metapb.DataType_Date,
+	"timestamp": metapb.DataType_TimeStamp,
+}
+
+// alterColumnRequest converts the alter statement to the column change sent
+// to the master, the table ids are filled in by the caller.
+func alterColumnRequest(stmt *sqlparser.AlterTable) (*mspb.AlterColumnRequest, error) {
+	req := &mspb.AlterColumnRequest{Name: string(stmt.Column)}
+	switch stmt.Action {
+	case sqlparser.AST_DROP_COLUMN:
+		req.Type = mspb.AlterColumnType_DropColumn
+	case sqlparser.AST_RENAME_COLUMN:
+		req.Type = mspb.AlterColumnType_RenameColumn
+		req.Column = &metapb.Column{Name: string(stmt.NewName)}
+	case sqlparser.AST_SET_DEFAULT:
+		req.Type = mspb.AlterColumnType_SetDefault
+		req.Column = new(metapb.Column)
+		switch v := stmt.Default.(type) {
+		case sqlparser.NumVal:
+			req.Column.DefaultValue = []byte(v)
+		case sqlparser.StrVal:
+			req.Column.DefaultValue = []byte(v)
+		}
+	case sqlparser.AST_DROP_DEFAULT:
+		req.Type = mspb.AlterColumnType_DropDefault
+	case sqlparser.AST_MODIFY_COLUMN:
+		col, err := alterColumnType(stmt.Type)
+		if err != nil {
+			return nil, err
+		}
+		req.Type = mspb.AlterColumnType_ModifyColumn
+		req.Column = col
+	default:
+		return nil, fmt.Errorf("unsupported alter table action %s", stmt.Action)
+	}
+	return req, nil
+}
+
+func alterColumnType(typ *sqlparser.ColumnType) (*metapb.Column, error) {
+	dataType, ok := alterDataTypes[string(typ.Type)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported column type %s", string(typ.Type))
+	}
+	col := &metapb.Column{DataType: dataType, Unsigned: typ.Unsigned, Nullable: !typ.NotNull}
+	var size []int32
+	for _, n := range typ.Size {
+		v, err := strconv.ParseInt(string(n), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of column type %s", string(typ.Type))
+		}
+		size = append(size, int32(v))
+	}
+	if len(size) > 0 {
+		switch dataType {
+		case metapb.DataType_Varchar:
+			col.Scale = size[0]
+		case metapb.DataType_Float, metapb.DataType_Double:
+			col.Precision = size[0]
+			if len(size) > 1 {
+				col.Scale = size[1]
+			}
+		}
+	}
+	return col, nil
+}
+
+// HandleAlterTable changes the column on the master and refreshes the table
+// cache with the returned schema.
+func (p *Proxy) HandleAlterTable(db string, stmt *sqlparser.AlterTable) (*mysql.Result, error) {
+	// the database name is ignored, the table is in the current database
+	table := string(stmt.Table.Name)
+	t := p.router.FindTable(db, table)
+	if t == nil {
+		log.Error("[alter] table %s.%s doesn't exist", db, table)
+		return nil, fmt.Errorf("Table '%s.%s' doesn't exist", db, table)
+	}
+	req, err := alterColumnRequest(stmt)
+	if err != nil {
+		return nil, err
+	}
+	req.DbId = t.GetDbId()
+	req.TableId = t.GetId()
+	if err = p.router.alterColumnToRemote(req); err != nil {
+		log.Error("[alter] table %s.%s column %s failed, err[%v]", db, table, req.Name, err)
+		return nil, err
+	}
+	return &mysql.Result{}, nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"testing"
+
+	"model/pkg/metapb"
+	"model/pkg/mspb"
+	"proxy/gateway-server/sqlparser"
+)
+
+func parseAlterColumn(t *testing.T, sql string) (*mspb.AlterColumnRequest, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		t.Fatalf("parse %s error: %v", sql, err)
+	}
+	return alterColumnRequest(stmt.(*sqlparser.AlterTable))
+}
+
+func TestAlterColumnRequest(t *testing.T) {
+	req, err := parseAlterColumn(t, "alter table db.t drop column c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Type != mspb.AlterColumnType_DropColumn || req.Name != "c" {
+		t.Fatalf("parse drop column error: %v", req)
+	}
+
+	req, err = parseAlterColumn(t, "alter table `t` rename column a to b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Type != mspb.AlterColumnType_RenameColumn || req.Name != "a" || req.Column.GetName() != "b" {
+		t.Fatalf("parse rename column error: %v", req)
+	}
+
+	req, err = parseAlterColumn(t, "alter table t alter column c set default -10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Type != mspb.AlterColumnType_SetDefault || string(req.Column.GetDefaultValue()) != "-10" {
+		t.Fatalf("parse set default error: %v", req)
+	}
+
+	req, err = parseAlterColumn(t, "alter table t alter c drop default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Type != mspb.AlterColumnType_DropDefault || req.Name != "c" {
+		t.Fatalf("parse drop default error: %v", req)
+	}
+
+	req, err = parseAlterColumn(t, "alter table t modify column c varchar(64) not null")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Type != mspb.AlterColumnType_ModifyColumn || req.Column.GetDataType() != metapb.DataType_Varchar ||
+		req.Column.GetScale() != 64 || req.Column.GetNullable() {
+		t.Fatalf("parse modify column error: %v", req)
+	}
+
+	req, err = parseAlterColumn(t, "alter table t modify c bigint unsigned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Column.GetDataType() != metapb.DataType_BigInt || !req.Column.GetUnsigned() || !req.Column.GetNullable() {
+		t.Fatalf("parse modify column error: %v", req)
+	}
+
+	// 不支持的列类型
+	if _, err = parseAlterColumn(t, "alter table t modify c json"); err == nil {
+		t.Fatal("modify column to json expected error")
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"pkg-go/ms_client"
+	"util/log"
+)
+
+const (
+	// 每次插入都向master申请, 所有gateway分配的id全局递增
+	AutoIncModeMonotonic = "monotonic"
+	// gateway缓存id段, 只保证唯一, 不同gateway分配的id不保证递增
+	AutoIncModeUnique = "unique"
+)
+
+// idSegment caches the auto increment ids of a table fetched from the master,
+// the next segment is prefetched in background when half of the ids is used.
+type idSegment struct {
+	cli     client.Client
+	dbId    uint64
+	tableId uint64
+	size    uint32
+
+	lock     sync.Mutex
+	ids      []uint64
+	next     []uint64
+	fetching bool
+}
+
+func newIdSegment(cli client.Client, dbId, tableId uint64, size uint32) *idSegment {
+	return &idSegment{cli: cli, dbId: dbId, tableId: tableId, size: size}
+}
+
+func (s *idSegment) alloc(n uint32) ([]uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if uint32(len(s.ids)) < n && len(s.next) > 0 {
+		s.ids = append(s.ids, s.next...)
+		s.next = nil
+	}
+	if uint32(len(s.ids)) < n {
+		size := s.size
+		if n-uint32(len(s.ids)) > size {
+			size = n - uint32(len(s.ids))
+		}
+		ids, err := s.cli.GetAutoIncId(s.dbId, s.tableId, size)
+		if err != nil {
+			return nil, err
+		}
+		s.ids = append(s.ids, ids...)
+		if uint32(len(s.ids)) < n {
+			return nil, fmt.Errorf("get auto increment id size %d less than %d", len(s.ids), n)
+		}
+	}
+	ids := make([]uint64, n)
+	copy(ids, s.ids)
+	s.ids = s.ids[n:]
+
+	if uint32(len(s.ids)) < s.size/2 && len(s.next) == 0 && !s.fetching {
+		s.fetching = true
+		go s.prefetch()
+	}
+	return ids, nil
+}
+
+func (s *idSegment) prefetch() {
+	ids, err := s.cli.GetAutoIncId(s.dbId, s.tableId, s.size)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.fetching = false
+	if err != nil {
+		log.Warn("prefetch table[%d] auto increment id failed, err[%v]", s.tableId, err)
+		return
+	}
+	s.next = ids
+}
+
+// allocAutoIncIds returns n auto increment ids of the table, an empty mode
+// is the default unique mode.
+func (p *Proxy) allocAutoIncIds(t *Table, n uint32) ([]uint64, error) {
+	conf := p.config.AutoIncrement
+	if conf.Mode == AutoIncModeMonotonic {
+		return p.msCli.GetAutoIncId(t.GetDbId(), t.GetId(), n)
+	}
+	size := conf.SegmentSize
+	if size == 0 {
+		size = DefaultAutoIncSegmentSize
+	}
+	p.idLock.Lock()
+	if p.idSegments == nil {
+		p.idSegments = make(map[uint64]*idSegment)
+	}
+	s, ok := p.idSegments[t.GetId()]
+	if !ok {
+		s = newIdSegment(p.msCli, t.GetDbId(), t.GetId(), size)
+		p.idSegments[t.GetId()] = s
+	}
+	p.idLock.Unlock()
+	return s.alloc(n)
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"model/pkg/metapb"
+	"pkg-go/ms_client"
+)
+
+type fakeIdClient struct {
+	client.Client
+	lock  sync.Mutex
+	next  uint64
+	calls int
+}
+
+func (c *fakeIdClient) GetAutoIncId(dbId, tableId uint64, size uint32) ([]uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls++
+	ids := make([]uint64, size)
+	for i := range ids {
+		c.next++
+		ids[i] = c.next
+	}
+	return ids, nil
+}
+
+func (c *fakeIdClient) getCalls() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.calls
+}
+
+func TestIdSegmentAlloc(t *testing.T) {
+	cli := &fakeIdClient{}
+	s := newIdSegment(cli, 1, 1, 10)
+	var last uint64
+	for i := 0; i < 20; i++ {
+		ids, err := s.alloc(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 3 {
+			t.Fatalf("expected 3 ids, actual %v", ids)
+		}
+		for _, id := range ids {
+			if id <= last {
+				t.Fatalf("expected id increasing in one gateway, %d after %d", id, last)
+			}
+			last = id
+		}
+		// 等待预取完成
+		time.Sleep(10 * time.Millisecond)
+	}
+	// 60个id, 每段10个, 预取的段不超过一段
+	if calls := cli.getCalls(); calls < 6 || calls > 7 {
+		t.Fatalf("unexpected fetch times %d", calls)
+	}
+
+	// 超过段大小的批量插入
+	ids, err := s.alloc(25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 25 {
+		t.Fatalf("expected 25 ids, actual %d", len(ids))
+	}
+}
+
+func TestAllocAutoIncIdsDefaultConfig(t *testing.T) {
+	// 未调整的配置: mode为空按unique处理, id段在首次分配时创建
+	p := &Proxy{msCli: &fakeIdClient{}, config: &Config{}}
+	table := &Table{Table: &metapb.Table{DbId: 1, Id: 1}}
+	for i := uint64(0); i < 3; i++ {
+		ids, err := p.allocAutoIncIds(table, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 2 || ids[0] != 2*i+1 || ids[1] != 2*i+2 {
+			t.Fatalf("unexpected ids %v", ids)
+		}
+	}
+}
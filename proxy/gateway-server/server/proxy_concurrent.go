@@ -181,7 +181,7 @@ func (it *SelectTask) init(proxy *Proxy, table *Table, fieldList []*kvrpcpb.Sele
 }
 
 func (it *SelectTask) Do() {
-	rows, err := it.p.doSelect(it.table, it.fieldList, it.matches, nil, nil)
+	rows, err := it.p.doSelect(it.p.ctx, it.table, it.fieldList, it.matches, nil, nil)
 	if err != nil {
 		log.Error("getcommand doselect error: %v", err)
 		it.done <- err
@@ -10,10 +10,12 @@ import (
 	"model/pkg/timestamp"
 	"util/log"
 	"proxy/store/dskv"
+
+	"golang.org/x/net/context"
 )
 
 // HandleDelete handle delete
-func (p *Proxy) HandleDelete(db string, stmt *sqlparser.Delete, args []interface{}) (*mysql.Result, error) {
+func (p *Proxy) HandleDelete(ctx context.Context, db string, stmt *sqlparser.Delete, args []interface{}, group *ResourceGroup) (*mysql.Result, error) {
 	//var parseTime time.Time
 	//start := time.Now()
 	//defer func() {
@@ -48,18 +50,25 @@ func (p *Proxy) HandleDelete(db string, stmt *sqlparser.Delete, args []interface
 		log.Debug("matchs %v", matchs)
 	}
 
+	if err := group.admit(0); err != nil {
+		return nil, err
+	}
+
 	//parseTime = time.Now()
-	affectedRows, err := p.doDelete(t, matchs)
+	affectedRows, err := p.doDelete(ctx, t, matchs)
 	if err != nil {
 		return nil, err
 	}
+	// 删除需要先扫描匹配的行
+	group.chargeRead(affectedRows)
+	chargeExamined(ctx, affectedRows)
 	ret := new(mysql.Result)
 	ret.AffectedRows = affectedRows
 	ret.Status = 0
 	return ret, nil
 }
 
-func (p *Proxy) doDelete(t *Table, matches []Match) (affected uint64, err error) {
+func (p *Proxy) doDelete(ctx context.Context, t *Table, matches []Match) (affected uint64, err error) {
 	pbMatches, err := makePBMatches(t, matches)
 	if err != nil {
 		log.Error("[delete]covert where matches failed(%v), Table: %s.%s", err, t.DbName(), t.Name())
@@ -78,7 +87,7 @@ func (p *Proxy) doDelete(t *Table, matches []Match) (affected uint64, err error)
 		WhereFilters: pbMatches,
 		Timestamp:    &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
 	}
-	affected, err = p.deleteRemote(t.DbName(), t.Name(), dreq)
+	affected, err = p.deleteRemote(ctx, t.DbName(), t.Name(), dreq)
 	if err != nil {
 		log.Error("[delete]delete failed. err: %v, key: %v, scope: %v", err, key, scope)
 	} else {
@@ -89,7 +98,7 @@ func (p *Proxy) doDelete(t *Table, matches []Match) (affected uint64, err error)
 	return
 }
 
-func (p *Proxy) deleteRemote(db, table string, req *kvrpcpb.DeleteRequest) (uint64, error) {
+func (p *Proxy) deleteRemote(ctx context.Context, db, table string, req *kvrpcpb.DeleteRequest) (uint64, error) {
 	t := p.router.FindTable(db, table)
 	if t == nil {
 		return 0, ErrNotExistTable
@@ -97,6 +106,7 @@ func (p *Proxy) deleteRemote(db, table string, req *kvrpcpb.DeleteRequest) (uint
 	proxy := dskv.GetKvProxy()
 	defer dskv.PutKvProxy(proxy)
 	proxy.Init(p.dsCli, p.clock, t.ranges, client.WriteTimeout, client.ReadTimeoutShort)
+	proxy.Ctx = ctx
 
 	// single delete
 	if len(req.Key) > 0 {
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"proxy/gateway-server/mysql"
+	"proxy/gateway-server/sqlparser"
+	"proxy/store/dskv"
+	"util"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	ExplainPointGet  = "point get"
+	ExplainRangeScan = "range scan"
+	ExplainFullScan  = "full scan"
+
+	// ranges listed one per row, the rest are only counted
+	maxExplainRanges = 20
+)
+
+var matchOperators = map[kvrpcpb.MatchType]string{
+	kvrpcpb.MatchType_Equal:         "=",
+	kvrpcpb.MatchType_NotEqual:      "!=",
+	kvrpcpb.MatchType_Less:          "<",
+	kvrpcpb.MatchType_LessOrEqual:   "<=",
+	kvrpcpb.MatchType_Larger:        ">",
+	kvrpcpb.MatchType_LargerOrEqual: ">=",
+}
+
+// HandleExplain shows how a select is routed and executed without running it
+func (p *Proxy) HandleExplain(db string, stmt *sqlparser.Explain) (*mysql.Result, error) {
+	sel, ok := stmt.Select.(*sqlparser.Select)
+	if !ok {
+		return nil, fmt.Errorf("explain only supports select from a table")
+	}
+	if isJoinSelect(sel) {
+		return nil, fmt.Errorf("explain doesn't support join")
+	}
+	parser := &StmtParser{}
+
+	tableName := parser.parseTable(sel)
+	t := p.router.FindTable(db, tableName)
+	if t == nil {
+		log.Error("[explain] table %s.%s doesn.t exist", db, tableName)
+		return nil, fmt.Errorf("Table '%s.%s' doesn't exist", db, tableName)
+	}
+
+	cols, err := parser.parseSelectCols(sel)
+	if err != nil {
+		log.Error("[explain] parse colum error: %v", err)
+		return nil, fmt.Errorf("handle explain parseColumn err %s", err.Error())
+	}
+	fieldList, err := makeFieldList(t, cols)
+	if err != nil {
+		log.Error("[explain] find %s.%s field list error(%s), ", t.DbName(), t.Name(), err)
+		return nil, err
+	}
+
+	var matchs []Match
+	if sel.Where != nil {
+		if matchs, err = parser.parseWhere(sel.Where); err != nil {
+			log.Error("handle explain parse where error(%v)", err.Error())
+			return nil, err
+		}
+	}
+	pbMatches, err := makePBMatches(t, matchs)
+	if err != nil {
+		return nil, err
+	}
+
+	var limit *Limit
+	if sel.Limit != nil {
+		offset, count, err := parseLimit(sel.Limit)
+		if err != nil {
+			return nil, err
+		}
+		if count > DefaultMaxRawCount {
+			return nil, ErrExceedMaxLimit
+		}
+		limit = &Limit{offset: offset, rowCount: count}
+	}
+	orderPushed, desc := pkOrder(t, matchs, sqlOrderColumns(sel.OrderBy))
+	scanLimit := limit
+	if len(sel.OrderBy) > 0 && !orderPushed {
+		scanLimit = nil
+	}
+	pbLimit, err := makePBLimit(p, scanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	key, scope, err := findPKScope(t, pbMatches)
+	if err != nil {
+		log.Error("[explain]get pk scope failed(%v), Table: %s.%s", err, t.DbName(), t.Name())
+		return nil, err
+	}
+
+	var values [][]interface{}
+	add := func(item, format string, args ...interface{}) {
+		values = append(values, []interface{}{item, fmt.Sprintf(format, args...)})
+	}
+
+	aggre := len(fieldList) > 0 && fieldList[0].Typ == kvrpcpb.SelectField_AggreFunction
+	columns, err := fieldList2ColNames(fieldList)
+	if err != nil {
+		return nil, err
+	}
+
+	add("table", "%s.%s", t.DbName(), t.Name())
+	add("fields", "%s", strings.Join(columns, ", "))
+	access := explainAccess(t, key, scope)
+	add("access", "%s", access)
+	if key != nil {
+		add("key", "%s", formatRouteKey(key))
+		scope = &kvrpcpb.Scope{Start: key, Limit: nextComparableBytes(key)}
+	} else {
+		add("scope", "[%s, %s)", formatRouteKey(scope.Start), formatRouteKey(scope.Limit))
+	}
+	add("pushed filters", "%s", formatMatches(pbMatches))
+	if sel.Having != nil {
+		add("proxy filters", "having %s, not evaluated", nstring(sel.Having.Expr))
+	} else {
+		add("proxy filters", "none")
+	}
+
+	switch {
+	case key != nil:
+		add("execution", "one request to the range holding the key")
+	case aggre:
+		add("execution", "aggregate functions run on every range in parallel, results merged in proxy")
+	case orderPushed && desc:
+		add("execution", "ranges are scanned one by one in reverse key order until enough rows are returned")
+	default:
+		add("execution", "ranges are scanned one by one in key order until enough rows are returned")
+	}
+	if aggre {
+		add("limit", "none, one row is returned")
+	} else if scanLimit != nil {
+		add("limit", "offset %d count %d pushed to data-servers, proxy stops after %d rows", pbLimit.GetOffset(), pbLimit.GetCount(), pbLimit.GetCount())
+	} else if limit != nil {
+		add("limit", "offset %d count %d applied in proxy after sorting, count %d (max limit) pushed to data-servers", limit.offset, limit.rowCount, pbLimit.GetCount())
+	} else {
+		add("limit", "count %d (max limit) pushed to data-servers", pbLimit.GetCount())
+	}
+	orderBy := strings.TrimPrefix(nstring(sel.OrderBy), " order by ")
+	if orderPushed && desc {
+		add("order by", "%s pushed down, rows are in reverse primary key order", orderBy)
+	} else if orderPushed {
+		add("order by", "%s pushed down, rows are in primary key order", orderBy)
+	} else if len(sel.OrderBy) > 0 && limit != nil {
+		add("order by", "%s top %d rows kept in proxy after the rows are merged", orderBy, limit.offset+limit.rowCount)
+	} else if len(sel.OrderBy) > 0 {
+		add("order by", "%s sorted in proxy after the rows are merged", orderBy)
+	} else {
+		add("order by", "none, rows are in primary key order")
+	}
+	if len(sel.GroupBy) > 0 {
+		add("group by", "%s not supported, the select fails", strings.TrimPrefix(nstring(sel.GroupBy), " group by "))
+	}
+
+	routes, total, nodeIds, err := explainRoutes(t, scope)
+	if err != nil {
+		return nil, err
+	}
+	bo := dskv.NewBackoffer(dskv.MsMaxBackoff, context.Background())
+	nodes := make([]string, 0, len(nodeIds))
+	for _, id := range nodeIds {
+		addr, _ := t.ranges.GetNodeAddr(bo, id)
+		nodes = append(nodes, fmt.Sprintf("%d(%s)", id, addr))
+	}
+	add("ranges", "%d", total)
+	add("nodes", "%s", strings.Join(nodes, ", "))
+	for _, r := range routes {
+		addr, _ := t.ranges.GetNodeAddr(bo, r.NodeId)
+		add("range", "%d [%s, %s) leader %d %s", r.Region.Id, formatRouteKey(r.StartKey), formatRouteKey(r.EndKey), r.NodeId, addr)
+	}
+	if total > len(routes) {
+		add("range", "... %d more", total-len(routes))
+	}
+
+	rs, err := buildResultset(nil, []string{"Item", "Info"}, values)
+	if err != nil {
+		log.Error("build explain result failed(%v), values: %v", err, values)
+		return nil, err
+	}
+	return &mysql.Result{
+		Status:       0,
+		AffectedRows: 0,
+		Resultset:    rs,
+	}, nil
+}
+
+func explainAccess(t *Table, key []byte, scope *kvrpcpb.Scope) string {
+	if key != nil {
+		return ExplainPointGet
+	}
+	prefix := util.EncodeStorePrefix(util.Store_Prefix_KV, t.GetId())
+	if bytes.Equal(scope.Start, prefix) && bytes.Equal(scope.Limit, nextComparableBytes(prefix)) {
+		return ExplainFullScan
+	}
+	return ExplainRangeScan
+}
+
+func formatMatches(matches []*kvrpcpb.Match) string {
+	if len(matches) == 0 {
+		return "none"
+	}
+	conds := make([]string, 0, len(matches))
+	for _, m := range matches {
+		value := string(m.Threshold)
+		switch m.Column.GetDataType() {
+		case metapb.DataType_Varchar, metapb.DataType_Binary, metapb.DataType_Date, metapb.DataType_TimeStamp:
+			value = "'" + value + "'"
+		}
+		conds = append(conds, fmt.Sprintf("%s %s %s", m.Column.GetName(), matchOperators[m.MatchType], value))
+	}
+	return strings.Join(conds, " and ")
+}
+
+// explainRoutes returns the first ranges the scope covers, the number of all
+// of them and their leader nodes
+func explainRoutes(t *Table, scope *kvrpcpb.Scope) ([]*dskv.KeyLocation, int, []uint64, error) {
+	var routes []*dskv.KeyLocation
+	var total int
+	var nodes []uint64
+	seen := make(map[uint64]bool)
+	key := scope.Start
+	for {
+		bo := dskv.NewBackoffer(dskv.MsMaxBackoff, context.Background())
+		route, err := t.ranges.LocateKey(bo, key)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("locate route failed: %v", err)
+		}
+		total++
+		if !seen[route.NodeId] {
+			seen[route.NodeId] = true
+			nodes = append(nodes, route.NodeId)
+		}
+		if len(routes) < maxExplainRanges {
+			routes = append(routes, route)
+		}
+		if len(route.EndKey) == 0 || bytes.Compare(route.EndKey, scope.Limit) >= 0 || bytes.Compare(route.EndKey, key) <= 0 {
+			break
+		}
+		key = route.EndKey
+	}
+	return routes, total, nodes, nil
+}
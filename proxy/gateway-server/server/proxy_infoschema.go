@@ -0,0 +1,968 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"model/pkg/metapb"
+	"proxy/gateway-server/mysql"
+	"proxy/gateway-server/sqlparser"
+	"util"
+	"util/hack"
+	"util/log"
+)
+
+const (
+	InfoSchemaDB = "information_schema"
+
+	infoSchemaCatalog   = "def"
+	infoSchemaEngine    = "sharkstore"
+	infoSchemaCharset   = "utf8"
+	infoSchemaCollation = "utf8_general_ci"
+	// varchar和binary的最大长度64KB
+	infoSchemaMaxLen = 65535
+)
+
+// infoSchemaColumn is a column of the virtual information_schema tables,
+// the values are strings or uint64 numbers, nil means NULL.
+type infoSchemaColumn struct {
+	name    string
+	numeric bool
+}
+
+func (c infoSchemaColumn) field() *mysql.Field {
+	field := &mysql.Field{Name: hack.Slice(c.name)}
+	if c.numeric {
+		formatField(field, uint64(0))
+	} else {
+		formatField(field, "")
+	}
+	field.Flag &^= mysql.NOT_NULL_FLAG
+	return field
+}
+
+// infoSchemaScope restricts the rows generated by a virtual table, it's
+// pushed down from the equal conditions of the where clause, so that the
+// metadata of all the databases is not loaded for one table.
+type infoSchemaScope struct {
+	db    string
+	table string
+}
+
+type infoSchemaTable struct {
+	name    string
+	columns []infoSchemaColumn
+	// the columns of the database and table name for the scope
+	dbColumn    string
+	tableColumn string
+	rows        func(p *Proxy, scope infoSchemaScope) ([][]interface{}, error)
+}
+
+func newInfoSchemaColumns(names []string, numeric ...string) []infoSchemaColumn {
+	cols := make([]infoSchemaColumn, 0, len(names))
+	for _, name := range names {
+		col := infoSchemaColumn{name: name}
+		for _, n := range numeric {
+			if n == name {
+				col.numeric = true
+			}
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// the tables are set in init, their rows refer to the table list itself
+var infoSchemaTables map[string]*infoSchemaTable
+
+func init() {
+	infoSchemaTables = make(map[string]*infoSchemaTable)
+	for _, t := range []*infoSchemaTable{
+		{
+			name: "SCHEMATA",
+			columns: newInfoSchemaColumns([]string{"CATALOG_NAME", "SCHEMA_NAME",
+				"DEFAULT_CHARACTER_SET_NAME", "DEFAULT_COLLATION_NAME", "SQL_PATH"}),
+			dbColumn: "SCHEMA_NAME",
+			rows:     schemataRows,
+		},
+		{
+			name: "TABLES",
+			columns: newInfoSchemaColumns([]string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME",
+				"TABLE_TYPE", "ENGINE", "VERSION", "ROW_FORMAT", "TABLE_ROWS", "AVG_ROW_LENGTH",
+				"DATA_LENGTH", "MAX_DATA_LENGTH", "INDEX_LENGTH", "DATA_FREE", "AUTO_INCREMENT",
+				"CREATE_TIME", "UPDATE_TIME", "CHECK_TIME", "TABLE_COLLATION", "CHECKSUM",
+				"CREATE_OPTIONS", "TABLE_COMMENT"},
+				"VERSION", "TABLE_ROWS", "AVG_ROW_LENGTH", "DATA_LENGTH", "MAX_DATA_LENGTH",
+				"INDEX_LENGTH", "DATA_FREE", "AUTO_INCREMENT", "CHECKSUM"),
+			dbColumn:    "TABLE_SCHEMA",
+			tableColumn: "TABLE_NAME",
+			rows:        tablesRows,
+		},
+		{
+			name: "COLUMNS",
+			columns: newInfoSchemaColumns([]string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME",
+				"COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_DEFAULT", "IS_NULLABLE", "DATA_TYPE",
+				"CHARACTER_MAXIMUM_LENGTH", "CHARACTER_OCTET_LENGTH", "NUMERIC_PRECISION",
+				"NUMERIC_SCALE", "DATETIME_PRECISION", "CHARACTER_SET_NAME", "COLLATION_NAME",
+				"COLUMN_TYPE", "COLUMN_KEY", "EXTRA", "PRIVILEGES", "COLUMN_COMMENT"},
+				"ORDINAL_POSITION", "CHARACTER_MAXIMUM_LENGTH", "CHARACTER_OCTET_LENGTH",
+				"NUMERIC_PRECISION", "NUMERIC_SCALE", "DATETIME_PRECISION"),
+			dbColumn:    "TABLE_SCHEMA",
+			tableColumn: "TABLE_NAME",
+			rows:        columnsRows,
+		},
+		{
+			name: "STATISTICS",
+			columns: newInfoSchemaColumns([]string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME",
+				"NON_UNIQUE", "INDEX_SCHEMA", "INDEX_NAME", "SEQ_IN_INDEX", "COLUMN_NAME",
+				"COLLATION", "CARDINALITY", "SUB_PART", "PACKED", "NULLABLE", "INDEX_TYPE",
+				"COMMENT", "INDEX_COMMENT"},
+				"NON_UNIQUE", "SEQ_IN_INDEX", "CARDINALITY", "SUB_PART"),
+			dbColumn:    "TABLE_SCHEMA",
+			tableColumn: "TABLE_NAME",
+			rows:        statisticsRows,
+		},
+		{
+			// 网关按sql指纹聚合的语句统计, 延时单位us
+			name: "STATEMENTS_SUMMARY",
+			columns: newInfoSchemaColumns([]string{"SCHEMA_NAME", "DIGEST", "DIGEST_TEXT",
+				"QUERY_SAMPLE_TEXT", "EXEC_COUNT", "SUM_ERRORS", "SUM_LATENCY", "AVG_LATENCY",
+				"MIN_LATENCY", "MAX_LATENCY", "P50_LATENCY", "P95_LATENCY", "P99_LATENCY",
+				"SUM_ROWS_EXAMINED", "AVG_ROWS_EXAMINED", "SUM_ROWS_RETURNED", "AVG_ROWS_RETURNED",
+				"FIRST_SEEN", "LAST_SEEN"},
+				"EXEC_COUNT", "SUM_ERRORS", "SUM_LATENCY", "AVG_LATENCY", "MIN_LATENCY",
+				"MAX_LATENCY", "P50_LATENCY", "P95_LATENCY", "P99_LATENCY", "SUM_ROWS_EXAMINED",
+				"AVG_ROWS_EXAMINED", "SUM_ROWS_RETURNED", "AVG_ROWS_RETURNED"),
+			dbColumn: "SCHEMA_NAME",
+			rows:     statementsSummaryRows,
+		},
+	} {
+		infoSchemaTables[t.name] = t
+	}
+}
+
+func isInfoSchema(db string) bool {
+	return strings.EqualFold(db, InfoSchemaDB)
+}
+
+// findInfoSchemaTable returns the virtual table of a select from
+// information_schema, either qualified or in the current database.
+func findInfoSchemaTable(db string, stmt *sqlparser.Select) (*infoSchemaTable, bool) {
+	if len(stmt.From) != 1 {
+		return nil, false
+	}
+	expr, ok := stmt.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return nil, false
+	}
+	name, ok := expr.Expr.(*sqlparser.TableName)
+	if !ok {
+		return nil, false
+	}
+	if name.Qualifier != nil {
+		db = string(name.Qualifier)
+	}
+	if !isInfoSchema(db) {
+		return nil, false
+	}
+	t, ok := infoSchemaTables[strings.ToUpper(string(name.Name))]
+	return t, ok
+}
+
+// infoSchemaVirtualTables describes the virtual tables as the tables of the
+// information_schema database.
+func infoSchemaVirtualTables() []*metapb.Table {
+	tables := make([]*metapb.Table, 0, len(infoSchemaTables))
+	for _, t := range infoSchemaTables {
+		table := &metapb.Table{Name: t.name, DbName: InfoSchemaDB}
+		for _, c := range t.columns {
+			col := &metapb.Column{Name: c.name, DataType: metapb.DataType_Varchar, Scale: 64, Nullable: true}
+			if c.numeric {
+				col.DataType = metapb.DataType_BigInt
+				col.Scale = 0
+				col.Unsigned = true
+			}
+			table.Columns = append(table.Columns, col)
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// schemaDBs returns the databases in the scope order by name.
+func (p *Proxy) schemaDBs(scope infoSchemaScope) ([]string, error) {
+	if len(scope.db) > 0 {
+		if isInfoSchema(scope.db) {
+			return []string{InfoSchemaDB}, nil
+		}
+		if p.router.FindDB(scope.db) == nil {
+			return nil, nil
+		}
+		return []string{scope.db}, nil
+	}
+	dbs, err := p.router.GetAllDBs()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{InfoSchemaDB}
+	for _, db := range dbs {
+		names = append(names, db.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// schemaTables returns the tables of the database in the scope order by name.
+func (p *Proxy) schemaTables(db string, scope infoSchemaScope) ([]*metapb.Table, error) {
+	var tables []*metapb.Table
+	switch {
+	case isInfoSchema(db):
+		tables = infoSchemaVirtualTables()
+	case len(scope.table) > 0:
+		if t := p.router.FindTable(db, scope.table); t != nil {
+			tables = append(tables, t.Table)
+		}
+	default:
+		var err error
+		if tables, err = p.router.GetAllTables(db); err != nil {
+			return nil, err
+		}
+	}
+	var ret []*metapb.Table
+	for _, t := range tables {
+		if len(scope.table) == 0 || (isInfoSchema(db) && strings.EqualFold(t.GetName(), scope.table)) ||
+			t.GetName() == scope.table {
+			ret = append(ret, t)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetName() < ret[j].GetName() })
+	return ret, nil
+}
+
+// eachSchemaTable calls fn with the tables in the scope of all databases.
+func (p *Proxy) eachSchemaTable(scope infoSchemaScope, fn func(db string, t *metapb.Table)) error {
+	dbs, err := p.schemaDBs(scope)
+	if err != nil {
+		return err
+	}
+	for _, db := range dbs {
+		tables, err := p.schemaTables(db, scope)
+		if err != nil {
+			return err
+		}
+		for _, t := range tables {
+			fn(db, t)
+		}
+	}
+	return nil
+}
+
+func schemataRows(p *Proxy, scope infoSchemaScope) ([][]interface{}, error) {
+	dbs, err := p.schemaDBs(scope)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]interface{}, 0, len(dbs))
+	for _, db := range dbs {
+		rows = append(rows, []interface{}{infoSchemaCatalog, db, infoSchemaCharset, infoSchemaCollation, nil})
+	}
+	return rows, nil
+}
+
+func tablesRows(p *Proxy, scope infoSchemaScope) ([][]interface{}, error) {
+	var rows [][]interface{}
+	err := p.eachSchemaTable(scope, func(db string, t *metapb.Table) {
+		tableType, engine := "BASE TABLE", infoSchemaEngine
+		if isInfoSchema(db) {
+			tableType, engine = "SYSTEM VIEW", "MEMORY"
+		}
+		var createTime interface{}
+		if t.GetCreateTime() > 0 {
+			createTime = time.Unix(t.GetCreateTime(), 0).Format("2006-01-02 15:04:05")
+		}
+		var options string
+		if ttl, err := util.ParseTableTTL(t.GetProperties()); err == nil && ttl > 0 {
+			options = fmt.Sprintf("ttl=%d", ttl)
+		}
+		rows = append(rows, []interface{}{infoSchemaCatalog, db, t.GetName(), tableType, engine,
+			uint64(10), "Dynamic", nil, nil, nil, nil, nil, nil, nil,
+			createTime, nil, nil, infoSchemaCollation, nil, options, ""})
+	})
+	return rows, err
+}
+
+func columnsRows(p *Proxy, scope infoSchemaScope) ([][]interface{}, error) {
+	var rows [][]interface{}
+	err := p.eachSchemaTable(scope, func(db string, t *metapb.Table) {
+		for i, col := range t.GetColumns() {
+			var def, charset, collation interface{}
+			if len(col.GetDefaultValue()) > 0 {
+				def = string(col.GetDefaultValue())
+			}
+			if col.GetDataType() == metapb.DataType_Varchar {
+				charset, collation = infoSchemaCharset, infoSchemaCollation
+			}
+			maxLen, octetLen := columnLength(col)
+			precision, scale := columnPrecision(col)
+			var datetimePrecision interface{}
+			if col.GetDataType() == metapb.DataType_TimeStamp {
+				datetimePrecision = uint64(0)
+			}
+			var extra string
+			if col.GetAutoIncrement() {
+				extra = "auto_increment"
+			}
+			rows = append(rows, []interface{}{infoSchemaCatalog, db, t.GetName(), col.GetName(),
+				uint64(i + 1), def, yesOrNo(col.GetNullable()), columnDataType(col), maxLen, octetLen,
+				precision, scale, datetimePrecision, charset, collation, columnType(col),
+				columnKey(col), extra, "select,insert,update,references", ""})
+		}
+	})
+	return rows, err
+}
+
+func statisticsRows(p *Proxy, scope infoSchemaScope) ([][]interface{}, error) {
+	var rows [][]interface{}
+	err := p.eachSchemaTable(scope, func(db string, t *metapb.Table) {
+		add := func(col *metapb.Column, nonUnique uint64, index string, seq int) {
+			var nullable string
+			if col.GetNullable() {
+				nullable = "YES"
+			}
+			rows = append(rows, []interface{}{infoSchemaCatalog, db, t.GetName(), nonUnique, db,
+				index, uint64(seq), col.GetName(), "A", nil, nil, nil, nullable, "BTREE", "", ""})
+		}
+		for i, col := range primaryColumns(t) {
+			add(col, 0, "PRIMARY", i+1)
+		}
+		for _, col := range t.GetColumns() {
+			if col.GetIndex() && col.GetPrimaryKey() == 0 {
+				add(col, 1, col.GetName(), 1)
+			}
+		}
+	})
+	return rows, err
+}
+
+// statementsSummaryRows returns the statement digests order by the total
+// latency.
+func statementsSummaryRows(p *Proxy, scope infoSchemaScope) ([][]interface{}, error) {
+	summaries := p.stmts.Top(scope.db, StmtOrderLatency, 0)
+	rows := make([][]interface{}, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []interface{}{s.GetDb(), s.GetDigest(), s.GetText(), s.GetSample(),
+			s.GetCount(), s.GetErrors(), s.GetSumLatency(), s.AvgLatency, s.GetMinLatency(),
+			s.GetMaxLatency(), s.P50Latency, s.P95Latency, s.P99Latency, s.GetRowsExamined(),
+			s.AvgExamined, s.GetRowsReturned(), s.AvgReturned,
+			time.Unix(s.GetFirstSeen(), 0).Format("2006-01-02 15:04:05"),
+			time.Unix(s.GetLastSeen(), 0).Format("2006-01-02 15:04:05")})
+	}
+	return rows, nil
+}
+
+// primaryColumns returns the primary key columns in the key order.
+func primaryColumns(t *metapb.Table) []*metapb.Column {
+	var pks []*metapb.Column
+	for _, col := range t.GetColumns() {
+		if col.GetPrimaryKey() > 0 {
+			pks = append(pks, col)
+		}
+	}
+	sort.SliceStable(pks, func(i, j int) bool { return pks[i].GetPrimaryKey() < pks[j].GetPrimaryKey() })
+	return pks
+}
+
+func yesOrNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+func columnDataType(col *metapb.Column) string {
+	switch col.GetDataType() {
+	case metapb.DataType_Tinyint:
+		return "tinyint"
+	case metapb.DataType_Smallint:
+		return "smallint"
+	case metapb.DataType_Int:
+		return "int"
+	case metapb.DataType_BigInt:
+		return "bigint"
+	case metapb.DataType_Float:
+		return "float"
+	case metapb.DataType_Double:
+		return "double"
+	case metapb.DataType_Varchar:
+		return "varchar"
+	case metapb.DataType_Binary:
+		return "blob"
+	case metapb.DataType_Date:
+		return "date"
+	case metapb.DataType_TimeStamp:
+		return "timestamp"
+	}
+	return strings.ToLower(col.GetDataType().String())
+}
+
+// columnType is the mysql column definition type of the column.
+func columnType(col *metapb.Column) string {
+	typ := columnDataType(col)
+	switch col.GetDataType() {
+	case metapb.DataType_Tinyint, metapb.DataType_Smallint, metapb.DataType_Int, metapb.DataType_BigInt:
+		if col.GetUnsigned() {
+			typ += " unsigned"
+		}
+	case metapb.DataType_Float, metapb.DataType_Double:
+		if col.GetPrecision() > 0 && col.GetScale() > 0 {
+			typ = fmt.Sprintf("%s(%d,%d)", typ, col.GetPrecision(), col.GetScale())
+		}
+	case metapb.DataType_Varchar:
+		maxLen, _ := columnLength(col)
+		typ = fmt.Sprintf("%s(%d)", typ, maxLen)
+	}
+	return typ
+}
+
+func columnKey(col *metapb.Column) string {
+	switch {
+	case col.GetPrimaryKey() > 0:
+		return "PRI"
+	case col.GetIndex():
+		return "MUL"
+	}
+	return ""
+}
+
+func columnLength(col *metapb.Column) (interface{}, interface{}) {
+	switch col.GetDataType() {
+	case metapb.DataType_Varchar:
+		n := uint64(infoSchemaMaxLen)
+		if col.GetScale() > 0 {
+			n = uint64(col.GetScale())
+		}
+		// utf8最多3个字节
+		return n, n * 3
+	case metapb.DataType_Binary:
+		return uint64(infoSchemaMaxLen), uint64(infoSchemaMaxLen)
+	}
+	return nil, nil
+}
+
+func columnPrecision(col *metapb.Column) (interface{}, interface{}) {
+	var precision uint64
+	switch col.GetDataType() {
+	case metapb.DataType_Tinyint:
+		precision = 3
+	case metapb.DataType_Smallint:
+		precision = 5
+	case metapb.DataType_Int:
+		precision = 10
+	case metapb.DataType_BigInt:
+		precision = 19
+		if col.GetUnsigned() {
+			precision = 20
+		}
+	case metapb.DataType_Float, metapb.DataType_Double:
+		precision = 12
+		if col.GetDataType() == metapb.DataType_Double {
+			precision = 22
+		}
+		if col.GetPrecision() > 0 {
+			precision = uint64(col.GetPrecision())
+		}
+		if col.GetScale() > 0 {
+			return precision, uint64(col.GetScale())
+		}
+		return precision, nil
+	default:
+		return nil, nil
+	}
+	return precision, uint64(0)
+}
+
+// metaRows are the rows of a virtual table or a SHOW statement, the columns
+// are looked up case insensitively like mysql.
+type metaRows struct {
+	columns []infoSchemaColumn
+	index   map[string]int
+	values  [][]interface{}
+}
+
+func newMetaRows(columns []infoSchemaColumn, values [][]interface{}) *metaRows {
+	r := &metaRows{columns: columns, index: make(map[string]int, len(columns)), values: values}
+	for i, c := range columns {
+		r.index[strings.ToLower(c.name)] = i
+	}
+	return r
+}
+
+func (r *metaRows) columnIndex(name string) (int, error) {
+	i, ok := r.index[strings.ToLower(name)]
+	if !ok {
+		return 0, mysql.NewDefaultError(mysql.ER_BAD_FIELD_ERROR, name, "field list")
+	}
+	return i, nil
+}
+
+// filter keeps the rows satisfying the where expression.
+func (r *metaRows) filter(where sqlparser.BoolExpr) error {
+	if where == nil {
+		return nil
+	}
+	values := r.values[:0]
+	for _, row := range r.values {
+		ok, err := r.evalBool(where, row)
+		if err != nil {
+			return err
+		}
+		if ok {
+			values = append(values, row)
+		}
+	}
+	r.values = values
+	return nil
+}
+
+// filterLike keeps the rows of which the first column matches the pattern.
+func (r *metaRows) filterLike(pattern string) {
+	re := likeRegexp(pattern)
+	values := r.values[:0]
+	for _, row := range r.values {
+		if s, ok := row[0].(string); ok && re.MatchString(s) {
+			values = append(values, row)
+		}
+	}
+	r.values = values
+}
+
+func (r *metaRows) evalBool(expr sqlparser.BoolExpr, row []interface{}) (bool, error) {
+	switch e := expr.(type) {
+	case *sqlparser.AndExpr:
+		left, err := r.evalBool(e.Left, row)
+		if err != nil || !left {
+			return false, err
+		}
+		return r.evalBool(e.Right, row)
+	case *sqlparser.OrExpr:
+		left, err := r.evalBool(e.Left, row)
+		if err != nil || left {
+			return left, err
+		}
+		return r.evalBool(e.Right, row)
+	case *sqlparser.NotExpr:
+		ok, err := r.evalBool(e.Expr, row)
+		return !ok, err
+	case *sqlparser.ParenBoolExpr:
+		return r.evalBool(e.Expr, row)
+	case *sqlparser.NullCheck:
+		v, err := r.evalValue(e.Expr, row)
+		if err != nil {
+			return false, err
+		}
+		return (v == nil) == (e.Operator == sqlparser.AST_IS_NULL), nil
+	case *sqlparser.RangeCond:
+		v, err := r.evalValue(e.Left, row)
+		if err != nil {
+			return false, err
+		}
+		from, err := r.evalValue(e.From, row)
+		if err != nil {
+			return false, err
+		}
+		to, err := r.evalValue(e.To, row)
+		if err != nil {
+			return false, err
+		}
+		if v == nil || from == nil || to == nil {
+			return false, nil
+		}
+		in := compareMetaValue(v, from) >= 0 && compareMetaValue(v, to) <= 0
+		return in == (e.Operator == sqlparser.AST_BETWEEN), nil
+	case *sqlparser.ComparisonExpr:
+		return r.evalComparison(e, row)
+	}
+	return false, fmt.Errorf("unsupported where expression %s", nstring(expr))
+}
+
+func (r *metaRows) evalComparison(e *sqlparser.ComparisonExpr, row []interface{}) (bool, error) {
+	left, err := r.evalValue(e.Left, row)
+	if err != nil {
+		return false, err
+	}
+	if e.Operator == sqlparser.AST_IN || e.Operator == sqlparser.AST_NOT_IN {
+		tuple, ok := e.Right.(sqlparser.ValTuple)
+		if !ok {
+			return false, fmt.Errorf("unsupported where expression %s", nstring(e))
+		}
+		if left == nil {
+			return false, nil
+		}
+		var in bool
+		for _, expr := range tuple {
+			v, err := r.evalValue(expr, row)
+			if err != nil {
+				return false, err
+			}
+			if v != nil && compareMetaValue(left, v) == 0 {
+				in = true
+				break
+			}
+		}
+		return in == (e.Operator == sqlparser.AST_IN), nil
+	}
+
+	right, err := r.evalValue(e.Right, row)
+	if err != nil {
+		return false, err
+	}
+	if e.Operator == sqlparser.AST_NSE {
+		if left == nil || right == nil {
+			return left == nil && right == nil, nil
+		}
+		return compareMetaValue(left, right) == 0, nil
+	}
+	if left == nil || right == nil {
+		return false, nil
+	}
+	switch e.Operator {
+	case sqlparser.AST_LIKE, sqlparser.AST_NOT_LIKE:
+		match := likeRegexp(metaString(right)).MatchString(metaString(left))
+		return match == (e.Operator == sqlparser.AST_LIKE), nil
+	}
+	cmp := compareMetaValue(left, right)
+	switch e.Operator {
+	case sqlparser.AST_EQ:
+		return cmp == 0, nil
+	case sqlparser.AST_NE:
+		return cmp != 0, nil
+	case sqlparser.AST_LT:
+		return cmp < 0, nil
+	case sqlparser.AST_LE:
+		return cmp <= 0, nil
+	case sqlparser.AST_GT:
+		return cmp > 0, nil
+	case sqlparser.AST_GE:
+		return cmp >= 0, nil
+	}
+	return false, fmt.Errorf("unsupported operator %s", e.Operator)
+}
+
+func (r *metaRows) evalValue(expr sqlparser.ValExpr, row []interface{}) (interface{}, error) {
+	switch e := expr.(type) {
+	case *sqlparser.ColName:
+		i, err := r.columnIndex(string(e.Name))
+		if err != nil {
+			return nil, err
+		}
+		return row[i], nil
+	case sqlparser.StrVal:
+		return string(e), nil
+	case sqlparser.NumVal:
+		return string(e), nil
+	case *sqlparser.NullVal:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported value %s", nstring(expr))
+}
+
+func metaString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// compareMetaValue compares as numbers if both are numbers, otherwise as
+// strings, the names are case sensitive.
+func compareMetaValue(a, b interface{}) int {
+	as, bs := metaString(a), metaString(b)
+	af, aerr := strconv.ParseFloat(as, 64)
+	bf, berr := strconv.ParseFloat(bs, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(as, bs)
+}
+
+// likeRegexp translates the LIKE pattern, % matches any chars and _ matches
+// one char, they are escaped by backslash.
+func likeRegexp(pattern string) *regexp.Regexp {
+	var buf bytes.Buffer
+	buf.WriteString("(?s)^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '%':
+			buf.WriteString(".*")
+		case '_':
+			buf.WriteString(".")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			buf.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	buf.WriteString("$")
+	return regexp.MustCompile(buf.String())
+}
+
+// resultset builds the result with the declared field types, NULL is written
+// as NULL rather than a string.
+func (r *metaRows) resultset() (*mysql.Resultset, error) {
+	rs := &mysql.Resultset{
+		Fields:     make([]*mysql.Field, 0, len(r.columns)),
+		FieldNames: make(map[string]int, len(r.columns)),
+		Values:     r.values,
+		RowDatas:   make([]mysql.RowData, 0, len(r.values)),
+	}
+	if rs.Values == nil {
+		rs.Values = make([][]interface{}, 0)
+	}
+	for i, c := range r.columns {
+		rs.Fields = append(rs.Fields, c.field())
+		rs.FieldNames[c.name] = i
+	}
+	for _, values := range r.values {
+		var row []byte
+		for _, v := range values {
+			if v == nil {
+				row = append(row, 0xfb)
+				continue
+			}
+			b, err := formatValue(v)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, mysql.PutLengthEncodedString(b)...)
+		}
+		rs.RowDatas = append(rs.RowDatas, row)
+	}
+	return rs, nil
+}
+
+// infoSchemaScopeOf pushes down the equal conditions on the database and the
+// table name combined by AND.
+func infoSchemaScopeOf(t *infoSchemaTable, expr sqlparser.BoolExpr, scope *infoSchemaScope) {
+	switch e := expr.(type) {
+	case *sqlparser.AndExpr:
+		infoSchemaScopeOf(t, e.Left, scope)
+		infoSchemaScopeOf(t, e.Right, scope)
+	case *sqlparser.ParenBoolExpr:
+		infoSchemaScopeOf(t, e.Expr, scope)
+	case *sqlparser.ComparisonExpr:
+		if e.Operator != sqlparser.AST_EQ {
+			return
+		}
+		col, ok := e.Left.(*sqlparser.ColName)
+		val, isStr := e.Right.(sqlparser.StrVal)
+		if !ok || !isStr {
+			col, ok = e.Right.(*sqlparser.ColName)
+			val, isStr = e.Left.(sqlparser.StrVal)
+			if !ok || !isStr {
+				return
+			}
+		}
+		switch name := string(col.Name); {
+		case len(t.dbColumn) > 0 && strings.EqualFold(name, t.dbColumn):
+			scope.db = string(val)
+		case len(t.tableColumn) > 0 && strings.EqualFold(name, t.tableColumn):
+			scope.table = string(val)
+		}
+	}
+}
+
+// HandleInfoSchemaSelect answers a select from the virtual information_schema
+// tables, only the columns, COUNT(*), WHERE, ORDER BY and LIMIT are supported.
+func (p *Proxy) HandleInfoSchemaSelect(db string, stmt *sqlparser.Select) (*mysql.Result, error) {
+	t, ok := findInfoSchemaTable(db, stmt)
+	if !ok {
+		return nil, fmt.Errorf("unknown information_schema table %s", nstring(stmt.From))
+	}
+	if len(stmt.Distinct) > 0 || len(stmt.GroupBy) > 0 || stmt.Having != nil {
+		return nil, fmt.Errorf("distinct and group by on information_schema are not supported")
+	}
+
+	var scope infoSchemaScope
+	if stmt.Where != nil {
+		infoSchemaScopeOf(t, stmt.Where.Expr, &scope)
+	}
+	values, err := t.rows(p, scope)
+	if err != nil {
+		log.Error("[information_schema] load %s failed(%v)", t.name, err)
+		return nil, err
+	}
+	rows := newMetaRows(t.columns, values)
+	if stmt.Where != nil {
+		if err = rows.filter(stmt.Where.Expr); err != nil {
+			return nil, err
+		}
+	}
+
+	if isCountStar(stmt.SelectExprs) {
+		name := nstring(stmt.SelectExprs[0])
+		if e := stmt.SelectExprs[0].(*sqlparser.NonStarExpr); e.As != nil {
+			name = string(e.As)
+		}
+		rows = newMetaRows([]infoSchemaColumn{{name: name, numeric: true}},
+			[][]interface{}{{uint64(len(rows.values))}})
+	} else {
+		if err = rows.sort(stmt.OrderBy, stmt.SelectExprs); err != nil {
+			return nil, err
+		}
+		if rows, err = rows.project(stmt.SelectExprs); err != nil {
+			return nil, err
+		}
+	}
+	if stmt.Limit != nil {
+		offset, count, err := parseLimit(stmt.Limit)
+		if err != nil {
+			return nil, err
+		}
+		rows.limit(offset, count)
+	}
+
+	rs, err := rows.resultset()
+	if err != nil {
+		return nil, err
+	}
+	return &mysql.Result{Resultset: rs}, nil
+}
+
+func isCountStar(exprs sqlparser.SelectExprs) bool {
+	if len(exprs) != 1 {
+		return false
+	}
+	e, ok := exprs[0].(*sqlparser.NonStarExpr)
+	if !ok {
+		return false
+	}
+	f, ok := e.Expr.(*sqlparser.FuncExpr)
+	return ok && strings.EqualFold(string(f.Name), CountFunc) && !f.Distinct
+}
+
+// sort orders the rows before the projection, a name in ORDER BY is the
+// alias of a select expression first, as mysql does, then a source column.
+func (r *metaRows) sort(orderBy sqlparser.OrderBy, exprs sqlparser.SelectExprs) error {
+	if len(orderBy) == 0 {
+		return nil
+	}
+	indexes := make([]int, len(orderBy))
+	for i, o := range orderBy {
+		col, ok := o.Expr.(*sqlparser.ColName)
+		if !ok {
+			return fmt.Errorf("unsupported order by %s", nstring(o.Expr))
+		}
+		name := string(col.Name)
+		if col.Qualifier == nil {
+			name = aliasSource(exprs, name)
+		}
+		index, err := r.columnIndex(name)
+		if err != nil {
+			return err
+		}
+		indexes[i] = index
+	}
+	sort.SliceStable(r.values, func(i, j int) bool {
+		for k, o := range orderBy {
+			a, b := r.values[i][indexes[k]], r.values[j][indexes[k]]
+			var cmp int
+			switch {
+			case a == nil && b == nil:
+			case a == nil:
+				cmp = -1
+			case b == nil:
+				cmp = 1
+			default:
+				cmp = compareMetaValue(a, b)
+			}
+			if cmp != 0 {
+				return (cmp < 0) == (o.Direction != sqlparser.AST_DESC)
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// aliasSource returns the column selected as the alias, or the name itself if
+// it is not an alias.
+func aliasSource(exprs sqlparser.SelectExprs, name string) string {
+	for _, expr := range exprs {
+		e, ok := expr.(*sqlparser.NonStarExpr)
+		if !ok || e.As == nil || !strings.EqualFold(string(e.As), name) {
+			continue
+		}
+		if col, ok := e.Expr.(*sqlparser.ColName); ok {
+			return string(col.Name)
+		}
+	}
+	return name
+}
+
+func (r *metaRows) project(exprs sqlparser.SelectExprs) (*metaRows, error) {
+	var columns []infoSchemaColumn
+	var indexes []int
+	for _, expr := range exprs {
+		switch e := expr.(type) {
+		case *sqlparser.StarExpr:
+			for i, c := range r.columns {
+				columns = append(columns, c)
+				indexes = append(indexes, i)
+			}
+		case *sqlparser.NonStarExpr:
+			col, ok := e.Expr.(*sqlparser.ColName)
+			if !ok {
+				return nil, fmt.Errorf("unsupported select expression %s on information_schema", nstring(e))
+			}
+			i, err := r.columnIndex(string(col.Name))
+			if err != nil {
+				return nil, err
+			}
+			name := string(col.Name)
+			if e.As != nil {
+				name = string(e.As)
+			}
+			columns = append(columns, infoSchemaColumn{name: name, numeric: r.columns[i].numeric})
+			indexes = append(indexes, i)
+		default:
+			return nil, fmt.Errorf("unsupported select expression %s on information_schema", nstring(expr))
+		}
+	}
+	values := make([][]interface{}, 0, len(r.values))
+	for _, row := range r.values {
+		v := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			v[i] = row[index]
+		}
+		values = append(values, v)
+	}
+	return newMetaRows(columns, values), nil
+}
+
+func (r *metaRows) limit(offset, count uint64) {
+	if offset >= uint64(len(r.values)) {
+		r.values = r.values[:0]
+		return
+	}
+	r.values = r.values[offset:]
+	if count < uint64(len(r.values)) {
+		r.values = r.values[:count]
+	}
+}
@@ -15,9 +15,11 @@ import (
 	"util"
 	"util/hack"
 	"util/log"
+
+	"golang.org/x/net/context"
 )
 
-func (p *Proxy) HandleInsert(db string, stmt *sqlparser.Insert, args []interface{}) (*mysql.Result, error) {
+func (p *Proxy) HandleInsert(ctx context.Context, db string, stmt *sqlparser.Insert, args []interface{}, group *ResourceGroup) (*mysql.Result, error) {
 	//var parseTime time.Time
 	//start := time.Now()
 	//defer func() {
@@ -73,6 +75,10 @@ func (p *Proxy) HandleInsert(db string, stmt *sqlparser.Insert, args []interface
 		}
 	}
 
+	if err = group.admit(insertRowsSize(rows)); err != nil {
+		return nil, err
+	}
+
 	// 按照表的每个列查找对应列值位置
 	colMap, t, err := p.matchInsertValues(t, cols)
 	if err != nil {
@@ -89,7 +95,7 @@ func (p *Proxy) HandleInsert(db string, stmt *sqlparser.Insert, args []interface
 	//填充自增id值
 	if len(pkName) > 0 {
 		colMap[pkName] = len(colMap)
-		ids, err := p.msCli.GetAutoIncId(t.GetDbId(), t.GetId(), uint32(len(rows)))
+		ids, err := p.allocAutoIncIds(t, uint32(len(rows)))
 		if err != nil {
 			log.Error("[insert] table %s.%s get auto_increment value err, %v", db, tableName, err)
 			return nil, err
@@ -110,7 +116,7 @@ func (p *Proxy) HandleInsert(db string, stmt *sqlparser.Insert, args []interface
 	//parseTime = time.Now()
 	// 编码、执行插入
 	res := new(mysql.Result)
-	affected, duplicateKey, err := p.insertRows(t, colMap, rows)
+	affected, duplicateKey, err := p.insertRows(ctx, t, colMap, rows)
 	if err != nil {
 		log.Error("insert error table[%s:%s], err %s", db, tableName, err.Error())
 		return nil, err
@@ -255,6 +261,13 @@ func (p *Proxy) EncodeRow(t *Table, colMap map[string]int, rowValue InsertRowVal
 	if err != nil {
 		return nil, fmt.Errorf("find row ttl error(%s)", err)
 	}
+	// 表设置了ttl时, 行的ttl列优先, 过期时间写入隐藏列
+	if t.TTL() > 0 {
+		if ttl == 0 {
+			ttl = t.TTL()
+		}
+		value = util.EncodeExpireValue(value, time.Now().Unix()+int64(ttl))
+	}
 
 	return &kvrpcpb.KeyValue{
 		Key:   key,
@@ -358,7 +371,7 @@ func (p *Proxy) batchInsert(context *dskv.ReqContext, t *Table, kvPairs []*kvrpc
 	return
 }
 
-func (p *Proxy) insertRows(t *Table, colMap map[string]int, rows []InsertRowValue) (affected uint64, duplicateKey []byte, err error) {
+func (p *Proxy) insertRows(ctx context.Context, t *Table, colMap map[string]int, rows []InsertRowValue) (affected uint64, duplicateKey []byte, err error) {
 	var kvPairs []*kvrpcpb.KeyValue
 	var kv *kvrpcpb.KeyValue
 	for i, r := range rows {
@@ -374,7 +387,7 @@ func (p *Proxy) insertRows(t *Table, colMap map[string]int, rows []InsertRowValu
 	var duplicateKeyTp []byte
 	var errTp error
 
-	context := dskv.NewPRConext(dskv.InsertMaxBackoff)
+	context := dskv.NewPRConextWithCtx(ctx, dskv.InsertMaxBackoff)
 	var errForRetry error
 	for metricLoop := 0; ; metricLoop++ {
 		if kvPairs == nil || len(kvPairs) == 0 {
@@ -0,0 +1,871 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"model/pkg/timestamp"
+	"pkg-go/ds_client"
+	"proxy/gateway-server/mysql"
+	"proxy/gateway-server/sqlparser"
+	"proxy/store/dskv"
+	"util/hack"
+	"util/log"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	JoinInner = "inner join"
+	JoinLeft  = "left join"
+)
+
+// joinTable is a table of a join, only the columns referenced by the
+// statement are read from the data servers.
+type joinTable struct {
+	t    *Table
+	name string // 别名或表名, 用来限定列名
+	typ  string // 与前面的表的连接方式
+	on   sqlparser.BoolExpr
+
+	cols   []*metapb.Column
+	offset int // 列在连接后的行中的起始位置
+	// 下推到data server的过滤条件
+	matches []Match
+	// 与前面的表的相等条件
+	keys []joinKey
+}
+
+type joinColumn struct {
+	table int
+	col   *metapb.Column
+}
+
+// joinKey is an equal condition between a column of the tables before and a
+// column of the joined table.
+type joinKey struct {
+	outer joinColumn
+	inner joinColumn
+}
+
+type joinOrder struct {
+	column joinColumn
+	desc   bool
+}
+
+// joinPlan is a left-deep join of the tables in the order of the from clause,
+// the rows of a join are the values of the referenced columns of all tables.
+type joinPlan struct {
+	tables []*joinTable
+	fields []joinColumn
+	names  []string
+	orders []joinOrder
+	limit  *Limit
+	width  int
+}
+
+func isJoinSelect(stmt *sqlparser.Select) bool {
+	if len(stmt.From) != 1 {
+		return true
+	}
+	_, ok := stmt.From[0].(*sqlparser.AliasedTableExpr)
+	return !ok
+}
+
+func (jt *joinTable) use(col *metapb.Column) {
+	if jt.index(col) < 0 {
+		jt.cols = append(jt.cols, col)
+	}
+}
+
+func (jt *joinTable) index(col *metapb.Column) int {
+	for i, c := range jt.cols {
+		if c.GetName() == col.GetName() {
+			return i
+		}
+	}
+	return -1
+}
+
+func (jt *joinTable) fieldList() []*kvrpcpb.SelectField {
+	fieldList := make([]*kvrpcpb.SelectField, 0, len(jt.cols))
+	for _, c := range jt.cols {
+		fieldList = append(fieldList, &kvrpcpb.SelectField{
+			Typ:    kvrpcpb.SelectField_Column,
+			Column: c,
+		})
+	}
+	return fieldList
+}
+
+// lookupable reports whether the rows of the table can be looked up by the
+// join keys, it requires the equal conditions to cover a prefix of the
+// primary keys with at least one join key in it.
+func (jt *joinTable) lookupable() bool {
+	var hasKey bool
+	for _, pk := range jt.t.PKS() {
+		if jt.isKey(pk) {
+			hasKey = true
+			continue
+		}
+		if !jt.hasEqualMatch(pk) {
+			break
+		}
+	}
+	return hasKey
+}
+
+func (jt *joinTable) isKey(column string) bool {
+	for _, k := range jt.keys {
+		if k.inner.col.GetName() == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (jt *joinTable) hasEqualMatch(column string) bool {
+	for _, m := range jt.matches {
+		if m.column == column && m.matchType == Equal {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proxy) buildJoinPlan(db string, stmt *sqlparser.Select) (*joinPlan, error) {
+	if len(stmt.Distinct) != 0 || stmt.GroupBy != nil || stmt.Having != nil {
+		return nil, fmt.Errorf("distinct, group by and having are not supported in join")
+	}
+	plan := new(joinPlan)
+	var err error
+	for _, expr := range stmt.From {
+		if plan.tables, err = p.appendJoinTables(plan.tables, db, expr); err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[string]bool, len(plan.tables))
+	for _, jt := range plan.tables {
+		if names[jt.name] {
+			return nil, fmt.Errorf("Not unique table/alias: '%s'", jt.name)
+		}
+		names[jt.name] = true
+	}
+
+	if err = plan.addFields(stmt.SelectExprs); err != nil {
+		return nil, err
+	}
+	for i, jt := range plan.tables {
+		if jt.on != nil {
+			if err = plan.addConditions(jt.on, i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if stmt.Where != nil {
+		if err = plan.addConditions(stmt.Where.Expr, -1); err != nil {
+			return nil, err
+		}
+	}
+	for _, jt := range plan.tables[1:] {
+		if len(jt.keys) == 0 {
+			return nil, fmt.Errorf("join table '%s' without equal condition is not supported", jt.name)
+		}
+	}
+
+	for _, o := range stmt.OrderBy {
+		col, ok := o.Expr.(*sqlparser.ColName)
+		if !ok {
+			return nil, fmt.Errorf("unsupported order by %s in join", nstring(o.Expr))
+		}
+		c, err := plan.findColumn(col, "order clause")
+		if err != nil {
+			return nil, err
+		}
+		plan.orders = append(plan.orders, joinOrder{column: c, desc: o.Direction == sqlparser.AST_DESC})
+	}
+	if stmt.Limit != nil {
+		offset, count, err := parseLimit(stmt.Limit)
+		if err != nil {
+			return nil, err
+		}
+		if count > DefaultMaxRawCount {
+			return nil, ErrExceedMaxLimit
+		}
+		plan.limit = &Limit{offset: offset, rowCount: count}
+	}
+
+	for _, jt := range plan.tables {
+		// 没有引用任何列的表也要读出行来连接
+		if len(jt.cols) == 0 {
+			jt.use(jt.t.FindColumn(jt.t.PKS()[0]))
+		}
+		jt.offset = plan.width
+		plan.width += len(jt.cols)
+	}
+	return plan, nil
+}
+
+// appendJoinTables flattens the table expression in the order of execution,
+// the right side of a join must be a table.
+func (p *Proxy) appendJoinTables(tables []*joinTable, db string, expr sqlparser.TableExpr) ([]*joinTable, error) {
+	switch v := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		jt, err := p.newJoinTable(db, v)
+		if err != nil {
+			return nil, err
+		}
+		// 逗号分隔的表, 连接条件在where中
+		jt.typ = JoinInner
+		return append(tables, jt), nil
+	case *sqlparser.ParenTableExpr:
+		return p.appendJoinTables(tables, db, v.Expr)
+	case *sqlparser.JoinTableExpr:
+		var typ string
+		switch v.Join {
+		case sqlparser.AST_JOIN, sqlparser.AST_STRAIGHT_JOIN, sqlparser.AST_CROSS_JOIN:
+			typ = JoinInner
+		case sqlparser.AST_LEFT_JOIN:
+			typ = JoinLeft
+		default:
+			return nil, fmt.Errorf("unsupported join type(%s)", v.Join)
+		}
+		tables, err := p.appendJoinTables(tables, db, v.LeftExpr)
+		if err != nil {
+			return nil, err
+		}
+		right := v.RightExpr
+		for {
+			paren, ok := right.(*sqlparser.ParenTableExpr)
+			if !ok {
+				break
+			}
+			right = paren.Expr
+		}
+		aliased, ok := right.(*sqlparser.AliasedTableExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported nested join %s", nstring(v.RightExpr))
+		}
+		jt, err := p.newJoinTable(db, aliased)
+		if err != nil {
+			return nil, err
+		}
+		jt.typ = typ
+		jt.on = v.On
+		return append(tables, jt), nil
+	default:
+		return nil, fmt.Errorf("unsupported table expression %s", nstring(expr))
+	}
+}
+
+func (p *Proxy) newJoinTable(db string, expr *sqlparser.AliasedTableExpr) (*joinTable, error) {
+	name, ok := expr.Expr.(*sqlparser.TableName)
+	if !ok {
+		return nil, fmt.Errorf("unsupported table %s in join", nstring(expr.Expr))
+	}
+	if len(name.Qualifier) != 0 {
+		db = string(name.Qualifier)
+	}
+	t := p.router.FindTable(db, string(name.Name))
+	if t == nil {
+		log.Error("[join] table %s.%s doesn.t exist", db, name.Name)
+		return nil, fmt.Errorf("Table '%s.%s' doesn't exist", db, name.Name)
+	}
+	jt := &joinTable{t: t, name: string(name.Name)}
+	if len(expr.As) != 0 {
+		jt.name = string(expr.As)
+	}
+	return jt, nil
+}
+
+func (plan *joinPlan) addFields(exprs sqlparser.SelectExprs) error {
+	for _, expr := range exprs {
+		switch v := expr.(type) {
+		case *sqlparser.StarExpr:
+			var found bool
+			for i, jt := range plan.tables {
+				if len(v.TableName) != 0 && jt.name != string(v.TableName) {
+					continue
+				}
+				found = true
+				for _, c := range jt.t.GetColumns() {
+					jt.use(c)
+					plan.fields = append(plan.fields, joinColumn{table: i, col: c})
+					plan.names = append(plan.names, c.GetName())
+				}
+			}
+			if !found {
+				return fmt.Errorf("Unknown table '%s'", v.TableName)
+			}
+		case *sqlparser.NonStarExpr:
+			col, ok := v.Expr.(*sqlparser.ColName)
+			if !ok {
+				return fmt.Errorf("unsupported field %s in join", nstring(v.Expr))
+			}
+			c, err := plan.findColumn(col, "field list")
+			if err != nil {
+				return err
+			}
+			name := c.col.GetName()
+			if len(v.As) != 0 {
+				name = string(v.As)
+			}
+			plan.fields = append(plan.fields, c)
+			plan.names = append(plan.names, name)
+		default:
+			return fmt.Errorf("unsupported field %s in join", nstring(expr))
+		}
+	}
+	return nil
+}
+
+// findColumn resolves a column by its table name or alias, an unqualified
+// column must be unique among the tables.
+func (plan *joinPlan) findColumn(col *sqlparser.ColName, clause string) (joinColumn, error) {
+	found := joinColumn{table: -1}
+	for i, jt := range plan.tables {
+		if len(col.Qualifier) != 0 && jt.name != string(col.Qualifier) {
+			continue
+		}
+		c := jt.t.FindColumn(string(col.Name))
+		if c == nil {
+			continue
+		}
+		if found.table >= 0 {
+			return found, fmt.Errorf("Column '%s' in %s is ambiguous", col.Name, clause)
+		}
+		found = joinColumn{table: i, col: c}
+	}
+	if found.table < 0 {
+		return found, fmt.Errorf("Unknown column '%s' in '%s'", nstring(col), clause)
+	}
+	plan.tables[found.table].use(found.col)
+	return found, nil
+}
+
+// addConditions adds the conditions of the on clause of the table on, or
+// the where clause if on is -1. The conditions of where and the on clause
+// of an inner join reject the null rows, so a left join filtered by them
+// is turned into an inner join.
+func (plan *joinPlan) addConditions(expr sqlparser.BoolExpr, on int) error {
+	if on >= 0 && plan.tables[on].typ == JoinInner {
+		on = -1
+	}
+	switch v := expr.(type) {
+	case *sqlparser.AndExpr:
+		if err := plan.addConditions(v.Left, on); err != nil {
+			return err
+		}
+		return plan.addConditions(v.Right, on)
+	case *sqlparser.ParenBoolExpr:
+		return plan.addConditions(v.Expr, on)
+	case *sqlparser.ComparisonExpr:
+		return plan.addComparison(v, on)
+	default:
+		return fmt.Errorf("unsupported join condition %s", nstring(expr))
+	}
+}
+
+func (plan *joinPlan) addComparison(expr *sqlparser.ComparisonExpr, on int) error {
+	clause := "where clause"
+	if on >= 0 {
+		clause = "on clause"
+	}
+	left, ok := expr.Left.(*sqlparser.ColName)
+	if !ok {
+		return fmt.Errorf("unsupported join condition %s", nstring(expr))
+	}
+	lc, err := plan.findColumn(left, clause)
+	if err != nil {
+		return err
+	}
+
+	// 两个表的列相等, 作为连接键
+	if right, ok := expr.Right.(*sqlparser.ColName); ok {
+		rc, err := plan.findColumn(right, clause)
+		if err != nil {
+			return err
+		}
+		if expr.Operator != sqlparser.AST_EQ || lc.table == rc.table {
+			return fmt.Errorf("unsupported join condition %s", nstring(expr))
+		}
+		if lc.table > rc.table {
+			lc, rc = rc, lc
+		}
+		if on >= 0 && rc.table != on {
+			return fmt.Errorf("unsupported join condition %s, left join on other tables", nstring(expr))
+		}
+		inner := plan.tables[rc.table]
+		if on < 0 && inner.typ == JoinLeft {
+			inner.typ = JoinInner
+		}
+		inner.keys = append(inner.keys, joinKey{outer: lc, inner: rc})
+		return nil
+	}
+
+	// 与常量比较, 下推到表的查询条件
+	if on >= 0 && lc.table != on {
+		return fmt.Errorf("unsupported join condition %s, left join on other tables", nstring(expr))
+	}
+	parser := &StmtParser{}
+	match, err := parser.parseComparison(expr)
+	if err != nil {
+		return err
+	}
+	jt := plan.tables[lc.table]
+	if on < 0 && jt.typ == JoinLeft {
+		jt.typ = JoinInner
+	}
+	jt.matches = append(jt.matches, *match)
+	return nil
+}
+
+func (plan *joinPlan) pos(c joinColumn) int {
+	jt := plan.tables[c.table]
+	return jt.offset + jt.index(c.col)
+}
+
+// keyPositions returns the positions of the join keys in the joined rows
+// and in the rows of the table.
+func (plan *joinPlan) keyPositions(jt *joinTable) (outer, inner []int) {
+	for _, k := range jt.keys {
+		outer = append(outer, plan.pos(k.outer))
+		inner = append(inner, jt.index(k.inner.col))
+	}
+	return
+}
+
+func (plan *joinPlan) buildResult(rows [][]interface{}) (*mysql.Result, error) {
+	if len(plan.orders) > 0 {
+		positions := make([]int, len(plan.orders))
+		for i, o := range plan.orders {
+			positions[i] = plan.pos(o.column)
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			for k, o := range plan.orders {
+				v := mysql.CompareValue(rows[i][positions[k]], rows[j][positions[k]])
+				if o.desc {
+					v = -v
+				}
+				if v != 0 {
+					return v < 0
+				}
+			}
+			return false
+		})
+	}
+	if plan.limit != nil {
+		if plan.limit.offset >= uint64(len(rows)) {
+			rows = nil
+		} else {
+			rows = rows[plan.limit.offset:]
+			if plan.limit.rowCount < uint64(len(rows)) {
+				rows = rows[:plan.limit.rowCount]
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return &mysql.Result{Status: 0, Resultset: newEmptyResultSet(plan.names)}, nil
+	}
+
+	positions := make([]int, len(plan.fields))
+	fields := make([]*mysql.Field, len(plan.fields))
+	for i, c := range plan.fields {
+		positions[i] = plan.pos(c)
+		fields[i] = plan.field(plan.names[i], c)
+	}
+	values := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = make([]interface{}, len(positions))
+		for j, pos := range positions {
+			values[i][j] = row[pos]
+		}
+	}
+	r, err := buildResultset(fields, plan.names, values)
+	if err != nil {
+		return nil, err
+	}
+	return &mysql.Result{Status: 0, AffectedRows: uint64(len(values)), Resultset: r}, nil
+}
+
+// field describes the column by its type, the columns of a left joined
+// table may be null.
+func (plan *joinPlan) field(name string, c joinColumn) *mysql.Field {
+	field := &mysql.Field{Name: hack.Slice(name)}
+	switch c.col.GetDataType() {
+	case metapb.DataType_Tinyint, metapb.DataType_Smallint, metapb.DataType_Int, metapb.DataType_BigInt:
+		if c.col.GetUnsigned() {
+			formatField(field, uint64(0))
+		} else {
+			formatField(field, int64(0))
+		}
+	case metapb.DataType_Float, metapb.DataType_Double:
+		formatField(field, float64(0))
+	default:
+		formatField(field, "")
+	}
+	if c.col.GetNullable() || plan.tables[c.table].typ == JoinLeft {
+		field.Flag &^= mysql.NOT_NULL_FLAG
+	}
+	return field
+}
+
+// joinKeyOf encodes the join key of a row, false if any of the values is
+// null, which never equals to others.
+func joinKeyOf(row []interface{}, positions []int) (string, bool) {
+	var key []byte
+	for _, pos := range positions {
+		if row[pos] == nil {
+			return "", false
+		}
+		b, err := formatValue(row[pos])
+		if err != nil {
+			return "", false
+		}
+		key = strconv.AppendInt(key, int64(len(b)), 10)
+		key = append(key, ':')
+		key = append(key, b...)
+	}
+	return string(key), true
+}
+
+func joinRowSize(values []interface{}) uint64 {
+	size := uint64(16 * len(values))
+	for _, v := range values {
+		switch v := v.(type) {
+		case []byte:
+			size += uint64(len(v))
+		case string:
+			size += uint64(len(v))
+		}
+	}
+	return size
+}
+
+func rowValues(r *Row) []interface{} {
+	values := make([]interface{}, len(r.fields))
+	for i, f := range r.fields {
+		values[i] = f.value
+	}
+	return values
+}
+
+// joinExecutor joins the tables of a plan in the gateway, the rows of a
+// table are looked up by the join keys if they are a primary key prefix,
+// otherwise they are read all and joined by a hash table. The rows held
+// are limited by the memory budget.
+type joinExecutor struct {
+	ctx       context.Context
+	p         *Proxy
+	group     *ResourceGroup
+	plan      *joinPlan
+	maxMemory uint64
+	memory    uint64
+	batchSize int
+	// lookups of a batch sent at the same time
+	concurrency int
+
+	// 读取表的行, 测试时替换
+	scan   func(jt *joinTable, matches []Match) ([]*Row, error)
+	lookup func(jt *joinTable, batch [][]Match) ([][]*Row, error)
+}
+
+func (p *Proxy) newJoinExecutor(ctx context.Context, plan *joinPlan, group *ResourceGroup) *joinExecutor {
+	e := &joinExecutor{
+		ctx:         ctx,
+		p:           p,
+		group:       group,
+		plan:        plan,
+		maxMemory:   p.config.Join.MaxMemory,
+		batchSize:   p.config.Join.LookupBatchSize,
+		concurrency: p.config.Join.LookupConcurrency,
+	}
+	if e.concurrency <= 0 {
+		e.concurrency = DefaultJoinLookupConcurrency
+	}
+	e.scan = e.scanTable
+	e.lookup = e.lookupTable
+	return e
+}
+
+func (p *Proxy) handleJoin(ctx context.Context, db string, stmt *sqlparser.Select, group *ResourceGroup) (*mysql.Result, error) {
+	plan, err := p.buildJoinPlan(db, stmt)
+	if err != nil {
+		log.Error("[join] build plan failed(%v), sql: %s", err, nstring(stmt))
+		return nil, err
+	}
+	if err = group.admit(0); err != nil {
+		return nil, err
+	}
+	rows, err := p.newJoinExecutor(ctx, plan, group).execute()
+	if err != nil {
+		log.Error("[join] execute failed(%v), sql: %s", err, nstring(stmt))
+		return nil, err
+	}
+	return plan.buildResult(rows)
+}
+
+func (e *joinExecutor) execute() ([][]interface{}, error) {
+	first := e.plan.tables[0]
+	rows, err := e.scan(first, first.matches)
+	if err != nil {
+		return nil, err
+	}
+	joined := make([][]interface{}, 0, len(rows))
+	for _, r := range rows {
+		row := make([]interface{}, e.plan.width)
+		copy(row[first.offset:], rowValues(r))
+		if err = e.charge(joinRowSize(row)); err != nil {
+			return nil, err
+		}
+		joined = append(joined, row)
+	}
+
+	for _, jt := range e.plan.tables[1:] {
+		if len(joined) == 0 {
+			break
+		}
+		if jt.lookupable() {
+			joined, err = e.lookupJoin(joined, jt)
+		} else {
+			joined, err = e.hashJoin(joined, jt)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return joined, nil
+}
+
+func (e *joinExecutor) charge(size uint64) error {
+	e.memory += size
+	if e.memory > e.maxMemory {
+		log.Warn("[join] rows of %d bytes exceed the memory budget %d", e.memory, e.maxMemory)
+		return ErrJoinMemoryExceeded
+	}
+	return nil
+}
+
+func (e *joinExecutor) hashJoin(outer [][]interface{}, jt *joinTable) ([][]interface{}, error) {
+	rows, err := e.scan(jt, jt.matches)
+	if err != nil {
+		return nil, err
+	}
+	outerPos, innerPos := e.plan.keyPositions(jt)
+	hash := make(map[string][][]interface{}, len(rows))
+	var size uint64
+	for _, r := range rows {
+		values := rowValues(r)
+		key, ok := joinKeyOf(values, innerPos)
+		if !ok {
+			continue
+		}
+		if err = e.charge(joinRowSize(values)); err != nil {
+			return nil, err
+		}
+		size += joinRowSize(values)
+		hash[key] = append(hash[key], values)
+	}
+	joined, err := e.probe(outer, jt, outerPos, hash)
+	// 哈希表用完即释放
+	e.memory -= size
+	return joined, err
+}
+
+func (e *joinExecutor) lookupJoin(outer [][]interface{}, jt *joinTable) ([][]interface{}, error) {
+	outerPos, innerPos := e.plan.keyPositions(jt)
+	var keys []string
+	var batch [][]Match
+	found := make(map[string][][]interface{})
+	var size uint64
+	flush := func() error {
+		rowss, err := e.lookup(jt, batch)
+		if err != nil {
+			return err
+		}
+		for i, rows := range rowss {
+			for _, r := range rows {
+				values := rowValues(r)
+				if err = e.charge(joinRowSize(values)); err != nil {
+					return err
+				}
+				size += joinRowSize(values)
+				found[keys[i]] = append(found[keys[i]], values)
+			}
+		}
+		keys, batch = keys[:0], batch[:0]
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range outer {
+		key, ok := joinKeyOf(row, outerPos)
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		matches := make([]Match, 0, len(jt.matches)+len(outerPos))
+		matches = append(matches, jt.matches...)
+		for i, pos := range outerPos {
+			value, err := formatValue(row[pos])
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, Match{column: jt.cols[innerPos[i]].GetName(), sqlValue: value, matchType: Equal})
+		}
+		keys = append(keys, key)
+		batch = append(batch, matches)
+		if len(batch) >= e.batchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	joined, err := e.probe(outer, jt, outerPos, found)
+	e.memory -= size
+	return joined, err
+}
+
+// probe joins the outer rows with the rows of the table by the key, an outer
+// row without matched rows is kept with nulls in left join.
+func (e *joinExecutor) probe(outer [][]interface{}, jt *joinTable, outerPos []int, rows map[string][][]interface{}) ([][]interface{}, error) {
+	joined := make([][]interface{}, 0, len(outer))
+	for _, o := range outer {
+		var matched [][]interface{}
+		if key, ok := joinKeyOf(o, outerPos); ok {
+			matched = rows[key]
+		}
+		if len(matched) == 0 {
+			if jt.typ == JoinLeft {
+				joined = append(joined, o)
+			}
+			continue
+		}
+		for i, values := range matched {
+			row := o
+			if i > 0 {
+				row = make([]interface{}, len(o))
+				copy(row, o)
+				if err := e.charge(joinRowSize(row)); err != nil {
+					return nil, err
+				}
+			}
+			copy(row[jt.offset:], values)
+			if err := e.charge(joinRowSize(values)); err != nil {
+				return nil, err
+			}
+			joined = append(joined, row)
+		}
+	}
+	return joined, nil
+}
+
+// scanTable reads the rows of the table matching the pushed down conditions.
+func (e *joinExecutor) scanTable(jt *joinTable, matches []Match) ([]*Row, error) {
+	rowss, err := e.p.doSelect(e.ctx, jt.t, jt.fieldList(), matches, nil, nil)
+	if err != nil {
+		log.Error("[join] select table %s.%s failed(%v)", jt.t.DbName(), jt.t.Name(), err)
+		return nil, err
+	}
+	rows := e.chargeRows(rowss)
+	// 超过最大行数的结果被截断, 连接的结果不完整
+	if uint64(len(rows)) >= e.p.config.MaxLimit {
+		log.Warn("[join] table %s.%s has more than %d rows to join", jt.t.DbName(), jt.t.Name(), e.p.config.MaxLimit)
+		return nil, ErrExceedMaxLimit
+	}
+	return rows, nil
+}
+
+// lookupTable reads the rows of a batch of join keys by point gets, or by
+// range scans of the primary key prefix. The keys are looked up in parallel,
+// at most concurrency of them at a time.
+func (e *joinExecutor) lookupTable(jt *joinTable, batch [][]Match) ([][]*Row, error) {
+	fieldList := jt.fieldList()
+	pbLimit, err := makePBLimit(e.p, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]*Row, len(batch))
+	// 被kill或者超过max_execution_time时不再发起新的查询
+	err = dskv.Parallel(e.ctx, e.concurrency, len(batch), func(i int) error {
+		rows, err := e.lookupKey(jt, fieldList, pbLimit, batch[i])
+		if err != nil {
+			return err
+		}
+		result[i] = rows
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = e.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lookupKey reads the rows of one join key.
+func (e *joinExecutor) lookupKey(jt *joinTable, fieldList []*kvrpcpb.SelectField, pbLimit *kvrpcpb.Limit, matches []Match) ([]*Row, error) {
+	kvproxy := dskv.GetKvProxy()
+	defer dskv.PutKvProxy(kvproxy)
+	kvproxy.Init(e.p.dsCli, e.p.clock, jt.t.ranges, client.WriteTimeout, client.ReadTimeoutShort)
+	kvproxy.Ctx = e.ctx
+
+	pbMatches, err := makePBMatches(jt.t, matches)
+	if err != nil {
+		return nil, err
+	}
+	key, scope, err := findPKScope(jt.t, pbMatches)
+	if err != nil {
+		return nil, err
+	}
+	now := e.p.clock.Now()
+	req := &kvrpcpb.SelectRequest{
+		Key:          key,
+		Scope:        scope,
+		FieldList:    fieldList,
+		WhereFilters: withExpireFilter(jt.t, pbMatches, time.Now().Unix()),
+		Limit:        pbLimit,
+		Timestamp:    &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
+	}
+	var pbRows [][]*kvrpcpb.Row
+	if len(key) != 0 {
+		pbRows, err = e.p.singleSelectRemote(kvproxy, req, key)
+	} else {
+		pbRows, err = e.p.rangeSelectRemote(kvproxy, req)
+	}
+	if err != nil {
+		log.Error("[join] lookup table %s.%s failed(%v)", jt.t.DbName(), jt.t.Name(), err)
+		return nil, err
+	}
+	rowss, err := decodeRows(jt.t, fieldList, pbRows)
+	if err != nil {
+		return nil, err
+	}
+	rows := e.chargeRows(rowss)
+	if uint64(len(rows)) >= e.p.config.MaxLimit {
+		log.Warn("[join] table %s.%s has more than %d rows of a key to join", jt.t.DbName(), jt.t.Name(), e.p.config.MaxLimit)
+		return nil, ErrExceedMaxLimit
+	}
+	return rows, nil
+}
+
+func (e *joinExecutor) chargeRows(rowss [][]*Row) []*Row {
+	n := rowsCount(rowss)
+	e.group.chargeRead(n)
+	chargeExamined(e.ctx, n)
+	rows := make([]*Row, 0, n)
+	for _, rs := range rowss {
+		rows = append(rows, rs...)
+	}
+	return rows
+}
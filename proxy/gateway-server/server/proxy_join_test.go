@@ -0,0 +1,203 @@
+package server
+
+import (
+	"testing"
+
+	"model/pkg/metapb"
+	"proxy/gateway-server/sqlparser"
+
+	"golang.org/x/net/context"
+)
+
+func newJoinTestProxy() *Proxy {
+	cli := &fakeMetaClient{
+		dbs: []*metapb.DataBase{{Name: "db1", Id: 1}},
+		tables: []*metapb.Table{
+			{Name: "user", DbName: "db1", DbId: 1, Id: 1, Columns: []*metapb.Column{
+				{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, Unsigned: true, PrimaryKey: 1},
+				{Name: "name", Id: 2, DataType: metapb.DataType_Varchar, Nullable: true},
+				{Name: "age", Id: 3, DataType: metapb.DataType_Int},
+			}},
+			{Name: "orders", DbName: "db1", DbId: 1, Id: 2, Columns: []*metapb.Column{
+				{Name: "user_id", Id: 1, DataType: metapb.DataType_BigInt, Unsigned: true, PrimaryKey: 1},
+				{Name: "id", Id: 2, DataType: metapb.DataType_BigInt, Unsigned: true, PrimaryKey: 2},
+				{Name: "amount", Id: 3, DataType: metapb.DataType_Double},
+			}},
+			{Name: "profile", DbName: "db1", DbId: 1, Id: 3, Columns: []*metapb.Column{
+				{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, Unsigned: true, PrimaryKey: 1},
+				{Name: "nick", Id: 2, DataType: metapb.DataType_Varchar},
+			}},
+		},
+	}
+	return &Proxy{msCli: cli, router: NewRouter(cli)}
+}
+
+func parseJoinPlan(t *testing.T, p *Proxy, sql string) (*joinPlan, error) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		t.Fatalf("parse %q failed: %v", sql, err)
+	}
+	sel := stmt.(*sqlparser.Select)
+	if !isJoinSelect(sel) {
+		t.Fatalf("%q is not a join", sql)
+	}
+	return p.buildJoinPlan("db1", sel)
+}
+
+func TestBuildJoinPlan(t *testing.T) {
+	p := newJoinTestProxy()
+	plan, err := parseJoinPlan(t, p, "select u.name, o.amount as a from user u left join orders o on u.id = o.user_id and o.amount > 10 where u.age > 18")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.tables) != 2 || plan.names[0] != "name" || plan.names[1] != "a" || plan.width != 5 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	u, o := plan.tables[0], plan.tables[1]
+	if o.typ != JoinLeft || len(o.keys) != 1 || o.keys[0].outer.col.GetName() != "id" || o.keys[0].inner.col.GetName() != "user_id" {
+		t.Fatalf("unexpected join %+v", o)
+	}
+	if len(u.matches) != 1 || u.matches[0].column != "age" || len(o.matches) != 1 || !o.lookupable() {
+		t.Fatalf("unexpected pushed down conditions %v %v", u.matches, o.matches)
+	}
+
+	// where过滤了右表的空行, 转为inner join
+	plan, err = parseJoinPlan(t, p, "select * from user left join profile on user.name = profile.nick where profile.id > 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr := plan.tables[1]; pr.typ != JoinInner || pr.lookupable() || len(plan.fields) != 5 {
+		t.Fatalf("unexpected join %+v", pr)
+	}
+
+	// 逗号连接, 连接条件在where中
+	plan, err = parseJoinPlan(t, p, "select profile.* from user, profile where profile.id = user.id order by user.age desc limit 1, 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.tables[1].keys) != 1 || len(plan.fields) != 2 || len(plan.orders) != 1 || !plan.orders[0].desc ||
+		plan.limit.offset != 1 || plan.limit.rowCount != 2 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+
+	for _, sql := range []string{
+		"select id from user join orders on user.id = orders.user_id",
+		"select * from user join orders",
+		"select * from user join orders on user.id > orders.user_id",
+		"select * from user right join orders on user.id = orders.user_id",
+		"select * from user u left join orders o on u.id = o.user_id and u.age > 1",
+		"select * from user join user on user.id = user.id",
+		"select count(*) from user join orders on user.id = orders.user_id",
+		"select * from user join missing on user.id = missing.id",
+	} {
+		if _, err := parseJoinPlan(t, p, sql); err == nil {
+			t.Fatalf("expected %q failed", sql)
+		}
+	}
+}
+
+// newJoinTestExecutor reads the rows from the values of the tables, only the
+// equal conditions are evaluated.
+func newJoinTestExecutor(plan *joinPlan, data map[string][]map[string]interface{}) (*joinExecutor, *int) {
+	read := func(jt *joinTable, matches []Match) []*Row {
+		var rows []*Row
+		for _, values := range data[jt.t.Name()] {
+			ok := true
+			for _, m := range matches {
+				b, _ := formatValue(values[m.column])
+				if m.matchType == Equal && string(b) != string(m.sqlValue) {
+					ok = false
+				}
+			}
+			if !ok {
+				continue
+			}
+			r := &Row{fields: make([]Field, len(jt.cols))}
+			for i, c := range jt.cols {
+				r.fields[i] = Field{col: c.GetName(), value: values[c.GetName()]}
+			}
+			rows = append(rows, r)
+		}
+		return rows
+	}
+	lookups := new(int)
+	e := &joinExecutor{ctx: context.Background(), plan: plan, maxMemory: 1 << 20, batchSize: 2}
+	e.scan = func(jt *joinTable, matches []Match) ([]*Row, error) {
+		return read(jt, matches), nil
+	}
+	e.lookup = func(jt *joinTable, batch [][]Match) ([][]*Row, error) {
+		*lookups++
+		rowss := make([][]*Row, 0, len(batch))
+		for _, matches := range batch {
+			rowss = append(rowss, read(jt, matches))
+		}
+		return rowss, nil
+	}
+	return e, lookups
+}
+
+func TestJoinExecutor(t *testing.T) {
+	p := newJoinTestProxy()
+	data := map[string][]map[string]interface{}{
+		"user": {
+			{"id": uint64(1), "name": []byte("a"), "age": int64(20)},
+			{"id": uint64(2), "name": []byte("b"), "age": int64(30)},
+			{"id": uint64(3), "name": nil, "age": int64(40)},
+		},
+		"orders": {
+			{"user_id": uint64(1), "id": uint64(1), "amount": float64(10)},
+			{"user_id": uint64(1), "id": uint64(2), "amount": float64(20)},
+			{"user_id": uint64(3), "id": uint64(3), "amount": float64(30)},
+		},
+		"profile": {
+			{"id": uint64(10), "nick": []byte("a")},
+			{"id": uint64(11), "nick": []byte("b")},
+		},
+	}
+
+	// 按主键前缀查找, 每批2个键
+	plan, err := parseJoinPlan(t, p, "select u.id, o.amount from user u left join orders o on o.user_id = u.id order by u.id, o.amount desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, lookups := newJoinTestExecutor(plan, data)
+	rows, err := e.execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := plan.buildResult(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]interface{}{{uint64(1), float64(20)}, {uint64(1), float64(10)}, {uint64(2), nil}, {uint64(3), float64(30)}}
+	if *lookups != 2 || len(res.Values) != len(expected) {
+		t.Fatalf("unexpected result %v, lookups %d", res.Values, *lookups)
+	}
+	for i, row := range expected {
+		if res.Values[i][0] != row[0] || res.Values[i][1] != row[1] {
+			t.Fatalf("unexpected row %d: %v", i, res.Values[i])
+		}
+	}
+
+	// 哈希连接, 空值不匹配
+	plan, err = parseJoinPlan(t, p, "select user.id, profile.id from user join profile on user.name = profile.nick limit 1, 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, lookups = newJoinTestExecutor(plan, data)
+	if rows, err = e.execute(); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = plan.buildResult(rows); err != nil {
+		t.Fatal(err)
+	}
+	if *lookups != 0 || len(res.Values) != 1 || res.Values[0][0] != uint64(2) || res.Values[0][1] != uint64(11) {
+		t.Fatalf("unexpected result %v, lookups %d", res.Values, *lookups)
+	}
+
+	e, _ = newJoinTestExecutor(plan, data)
+	e.maxMemory = 64
+	if _, err = e.execute(); err != ErrJoinMemoryExceeded {
+		t.Fatalf("expected memory exceeded, actual %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"proxy/gateway-server/sqlparser"
+)
+
+// orderColumn is one column of the order by clause
+type orderColumn struct {
+	name string
+	desc bool
+}
+
+// sqlOrderColumns converts the order by clause, nil is returned if some
+// expression is not a column.
+func sqlOrderColumns(orderBy sqlparser.OrderBy) []orderColumn {
+	orders := make([]orderColumn, 0, len(orderBy))
+	for _, o := range orderBy {
+		col, ok := o.Expr.(*sqlparser.ColName)
+		if !ok {
+			return nil
+		}
+		orders = append(orders, orderColumn{name: string(col.Name), desc: o.Direction == sqlparser.AST_DESC})
+	}
+	return orders
+}
+
+func restOrderColumns(order []*Order) []orderColumn {
+	orders := make([]orderColumn, 0, len(order))
+	for _, o := range order {
+		orders = append(orders, orderColumn{name: o.By, desc: o.Desc})
+	}
+	return orders
+}
+
+// pkOrder 判断排序是否与主键顺序一致, 一致时可以下推到dataserver按key的顺序(desc为逆序)扫描.
+// 被等值条件固定的主键列可以跳过, 排序列覆盖所有主键后其余的列不影响顺序.
+func pkOrder(t *Table, matches []Match, orders []orderColumn) (pushdown bool, desc bool) {
+	if len(orders) == 0 {
+		return false, false
+	}
+	fixed := make(map[string]bool)
+	for _, m := range matches {
+		if m.matchType == Equal {
+			fixed[m.column] = true
+		}
+	}
+
+	pks := t.PKS()
+	var i int
+	var directed bool
+	for _, o := range orders {
+		for i < len(pks) && fixed[pks[i]] && pks[i] != o.name {
+			i++
+		}
+		if i >= len(pks) {
+			// 主键已经唯一确定了行的顺序
+			break
+		}
+		if pks[i] != o.name {
+			if fixed[o.name] {
+				// 常量列不影响顺序
+				continue
+			}
+			return false, false
+		}
+		i++
+		if fixed[o.name] {
+			continue
+		}
+		if directed && o.desc != desc {
+			return false, false
+		}
+		directed, desc = true, o.desc
+	}
+	return true, desc
+}
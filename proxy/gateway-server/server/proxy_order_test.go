@@ -0,0 +1,101 @@
+package server
+
+import (
+	"sort"
+	"testing"
+
+	"model/pkg/kvrpcpb"
+	"proxy/gateway-server/sqlparser"
+)
+
+func TestPKOrder(t *testing.T) {
+	p := newJoinTestProxy()
+	table := p.router.FindTable("db1", "orders")
+	if table == nil {
+		t.Fatal("table orders not found")
+	}
+	parser := &StmtParser{}
+	for _, c := range []struct {
+		sql      string
+		pushdown bool
+		desc     bool
+	}{
+		{"select * from orders order by user_id", true, false},
+		{"select * from orders order by user_id desc, id desc limit 10", true, true},
+		{"select * from orders where user_id = 1 order by id desc", true, true},
+		{"select * from orders where user_id = 1 order by user_id asc, id desc", true, true},
+		{"select * from orders order by user_id, id, amount desc", true, false},
+		{"select * from orders where amount = 1 order by amount, user_id desc", true, true},
+		{"select * from orders order by id", false, false},
+		{"select * from orders order by user_id, id desc", false, false},
+		{"select * from orders order by user_id, amount", false, false},
+		{"select * from orders order by amount", false, false},
+		{"select * from orders", false, false},
+	} {
+		stmt, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("parse %q failed: %v", c.sql, err)
+		}
+		sel := stmt.(*sqlparser.Select)
+		var matches []Match
+		if sel.Where != nil {
+			if matches, err = parser.parseWhere(sel.Where); err != nil {
+				t.Fatalf("parse where of %q failed: %v", c.sql, err)
+			}
+		}
+		pushdown, desc := pkOrder(table, matches, sqlOrderColumns(sel.OrderBy))
+		if pushdown != c.pushdown || desc != c.desc {
+			t.Fatalf("%q: expected pushdown %v desc %v, actual %v %v", c.sql, c.pushdown, c.desc, pushdown, desc)
+		}
+	}
+
+	pushdown, desc := pkOrder(table, nil, restOrderColumns([]*Order{{By: "user_id", Desc: true}}))
+	if !pushdown || !desc {
+		t.Fatalf("unexpected rest order pushdown %v desc %v", pushdown, desc)
+	}
+}
+
+func TestNextSubLimit(t *testing.T) {
+	if l, more := nextSubLimit(nil, 100); l != nil || !more {
+		t.Fatalf("unexpected sub limit %v %v", l, more)
+	}
+	for _, c := range []struct {
+		all, offset, count uint64
+		more               bool
+	}{
+		{0, 5, 10, true},
+		{3, 2, 10, true},
+		{7, 0, 8, true},
+		{15, 5, 0, false},
+	} {
+		l, more := nextSubLimit(&kvrpcpb.Limit{Offset: 5, Count: 10}, c.all)
+		if more != c.more || more && (l.Offset != c.offset || l.Count != c.count) {
+			t.Fatalf("all %d: unexpected sub limit %v %v", c.all, l, more)
+		}
+	}
+}
+
+func TestRowHeap(t *testing.T) {
+	row := func(id int64, name string) *Row {
+		return &Row{fields: []Field{{col: "id", value: id}, {col: "name", value: name}}}
+	}
+	// order by name desc, id
+	h := &rowHeap{indexes: []int{1, 0}, desc: []bool{true, false}}
+	for _, r := range []*Row{row(1, "a"), row(2, "c"), row(3, "b"), row(4, "c"), row(5, "a"), row(6, "d")} {
+		h.add(r, 3)
+	}
+	sort.Slice(h.rows, func(i, j int) bool { return h.before(h.rows[i], h.rows[j]) })
+	var ids []int64
+	for _, r := range h.rows {
+		ids = append(ids, r.fields[0].value.(int64))
+	}
+	if len(ids) != 3 || ids[0] != 6 || ids[1] != 2 || ids[2] != 4 {
+		t.Fatalf("expected rows [6 2 4], actual %v", ids)
+	}
+
+	h = &rowHeap{indexes: []int{0}, desc: []bool{false}}
+	h.add(row(1, "a"), 0)
+	if h.Len() != 0 {
+		t.Fatalf("expected no row kept, actual %d", h.Len())
+	}
+}
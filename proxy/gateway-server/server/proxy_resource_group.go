@@ -0,0 +1,342 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"proxy/metric"
+	"util/log"
+)
+
+const (
+	// 按mysql登录用户匹配
+	ResourceGroupUser = "user"
+	// 按数据库匹配
+	ResourceGroupDb = "db"
+	// 按REST请求的Sign匹配
+	ResourceGroupSign = "sign"
+)
+
+var ErrResourceGroupLimit = errors.New("resource group limit exceeded")
+
+// tokenBucket refills rate tokens per second up to one second of burst.
+// The read rows and written bytes are only known after the request, so
+// the bucket may go into debt and rejects the next requests until repaid.
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate uint64, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: float64(rate), tokens: float64(rate), last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if b.rate == 0 {
+		return
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.rate {
+			b.tokens = b.rate
+		}
+	}
+	b.last = now
+}
+
+// available reports whether n tokens can be taken, a request larger than
+// the burst is admitted when the bucket is full.
+func (b *tokenBucket) available(n float64) bool {
+	if b.rate == 0 {
+		return true
+	}
+	if n > b.rate {
+		n = b.rate
+	}
+	return b.tokens >= n
+}
+
+func (b *tokenBucket) take(n float64) {
+	if b.rate == 0 {
+		return
+	}
+	b.tokens -= n
+}
+
+// ResourceGroup limits the qps, read rows and written bytes per second of
+// the requests matched by Kind and Key, a zero limit means unlimited. The
+// read rows are the rows returned by selects and deleted by deletes, the
+// rows filtered out on the data server are not counted.
+type ResourceGroup struct {
+	Name       string
+	Kind       string
+	Key        string
+	QPS        uint64
+	ReadRows   uint64
+	WriteBytes uint64
+
+	lock      sync.Mutex
+	qpsBucket *tokenBucket
+	rowBucket *tokenBucket
+	// 写入字节数的令牌桶
+	byteBucket *tokenBucket
+
+	requests uint64
+	rejected uint64
+	rows     uint64
+	bytes    uint64
+}
+
+func NewResourceGroup(name, kind, key string, qps, readRows, writeBytes uint64) (*ResourceGroup, error) {
+	if len(name) == 0 {
+		return nil, errors.New("resource group name is required")
+	}
+	switch kind {
+	case ResourceGroupUser, ResourceGroupDb, ResourceGroupSign:
+	default:
+		return nil, fmt.Errorf("invalid resource group kind %s, expect %s, %s or %s",
+			kind, ResourceGroupUser, ResourceGroupDb, ResourceGroupSign)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("resource group %s: %s is required", name, kind)
+	}
+	now := time.Now()
+	return &ResourceGroup{
+		Name:       name,
+		Kind:       kind,
+		Key:        key,
+		QPS:        qps,
+		ReadRows:   readRows,
+		WriteBytes: writeBytes,
+		qpsBucket:  newTokenBucket(qps, now),
+		rowBucket:  newTokenBucket(readRows, now),
+		byteBucket: newTokenBucket(writeBytes, now),
+	}, nil
+}
+
+// admit takes a token for the request and the bytes it is going to write,
+// the request is rejected if any limit of the group is exhausted.
+// A nil group admits all requests.
+func (g *ResourceGroup) admit(writeBytes uint64) error {
+	if g == nil {
+		return nil
+	}
+	g.lock.Lock()
+	now := time.Now()
+	g.qpsBucket.refill(now)
+	g.rowBucket.refill(now)
+	g.byteBucket.refill(now)
+	ok := g.qpsBucket.available(1) && g.rowBucket.available(0) && g.byteBucket.available(float64(writeBytes))
+	if ok {
+		g.qpsBucket.take(1)
+		g.byteBucket.take(float64(writeBytes))
+	}
+	g.lock.Unlock()
+
+	atomic.AddUint64(&g.requests, 1)
+	metric.GsMetric.ResourceGroupMetric(g.Name, ok)
+	if !ok {
+		atomic.AddUint64(&g.rejected, 1)
+		return fmt.Errorf("%v: %s", ErrResourceGroupLimit, g.Name)
+	}
+	atomic.AddUint64(&g.bytes, writeBytes)
+	return nil
+}
+
+// chargeRead charges the rows read by an admitted request.
+func (g *ResourceGroup) chargeRead(rows uint64) {
+	if g == nil || rows == 0 {
+		return
+	}
+	g.lock.Lock()
+	g.rowBucket.refill(time.Now())
+	g.rowBucket.take(float64(rows))
+	g.lock.Unlock()
+	atomic.AddUint64(&g.rows, rows)
+}
+
+type ResourceGroupStats struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Key        string `json:"key"`
+	QPS        uint64 `json:"qps"`
+	ReadRows   uint64 `json:"read_rows"`
+	WriteBytes uint64 `json:"write_bytes"`
+
+	Requests     uint64 `json:"requests"`
+	Rejected     uint64 `json:"rejected"`
+	RowsRead     uint64 `json:"rows_read"`
+	BytesWritten uint64 `json:"bytes_written"`
+}
+
+func (g *ResourceGroup) stats() *ResourceGroupStats {
+	return &ResourceGroupStats{
+		Name:         g.Name,
+		Kind:         g.Kind,
+		Key:          g.Key,
+		QPS:          g.QPS,
+		ReadRows:     g.ReadRows,
+		WriteBytes:   g.WriteBytes,
+		Requests:     atomic.LoadUint64(&g.requests),
+		Rejected:     atomic.LoadUint64(&g.rejected),
+		RowsRead:     atomic.LoadUint64(&g.rows),
+		BytesWritten: atomic.LoadUint64(&g.bytes),
+	}
+}
+
+// ResourceGroups holds the resource groups of the gateway, they are kept by
+// the master and pulled periodically, so every gateway applies the same
+// groups. The stats are of this gateway only.
+type ResourceGroups struct {
+	lock   sync.RWMutex
+	groups map[string]*ResourceGroup
+}
+
+func NewResourceGroups() *ResourceGroups {
+	return &ResourceGroups{groups: make(map[string]*ResourceGroup)}
+}
+
+// Reset replaces the groups with the pulled ones. A group with unchanged
+// limits is kept as it is, a changed group keeps the stats of the old one.
+func (rg *ResourceGroups) Reset(groups []*ResourceGroup) {
+	rg.lock.Lock()
+	defer rg.lock.Unlock()
+	m := make(map[string]*ResourceGroup, len(groups))
+	for _, g := range groups {
+		old, ok := rg.groups[g.Name]
+		switch {
+		case !ok:
+		case old.Kind == g.Kind && old.Key == g.Key && old.QPS == g.QPS && old.ReadRows == g.ReadRows && old.WriteBytes == g.WriteBytes:
+			g = old
+		default:
+			g.requests = atomic.LoadUint64(&old.requests)
+			g.rejected = atomic.LoadUint64(&old.rejected)
+			g.rows = atomic.LoadUint64(&old.rows)
+			g.bytes = atomic.LoadUint64(&old.bytes)
+		}
+		m[g.Name] = g
+	}
+	rg.groups = m
+}
+
+// refreshResourceGroups pulls the resource groups from the master, the
+// groups are kept if the master can't be reached.
+func (p *Proxy) refreshResourceGroups(interval time.Duration) {
+	defer p.wg.Done()
+	if interval <= 0 {
+		interval = DefaultResourceGroupRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.pullResourceGroups()
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Proxy) pullResourceGroups() {
+	pbGroups, err := p.msCli.GetResourceGroups()
+	if err != nil {
+		log.Warn("pull resource groups failed, err %v", err)
+		return
+	}
+	groups := make([]*ResourceGroup, 0, len(pbGroups))
+	for _, pb := range pbGroups {
+		g, err := NewResourceGroup(pb.GetName(), pb.GetKind(), pb.GetKey(), pb.GetQps(), pb.GetReadRows(), pb.GetWriteBytes())
+		if err != nil {
+			log.Warn("invalid resource group %s, err %v", pb.GetName(), err)
+			continue
+		}
+		groups = append(groups, g)
+	}
+	p.groups.Reset(groups)
+}
+
+// List returns the stats of all groups order by name.
+func (rg *ResourceGroups) List() []*ResourceGroupStats {
+	rg.lock.RLock()
+	stats := make([]*ResourceGroupStats, 0, len(rg.groups))
+	for _, g := range rg.groups {
+		stats = append(stats, g.stats())
+	}
+	rg.lock.RUnlock()
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// Match returns the group of the request, the user group takes precedence
+// over the sign group and then the database group. It returns nil if no
+// group matches.
+func (rg *ResourceGroups) Match(user, db, sign string) *ResourceGroup {
+	if rg == nil {
+		return nil
+	}
+	rg.lock.RLock()
+	defer rg.lock.RUnlock()
+	if len(rg.groups) == 0 {
+		return nil
+	}
+	var bySign, byDb *ResourceGroup
+	for _, g := range rg.groups {
+		switch {
+		case g.Kind == ResourceGroupUser && len(user) > 0 && g.Key == user:
+			return g
+		case g.Kind == ResourceGroupSign && len(sign) > 0 && g.Key == sign:
+			bySign = g
+		case g.Kind == ResourceGroupDb && len(db) > 0 && g.Key == db:
+			byDb = g
+		}
+	}
+	if bySign != nil {
+		return bySign
+	}
+	return byDb
+}
+
+// insertRowsSize is the bytes an insert is going to write, it's the raw size
+// of the values rather than the encoded size.
+func insertRowsSize(rows []InsertRowValue) uint64 {
+	var size uint64
+	for _, row := range rows {
+		for _, v := range row {
+			size += uint64(len(v))
+		}
+	}
+	return size
+}
+
+func rowsCount(rowss [][]*Row) uint64 {
+	var n uint64
+	for _, rows := range rowss {
+		n += uint64(len(rows))
+	}
+	return n
+}
+
+// commandValuesSize is the bytes a REST set command is going to write.
+func commandValuesSize(values [][]interface{}) uint64 {
+	var size uint64
+	for _, row := range values {
+		for _, v := range row {
+			switch v := v.(type) {
+			case string:
+				size += uint64(len(v))
+			case []byte:
+				size += uint64(len(v))
+			case nil:
+			default:
+				size += 8
+			}
+		}
+	}
+	return size
+}
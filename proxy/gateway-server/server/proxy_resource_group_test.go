@@ -0,0 +1,131 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, now)
+	for i := 0; i < 10; i++ {
+		if !b.available(1) {
+			t.Fatalf("expected token %d available", i)
+		}
+		b.take(1)
+	}
+	if b.available(1) {
+		t.Fatal("expected bucket exhausted")
+	}
+	b.refill(now.Add(500 * time.Millisecond))
+	if !b.available(5) || b.available(6) {
+		t.Fatalf("expected 5 tokens after half second, actual %v", b.tokens)
+	}
+
+	// 超过容量的请求在桶满时放行, 欠下的令牌需要还清
+	b.refill(now.Add(2 * time.Second))
+	if !b.available(100) {
+		t.Fatal("expected request larger than burst admitted by a full bucket")
+	}
+	b.take(100)
+	b.refill(now.Add(3 * time.Second))
+	if b.available(0) {
+		t.Fatalf("expected bucket in debt, actual %v", b.tokens)
+	}
+
+	unlimited := newTokenBucket(0, now)
+	unlimited.take(100)
+	if !unlimited.available(100) {
+		t.Fatal("expected zero rate unlimited")
+	}
+}
+
+func TestResourceGroupAdmit(t *testing.T) {
+	g, err := NewResourceGroup("g1", ResourceGroupUser, "u1", 0, 10, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.admit(0); err != nil {
+		t.Fatal(err)
+	}
+	g.chargeRead(50)
+	if err := g.admit(0); err == nil {
+		t.Fatal("expected rejected after scanning too many rows")
+	}
+	if err := g.admit(200); err == nil {
+		t.Fatal("expected rejected by the rows in debt")
+	}
+	stats := g.stats()
+	if stats.Requests != 3 || stats.Rejected != 2 || stats.RowsRead != 50 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	var nilGroup *ResourceGroup
+	if err := nilGroup.admit(1 << 30); err != nil {
+		t.Fatal("expected nil group unlimited")
+	}
+	nilGroup.chargeRead(1 << 30)
+
+	if _, err := NewResourceGroup("g2", "table", "t1", 1, 1, 1); err == nil {
+		t.Fatal("expected invalid kind")
+	}
+}
+
+func TestResourceGroupsMatch(t *testing.T) {
+	newGroup := func(name, kind, key string, qps uint64) *ResourceGroup {
+		g, err := NewResourceGroup(name, kind, key, qps, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+	groups := NewResourceGroups()
+	groups.Reset([]*ResourceGroup{
+		newGroup("by_user", ResourceGroupUser, "u1", 100),
+		newGroup("by_sign", ResourceGroupSign, "s1", 100),
+		newGroup("by_db", ResourceGroupDb, "d1", 100),
+	})
+
+	cases := []struct {
+		user, db, sign string
+		group          string
+	}{
+		{"u1", "d1", "s1", "by_user"},
+		{"u2", "d1", "s1", "by_sign"},
+		{"u2", "d1", "", "by_db"},
+		{"", "d2", "s2", ""},
+	}
+	for _, c := range cases {
+		g := groups.Match(c.user, c.db, c.sign)
+		var name string
+		if g != nil {
+			name = g.Name
+		}
+		if name != c.group {
+			t.Fatalf("match(%s, %s, %s) expected group %q, actual %q", c.user, c.db, c.sign, c.group, name)
+		}
+	}
+
+	// 限制不变的资源组保留原对象, 修改限制保留统计
+	byUser := groups.Match("u1", "", "")
+	byUser.admit(0)
+	groups.Match("", "", "s1").admit(0)
+	groups.Reset([]*ResourceGroup{
+		newGroup("by_user", ResourceGroupUser, "u1", 100),
+		newGroup("by_sign", ResourceGroupSign, "s1", 200),
+		newGroup("by_db", ResourceGroupDb, "d1", 100),
+	})
+	if g := groups.Match("u1", "", ""); g != byUser {
+		t.Fatal("expected unchanged group kept")
+	}
+	list := groups.List()
+	if len(list) != 3 || list[1].Name != "by_sign" || list[1].QPS != 200 || list[1].Requests != 1 {
+		t.Fatalf("unexpected groups %+v", list)
+	}
+
+	// 在master上删除的资源组不再匹配
+	groups.Reset([]*ResourceGroup{newGroup("by_db", ResourceGroupDb, "d1", 100)})
+	if g := groups.Match("u1", "d1", ""); g == nil || g.Name != "by_db" {
+		t.Fatalf("expected fallback to database group, actual %v", g)
+	}
+}
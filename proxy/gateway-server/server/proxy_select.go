@@ -1,7 +1,9 @@
 package server
 
 import (
+	"container/heap"
 	"fmt"
+	"time"
 
 	"bytes"
 	"model/pkg/kvrpcpb"
@@ -13,9 +15,11 @@ import (
 	"proxy/store/dskv"
 	"util/log"
 	"master-server/engine/errors"
+
+	"golang.org/x/net/context"
 )
 
-func (p *Proxy) HandleSelect(db string, stmt *sqlparser.Select, args []interface{}) (*mysql.Result, error) {
+func (p *Proxy) HandleSelect(ctx context.Context, db string, stmt *sqlparser.Select, args []interface{}, group *ResourceGroup) (*mysql.Result, error) {
 	//var parseTime time.Time
 	//start := time.Now()
 	//defer func() {
@@ -28,6 +32,10 @@ func (p *Proxy) HandleSelect(db string, stmt *sqlparser.Select, args []interface
 	//		log.Info("[select slow log %v %v ", delay.String(), trace.String())
 	//	}
 	//}()
+	// 多表连接在网关中执行
+	if isJoinSelect(stmt) {
+		return p.handleJoin(ctx, db, stmt, group)
+	}
 	parser := &StmtParser{}
 
 	// 解析表名
@@ -75,31 +83,166 @@ func (p *Proxy) HandleSelect(db string, stmt *sqlparser.Select, args []interface
 		limit = &Limit{offset: offset, rowCount: count}
 	}
 
+	// 排序与主键顺序一致时下推到dataserver, 否则取出所有行后在proxy中排序, 不能下推limit
+	orderPushed, desc := pkOrder(t, matchs, sqlOrderColumns(stmt.OrderBy))
+	sortInProxy := len(stmt.OrderBy) > 0 && !orderPushed
+	scanLimit := limit
+	if sortInProxy {
+		scanLimit = nil
+	}
+	columns, err := fieldList2ColNames(fieldList)
+	if err != nil {
+		log.Error("[select] Table %s.%s covert field list to column name failed(%v)", t.DbName(), t.Name(), err)
+		return nil, fmt.Errorf("covert field list error(%v)", err)
+	}
+
 	if log.GetFileLogger().IsEnableDebug() {
 		log.Debug("where %v", stmt.Where)
 		log.Debug("have %v", stmt.Having)
 		log.Debug("cols %v", cols)
 		log.Debug("matchs %v", matchs)
+		log.Debug("order pushed %v desc %v", orderPushed, desc)
+	}
+
+	if err = group.admit(0); err != nil {
+		return nil, err
 	}
 
 	//parseTime = time.Now()
 	// 向dataserver查询
-	rowss, err := p.doSelect(t, fieldList, matchs, limit, nil)
+	sreq, err := p.buildSelectRequest(t, fieldList, matchs, scanLimit, nil)
 	if err != nil {
 		return nil, err
 	}
-
-	columns, err := fieldList2ColNames(fieldList)
+	sreq.Reverse = orderPushed && desc
+	// 有limit时扫描所有行, 只保留排序后的前offset+count行, 不受max limit限制
+	aggre := len(sreq.FieldList) > 0 && sreq.FieldList[0].Typ == kvrpcpb.SelectField_AggreFunction
+	if sortInProxy && limit != nil && len(sreq.Key) == 0 && !aggre {
+		rows, err := p.selectTopN(ctx, t, sreq, columns, stmt.OrderBy, limit.offset+limit.rowCount, group)
+		if err != nil {
+			return nil, err
+		}
+		return buildSelectResult(stmt, [][]*Row{rows}, columns, false)
+	}
+	rowss, err := p.selectRemote(ctx, t, sreq)
 	if err != nil {
-		log.Error("[select] Table %s.%s covert field list to column name failed(%v)", t.DbName(), t.Name(), err)
-		return nil, fmt.Errorf("covert field list error(%v)", err)
+		return nil, err
+	}
+	group.chargeRead(rowsCount(rowss))
+	chargeExamined(ctx, rowsCount(rowss))
+	// 行数被max limit截断时proxy中的排序结果不正确
+	if sortInProxy && rowsCount(rowss) >= p.config.MaxLimit {
+		log.Warn("[select] Table %s.%s order by %s reads more than %d rows", t.DbName(), t.Name(), nstring(stmt.OrderBy), p.config.MaxLimit)
+		return nil, ErrExceedMaxLimit
 	}
 
 	// 合并结果
-	return buildSelectResult(stmt, rowss, columns)
+	return buildSelectResult(stmt, rowss, columns, orderPushed)
+}
+
+// selectTopN scans all the rows of the request and keeps the first n rows in
+// the order, the rows are kept in a heap so the memory is bounded by n rather
+// than the rows scanned. The rows are returned unordered.
+func (p *Proxy) selectTopN(ctx context.Context, t *Table, sreq *kvrpcpb.SelectRequest, columns []string,
+	orderBy sqlparser.OrderBy, n uint64, group *ResourceGroup) ([]*Row, error) {
+	h := &rowHeap{}
+	for _, o := range orderBy {
+		name := nstring(o.Expr)
+		index := -1
+		for i, c := range columns {
+			if c == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("key %s not in resultset fields, can not sort", name)
+		}
+		h.indexes = append(h.indexes, index)
+		h.desc = append(h.desc, o.Direction == sqlparser.AST_DESC)
+	}
+
+	proxy := dskv.GetKvProxy()
+	defer dskv.PutKvProxy(proxy)
+	proxy.Init(p.dsCli, p.clock, t.ranges, client.WriteTimeout, client.ReadTimeoutShort)
+	proxy.Ctx = ctx
+
+	err := proxy.ScanScope(ctx, sreq, sreq.Scope.Start, sreq.Scope.Limit, p.config.MaxLimit, func(pbRows []*kvrpcpb.Row) error {
+		group.chargeRead(uint64(len(pbRows)))
+		chargeExamined(ctx, uint64(len(pbRows)))
+		for _, pr := range pbRows {
+			row, err := decodeRow(t, sreq.FieldList, pr)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				h.add(row, n)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.rows, nil
+}
+
+// rowHeap is a max heap of the rows by the order, the top is the last one of
+// the rows kept.
+type rowHeap struct {
+	rows    []*Row
+	indexes []int
+	desc    []bool
+}
+
+// before reports whether a is ordered before b.
+func (h *rowHeap) before(a, b *Row) bool {
+	for k, index := range h.indexes {
+		cmp := mysql.CompareValue(a.fields[index].value, b.fields[index].value)
+		if h.desc[k] {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return false
 }
 
-func (p *Proxy) doSelect(t *Table, fieldList []*kvrpcpb.SelectField, matches []Match, limit *Limit, userScope *Scope) ([][]*Row, error) {
+// add keeps the row if it is one of the first n rows seen so far.
+func (h *rowHeap) add(row *Row, n uint64) {
+	if uint64(h.Len()) < n {
+		heap.Push(h, row)
+	} else if n > 0 && h.before(row, h.rows[0]) {
+		h.rows[0] = row
+		heap.Fix(h, 0)
+	}
+}
+
+func (h *rowHeap) Len() int           { return len(h.rows) }
+func (h *rowHeap) Less(i, j int) bool { return h.before(h.rows[j], h.rows[i]) }
+func (h *rowHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+
+func (h *rowHeap) Push(x interface{}) {
+	h.rows = append(h.rows, x.(*Row))
+}
+
+func (h *rowHeap) Pop() interface{} {
+	n := len(h.rows)
+	x := h.rows[n-1]
+	h.rows = h.rows[:n-1]
+	return x
+}
+
+func (p *Proxy) doSelect(ctx context.Context, t *Table, fieldList []*kvrpcpb.SelectField, matches []Match, limit *Limit, userScope *Scope) ([][]*Row, error) {
+	sreq, err := p.buildSelectRequest(t, fieldList, matches, limit, userScope)
+	if err != nil {
+		return nil, err
+	}
+	return p.selectRemote(ctx, t, sreq)
+}
+
+func (p *Proxy) buildSelectRequest(t *Table, fieldList []*kvrpcpb.SelectField, matches []Match, limit *Limit, userScope *Scope) (*kvrpcpb.SelectRequest, error) {
 	var err error
 
 	pbMatches, err := makePBMatches(t, matches)
@@ -143,17 +286,18 @@ func (p *Proxy) doSelect(t *Table, fieldList []*kvrpcpb.SelectField, matches []M
 		Key:          key,
 		Scope:        scope,
 		FieldList:    fieldList,
-		WhereFilters: pbMatches,
+		WhereFilters: withExpireFilter(t, pbMatches, time.Now().Unix()),
 		Limit:        pbLimit,
 		Timestamp:    &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
 	}
-	return p.selectRemote(t, sreq)
+	return sreq, nil
 }
 
-func (p *Proxy) selectRemote(t *Table, req *kvrpcpb.SelectRequest) ([][]*Row, error) {
+func (p *Proxy) selectRemote(ctx context.Context, t *Table, req *kvrpcpb.SelectRequest) ([][]*Row, error) {
 	proxy := dskv.GetKvProxy()
 	defer dskv.PutKvProxy(proxy)
 	proxy.Init(p.dsCli, p.clock, t.ranges, client.WriteTimeout, client.ReadTimeoutShort)
+	proxy.Ctx = ctx
 
 	var pbRows [][]*kvrpcpb.Row
 	var err error
@@ -164,6 +308,8 @@ func (p *Proxy) selectRemote(t *Table, req *kvrpcpb.SelectRequest) ([][]*Row, er
 		// 聚合函数，并行执行, 并且没有limit、offset逻辑
 		if len(req.FieldList) > 0 && req.FieldList[0].Typ == kvrpcpb.SelectField_AggreFunction {
 			pbRows, err = p.selectAggre(t, proxy, req)
+		} else if req.Reverse { // 从范围的末尾逆序查询
+			pbRows, err = p.reverseRangeSelectRemote(proxy, req)
 		} else { // 普通的范围查询
 			pbRows, err = p.rangeSelectRemote(proxy, req)
 		}
@@ -203,15 +349,20 @@ func (p *Proxy) rangeSelectRemote(kvproxy *dskv.KvProxy, sreq *kvrpcpb.SelectReq
 	var err error
 	var allRows [][]*kvrpcpb.Row
 	var all, count uint64
-	var offset, rawCount uint64
 	scope := sreq.Scope
 	limit := sreq.Limit
 	var subLimit *kvrpcpb.Limit
+	var more bool
 
 	start = scope.Start
 	end = scope.Limit
 	var rangeCount int
 	for {
+		// 被kill或者超过max_execution_time
+		if err = kvproxy.Context().Err(); err != nil {
+			log.Warn("select interrupted after %d ranges: %v", rangeCount, err)
+			return nil, err
+		}
 		if key == nil {
 			key = start
 		} else if route != nil {
@@ -222,20 +373,8 @@ func (p *Proxy) rangeSelectRemote(kvproxy *dskv.KvProxy, sreq *kvrpcpb.SelectReq
 				break
 			}
 		}
-		if limit != nil {
-			if limit.Offset > all {
-				offset = limit.Offset - all
-				rawCount = limit.Count
-			} else {
-				offset = 0
-				if limit.Count > (all - limit.Offset) {
-					rawCount = limit.Count - (all - limit.Offset)
-				} else {
-					break
-				}
-			}
-			subLimit = &kvrpcpb.Limit{Offset: offset, Count: rawCount}
-			log.Debug("limit %v", subLimit)
+		if subLimit, more = nextSubLimit(limit, all); !more {
+			break
 		}
 		now := p.clock.Now()
 		req := &kvrpcpb.SelectRequest{
@@ -285,3 +424,108 @@ func (p *Proxy) rangeSelectRemote(kvproxy *dskv.KvProxy, sreq *kvrpcpb.SelectReq
 
 	return allRows, nil
 }
+
+// reverseRangeSelectRemote 从范围的末尾开始逆序查询, 每个range内的行由dataserver按key逆序返回
+func (p *Proxy) reverseRangeSelectRemote(kvproxy *dskv.KvProxy, sreq *kvrpcpb.SelectRequest) ([][]*kvrpcpb.Row, error) {
+	var allRows [][]*kvrpcpb.Row
+	var all, count uint64
+	limit := sreq.Limit
+	// 待查询的范围是[start, end), end为空表示没有上界
+	start, end := sreq.Scope.Start, sreq.Scope.Limit
+	locations, err := kvproxy.LocateRanges(start, end)
+	if err != nil {
+		return nil, err
+	}
+	var rangeCount int
+	for {
+		// 被kill或者超过max_execution_time
+		if err = kvproxy.Context().Err(); err != nil {
+			log.Warn("reverse select interrupted after %d ranges: %v", rangeCount, err)
+			return nil, err
+		}
+		// 去掉已经查询过的range
+		for len(locations) > 0 && !keyBefore(locations[len(locations)-1].StartKey, end) {
+			locations = locations[:len(locations)-1]
+		}
+		if len(locations) == 0 {
+			break
+		}
+		key := locations[len(locations)-1].StartKey
+		if bytes.Compare(key, start) < 0 {
+			key = start
+		}
+		subLimit, more := nextSubLimit(limit, all)
+		if !more {
+			break
+		}
+		now := p.clock.Now()
+		req := &kvrpcpb.SelectRequest{
+			Scope:        &kvrpcpb.Scope{Start: start, Limit: end},
+			FieldList:    sreq.FieldList,
+			WhereFilters: sreq.WhereFilters,
+			Limit:        subLimit,
+			Timestamp:    &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
+			Reverse:      true,
+		}
+		resp, route, err := kvproxy.SqlQuery(req, key)
+		if err != nil {
+			return nil, err
+		}
+		if resp.GetCode() != 0 {
+			log.Error("remote server return code: %v", resp.GetCode())
+			return nil, errors.New(fmt.Sprintf("response code is err %v", resp.GetCode()))
+		}
+		rangeCount++
+		// range在定位之后分裂了, dataserver只返回了前一半, 重新定位剩余的范围
+		if len(route.EndKey) > 0 && keyBefore(route.EndKey, end) {
+			log.Info("range %d split during reverse select, relocate [%v, %v)", route.Region.Id, start, end)
+			if locations, err = kvproxy.LocateRanges(start, end); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		rows := resp.GetRows()
+		all += resp.GetOffset()
+		if limit != nil && (uint64(len(rows))+count >= limit.Count) {
+			rows = rows[:limit.Count-count]
+			allRows = append(allRows, rows)
+			return allRows, nil
+		}
+		if len(rows) > 0 {
+			allRows = append(allRows, rows)
+			count += uint64(len(rows))
+		}
+		if bytes.Compare(route.StartKey, start) <= 0 {
+			break
+		}
+		end = route.StartKey
+	}
+
+	if rangeCount >= 3 {
+		log.Warn("request to too much ranges(%d): req: %v", rangeCount, sreq)
+	}
+
+	return allRows, nil
+}
+
+// nextSubLimit 根据已经匹配的行数all计算下一个range的limit, more为false时已经取够了行
+func nextSubLimit(limit *kvrpcpb.Limit, all uint64) (subLimit *kvrpcpb.Limit, more bool) {
+	if limit == nil {
+		return nil, true
+	}
+	if limit.Offset > all {
+		subLimit = &kvrpcpb.Limit{Offset: limit.Offset - all, Count: limit.Count}
+	} else if limit.Count > (all - limit.Offset) {
+		subLimit = &kvrpcpb.Limit{Offset: 0, Count: limit.Count - (all - limit.Offset)}
+	} else {
+		return nil, false
+	}
+	log.Debug("limit %v", subLimit)
+	return subLimit, true
+}
+
+// keyBefore 判断key是否小于limit, limit为空表示没有上界
+func keyBefore(key, limit []byte) bool {
+	return len(limit) == 0 || bytes.Compare(key, limit) < 0
+}
@@ -13,7 +13,6 @@ import (
 	"proxy/store/dskv"
 	"util/log"
 	"util"
-	"context"
 	"fmt"
 )
 
@@ -40,7 +39,7 @@ func (p *Proxy) selectAggre(t *Table, kvproxy *dskv.KvProxy, req *kvrpcpb.Select
 		if key == nil {
 			key = start
 		} else {
-			bo := dskv.NewBackoffer(dskv.MsMaxBackoff, context.Background())
+			bo := dskv.NewBackoffer(dskv.MsMaxBackoff, kvproxy.Context())
 			route, err := kvproxy.RangeCache.LocateKey(bo, key)
 			if err != nil {
 				return nil, fmt.Errorf("locate route failed: %v", err)
@@ -9,8 +9,8 @@ import (
 	"util/log"
 )
 
-// 把来自多个dataserver的多个行转换成最终结果
-func buildSelectResult(stmt *sqlparser.Select, rowss [][]*Row, columns []string) (*mysql.Result, error) {
+// 把来自多个dataserver的多个行转换成最终结果, orderPushed表示行已经按order by的顺序返回
+func buildSelectResult(stmt *sqlparser.Select, rowss [][]*Row, columns []string, orderPushed bool) (*mysql.Result, error) {
 	// 没有记录
 	if len(rowss) <= 0 {
 		return &mysql.Result{
@@ -45,9 +45,16 @@ func buildSelectResult(stmt *sqlparser.Select, rowss [][]*Row, columns []string)
 		}
 		rs = append(rs, result)
 	}
+	// 记录都已过期
+	if len(rs) == 0 {
+		return &mysql.Result{
+			Status:    0,
+			Resultset: newEmptyResultSet(columns),
+		}, nil
+	}
 
 	// 合并来自多个dataserver的mysql.Result
-	return mergeSelectResult(rs, stmt, columns)
+	return mergeSelectResult(rs, stmt, columns, orderPushed)
 }
 
 // make: Empty Set
@@ -119,7 +126,7 @@ func buildResultset(fields []*mysql.Field, names []string, values [][]interface{
 }
 
 // 合并来自多个不同dataserver的结果
-func mergeSelectResult(rs []*mysql.Result, stmt *sqlparser.Select, columns []string) (*mysql.Result, error) {
+func mergeSelectResult(rs []*mysql.Result, stmt *sqlparser.Select, columns []string, orderPushed bool) (*mysql.Result, error) {
 	if len(rs) == 0 {
 		return nil, fmt.Errorf("invalid mysql select result(empty)")
 	}
@@ -155,6 +162,9 @@ func mergeSelectResult(rs []*mysql.Result, stmt *sqlparser.Select, columns []str
 	}
 
 	// order by
+	if orderPushed {
+		return res, nil
+	}
 	if err = sortSelectResult(res.Resultset, stmt); err != nil {
 		return nil, fmt.Errorf("sort select result failed(%v)", err)
 	}
@@ -162,7 +172,7 @@ func mergeSelectResult(rs []*mysql.Result, stmt *sqlparser.Select, columns []str
 	return res, nil
 }
 
-// 排序，处理order by
+// 排序，处理order by, 有limit时只保留前offset+count行再截取
 func sortSelectResult(r *mysql.Resultset, stmt *sqlparser.Select) error {
 	if stmt.OrderBy == nil {
 		return nil
@@ -175,7 +185,17 @@ func sortSelectResult(r *mysql.Resultset, stmt *sqlparser.Select) error {
 		sk[i].Direction = o.Direction
 	}
 
-	return r.Sort(sk)
+	if stmt.Limit == nil {
+		return r.Sort(sk)
+	}
+	offset, count, err := parseLimit(stmt.Limit)
+	if err != nil {
+		return err
+	}
+	if err = r.TopN(sk, int(offset+count)); err != nil {
+		return err
+	}
+	return limitSelectResult(r, stmt)
 }
 
 func limitSelectResult(r *mysql.Resultset, stmt *sqlparser.Select) error {
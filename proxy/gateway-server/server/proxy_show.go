@@ -0,0 +1,432 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"proxy/gateway-server/errors"
+	"proxy/gateway-server/mysql"
+	"proxy/gateway-server/sqlparser"
+	"util/log"
+)
+
+const (
+	ShowDatabases   = "databases"
+	ShowTables      = "tables"
+	ShowTableStatus = "table status"
+	ShowColumns     = "columns"
+	ShowIndex       = "index"
+	ShowCreateTable = "create table"
+	ShowStmtSummary = "statement summary"
+	// 以下只返回空结果, 供客户端连接时探测
+	ShowWarnings  = "warnings"
+	ShowVariables = "variables"
+)
+
+// ShowStmt is a SHOW statement answered from the metadata, the sql parser
+// doesn't support SHOW, so it is parsed here:
+//
+//	SHOW {DATABASES | SCHEMAS} [like_or_where]
+//	SHOW [FULL] TABLES [{FROM | IN} db] [like_or_where]
+//	SHOW TABLE STATUS [{FROM | IN} db] [like_or_where]
+//	SHOW [FULL] {COLUMNS | FIELDS} {FROM | IN} t [{FROM | IN} db] [like_or_where]
+//	SHOW {INDEX | INDEXES | KEYS} {FROM | IN} t [{FROM | IN} db] [WHERE expr]
+//	SHOW CREATE TABLE t
+//	SHOW STATEMENT SUMMARY [{FROM | IN} db] [like_or_where]
+//	SHOW {WARNINGS | ERRORS}
+//	SHOW [GLOBAL | SESSION] {VARIABLES | STATUS} [like_or_where]
+type ShowStmt struct {
+	Type  string
+	Full  bool
+	DB    string
+	Table string
+	// the LIKE pattern matched against the first column
+	Like    string
+	HasLike bool
+	Where   sqlparser.BoolExpr
+}
+
+// showColumn is a column of the SHOW result from the information_schema column.
+type showColumn struct {
+	name   string
+	source string
+}
+
+var showTableStatusColumns = []showColumn{
+	{"Name", "TABLE_NAME"},
+	{"Engine", "ENGINE"},
+	{"Version", "VERSION"},
+	{"Row_format", "ROW_FORMAT"},
+	{"Rows", "TABLE_ROWS"},
+	{"Avg_row_length", "AVG_ROW_LENGTH"},
+	{"Data_length", "DATA_LENGTH"},
+	{"Max_data_length", "MAX_DATA_LENGTH"},
+	{"Index_length", "INDEX_LENGTH"},
+	{"Data_free", "DATA_FREE"},
+	{"Auto_increment", "AUTO_INCREMENT"},
+	{"Create_time", "CREATE_TIME"},
+	{"Update_time", "UPDATE_TIME"},
+	{"Check_time", "CHECK_TIME"},
+	{"Collation", "TABLE_COLLATION"},
+	{"Checksum", "CHECKSUM"},
+	{"Create_options", "CREATE_OPTIONS"},
+	{"Comment", "TABLE_COMMENT"},
+}
+
+var showIndexColumns = []showColumn{
+	{"Table", "TABLE_NAME"},
+	{"Non_unique", "NON_UNIQUE"},
+	{"Key_name", "INDEX_NAME"},
+	{"Seq_in_index", "SEQ_IN_INDEX"},
+	{"Column_name", "COLUMN_NAME"},
+	{"Collation", "COLLATION"},
+	{"Cardinality", "CARDINALITY"},
+	{"Sub_part", "SUB_PART"},
+	{"Packed", "PACKED"},
+	{"Null", "NULLABLE"},
+	{"Index_type", "INDEX_TYPE"},
+	{"Comment", "COMMENT"},
+	{"Index_comment", "INDEX_COMMENT"},
+}
+
+// the LIKE pattern of SHOW STATEMENT SUMMARY matches the digest text
+var showStmtSummaryColumns = []showColumn{
+	{"Digest_text", "DIGEST_TEXT"},
+	{"Schema", "SCHEMA_NAME"},
+	{"Digest", "DIGEST"},
+	{"Exec_count", "EXEC_COUNT"},
+	{"Errors", "SUM_ERRORS"},
+	{"Avg_latency", "AVG_LATENCY"},
+	{"Max_latency", "MAX_LATENCY"},
+	{"P95_latency", "P95_LATENCY"},
+	{"P99_latency", "P99_LATENCY"},
+	{"Avg_rows_examined", "AVG_ROWS_EXAMINED"},
+	{"Avg_rows_returned", "AVG_ROWS_RETURNED"},
+	{"First_seen", "FIRST_SEEN"},
+	{"Last_seen", "LAST_SEEN"},
+	{"Sample", "QUERY_SAMPLE_TEXT"},
+}
+
+// isShowStmt reports whether the statement is SHOW.
+func isShowStmt(sql string) bool {
+	tkn := sqlparser.NewStringTokenizer(sql)
+	typ, val := tkn.Scan()
+	for typ == sqlparser.COMMENT {
+		typ, val = tkn.Scan()
+	}
+	return typ == sqlparser.ID && strings.EqualFold(string(val), "show")
+}
+
+func parseShowStmt(sql string) (*ShowStmt, error) {
+	p, err := newTokenParser(sql)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(sqlparser.ID, "show"); err != nil {
+		return nil, err
+	}
+	stmt := new(ShowStmt)
+	stmt.Full = p.accept(sqlparser.ID, "full")
+	switch {
+	case !stmt.Full && (p.accept(sqlparser.ID, "databases") || p.accept(sqlparser.ID, "schemas")):
+		stmt.Type = ShowDatabases
+	case p.accept(sqlparser.ID, "tables"):
+		stmt.Type = ShowTables
+		if stmt.DB, err = p.fromDB(); err != nil {
+			return nil, err
+		}
+	case !stmt.Full && p.accept(sqlparser.TABLE, ""):
+		if err = p.expect(sqlparser.ID, "status"); err != nil {
+			return nil, err
+		}
+		stmt.Type = ShowTableStatus
+		if stmt.DB, err = p.fromDB(); err != nil {
+			return nil, err
+		}
+	case p.accept(sqlparser.ID, "columns") || p.accept(sqlparser.ID, "fields"):
+		stmt.Type = ShowColumns
+		if err = p.fromTable(stmt); err != nil {
+			return nil, err
+		}
+	case !stmt.Full && (p.accept(sqlparser.INDEX, "") || p.accept(sqlparser.ID, "indexes") || p.accept(sqlparser.ID, "keys")):
+		stmt.Type = ShowIndex
+		if err = p.fromTable(stmt); err != nil {
+			return nil, err
+		}
+	case !stmt.Full && p.accept(sqlparser.CREATE, ""):
+		if err = p.expect(sqlparser.TABLE, ""); err != nil {
+			return nil, err
+		}
+		stmt.Type = ShowCreateTable
+		if stmt.Table, err = p.ident(); err != nil {
+			return nil, err
+		}
+		if p.accept('.', "") {
+			stmt.DB = stmt.Table
+			if stmt.Table, err = p.ident(); err != nil {
+				return nil, err
+			}
+		}
+	case !stmt.Full && p.accept(sqlparser.ID, "statement"):
+		if err = p.expect(sqlparser.ID, "summary"); err != nil {
+			return nil, err
+		}
+		stmt.Type = ShowStmtSummary
+		if stmt.DB, err = p.fromDB(); err != nil {
+			return nil, err
+		}
+	case !stmt.Full && (p.accept(sqlparser.ID, "warnings") || p.accept(sqlparser.ID, "errors")):
+		stmt.Type = ShowWarnings
+	case !stmt.Full:
+		if !p.accept(sqlparser.GLOBAL, "") {
+			p.accept(sqlparser.SESSION, "")
+		}
+		if !p.accept(sqlparser.ID, "variables") && !p.accept(sqlparser.ID, "status") {
+			return nil, p.syntaxError()
+		}
+		stmt.Type = ShowVariables
+	default:
+		return nil, p.syntaxError()
+	}
+
+	switch {
+	case p.accept(sqlparser.LIKE, ""):
+		t := p.next()
+		if t.typ != sqlparser.STRING {
+			return nil, fmt.Errorf("syntax error near %s", string(t.val))
+		}
+		stmt.Like, stmt.HasLike = string(t.val), true
+	case p.peek().typ == sqlparser.WHERE:
+		// the where expression is parsed by the sql parser
+		if stmt.Where, err = parseShowWhere(sql[p.next().end:]); err != nil {
+			return nil, err
+		}
+		return stmt, nil
+	}
+	if p.pos != len(p.tokens) {
+		return nil, p.syntaxError()
+	}
+	return stmt, nil
+}
+
+func parseShowWhere(expr string) (sqlparser.BoolExpr, error) {
+	stmt, err := sqlparser.Parse("select * from dual where " + expr)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.Where == nil || sel.GroupBy != nil || sel.OrderBy != nil || sel.Limit != nil {
+		return nil, fmt.Errorf("syntax error near where %s", expr)
+	}
+	return sel.Where.Expr, nil
+}
+
+// fromDB parses the optional {FROM | IN} db.
+func (p *tokenParser) fromDB() (string, error) {
+	if !p.accept(sqlparser.FROM, "") && !p.accept(sqlparser.IN, "") {
+		return "", nil
+	}
+	return p.ident()
+}
+
+// fromTable parses {FROM | IN} [db.]t [{FROM | IN} db].
+func (p *tokenParser) fromTable(stmt *ShowStmt) error {
+	if !p.accept(sqlparser.FROM, "") && !p.accept(sqlparser.IN, "") {
+		return p.syntaxError()
+	}
+	var err error
+	if stmt.Table, err = p.ident(); err != nil {
+		return err
+	}
+	if p.accept('.', "") {
+		stmt.DB = stmt.Table
+		if stmt.Table, err = p.ident(); err != nil {
+			return err
+		}
+	}
+	if db, err := p.fromDB(); err != nil {
+		return err
+	} else if len(db) > 0 {
+		stmt.DB = db
+	}
+	return nil
+}
+
+// HandleShow answers the SHOW statement by the rows of the information_schema
+// tables, db is the current database.
+func (p *Proxy) HandleShow(db string, stmt *ShowStmt) (*mysql.Result, error) {
+	if len(stmt.DB) > 0 {
+		db = stmt.DB
+	}
+	var rows *metaRows
+	var err error
+	switch stmt.Type {
+	case ShowDatabases:
+		rows, err = p.showRows("SCHEMATA", infoSchemaScope{}, []showColumn{{"Database", "SCHEMA_NAME"}})
+	case ShowTables:
+		if err = p.checkShowDB(db); err != nil {
+			return nil, err
+		}
+		columns := []showColumn{{"Tables_in_" + db, "TABLE_NAME"}}
+		if stmt.Full {
+			columns = append(columns, showColumn{"Table_type", "TABLE_TYPE"})
+		}
+		rows, err = p.showRows("TABLES", infoSchemaScope{db: db}, columns)
+	case ShowTableStatus:
+		if err = p.checkShowDB(db); err != nil {
+			return nil, err
+		}
+		rows, err = p.showRows("TABLES", infoSchemaScope{db: db}, showTableStatusColumns)
+	case ShowColumns:
+		if err = p.checkShowTable(db, stmt.Table); err != nil {
+			return nil, err
+		}
+		columns := []showColumn{{"Field", "COLUMN_NAME"}, {"Type", "COLUMN_TYPE"}}
+		if stmt.Full {
+			columns = append(columns, showColumn{"Collation", "COLLATION_NAME"})
+		}
+		columns = append(columns, showColumn{"Null", "IS_NULLABLE"}, showColumn{"Key", "COLUMN_KEY"},
+			showColumn{"Default", "COLUMN_DEFAULT"}, showColumn{"Extra", "EXTRA"})
+		if stmt.Full {
+			columns = append(columns, showColumn{"Privileges", "PRIVILEGES"}, showColumn{"Comment", "COLUMN_COMMENT"})
+		}
+		rows, err = p.showRows("COLUMNS", infoSchemaScope{db: db, table: stmt.Table}, columns)
+	case ShowIndex:
+		if err = p.checkShowTable(db, stmt.Table); err != nil {
+			return nil, err
+		}
+		rows, err = p.showRows("STATISTICS", infoSchemaScope{db: db, table: stmt.Table}, showIndexColumns)
+	case ShowCreateTable:
+		rows, err = p.showCreateTable(db, stmt.Table)
+	case ShowStmtSummary:
+		// 不指定库时返回所有库的语句
+		rows, err = p.showRows("STATEMENTS_SUMMARY", infoSchemaScope{db: stmt.DB}, showStmtSummaryColumns)
+	case ShowWarnings:
+		rows = newMetaRows([]infoSchemaColumn{{name: "Level"}, {name: "Code", numeric: true}, {name: "Message"}}, nil)
+	case ShowVariables:
+		rows = newMetaRows([]infoSchemaColumn{{name: "Variable_name"}, {name: "Value"}}, nil)
+	default:
+		return nil, fmt.Errorf("show %s not support now", stmt.Type)
+	}
+	if err != nil {
+		log.Error("[show] show %s failed(%v)", stmt.Type, err)
+		return nil, err
+	}
+
+	if stmt.HasLike {
+		rows.filterLike(stmt.Like)
+	}
+	if err = rows.filter(stmt.Where); err != nil {
+		return nil, err
+	}
+	rs, err := rows.resultset()
+	if err != nil {
+		return nil, err
+	}
+	return &mysql.Result{Resultset: rs}, nil
+}
+
+func (p *Proxy) checkShowDB(db string) error {
+	if len(db) == 0 {
+		return errors.ErrNoDatabase
+	}
+	if !isInfoSchema(db) && p.router.FindDB(db) == nil {
+		return mysql.NewDefaultError(mysql.ER_BAD_DB_ERROR, db)
+	}
+	return nil
+}
+
+func (p *Proxy) checkShowTable(db, table string) error {
+	if err := p.checkShowDB(db); err != nil {
+		return err
+	}
+	if isInfoSchema(db) {
+		if _, ok := infoSchemaTables[strings.ToUpper(table)]; ok {
+			return nil
+		}
+	} else if p.router.FindTable(db, table) != nil {
+		return nil
+	}
+	return mysql.NewDefaultError(mysql.ER_NO_SUCH_TABLE, db, table)
+}
+
+// showRows projects the rows of the information_schema table to the columns.
+func (p *Proxy) showRows(table string, scope infoSchemaScope, columns []showColumn) (*metaRows, error) {
+	t := infoSchemaTables[table]
+	values, err := t.rows(p, scope)
+	if err != nil {
+		return nil, err
+	}
+	src := newMetaRows(t.columns, values)
+	cols := make([]infoSchemaColumn, 0, len(columns))
+	indexes := make([]int, 0, len(columns))
+	for _, c := range columns {
+		i, err := src.columnIndex(c.source)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, infoSchemaColumn{name: c.name, numeric: t.columns[i].numeric})
+		indexes = append(indexes, i)
+	}
+	rows := make([][]interface{}, 0, len(values))
+	for _, v := range values {
+		row := make([]interface{}, 0, len(indexes))
+		for _, i := range indexes {
+			row = append(row, v[i])
+		}
+		rows = append(rows, row)
+	}
+	return newMetaRows(cols, rows), nil
+}
+
+// showCreateTable rebuilds the CREATE TABLE statement from the metadata.
+func (p *Proxy) showCreateTable(db, table string) (*metaRows, error) {
+	if err := p.checkShowTable(db, table); err != nil {
+		return nil, err
+	}
+	tables, err := p.schemaTables(db, infoSchemaScope{db: db, table: table})
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, mysql.NewDefaultError(mysql.ER_NO_SUCH_TABLE, db, table)
+	}
+	t := tables[0]
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "CREATE TABLE `%s` (\n", t.GetName())
+	var defs []string
+	for _, col := range t.GetColumns() {
+		def := fmt.Sprintf("  `%s` %s", col.GetName(), columnType(col))
+		if !col.GetNullable() {
+			def += " NOT NULL"
+		}
+		if len(col.GetDefaultValue()) > 0 {
+			def += fmt.Sprintf(" DEFAULT '%s'", strings.Replace(string(col.GetDefaultValue()), "'", "''", -1))
+		} else if col.GetNullable() {
+			def += " DEFAULT NULL"
+		}
+		if col.GetAutoIncrement() {
+			def += " AUTO_INCREMENT"
+		}
+		defs = append(defs, def)
+	}
+	if pks := primaryColumns(t); len(pks) > 0 {
+		names := make([]string, 0, len(pks))
+		for _, col := range pks {
+			names = append(names, "`"+col.GetName()+"`")
+		}
+		defs = append(defs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(names, ",")))
+	}
+	for _, col := range t.GetColumns() {
+		if col.GetIndex() && col.GetPrimaryKey() == 0 {
+			defs = append(defs, fmt.Sprintf("  KEY `%s` (`%s`)", col.GetName(), col.GetName()))
+		}
+	}
+	buf.WriteString(strings.Join(defs, ",\n"))
+	fmt.Fprintf(&buf, "\n) ENGINE=%s DEFAULT CHARSET=%s", infoSchemaEngine, infoSchemaCharset)
+
+	return newMetaRows([]infoSchemaColumn{{name: "Table"}, {name: "Create Table"}},
+		[][]interface{}{{t.GetName(), buf.String()}}), nil
+}
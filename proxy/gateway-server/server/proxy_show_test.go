@@ -0,0 +1,210 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"model/pkg/metapb"
+	"pkg-go/ms_client"
+	"proxy/gateway-server/sqlparser"
+)
+
+type fakeMetaClient struct {
+	client.Client
+	dbs    []*metapb.DataBase
+	tables []*metapb.Table
+}
+
+func (c *fakeMetaClient) GetDBs() ([]*metapb.DataBase, error) {
+	return c.dbs, nil
+}
+
+func (c *fakeMetaClient) GetDB(dbName string) (*metapb.DataBase, error) {
+	for _, db := range c.dbs {
+		if db.GetName() == dbName {
+			return db, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *fakeMetaClient) GetTables(dbName string) ([]*metapb.Table, error) {
+	var tables []*metapb.Table
+	for _, t := range c.tables {
+		if t.GetDbName() == dbName {
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+func (c *fakeMetaClient) GetTable(dbName, tableName string) (*metapb.Table, error) {
+	for _, t := range c.tables {
+		if t.GetDbName() == dbName && t.GetName() == tableName {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func newShowTestProxy() *Proxy {
+	cli := &fakeMetaClient{
+		dbs: []*metapb.DataBase{{Name: "db1", Id: 1}, {Name: "db2", Id: 2}},
+		tables: []*metapb.Table{
+			{Name: "user", DbName: "db1", DbId: 1, Id: 1, Columns: []*metapb.Column{
+				{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, Unsigned: true, PrimaryKey: 1, AutoIncrement: true},
+				{Name: "name", Id: 2, DataType: metapb.DataType_Varchar, Scale: 64, Nullable: true, Index: true},
+				{Name: "age", Id: 3, DataType: metapb.DataType_Int, DefaultValue: []byte("18")},
+			}},
+			{Name: "order", DbName: "db1", DbId: 1, Id: 2, Columns: []*metapb.Column{
+				{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1},
+			}},
+		},
+	}
+	return &Proxy{msCli: cli, router: NewRouter(cli)}
+}
+
+func TestParseShowStmt(t *testing.T) {
+	cases := []struct {
+		sql  string
+		stmt ShowStmt
+	}{
+		{"show databases", ShowStmt{Type: ShowDatabases}},
+		{"SHOW SCHEMAS LIKE 'db%'", ShowStmt{Type: ShowDatabases, Like: "db%", HasLike: true}},
+		{"show full tables from `db1`", ShowStmt{Type: ShowTables, Full: true, DB: "db1"}},
+		{"show table status in db1 like 'u%'", ShowStmt{Type: ShowTableStatus, DB: "db1", Like: "u%", HasLike: true}},
+		{"show full columns from db1.user", ShowStmt{Type: ShowColumns, Full: true, DB: "db1", Table: "user"}},
+		{"show fields in user from db2", ShowStmt{Type: ShowColumns, DB: "db2", Table: "user"}},
+		{"show keys from user", ShowStmt{Type: ShowIndex, Table: "user"}},
+		{"show create table db1.user", ShowStmt{Type: ShowCreateTable, DB: "db1", Table: "user"}},
+		{"show warnings", ShowStmt{Type: ShowWarnings}},
+		{"show session variables like 'sql_mode'", ShowStmt{Type: ShowVariables, Like: "sql_mode", HasLike: true}},
+	}
+	for _, c := range cases {
+		if !isShowStmt(c.sql) {
+			t.Fatalf("expected %q is show", c.sql)
+		}
+		stmt, err := parseShowStmt(c.sql)
+		if err != nil {
+			t.Fatalf("parse %q failed: %v", c.sql, err)
+		}
+		if *stmt != c.stmt {
+			t.Fatalf("parse %q: expected %+v, actual %+v", c.sql, c.stmt, *stmt)
+		}
+	}
+
+	stmt, err := parseShowStmt("show full tables where Table_type = 'BASE TABLE' and `Tables_in_db1` like 'u%'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stmt.Where.(*sqlparser.AndExpr); !ok {
+		t.Fatalf("expected where expression, actual %v", nstring(stmt.Where))
+	}
+
+	for _, sql := range []string{"show", "show full databases", "show columns user", "show tables from", "show tables like user", "show create user"} {
+		if _, err := parseShowStmt(sql); err == nil {
+			t.Fatalf("expected parse %q failed", sql)
+		}
+	}
+	if isShowStmt("select * from show") {
+		t.Fatal("expected select is not show")
+	}
+}
+
+func TestHandleShow(t *testing.T) {
+	p := newShowTestProxy()
+	show := func(db, sql string) [][]interface{} {
+		stmt, err := parseShowStmt(sql)
+		if err != nil {
+			t.Fatalf("parse %q failed: %v", sql, err)
+		}
+		res, err := p.HandleShow(db, stmt)
+		if err != nil {
+			t.Fatalf("%q failed: %v", sql, err)
+		}
+		return res.Values
+	}
+
+	if rows := show("", "show databases"); len(rows) != 3 || rows[0][0] != "db1" || rows[2][0] != InfoSchemaDB {
+		t.Fatalf("unexpected databases %v", rows)
+	}
+	if rows := show("db1", "show full tables where Table_type = 'BASE TABLE' and Tables_in_db1 like 'u%'"); len(rows) != 1 || rows[0][0] != "user" {
+		t.Fatalf("unexpected tables %v", rows)
+	}
+	if rows := show("db1", "show tables from information_schema"); len(rows) != len(infoSchemaTables) {
+		t.Fatalf("unexpected information_schema tables %v", rows)
+	}
+
+	rows := show("db1", "show columns from user")
+	if len(rows) != 3 {
+		t.Fatalf("unexpected columns %v", rows)
+	}
+	if rows[0][1] != "bigint unsigned" || rows[0][3] != "PRI" || rows[0][5] != "auto_increment" ||
+		rows[1][1] != "varchar(64)" || rows[1][3] != "MUL" || rows[1][4] != nil || rows[2][4] != "18" {
+		t.Fatalf("unexpected columns %v", rows)
+	}
+
+	rows = show("db1", "show index from user")
+	if len(rows) != 2 || rows[0][2] != "PRIMARY" || rows[1][2] != "name" || rows[1][1] != uint64(1) {
+		t.Fatalf("unexpected index %v", rows)
+	}
+
+	rows = show("db1", "show create table user")
+	create := rows[0][1].(string)
+	for _, s := range []string{"`id` bigint unsigned NOT NULL AUTO_INCREMENT", "`age` int NOT NULL DEFAULT '18'",
+		"PRIMARY KEY (`id`)", "KEY `name` (`name`)"} {
+		if !strings.Contains(create, s) {
+			t.Fatalf("expected %q in %s", s, create)
+		}
+	}
+
+	stmt, _ := parseShowStmt("show columns from nothing")
+	if _, err := p.HandleShow("db1", stmt); err == nil {
+		t.Fatal("expected table not exist")
+	}
+	stmt, _ = parseShowStmt("show tables")
+	if _, err := p.HandleShow("", stmt); err == nil {
+		t.Fatal("expected no database selected")
+	}
+}
+
+func TestInfoSchemaSelect(t *testing.T) {
+	p := newShowTestProxy()
+	query := func(db, sql string) [][]interface{} {
+		stmt, err := sqlparser.Parse(sql)
+		if err != nil {
+			t.Fatalf("parse %q failed: %v", sql, err)
+		}
+		if _, ok := findInfoSchemaTable(db, stmt.(*sqlparser.Select)); !ok {
+			t.Fatalf("expected %q from information_schema", sql)
+		}
+		res, err := p.HandleInfoSchemaSelect(db, stmt.(*sqlparser.Select))
+		if err != nil {
+			t.Fatalf("%q failed: %v", sql, err)
+		}
+		return res.Values
+	}
+
+	rows := query("db1", "select table_name, ordinal_position as pos from information_schema.columns "+
+		"where table_schema = 'db1' and table_name in ('user', 'order') order by table_name desc, pos limit 1, 2")
+	if len(rows) != 2 || rows[0][0] != "user" || rows[0][1] != uint64(2) || rows[1][1] != uint64(3) {
+		t.Fatalf("unexpected columns %v", rows)
+	}
+	rows = query("information_schema", "select count(*) from tables where table_schema = 'db1'")
+	if len(rows) != 1 || rows[0][0] != uint64(2) {
+		t.Fatalf("unexpected count %v", rows)
+	}
+	rows = query("", "select * from INFORMATION_SCHEMA.SCHEMATA where schema_name like 'db%' and sql_path is null")
+	if len(rows) != 2 || rows[1][1] != "db2" {
+		t.Fatalf("unexpected schemata %v", rows)
+	}
+
+	stmt, _ := sqlparser.Parse("select * from user")
+	if _, ok := findInfoSchemaTable("db1", stmt.(*sqlparser.Select)); ok {
+		t.Fatal("expected user is not in information_schema")
+	}
+	stmt, _ = sqlparser.Parse("select nothing from information_schema.tables")
+	if _, err := p.HandleInfoSchemaSelect("", stmt.(*sqlparser.Select)); err == nil {
+		t.Fatal("expected unknown column")
+	}
+}
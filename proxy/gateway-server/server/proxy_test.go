@@ -1135,6 +1135,59 @@ func TestProxyAdminRoute(t *testing.T) {
 	}, fmt.Sprintf("admin route('show', '%s')", testTableName))
 }
 
+func TestProxyExplain(t *testing.T) {
+	columns := []*columnInfo{
+		&columnInfo{name: "id", typ: metapb.DataType_BigInt, isPK: true},
+		&columnInfo{name: "name", typ: metapb.DataType_Varchar},
+		&columnInfo{name: "balance", typ: metapb.DataType_Double},
+	}
+	db := &metapb.DataBase{Name: testDBName, Id: 1}
+	table := makeTestTable(columns)
+	start := util.EncodeStorePrefix(util.Store_Prefix_KV, table.GetId())
+	r := util.BytesPrefix(start)
+	middle, _ := util.EncodePrimaryKey(start, table.Columns[0], []byte("8"))
+	rng := &metapb.Range{
+		Id:         1,
+		TableId:    1,
+		StartKey:   r.Start,
+		EndKey:     middle,
+		RangeEpoch: &metapb.RangeEpoch{ConfVer: 1, Version: 1},
+		Peers:      []*metapb.Peer{&metapb.Peer{Id: 2, NodeId: 1}},
+	}
+	rng1 := &metapb.Range{
+		Id:         2,
+		TableId:    1,
+		StartKey:   middle,
+		EndKey:     r.Limit,
+		RangeEpoch: &metapb.RangeEpoch{ConfVer: 1, Version: 1},
+		Peers:      []*metapb.Peer{&metapb.Peer{Id: 2, NodeId: 1}},
+	}
+	p := newTestProxy2(db, table, rng, rng1)
+	defer CloseMock(p)
+	defer p.Close()
+
+	plan := testProxyExplain(t, p, "explain select * from "+testTableName+" where id = 3")
+	if plan["access"] != ExplainPointGet || plan["ranges"] != "1" || plan["pushed filters"] != "id = 3" {
+		t.Errorf("unexpected point get plan: %v", plan)
+	}
+
+	plan = testProxyExplain(t, p, "explain select * from "+testTableName+" where id > 10 limit 5")
+	if plan["access"] != ExplainRangeScan || plan["ranges"] != "1" || !strings.HasPrefix(plan["range"], "2 ") {
+		t.Errorf("unexpected range scan plan: %v", plan)
+	}
+	if !strings.HasPrefix(plan["limit"], "offset 0 count 5") {
+		t.Errorf("unexpected limit plan: %v", plan["limit"])
+	}
+
+	plan = testProxyExplain(t, p, "explain select id, name from "+testTableName+" where name = 'a' order by name")
+	if plan["access"] != ExplainFullScan || plan["ranges"] != "2" || plan["pushed filters"] != "name = 'a'" {
+		t.Errorf("unexpected full scan plan: %v", plan)
+	}
+	if !strings.HasPrefix(plan["order by"], "name") || plan["nodes"] != "1(127.0.0.1:6060)" {
+		t.Errorf("unexpected full scan plan: %v", plan)
+	}
+}
+
 //
 //func TestProxyRoute(t *testing.T){
 //	conf := new(Config)
@@ -241,7 +241,7 @@ func testProxyInsert(t *testing.T, p *Proxy, expectedAffected uint64, sql string
 	if !ok {
 		t.Fatalf("not insert stamentent: %s", sql)
 	}
-	res, err := p.HandleInsert(testDBName, stmt, nil)
+	res, err := p.HandleInsert(context.Background(), testDBName, stmt, nil, nil)
 	if err != nil {
 		if  err == dskv.ErrRouteChange{
 			t.Logf("insert failed, %v, sqlL%v", err, sql)
@@ -270,7 +270,7 @@ func testProxyDelete(t *testing.T, p *Proxy, expectAffected uint64, sql string)
 	if !ok {
 		t.Fatalf("not delete stamentent: %s", sql)
 	}
-	res, err := p.HandleDelete(testDBName, stmt, nil)
+	res, err := p.HandleDelete(context.Background(), testDBName, stmt, nil, nil)
 	if err != nil {
 		t.Fatalf("delete faile: %v, sql: %s", err, sql)
 	}
@@ -293,7 +293,7 @@ func testProxySelect(t *testing.T, p *Proxy, expectResult [][]string, sql string
 	if !ok {
 		t.Fatalf("not select stamentent: %s", sql)
 	}
-	r, err := p.HandleSelect(testDBName, stmt, nil)
+	r, err := p.HandleSelect(context.Background(), testDBName, stmt, nil, nil)
 	if err != nil {
 		t.Fatalf("select failed: %v, sql: %v", err, sql)
 	}
@@ -443,7 +443,7 @@ func testGetCommand(t *testing.T, table *Table, p *Proxy, filter *Filter, stmt *
 	if err != nil {
 		t.Fatal("get command, find field list error: " , err)
 	}
-	rowss, err := p.doSelect(table, fieldList, filter.matchs, limit, nil)
+	rowss, err := p.doSelect(context.Background(), table, fieldList, filter.matchs, limit, nil)
 	if err != nil {
 		t.Fatal("get command run error: ", err)
 	}
@@ -506,3 +506,29 @@ func getSelectFilter(tt *testing.T, p *Proxy, db string, stmt *sqlparser.Select)
 
 	return &Filter{columns: columns, matchs: matchs}
 }
+
+// testProxyExplain returns the first info of each item in the plan
+func testProxyExplain(t *testing.T, p *Proxy, sql string) map[string]string {
+	t.Logf("sql> %s ", sql)
+
+	sqlstmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt, ok := sqlstmt.(*sqlparser.Explain)
+	if !ok {
+		t.Fatalf("not explain stamentent: %s", sql)
+	}
+	r, err := p.HandleExplain(testDBName, stmt)
+	if err != nil {
+		t.Fatalf("explain failed: %v, sql: %v", err, sql)
+	}
+	plan := make(map[string]string)
+	for _, row := range formatSelectResult(r) {
+		if _, find := plan[row[0]]; !find {
+			plan[row[0]] = row[1]
+		}
+	}
+	t.Logf("explain result: %v", plan)
+	return plan
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"strconv"
+
+	"model/pkg/kvrpcpb"
+	"util"
+)
+
+// withExpireFilter appends the filter skipping the rows expired before
+// now(unix seconds) to the filters of a table with ttl. The data-server
+// applies it before the limit and the aggregate functions, so the expired rows
+// are never returned or counted until they are deleted by the master.
+func withExpireFilter(t *Table, filters []*kvrpcpb.Match, now int64) []*kvrpcpb.Match {
+	if t.TTL() == 0 {
+		return filters
+	}
+	matches := make([]*kvrpcpb.Match, 0, len(filters)+1)
+	matches = append(matches, filters...)
+	return append(matches, &kvrpcpb.Match{
+		Column:    util.TTLExpireColumn(),
+		Threshold: []byte(strconv.FormatInt(now, 10)),
+		MatchType: kvrpcpb.MatchType_Larger,
+		// the rows written before the ttl is set have no expire time
+		MatchUnset: true,
+	})
+}
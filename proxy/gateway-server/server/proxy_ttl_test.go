@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/metapb"
+	"util"
+)
+
+func TestWithExpireFilter(t *testing.T) {
+	table := NewTable(&metapb.Table{
+		Name:       "t",
+		Columns:    []*metapb.Column{{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1}},
+		Properties: `{"ttl":60}`,
+	}, nil, 0)
+	if table.TTL() != 60 {
+		t.Fatalf("expected ttl 60, actual %d", table.TTL())
+	}
+	filters := []*kvrpcpb.Match{{Column: table.FindColumn("id"), Threshold: []byte("1"), MatchType: kvrpcpb.MatchType_Equal}}
+	matches := withExpireFilter(table, filters, 100)
+	if len(matches) != 2 {
+		t.Fatalf("expected expire filter appended: %v", matches)
+	}
+	m := matches[1]
+	if m.Column.GetId() != util.TTL_EXPIRE_COL_ID || string(m.Threshold) != "100" ||
+		m.MatchType != kvrpcpb.MatchType_Larger || !m.MatchUnset {
+		t.Fatalf("unexpected expire filter: %v", m)
+	}
+	if len(filters) != 1 {
+		t.Fatal("the filters of caller is changed")
+	}
+
+	noTTL := NewTable(&metapb.Table{
+		Name:    "t",
+		Columns: []*metapb.Column{{Name: "id", Id: 1, DataType: metapb.DataType_BigInt, PrimaryKey: 1}},
+	}, nil, 0)
+	if len(withExpireFilter(noTTL, filters, 100)) != 1 {
+		t.Fatal("expected no expire filter for table without ttl")
+	}
+}
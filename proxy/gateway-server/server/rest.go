@@ -87,6 +87,8 @@ type CreateTable struct {
 	DatabaseName string    `json:"databasename"`
 	TableName    string    `json:"tablename"`
 	Columns      []*Column `json:"columns"`
+	// 行的过期时间(秒), 0表示不过期
+	Ttl uint64 `json:"ttl,omitempty"`
 }
 
 type Reply struct {
@@ -99,6 +101,7 @@ type Reply struct {
 type TableProperty struct {
 	Columns []*metapb.Column `json:"columns"`
 	Regxs   []*metapb.Column `json:"regxs"`
+	Ttl     uint64           `json:"ttl,omitempty"`
 }
 
 func (q *Query) parseColumnNames() []string {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"model/pkg/metapb"
+	"model/pkg/mspb"
 	"util/log"
 	"pkg-go/ms_client"
 	"util/ttlcache"
@@ -68,6 +69,22 @@ func (rr *Router) addColumnToRemote(dbId,tableId uint64,cols []string) error{
 	return nil
 }
 
+// alterColumnToRemote alters the column on the master, the table cache is
+// updated by the returned table at once rather than waiting for the expiry.
+func (rr *Router) alterColumnToRemote(req *mspb.AlterColumnRequest) error {
+	table, err := rr.cli.AlterColumn(req)
+	if err != nil {
+		return err
+	}
+	if table == nil {
+		return nil
+	}
+	if db := rr.findDBById(table.GetDbId()); db != nil {
+		db.UpdateTable(table)
+	}
+	return nil
+}
+
 func (rr *Router) findDbFromRemote(dbname string) *DataBase {
 	var _db *metapb.DataBase
 	var err error
@@ -136,4 +153,23 @@ func (rr *Router) GetAllColumns(db, table string) []*metapb.Column {
 		return t.GetAllColumns()
 	}
 	return nil
-}
\ No newline at end of file
+}
+// GetAllDBs lists the databases from the master, they are not cached.
+func (rr *Router) GetAllDBs() ([]*metapb.DataBase, error) {
+	dbs, err := rr.cli.GetDBs()
+	if err != nil {
+		log.Error("get databases from master server failed, err[%v]", err)
+		return nil, err
+	}
+	return dbs, nil
+}
+
+// GetAllTables lists the running tables of the database from the master.
+func (rr *Router) GetAllTables(dbname string) ([]*metapb.Table, error) {
+	tables, err := rr.cli.GetTables(dbname)
+	if err != nil {
+		log.Error("get tables of db[%s] from master server failed, err[%v]", dbname, err)
+		return nil, err
+	}
+	return tables, nil
+}
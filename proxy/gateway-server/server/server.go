@@ -19,6 +19,7 @@ import (
 	"net"
 	"net/http"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -65,11 +66,16 @@ type Server struct {
 	allowipsIndex      int32
 	allowips           [2][]net.IP
 
-	proxy   *Proxy
-	httpSvr *server.Server
+	proxy    *Proxy
+	httpSvr  *server.Server
+	bulkLoad *BulkLoadManager
 
 	listener net.Listener
 	running  bool
+
+	// 已认证的客户端连接, key为connection id
+	connLock sync.RWMutex
+	conns    map[uint32]*ClientConn
 }
 
 func (s *Server) Status() string {
@@ -96,6 +102,7 @@ func NewServer(cfg *Config) (*Server, error) {
 	s.lockRpcAddr = fmt.Sprintf(":%d", cfg.LockRpcPort)
 	s.user = cfg.User
 	s.password = cfg.Password
+	s.conns = make(map[uint32]*ClientConn)
 	atomic.StoreInt32(&s.statusIndex, 0)
 	s.status[s.statusIndex] = Online
 	//	atomic.StoreInt32(&s.logSqlIndex, 0)
@@ -133,6 +140,7 @@ func NewServer(cfg *Config) (*Server, error) {
 		return nil, nil
 	}
 	s.proxy = proxy
+	s.bulkLoad = NewBulkLoadManager(&cfg.BulkLoad, proxy)
 	// start http server for manage
 	svr := server.NewServer()
 	config := &server.ServerConfig{
@@ -152,6 +160,11 @@ func NewServer(cfg *Config) (*Server, error) {
 	svr.Handle("/lock/debug", s.handleLockDebug)
 	svr.Handle("/metric/config/set", s.handleMetricConfigSet)
 	svr.Handle("/metric/config/get", s.handleMetricConfigGet)
+	svr.Handle("/resource_group/list", s.handleResourceGroupList)
+	svr.Handle("/statement/summary", s.handleStatementSummary)
+	svr.Handle("/bulkload", s.handleBulkLoad)
+	svr.Handle("/bulkload/status", s.handleBulkLoadStatus)
+	svr.Handle("/bulkload/resume", s.handleBulkLoadResume)
 	go svr.Run()
 	s.httpSvr = svr
 
@@ -221,6 +234,8 @@ func (s *Server) newClientConn(co net.Conn) *ClientConn {
 	c.stmtId = 0
 	c.stmts = make(map[uint32]*Stmt)
 
+	c.proc = process{command: processCommandSleep, start: time.Now()}
+
 	return c
 }
 
@@ -254,6 +269,8 @@ func (s *Server) onConn(c net.Conn) {
 		conn.Close()
 		return
 	}
+	s.addConn(conn)
+	defer s.removeConn(conn)
 
 	conn.Run()
 }
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"model/pkg/statspb"
+	"proxy/gateway-server/mysql"
+	"proxy/metric"
+	"util/metrics"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	StmtOrderLatency  = "latency"
+	StmtOrderCount    = "count"
+	StmtOrderErrors   = "errors"
+	StmtOrderExamined = "examined"
+	StmtOrderLastSeen = "last_seen"
+)
+
+// stmtExecStats counts the rows of the statement being executed, it's
+// carried to the proxy by the statement context.
+type stmtExecStats struct {
+	examined uint64
+	returned uint64
+}
+
+type stmtExecStatsKey struct{}
+
+func withStmtExecStats(ctx context.Context, stats *stmtExecStats) context.Context {
+	return context.WithValue(ctx, stmtExecStatsKey{}, stats)
+}
+
+// chargeExamined adds the rows read from the data servers for the statement.
+func chargeExamined(ctx context.Context, rows uint64) {
+	if stats, ok := ctx.Value(stmtExecStatsKey{}).(*stmtExecStats); ok {
+		atomic.AddUint64(&stats.examined, rows)
+	}
+}
+
+func (s *stmtExecStats) chargeReturned(rows uint64) {
+	if s != nil {
+		atomic.AddUint64(&s.returned, rows)
+	}
+}
+
+func (s *stmtExecStats) rows() (examined, returned uint64) {
+	if s == nil {
+		return 0, 0
+	}
+	return atomic.LoadUint64(&s.examined), atomic.LoadUint64(&s.returned)
+}
+
+// StatementSummary is a digest with the averages and latency percentiles,
+// the latencies are in microseconds.
+type StatementSummary struct {
+	*statspb.StatementDigest
+	AvgLatency  uint64 `json:"avg_latency"`
+	P50Latency  uint64 `json:"p50_latency"`
+	P95Latency  uint64 `json:"p95_latency"`
+	P99Latency  uint64 `json:"p99_latency"`
+	AvgExamined uint64 `json:"avg_rows_examined"`
+	AvgReturned uint64 `json:"avg_rows_returned"`
+}
+
+func newStatementSummary(d *statspb.StatementDigest) *StatementSummary {
+	s := &StatementSummary{StatementDigest: d}
+	if count := d.GetCount(); count > 0 {
+		s.AvgLatency = d.GetSumLatency() / count
+		s.AvgExamined = d.GetRowsExamined() / count
+		s.AvgReturned = d.GetRowsReturned() / count
+	}
+	s.P50Latency = metrics.LatencyPercentile(d.GetLatencyBuckets(), d.GetMaxLatency(), 0.5)
+	s.P95Latency = metrics.LatencyPercentile(d.GetLatencyBuckets(), d.GetMaxLatency(), 0.95)
+	s.P99Latency = metrics.LatencyPercentile(d.GetLatencyBuckets(), d.GetMaxLatency(), 0.99)
+	return s
+}
+
+type stmtDigestKey struct {
+	db     string
+	digest string
+}
+
+// StmtSummary aggregates the statements by the database and the digest of
+// the sql fingerprint, the least recently seen digest is evicted when full.
+type StmtSummary struct {
+	lock         sync.Mutex
+	maxDigests   int
+	maxSampleLen int
+	digests      map[stmtDigestKey]*list.Element
+	// 按最近出现时间排序, 队首最新
+	lru *list.List
+}
+
+func NewStmtSummary(maxDigests, maxSampleLen int) *StmtSummary {
+	return &StmtSummary{
+		maxDigests:   maxDigests,
+		maxSampleLen: maxSampleLen,
+		digests:      make(map[stmtDigestKey]*list.Element),
+		lru:          list.New(),
+	}
+}
+
+// Record adds an executed statement to its digest.
+func (s *StmtSummary) Record(db, sql string, ok bool, delay time.Duration, examined, returned uint64) {
+	if s == nil {
+		return
+	}
+	text := mysql.GetFingerprint(sql)
+	key := stmtDigestKey{db: db, digest: mysql.GetMd5(text)}
+	latency := uint64(delay / time.Microsecond)
+	now := time.Now().Unix()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var d *statspb.StatementDigest
+	if e, find := s.digests[key]; find {
+		s.lru.MoveToFront(e)
+		d = e.Value.(*statspb.StatementDigest)
+	} else {
+		if len(sql) > s.maxSampleLen {
+			sql = sql[:s.maxSampleLen]
+		}
+		d = &statspb.StatementDigest{
+			Digest:         key.digest,
+			Db:             db,
+			Text:           text,
+			Sample:         sql,
+			MinLatency:     latency,
+			LatencyBuckets: make([]uint64, len(metrics.LatencyBuckets)+1),
+			FirstSeen:      now,
+		}
+		s.digests[key] = s.lru.PushFront(d)
+		for s.lru.Len() > s.maxDigests {
+			e := s.lru.Back()
+			old := s.lru.Remove(e).(*statspb.StatementDigest)
+			delete(s.digests, stmtDigestKey{db: old.Db, digest: old.Digest})
+		}
+	}
+	d.Count++
+	if !ok {
+		d.Errors++
+	}
+	d.SumLatency += latency
+	if latency > d.MaxLatency {
+		d.MaxLatency = latency
+	}
+	if latency < d.MinLatency {
+		d.MinLatency = latency
+	}
+	d.LatencyBuckets[metrics.LatencyBucket(delay)]++
+	d.RowsExamined += examined
+	d.RowsReturned += returned
+	d.LastSeen = now
+}
+
+// Digests returns a copy of the digests.
+func (s *StmtSummary) Digests() []*statspb.StatementDigest {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	digests := make([]*statspb.StatementDigest, 0, s.lru.Len())
+	for e := s.lru.Front(); e != nil; e = e.Next() {
+		d := *e.Value.(*statspb.StatementDigest)
+		d.LatencyBuckets = append([]uint64(nil), d.LatencyBuckets...)
+		digests = append(digests, &d)
+	}
+	return digests
+}
+
+// Top returns the top n summaries of the database in the order, db and n
+// are not limited if empty or 0.
+func (s *StmtSummary) Top(db, order string, n int) []*StatementSummary {
+	var summaries []*StatementSummary
+	for _, d := range s.Digests() {
+		if len(db) == 0 || d.GetDb() == db {
+			summaries = append(summaries, newStatementSummary(d))
+		}
+	}
+	sortStatementSummaries(summaries, order)
+	if n > 0 && len(summaries) > n {
+		summaries = summaries[:n]
+	}
+	return summaries
+}
+
+// sortStatementSummaries sorts the summaries in descending order, by the
+// total latency if the order is unknown.
+func sortStatementSummaries(summaries []*StatementSummary, order string) {
+	var key func(s *StatementSummary) uint64
+	switch order {
+	case StmtOrderCount:
+		key = func(s *StatementSummary) uint64 { return s.GetCount() }
+	case StmtOrderErrors:
+		key = func(s *StatementSummary) uint64 { return s.GetErrors() }
+	case StmtOrderExamined:
+		key = func(s *StatementSummary) uint64 { return s.GetRowsExamined() }
+	case StmtOrderLastSeen:
+		key = func(s *StatementSummary) uint64 { return uint64(s.GetLastSeen()) }
+	default:
+		key = func(s *StatementSummary) uint64 { return s.GetSumLatency() }
+	}
+	sort.SliceStable(summaries, func(i, j int) bool { return key(summaries[i]) > key(summaries[j]) })
+}
+
+// pushStmtSummary reports the summary to the metric server periodically, an
+// interval not set by the config falls back to the default.
+func (p *Proxy) pushStmtSummary(interval time.Duration) {
+	defer p.wg.Done()
+	if interval <= 0 {
+		interval = DefaultStmtSummaryPushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			metric.GsMetric.StatementMetric(&statspb.StatementStats{Digests: p.stmts.Digests()})
+		}
+	}
+}
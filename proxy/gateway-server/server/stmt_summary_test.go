@@ -0,0 +1,90 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestStmtSummary(t *testing.T) {
+	s := NewStmtSummary(2, 16)
+	s.Record("db1", "select * from user where id = 1", true, time.Millisecond, 1, 1)
+	s.Record("db1", "SELECT *  FROM user WHERE id = 2", false, 3*time.Millisecond, 0, 0)
+	s.Record("db2", "select * from user where id = 3", true, 100*time.Millisecond, 5, 0)
+
+	top := s.Top("", "", 0)
+	if len(top) != 2 {
+		t.Fatalf("expected 2 digests, actual %d", len(top))
+	}
+	if top[0].Db != "db2" || top[1].Db != "db1" || top[0].Digest != top[1].Digest {
+		t.Fatalf("unexpected digests %v %v", top[0], top[1])
+	}
+	d := top[1]
+	if d.Count != 2 || d.Errors != 1 || d.SumLatency != 4000 || d.MinLatency != 1000 || d.MaxLatency != 3000 ||
+		d.RowsExamined != 1 || d.RowsReturned != 1 || d.AvgLatency != 2000 || d.P50Latency != 1000 || d.P99Latency != 3000 {
+		t.Fatalf("unexpected digest %+v", d)
+	}
+	if d.Text != "select * from user where id = ?" || d.Sample != "select * from us" {
+		t.Fatalf("unexpected digest text %q, sample %q", d.Text, d.Sample)
+	}
+
+	// 最久未出现的db2被淘汰
+	s.Record("db1", "select * from user where id = 4", true, time.Millisecond, 0, 0)
+	s.Record("db1", "delete from user", true, time.Millisecond, 0, 0)
+	top = s.Top("", StmtOrderCount, 0)
+	if len(top) != 2 || top[0].Count != 3 || top[1].Text != "delete from user" {
+		t.Fatalf("unexpected digests %v", top)
+	}
+	if top = s.Top("db2", "", 0); len(top) != 0 {
+		t.Fatalf("expected db2 evicted, actual %v", top)
+	}
+	if top = s.Top("db1", StmtOrderLastSeen, 1); len(top) != 1 {
+		t.Fatalf("expected top 1, actual %v", top)
+	}
+}
+
+func TestStmtExecStats(t *testing.T) {
+	stats := new(stmtExecStats)
+	ctx := withStmtExecStats(context.Background(), stats)
+	chargeExamined(ctx, 10)
+	chargeExamined(context.Background(), 10)
+	stats.chargeReturned(3)
+	if examined, returned := stats.rows(); examined != 10 || returned != 3 {
+		t.Fatalf("unexpected rows %d %d", examined, returned)
+	}
+	var none *stmtExecStats
+	none.chargeReturned(1)
+	if examined, returned := none.rows(); examined != 0 || returned != 0 {
+		t.Fatal("expected no rows")
+	}
+}
+
+func TestShowStmtSummary(t *testing.T) {
+	stmt, err := parseShowStmt("show statement summary from db1 like 'select%'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *stmt != (ShowStmt{Type: ShowStmtSummary, DB: "db1", Like: "select%", HasLike: true}) {
+		t.Fatalf("unexpected stmt %+v", *stmt)
+	}
+
+	p := newShowTestProxy()
+	p.stmts = NewStmtSummary(10, 1024)
+	p.stmts.Record("db1", "select * from user where id = 1", true, time.Millisecond, 1, 1)
+	p.stmts.Record("db1", "delete from user where id = 1", true, time.Millisecond, 1, 0)
+	p.stmts.Record("db2", "select * from user where id = 1", true, time.Millisecond, 1, 0)
+	res, err := p.HandleShow("", stmt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Values) != 1 || res.Values[0][0] != "select * from user where id = ?" || res.Values[0][1] != "db1" ||
+		res.Values[0][3] != uint64(1) {
+		t.Fatalf("unexpected summary %v", res.Values)
+	}
+
+	stmt, _ = parseShowStmt("show statement summary where Exec_count > 0")
+	if res, err = p.HandleShow("db1", stmt); err != nil || len(res.Values) != 3 {
+		t.Fatalf("unexpected summary %v, err %v", res, err)
+	}
+}
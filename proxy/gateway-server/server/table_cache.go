@@ -7,6 +7,7 @@ import (
 
 	"pkg-go/ms_client"
 	"model/pkg/metapb"
+	"util"
 	"util/log"
 	"proxy/store/dskv"
 )
@@ -23,6 +24,9 @@ type Table struct {
 	cLock     sync.RWMutex
 	columns   map[string]*metapb.Column
 	columnIds map[uint64]*metapb.Column
+
+	// 行的过期时间(秒), 0表示不过期
+	ttl uint64
 }
 
 func NewTable(table *metapb.Table, cli client.Client, ttl time.Duration) *Table {
@@ -45,6 +49,11 @@ func NewTable(table *metapb.Table, cli client.Client, ttl time.Duration) *Table
 		}
 	}
 	t.primaryKeys = pks
+	rowTTL, err := util.ParseTableTTL(table.GetProperties())
+	if err != nil {
+		log.Warn("table %s.%s invalid properties: %v", t.DbName(), t.Name(), err)
+	}
+	t.ttl = rowTTL
 	return t
 }
 
@@ -64,6 +73,10 @@ func (t *Table) PKS() []string {
 	return t.primaryKeys
 }
 
+func (t *Table) TTL() uint64 {
+	return t.ttl
+}
+
 type SortRoutes []*metapb.Route
 
 func (s SortRoutes) Len() int {
@@ -133,14 +146,14 @@ func (t *Table) AddColumn(c *metapb.Column) {
 	}
 
 	t.cLock.Lock()
-	defer t.cLock.Lock()
+	defer t.cLock.Unlock()
 	t.columns[c.Name] = c
 	t.columnIds[c.Id] = c
 }
 
 func (t *Table) DeleteColumn(columnName string) {
 	t.cLock.Lock()
-	defer t.cLock.Lock()
+	defer t.cLock.Unlock()
 	if c, ok := t.columns[columnName]; ok {
 		delete(t.columns, columnName)
 		delete(t.columnIds, c.Id)
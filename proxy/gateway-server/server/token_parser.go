@@ -0,0 +1,94 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"proxy/gateway-server/sqlparser"
+)
+
+type parserToken struct {
+	typ int
+	val []byte
+	// offset in the sql after the token
+	end int
+}
+
+// tokenParser parses the statements the sql grammar doesn't support, such as
+// show and kill, from the tokens of the sql tokenizer.
+type tokenParser struct {
+	tokens []parserToken
+	pos    int
+}
+
+func newTokenParser(sql string) (*tokenParser, error) {
+	tkn := sqlparser.NewStringTokenizer(sql)
+	p := new(tokenParser)
+	for {
+		typ, val := tkn.Scan()
+		switch typ {
+		case 0:
+			return p, nil
+		case sqlparser.COMMENT:
+			continue
+		case sqlparser.LEX_ERROR:
+			return nil, fmt.Errorf("syntax error near %s", string(val))
+		}
+		// the tokenizer has read one char ahead
+		p.tokens = append(p.tokens, parserToken{typ: typ, val: val, end: tkn.Position - 1})
+	}
+}
+
+func (p *tokenParser) peek() parserToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return parserToken{}
+}
+
+func (p *tokenParser) next() parserToken {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the keyword or the identifier word.
+func (p *tokenParser) accept(typ int, word string) bool {
+	t := p.peek()
+	if t.typ != typ {
+		return false
+	}
+	if len(word) > 0 && !strings.EqualFold(string(t.val), word) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *tokenParser) expect(typ int, word string) error {
+	if !p.accept(typ, word) {
+		return p.syntaxError()
+	}
+	return nil
+}
+
+func (p *tokenParser) ident() (string, error) {
+	t := p.next()
+	if t.typ != sqlparser.ID {
+		return "", p.syntaxError()
+	}
+	return string(t.val), nil
+}
+
+func (p *tokenParser) syntaxError() error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("syntax error at the end of statement")
+	}
+	t := p.tokens[p.pos]
+	if t.val == nil {
+		return fmt.Errorf("syntax error near %c", rune(t.typ))
+	}
+	return fmt.Errorf("syntax error near %s", string(t.val))
+}
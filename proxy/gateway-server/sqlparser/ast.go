@@ -237,6 +237,84 @@ func (node *DDL) Format(buf *TrackedBuffer) {
 	}
 }
 
+// AlterTable represents an ALTER TABLE statement changing one column.
+// NewName is set for AST_RENAME_COLUMN, Default for AST_SET_DEFAULT
+// and Type for AST_MODIFY_COLUMN.
+type AlterTable struct {
+	Ignore  string
+	Table   *TableName
+	Action  string
+	Column  []byte
+	NewName []byte
+	Default ValExpr
+	Type    *ColumnType
+}
+
+const (
+	AST_DROP_COLUMN   = "drop column"
+	AST_RENAME_COLUMN = "rename column"
+	AST_SET_DEFAULT   = "set default"
+	AST_DROP_DEFAULT  = "drop default"
+	AST_MODIFY_COLUMN = "modify column"
+)
+
+func (*AlterTable) IStatement() {}
+
+func (node *AlterTable) Format(buf *TrackedBuffer) {
+	if node.Ignore != "" {
+		buf.Fprintf("alter %s table %v ", node.Ignore, node.Table)
+	} else {
+		buf.Fprintf("alter table %v ", node.Table)
+	}
+	switch node.Action {
+	case AST_DROP_COLUMN, AST_MODIFY_COLUMN:
+		buf.Fprintf("%s ", node.Action)
+		escape(buf, node.Column)
+		if node.Type != nil {
+			buf.Fprintf(" %v", node.Type)
+		}
+	case AST_RENAME_COLUMN:
+		buf.Fprintf("%s ", node.Action)
+		escape(buf, node.Column)
+		buf.Fprintf(" to ")
+		escape(buf, node.NewName)
+	case AST_SET_DEFAULT:
+		buf.Fprintf("alter column ")
+		escape(buf, node.Column)
+		buf.Fprintf(" set default %v", node.Default)
+	case AST_DROP_DEFAULT:
+		buf.Fprintf("alter column ")
+		escape(buf, node.Column)
+		buf.Fprintf(" drop default")
+	}
+}
+
+// ColumnType represents the type of a column in MODIFY COLUMN.
+type ColumnType struct {
+	Type     []byte
+	Size     [][]byte
+	Unsigned bool
+	NotNull  bool
+}
+
+func (node *ColumnType) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", node.Type)
+	prefix := "("
+	for _, n := range node.Size {
+		buf.Fprintf("%s%s", prefix, n)
+		prefix = ", "
+	}
+	if len(node.Size) > 0 {
+		buf.Fprintf(")")
+	}
+	if node.Unsigned {
+		buf.Fprintf(" unsigned")
+	}
+	if node.NotNull {
+		buf.Fprintf(" not null")
+	}
+}
+
 // Comments represents a list of comments.
 type Comments [][]byte
 
@@ -1030,3 +1108,14 @@ func (*Describe) IStatement() {}
 func (node *Describe) Format(buf *TrackedBuffer) {
 	buf.Fprintf("describe %v", string(node.TableName))
 }
+
+// Explain shows how a select is routed and executed
+type Explain struct {
+	Select SelectStatement
+}
+
+func (*Explain) IStatement() {}
+
+func (node *Explain) Format(buf *TrackedBuffer) {
+	buf.Fprintf("explain %v", node.Select)
+}
@@ -19,13 +19,15 @@ func ForceEOF(yylex interface{}) {
 }
 
 var (
-	SHARE        = []byte("share")
-	MODE         = []byte("mode")
-	IF_BYTES     = []byte("if")
-	VALUES_BYTES = []byte("values")
+	SHARE          = []byte("share")
+	MODE           = []byte("mode")
+	IF_BYTES       = []byte("if")
+	VALUES_BYTES   = []byte("values")
+	MODIFY_BYTES   = []byte("modify")
+	UNSIGNED_BYTES = []byte("unsigned")
 )
 
-//line sql.y:45
+//line sql.y:47
 type yySymType struct {
 	yys         int
 	empty       struct{}
@@ -60,6 +62,9 @@ type yySymType struct {
 	insRows     InsertRows
 	updateExprs UpdateExprs
 	updateExpr  *UpdateExpr
+	alterTable  *AlterTable
+	columnType  *ColumnType
+	boolVal     bool
 }
 
 const LEX_ERROR = 57346
@@ -159,8 +164,10 @@ const IGNORE = 57439
 const IF = 57440
 const UNIQUE = 57441
 const USING = 57442
-const TRUNCATE = 57443
-const DESCRIBE = 57444
+const COLUMN = 57443
+const TRUNCATE = 57444
+const DESCRIBE = 57445
+const EXPLAIN = 57446
 
 var yyToknames = [...]string{
 	"$end",
@@ -278,8 +285,10 @@ var yyToknames = [...]string{
 	"IF",
 	"UNIQUE",
 	"USING",
+	"COLUMN",
 	"TRUNCATE",
 	"DESCRIBE",
+	"EXPLAIN",
 	"')'",
 }
 var yyStatenames = [...]string{}
@@ -297,286 +306,305 @@ var yyExca = [...]int{
 
 const yyPrivate = 57344
 
-const yyLast = 690
+const yyLast = 705
 
 var yyAct = [...]int{
 
-	115, 161, 112, 413, 381, 195, 283, 193, 149, 78,
-	106, 376, 123, 196, 3, 298, 241, 278, 142, 422,
-	208, 101, 113, 235, 80, 75, 118, 122, 422, 102,
-	128, 39, 40, 41, 42, 224, 65, 170, 169, 94,
-	105, 119, 120, 121, 422, 110, 126, 87, 56, 82,
-	57, 81, 69, 275, 89, 118, 122, 91, 51, 128,
-	53, 95, 394, 57, 54, 109, 163, 129, 297, 105,
-	119, 120, 121, 152, 110, 126, 349, 163, 163, 107,
-	393, 271, 392, 124, 125, 103, 238, 137, 88, 148,
-	100, 83, 424, 90, 109, 58, 129, 156, 269, 341,
-	151, 423, 85, 134, 84, 166, 362, 364, 272, 59,
-	60, 61, 124, 125, 103, 342, 343, 421, 197, 127,
-	192, 194, 198, 157, 141, 160, 291, 62, 373, 290,
-	319, 201, 292, 82, 287, 81, 82, 204, 81, 372,
-	179, 215, 206, 219, 220, 168, 212, 213, 127, 348,
-	330, 328, 140, 273, 270, 221, 79, 205, 133, 239,
-	210, 143, 144, 256, 363, 234, 427, 139, 248, 215,
-	279, 107, 247, 93, 147, 245, 255, 254, 216, 252,
-	250, 251, 257, 258, 169, 261, 262, 263, 264, 265,
-	266, 267, 268, 246, 370, 253, 308, 309, 310, 311,
-	312, 389, 313, 314, 279, 377, 333, 107, 107, 230,
-	145, 178, 177, 180, 181, 182, 183, 184, 179, 286,
-	285, 274, 276, 249, 228, 294, 293, 282, 155, 280,
-	231, 259, 170, 169, 295, 96, 288, 82, 82, 81,
-	303, 182, 183, 184, 179, 391, 301, 135, 135, 300,
-	180, 181, 182, 183, 184, 179, 245, 356, 377, 390,
-	318, 305, 357, 323, 324, 170, 169, 354, 366, 260,
-	360, 375, 355, 359, 320, 55, 358, 322, 209, 327,
-	162, 271, 82, 107, 81, 338, 164, 398, 340, 337,
-	334, 336, 209, 384, 300, 332, 335, 329, 217, 409,
-	408, 227, 229, 226, 178, 177, 180, 181, 182, 183,
-	184, 179, 407, 306, 400, 401, 163, 20, 138, 245,
-	245, 74, 347, 352, 353, 244, 202, 135, 368, 369,
-	243, 321, 281, 371, 39, 40, 41, 42, 200, 379,
-	199, 374, 237, 98, 236, 130, 244, 382, 378, 304,
-	82, 243, 385, 383, 237, 20, 21, 22, 23, 178,
-	177, 180, 181, 182, 183, 184, 179, 178, 177, 180,
-	181, 182, 183, 184, 179, 317, 167, 159, 395, 24,
-	66, 83, 367, 396, 131, 365, 345, 211, 344, 233,
-	316, 66, 232, 403, 405, 216, 212, 207, 404, 76,
-	406, 153, 150, 411, 35, 412, 382, 402, 414, 414,
-	414, 146, 415, 416, 92, 68, 64, 218, 82, 419,
-	81, 49, 50, 428, 132, 410, 397, 425, 429, 20,
-	430, 97, 326, 420, 20, 222, 29, 30, 72, 31,
-	32, 154, 70, 164, 118, 122, 388, 339, 128, 284,
-	33, 34, 387, 299, 25, 26, 28, 27, 83, 119,
-	120, 121, 351, 110, 126, 209, 36, 37, 77, 426,
-	417, 20, 118, 122, 44, 19, 128, 18, 17, 16,
-	15, 14, 13, 109, 12, 129, 83, 119, 120, 121,
-	289, 110, 126, 178, 177, 180, 181, 182, 183, 184,
-	179, 124, 125, 122, 99, 223, 128, 52, 296, 225,
-	86, 109, 302, 129, 418, 20, 83, 119, 120, 121,
-	399, 138, 126, 380, 386, 350, 331, 203, 277, 124,
-	125, 122, 117, 114, 128, 116, 346, 127, 214, 111,
-	171, 108, 361, 129, 83, 119, 120, 121, 242, 138,
-	126, 307, 240, 104, 122, 315, 165, 128, 71, 124,
-	125, 136, 38, 122, 158, 127, 128, 83, 119, 120,
-	121, 129, 138, 126, 73, 11, 83, 119, 120, 121,
-	10, 138, 126, 9, 8, 7, 6, 124, 125, 5,
-	4, 2, 1, 0, 129, 127, 177, 180, 181, 182,
-	183, 184, 179, 129, 0, 0, 0, 0, 0, 0,
-	124, 125, 0, 0, 0, 0, 0, 0, 0, 124,
-	125, 173, 175, 127, 0, 0, 0, 185, 186, 187,
-	188, 189, 190, 191, 176, 174, 172, 178, 177, 180,
-	181, 182, 183, 184, 179, 325, 127, 308, 309, 310,
-	311, 312, 43, 313, 314, 127, 0, 0, 0, 0,
-	0, 0, 178, 177, 180, 181, 182, 183, 184, 179,
-	0, 0, 0, 0, 45, 46, 47, 48, 0, 0,
-	0, 0, 0, 0, 0, 0, 63, 0, 0, 67,
+	118, 164, 115, 394, 81, 444, 287, 109, 126, 198,
+	152, 389, 245, 307, 116, 282, 211, 199, 3, 196,
+	239, 104, 105, 303, 83, 145, 459, 279, 459, 121,
+	125, 78, 459, 131, 173, 172, 454, 67, 41, 42,
+	43, 44, 468, 108, 122, 123, 124, 304, 113, 129,
+	302, 85, 166, 84, 97, 301, 92, 71, 72, 94,
+	362, 227, 90, 98, 59, 375, 377, 166, 112, 397,
+	132, 317, 318, 319, 320, 321, 166, 322, 323, 155,
+	275, 242, 58, 53, 59, 55, 127, 128, 106, 56,
+	398, 412, 151, 411, 110, 410, 103, 273, 91, 144,
+	159, 461, 140, 460, 93, 154, 60, 458, 169, 350,
+	86, 453, 137, 61, 62, 63, 88, 276, 146, 147,
+	195, 197, 130, 376, 386, 153, 87, 385, 64, 277,
+	204, 328, 160, 200, 163, 361, 85, 201, 84, 85,
+	209, 84, 339, 232, 218, 379, 291, 295, 351, 352,
+	294, 337, 207, 296, 235, 274, 243, 171, 231, 182,
+	224, 215, 216, 213, 68, 143, 208, 399, 222, 223,
+	238, 252, 218, 136, 249, 82, 185, 186, 187, 182,
+	254, 255, 219, 466, 142, 283, 110, 251, 148, 250,
+	257, 283, 451, 342, 256, 173, 172, 261, 262, 150,
+	265, 266, 267, 268, 269, 270, 271, 272, 172, 418,
+	419, 183, 184, 185, 186, 187, 182, 329, 439, 407,
+	289, 96, 110, 110, 278, 280, 286, 253, 298, 284,
+	452, 390, 438, 436, 290, 234, 233, 229, 263, 299,
+	297, 85, 85, 84, 312, 310, 292, 181, 180, 183,
+	184, 185, 186, 187, 182, 158, 249, 309, 305, 306,
+	327, 314, 181, 180, 183, 184, 185, 186, 187, 182,
+	260, 173, 172, 331, 330, 437, 264, 388, 409, 332,
+	333, 408, 369, 259, 258, 99, 85, 370, 84, 347,
+	345, 343, 349, 346, 373, 336, 383, 341, 344, 110,
+	372, 338, 309, 181, 180, 183, 184, 185, 186, 187,
+	182, 21, 57, 181, 180, 183, 184, 185, 186, 187,
+	182, 367, 371, 360, 249, 249, 368, 125, 365, 366,
+	131, 214, 181, 180, 183, 184, 185, 186, 187, 182,
+	86, 122, 123, 124, 138, 141, 129, 212, 392, 381,
+	382, 248, 138, 275, 384, 390, 247, 391, 416, 401,
+	77, 212, 387, 85, 396, 403, 402, 132, 395, 334,
+	220, 285, 432, 21, 22, 23, 24, 41, 42, 43,
+	44, 241, 315, 127, 128, 240, 181, 180, 183, 184,
+	185, 186, 187, 182, 431, 241, 138, 25, 317, 318,
+	319, 320, 321, 413, 322, 323, 430, 133, 414, 141,
+	424, 426, 165, 427, 205, 429, 428, 425, 167, 130,
+	434, 203, 36, 202, 441, 101, 215, 21, 443, 464,
+	449, 445, 445, 445, 442, 326, 395, 446, 447, 180,
+	183, 184, 185, 186, 187, 182, 313, 162, 166, 85,
+	325, 84, 68, 462, 30, 31, 248, 32, 33, 86,
+	467, 247, 121, 125, 420, 380, 131, 469, 34, 35,
+	470, 378, 26, 27, 29, 28, 108, 122, 123, 124,
+	21, 113, 129, 170, 358, 37, 38, 39, 357, 356,
+	355, 354, 353, 237, 236, 121, 125, 219, 68, 131,
+	210, 112, 79, 132, 156, 149, 45, 95, 70, 86,
+	122, 123, 124, 66, 113, 129, 221, 51, 52, 127,
+	128, 106, 135, 121, 125, 422, 421, 131, 433, 47,
+	48, 49, 50, 456, 112, 415, 132, 86, 122, 123,
+	124, 65, 113, 129, 69, 134, 21, 457, 100, 463,
+	335, 225, 127, 128, 125, 130, 157, 131, 75, 73,
+	167, 406, 112, 348, 132, 308, 288, 86, 122, 123,
+	124, 405, 141, 129, 364, 212, 80, 465, 448, 21,
+	127, 128, 46, 125, 20, 19, 131, 18, 130, 217,
+	139, 17, 16, 15, 132, 14, 86, 122, 123, 124,
+	13, 141, 129, 12, 293, 102, 450, 440, 423, 400,
+	127, 128, 125, 435, 230, 131, 130, 226, 54, 300,
+	228, 89, 311, 132, 455, 86, 122, 123, 124, 417,
+	141, 129, 393, 404, 363, 340, 206, 281, 120, 127,
+	128, 117, 119, 359, 114, 174, 130, 111, 374, 246,
+	316, 244, 132, 107, 324, 168, 74, 40, 161, 76,
+	11, 10, 9, 8, 7, 6, 5, 4, 127, 128,
+	176, 178, 2, 1, 0, 130, 188, 189, 190, 191,
+	192, 193, 194, 179, 177, 175, 181, 180, 183, 184,
+	185, 186, 187, 182, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 130,
 }
 var yyPact = [...]int{
 
-	350, -1000, -1000, 293, -1000, -1000, -1000, -1000, -1000, -1000,
+	368, -1000, -1000, 336, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 390, -50, -62, -13, 1, -1000,
-	39, -1000, -1000, -1000, 382, 346, -1000, 381, 466, 425,
-	-1000, -1000, -1000, 420, -1000, -49, 365, 459, 57, 16,
-	14, -66, -21, 346, -1000, -15, 346, -1000, 380, -74,
-	346, -74, -1000, 406, 304, -1000, -1000, -18, -1000, -1000,
-	-1000, 6, -1000, 307, 359, 395, 75, 365, 203, 533,
-	-1000, 102, -1000, 69, 70, 70, 377, 115, 346, -1000,
-	368, -1000, -38, 367, 421, 172, 346, 365, 342, 365,
-	-1000, 271, -1000, -1000, 357, 62, 175, 562, -1000, 452,
-	424, -1000, -1000, -1000, 542, 301, 299, -1000, 287, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 542,
-	-1000, 365, 347, 363, 455, 347, -1000, 284, 510, 482,
-	361, 253, -1000, 384, 50, 253, -1000, 415, -80, -1000,
-	196, -1000, 358, -1000, -1000, 355, -1000, 315, 41, -1000,
-	-1000, -1000, 291, 6, 542, -1000, -1000, 346, 144, 452,
-	452, 542, 279, 103, 542, 542, 210, 542, 542, 542,
-	542, 542, 542, 542, 542, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 562, -20, 36, -10, 562, -1000, 35,
-	6, -1000, 466, 108, 418, 303, 282, -1000, 436, 452,
-	-1000, 542, 418, 418, -1000, -1000, 51, 70, 34, -1000,
-	-1000, -1000, -1000, 170, 346, -1000, -43, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 429, 347, 347, 314, -1000,
-	268, 601, 356, 312, 47, -1000, -1000, 229, -1000, -1000,
-	-1000, 126, 418, -1000, 279, 542, 542, 418, 587, -1000,
-	411, 173, 520, -1000, 162, 162, 58, 58, 58, -1000,
-	-1000, 542, -1000, -1000, 33, 6, 32, 142, -1000, 452,
-	429, 347, 436, 428, 433, 175, 418, 346, -1000, -1000,
-	7, 19, -1000, 354, -1000, -1000, 352, -1000, -1000, 279,
-	293, 203, 31, -1000, -1000, 451, 291, 291, -1000, -1000,
-	221, 211, 230, 227, 224, 52, -1000, 351, 150, 348,
-	542, 542, -1000, 418, 136, 542, -1000, 418, -1000, 21,
-	-1000, 43, -1000, 542, 208, 149, 202, 428, -1000, 542,
-	-1000, -1000, -1000, -1000, -1000, -1000, 248, -1000, -1000, 347,
-	440, 432, 601, 145, -1000, 213, -1000, 199, -1000, -1000,
-	-1000, -1000, -27, -29, -47, -1000, -1000, -1000, 418, 418,
-	542, 418, -1000, -1000, 418, 542, -1000, 400, -1000, -1000,
-	242, -1000, 292, -1000, 279, -1000, 436, 452, 542, 452,
-	-1000, -1000, 273, 261, 260, 418, 418, 398, 542, -1000,
-	-1000, -1000, -1000, 428, 175, 236, 175, 346, 346, 346,
-	463, -1000, 403, -1, -1000, -17, -26, 347, -1000, 462,
-	92, -1000, 346, -1000, -1000, 203, -1000, 346, -1000, 346,
+	-1000, -1000, -1000, -1000, -1000, 486, -25, -28, -2, 5,
+	-1000, 40, -1000, -1000, -1000, 479, 418, -1000, 474, 574,
+	574, 542, -1000, -1000, -1000, 540, -1000, -48, 468, 567,
+	76, 38, 28, -51, -11, 418, -1000, -4, 418, -1000,
+	473, -59, 418, -59, -1000, 523, 386, -1000, -1000, -12,
+	-1000, 336, -1000, -1000, 442, -1000, 369, 520, 493, 90,
+	468, 307, 562, -1000, 119, -1000, 82, 27, 27, 471,
+	140, 418, -1000, 468, -1000, -32, 470, 536, 199, 418,
+	468, 412, 468, -1000, 403, -1000, -1000, 464, 74, 138,
+	611, -1000, 503, 475, -1000, -1000, -1000, 591, 384, 382,
+	-1000, 375, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 591, -1000, 468, 425, 466, 565, 425, -1000,
+	228, 306, 533, 463, 325, -1000, 483, 75, 325, -1000,
+	531, -54, -1000, 130, -1000, 460, -1000, -1000, 459, -1000,
+	356, 36, -1000, -1000, -1000, 317, 442, 591, -1000, -1000,
+	418, 148, 503, 503, 591, 370, 210, 591, 591, 217,
+	591, 591, 591, 591, 591, 591, 591, 591, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 611, -23, 35, -3,
+	611, -1000, 9, 442, -1000, 574, 123, 257, 342, 351,
+	-1000, 553, 503, -1000, 591, 257, 257, -1000, -1000, 63,
+	27, 55, -1000, -1000, -1000, -1000, 184, 418, -1000, -61,
+	-1000, -1000, -1000, -69, -69, -69, -1000, -1000, -1000, 541,
+	425, 425, 411, -1000, 337, 352, 416, 422, 48, -1000,
+	-1000, 172, -1000, -1000, -1000, 150, 257, -1000, 370, 591,
+	591, 257, 311, -1000, 529, 134, 363, -1000, 97, 97,
+	77, 77, 77, -1000, -1000, 591, -1000, -1000, 31, 442,
+	22, 129, -1000, 503, 541, 425, 553, 545, 549, 138,
+	257, 418, -1000, -1000, 17, 52, -1000, 458, -1000, -1000,
+	457, 456, -1000, 455, -1000, 454, 450, -1000, 370, 336,
+	307, 15, -1000, -1000, 563, 317, 317, -1000, -1000, 275,
+	236, 276, 254, 248, 11, -1000, 437, 25, 431, 591,
+	591, -1000, 257, 238, 591, -1000, 257, -1000, 7, -1000,
+	39, -1000, 591, 214, 175, 299, 545, -1000, 591, -1000,
+	-1000, -1000, -1000, -1000, -1000, -42, -1000, 61, 418, 321,
+	-1000, -1000, 425, 559, 547, 352, 163, -1000, 235, -1000,
+	232, -1000, -1000, -1000, -1000, -14, -16, -18, -1000, -1000,
+	-1000, 257, 257, 591, 257, -1000, -1000, 257, 591, -1000,
+	509, -1000, -1000, 313, -1000, 187, -1000, 430, 498, 497,
+	-1000, 371, 370, -1000, 553, 503, 591, 503, -1000, -1000,
+	367, 355, 333, 257, 257, 501, 591, -1000, -1000, -1000,
+	-1000, 197, -1000, 418, 398, -1000, 545, 138, 308, 138,
+	418, 418, 418, 571, -1000, -1000, -1000, 394, -1000, -1000,
+	171, -1000, -9, 517, -13, -1000, -17, -19, 425, -1000,
+	-1000, -1000, 528, -1000, 393, -1000, 570, 109, -1000, 418,
+	-1000, -1000, 307, -1000, -78, -1000, 418, -1000, -1000, 418,
 	-1000,
 }
 var yyPgo = [...]int{
 
-	0, 592, 591, 13, 590, 589, 586, 585, 584, 583,
-	580, 575, 652, 574, 564, 562, 558, 275, 21, 29,
-	556, 555, 553, 552, 16, 551, 548, 25, 542, 3,
-	20, 10, 541, 540, 15, 539, 7, 22, 5, 536,
-	535, 12, 533, 2, 532, 528, 17, 527, 526, 525,
-	524, 6, 523, 4, 520, 1, 514, 23, 512, 11,
-	9, 24, 173, 510, 509, 508, 507, 505, 0, 8,
-	504, 18, 124, 490, 484, 482, 481, 480, 479, 478,
-	477, 475, 474,
+	0, 673, 672, 17, 667, 666, 665, 664, 663, 662,
+	661, 660, 506, 659, 658, 657, 656, 312, 21, 22,
+	655, 654, 653, 651, 12, 650, 649, 31, 648, 5,
+	16, 7, 647, 645, 13, 644, 19, 14, 9, 643,
+	642, 8, 641, 2, 638, 637, 15, 636, 635, 634,
+	633, 6, 632, 3, 629, 1, 624, 20, 622, 11,
+	4, 24, 221, 621, 620, 619, 618, 617, 23, 614,
+	613, 609, 608, 607, 606, 0, 10, 605, 25, 99,
+	604, 603, 600, 595, 593, 592, 591, 587, 585, 584,
+	582,
 }
 var yyR1 = [...]int{
 
 	0, 1, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 2, 2, 2, 2, 2, 2, 2, 2, 3,
-	3, 3, 4, 4, 77, 77, 5, 6, 7, 7,
-	7, 7, 7, 7, 72, 72, 71, 71, 71, 73,
-	73, 73, 73, 14, 14, 14, 74, 74, 75, 76,
-	78, 81, 79, 80, 8, 8, 8, 9, 9, 9,
-	10, 11, 11, 11, 82, 12, 13, 13, 15, 15,
-	15, 15, 15, 16, 16, 18, 18, 19, 19, 19,
-	22, 22, 20, 20, 20, 23, 23, 24, 24, 24,
-	24, 21, 21, 21, 25, 25, 25, 25, 25, 25,
-	25, 25, 25, 26, 26, 26, 27, 27, 28, 28,
-	28, 28, 29, 29, 30, 30, 31, 31, 31, 31,
-	31, 32, 32, 32, 32, 32, 32, 32, 32, 32,
-	32, 33, 33, 33, 33, 33, 33, 33, 34, 34,
-	39, 39, 37, 37, 41, 38, 38, 36, 36, 36,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	3, 3, 3, 4, 4, 84, 84, 5, 6, 7,
+	7, 7, 7, 7, 7, 79, 79, 78, 78, 78,
+	80, 80, 80, 80, 14, 14, 14, 81, 81, 82,
+	83, 85, 88, 89, 86, 87, 8, 8, 8, 9,
+	9, 9, 9, 69, 69, 69, 69, 69, 70, 70,
+	70, 70, 71, 72, 72, 72, 73, 73, 74, 74,
+	74, 10, 11, 11, 11, 90, 12, 13, 13, 15,
+	15, 15, 15, 15, 16, 16, 18, 18, 19, 19,
+	19, 22, 22, 20, 20, 20, 23, 23, 24, 24,
+	24, 24, 21, 21, 21, 25, 25, 25, 25, 25,
+	25, 25, 25, 25, 26, 26, 26, 27, 27, 28,
+	28, 28, 28, 29, 29, 30, 30, 31, 31, 31,
+	31, 31, 32, 32, 32, 32, 32, 32, 32, 32,
+	32, 32, 33, 33, 33, 33, 33, 33, 33, 34,
+	34, 39, 39, 37, 37, 41, 38, 38, 36, 36,
 	36, 36, 36, 36, 36, 36, 36, 36, 36, 36,
-	36, 36, 36, 36, 40, 40, 42, 42, 42, 44,
-	47, 47, 45, 45, 46, 48, 48, 43, 43, 43,
-	35, 35, 35, 35, 49, 49, 50, 50, 51, 51,
-	52, 52, 53, 54, 54, 54, 55, 55, 55, 55,
-	56, 56, 56, 57, 57, 58, 58, 59, 59, 60,
-	60, 61, 61, 62, 62, 63, 63, 17, 17, 64,
-	64, 64, 64, 64, 65, 65, 66, 66, 67, 67,
-	68, 69, 70, 70,
+	36, 36, 36, 36, 36, 40, 40, 42, 42, 42,
+	44, 47, 47, 45, 45, 46, 48, 48, 43, 43,
+	43, 35, 35, 35, 35, 49, 49, 50, 50, 51,
+	51, 52, 52, 53, 54, 54, 54, 55, 55, 55,
+	55, 56, 56, 56, 57, 57, 58, 58, 59, 59,
+	60, 60, 61, 61, 62, 62, 63, 63, 17, 17,
+	64, 64, 68, 68, 65, 65, 66, 66, 67, 67,
+	75, 76, 77, 77,
 }
 var yyR2 = [...]int{
 
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 5,
-	12, 3, 8, 8, 6, 6, 8, 7, 3, 4,
-	4, 6, 4, 4, 1, 3, 3, 2, 2, 2,
-	2, 2, 1, 0, 1, 3, 1, 2, 1, 1,
-	5, 2, 2, 4, 5, 8, 4, 6, 7, 4,
-	5, 4, 5, 5, 0, 2, 0, 2, 1, 2,
-	1, 1, 1, 0, 1, 1, 3, 1, 2, 3,
-	1, 1, 0, 1, 2, 1, 3, 3, 3, 3,
-	5, 0, 1, 2, 1, 1, 2, 3, 2, 3,
-	2, 2, 2, 1, 3, 1, 1, 3, 0, 5,
-	5, 5, 1, 3, 0, 2, 1, 3, 3, 2,
-	3, 3, 3, 4, 3, 4, 5, 6, 3, 4,
-	2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
-	1, 3, 3, 1, 3, 1, 3, 1, 1, 1,
-	3, 3, 3, 3, 3, 3, 3, 3, 2, 3,
-	4, 5, 4, 1, 1, 1, 1, 1, 1, 5,
-	0, 1, 1, 2, 4, 0, 2, 1, 3, 5,
-	1, 1, 1, 1, 0, 3, 0, 2, 0, 3,
-	1, 3, 2, 0, 1, 1, 0, 2, 4, 4,
-	0, 2, 4, 0, 3, 1, 3, 0, 5, 1,
-	3, 3, 3, 0, 2, 0, 3, 0, 1, 1,
-	1, 1, 1, 1, 0, 1, 0, 1, 0, 2,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	5, 12, 3, 8, 8, 6, 6, 8, 7, 3,
+	4, 4, 6, 4, 4, 1, 3, 3, 2, 2,
+	2, 2, 2, 1, 0, 1, 3, 1, 2, 1,
+	1, 5, 2, 2, 2, 4, 5, 8, 4, 6,
+	7, 5, 4, 3, 5, 6, 5, 4, 1, 2,
+	1, 1, 4, 0, 3, 5, 0, 1, 0, 1,
+	2, 5, 4, 5, 5, 0, 2, 0, 2, 1,
+	2, 1, 1, 1, 0, 1, 1, 3, 1, 2,
+	3, 1, 1, 0, 1, 2, 1, 3, 3, 3,
+	3, 5, 0, 1, 2, 1, 1, 2, 3, 2,
+	3, 2, 2, 2, 1, 3, 1, 1, 3, 0,
+	5, 5, 5, 1, 3, 0, 2, 1, 3, 3,
+	2, 3, 3, 3, 4, 3, 4, 5, 6, 3,
+	4, 2, 1, 1, 1, 1, 1, 1, 1, 2,
+	1, 1, 3, 3, 1, 3, 1, 3, 1, 1,
+	1, 3, 3, 3, 3, 3, 3, 3, 3, 2,
+	3, 4, 5, 4, 1, 1, 1, 1, 1, 1,
+	5, 0, 1, 1, 2, 4, 0, 2, 1, 3,
+	5, 1, 1, 1, 1, 0, 3, 0, 2, 0,
+	3, 1, 3, 2, 0, 1, 1, 0, 2, 4,
+	4, 0, 2, 4, 0, 3, 1, 3, 0, 5,
+	1, 3, 3, 3, 0, 2, 0, 3, 0, 1,
+	1, 1, 0, 1, 0, 1, 0, 1, 0, 2,
 	1, 0, 0, 1,
 }
 var yyChk = [...]int{
 
 	-1000, -1, -2, -3, -4, -5, -6, -7, -8, -9,
-	-10, -11, -74, -75, -76, -77, -78, -79, -80, -81,
-	5, 6, 7, 8, 29, 104, 105, 107, 106, 86,
-	87, 89, 90, 100, 101, 54, 116, 117, -15, 41,
-	42, 43, 44, -12, -82, -12, -12, -12, -12, 31,
-	32, 108, -66, 110, 114, -17, 110, 112, 108, 108,
-	109, 110, 88, -12, 34, -68, 34, -12, 34, -3,
-	17, -16, 18, -13, -17, -27, 34, 9, -60, 99,
-	-61, -43, -68, 34, 88, 88, -63, 113, 109, -68,
-	108, -68, 34, -62, 113, -68, -62, 25, 39, -70,
-	108, -18, -19, 79, -22, 34, -31, -36, -32, 59,
-	39, -35, -43, -37, -42, -68, -40, -44, 20, 35,
-	36, 37, 21, -41, 77, 78, 40, 113, 24, 61,
-	38, 25, 29, 83, -27, 45, 28, -36, 39, 65,
-	83, -72, -71, 91, 92, -72, 34, 59, -68, -69,
-	34, -69, 111, 34, 20, 56, -68, -27, -14, 35,
-	-27, -55, 9, 45, 15, -20, -68, 19, 83, 58,
-	57, -33, 74, 59, 73, 60, 72, 76, 75, 82,
-	77, 78, 79, 80, 81, 65, 66, 67, 68, 69,
-	70, 71, -31, -36, -31, -38, -3, -36, -36, 39,
-	39, -41, 39, -47, -36, -27, -60, 34, -30, 10,
-	-61, 103, -36, -36, 56, -68, 34, 45, 33, 93,
-	94, -69, 20, -67, 115, -64, 107, 105, 28, 106,
-	13, 34, 34, 34, -69, -57, 29, 39, 45, 118,
-	-23, -24, -26, 39, 34, -41, -19, -36, -68, 79,
-	-31, -31, -36, -37, 74, 73, 60, -36, -36, 21,
-	59, -36, -36, -36, -36, -36, -36, -36, -36, 118,
-	118, 45, 118, 118, -18, 18, -18, -45, -46, 62,
-	-57, 29, -30, -51, 13, -31, -36, 83, -71, -73,
-	95, 92, 98, 56, -68, -69, -65, 111, -34, 24,
-	-3, -60, -58, -43, 35, -30, 45, -25, 46, 47,
-	48, 49, 50, 52, 53, -21, 34, 19, -24, 83,
-	45, 102, -37, -36, -36, 58, 21, -36, 118, -18,
-	118, -48, -46, 64, -31, -34, -60, -51, -55, 14,
-	-68, 92, 96, 97, 34, 34, -39, -37, 118, 45,
-	-49, 11, -24, -24, 46, 51, 46, 51, 46, 46,
-	46, -28, 54, 112, 55, 34, 118, 34, -36, -36,
-	58, -36, 118, 85, -36, 63, -59, 56, -59, -55,
-	-52, -53, -36, -69, 45, -43, -50, 12, 14, 56,
-	46, 46, 109, 109, 109, -36, -36, 26, 45, -54,
-	22, 23, -37, -51, -31, -38, -31, 39, 39, 39,
-	27, -53, -55, -29, -68, -29, -29, 7, -56, 16,
-	30, 118, 45, 118, 118, -60, 7, 74, -68, -68,
-	-68,
+	-10, -11, -81, -82, -83, -84, -85, -86, -87, -88,
+	-89, 5, 6, 7, 8, 29, 104, 105, 107, 106,
+	86, 87, 89, 90, 100, 101, 54, 117, 118, 119,
+	-15, 41, 42, 43, 44, -12, -90, -12, -12, -12,
+	-12, 31, 32, 108, -66, 110, 114, -17, 110, 112,
+	108, 108, 109, 110, 88, -12, 34, -75, 34, -12,
+	34, -3, -3, 17, -16, 18, -13, -17, -27, 34,
+	9, -60, 99, -61, -43, -75, 34, 88, 88, -63,
+	113, 109, -75, 108, -75, 34, -62, 113, -75, -62,
+	25, 39, -77, 108, -18, -19, 79, -22, 34, -31,
+	-36, -32, 59, 39, -35, -43, -37, -42, -75, -40,
+	-44, 20, 35, 36, 37, 21, -41, 77, 78, 40,
+	113, 24, 61, 38, 25, 29, 83, -27, 45, 28,
+	-36, 39, 65, 83, -79, -78, 91, 92, -79, 34,
+	59, -75, -76, -27, -76, 111, 34, 20, 56, -75,
+	-27, -14, 35, -27, -55, 9, 45, 15, -20, -75,
+	19, 83, 58, 57, -33, 74, 59, 73, 60, 72,
+	76, 75, 82, 77, 78, 79, 80, 81, 65, 66,
+	67, 68, 69, 70, 71, -31, -36, -31, -38, -3,
+	-36, -36, 39, 39, -41, 39, -47, -36, -27, -60,
+	34, -30, 10, -61, 103, -36, -36, 56, -75, 34,
+	45, 33, 93, 94, -76, 20, -67, 115, -64, 107,
+	-69, 28, 13, 106, 105, -75, 34, 34, -76, -57,
+	29, 39, 45, 120, -23, -24, -26, 39, 34, -41,
+	-19, -36, -75, 79, -31, -31, -36, -37, 74, 73,
+	60, -36, -36, 21, 59, -36, -36, -36, -36, -36,
+	-36, -36, -36, 120, 120, 45, 120, 120, -18, 18,
+	-18, -45, -46, 62, -57, 29, -30, -51, 13, -31,
+	-36, 83, -78, -80, 95, 92, 98, 56, -75, -76,
+	-65, 116, 111, -68, 116, -68, -68, -34, 24, -3,
+	-60, -58, -43, 35, -30, 45, -25, 46, 47, 48,
+	49, 50, 52, 53, -21, 34, 19, -24, 83, 45,
+	102, -37, -36, -36, 58, 21, -36, 120, -18, 120,
+	-48, -46, 64, -31, -34, -60, -51, -55, 14, -75,
+	92, 96, 97, 34, 34, 34, 34, 34, 34, -39,
+	-37, 120, 45, -49, 11, -24, -24, 46, 51, 46,
+	51, 46, 46, 46, -28, 54, 112, 55, 34, 120,
+	34, -36, -36, 58, -36, 120, 85, -36, 63, -59,
+	56, -59, -55, -52, -53, -36, -76, 111, 29, 106,
+	-71, -75, 45, -43, -50, 12, 14, 56, 46, 46,
+	109, 109, 109, -36, -36, 26, 45, -54, 22, 23,
+	34, 28, 28, -72, 39, -37, -51, -31, -38, -31,
+	39, 39, 39, 27, -53, -70, 36, 78, 35, 21,
+	-73, -75, 36, -55, -29, -75, -29, -29, 7, 36,
+	-74, 21, 59, 120, 45, -56, 16, 30, 120, 45,
+	120, 120, -60, 21, 36, 7, 74, -75, 120, -75,
+	-75,
 }
 var yyDef = [...]int{
 
 	0, -2, 1, 2, 3, 4, 5, 6, 7, 8,
 	9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
-	64, 64, 64, 64, 64, 226, 217, 0, 0, 46,
-	0, 48, 49, 64, 0, 0, 64, 0, 0, 68,
-	70, 71, 72, 73, 66, 217, 0, 0, 0, 0,
-	0, 215, 0, 0, 227, 0, 0, 218, 0, 213,
-	0, 213, 47, 0, 0, 52, 230, 232, 51, 21,
-	69, 0, 74, 65, 0, 0, 106, 0, 28, 0,
-	209, 0, 177, 230, 0, 0, 0, 0, 0, 231,
-	0, 231, 0, 0, 0, 0, 0, 0, 43, 0,
-	233, 196, 75, 77, 82, 230, 80, 81, 116, 0,
-	0, 147, 148, 149, 0, 177, 0, 163, 0, 180,
-	181, 182, 183, 143, 166, 167, 168, 164, 165, 170,
-	67, 0, 0, 0, 114, 0, 29, 30, 0, 0,
-	0, 32, 34, 0, 0, 33, 231, 0, 228, 56,
-	0, 59, 0, 61, 214, 0, 231, 203, 0, 44,
-	53, 19, 0, 0, 0, 78, 83, 0, 0, 0,
+	19, 85, 85, 85, 85, 85, 246, 238, 0, 0,
+	47, 0, 49, 50, 85, 0, 0, 85, 0, 0,
+	0, 89, 91, 92, 93, 94, 87, 238, 0, 0,
+	0, 0, 0, 236, 0, 0, 247, 0, 0, 239,
+	0, 234, 0, 234, 48, 0, 0, 54, 250, 252,
+	52, 53, 22, 90, 0, 95, 86, 0, 0, 127,
+	0, 29, 0, 230, 0, 198, 250, 0, 0, 0,
+	0, 0, 251, 0, 251, 0, 0, 0, 0, 0,
+	0, 44, 0, 253, 217, 96, 98, 103, 250, 101,
+	102, 137, 0, 0, 168, 169, 170, 0, 198, 0,
+	184, 0, 201, 202, 203, 204, 164, 187, 188, 189,
+	185, 186, 191, 88, 0, 0, 0, 135, 0, 30,
+	31, 0, 0, 0, 33, 35, 0, 0, 34, 251,
+	0, 248, 58, 0, 62, 0, 82, 235, 0, 251,
+	224, 0, 45, 55, 20, 0, 0, 0, 99, 104,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 131, 132, 133, 134, 135,
-	136, 137, 119, 0, 0, 0, 0, 145, 158, 0,
-	0, 130, 0, 0, 171, 203, 114, 107, 188, 0,
-	210, 0, 145, 211, 212, 178, 230, 0, 0, 37,
-	38, 54, 216, 0, 0, 231, 224, 219, 220, 221,
-	222, 223, 60, 62, 63, 0, 0, 0, 0, 50,
-	114, 85, 91, 0, 103, 105, 76, 197, 84, 79,
-	117, 118, 121, 122, 0, 0, 0, 124, 0, 128,
-	0, 150, 151, 152, 153, 154, 155, 156, 157, 120,
-	142, 0, 144, 159, 0, 0, 0, 175, 172, 0,
-	0, 0, 188, 196, 0, 115, 31, 0, 35, 36,
-	0, 0, 42, 0, 229, 57, 0, 225, 24, 0,
-	139, 25, 0, 205, 45, 184, 0, 0, 94, 95,
-	0, 0, 0, 0, 0, 108, 92, 0, 0, 0,
-	0, 0, 123, 125, 0, 0, 129, 146, 160, 0,
-	162, 0, 173, 0, 0, 207, 207, 196, 27, 0,
-	179, 39, 40, 41, 231, 58, 138, 140, 204, 0,
-	186, 0, 86, 89, 96, 0, 98, 0, 100, 101,
-	102, 87, 0, 0, 0, 93, 88, 104, 198, 199,
-	0, 126, 161, 169, 176, 0, 22, 0, 23, 26,
-	189, 190, 193, 55, 0, 206, 188, 0, 0, 0,
-	97, 99, 0, 0, 0, 127, 174, 0, 0, 192,
-	194, 195, 141, 196, 187, 185, 90, 0, 0, 0,
-	0, 191, 200, 0, 112, 0, 0, 0, 20, 0,
-	0, 109, 0, 110, 111, 208, 201, 0, 113, 0,
-	202,
+	0, 0, 0, 0, 0, 0, 0, 0, 152, 153,
+	154, 155, 156, 157, 158, 140, 0, 0, 0, 0,
+	166, 179, 0, 0, 151, 0, 0, 192, 224, 135,
+	128, 209, 0, 231, 0, 166, 232, 233, 199, 250,
+	0, 0, 38, 39, 56, 237, 0, 0, 251, 244,
+	61, 240, 241, 242, 242, 242, 81, 83, 84, 0,
+	0, 0, 0, 51, 135, 106, 112, 0, 124, 126,
+	97, 218, 105, 100, 138, 139, 142, 143, 0, 0,
+	0, 145, 0, 149, 0, 171, 172, 173, 174, 175,
+	176, 177, 178, 141, 163, 0, 165, 180, 0, 0,
+	0, 196, 193, 0, 0, 0, 209, 217, 0, 136,
+	32, 0, 36, 37, 0, 0, 43, 0, 249, 59,
+	0, 0, 245, 0, 243, 0, 0, 25, 0, 160,
+	26, 0, 226, 46, 205, 0, 0, 115, 116, 0,
+	0, 0, 0, 0, 129, 113, 0, 0, 0, 0,
+	0, 144, 146, 0, 0, 150, 167, 181, 0, 183,
+	0, 194, 0, 0, 228, 228, 217, 28, 0, 200,
+	40, 41, 42, 251, 60, 0, 63, 0, 0, 159,
+	161, 225, 0, 207, 0, 107, 110, 117, 0, 119,
+	0, 121, 122, 123, 108, 0, 0, 0, 114, 109,
+	125, 219, 220, 0, 147, 182, 190, 197, 0, 23,
+	0, 24, 27, 210, 211, 214, 57, 0, 0, 0,
+	67, 73, 0, 227, 209, 0, 0, 0, 118, 120,
+	0, 0, 0, 148, 195, 0, 0, 213, 215, 216,
+	64, 0, 66, 76, 0, 162, 217, 208, 206, 111,
+	0, 0, 0, 0, 212, 65, 68, 0, 70, 71,
+	78, 77, 0, 221, 0, 133, 0, 0, 0, 69,
+	72, 79, 0, 74, 0, 21, 0, 0, 130, 0,
+	131, 132, 229, 80, 0, 222, 0, 134, 75, 0,
+	223,
 }
 var yyTok1 = [...]int{
 
@@ -584,7 +612,7 @@ var yyTok1 = [...]int{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 81, 76, 3,
-	39, 118, 79, 77, 45, 78, 83, 80, 3, 3,
+	39, 120, 79, 77, 45, 78, 83, 80, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	66, 65, 67, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
@@ -606,7 +634,7 @@ var yyTok2 = [...]int{
 	87, 88, 89, 90, 91, 92, 93, 94, 95, 96,
 	97, 98, 99, 100, 101, 102, 103, 104, 105, 106,
 	107, 108, 109, 110, 111, 112, 113, 114, 115, 116,
-	117,
+	117, 118, 119,
 }
 var yyTok3 = [...]int{
 	0,
@@ -951,43 +979,43 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:200
+		//line sql.y:214
 		{
 			SetParseTree(yylex, yyDollar[1].statement)
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:206
+		//line sql.y:220
 		{
 			yyVAL.statement = yyDollar[1].selStmt
 		}
-	case 19:
+	case 20:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:228
+		//line sql.y:243
 		{
 			yyVAL.selStmt = &SimpleSelect{Comments: Comments(yyDollar[2].bytes2), Distinct: yyDollar[3].str, SelectExprs: yyDollar[4].selectExprs, Limit: yyDollar[5].limit}
 		}
-	case 20:
+	case 21:
 		yyDollar = yyS[yypt-12 : yypt+1]
-		//line sql.y:232
+		//line sql.y:247
 		{
 			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), Distinct: yyDollar[3].str, SelectExprs: yyDollar[4].selectExprs, From: yyDollar[6].tableExprs, Where: NewWhere(AST_WHERE, yyDollar[7].boolExpr), GroupBy: GroupBy(yyDollar[8].valExprs), Having: NewWhere(AST_HAVING, yyDollar[9].boolExpr), OrderBy: yyDollar[10].orderBy, Limit: yyDollar[11].limit, Lock: yyDollar[12].str}
 		}
-	case 21:
+	case 22:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:236
+		//line sql.y:251
 		{
 			yyVAL.selStmt = &Union{Type: yyDollar[2].str, Left: yyDollar[1].selStmt, Right: yyDollar[3].selStmt}
 		}
-	case 22:
+	case 23:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:243
+		//line sql.y:258
 		{
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, Columns: yyDollar[6].columns, Rows: yyDollar[7].insRows, OnDup: OnDup(yyDollar[8].updateExprs)}
 		}
-	case 23:
+	case 24:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:247
+		//line sql.y:262
 		{
 			cols := make(Columns, 0, len(yyDollar[7].updateExprs))
 			vals := make(ValTuple, 0, len(yyDollar[7].updateExprs))
@@ -997,15 +1025,15 @@ yydefault:
 			}
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, Columns: cols, Rows: Values{vals}, OnDup: OnDup(yyDollar[8].updateExprs)}
 		}
-	case 24:
+	case 25:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:259
+		//line sql.y:274
 		{
 			yyVAL.statement = &Replace{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Columns: yyDollar[5].columns, Rows: yyDollar[6].insRows}
 		}
-	case 25:
+	case 26:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:263
+		//line sql.y:278
 		{
 			cols := make(Columns, 0, len(yyDollar[6].updateExprs))
 			vals := make(ValTuple, 0, len(yyDollar[6].updateExprs))
@@ -1015,39 +1043,39 @@ yydefault:
 			}
 			yyVAL.statement = &Replace{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Columns: cols, Rows: Values{vals}}
 		}
-	case 26:
+	case 27:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:276
+		//line sql.y:291
 		{
 			yyVAL.statement = &Update{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[3].tableName, Exprs: yyDollar[5].updateExprs, Where: NewWhere(AST_WHERE, yyDollar[6].boolExpr), OrderBy: yyDollar[7].orderBy, Limit: yyDollar[8].limit}
 		}
-	case 27:
+	case 28:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:282
+		//line sql.y:297
 		{
 			yyVAL.statement = &Delete{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Where: NewWhere(AST_WHERE, yyDollar[5].boolExpr), OrderBy: yyDollar[6].orderBy, Limit: yyDollar[7].limit}
 		}
-	case 28:
+	case 29:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:288
+		//line sql.y:303
 		{
 			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].bytes2), Exprs: yyDollar[3].updateExprs}
 		}
-	case 29:
+	case 30:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:292
+		//line sql.y:307
 		{
 			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].bytes2), Exprs: UpdateExprs{&UpdateExpr{Name: &ColName{Name: []byte("names")}, Expr: StrVal("default")}}}
 		}
-	case 30:
+	case 31:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:296
+		//line sql.y:311
 		{
 			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].bytes2), Exprs: UpdateExprs{&UpdateExpr{Name: &ColName{Name: []byte("names")}, Expr: yyDollar[4].valExpr}}}
 		}
-	case 31:
+	case 32:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:300
+		//line sql.y:315
 		{
 			yyVAL.statement = &Set{
 				Comments: Comments(yyDollar[2].bytes2),
@@ -1061,9 +1089,9 @@ yydefault:
 				},
 			}
 		}
-	case 32:
+	case 33:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:314
+		//line sql.y:329
 		{
 			yyVAL.statement = &Set{
 				Exprs: UpdateExprs{
@@ -1073,9 +1101,9 @@ yydefault:
 				},
 			}
 		}
-	case 33:
+	case 34:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:324
+		//line sql.y:339
 		{
 			yyVAL.statement = &Set{
 				Exprs: UpdateExprs{
@@ -1085,697 +1113,827 @@ yydefault:
 				},
 			}
 		}
-	case 43:
+	case 44:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:350
+		//line sql.y:365
 		{
 			yyVAL.bytes2 = nil
 		}
-	case 44:
+	case 45:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:354
+		//line sql.y:369
 		{
 			yyVAL.bytes2 = [][]byte{yyDollar[1].bytes}
 		}
-	case 45:
+	case 46:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:358
+		//line sql.y:373
 		{
 			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[3].bytes)
 		}
-	case 46:
+	case 47:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:364
+		//line sql.y:379
 		{
 			yyVAL.statement = &Begin{}
 		}
-	case 47:
+	case 48:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:368
+		//line sql.y:383
 		{
 			yyVAL.statement = &Begin{}
 		}
-	case 48:
+	case 49:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:375
+		//line sql.y:390
 		{
 			yyVAL.statement = &Commit{}
 		}
-	case 49:
+	case 50:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:381
+		//line sql.y:396
 		{
 			yyVAL.statement = &Rollback{}
 		}
-	case 50:
+	case 51:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:387
+		//line sql.y:402
 		{
 			yyVAL.statement = &Admin{Command: yyDollar[2].bytes, Args: yyDollar[4].bytes2}
 		}
-	case 51:
+	case 52:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:393
+		//line sql.y:408
 		{
 			yyVAL.statement = &Describe{TableName: yyDollar[2].bytes}
 		}
-	case 52:
+	case 53:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:399
+		//line sql.y:414
+		{
+			yyVAL.statement = &Explain{Select: yyDollar[2].selStmt}
+		}
+	case 54:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:420
 		{
 			yyVAL.statement = &UseDB{DB: string(yyDollar[2].bytes)}
 		}
-	case 53:
+	case 55:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:405
+		//line sql.y:426
 		{
 			yyVAL.statement = &Truncate{Comments: Comments(yyDollar[2].bytes2), TableOpt: yyDollar[3].str, Table: yyDollar[4].tableName}
 		}
-	case 54:
+	case 56:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:411
+		//line sql.y:432
 		{
 			yyVAL.statement = &DDL{Action: AST_CREATE, NewName: yyDollar[4].bytes}
 		}
-	case 55:
+	case 57:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:415
+		//line sql.y:436
 		{
 			// Change this to an alter statement
 			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[7].bytes, NewName: yyDollar[7].bytes}
 		}
-	case 56:
+	case 58:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:420
+		//line sql.y:441
 		{
 			yyVAL.statement = &DDL{Action: AST_CREATE, NewName: yyDollar[3].bytes}
 		}
-	case 57:
+	case 59:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:426
+		//line sql.y:447
 		{
-			yyVAL.statement = &DDL{Action: AST_ALTER, Ignore: yyDollar[2].str, Table: yyDollar[4].bytes, NewName: yyDollar[4].bytes}
+			yyVAL.statement = &DDL{Action: AST_ALTER, Ignore: yyDollar[2].str, Table: yyDollar[4].tableName.Name, NewName: yyDollar[4].tableName.Name}
 		}
-	case 58:
+	case 60:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:430
+		//line sql.y:451
 		{
 			// Change this to a rename statement
-			yyVAL.statement = &DDL{Action: AST_RENAME, Ignore: yyDollar[2].str, Table: yyDollar[4].bytes, NewName: yyDollar[7].bytes}
+			yyVAL.statement = &DDL{Action: AST_RENAME, Ignore: yyDollar[2].str, Table: yyDollar[4].tableName.Name, NewName: yyDollar[7].bytes}
 		}
-	case 59:
+	case 61:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line sql.y:456
+		{
+			yyDollar[5].alterTable.Ignore = yyDollar[2].str
+			yyDollar[5].alterTable.Table = yyDollar[4].tableName
+			yyVAL.statement = yyDollar[5].alterTable
+		}
+	case 62:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:435
+		//line sql.y:462
 		{
 			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[3].bytes, NewName: yyDollar[3].bytes}
 		}
-	case 60:
+	case 63:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line sql.y:468
+		{
+			yyVAL.alterTable = &AlterTable{Action: AST_DROP_COLUMN, Column: yyDollar[3].bytes}
+		}
+	case 64:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:441
+		//line sql.y:472
+		{
+			yyVAL.alterTable = &AlterTable{Action: AST_RENAME_COLUMN, Column: yyDollar[3].bytes, NewName: yyDollar[5].bytes}
+		}
+	case 65:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		//line sql.y:476
+		{
+			yyVAL.alterTable = &AlterTable{Action: AST_SET_DEFAULT, Column: yyDollar[3].bytes, Default: yyDollar[6].valExpr}
+		}
+	case 66:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line sql.y:480
+		{
+			yyVAL.alterTable = &AlterTable{Action: AST_DROP_DEFAULT, Column: yyDollar[3].bytes}
+		}
+	case 67:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line sql.y:484
+		{
+			if !bytes.Equal(yyDollar[1].bytes, MODIFY_BYTES) {
+				yylex.Error("alter table only supports drop, rename, alter and modify column")
+				return 1
+			}
+			yyVAL.alterTable = &AlterTable{Action: AST_MODIFY_COLUMN, Column: yyDollar[3].bytes, Type: yyDollar[4].columnType}
+		}
+	case 68:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:494
+		{
+			yyVAL.valExpr = NumVal(yyDollar[1].bytes)
+		}
+	case 69:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:498
+		{
+			yyVAL.valExpr = append(NumVal("-"), yyDollar[2].bytes...)
+		}
+	case 70:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:502
+		{
+			yyVAL.valExpr = StrVal(yyDollar[1].bytes)
+		}
+	case 71:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:506
+		{
+			yyVAL.valExpr = &NullVal{}
+		}
+	case 72:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line sql.y:512
+		{
+			yyVAL.columnType = &ColumnType{Type: yyDollar[1].bytes, Size: yyDollar[2].bytes2, Unsigned: yyDollar[3].boolVal, NotNull: yyDollar[4].boolVal}
+		}
+	case 73:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line sql.y:517
+		{
+			yyVAL.bytes2 = nil
+		}
+	case 74:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line sql.y:521
+		{
+			yyVAL.bytes2 = [][]byte{yyDollar[2].bytes}
+		}
+	case 75:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line sql.y:525
+		{
+			yyVAL.bytes2 = [][]byte{yyDollar[2].bytes, yyDollar[4].bytes}
+		}
+	case 76:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line sql.y:530
+		{
+			yyVAL.boolVal = false
+		}
+	case 77:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:534
+		{
+			if !bytes.Equal(yyDollar[1].bytes, UNSIGNED_BYTES) {
+				yylex.Error("expecting unsigned")
+				return 1
+			}
+			yyVAL.boolVal = true
+		}
+	case 78:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line sql.y:543
+		{
+			yyVAL.boolVal = false
+		}
+	case 79:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:547
+		{
+			yyVAL.boolVal = false
+		}
+	case 80:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:551
+		{
+			yyVAL.boolVal = true
+		}
+	case 81:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line sql.y:557
 		{
 			yyVAL.statement = &DDL{Action: AST_RENAME, Table: yyDollar[3].bytes, NewName: yyDollar[5].bytes}
 		}
-	case 61:
+	case 82:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:447
+		//line sql.y:563
 		{
 			yyVAL.statement = &DDL{Action: AST_DROP, Table: yyDollar[4].bytes}
 		}
-	case 62:
+	case 83:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:451
+		//line sql.y:567
 		{
 			// Change this to an alter statement
 			yyVAL.statement = &DDL{Action: AST_ALTER, Table: yyDollar[5].bytes, NewName: yyDollar[5].bytes}
 		}
-	case 63:
+	case 84:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:456
+		//line sql.y:572
 		{
 			yyVAL.statement = &DDL{Action: AST_DROP, Table: yyDollar[4].bytes}
 		}
-	case 64:
+	case 85:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:461
+		//line sql.y:577
 		{
 			SetAllowComments(yylex, true)
 		}
-	case 65:
+	case 86:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:465
+		//line sql.y:581
 		{
 			yyVAL.bytes2 = yyDollar[2].bytes2
 			SetAllowComments(yylex, false)
 		}
-	case 66:
+	case 87:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:471
+		//line sql.y:587
 		{
 			yyVAL.bytes2 = nil
 		}
-	case 67:
+	case 88:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:475
+		//line sql.y:591
 		{
 			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
 		}
-	case 68:
+	case 89:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:481
+		//line sql.y:597
 		{
 			yyVAL.str = AST_UNION
 		}
-	case 69:
+	case 90:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:485
+		//line sql.y:601
 		{
 			yyVAL.str = AST_UNION_ALL
 		}
-	case 70:
+	case 91:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:489
+		//line sql.y:605
 		{
 			yyVAL.str = AST_SET_MINUS
 		}
-	case 71:
+	case 92:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:493
+		//line sql.y:609
 		{
 			yyVAL.str = AST_EXCEPT
 		}
-	case 72:
+	case 93:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:497
+		//line sql.y:613
 		{
 			yyVAL.str = AST_INTERSECT
 		}
-	case 73:
+	case 94:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:502
+		//line sql.y:618
 		{
 			yyVAL.str = ""
 		}
-	case 74:
+	case 95:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:506
+		//line sql.y:622
 		{
 			yyVAL.str = AST_DISTINCT
 		}
-	case 75:
+	case 96:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:512
+		//line sql.y:628
 		{
 			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
 		}
-	case 76:
+	case 97:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:516
+		//line sql.y:632
 		{
 			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
 		}
-	case 77:
+	case 98:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:522
+		//line sql.y:638
 		{
 			yyVAL.selectExpr = &StarExpr{}
 		}
-	case 78:
+	case 99:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:526
+		//line sql.y:642
 		{
 			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].bytes}
 		}
-	case 79:
+	case 100:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:530
+		//line sql.y:646
 		{
 			yyVAL.selectExpr = &StarExpr{TableName: yyDollar[1].bytes}
 		}
-	case 80:
+	case 101:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:536
+		//line sql.y:652
 		{
 			yyVAL.expr = yyDollar[1].boolExpr
 		}
-	case 81:
+	case 102:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:540
+		//line sql.y:656
 		{
 			yyVAL.expr = yyDollar[1].valExpr
 		}
-	case 82:
+	case 103:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:545
+		//line sql.y:661
 		{
 			yyVAL.bytes = nil
 		}
-	case 83:
+	case 104:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:549
+		//line sql.y:665
 		{
 			yyVAL.bytes = yyDollar[1].bytes
 		}
-	case 84:
+	case 105:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:553
+		//line sql.y:669
 		{
 			yyVAL.bytes = yyDollar[2].bytes
 		}
-	case 85:
+	case 106:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:559
+		//line sql.y:675
 		{
 			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
 		}
-	case 86:
+	case 107:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:563
+		//line sql.y:679
 		{
 			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
 		}
-	case 87:
+	case 108:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:569
+		//line sql.y:685
 		{
 			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].smTableExpr, As: yyDollar[2].bytes, Hints: yyDollar[3].indexHints}
 		}
-	case 88:
+	case 109:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:573
+		//line sql.y:689
 		{
 			yyVAL.tableExpr = &ParenTableExpr{Expr: yyDollar[2].tableExpr}
 		}
-	case 89:
+	case 110:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:577
+		//line sql.y:693
 		{
 			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
 		}
-	case 90:
+	case 111:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:581
+		//line sql.y:697
 		{
 			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
 		}
-	case 91:
+	case 112:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:586
+		//line sql.y:702
 		{
 			yyVAL.bytes = nil
 		}
-	case 92:
+	case 113:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:590
+		//line sql.y:706
 		{
 			yyVAL.bytes = yyDollar[1].bytes
 		}
-	case 93:
+	case 114:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:594
+		//line sql.y:710
 		{
 			yyVAL.bytes = yyDollar[2].bytes
 		}
-	case 94:
+	case 115:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:600
+		//line sql.y:716
 		{
 			yyVAL.str = AST_JOIN
 		}
-	case 95:
+	case 116:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:604
+		//line sql.y:720
 		{
 			yyVAL.str = AST_STRAIGHT_JOIN
 		}
-	case 96:
+	case 117:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:608
+		//line sql.y:724
 		{
 			yyVAL.str = AST_LEFT_JOIN
 		}
-	case 97:
+	case 118:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:612
+		//line sql.y:728
 		{
 			yyVAL.str = AST_LEFT_JOIN
 		}
-	case 98:
+	case 119:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:616
+		//line sql.y:732
 		{
 			yyVAL.str = AST_RIGHT_JOIN
 		}
-	case 99:
+	case 120:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:620
+		//line sql.y:736
 		{
 			yyVAL.str = AST_RIGHT_JOIN
 		}
-	case 100:
+	case 121:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:624
+		//line sql.y:740
 		{
 			yyVAL.str = AST_JOIN
 		}
-	case 101:
+	case 122:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:628
+		//line sql.y:744
 		{
 			yyVAL.str = AST_CROSS_JOIN
 		}
-	case 102:
+	case 123:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:632
+		//line sql.y:748
 		{
 			yyVAL.str = AST_NATURAL_JOIN
 		}
-	case 103:
+	case 124:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:638
+		//line sql.y:754
 		{
 			yyVAL.smTableExpr = &TableName{Name: yyDollar[1].bytes}
 		}
-	case 104:
+	case 125:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:642
+		//line sql.y:758
 		{
 			yyVAL.smTableExpr = &TableName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
 		}
-	case 105:
+	case 126:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:646
+		//line sql.y:762
 		{
 			yyVAL.smTableExpr = yyDollar[1].subquery
 		}
-	case 106:
+	case 127:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:652
+		//line sql.y:768
 		{
 			yyVAL.tableName = &TableName{Name: yyDollar[1].bytes}
 		}
-	case 107:
+	case 128:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:656
+		//line sql.y:772
 		{
 			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
 		}
-	case 108:
+	case 129:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:661
+		//line sql.y:777
 		{
 			yyVAL.indexHints = nil
 		}
-	case 109:
+	case 130:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:665
+		//line sql.y:781
 		{
 			yyVAL.indexHints = &IndexHints{Type: AST_USE, Indexes: yyDollar[4].bytes2}
 		}
-	case 110:
+	case 131:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:669
+		//line sql.y:785
 		{
 			yyVAL.indexHints = &IndexHints{Type: AST_IGNORE, Indexes: yyDollar[4].bytes2}
 		}
-	case 111:
+	case 132:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:673
+		//line sql.y:789
 		{
 			yyVAL.indexHints = &IndexHints{Type: AST_FORCE, Indexes: yyDollar[4].bytes2}
 		}
-	case 112:
+	case 133:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:679
+		//line sql.y:795
 		{
 			yyVAL.bytes2 = [][]byte{yyDollar[1].bytes}
 		}
-	case 113:
+	case 134:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:683
+		//line sql.y:799
 		{
 			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[3].bytes)
 		}
-	case 114:
+	case 135:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:688
+		//line sql.y:804
 		{
 			yyVAL.boolExpr = nil
 		}
-	case 115:
+	case 136:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:692
+		//line sql.y:808
 		{
 			yyVAL.boolExpr = yyDollar[2].boolExpr
 		}
-	case 117:
+	case 138:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:699
+		//line sql.y:815
 		{
 			yyVAL.boolExpr = &AndExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
 		}
-	case 118:
+	case 139:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:703
+		//line sql.y:819
 		{
 			yyVAL.boolExpr = &OrExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
 		}
-	case 119:
+	case 140:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:707
+		//line sql.y:823
 		{
 			yyVAL.boolExpr = &NotExpr{Expr: yyDollar[2].boolExpr}
 		}
-	case 120:
+	case 141:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:711
+		//line sql.y:827
 		{
 			yyVAL.boolExpr = &ParenBoolExpr{Expr: yyDollar[2].boolExpr}
 		}
-	case 121:
+	case 142:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:717
+		//line sql.y:833
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr}
 		}
-	case 122:
+	case 143:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:721
+		//line sql.y:837
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_IN, Right: yyDollar[3].tuple}
 		}
-	case 123:
+	case 144:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:725
+		//line sql.y:841
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_IN, Right: yyDollar[4].tuple}
 		}
-	case 124:
+	case 145:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:729
+		//line sql.y:845
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_LIKE, Right: yyDollar[3].valExpr}
 		}
-	case 125:
+	case 146:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:733
+		//line sql.y:849
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_LIKE, Right: yyDollar[4].valExpr}
 		}
-	case 126:
+	case 147:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:737
+		//line sql.y:853
 		{
 			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_BETWEEN, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
 		}
-	case 127:
+	case 148:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:741
+		//line sql.y:857
 		{
 			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_NOT_BETWEEN, From: yyDollar[4].valExpr, To: yyDollar[6].valExpr}
 		}
-	case 128:
+	case 149:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:745
+		//line sql.y:861
 		{
 			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NULL, Expr: yyDollar[1].valExpr}
 		}
-	case 129:
+	case 150:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:749
+		//line sql.y:865
 		{
 			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: yyDollar[1].valExpr}
 		}
-	case 130:
+	case 151:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:753
+		//line sql.y:869
 		{
 			yyVAL.boolExpr = &ExistsExpr{Subquery: yyDollar[2].subquery}
 		}
-	case 131:
+	case 152:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:759
+		//line sql.y:875
 		{
 			yyVAL.str = AST_EQ
 		}
-	case 132:
+	case 153:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:763
+		//line sql.y:879
 		{
 			yyVAL.str = AST_LT
 		}
-	case 133:
+	case 154:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:767
+		//line sql.y:883
 		{
 			yyVAL.str = AST_GT
 		}
-	case 134:
+	case 155:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:771
+		//line sql.y:887
 		{
 			yyVAL.str = AST_LE
 		}
-	case 135:
+	case 156:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:775
+		//line sql.y:891
 		{
 			yyVAL.str = AST_GE
 		}
-	case 136:
+	case 157:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:779
+		//line sql.y:895
 		{
 			yyVAL.str = AST_NE
 		}
-	case 137:
+	case 158:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:783
+		//line sql.y:899
 		{
 			yyVAL.str = AST_NSE
 		}
-	case 138:
+	case 159:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:789
+		//line sql.y:905
 		{
 			yyVAL.insRows = yyDollar[2].values
 		}
-	case 139:
+	case 160:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:793
+		//line sql.y:909
 		{
 			yyVAL.insRows = yyDollar[1].selStmt
 		}
-	case 140:
+	case 161:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:799
+		//line sql.y:915
 		{
 			yyVAL.values = Values{yyDollar[1].tuple}
 		}
-	case 141:
+	case 162:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:803
+		//line sql.y:919
 		{
 			yyVAL.values = append(yyDollar[1].values, yyDollar[3].tuple)
 		}
-	case 142:
+	case 163:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:809
+		//line sql.y:925
 		{
 			yyVAL.tuple = ValTuple(yyDollar[2].valExprs)
 		}
-	case 143:
+	case 164:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:813
+		//line sql.y:929
 		{
 			yyVAL.tuple = yyDollar[1].subquery
 		}
-	case 144:
+	case 165:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:819
+		//line sql.y:935
 		{
 			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
 		}
-	case 145:
+	case 166:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:825
+		//line sql.y:941
 		{
 			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
 		}
-	case 146:
+	case 167:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:829
+		//line sql.y:945
 		{
 			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
 		}
-	case 147:
+	case 168:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:835
+		//line sql.y:951
 		{
 			yyVAL.valExpr = yyDollar[1].valExpr
 		}
-	case 148:
+	case 169:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:839
+		//line sql.y:955
 		{
 			yyVAL.valExpr = yyDollar[1].colName
 		}
-	case 149:
+	case 170:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:843
+		//line sql.y:959
 		{
 			yyVAL.valExpr = yyDollar[1].tuple
 		}
-	case 150:
+	case 171:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:847
+		//line sql.y:963
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITAND, Right: yyDollar[3].valExpr}
 		}
-	case 151:
+	case 172:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:851
+		//line sql.y:967
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITOR, Right: yyDollar[3].valExpr}
 		}
-	case 152:
+	case 173:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:855
+		//line sql.y:971
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITXOR, Right: yyDollar[3].valExpr}
 		}
-	case 153:
+	case 174:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:859
+		//line sql.y:975
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_PLUS, Right: yyDollar[3].valExpr}
 		}
-	case 154:
+	case 175:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:863
+		//line sql.y:979
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MINUS, Right: yyDollar[3].valExpr}
 		}
-	case 155:
+	case 176:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:867
+		//line sql.y:983
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MULT, Right: yyDollar[3].valExpr}
 		}
-	case 156:
+	case 177:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:871
+		//line sql.y:987
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_DIV, Right: yyDollar[3].valExpr}
 		}
-	case 157:
+	case 178:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:875
+		//line sql.y:991
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MOD, Right: yyDollar[3].valExpr}
 		}
-	case 158:
+	case 179:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:879
+		//line sql.y:995
 		{
 			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
 				switch yyDollar[1].byt {
@@ -1790,267 +1948,267 @@ yydefault:
 				yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
 			}
 		}
-	case 159:
+	case 180:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:894
+		//line sql.y:1010
 		{
 			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes}
 		}
-	case 160:
+	case 181:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:898
+		//line sql.y:1014
 		{
 			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].selectExprs}
 		}
-	case 161:
+	case 182:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:902
+		//line sql.y:1018
 		{
 			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes, Distinct: true, Exprs: yyDollar[4].selectExprs}
 		}
-	case 162:
+	case 183:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:906
+		//line sql.y:1022
 		{
 			yyVAL.valExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].selectExprs}
 		}
-	case 163:
+	case 184:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:910
+		//line sql.y:1026
 		{
 			yyVAL.valExpr = yyDollar[1].caseExpr
 		}
-	case 164:
+	case 185:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:916
+		//line sql.y:1032
 		{
 			yyVAL.bytes = IF_BYTES
 		}
-	case 165:
+	case 186:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:920
+		//line sql.y:1036
 		{
 			yyVAL.bytes = VALUES_BYTES
 		}
-	case 166:
+	case 187:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:926
+		//line sql.y:1042
 		{
 			yyVAL.byt = AST_UPLUS
 		}
-	case 167:
+	case 188:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:930
+		//line sql.y:1046
 		{
 			yyVAL.byt = AST_UMINUS
 		}
-	case 168:
+	case 189:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:934
+		//line sql.y:1050
 		{
 			yyVAL.byt = AST_TILDA
 		}
-	case 169:
+	case 190:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:940
+		//line sql.y:1056
 		{
 			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].valExpr, Whens: yyDollar[3].whens, Else: yyDollar[4].valExpr}
 		}
-	case 170:
+	case 191:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:945
+		//line sql.y:1061
 		{
 			yyVAL.valExpr = nil
 		}
-	case 171:
+	case 192:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:949
+		//line sql.y:1065
 		{
 			yyVAL.valExpr = yyDollar[1].valExpr
 		}
-	case 172:
+	case 193:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:955
+		//line sql.y:1071
 		{
 			yyVAL.whens = []*When{yyDollar[1].when}
 		}
-	case 173:
+	case 194:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:959
+		//line sql.y:1075
 		{
 			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
 		}
-	case 174:
+	case 195:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:965
+		//line sql.y:1081
 		{
 			yyVAL.when = &When{Cond: yyDollar[2].boolExpr, Val: yyDollar[4].valExpr}
 		}
-	case 175:
+	case 196:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:970
+		//line sql.y:1086
 		{
 			yyVAL.valExpr = nil
 		}
-	case 176:
+	case 197:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:974
+		//line sql.y:1090
 		{
 			yyVAL.valExpr = yyDollar[2].valExpr
 		}
-	case 177:
+	case 198:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:980
+		//line sql.y:1096
 		{
 			yyVAL.colName = &ColName{Name: yyDollar[1].bytes}
 		}
-	case 178:
+	case 199:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:984
+		//line sql.y:1100
 		{
 			yyVAL.colName = &ColName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
 		}
-	case 179:
+	case 200:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:988
+		//line sql.y:1104
 		{
 			yyVAL.colName = &ColName{Qualifier: yyDollar[3].bytes, Name: yyDollar[5].bytes}
 		}
-	case 180:
+	case 201:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:994
+		//line sql.y:1110
 		{
 			yyVAL.valExpr = StrVal(yyDollar[1].bytes)
 		}
-	case 181:
+	case 202:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:998
+		//line sql.y:1114
 		{
 			yyVAL.valExpr = NumVal(yyDollar[1].bytes)
 		}
-	case 182:
+	case 203:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1002
+		//line sql.y:1118
 		{
 			yyVAL.valExpr = ValArg(yyDollar[1].bytes)
 		}
-	case 183:
+	case 204:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1006
+		//line sql.y:1122
 		{
 			yyVAL.valExpr = &NullVal{}
 		}
-	case 184:
+	case 205:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1011
+		//line sql.y:1127
 		{
 			yyVAL.valExprs = nil
 		}
-	case 185:
+	case 206:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1015
+		//line sql.y:1131
 		{
 			yyVAL.valExprs = yyDollar[3].valExprs
 		}
-	case 186:
+	case 207:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1020
+		//line sql.y:1136
 		{
 			yyVAL.boolExpr = nil
 		}
-	case 187:
+	case 208:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1024
+		//line sql.y:1140
 		{
 			yyVAL.boolExpr = yyDollar[2].boolExpr
 		}
-	case 188:
+	case 209:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1029
+		//line sql.y:1145
 		{
 			yyVAL.orderBy = nil
 		}
-	case 189:
+	case 210:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1033
+		//line sql.y:1149
 		{
 			yyVAL.orderBy = yyDollar[3].orderBy
 		}
-	case 190:
+	case 211:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1039
+		//line sql.y:1155
 		{
 			yyVAL.orderBy = OrderBy{yyDollar[1].order}
 		}
-	case 191:
+	case 212:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1043
+		//line sql.y:1159
 		{
 			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
 		}
-	case 192:
+	case 213:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1049
+		//line sql.y:1165
 		{
 			yyVAL.order = &Order{Expr: yyDollar[1].valExpr, Direction: yyDollar[2].str}
 		}
-	case 193:
+	case 214:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1054
+		//line sql.y:1170
 		{
 			yyVAL.str = AST_ASC
 		}
-	case 194:
+	case 215:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1058
+		//line sql.y:1174
 		{
 			yyVAL.str = AST_ASC
 		}
-	case 195:
+	case 216:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1062
+		//line sql.y:1178
 		{
 			yyVAL.str = AST_DESC
 		}
-	case 196:
+	case 217:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1067
+		//line sql.y:1183
 		{
 			yyVAL.limit = nil
 		}
-	case 197:
+	case 218:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1071
+		//line sql.y:1187
 		{
 			yyVAL.limit = &Limit{Rowcount: yyDollar[2].valExpr}
 		}
-	case 198:
+	case 219:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1075
+		//line sql.y:1191
 		{
 			yyVAL.limit = &Limit{Offset: yyDollar[2].valExpr, Rowcount: yyDollar[4].valExpr}
 		}
-	case 199:
+	case 220:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1079
+		//line sql.y:1195
 		{
 			yyVAL.limit = &Limit{Offset: yyDollar[4].valExpr, Rowcount: yyDollar[2].valExpr}
 		}
-	case 200:
+	case 221:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1084
+		//line sql.y:1200
 		{
 			yyVAL.str = ""
 		}
-	case 201:
+	case 222:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1088
+		//line sql.y:1204
 		{
 			yyVAL.str = AST_FOR_UPDATE
 		}
-	case 202:
+	case 223:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:1092
+		//line sql.y:1208
 		{
 			if !bytes.Equal(yyDollar[3].bytes, SHARE) {
 				yylex.Error("expecting share")
@@ -2062,189 +2220,183 @@ yydefault:
 			}
 			yyVAL.str = AST_SHARE_MODE
 		}
-	case 203:
+	case 224:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1105
+		//line sql.y:1221
 		{
 			yyVAL.columns = nil
 		}
-	case 204:
+	case 225:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1109
+		//line sql.y:1225
 		{
 			yyVAL.columns = yyDollar[2].columns
 		}
-	case 205:
+	case 226:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1115
+		//line sql.y:1231
 		{
 			yyVAL.columns = Columns{&NonStarExpr{Expr: yyDollar[1].colName}}
 		}
-	case 206:
+	case 227:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1119
+		//line sql.y:1235
 		{
 			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyDollar[3].colName})
 		}
-	case 207:
+	case 228:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1124
+		//line sql.y:1240
 		{
 			yyVAL.updateExprs = nil
 		}
-	case 208:
+	case 229:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:1128
+		//line sql.y:1244
 		{
 			yyVAL.updateExprs = yyDollar[5].updateExprs
 		}
-	case 209:
+	case 230:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1134
+		//line sql.y:1250
 		{
 			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
 		}
-	case 210:
+	case 231:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1138
+		//line sql.y:1254
 		{
 			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
 		}
-	case 211:
+	case 232:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1144
+		//line sql.y:1260
 		{
 			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].valExpr}
 		}
-	case 212:
+	case 233:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1148
+		//line sql.y:1264
 		{
 			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: StrVal("ON")}
 		}
-	case 213:
+	case 234:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1153
+		//line sql.y:1269
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 214:
+	case 235:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1155
+		//line sql.y:1271
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 215:
+	case 236:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1158
+		//line sql.y:1274
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 216:
+	case 237:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1160
+		//line sql.y:1276
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 217:
+	case 238:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1163
+		//line sql.y:1279
 		{
 			yyVAL.str = ""
 		}
-	case 218:
+	case 239:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1165
+		//line sql.y:1281
 		{
 			yyVAL.str = AST_IGNORE
 		}
-	case 219:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1169
-		{
-			yyVAL.empty = struct{}{}
-		}
-	case 220:
+	case 240:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1171
+		//line sql.y:1285
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 221:
+	case 241:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1173
+		//line sql.y:1287
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 222:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1175
+	case 242:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line sql.y:1290
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 223:
+	case 243:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1177
+		//line sql.y:1292
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 224:
+	case 244:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1180
+		//line sql.y:1295
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 225:
+	case 245:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1182
+		//line sql.y:1297
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 226:
+	case 246:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1185
+		//line sql.y:1300
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 227:
+	case 247:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1187
+		//line sql.y:1302
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 228:
+	case 248:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1190
+		//line sql.y:1305
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 229:
+	case 249:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1192
+		//line sql.y:1307
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 230:
+	case 250:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1196
+		//line sql.y:1311
 		{
 			yyVAL.bytes = bytes.ToLower(yyDollar[1].bytes)
 		}
-	case 231:
+	case 251:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1201
+		//line sql.y:1316
 		{
 			ForceEOF(yylex)
 		}
-	case 232:
+	case 252:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1206
+		//line sql.y:1321
 		{
 			yyVAL.str = ""
 		}
-	case 233:
+	case 253:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1210
+		//line sql.y:1325
 		{
 			yyVAL.str = AST_TABLE
 		}
@@ -38,6 +38,8 @@ var (
   MODE  =        []byte("mode")
   IF_BYTES =     []byte("if")
   VALUES_BYTES = []byte("values")
+  MODIFY_BYTES = []byte("modify")
+  UNSIGNED_BYTES = []byte("unsigned")
 )
 
 %}
@@ -75,6 +77,9 @@ var (
   insRows     InsertRows
   updateExprs UpdateExprs
   updateExpr  *UpdateExpr
+  alterTable  *AlterTable
+  columnType  *ColumnType
+  boolVal     bool
 }
 
 %token LEX_ERROR
@@ -123,7 +128,7 @@ var (
 
 // DDL Tokens
 %token <empty> CREATE ALTER DROP RENAME
-%token <empty> TABLE INDEX VIEW TO IGNORE IF UNIQUE USING
+%token <empty> TABLE INDEX VIEW TO IGNORE IF UNIQUE USING COLUMN
 
 // truncate 
 %token <empty> TRUNCATE
@@ -131,6 +136,9 @@ var (
 // describe
 %token <empty> DESCRIBE
 
+// explain
+%token <empty> EXPLAIN
+
 %start any_command
 
 %type <statement> command
@@ -179,7 +187,12 @@ var (
 %type <updateExprs> on_dup_opt
 %type <updateExprs> update_list
 %type <updateExpr> update_expression
-%type <empty> exists_opt not_exists_opt non_rename_operation to_opt constraint_opt using_opt
+%type <empty> exists_opt not_exists_opt non_rename_operation to_opt constraint_opt using_opt column_opt
+%type <alterTable> alter_column_operation
+%type <valExpr> default_value
+%type <columnType> column_type
+%type <bytes2> column_size_opt
+%type <boolVal> unsigned_opt not_null_opt
 %type <bytes> sql_id
 %type <empty> force_eof
 %type <str> table_opt
@@ -192,6 +205,7 @@ var (
 %type <statement> truncate_statement
 
 %type <statement> describe_statement 
+%type <statement> explain_statement
 
 %%
 
@@ -222,6 +236,7 @@ command:
 | use_statement
 | truncate_statement
 | describe_statement
+| explain_statement
 
 select_statement:
   SELECT comment_opt distinct_opt select_expression_list limit_opt
@@ -394,6 +409,12 @@ describe_statement:
     $$ = &Describe{TableName: $2}
   }
 
+explain_statement:
+  EXPLAIN select_statement
+  {
+    $$ = &Explain{Select: $2}
+  }
+
 use_statement:
   USE sql_id
   {
@@ -422,20 +443,115 @@ create_statement:
   }
 
 alter_statement:
-  ALTER ignore_opt TABLE ID non_rename_operation force_eof
+  ALTER ignore_opt TABLE dml_table_expression non_rename_operation force_eof
   {
-    $$ = &DDL{Action: AST_ALTER, Ignore: $2, Table: $4, NewName: $4}
+    $$ = &DDL{Action: AST_ALTER, Ignore: $2, Table: $4.Name, NewName: $4.Name}
   }
-| ALTER ignore_opt TABLE ID RENAME to_opt ID
+| ALTER ignore_opt TABLE dml_table_expression RENAME to_opt ID
   {
     // Change this to a rename statement
-    $$ = &DDL{Action: AST_RENAME, Ignore: $2, Table: $4, NewName: $7}
+    $$ = &DDL{Action: AST_RENAME, Ignore: $2, Table: $4.Name, NewName: $7}
+  }
+| ALTER ignore_opt TABLE dml_table_expression alter_column_operation
+  {
+    $5.Ignore = $2
+    $5.Table = $4
+    $$ = $5
   }
 | ALTER VIEW sql_id force_eof
   {
     $$ = &DDL{Action: AST_ALTER, Table: $3, NewName: $3}
   }
 
+alter_column_operation:
+  DROP column_opt ID
+  {
+    $$ = &AlterTable{Action: AST_DROP_COLUMN, Column: $3}
+  }
+| RENAME COLUMN ID TO ID
+  {
+    $$ = &AlterTable{Action: AST_RENAME_COLUMN, Column: $3, NewName: $5}
+  }
+| ALTER column_opt ID SET DEFAULT default_value
+  {
+    $$ = &AlterTable{Action: AST_SET_DEFAULT, Column: $3, Default: $6}
+  }
+| ALTER column_opt ID DROP DEFAULT
+  {
+    $$ = &AlterTable{Action: AST_DROP_DEFAULT, Column: $3}
+  }
+| sql_id column_opt ID column_type
+  {
+    if !bytes.Equal($1, MODIFY_BYTES) {
+      yylex.Error("alter table only supports drop, rename, alter and modify column")
+      return 1
+    }
+    $$ = &AlterTable{Action: AST_MODIFY_COLUMN, Column: $3, Type: $4}
+  }
+
+default_value:
+  NUMBER
+  {
+    $$ = NumVal($1)
+  }
+| '-' NUMBER
+  {
+    $$ = append(NumVal("-"), $2...)
+  }
+| STRING
+  {
+    $$ = StrVal($1)
+  }
+| NULL
+  {
+    $$ = &NullVal{}
+  }
+
+column_type:
+  sql_id column_size_opt unsigned_opt not_null_opt
+  {
+    $$ = &ColumnType{Type: $1, Size: $2, Unsigned: $3, NotNull: $4}
+  }
+
+column_size_opt:
+  {
+    $$ = nil
+  }
+| '(' NUMBER ')'
+  {
+    $$ = [][]byte{$2}
+  }
+| '(' NUMBER ',' NUMBER ')'
+  {
+    $$ = [][]byte{$2, $4}
+  }
+
+unsigned_opt:
+  {
+    $$ = false
+  }
+| sql_id
+  {
+    if !bytes.Equal($1, UNSIGNED_BYTES) {
+      yylex.Error("expecting unsigned")
+      return 1
+    }
+    $$ = true
+  }
+
+not_null_opt:
+  {
+    $$ = false
+  }
+| NULL
+  {
+    $$ = false
+  }
+| NOT NULL
+  {
+    $$ = true
+  }
+
 rename_statement:
   RENAME TABLE ID TO ID
   {
@@ -1165,15 +1281,14 @@ ignore_opt:
   { $$ = AST_IGNORE }
 
 non_rename_operation:
-  ALTER
-  { $$ = struct{}{} }
-| DEFAULT
-  { $$ = struct{}{} }
-| DROP
+  DEFAULT
   { $$ = struct{}{} }
 | ORDER
   { $$ = struct{}{} }
-| ID
+
+column_opt:
+  { $$ = struct{}{} }
+| COLUMN
   { $$ = struct{}{} }
 
 to_opt:
@@ -74,3 +74,61 @@ func TestDescribe(t *testing.T) {
 		t.Fatalf("expected tableName=abc, actual: %v", desc.TableName)
 	}
 }
+
+func TestExplain(t *testing.T) {
+	sql := "explain select * from abc where id > 1 limit 10"
+	stmt, err := Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explain, ok := stmt.(*Explain)
+	if !ok {
+		t.Fatalf("expected explain statement. actual: %T", stmt)
+	}
+	if _, ok := explain.Select.(*Select); !ok {
+		t.Fatalf("expected select statement. actual: %T", explain.Select)
+	}
+	if String(explain) != sql {
+		t.Fatalf("expected %s, actual: %s", sql, String(explain))
+	}
+}
+
+func TestAlterTable(t *testing.T) {
+	cases := map[string]string{
+		"alter table db.t drop column c":                      "alter table db.t drop column c",
+		"alter ignore table t drop c":                         "alter ignore table t drop column c",
+		"alter table `t` rename column a to b":                "alter table t rename column a to b",
+		"alter table t alter column c set default -10":        "alter table t alter column c set default -10",
+		"alter table t alter c set default 'x'":               "alter table t alter column c set default 'x'",
+		"alter table t alter c drop default":                  "alter table t alter column c drop default",
+		"alter table t modify column c varchar(64) not null":  "alter table t modify column c varchar(64) not null",
+		"alter table t modify c DECIMAL(10, 2) unsigned null": "alter table t modify column c decimal(10, 2) unsigned",
+		"alter table t MODIFY c bigint unsigned":              "alter table t modify column c bigint unsigned",
+	}
+	for sql, expected := range cases {
+		stmt, err := Parse(sql)
+		if err != nil {
+			t.Fatalf("parse %s error: %v", sql, err)
+		}
+		alter, ok := stmt.(*AlterTable)
+		if !ok {
+			t.Fatalf("expected alter table statement. actual: %T", stmt)
+		}
+		if String(alter) != expected {
+			t.Fatalf("expected %s, actual: %s", expected, String(alter))
+		}
+	}
+
+	for _, sql := range []string{
+		"alter table t add column c int",
+		"alter table t drop column",
+		"alter table t rename column a b",
+		"alter table t modify c int signed",
+		"alter table t drop c, drop d",
+	} {
+		if _, err := Parse(sql); err == nil {
+			t.Fatalf("parse %s expected error", sql)
+		}
+	}
+}
@@ -118,6 +118,7 @@ var keywords = map[string]int{
 	"if":     IF,
 	"unique": UNIQUE,
 	"using":  USING,
+	"column": COLUMN,
 
 	"begin":    BEGIN,
 	"rollback": ROLLBACK,
@@ -148,6 +149,7 @@ var keywords = map[string]int{
 
 	// for fbase
 	"describe": DESCRIBE,
+	"explain":  EXPLAIN,
 }
 
 // Lex returns the next token form the Tokenizer.
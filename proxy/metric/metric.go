@@ -51,6 +51,8 @@ type Metric struct {
 	proxyMeter *metrics.MetricMeter
 	//store level
 	storeMeter    *metrics.MetricMeter
+	//resource group level
+	groupMeter    *metrics.MetricMeter
 	maxSlowLogNum uint64
 
 	lock       sync.Mutex
@@ -92,6 +94,7 @@ func NewMetric(clusterId uint64, host, addr string, maxSlowLogNum uint64, alarmS
 	}
 	metric.proxyMeter = metrics.NewMetricMeter("GS-Proxy", time.Second * 60, metric)
 	metric.storeMeter = metrics.NewMetricMeter("GS-Store", time.Second * 60, metric)
+	metric.groupMeter = metrics.NewMetricMeter("GS-Group", time.Second * 60, metric)
 
 	if len(alarmServerAddr) != 0 {
 		var err error
@@ -233,6 +236,15 @@ func (m *Metric) StoreApiMetric(method string, ack bool, delay time.Duration) {
 	m.storeMeter.AddApiWithDelay(method, ack, delay)
 }
 
+// ResourceGroupMetric counts the requests of a resource group, the rejected
+// requests are reported as error responses.
+func (m *Metric) ResourceGroupMetric(group string, admitted bool) {
+	if m == nil {
+		return
+	}
+	m.groupMeter.AddApi(group, admitted)
+}
+
 func (m *Metric) ErrorLogMetric(errorLog string) {
 	if m == nil {
 		return
@@ -274,6 +286,22 @@ func (m *Metric) SlowLogMetric(slowLog string, delay time.Duration) {
 	slowLogger.currentIndex++
 }
 
+// StatementMetric reports the statement digest summary, the summary is
+// cumulative so the metric server keeps the latest one of each gateway.
+func (m *Metric) StatementMetric(stats *statspb.StatementStats) {
+	if m == nil || len(stats.GetDigests()) == 0 {
+		return
+	}
+	values := url.Values{}
+	values.Set("clusterId", fmt.Sprintf("%d", m.clusterId))
+	values.Set("namespace", "GS")
+	values.Set("subsystem", m.host)
+	_url := fmt.Sprintf(`http://%s/metric/statement?%s`, m.metricAddr, values.Encode())
+	if err := m.SendMetric(_url, stats); err != nil {
+		log.Warn("send statement metric failed, err[%v]", err)
+	}
+}
+
 func (m *Metric) AddConnectCount(delta int64) {
 	if m == nil {
 		return
@@ -0,0 +1,170 @@
+package dskv
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"model/pkg/kvrpcpb"
+	"model/pkg/timestamp"
+
+	"golang.org/x/net/context"
+)
+
+// InsertRows inserts sorted rows, every request carries the rows of one range.
+func (p *KvProxy) InsertRows(rows []*kvrpcpb.KeyValue) error {
+	rContext := NewPRConext(InsertMaxBackoff)
+	var errForRetry error
+	for len(rows) > 0 {
+		if errForRetry != nil {
+			if err := rContext.GetBackOff().Backoff(BoMSRPC, errForRetry); err != nil {
+				return err
+			}
+			errForRetry = nil
+		}
+		l, err := p.RangeCache.LocateKey(rContext.GetBackOff(), rows[0].GetKey())
+		if err != nil {
+			return err
+		}
+		n := 1
+		for n < len(rows) && l.Contains(rows[n].GetKey()) {
+			n++
+		}
+		now := p.Clock.Now()
+		req := &kvrpcpb.InsertRequest{
+			Rows:      rows[:n],
+			Timestamp: &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
+		}
+		if _, _, err = p.Insert(rContext, req, rows[0].GetKey()); err != nil {
+			if err == ErrRouteChange {
+				errForRetry = err
+				continue
+			}
+			return err
+		}
+		rows = rows[n:]
+	}
+	return nil
+}
+
+// CompareAndInsert writes the row only if its current value is the expected
+// one, false is returned if the row is changed or deleted.
+func (p *KvProxy) CompareAndInsert(kv *kvrpcpb.KeyValue, expected []byte) (bool, error) {
+	rContext := NewPRConext(InsertMaxBackoff)
+	for {
+		now := p.Clock.Now()
+		req := &kvrpcpb.InsertRequest{
+			Rows:           []*kvrpcpb.KeyValue{kv},
+			ExpectedValues: [][]byte{expected},
+			Timestamp:      &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical},
+		}
+		resp, _, err := p.Insert(rContext, req, kv.GetKey())
+		if err == ErrRouteChange {
+			if err = rContext.GetBackOff().Backoff(BoMSRPC, err); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		return resp.GetAffectedKeys() == 1, nil
+	}
+}
+
+// errCodeExpired is the kExpired status of the data server, a snapshot read
+// returns it when the range applied a write after the read timestamp.
+const errCodeExpired = 10
+
+// ScanScope selects the rows of [start, end) batch by batch, req gives the
+// fields and filters of the select, an empty end means no upper bound. The
+// routes are followed until the end key, so a range split during the scan is
+// still covered. A snapshot read sends the timestamp of req with every batch
+// and fails with ErrWrittenAfterRead once a range is written after it.
+func (p *KvProxy) ScanScope(ctx context.Context, req *kvrpcpb.SelectRequest, start, end []byte, batch uint64,
+	fn func(rows []*kvrpcpb.Row) error) error {
+	key := start
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		ts := req.GetTimestamp()
+		if !req.GetSnapshotRead() {
+			now := p.Clock.Now()
+			ts = &timestamp.Timestamp{WallTime: now.WallTime, Logical: now.Logical}
+		}
+		r := &kvrpcpb.SelectRequest{
+			Scope:        &kvrpcpb.Scope{Start: key, Limit: end},
+			FieldList:    req.GetFieldList(),
+			WhereFilters: req.GetWhereFilters(),
+			Limit:        &kvrpcpb.Limit{Count: batch},
+			Timestamp:    ts,
+			SnapshotRead: req.GetSnapshotRead(),
+		}
+		resp, route, err := p.SqlQuery(r, key)
+		if err != nil {
+			return err
+		}
+		if resp.GetCode() == errCodeExpired && req.GetSnapshotRead() {
+			return ErrWrittenAfterRead
+		}
+		if resp.GetCode() != 0 {
+			return fmt.Errorf("select failed, code[%d]", resp.GetCode())
+		}
+		rows := resp.GetRows()
+		if len(rows) > 0 {
+			if err = fn(rows); err != nil {
+				return err
+			}
+		}
+		if uint64(len(rows)) >= batch {
+			// more rows in this route, continue after the last key
+			key = append(append([]byte(nil), rows[len(rows)-1].GetKey()...), 0)
+			continue
+		}
+		if route == nil || len(route.EndKey) == 0 || (len(end) > 0 && bytes.Compare(route.EndKey, end) >= 0) {
+			return nil
+		}
+		key = route.EndKey
+	}
+}
+
+// Parallel runs fn for 0..n-1 with at most concurrency goroutines and
+// returns the first error, the rest are not started after an error.
+func Parallel(ctx context.Context, concurrency, n int, fn func(i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	index := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range index {
+				if err := fn(i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case index <- i:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(index)
+	wg.Wait()
+	if firstErr == nil && ctx.Err() != nil {
+		// canceled by the caller
+		return ctx.Err()
+	}
+	return firstErr
+}
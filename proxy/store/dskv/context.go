@@ -31,7 +31,13 @@ type ReqContext struct {
 }
 
 func NewPRConext(sleep int) *ReqContext {
-	reqBo := NewBackoffer(sleep, context.Background())
+	return NewPRConextWithCtx(context.Background(), sleep)
+}
+
+// NewPRConextWithCtx creates a request context whose backoffer and rpc calls
+// are cancelled with ctx.
+func NewPRConextWithCtx(ctx context.Context, sleep int) *ReqContext {
+	reqBo := NewBackoffer(sleep, ctx)
 	//todo: need a better good uuid generate method
 	//var reqUuid string
 	//id, err := uuid.NewV4()
@@ -11,4 +11,5 @@ var (
 	ErrNotSupportParallelExec = errors.New("proxy not support parallel exec")
 
 	ErrAffectRows = errors.New("affect rows is not equal")
+	ErrWrittenAfterRead = errors.New("range written after the read timestamp")
 )
@@ -22,6 +22,8 @@ type KvProxy struct {
 	RangeCache   *RangeCache
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+	// Ctx cancels the requests of the proxy, nil means never cancelled
+	Ctx context.Context
 }
 
 func (p *KvProxy) Init(cli client.KvClient, clock *hlc.Clock, cache *RangeCache, wTimeout, rTimeout time.Duration) {
@@ -36,6 +38,14 @@ func (p *KvProxy) Reset() {
 	*p = KvProxy{}
 }
 
+// Context returns the context cancelling the requests of the proxy.
+func (p *KvProxy) Context() context.Context {
+	if p.Ctx == nil {
+		return context.Background()
+	}
+	return p.Ctx
+}
+
 func (p *KvProxy) send(bo *Backoffer, _ctx *Context, req *Request) (resp *Response, retry bool, err error) {
 	resp = &Response{Type: req.GetType()}
 	ctx, cancel := context.WithTimeout(bo.ctx, _ctx.Timeout)
@@ -325,6 +335,30 @@ func (p *KvProxy) do(bo *Backoffer, req *Request, key []byte) (resp *Response, l
 	return
 }
 
+// Send sends the request once to the range holding the key, ErrRouteChange is
+// returned when the range is split, moved or its leader changed.
+func (p *KvProxy) Send(bo *Backoffer, req *Request, key []byte) (*Response, *KeyLocation, error) {
+	return p.do(bo, req, key)
+}
+
+// Do sends the request to the range holding the key. When the range is split,
+// moved or its leader changed, the route is refreshed and the request is sent
+// again, until the backoffer gives up or its context is done.
+func (p *KvProxy) Do(bo *Backoffer, req *Request, key []byte) (*Response, *KeyLocation, error) {
+	for {
+		resp, l, err := p.do(bo, req, key)
+		if err != ErrRouteChange {
+			return resp, l, err
+		}
+		if bo.ctx.Err() != nil {
+			return nil, nil, bo.ctx.Err()
+		}
+		if err = bo.Backoff(BoMSRPC, err); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
 func (p *KvProxy) sendReq(bo *Backoffer, ctx *Context, req *Request) (resp *Response, err error) {
 	var retry bool
 	var pErr *errorpb.Error
@@ -1,6 +1,7 @@
 package mock_ds
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"sync"
@@ -288,7 +289,8 @@ func (svr *DsRpcServer) insert(msg *dsClient.Message) {
 }
 
 /**
-select all
+select the key, or the rows of the scope in the range, the offset and count
+of the limit are applied
  */
 func (svr *DsRpcServer) query(msg *dsClient.Message) {
 	var resp *kvrpcpb.DsSelectResponse
@@ -304,13 +306,33 @@ func (svr *DsRpcServer) query(msg *dsClient.Message) {
 		log.Info("query range:%d,startKey:%v,endKey:%v",rangeId,rng.StartKey,rng.EndKey)
 		if rng.RangeEpoch.Version == rngEpoch.Version && rng.RangeEpoch.ConfVer == rngEpoch.ConfVer {
 			
-			it := svr.store.NewIterator(rng.StartKey, rng.EndKey)
+			start, end := rng.StartKey, rng.EndKey
+			if key := req.GetReq().GetKey(); key != nil {
+				start, end = key, append(append([]byte{}, key...), 0)
+			} else if scope := req.GetReq().GetScope(); scope != nil {
+				if bytes.Compare(scope.GetStart(), start) > 0 {
+					start = scope.GetStart()
+				}
+				if len(scope.GetLimit()) > 0 && bytes.Compare(scope.GetLimit(), end) < 0 {
+					end = scope.GetLimit()
+				}
+			}
+			limit := req.GetReq().GetLimit()
+			var scanned uint64
+			it := svr.store.NewIterator(start, end)
 			rows := make([]*kvrpcpb.Row, 0)
 
 			for it.Next() {
+				if limit != nil && limit.GetCount() > 0 && uint64(len(rows)) >= limit.GetCount() {
+					break
+				}
+				scanned++
+				if limit != nil && scanned <= limit.GetOffset() {
+					continue
+				}
 				fields := make([]byte,0)
 				row := new(kvrpcpb.Row)
-				row.Key = it.Key()
+				row.Key = append([]byte{}, it.Key()...)
 				pks := rng.PrimaryKeys
 				buf := it.Key()[9:]//drop table prefix
 				var v []byte
@@ -358,7 +380,7 @@ func (svr *DsRpcServer) query(msg *dsClient.Message) {
 			resp = &kvrpcpb.DsSelectResponse{Header: &kvrpcpb.ResponseHeader{}}
 			resp.Resp = &kvrpcpb.SelectResponse{}
 			resp.Resp.Rows = rows
-			resp.Resp.Offset = uint64(len(rows))
+			resp.Resp.Offset = scanned
 		}else{
 			resp = &kvrpcpb.DsSelectResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.SelectResponse{Code: 1,}}
 
@@ -616,7 +638,17 @@ func (svr *DsRpcServer) kvScan(msg *dsClient.Message) {
 		var kvs []*kvrpcpb.RedisKeyValue
 		var lastKey []byte
 		maxCount := req.GetReq().GetMaxCount()
-		iter := svr.store.NewIterator(req.GetReq().GetStart(), req.GetReq().GetLimit())
+		start, limit := req.GetReq().GetStart(), req.GetReq().GetLimit()
+		// the scan stops at the end of the range, like the data server
+		if rng := svr.GetRange(req.GetHeader().GetRangeId()); rng != nil {
+			if bytes.Compare(rng.GetStartKey(), start) > 0 {
+				start = rng.GetStartKey()
+			}
+			if len(rng.GetEndKey()) > 0 && (len(limit) == 0 || bytes.Compare(rng.GetEndKey(), limit) < 0) {
+				limit = rng.GetEndKey()
+			}
+		}
+		iter := svr.store.NewIterator(start, limit)
 		defer iter.Release()
 		for iter.Next() {
 			count++
@@ -744,6 +776,65 @@ func (svr *DsRpcServer) kvRangeDel(msg *dsClient.Message) {
 	msg.SetData(data)
 }
 
+// lockKey stores the lock value under the key, the lock held by another id
+// fails with code 1 until it expires
+func (svr *DsRpcServer) lockKey(msg *dsClient.Message) {
+	var resp *kvrpcpb.DsLockResponse
+	req := new(kvrpcpb.DsLockRequest)
+	err := proto.Unmarshal(msg.GetData(), req)
+	if err != nil {
+		resp = &kvrpcpb.DsLockResponse{Header: &kvrpcpb.ResponseHeader{Error: &errorpb.Error{Message: "decode lock failed"}}}
+	} else {
+		now := time.Now().UnixNano() / int64(time.Millisecond)
+		key := req.GetReq().GetKey()
+		old := new(kvrpcpb.LockValue)
+		value, err := svr.store.Get(key)
+		if err == nil && len(value) > 0 && proto.Unmarshal(value, old) == nil &&
+			(old.GetDeleteTime() == 0 || old.GetDeleteTime() > now) && old.GetId() != req.GetReq().GetValue().GetId() {
+			resp = &kvrpcpb.DsLockResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.LockResponse{Code: 1, Error: "lock exist", Value: old.GetValue()}}
+		} else {
+			lock := *req.GetReq().GetValue()
+			if lock.DeleteTime > 0 {
+				lock.DeleteTime += now
+			}
+			lock.UpdateTime = now
+			data, _ := proto.Marshal(&lock)
+			if err = svr.store.Put(key, data); err != nil {
+				resp = &kvrpcpb.DsLockResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.LockResponse{Code: 5, Error: err.Error()}}
+			} else {
+				resp = &kvrpcpb.DsLockResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.LockResponse{UpdateTime: now}}
+			}
+		}
+	}
+	data, _ := proto.Marshal(resp)
+	msg.SetMsgType(0x12)
+	msg.SetData(data)
+}
+
+func (svr *DsRpcServer) unlockKey(msg *dsClient.Message) {
+	var resp *kvrpcpb.DsUnlockResponse
+	req := new(kvrpcpb.DsUnlockRequest)
+	err := proto.Unmarshal(msg.GetData(), req)
+	if err != nil {
+		resp = &kvrpcpb.DsUnlockResponse{Header: &kvrpcpb.ResponseHeader{Error: &errorpb.Error{Message: "decode unlock failed"}}}
+	} else {
+		key := req.GetReq().GetKey()
+		old := new(kvrpcpb.LockValue)
+		value, err := svr.store.Get(key)
+		if err != nil || len(value) == 0 || proto.Unmarshal(value, old) != nil {
+			resp = &kvrpcpb.DsUnlockResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.LockResponse{Code: 2, Error: "lock not exist"}}
+		} else if old.GetId() != req.GetReq().GetId() {
+			resp = &kvrpcpb.DsUnlockResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.LockResponse{Code: 3, Error: "not lock owner"}}
+		} else {
+			svr.store.Delete(key)
+			resp = &kvrpcpb.DsUnlockResponse{Header: &kvrpcpb.ResponseHeader{}, Resp: &kvrpcpb.LockResponse{}}
+		}
+	}
+	data, _ := proto.Marshal(resp)
+	msg.SetMsgType(0x12)
+	msg.SetData(data)
+}
+
 func (svr *DsRpcServer)do(msg *dsClient.Message) {
 	switch funcpb.FunctionID(msg.GetFuncId()) {
 	case funcpb.FunctionID_kFuncCreateRange:
@@ -770,6 +861,10 @@ func (svr *DsRpcServer)do(msg *dsClient.Message) {
 		svr.kvRangeDel(msg)
 	case funcpb.FunctionID_kFuncKvScan:
 		svr.kvScan(msg)
+	case funcpb.FunctionID_kFuncLock:
+		svr.lockKey(msg)
+	case funcpb.FunctionID_kFuncUnlock:
+		svr.unlockKey(msg)
 	case funcpb.FunctionID_kFuncHeartbeat:
 		msg.SetMsgType(0x12)
 	}
@@ -225,6 +225,43 @@ func (c *Cluster) AddColumn(ctx context.Context, req *mspb.AddColumnRequest) (*m
 	return resp, nil
 }
 
+func (c *Cluster) AlterColumn(ctx context.Context, req *mspb.AlterColumnRequest) (*mspb.AlterColumnResponse, error) {
+	resp := &mspb.AlterColumnResponse{Header: &mspb.ResponseHeader{}}
+	return resp, nil
+}
+
+func (c *Cluster) GetDBs(ctx context.Context, req *mspb.GetDBsRequest) (*mspb.GetDBsResponse, error) {
+	resp := &mspb.GetDBsResponse{Header: &mspb.ResponseHeader{}}
+	for _, db := range c.db.GetAllDatabase() {
+		resp.Dbs = append(resp.Dbs, db.DataBase)
+	}
+	return resp, nil
+}
+
+func (c *Cluster) SplitTable(ctx context.Context, req *mspb.SplitTableRequest) (*mspb.SplitTableResponse, error) {
+	resp := &mspb.SplitTableResponse{Header: &mspb.ResponseHeader{}}
+	return resp, nil
+}
+
+func (c *Cluster) GetResourceGroups(ctx context.Context, req *mspb.GetResourceGroupsRequest) (*mspb.GetResourceGroupsResponse, error) {
+	resp := &mspb.GetResourceGroupsResponse{Header: &mspb.ResponseHeader{}}
+	return resp, nil
+}
+
+func (c *Cluster) GetTables(ctx context.Context, req *mspb.GetTablesRequest) (*mspb.GetTablesResponse, error) {
+	db, find := c.db.FindDb(req.GetDbName())
+	if !find {
+		return nil, ErrNotExistDatabase
+	}
+	resp := &mspb.GetTablesResponse{Header: &mspb.ResponseHeader{}}
+	for _, t := range c.tables.GetAllTable() {
+		if t.GetDbId() == db.GetId() {
+			resp.Tables = append(resp.Tables, t.Table)
+		}
+	}
+	return resp, nil
+}
+
 func (c *Cluster) CreateDatabase(ctx context.Context, req *mspb.CreateDatabaseRequest) (*mspb.CreateDatabaseResponse, error) {
 	return nil, nil
 }
@@ -68,7 +68,8 @@ func (p *KvProxy) Insert(rContext *ReqContext, req *kvrpcpb.InsertRequest, key [
 		return nil, nil, err
 	}
 	response := resp.GetInsertResp().GetResp()
-	if response != nil && response.GetCode() == 0 && response.GetAffectedKeys() != uint64(len(req.Rows)) {
+	// the conditional insert skips the rows not matched
+	if response != nil && response.GetCode() == 0 && len(req.ExpectedValues) == 0 && response.GetAffectedKeys() != uint64(len(req.Rows)) {
 		var nodeId uint64 = 0
 		l, err = p.RangeCache.LocateKey(rContext.GetBackOff(), key)
 		if l != nil {
@@ -91,7 +92,7 @@ func (p *KvProxy) Insert(rContext *ReqContext, req *kvrpcpb.InsertRequest, key [
 
 func (p *KvProxy) SqlQuery(req *kvrpcpb.SelectRequest, key []byte) (*kvrpcpb.SelectResponse, *KeyLocation, error) {
 	log.Debug("select by route key: %v",key)
-	context := NewPRConext(GetMaxBackoff)
+	context := NewPRConextWithCtx(p.Context(), GetMaxBackoff)
 	var retErr, errForRetry error
 	for metricLoop := 0; ; metricLoop++ {
 		if errForRetry != nil {
@@ -129,6 +130,25 @@ func (p *KvProxy) SqlQuery(req *kvrpcpb.SelectRequest, key []byte) (*kvrpcpb.Sel
 	return nil, nil, retErr
 }
 
+// LocateRanges returns the locations of the ranges overlapping [start, limit)
+// in the order of the keys, an empty limit means no upper bound.
+func (p *KvProxy) LocateRanges(start, limit []byte) ([]*KeyLocation, error) {
+	bo := NewBackoffer(GetMaxBackoff, p.Context())
+	var locations []*KeyLocation
+	key := start
+	for {
+		l, err := p.RangeCache.LocateKey(bo, key)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, l)
+		key = l.EndKey
+		if len(key) == 0 || len(limit) > 0 && bytes.Compare(key, limit) >= 0 {
+			return locations, nil
+		}
+	}
+}
+
 func (p *KvProxy) SqlDelete(req *kvrpcpb.DeleteRequest, scope *kvrpcpb.Scope) ([]*kvrpcpb.DeleteResponse, error) {
 	var key, start, limit []byte
 	var resp *kvrpcpb.DeleteResponse
@@ -139,6 +159,9 @@ func (p *KvProxy) SqlDelete(req *kvrpcpb.DeleteRequest, scope *kvrpcpb.Scope) ([
 	start = scope.Start
 	limit = scope.Limit
 	for {
+		if err = p.Context().Err(); err != nil {
+			return nil, err
+		}
 		if key == nil {
 			key = start
 		} else if route != nil {
@@ -166,7 +189,7 @@ func (p *KvProxy) SqlDelete(req *kvrpcpb.DeleteRequest, scope *kvrpcpb.Scope) ([
 
 func (p *KvProxy) Delete(req *kvrpcpb.DeleteRequest, key []byte) (*kvrpcpb.DeleteResponse, *KeyLocation, error) {
 	var retErr, errForRetry error
-	context :=  NewPRConext(ScannerNextMaxBackoff)
+	context := NewPRConextWithCtx(p.Context(), ScannerNextMaxBackoff)
 	for metricLoop := 0; ; metricLoop++ {
 		if errForRetry != nil {
 			errForRetry = context.GetBackOff().Backoff(BoMSRPC, errForRetry)
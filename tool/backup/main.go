@@ -0,0 +1,200 @@
+package main
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+var master = flag.String("master", "127.0.0.1:8887", "master http addr")
+var clusterId = flag.Uint64("cluster", 1, "cluster id")
+var token = flag.String("token", "", "master secret key, empty if the master does not verify signature")
+var wait = flag.Bool("wait", false, "wait for the job and print its progress")
+
+type job struct {
+	Id        uint64 `json:"id"`
+	Type      string `json:"type"`
+	DbName    string `json:"db_name"`
+	TableName string `json:"table_name"`
+	TableId   uint64 `json:"table_id"`
+	Storage   string `json:"storage"`
+	State     string `json:"state"`
+	Error     string `json:"error"`
+	Progress  struct {
+		TotalFiles uint64 `json:"total_files"`
+		DoneFiles  uint64 `json:"done_files"`
+		Rows       uint64 `json:"rows"`
+		Bytes      uint64 `json:"bytes"`
+	} `json:"progress"`
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+}
+
+type reply struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: %s [flags] <command> [args]
+
+commands:
+    backup  <db> <table> <storage>    back up the table to storage
+    restore <db> <table> <storage>    restore the backup in storage into a new table
+    status  [job id]                  show one or all backup jobs
+    cancel  <job id>                  cancel a running job
+
+storage is a local directory of the master, file:///dir, or
+s3://bucket/prefix?endpoint=http://host:port&region=us-east-1
+
+flags:
+`, os.Args[0])
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch args[0] {
+	case "backup", "restore":
+		if len(args) != 4 {
+			usage()
+			os.Exit(2)
+		}
+		err = start(args[0], args[1], args[2], args[3])
+	case "status":
+		if len(args) > 1 {
+			err = status(args[1])
+		} else {
+			err = list()
+		}
+	case "cancel":
+		if len(args) != 2 {
+			usage()
+			os.Exit(2)
+		}
+		_, err = call("/manage/table/backup/cancel", url.Values{"jobId": {args[1]}})
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func call(path string, params url.Values) (json.RawMessage, error) {
+	if *token != "" {
+		d := fmt.Sprintf("%d", time.Now().Unix())
+		h := md5.New()
+		h.Write([]byte(fmt.Sprintf("%d", *clusterId)))
+		h.Write([]byte(d))
+		h.Write([]byte(*token))
+		params.Set("d", d)
+		params.Set("s", fmt.Sprintf("%x", h.Sum(nil)))
+	}
+	resp, err := http.PostForm(fmt.Sprintf("http://%s%s", *master, path), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	r := new(reply)
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("invalid reply %s", string(data))
+	}
+	if r.Code != 0 {
+		return nil, fmt.Errorf("master error %d: %s", r.Code, r.Message)
+	}
+	return r.Data, nil
+}
+
+func start(typ, db, table, storage string) error {
+	data, err := call("/manage/table/"+typ, url.Values{"dbName": {db}, "tableName": {table}, "storage": {storage}})
+	if err != nil {
+		return err
+	}
+	j := new(job)
+	if err = json.Unmarshal(data, j); err != nil {
+		return err
+	}
+	fmt.Printf("%s job %d started, table %s.%s[%d], storage %s\n", j.Type, j.Id, j.DbName, j.TableName, j.TableId, j.Storage)
+	if !*wait {
+		return nil
+	}
+	for {
+		time.Sleep(2 * time.Second)
+		data, err = call("/manage/table/backup/query", url.Values{"jobId": {fmt.Sprintf("%d", j.Id)}})
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(data, j); err != nil {
+			return err
+		}
+		printJob(j)
+		switch j.State {
+		case "running":
+		case "success":
+			return nil
+		default:
+			return fmt.Errorf("job %d %s: %s", j.Id, j.State, j.Error)
+		}
+	}
+}
+
+func status(id string) error {
+	data, err := call("/manage/table/backup/query", url.Values{"jobId": {id}})
+	if err != nil {
+		return err
+	}
+	j := new(job)
+	if err = json.Unmarshal(data, j); err != nil {
+		return err
+	}
+	printJob(j)
+	return nil
+}
+
+func list() error {
+	data, err := call("/manage/table/backup/query", url.Values{})
+	if err != nil {
+		return err
+	}
+	var jobs []*job
+	if err = json.Unmarshal(data, &jobs); err != nil {
+		return err
+	}
+	for _, j := range jobs {
+		printJob(j)
+	}
+	return nil
+}
+
+func printJob(j *job) {
+	fmt.Printf("job %d %s %s.%s[%d] %s: files %d/%d, rows %d, bytes %d, started %s",
+		j.Id, j.Type, j.DbName, j.TableName, j.TableId, j.State,
+		j.Progress.DoneFiles, j.Progress.TotalFiles, j.Progress.Rows, j.Progress.Bytes,
+		time.Unix(j.StartTime, 0).Format("2006-01-02 15:04:05"))
+	if j.Error != "" {
+		fmt.Printf(", error %s", j.Error)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	dsClient "pkg-go/ds_client"
+	msClient "pkg-go/ms_client"
+	"proxy/bulkload"
+
+	"golang.org/x/net/context"
+)
+
+var master = flag.String("ms", "127.0.0.1:8887", "master rpc addrs, separated by comma")
+var dbName = flag.String("db", "", "database name")
+var tableName = flag.String("table", "", "table name")
+var file = flag.String("file", "", "input file, '-' for stdin, not needed to resume a sorted load")
+var format = flag.String("format", bulkload.FORMAT_CSV, "input format, csv with header line or json (one object per line)")
+var properties = flag.String("properties", "", "table properties json to create the table if not exist, e.g. {\"columns\":[...]}")
+var regions = flag.Int("regions", 0, "ranges to pre-split the new table into, 0 for one range every million rows")
+var workDir = flag.String("work-dir", "./bulkload", "dir of sorted files and checkpoint, run again with the same dir to resume")
+var concurrency = flag.Int("concurrency", 8, "ranges loaded in parallel")
+var batchSize = flag.Int("batch-size", 2000, "rows of one insert request")
+var chunkRows = flag.Int("chunk-rows", 1000000, "rows sorted in memory")
+
+func main() {
+	flag.Parse()
+	if *dbName == "" || *tableName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var tp *bulkload.TableProperty
+	if *properties != "" {
+		tp = new(bulkload.TableProperty)
+		if err := json.Unmarshal([]byte(*properties), tp); err != nil {
+			return fmt.Errorf("invalid properties: %v", err)
+		}
+	}
+	var input io.Reader
+	switch *file {
+	case "":
+	case "-":
+		input = os.Stdin
+	default:
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	msCli, err := msClient.NewClient(strings.Split(*master, ","))
+	if err != nil {
+		return err
+	}
+	defer msCli.Close()
+	kvCli := dsClient.NewRPCClient()
+	defer kvCli.Close()
+
+	loader := bulkload.NewLoader(bulkload.Config{
+		WorkDir:     *workDir,
+		Format:      *format,
+		Regions:     *regions,
+		ChunkRows:   *chunkRows,
+		BatchSize:   *batchSize,
+		Concurrency: *concurrency,
+	}, msCli, kvCli)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- loader.Run(context.Background(), *dbName, *tableName, tp, input)
+	}()
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case err = <-done:
+			printProgress(loader.Progress())
+			return err
+		case <-ticker.C:
+			printProgress(loader.Progress())
+		}
+	}
+}
+
+func printProgress(p bulkload.Progress) {
+	fmt.Printf("%s phase %s: read %d rows, %d sorted runs, loaded %d/%d rows into %d partitions\n",
+		time.Now().Format("2006-01-02 15:04:05"), p.Phase, p.ReadRows, p.SortedRuns, p.LoadedRows, p.TotalRows, p.Partitions)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+var master = flag.String("master", "127.0.0.1:8887", "master http addr")
+var clusterId = flag.Uint64("cluster", 1, "cluster id")
+var token = flag.String("token", "", "master secret key, empty if the master does not verify signature")
+
+type reply struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: %s [flags] <command> [args]
+
+commands:
+    export <file>     dump the master metadata to file, - for stdout
+    import <file>     seed a new master cluster with the dumped metadata
+    recover start     rebuild the ranges from the heartbeats of data servers
+    recover status    show the reported, missing, orphan and conflict ranges
+    recover probe     query the replicas of the missing ranges on data servers
+    recover stop      finish the recovery and resume scheduling
+
+flags:
+`, os.Args[0])
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) != 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch args[0] {
+	case "export":
+		err = export(args[1])
+	case "import":
+		err = load(args[1])
+	case "recover":
+		switch args[1] {
+		case "start", "status", "probe", "stop":
+			var data json.RawMessage
+			data, err = call("/manage/meta/recover/"+args[1], nil)
+			if err == nil && len(data) != 0 && string(data) != "null" {
+				err = printJson(os.Stdout, data)
+			}
+		default:
+			usage()
+			os.Exit(2)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func sign() url.Values {
+	params := url.Values{}
+	if *token != "" {
+		d := fmt.Sprintf("%d", time.Now().Unix())
+		h := md5.New()
+		h.Write([]byte(fmt.Sprintf("%d", *clusterId)))
+		h.Write([]byte(d))
+		h.Write([]byte(*token))
+		params.Set("d", d)
+		params.Set("s", fmt.Sprintf("%x", h.Sum(nil)))
+	}
+	return params
+}
+
+// call posts the body as json if it is not nil, the signature is in the url.
+func call(path string, body []byte) (json.RawMessage, error) {
+	addr := fmt.Sprintf("http://%s%s?%s", *master, path, sign().Encode())
+	var resp *http.Response
+	var err error
+	if body != nil {
+		resp, err = http.Post(addr, "application/json", bytes.NewReader(body))
+	} else {
+		resp, err = http.Post(addr, "application/x-www-form-urlencoded", nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	r := new(reply)
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("invalid reply %s", string(data))
+	}
+	if r.Code != 0 {
+		return nil, fmt.Errorf("master error %d: %s", r.Code, r.Message)
+	}
+	return r.Data, nil
+}
+
+func printJson(w io.Writer, data json.RawMessage) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(w)
+	return err
+}
+
+func export(file string) error {
+	data, err := call("/manage/meta/export", nil)
+	if err != nil {
+		return err
+	}
+	if file == "-" {
+		return printJson(os.Stdout, data)
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err = printJson(f, data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func load(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if _, err = call("/manage/meta/import", data); err != nil {
+		return err
+	}
+	fmt.Printf("meta in %s imported\n", file)
+	return nil
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds in microseconds of the latency
+// histogram, the last bucket counts the latencies exceeding all the bounds.
+// The histograms of the same bounds can be merged by adding the counts.
+var LatencyBuckets = []uint64{
+	500, 1000, 2000, 5000, 10000, 20000, 50000,
+	100000, 200000, 500000, 1000000, 2000000, 5000000, 10000000,
+}
+
+// LatencyBucket returns the histogram bucket of the latency.
+func LatencyBucket(delay time.Duration) int {
+	us := uint64(delay / time.Microsecond)
+	return sort.Search(len(LatencyBuckets), func(i int) bool {
+		return us <= LatencyBuckets[i]
+	})
+}
+
+// MergeLatencyBuckets adds the counts of src to dst.
+func MergeLatencyBuckets(dst, src []uint64) []uint64 {
+	if len(dst) < len(src) {
+		dst = append(dst, make([]uint64, len(src)-len(dst))...)
+	}
+	for i, n := range src {
+		dst[i] += n
+	}
+	return dst
+}
+
+// LatencyPercentile estimates the percentile (0 ~ 1) in microseconds as the
+// upper bound of the bucket it falls in, bounded by the max latency.
+func LatencyPercentile(buckets []uint64, max uint64, p float64) uint64 {
+	var total uint64
+	for _, n := range buckets {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(p*float64(total) + 0.5)
+	if rank == 0 {
+		rank = 1
+	}
+	var count uint64
+	for i, n := range buckets {
+		count += n
+		if count < rank {
+			continue
+		}
+		if i < len(LatencyBuckets) && LatencyBuckets[i] < max {
+			return LatencyBuckets[i]
+		}
+		break
+	}
+	return max
+}
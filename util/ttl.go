@@ -0,0 +1,47 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+
+	"model/pkg/metapb"
+	"util/encoding"
+)
+
+// TTL_EXPIRE_COL_ID is the hidden column holding the expire time(unix seconds)
+// of the rows in a table with ttl, the id is beyond the ids of the user columns.
+const TTL_EXPIRE_COL_ID uint64 = math.MaxUint32
+
+// TTL_EXPIRE_COL_NAME is not a valid sql identifier, it never clashes with a
+// user column.
+const TTL_EXPIRE_COL_NAME string = "$expire_at"
+
+// TTLExpireColumn returns the hidden expire column of the tables with ttl.
+func TTLExpireColumn() *metapb.Column {
+	return &metapb.Column{
+		Id:       TTL_EXPIRE_COL_ID,
+		Name:     TTL_EXPIRE_COL_NAME,
+		DataType: metapb.DataType_BigInt,
+		Nullable: true,
+	}
+}
+
+// EncodeExpireValue appends the hidden expire column to the encoded row value.
+func EncodeExpireValue(buf []byte, expireAt int64) []byte {
+	return encoding.EncodeIntValue(buf, uint32(TTL_EXPIRE_COL_ID), expireAt)
+}
+
+// ParseTableTTL returns the ttl(seconds) in the table properties, zero means
+// the rows never expire.
+func ParseTableTTL(properties string) (uint64, error) {
+	if len(properties) == 0 {
+		return 0, nil
+	}
+	tp := struct {
+		Ttl uint64 `json:"ttl"`
+	}{}
+	if err := json.Unmarshal([]byte(properties), &tp); err != nil {
+		return 0, err
+	}
+	return tp.Ttl, nil
+}